@@ -0,0 +1,84 @@
+/*
+* Copyright 2026 Google LLC. All Rights Reserved.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+package cloudbuild
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	cloudbuildv1 "google.golang.org/api/cloudbuild/v1"
+)
+
+// runningStatuses are the build statuses for which a retry would duplicate work in progress.
+var runningStatuses = map[string]bool{
+	"PENDING": true,
+	"QUEUED":  true,
+	"WORKING": true,
+}
+
+// RetryBuild retries the most recent build of the given trigger for a commit and returns the
+// new build's ID. It returns an error if there's no such build or it is still running.
+func (cb *Client) RetryBuild(triggerId, commitSha string) (string, error) {
+	ctx := context.Background()
+
+	c, err := cloudbuildv1.NewService(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	build, err := getLatestBuild(c, PROJECT_ID, triggerId, commitSha)
+	if err != nil {
+		return "", err
+	}
+	if build == nil {
+		return "", fmt.Errorf("no build found for trigger %s at commit %s", triggerId, commitSha)
+	}
+	if runningStatuses[build.Status] {
+		return "", fmt.Errorf("build %s is still running (%s)", build.Id, build.Status)
+	}
+
+	op, err := c.Projects.Builds.Retry(PROJECT_ID, build.Id, &cloudbuildv1.RetryBuildRequest{}).Do()
+	if err != nil {
+		return "", err
+	}
+
+	var metadata cloudbuildv1.BuildOperationMetadata
+	if err := json.Unmarshal(op.Metadata, &metadata); err != nil {
+		return "", fmt.Errorf("failed to read retried build from operation %s: %w", op.Name, err)
+	}
+	if metadata.Build == nil {
+		return "", fmt.Errorf("operation %s has no retried build", op.Name)
+	}
+
+	fmt.Printf("Retried build %s as %s\n", build.Id, metadata.Build.Id)
+
+	return metadata.Build.Id, nil
+}
+
+// getLatestBuild returns the most recent build of a trigger for a commit, or nil if there isn't one.
+func getLatestBuild(c *cloudbuildv1.Service, projectId, triggerId, commitSha string) (*cloudbuildv1.Build, error) {
+	filter := fmt.Sprintf("trigger_id=%s AND substitutions.COMMIT_SHA=%s", triggerId, commitSha)
+	// Builds will be sorted by createTime, descending order.
+	builds, err := c.Projects.Builds.List(projectId).Filter(filter).PageSize(1).Do()
+	if err != nil {
+		return nil, err
+	}
+	if len(builds.Builds) == 0 {
+		return nil, nil
+	}
+	return builds.Builds[0], nil
+}
@@ -0,0 +1,56 @@
+/*
+* Copyright 2026 Google LLC. All Rights Reserved.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+package cloudbuild
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	cloudbuildv1 "google.golang.org/api/cloudbuild/v1"
+)
+
+// RunTrigger starts a build of the given trigger at a commit with extra substitutions and returns
+// the new build's ID.
+func (cb *Client) RunTrigger(triggerId, commitSha string, substitutions map[string]string) (string, error) {
+	ctx := context.Background()
+
+	c, err := cloudbuildv1.NewService(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	repoSource := &cloudbuildv1.RepoSource{
+		CommitSha:     commitSha,
+		Substitutions: substitutions,
+	}
+	op, err := c.Projects.Triggers.Run(PROJECT_ID, triggerId, repoSource).Do()
+	if err != nil {
+		return "", err
+	}
+
+	var metadata cloudbuildv1.BuildOperationMetadata
+	if err := json.Unmarshal(op.Metadata, &metadata); err != nil {
+		return "", fmt.Errorf("failed to read build from operation %s: %w", op.Name, err)
+	}
+	if metadata.Build == nil {
+		return "", fmt.Errorf("operation %s has no build", op.Name)
+	}
+
+	fmt.Printf("Started build %s of trigger %s at %s\n", metadata.Build.Id, triggerId, commitSha)
+
+	return metadata.Build.Id, nil
+}
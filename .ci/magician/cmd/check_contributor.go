@@ -0,0 +1,97 @@
+/*
+* Copyright 2026 Google LLC. All Rights Reserved.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+package cmd
+
+import (
+	"fmt"
+	"magician/cloudbuild"
+	"magician/github"
+
+	"github.com/spf13/cobra"
+)
+
+const awaitingApprovalLabel = "awaiting-approval"
+
+const awaitingApprovalComment = "Cloud tests for this PR are waiting for a maintainer to review the changes. " +
+	"Once they've taken a look, a maintainer can comment `/gcbrun COMMIT_SHA` with the commit they reviewed to run its tests."
+
+// checkContributorCmd represents the check-contributor command
+var checkContributorCmd = &cobra.Command{
+	Use:   "check-contributor PR_NUMBER",
+	Short: "Runs or holds cloud tests based on the PR author",
+	Long: `This command decides whether cloud tests may run for a PR's latest commit.
+
+	The downstream generation and test trigger requires approval for every build. Builds are
+	approved automatically unless the author is a community contributor, as decided by the same
+	user type used for reviewer assignment.
+
+	The command expects the following pull request details as arguments:
+	1. PR Number
+
+	It then performs the following operations:
+	1. Determines the author of the pull request
+	2. If the author is a core contributor or Googler, approves the pending build for the PR's
+	   head commit.
+	3. Otherwise, adds the 'awaiting-approval' label and comments that a maintainer needs to
+	   comment /gcbrun COMMIT_SHA to run the tests for the commit they reviewed.
+
+	The DOWNSTREAM_GENERATION_AND_TEST_TRIGGER environment variable must hold the ID of the trigger.
+	`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		prNumber := args[0]
+		fmt.Println("PR Number: ", prNumber)
+		// Organization membership is only visible to members, so the magician's token is needed to
+		// see Googlers who keep their membership private.
+		githubToken, ok := lookupGithubTokenOrFallback("GITHUB_TOKEN_MAGIC_MODULES")
+		if !ok {
+			return fmt.Errorf("did not provide GITHUB_TOKEN_MAGIC_MODULES or GITHUB_TOKEN environment variables")
+		}
+		gh := github.NewClient(githubToken)
+		cb := cloudbuild.NewClient()
+		return execCheckContributor(prNumber, gh, cb)
+	},
+}
+
+func execCheckContributor(prNumber string, gh GithubClient, cb CloudbuildClient) error {
+	pullRequest, err := gh.GetPullRequest(prNumber)
+	if err != nil {
+		return err
+	}
+
+	author := pullRequest.User.Login
+	// Authors are trusted the same way everywhere else, so only community contributors are held.
+	if userType := gh.GetUserType(author); userType != github.CommunityUserType {
+		fmt.Printf("Author %s is a %s - approving cloud tests for %s\n", author, userType, pullRequest.HeadSha)
+		return cb.ApproveDownstreamGenAndTest(prNumber, pullRequest.HeadSha)
+	}
+
+	fmt.Printf("Author %s is not trusted - holding cloud tests for maintainer approval\n", author)
+	for _, label := range pullRequest.Labels {
+		if label.Name == awaitingApprovalLabel {
+			// Already waiting; don't comment again on every push.
+			return nil
+		}
+	}
+	if err := gh.AddLabels(prNumber, []string{awaitingApprovalLabel}); err != nil {
+		return err
+	}
+	return gh.PostComment(prNumber, awaitingApprovalComment)
+}
+
+func init() {
+	rootCmd.AddCommand(checkContributorCmd)
+}
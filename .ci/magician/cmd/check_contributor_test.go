@@ -0,0 +1,87 @@
+/*
+* Copyright 2026 Google LLC. All Rights Reserved.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+package cmd
+
+import (
+	"testing"
+
+	"magician/github"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecCheckContributor(t *testing.T) {
+	cases := map[string]struct {
+		author        string
+		userType      github.UserType
+		labels        []github.Label
+		expectApprove bool
+		expectHold    bool
+	}{
+		"core contributor's build is approved": {
+			author:        "core",
+			userType:      github.CoreContributorUserType,
+			expectApprove: true,
+		},
+		"googler's build is approved": {
+			author:        "googler",
+			userType:      github.GooglerUserType,
+			expectApprove: true,
+		},
+		"community contributor's build is held": {
+			author:     "community",
+			userType:   github.CommunityUserType,
+			expectHold: true,
+		},
+		"already held PR isn't labeled or commented on again": {
+			author:   "community",
+			userType: github.CommunityUserType,
+			labels:   []github.Label{{Name: "awaiting-approval"}},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			gh := &mockGithub{
+				pullRequest: github.PullRequest{
+					User:    github.User{Login: tc.author},
+					Labels:  tc.labels,
+					HeadSha: "abc123",
+				},
+				userType:      tc.userType,
+				calledMethods: make(map[string][][]any),
+			}
+			cb := &mockCloudBuild{calledMethods: make(map[string][][]any)}
+
+			if err := execCheckContributor("1", gh, cb); err != nil {
+				t.Fatalf("execCheckContributor failed: %v", err)
+			}
+
+			if tc.expectApprove {
+				assert.Equal(t, [][]any{{"1", "abc123"}}, cb.calledMethods["ApproveDownstreamGenAndTest"])
+			} else {
+				assert.Empty(t, cb.calledMethods["ApproveDownstreamGenAndTest"])
+			}
+			if tc.expectHold {
+				assert.Equal(t, [][]any{{"1", []string{"awaiting-approval"}}}, gh.calledMethods["AddLabels"])
+				assert.Equal(t, [][]any{{"1", awaitingApprovalComment}}, gh.calledMethods["PostComment"])
+			} else {
+				assert.Empty(t, gh.calledMethods["AddLabels"])
+				assert.Empty(t, gh.calledMethods["PostComment"])
+			}
+		})
+	}
+}
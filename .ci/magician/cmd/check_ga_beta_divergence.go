@@ -0,0 +1,201 @@
+/*
+* Copyright 2026 Google LLC. All Rights Reserved.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+package cmd
+
+import (
+	"fmt"
+	"magician/exec"
+	"magician/github"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+const gaBetaDivergenceCheckName = "ga-beta-divergence"
+const allowGABetaDivergenceLabel = "override-ga-beta-divergence"
+
+// maxDivergenceSummaryLength keeps the check run summary under GitHub's 65535 character limit.
+const maxDivergenceSummaryLength = 60000
+
+// tpgtools keeps separate GA and beta copies of its overrides: tpgtools/overrides/<product>/<file>
+// and tpgtools/overrides/<product>/beta/<file>.
+var (
+	gaOverrideRegexp   = regexp.MustCompile(`^tpgtools/overrides/([^/]+)/([^/]+)$`)
+	betaOverrideRegexp = regexp.MustCompile(`^tpgtools/overrides/([^/]+)/beta/([^/]+)$`)
+)
+
+// gaBetaCopies is a file with separate GA and beta copies where the PR only changed one of them.
+type gaBetaCopies struct {
+	Changed   string
+	Unchanged string
+	Diff      string
+}
+
+// checkGABetaDivergenceCmd represents the check-ga-beta-divergence command
+var checkGABetaDivergenceCmd = &cobra.Command{
+	Use:   "check-ga-beta-divergence",
+	Short: "Flags PRs that change only one of a file's GA and beta copies",
+	Long: `This command checks whether a PR changes a file that has separate GA and beta copies without
+	changing both of them, since this routinely causes beta-only regressions.
+
+	It expects the following arguments:
+	1. PR number
+	2. SHA of the PR's head commit
+	3. Path to a checkout of the PR's head commit
+
+	It reports the result as the ga-beta-divergence check run, with a diff of each pair of copies
+	that differ after the PR. An 'override-ga-beta-divergence' label can be added to allow merging.
+
+	The following environment variables are required:
+	1. GITHUB_TOKEN
+	`,
+	Args: cobra.ExactArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		githubToken, ok := lookupGithubTokenOrFallback("GITHUB_TOKEN")
+		if !ok {
+			return fmt.Errorf("did not provide GITHUB_TOKEN environment variable")
+		}
+		gh := github.NewClient(githubToken)
+		rnr, err := exec.NewRunner()
+		if err != nil {
+			return fmt.Errorf("error creating a runner: %w", err)
+		}
+		return execCheckGABetaDivergence(args[0], args[1], args[2], gh, rnr)
+	},
+}
+
+func execCheckGABetaDivergence(prNumber, commitSha, checkoutPath string, gh GithubClient, rnr ExecRunner) error {
+	pullRequest, err := gh.GetPullRequest(prNumber)
+	if err != nil {
+		return err
+	}
+	files, err := gh.GetPullRequestFiles(prNumber)
+	if err != nil {
+		return err
+	}
+
+	var divergent []gaBetaCopies
+	for _, copies := range singleCopyChanges(files) {
+		changed, err := rnr.ReadFile(filepath.Join(checkoutPath, copies.Changed))
+		if err != nil {
+			// The changed copy was deleted, which is a separate concern from divergence.
+			continue
+		}
+		unchanged, err := rnr.ReadFile(filepath.Join(checkoutPath, copies.Unchanged))
+		if err != nil || changed == unchanged {
+			continue
+		}
+		if err := rnr.PushDir(checkoutPath); err != nil {
+			return err
+		}
+		// diff exits with an error when the files differ, so only its output is used.
+		copies.Diff, _ = rnr.Run("diff", []string{"-u", copies.Unchanged, copies.Changed}, nil)
+		if err := rnr.PopDir(); err != nil {
+			return err
+		}
+		divergent = append(divergent, copies)
+	}
+
+	checkRun := gaBetaDivergenceCheckRun(divergent, hasLabel(pullRequest.Labels, allowGABetaDivergenceLabel), commitSha)
+	if err := gh.CreateCheckRun(checkRun); err != nil {
+		fmt.Printf("Error creating %s check run for pr %s commit %s, falling back to a build status: %v\n", gaBetaDivergenceCheckName, prNumber, commitSha, err)
+		return gh.PostBuildStatus(prNumber, gaBetaDivergenceCheckName, checkRun.Conclusion, pullRequest.HTMLUrl, commitSha)
+	}
+	return nil
+}
+
+// gaBetaCounterpart returns the other copy of a file with separate GA and beta copies.
+func gaBetaCounterpart(path string) (string, bool) {
+	if m := betaOverrideRegexp.FindStringSubmatch(path); m != nil {
+		return fmt.Sprintf("tpgtools/overrides/%s/%s", m[1], m[2]), true
+	}
+	if m := gaOverrideRegexp.FindStringSubmatch(path); m != nil {
+		return fmt.Sprintf("tpgtools/overrides/%s/beta/%s", m[1], m[2]), true
+	}
+	return "", false
+}
+
+// singleCopyChanges returns the changed files whose GA or beta counterpart wasn't changed,
+// sorted by the changed file.
+func singleCopyChanges(files []string) []gaBetaCopies {
+	var changes []gaBetaCopies
+	for _, f := range files {
+		counterpart, ok := gaBetaCounterpart(f)
+		if !ok || slices.Contains(files, counterpart) {
+			continue
+		}
+		changes = append(changes, gaBetaCopies{Changed: f, Unchanged: counterpart})
+	}
+	slices.SortFunc(changes, func(a, b gaBetaCopies) int { return strings.Compare(a.Changed, b.Changed) })
+	return changes
+}
+
+func gaBetaDivergenceCheckRun(divergent []gaBetaCopies, allowed bool, commitSha string) github.CheckRun {
+	checkRun := github.CheckRun{
+		Name:       gaBetaDivergenceCheckName,
+		HeadSha:    commitSha,
+		Conclusion: "success",
+		Title:      "No GA/beta divergence detected",
+		Summary:    "This pull request doesn't change only one of a file's GA and beta copies.",
+	}
+	if len(divergent) == 0 {
+		return checkRun
+	}
+
+	level := "failure"
+	checkRun.Conclusion = "failure"
+	checkRun.Title = fmt.Sprintf("%d file(s) changed in only one of GA and beta", len(divergent))
+	summary := "The files annotated below have separate GA and beta copies, and this pull request only changes one of them. " +
+		"Please make the same change to the other copy, unless the difference is intended. " +
+		"An `" + allowGABetaDivergenceLabel + "` label can be added to allow merging."
+	if allowed {
+		level = "warning"
+		checkRun.Conclusion = "success"
+		summary = "The GA/beta divergence annotated below was allowed with the `" + allowGABetaDivergenceLabel + "` label."
+	}
+
+	sb := new(strings.Builder)
+	sb.WriteString(summary)
+	truncated := false
+	for _, d := range divergent {
+		checkRun.Annotations = append(checkRun.Annotations, github.CheckRunAnnotation{
+			Path:       d.Changed,
+			Level:      level,
+			Title:      "Only one of the GA and beta copies changed",
+			Message:    fmt.Sprintf("%s was changed, but %s wasn't.", d.Changed, d.Unchanged),
+			RawDetails: d.Diff,
+		})
+		if truncated {
+			continue
+		}
+		section := fmt.Sprintf("\n\n#### `%s` / `%s`\n\n```diff\n%s\n```", d.Unchanged, d.Changed, strings.TrimSuffix(d.Diff, "\n"))
+		if sb.Len()+len(section) > maxDivergenceSummaryLength {
+			sb.WriteString("\n\nThe remaining diffs are too long to show here; see the annotations.")
+			truncated = true
+			continue
+		}
+		sb.WriteString(section)
+	}
+	checkRun.Summary = sb.String()
+	return checkRun
+}
+
+func init() {
+	rootCmd.AddCommand(checkGABetaDivergenceCmd)
+}
@@ -0,0 +1,151 @@
+/*
+* Copyright 2026 Google LLC. All Rights Reserved.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"magician/github"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSingleCopyChanges(t *testing.T) {
+	cases := map[string]struct {
+		files    []string
+		expected []gaBetaCopies
+	}{
+		"both copies changed": {
+			files: []string{"tpgtools/overrides/apikeys/key.yaml", "tpgtools/overrides/apikeys/beta/key.yaml"},
+		},
+		"only GA changed": {
+			files:    []string{"tpgtools/overrides/apikeys/key.yaml"},
+			expected: []gaBetaCopies{{Changed: "tpgtools/overrides/apikeys/key.yaml", Unchanged: "tpgtools/overrides/apikeys/beta/key.yaml"}},
+		},
+		"only beta changed": {
+			files:    []string{"tpgtools/overrides/dataproc/beta/workflow_template.yaml", "mmv1/products/redis/Instance.yaml"},
+			expected: []gaBetaCopies{{Changed: "tpgtools/overrides/dataproc/beta/workflow_template.yaml", Unchanged: "tpgtools/overrides/dataproc/workflow_template.yaml"}},
+		},
+		"samples don't have beta copies": {
+			files: []string{"tpgtools/overrides/apikeys/samples/key/basic.tf.tmpl"},
+		},
+		"sorted by changed file": {
+			files: []string{"tpgtools/overrides/gkehub/beta/membership.yaml", "tpgtools/overrides/apikeys/key.yaml"},
+			expected: []gaBetaCopies{
+				{Changed: "tpgtools/overrides/apikeys/key.yaml", Unchanged: "tpgtools/overrides/apikeys/beta/key.yaml"},
+				{Changed: "tpgtools/overrides/gkehub/beta/membership.yaml", Unchanged: "tpgtools/overrides/gkehub/membership.yaml"},
+			},
+		},
+	}
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			assert.Equal(t, tc.expected, singleCopyChanges(tc.files))
+		})
+	}
+}
+
+func TestGABetaDivergenceCheckRun(t *testing.T) {
+	divergent := []gaBetaCopies{{
+		Changed:   "tpgtools/overrides/apikeys/key.yaml",
+		Unchanged: "tpgtools/overrides/apikeys/beta/key.yaml",
+		Diff:      "--- a\n+++ b\n-old\n+new\n",
+	}}
+	cases := map[string]struct {
+		divergent        []gaBetaCopies
+		allowed          bool
+		expectConclusion string
+		expectLevel      string
+		expectSummary    []string
+	}{
+		"no divergence": {
+			expectConclusion: "success",
+		},
+		"divergence": {
+			divergent:        divergent,
+			expectConclusion: "failure",
+			expectLevel:      "failure",
+			expectSummary:    []string{"`override-ga-beta-divergence`", "```diff\n--- a\n+++ b\n-old\n+new\n```"},
+		},
+		"allowed divergence": {
+			divergent:        divergent,
+			allowed:          true,
+			expectConclusion: "success",
+			expectLevel:      "warning",
+			expectSummary:    []string{"allowed with the `override-ga-beta-divergence` label"},
+		},
+	}
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			checkRun := gaBetaDivergenceCheckRun(tc.divergent, tc.allowed, "sha1")
+			assert.Equal(t, gaBetaDivergenceCheckName, checkRun.Name)
+			assert.Equal(t, "sha1", checkRun.HeadSha)
+			assert.Equal(t, tc.expectConclusion, checkRun.Conclusion)
+			if assert.Len(t, checkRun.Annotations, len(tc.divergent)) && len(tc.divergent) > 0 {
+				assert.Equal(t, tc.expectLevel, checkRun.Annotations[0].Level)
+				assert.Equal(t, "tpgtools/overrides/apikeys/key.yaml", checkRun.Annotations[0].Path)
+			}
+			for _, s := range tc.expectSummary {
+				assert.Contains(t, checkRun.Summary, s)
+			}
+		})
+	}
+}
+
+func TestGABetaDivergenceCheckRunTruncatesSummary(t *testing.T) {
+	long := strings.Repeat("+line\n", maxDivergenceSummaryLength/6)
+	divergent := []gaBetaCopies{
+		{Changed: "tpgtools/overrides/apikeys/key.yaml", Unchanged: "tpgtools/overrides/apikeys/beta/key.yaml", Diff: long},
+		{Changed: "tpgtools/overrides/gkehub/membership.yaml", Unchanged: "tpgtools/overrides/gkehub/beta/membership.yaml", Diff: long},
+	}
+	checkRun := gaBetaDivergenceCheckRun(divergent, false, "sha1")
+	assert.Len(t, checkRun.Annotations, 2)
+	assert.True(t, len(checkRun.Summary) <= maxDivergenceSummaryLength+100)
+	assert.Contains(t, checkRun.Summary, "too long to show here")
+}
+
+func TestExecCheckGABetaDivergence(t *testing.T) {
+	mr := NewMockRunner().(*mockRunner)
+	mr.fileContents = map[string]string{
+		"/mock/pr/tpgtools/overrides/apikeys/key.yaml":            "new",
+		"/mock/pr/tpgtools/overrides/apikeys/beta/key.yaml":       "old",
+		"/mock/pr/tpgtools/overrides/gkehub/beta/membership.yaml": "same",
+		"/mock/pr/tpgtools/overrides/gkehub/membership.yaml":      "same",
+	}
+	mr.cmdResults["/mock/pr diff [-u tpgtools/overrides/apikeys/beta/key.yaml tpgtools/overrides/apikeys/key.yaml] map[]"] = "-old\n+new\n"
+	gh := &mockGithub{
+		pullRequest: github.PullRequest{Labels: []github.Label{{Name: "some-label"}}},
+		pullRequestFiles: []string{
+			"tpgtools/overrides/apikeys/key.yaml",
+			"tpgtools/overrides/gkehub/beta/membership.yaml",
+		},
+		calledMethods: make(map[string][][]any),
+	}
+
+	if err := execCheckGABetaDivergence("1", "sha1", "/mock/pr", gh, mr); err != nil {
+		t.Fatal(err)
+	}
+
+	calls := gh.calledMethods["CreateCheckRun"]
+	if assert.Len(t, calls, 1) {
+		checkRun := calls[0][0].(github.CheckRun)
+		assert.Equal(t, "failure", checkRun.Conclusion)
+		if assert.Len(t, checkRun.Annotations, 1) {
+			assert.Equal(t, "tpgtools/overrides/apikeys/key.yaml", checkRun.Annotations[0].Path)
+			assert.Equal(t, "-old\n+new\n", checkRun.Annotations[0].RawDetails)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+/*
+* Copyright 2026 Google LLC. All Rights Reserved.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+package cmd
+
+import (
+	"fmt"
+	"magician/github"
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+const needsRebaseLabel = "needs-rebase"
+
+// needsRebaseMarker identifies the needs-rebase comment, so that a PR only ever gets one.
+const needsRebaseMarker = "<!-- modular-magician:needs-rebase -->"
+
+const needsRebaseComment = "@%s this PR has merge conflicts with `main`. Please rebase or merge `main` into your branch " +
+	"and resolve the conflicts; the `" + needsRebaseLabel + "` label will be removed automatically once they're resolved.\n" +
+	needsRebaseMarker
+
+// checkMergeConflictsCmd represents the check-merge-conflicts command
+var checkMergeConflictsCmd = &cobra.Command{
+	Use:   "check-merge-conflicts",
+	Short: "Flags open PRs with merge conflicts",
+	Long: `This command checks open PRs against main for merge conflicts.
+
+	The command performs the following steps for each open, non-draft PR:
+	1. If it has merge conflicts, add the 'needs-rebase' label and ask the author to rebase.
+	   The magician's needs-rebase comment is updated rather than posted again if the PR
+	   already has one.
+	2. If it has no merge conflicts, remove the 'needs-rebase' label if present.
+	PRs whose mergeability GitHub hasn't computed yet are checked on the next run.
+	`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		githubToken, ok := lookupGithubTokenOrFallback("GITHUB_TOKEN_MAGIC_MODULES")
+		if !ok {
+			return fmt.Errorf("did not provide GITHUB_TOKEN_MAGIC_MODULES or GITHUB_TOKEN environment variables")
+		}
+		gh := github.NewClient(githubToken)
+		return execCheckMergeConflicts(gh)
+	},
+}
+
+func execCheckMergeConflicts(gh GithubClient) error {
+	pullRequests, err := gh.GetOpenPullRequests("main")
+	if err != nil {
+		return err
+	}
+
+	for _, listed := range pullRequests {
+		if listed.Draft {
+			continue
+		}
+		prNumber := strconv.Itoa(listed.Number)
+
+		// Listed PRs don't include their mergeable state.
+		pullRequest, err := gh.GetPullRequest(prNumber)
+		if err != nil {
+			fmt.Printf("PR %s: error fetching PR: %s\n", prNumber, err)
+			continue
+		}
+
+		labeled := hasLabel(pullRequest.Labels, needsRebaseLabel)
+		switch pullRequest.MergeableState {
+		case "unknown", "":
+			fmt.Printf("PR %s: mergeability not computed yet\n", prNumber)
+		case "dirty":
+			if labeled {
+				continue
+			}
+			fmt.Printf("PR %s: has merge conflicts\n", prNumber)
+			if err := flagMergeConflicts(prNumber, pullRequest.User.Login, gh); err != nil {
+				return err
+			}
+		default:
+			if labeled {
+				fmt.Printf("PR %s: merge conflicts resolved\n", prNumber)
+				if err := gh.RemoveLabel(prNumber, needsRebaseLabel); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func flagMergeConflicts(prNumber, author string, gh GithubClient) error {
+	if err := gh.AddLabels(prNumber, []string{needsRebaseLabel}); err != nil {
+		return err
+	}
+	comments, err := gh.GetPullRequestComments(prNumber)
+	if err != nil {
+		return err
+	}
+	comment := fmt.Sprintf(needsRebaseComment, author)
+	if existing, ok := findMagicianComment(comments, needsRebaseMarker); ok {
+		return gh.UpdateComment(prNumber, comment, existing.ID)
+	}
+	return gh.PostComment(prNumber, comment)
+}
+
+func init() {
+	rootCmd.AddCommand(checkMergeConflictsCmd)
+}
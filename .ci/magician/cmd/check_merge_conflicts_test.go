@@ -0,0 +1,104 @@
+/*
+* Copyright 2026 Google LLC. All Rights Reserved.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+package cmd
+
+import (
+	"fmt"
+	"testing"
+
+	"magician/github"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecCheckMergeConflicts(t *testing.T) {
+	comment := fmt.Sprintf(needsRebaseComment, "author")
+	cases := map[string]struct {
+		pullRequest   github.PullRequest
+		comments      []github.PullRequestComment
+		expectMethods map[string][][]any
+	}{
+		"conflicting PR is labeled and notified": {
+			pullRequest: github.PullRequest{MergeableState: "dirty"},
+			expectMethods: map[string][][]any{
+				"AddLabels":   {{"1", []string{needsRebaseLabel}}},
+				"PostComment": {{"1", comment}},
+			},
+		},
+		"existing needs-rebase comment is updated": {
+			pullRequest: github.PullRequest{MergeableState: "dirty"},
+			comments: []github.PullRequestComment{
+				{User: github.User{Login: "modular-magician"}, Body: "old\n" + needsRebaseMarker, ID: 123},
+				{User: github.User{Login: "author"}, Body: needsRebaseMarker, ID: 456},
+			},
+			expectMethods: map[string][][]any{
+				"AddLabels":     {{"1", []string{needsRebaseLabel}}},
+				"UpdateComment": {{"1", comment, 123}},
+			},
+		},
+		"labeled conflicting PR is left alone": {
+			pullRequest: github.PullRequest{
+				MergeableState: "dirty",
+				Labels:         []github.Label{{Name: needsRebaseLabel}},
+			},
+			expectMethods: map[string][][]any{},
+		},
+		"resolved PR is unlabeled": {
+			pullRequest: github.PullRequest{
+				MergeableState: "blocked",
+				Labels:         []github.Label{{Name: needsRebaseLabel}},
+			},
+			expectMethods: map[string][][]any{
+				"RemoveLabel": {{"1", needsRebaseLabel}},
+			},
+		},
+		"clean PR is left alone": {
+			pullRequest:   github.PullRequest{MergeableState: "clean"},
+			expectMethods: map[string][][]any{},
+		},
+		"unknown mergeability keeps the label": {
+			pullRequest: github.PullRequest{
+				MergeableState: "unknown",
+				Labels:         []github.Label{{Name: needsRebaseLabel}},
+			},
+			expectMethods: map[string][][]any{},
+		},
+		"draft PR is skipped": {
+			pullRequest:   github.PullRequest{MergeableState: "dirty", Draft: true},
+			expectMethods: map[string][][]any{},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			tc.pullRequest.Number = 1
+			tc.pullRequest.User = github.User{Login: "author"}
+			gh := &mockGithub{
+				pullRequest:         tc.pullRequest,
+				pullRequestComments: tc.comments,
+				calledMethods:       make(map[string][][]any),
+			}
+
+			if err := execCheckMergeConflicts(gh); err != nil {
+				t.Fatalf("execCheckMergeConflicts failed: %v", err)
+			}
+
+			for _, method := range []string{"AddLabels", "RemoveLabel", "PostComment", "UpdateComment"} {
+				assert.Equal(t, tc.expectMethods[method], gh.calledMethods[method], method)
+			}
+		})
+	}
+}
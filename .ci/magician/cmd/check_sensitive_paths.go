@@ -0,0 +1,274 @@
+/*
+* Copyright 2026 Google LLC. All Rights Reserved.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+package cmd
+
+import (
+	"fmt"
+	"magician/github"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	_ "embed"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+var (
+	//go:embed sensitive_paths.yaml
+	sensitivePathsYaml []byte
+)
+
+const sensitivePathsCheckName = "sensitive-paths-approval"
+
+// sensitivePathsRequiredApprovals is how many distinct approvals a PR that changes sensitive
+// paths needs, including the approvals from each group's owners.
+const sensitivePathsRequiredApprovals = 2
+
+// sensitivePathGroup is a set of sensitive paths and the reviewers who own them.
+type sensitivePathGroup struct {
+	Name   string   `yaml:"name"`
+	Paths  []string `yaml:"paths"`
+	Owners []string `yaml:"owners"`
+}
+
+// sensitivePathsFile is the format of sensitive_paths.yaml.
+type sensitivePathsFile struct {
+	Groups []sensitivePathGroup `yaml:"groups"`
+}
+
+// sensitiveChange is a group whose paths a PR changes.
+type sensitiveChange struct {
+	Group sensitivePathGroup
+	Files []string
+}
+
+// checkSensitivePathsCmd represents the check-sensitive-paths command
+var checkSensitivePathsCmd = &cobra.Command{
+	Use:   "check-sensitive-paths",
+	Short: "Requires an owner's approval on PRs that change sensitive paths",
+	Long: `This command checks whether PRs that change sensitive paths have the approvals they need.
+
+	Sensitive paths are listed in sensitive_paths.yaml in groups, each with its own owners. A PR
+	that changes any of them needs approvals from two reviewers other than its author, and one of
+	them must be an owner of each group whose paths it changes.
+
+	It accepts the following optional argument:
+	1. PR number. If omitted, all open PRs against main that change sensitive paths are checked,
+	   so that new reviews are picked up.
+
+	The result is reported as the sensitive-paths-approval check run on the PR's head commit.
+
+	The following environment variables are required:
+	1. GITHUB_TOKEN
+	`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		githubToken, ok := lookupGithubTokenOrFallback("GITHUB_TOKEN")
+		if !ok {
+			return fmt.Errorf("did not provide GITHUB_TOKEN environment variable")
+		}
+		gh := github.NewClient(githubToken)
+		groups, err := parseSensitivePaths(sensitivePathsYaml)
+		if err != nil {
+			return err
+		}
+		if len(args) == 1 {
+			return execCheckSensitivePaths(args[0], groups, true, gh)
+		}
+		return execCheckAllSensitivePaths(groups, gh)
+	},
+}
+
+func parseSensitivePaths(data []byte) ([]sensitivePathGroup, error) {
+	var file sensitivePathsFile
+	if err := yaml.UnmarshalStrict(data, &file); err != nil {
+		return nil, fmt.Errorf("error unmarshalling sensitive paths: %w", err)
+	}
+	for _, g := range file.Groups {
+		if g.Name == "" {
+			return nil, fmt.Errorf("group with paths %v has no name", g.Paths)
+		}
+		if len(g.Paths) == 0 {
+			return nil, fmt.Errorf("%s: no paths", g.Name)
+		}
+		for _, p := range g.Paths {
+			if _, err := path.Match(p, ""); err != nil {
+				return nil, fmt.Errorf("%s: invalid path %q: %w", g.Name, p, err)
+			}
+		}
+		if len(g.Owners) == 0 {
+			return nil, fmt.Errorf("%s: no owners", g.Name)
+		}
+		for _, o := range g.Owners {
+			if !github.IsCoreReviewer(o) {
+				return nil, fmt.Errorf("%s: owner %q is not a core reviewer", g.Name, o)
+			}
+		}
+	}
+	return file.Groups, nil
+}
+
+func execCheckAllSensitivePaths(groups []sensitivePathGroup, gh GithubClient) error {
+	pullRequests, err := gh.GetOpenPullRequests("main")
+	if err != nil {
+		return err
+	}
+	for _, pullRequest := range pullRequests {
+		if pullRequest.Draft {
+			continue
+		}
+		prNumber := strconv.Itoa(pullRequest.Number)
+		if err := execCheckSensitivePaths(prNumber, groups, false, gh); err != nil {
+			fmt.Printf("PR %s: error checking sensitive paths: %s\n", prNumber, err)
+		}
+	}
+	return nil
+}
+
+// execCheckSensitivePaths reports the sensitive-paths-approval check run for a PR. PRs that don't
+// change sensitive paths only get a check run if always is set.
+func execCheckSensitivePaths(prNumber string, groups []sensitivePathGroup, always bool, gh GithubClient) error {
+	pullRequest, err := gh.GetPullRequest(prNumber)
+	if err != nil {
+		return err
+	}
+	files, err := gh.GetPullRequestFiles(prNumber)
+	if err != nil {
+		return err
+	}
+	changes := sensitiveChanges(files, groups)
+	if len(changes) == 0 && !always {
+		return nil
+	}
+	reviews, err := gh.GetPullRequestReviews(prNumber)
+	if err != nil {
+		return err
+	}
+
+	checkRun := sensitivePathsCheckRun(changes, approvers(pullRequest.User.Login, reviews), pullRequest.HeadSha)
+	fmt.Printf("PR %s: %s\n", prNumber, checkRun.Title)
+	if err := gh.CreateCheckRun(checkRun); err != nil {
+		fmt.Printf("Error creating %s check run for pr %s commit %s, falling back to a build status: %v\n", sensitivePathsCheckName, prNumber, pullRequest.HeadSha, err)
+		return gh.PostBuildStatus(prNumber, sensitivePathsCheckName, checkRun.Conclusion, pullRequest.HTMLUrl, pullRequest.HeadSha)
+	}
+	return nil
+}
+
+// matchesSensitivePath reports whether a file matches a sensitive path pattern. Patterns ending
+// in / match everything under that directory.
+func matchesSensitivePath(file, pattern string) bool {
+	if strings.HasSuffix(pattern, "/") {
+		return strings.HasPrefix(file, pattern)
+	}
+	matched, _ := path.Match(pattern, file)
+	return matched
+}
+
+// sensitiveChanges returns the groups whose paths are changed by files, in config order.
+func sensitiveChanges(files []string, groups []sensitivePathGroup) []sensitiveChange {
+	var changes []sensitiveChange
+	for _, g := range groups {
+		var matched []string
+		for _, f := range files {
+			if slices.ContainsFunc(g.Paths, func(p string) bool { return matchesSensitivePath(f, p) }) {
+				matched = append(matched, f)
+			}
+		}
+		if len(matched) > 0 {
+			changes = append(changes, sensitiveChange{Group: g, Files: matched})
+		}
+	}
+	return changes
+}
+
+// approvers returns the sorted reviewers other than the author whose latest review approves.
+// reviews must be in submission order.
+func approvers(author string, reviews []github.PullRequestReview) []string {
+	latest := make(map[string]string)
+	for _, review := range reviews {
+		if review.User.Login == author {
+			continue
+		}
+		switch review.State {
+		case "APPROVED", "CHANGES_REQUESTED", "DISMISSED":
+			latest[review.User.Login] = review.State
+		}
+	}
+	var approved []string
+	for login, state := range latest {
+		if state == "APPROVED" {
+			approved = append(approved, login)
+		}
+	}
+	slices.Sort(approved)
+	return approved
+}
+
+func sensitivePathsCheckRun(changes []sensitiveChange, approved []string, commitSha string) github.CheckRun {
+	checkRun := github.CheckRun{
+		Name:       sensitivePathsCheckName,
+		HeadSha:    commitSha,
+		Conclusion: "success",
+		Title:      "No sensitive paths changed",
+		Summary:    "This pull request doesn't change any paths listed in `.ci/magician/cmd/sensitive_paths.yaml`.",
+	}
+	if len(changes) == 0 {
+		return checkRun
+	}
+
+	var missing []string
+	sb := new(strings.Builder)
+	fmt.Fprintf(sb, "This pull request changes sensitive paths, so it needs %d approvals and at least one of them from an owner of each group below.\n", sensitivePathsRequiredApprovals)
+	for _, c := range changes {
+		approver := ""
+		for _, o := range c.Group.Owners {
+			if slices.Contains(approved, o) {
+				approver = o
+				break
+			}
+		}
+		status := "approved by @" + approver
+		if approver == "" {
+			status = "needs approval from one of " + strings.Join(c.Group.Owners, ", ")
+			missing = append(missing, c.Group.Name)
+		}
+		fmt.Fprintf(sb, "\n#### %s (%s)\n\n", c.Group.Name, status)
+		for _, f := range c.Files {
+			fmt.Fprintf(sb, "- `%s`\n", f)
+		}
+	}
+	fmt.Fprintf(sb, "\nApprovals: %d of %d\n", len(approved), sensitivePathsRequiredApprovals)
+	checkRun.Summary = sb.String()
+
+	switch {
+	case len(missing) > 0:
+		checkRun.Conclusion = "failure"
+		checkRun.Title = "Needs approval from an owner of " + strings.Join(missing, ", ")
+	case len(approved) < sensitivePathsRequiredApprovals:
+		checkRun.Conclusion = "failure"
+		checkRun.Title = fmt.Sprintf("Needs %d approvals, has %d", sensitivePathsRequiredApprovals, len(approved))
+	default:
+		checkRun.Title = "Sensitive paths approved"
+	}
+	return checkRun
+}
+
+func init() {
+	rootCmd.AddCommand(checkSensitivePathsCmd)
+}
@@ -0,0 +1,219 @@
+/*
+* Copyright 2026 Google LLC. All Rights Reserved.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+package cmd
+
+import (
+	"testing"
+
+	"magician/github"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testSensitivePathGroups = []sensitivePathGroup{
+	{
+		Name:   "Transport",
+		Paths:  []string{"mmv1/third_party/terraform/transport/"},
+		Owners: []string{"rileykarson", "roaks3"},
+	},
+	{
+		Name:   "Templates",
+		Paths:  []string{"mmv1/templates/terraform/*.tmpl"},
+		Owners: []string{"melinath"},
+	},
+}
+
+func TestSensitivePathsFile(t *testing.T) {
+	if _, err := parseSensitivePaths(sensitivePathsYaml); err != nil {
+		t.Fatalf("sensitive_paths.yaml is invalid: %s", err)
+	}
+}
+
+func TestParseSensitivePathsErrors(t *testing.T) {
+	cases := map[string]struct {
+		yaml        string
+		expectError string
+	}{
+		"unknown field": {
+			yaml:        "groups:\n  - name: CI\n    paths: [.ci/]\n    owners: [melinath]\n    reviewers: [melinath]\n",
+			expectError: "field reviewers not found",
+		},
+		"no paths": {
+			yaml:        "groups:\n  - name: CI\n    owners: [melinath]\n",
+			expectError: "CI: no paths",
+		},
+		"invalid path": {
+			yaml:        "groups:\n  - name: CI\n    paths: ['.ci/[']\n    owners: [melinath]\n",
+			expectError: "invalid path",
+		},
+		"no owners": {
+			yaml:        "groups:\n  - name: CI\n    paths: [.ci/]\n",
+			expectError: "CI: no owners",
+		},
+		"owner is not a core reviewer": {
+			yaml:        "groups:\n  - name: CI\n    paths: [.ci/]\n    owners: [octocat]\n",
+			expectError: "not a core reviewer",
+		},
+	}
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			_, err := parseSensitivePaths([]byte(tc.yaml))
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tc.expectError)
+			}
+		})
+	}
+}
+
+func TestSensitiveChanges(t *testing.T) {
+	cases := map[string]struct {
+		files    []string
+		expected []sensitiveChange
+	}{
+		"no sensitive paths": {
+			files: []string{"mmv1/products/redis/Instance.yaml", "mmv1/templates/terraform/examples/redis_instance_basic.tf.tmpl"},
+		},
+		"directory": {
+			files: []string{"mmv1/third_party/terraform/transport/config.go.tmpl", "mmv1/products/redis/Instance.yaml"},
+			expected: []sensitiveChange{
+				{Group: testSensitivePathGroups[0], Files: []string{"mmv1/third_party/terraform/transport/config.go.tmpl"}},
+			},
+		},
+		"multiple groups": {
+			files: []string{"mmv1/templates/terraform/resource.go.tmpl", "mmv1/third_party/terraform/transport/transport.go"},
+			expected: []sensitiveChange{
+				{Group: testSensitivePathGroups[0], Files: []string{"mmv1/third_party/terraform/transport/transport.go"}},
+				{Group: testSensitivePathGroups[1], Files: []string{"mmv1/templates/terraform/resource.go.tmpl"}},
+			},
+		},
+	}
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			assert.Equal(t, tc.expected, sensitiveChanges(tc.files, testSensitivePathGroups))
+		})
+	}
+}
+
+func TestApprovers(t *testing.T) {
+	reviews := []github.PullRequestReview{
+		{User: github.User{Login: "author"}, State: "APPROVED"},
+		{User: github.User{Login: "roaks3"}, State: "APPROVED"},
+		{User: github.User{Login: "melinath"}, State: "APPROVED"},
+		{User: github.User{Login: "rileykarson"}, State: "CHANGES_REQUESTED"},
+		{User: github.User{Login: "melinath"}, State: "COMMENTED"},
+		{User: github.User{Login: "roaks3"}, State: "DISMISSED"},
+		{User: github.User{Login: "rileykarson"}, State: "APPROVED"},
+	}
+	assert.Equal(t, []string{"melinath", "rileykarson"}, approvers("author", reviews))
+}
+
+func TestSensitivePathsCheckRun(t *testing.T) {
+	changes := sensitiveChanges([]string{
+		"mmv1/templates/terraform/resource.go.tmpl",
+		"mmv1/third_party/terraform/transport/transport.go",
+	}, testSensitivePathGroups)
+	cases := map[string]struct {
+		changes          []sensitiveChange
+		approved         []string
+		expectConclusion string
+		expectTitle      string
+		expectSummary    []string
+	}{
+		"no sensitive paths": {
+			expectConclusion: "success",
+			expectTitle:      "No sensitive paths changed",
+		},
+		"no approvals": {
+			changes:          changes,
+			expectConclusion: "failure",
+			expectTitle:      "Needs approval from an owner of Transport, Templates",
+			expectSummary:    []string{"needs approval from one of rileykarson, roaks3", "- `mmv1/templates/terraform/resource.go.tmpl`"},
+		},
+		"one group approved": {
+			changes:          changes,
+			approved:         []string{"melinath", "SirGitsalot"},
+			expectConclusion: "failure",
+			expectTitle:      "Needs approval from an owner of Transport",
+			expectSummary:    []string{"Templates (approved by @melinath)"},
+		},
+		"owners approved but only one approval": {
+			changes:          changes[1:],
+			approved:         []string{"melinath"},
+			expectConclusion: "failure",
+			expectTitle:      "Needs 2 approvals, has 1",
+		},
+		"approved": {
+			changes:          changes,
+			approved:         []string{"melinath", "roaks3"},
+			expectConclusion: "success",
+			expectTitle:      "Sensitive paths approved",
+			expectSummary:    []string{"Transport (approved by @roaks3)", "Approvals: 2 of 2"},
+		},
+	}
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			checkRun := sensitivePathsCheckRun(tc.changes, tc.approved, "sha1")
+			assert.Equal(t, sensitivePathsCheckName, checkRun.Name)
+			assert.Equal(t, "sha1", checkRun.HeadSha)
+			assert.Equal(t, tc.expectConclusion, checkRun.Conclusion)
+			assert.Equal(t, tc.expectTitle, checkRun.Title)
+			for _, s := range tc.expectSummary {
+				assert.Contains(t, checkRun.Summary, s)
+			}
+		})
+	}
+}
+
+func TestExecCheckSensitivePaths(t *testing.T) {
+	cases := map[string]struct {
+		files          []string
+		always         bool
+		expectCheckRun bool
+	}{
+		"sensitive paths": {
+			files:          []string{"mmv1/third_party/terraform/transport/transport.go"},
+			expectCheckRun: true,
+		},
+		"no sensitive paths": {
+			files: []string{"mmv1/products/redis/Instance.yaml"},
+		},
+		"no sensitive paths, always": {
+			files:          []string{"mmv1/products/redis/Instance.yaml"},
+			always:         true,
+			expectCheckRun: true,
+		},
+	}
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			gh := &mockGithub{
+				pullRequest:      github.PullRequest{User: github.User{Login: "author"}, HeadSha: "sha1"},
+				pullRequestFiles: tc.files,
+				calledMethods:    make(map[string][][]any),
+			}
+			if err := execCheckSensitivePaths("1", testSensitivePathGroups, tc.always, gh); err != nil {
+				t.Fatal(err)
+			}
+			calls := gh.calledMethods["CreateCheckRun"]
+			if !tc.expectCheckRun {
+				assert.Empty(t, calls)
+				return
+			}
+			if assert.Len(t, calls, 1) {
+				assert.Equal(t, "sha1", calls[0][0].(github.CheckRun).HeadSha)
+			}
+		})
+	}
+}
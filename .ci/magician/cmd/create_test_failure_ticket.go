@@ -23,6 +23,7 @@ import (
 	utils "magician/utility"
 	"os"
 	"regexp"
+	"slices"
 	"strings"
 	"text/template"
 	"time"
@@ -340,7 +341,7 @@ func failingTestNamesFromActiveIssues(ctx context.Context, gh *github.Client) ([
 
 	opts := &github.IssueListByRepoOptions{
 		State:       "open",
-		Labels:      []string{"test-failure"},
+		Labels:      []string{labeler.LabelTestFailure},
 		ListOptions: github.ListOptions{PerPage: 100},
 	}
 	issues, err := ListIssuesWithOpts(ctx, gh, opts)
@@ -360,7 +361,7 @@ func failingTestNamesFromClosedIssuesToday(ctx context.Context, gh *github.Clien
 	lastday := date.AddDate(0, 0, -1)
 	opts := &github.IssueListByRepoOptions{
 		State:       "closed",
-		Labels:      []string{"test-failure"},
+		Labels:      []string{labeler.LabelTestFailure},
 		Since:       lastday,
 		ListOptions: github.ListOptions{PerPage: 100},
 	}
@@ -396,11 +397,16 @@ func ListIssuesWithOpts(ctx context.Context, gh *github.Client, opts *github.Iss
 }
 
 func createTicket(ctx context.Context, gh *github.Client, testFailure *testFailure) error {
-	issueTitle := fmt.Sprintf("Failing test(s): %s", testFailure.TestName)
-	issueBody, err := formatIssueBody(*testFailure)
+	issueDetails, err := formatIssueBody(*testFailure)
 	if err != nil {
 		return fmt.Errorf("error formatting issue body: %w", err)
 	}
+	// Lay the issue out like the test failure issue form, so it's routed like a filed one
+	issue := labeler.TestFailureIssue{
+		Tests:     []string{testFailure.TestName},
+		Resources: []string{testFailure.AffectedResource},
+		Details:   issueDetails,
+	}
 
 	failureRatelabel := testFailure.FailureRateLabels[provider.GA].String()
 
@@ -410,7 +416,6 @@ func createTicket(ctx context.Context, gh *github.Client, testFailure *testFailu
 
 	ticketLabels := []string{
 		"size/xs",
-		"test-failure",
 		failureRatelabel,
 	}
 
@@ -420,12 +425,11 @@ func createTicket(ctx context.Context, gh *github.Client, testFailure *testFailu
 		return fmt.Errorf("error building regex labels: %w", err)
 	}
 
-	labels := labeler.ComputeLabels([]string{testFailure.AffectedResource}, regexpLabels)
-	ticketLabels = append(ticketLabels, labels...)
+	ticketLabels = append(ticketLabels, issue.Labels(regexpLabels, labeler.ConflictPolicy{})...)
 
 	issueRquest := &github.IssueRequest{
-		Title:  github.String(issueTitle),
-		Body:   github.String(issueBody),
+		Title:  github.String(issue.Title()),
+		Body:   github.String(issue.Body()),
 		Labels: &ticketLabels,
 		// Milestone: Near-Term Goals
 		// https://github.com/hashicorp/terraform-provider-google/milestone/11
@@ -493,6 +497,14 @@ func testNamesFromIssue(issue *github.Issue) ([]string, error) {
 		}
 
 	}
+
+	// Also include tests the labeler finds in the impacted tests section, like ones listed with
+	// other bullets or after notes, so that failures they track aren't filed again.
+	for _, testName := range labeler.ImpactedTests(issue.GetBody()) {
+		if !slices.Contains(testNames, testName) {
+			testNames = append(testNames, testName)
+		}
+	}
 	return testNames, nil
 }
 
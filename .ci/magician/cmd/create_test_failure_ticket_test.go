@@ -16,6 +16,7 @@
 package cmd
 
 import (
+	"github.com/google/go-github/v68/github"
 	"github.com/stretchr/testify/assert"
 	"testing"
 )
@@ -66,3 +67,31 @@ func TestConvertTestNameToResource(t *testing.T) {
 		})
 	}
 }
+
+func TestTestNamesFromIssue(t *testing.T) {
+	cases := map[string]struct {
+		body string
+		want []string
+	}{
+		"generated issue": {
+			body: "### Impacted tests\n\n- TestAccComputeInstance_basic\n\n### Affected Resource(s)\n\n* google_compute_instance\n",
+			want: []string{"TestAccComputeInstance_basic"},
+		},
+		"issue form": {
+			body: "### Impacted tests\r\n\r\n<!-- List all impacted tests for searchability. The title of the issue can instead list one or more groups of tests, or describe the overall root cause. -->\r\n- TestAccFoo_basic\r\n- TestAccFoo_update\r\n\r\n### Affected Resource(s)\r\n\r\ngoogle_foo\r\n",
+			want: []string{"TestAccFoo_basic", "TestAccFoo_update"},
+		},
+		"listed with other bullets": {
+			body: "### Impacted tests\n\nFailing since the API change:\n* TestAccFoo_basic\n* TestAccFoo_update\n",
+			want: []string{"TestAccFoo_basic", "TestAccFoo_update"},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			got, err := testNamesFromIssue(&github.Issue{Body: github.String(tc.body)})
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,286 @@
+/*
+* Copyright 2026 Google LLC. All Rights Reserved.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"magician/exec"
+	"magician/github"
+	"magician/source"
+
+	"github.com/hashicorp/go-changelog"
+	"github.com/spf13/cobra"
+)
+
+// releaseNoteSections are the CHANGELOG.md sections in the order .ci/changelog2.tmpl renders them,
+// with the release note types that go in each.
+var releaseNoteSections = []struct {
+	Title string
+	Types []string
+}{
+	{"UNKNOWN CHANGELOG TYPE", []string{"unknown"}},
+	{"NOTES", []string{"note"}},
+	{"DEPRECATIONS", []string{"deprecation"}},
+	{"BREAKING CHANGES", []string{"breaking-change"}},
+	{"FEATURES", []string{"new-resource", "new-datasource"}},
+	{"IMPROVEMENTS", []string{"enhancement"}},
+	{"BUG FIXES", []string{"bug"}},
+}
+
+var releaseTagRegexp = regexp.MustCompile(`^v(\d+)\.(\d+)\.\d+$`)
+
+// changelogEntryRegexp captures the PR number from a downstream .changelog/<PR>.txt file.
+var changelogEntryRegexp = regexp.MustCompile(`^\.changelog/(\d+)\.txt$`)
+
+// releaseNotesEntry is a release note merged downstream, with the magic-modules PR it came from.
+type releaseNotesEntry struct {
+	ReleaseNote
+	PR string
+}
+
+// draftReleaseNotesCmd represents the draft-release-notes command
+var draftReleaseNotesCmd = &cobra.Command{
+	Use:   "draft-release-notes",
+	Short: "Opens a draft PR with release notes for the next downstream release",
+	Long: `This command drafts the CHANGELOG.md section for the next release of a downstream provider.
+
+	It expects the following arguments:
+	1. Downstream repo name (terraform-provider-google or terraform-provider-google-beta)
+
+	The command performs the following steps:
+	1. Clone the downstream repo and find its latest release tag.
+	2. Collect the .changelog entries added since that tag.
+	3. Group the release notes by type, sorted by service, and add them to CHANGELOG.md
+	   under the next minor version.
+	4. Push the change to a release-notes branch and open a draft PR against main.
+
+	The following environment variables are required:
+	1. GITHUB_TOKEN_DOWNSTREAMS
+	2. GOPATH`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		repoName := args[0]
+		if repoName != "terraform-provider-google" && repoName != "terraform-provider-google-beta" {
+			return fmt.Errorf("wrong repo name %q, expected terraform-provider-google or terraform-provider-google-beta", repoName)
+		}
+
+		githubToken, ok := lookupGithubTokenOrFallback("GITHUB_TOKEN_DOWNSTREAMS")
+		if !ok {
+			return fmt.Errorf("did not provide GITHUB_TOKEN_DOWNSTREAMS or GITHUB_TOKEN environment variables")
+		}
+		goPath, ok := os.LookupEnv("GOPATH")
+		if !ok {
+			return fmt.Errorf("did not provide GOPATH environment variable")
+		}
+
+		gh := github.NewClient(githubToken)
+		rnr, err := exec.NewRunner()
+		if err != nil {
+			return fmt.Errorf("error creating a runner: %w", err)
+		}
+		ctlr := source.NewController(goPath, "modular-magician", githubToken, rnr)
+		return execDraftReleaseNotes(repoName, gh, rnr, ctlr)
+	},
+}
+
+func execDraftReleaseNotes(repoName string, gh GithubClient, rnr ExecRunner, ctlr *source.Controller) error {
+	repo := &source.Repo{
+		Name:  repoName,
+		Owner: "hashicorp",
+	}
+	ctlr.SetPath(repo)
+	if err := ctlr.Clone(repo); err != nil {
+		return fmt.Errorf("error cloning %s: %w", repoName, err)
+	}
+	if err := rnr.PushDir(repo.Path); err != nil {
+		return err
+	}
+
+	lastTag, err := rnr.Run("git", []string{"describe", "--tags", "--abbrev=0"}, nil)
+	if err != nil {
+		return fmt.Errorf("error finding the latest release tag: %w", err)
+	}
+	lastTag = strings.TrimSpace(lastTag)
+	version, err := nextMinorVersion(lastTag)
+	if err != nil {
+		return err
+	}
+
+	entries, err := addedReleaseNotes(rnr, repo.Path, lastTag, "HEAD")
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		fmt.Printf("No release notes in %s since %s\n", repoName, lastTag)
+		return rnr.PopDir()
+	}
+	fmt.Printf("Found %d release notes in %s since %s\n", len(entries), repoName, lastTag)
+
+	releaseNotes := formatReleaseNotesDraft(version, entries)
+	changelog, err := rnr.ReadFile(filepath.Join(repo.Path, "CHANGELOG.md"))
+	if err != nil {
+		return fmt.Errorf("error reading CHANGELOG.md: %w", err)
+	}
+	branch := "release-notes-" + version
+	if _, err := rnr.Run("git", []string{"checkout", "-B", branch}, nil); err != nil {
+		return err
+	}
+	if err := rnr.WriteFile(filepath.Join(repo.Path, "CHANGELOG.md"), releaseNotes+"\n"+changelog); err != nil {
+		return fmt.Errorf("error writing CHANGELOG.md: %w", err)
+	}
+	for _, args := range [][]string{
+		{"config", "--local", "user.name", "Modular Magician"},
+		{"config", "--local", "user.email", "magic-modules@google.com"},
+		{"add", "CHANGELOG.md"},
+		{"commit", "--signoff", "-m", "Draft release notes for " + version},
+		{"push", ctlr.URL(&source.Repo{Name: repoName}), branch, "-f"},
+	} {
+		if _, err := rnr.Run("git", args, nil); err != nil {
+			return err
+		}
+	}
+	if err := rnr.PopDir(); err != nil {
+		return err
+	}
+
+	body := fmt.Sprintf("Release notes for the changes merged since %s. Please review the notes, fix any in the "+
+		"UNKNOWN CHANGELOG TYPE section and mark this PR ready for review before the release.", lastTag)
+	if _, err := gh.CreatePullRequest(repo.Owner, repoName, "Release notes for "+version, body, "modular-magician:"+branch, "main", true); err != nil {
+		// The branch was force-pushed above, so an existing PR is already up to date.
+		if strings.Contains(err.Error(), "A pull request already exists") {
+			fmt.Printf("Updated the existing release notes PR for %s\n", version)
+			return nil
+		}
+		return fmt.Errorf("error creating release notes PR: %w", err)
+	}
+	return nil
+}
+
+// nextMinorVersion returns the version after the release tag (e.g. v6.12.1 -> 6.13.0).
+func nextMinorVersion(tag string) (string, error) {
+	m := releaseTagRegexp.FindStringSubmatch(tag)
+	if m == nil {
+		return "", fmt.Errorf("release tag %q is not of the form vX.Y.Z", tag)
+	}
+	minor, err := strconv.Atoi(m[2])
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s.%d.0", m[1], minor+1), nil
+}
+
+// changelogEntryNotes returns the release notes in a .changelog entry. Entries without release
+// note blocks, such as the "unknown: <PR title>" entries generate-downstream writes for PRs that
+// didn't have any, are returned as a single note of unknown type.
+func changelogEntryNotes(content string) []ReleaseNote {
+	notes := parseReleaseNotes(content)
+	if len(notes) > 0 {
+		return notes
+	}
+	content = strings.TrimSpace(strings.TrimPrefix(content, "unknown:"))
+	if content == "" {
+		return nil
+	}
+	return []ReleaseNote{{Type: "unknown", Note: content}}
+}
+
+// addedReleaseNotes returns the release notes in the .changelog entries added to the downstream
+// repo checked out at repoPath between the from and to refs. The current directory must be repoPath.
+func addedReleaseNotes(rnr ExecRunner, repoPath, from, to string) ([]releaseNotesEntry, error) {
+	addedFiles, err := rnr.Run("git", []string{"log", "--diff-filter=A", "--name-only", "--format=", from + ".." + to, "--", ".changelog"}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error listing changelog entries since %s: %w", from, err)
+	}
+	var entries []releaseNotesEntry
+	for _, file := range strings.Split(addedFiles, "\n") {
+		m := changelogEntryRegexp.FindStringSubmatch(strings.TrimSpace(file))
+		if m == nil {
+			continue
+		}
+		content, err := rnr.ReadFile(filepath.Join(repoPath, m[0]))
+		if err != nil {
+			return nil, fmt.Errorf("error reading %s: %w", m[0], err)
+		}
+		for _, note := range changelogEntryNotes(content) {
+			entries = append(entries, releaseNotesEntry{ReleaseNote: note, PR: m[1]})
+		}
+	}
+	return entries, nil
+}
+
+// releaseNoteService returns the service a release note is prefixed with, or "" if it has none.
+func releaseNoteService(note string) string {
+	changelogNote := changelog.Note{Body: note}
+	return changelogNote.Parse().Service
+}
+
+// formatReleaseNotesDraft renders the CHANGELOG.md section for a release. Notes are grouped by type
+// in the order of the changelog template and sorted by service within each type. Notes of type
+// none are left out, and types go-changelog doesn't know are listed as unknown for the reviewer to fix.
+func formatReleaseNotesDraft(version string, entries []releaseNotesEntry) string {
+	byType := make(map[string][]releaseNotesEntry)
+	for _, e := range entries {
+		typ := e.Type
+		if typ == "none" {
+			continue
+		}
+		if !slices.Contains(releaseNoteTypes, typ) {
+			typ = "unknown"
+		}
+		byType[typ] = append(byType[typ], e)
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "## %s (Unreleased)\n", version)
+	for _, section := range releaseNoteSections {
+		var notes []releaseNotesEntry
+		for _, typ := range section.Types {
+			notes = append(notes, byType[typ]...)
+		}
+		if len(notes) == 0 {
+			continue
+		}
+		slices.SortStableFunc(notes, func(a, b releaseNotesEntry) int {
+			if c := strings.Compare(releaseNoteService(a.Note), releaseNoteService(b.Note)); c != 0 {
+				return c
+			}
+			return strings.Compare(a.Note, b.Note)
+		})
+		fmt.Fprintf(&sb, "\n%s:\n", section.Title)
+		for _, n := range notes {
+			prefix := ""
+			switch n.Type {
+			case "new-resource":
+				prefix = "**New Resource:** "
+			case "new-datasource":
+				prefix = "**New Data Source:** "
+			}
+			fmt.Fprintf(&sb, "* %s%s ([#%s](https://github.com/GoogleCloudPlatform/magic-modules/pull/%s))\n", prefix, n.Note, n.PR, n.PR)
+		}
+	}
+	return sb.String()
+}
+
+func init() {
+	rootCmd.AddCommand(draftReleaseNotesCmd)
+}
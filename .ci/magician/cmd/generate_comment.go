@@ -58,8 +58,13 @@ type Diff struct {
 }
 
 type BreakingChange struct {
+	Resource               string
+	Field                  string
 	Message                string
 	DocumentationReference string
+	RuleName               string
+	// Severity is high, medium or low; older diff-processor builds leave it empty.
+	Severity string
 }
 
 type MissingTestInfo struct {
@@ -88,9 +93,12 @@ type diffCommentData struct {
 	BreakingChanges      []BreakingChange
 	MissingServiceLabels []string
 	MissingTests         map[string]*MissingTestInfo
+	BetaOnlyTests        map[string][]string
 	MissingDocs          *MissingDocsSummary
 	MultipleResources    []string
+	ReleaseNotes         ReleaseNoteReport
 	Errors               []Errors
+	CommitSHA            string
 }
 
 type simpleSchemaDiff struct {
@@ -98,6 +106,10 @@ type simpleSchemaDiff struct {
 }
 
 const allowBreakingChangesLabel = "override-breaking-change"
+const breakingChangeCheckName = "terraform-provider-breaking-change-test"
+
+// diffCommentMarker identifies the diff comment, which is updated in place on later pushes.
+const diffCommentMarker = "<!-- modular-magician:diff-report -->"
 const allowMissingServiceLabelsLabel = "override-missing-service-labels"
 const allowMultipleResourcesLabel = "override-multiple-resources"
 
@@ -125,8 +137,9 @@ var generateCommentCmd = &cobra.Command{
 	2. Compute the diffs between auto-pr-# and auto-pr-#-old branches.
 	3. Run the diff processor to detect breaking changes.
 	4. Run the missing test detector to detect missing tests for fields changed.
-	5. Report the results in a PR comment.
-	6. Run unit tests for the missing test detector.
+	5. Check the release notes in the PR body and suggest notes for changed resources without one.
+	6. Report the results in a PR comment, updating the existing one if the magician already posted it.
+	7. Run unit tests for the missing test detector.
 	`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		env := make(map[string]string, len(gcEnvironmentVariables))
@@ -187,6 +200,7 @@ func execGenerateComment(prNumber int, ghTokenMagicModules, buildId, buildStep,
 	// ------------------------------------------------------------
 
 	pullRequest, err := gh.GetPullRequest(strconv.Itoa(prNumber))
+	fetchedPullRequest := err == nil
 	if err != nil {
 		fmt.Printf("Error getting pull request: %v\n", err)
 		errors["Other"] = append(errors["Other"], "Failed to fetch PR data")
@@ -298,8 +312,10 @@ func execGenerateComment(prNumber int, ghTokenMagicModules, buildId, buildStep,
 
 	// The breaking changes are unique across both provider versions
 	uniqueAddedResources := map[string]struct{}{}
+	uniqueModifiedResources := map[string]struct{}{}
 	uniqueAffectedResources := map[string]struct{}{}
 	uniqueBreakingChanges := map[string]BreakingChange{}
+	var changelogEntries []ReleaseNote
 	diffProcessorPath := filepath.Join(mmLocalPath, "tools", "diff-processor")
 	diffProcessorEnv := map[string]string{
 		"OLD_REF": oldBranch,
@@ -334,6 +350,17 @@ func execGenerateComment(prNumber int, ghTokenMagicModules, buildId, buildStep,
 			uniqueBreakingChanges[breakingChange.Message] = breakingChange
 		}
 
+		if repo.Name == "terraform-provider-google" {
+			// GA fields are only tested in the beta provider's services, where GA and beta tests
+			// live side by side; compare them against the GA schema diff.
+			betaOnlyTests, err := detectBetaOnlyTests(diffProcessorPath, tpgbRepo.Path, rnr)
+			if err != nil {
+				fmt.Println("Error running beta only test detector: ", err)
+				errors[repo.Title] = append(errors[repo.Title], "The beta only test detector failed to run.")
+			}
+			data.BetaOnlyTests = betaOnlyTests
+		}
+
 		if repo.Name == "terraform-provider-google-beta" {
 			// Run missing test detector (currently only for beta)
 			missingTests, err := detectMissingTests(diffProcessorPath, repo.Path, rnr)
@@ -350,6 +377,13 @@ func execGenerateComment(prNumber int, ghTokenMagicModules, buildId, buildStep,
 			}
 			data.MissingDocs = missingDocs
 
+			// The beta provider's entries cover changes to GA fields too.
+			changelogEntries, err = computeChangelogEntries(diffProcessorPath, rnr)
+			if err != nil {
+				fmt.Println("Error generating changelog entries: ", err)
+				errors[repo.Title] = append(errors[repo.Title], "The diff processor crashed while generating changelog entries.")
+			}
+
 			errStrs := checkDocumentFrontmatter(repo)
 			if len(errStrs) > 0 {
 				errors[repo.Title] = append(errors[repo.Title], errStrs...)
@@ -365,6 +399,9 @@ func execGenerateComment(prNumber int, ghTokenMagicModules, buildId, buildStep,
 			uniqueAddedResources[resource] = struct{}{}
 			uniqueAffectedResources[resource] = struct{}{}
 		}
+		for _, resource := range simpleDiff.ModifiedResources {
+			uniqueModifiedResources[resource] = struct{}{}
+		}
 		for _, resource := range append(simpleDiff.ModifiedResources, simpleDiff.RemovedResources...) {
 			uniqueAffectedResources[resource] = struct{}{}
 		}
@@ -409,21 +446,20 @@ func execGenerateComment(prNumber int, ghTokenMagicModules, buildId, buildStep,
 	}
 	fmt.Printf("affected resources based on changed files: %v\n", maps.Keys(changedFilesAffectedResources))
 
-	// Compute service labels based on affected resources
-	uniqueServiceLabels := map[string]struct{}{}
+	// Compute service labels based on affected resources, matching them the same way the issue
+	// labeler does so PRs and issues for a resource get the same labels.
+	var serviceLabels []string
 	regexpLabels, err := labeler.BuildRegexLabels(labeler.EnrolledTeamsYaml)
 	if err != nil {
 		fmt.Println("error building regexp labels: ", err)
 		errors["Other"] = append(errors["Other"], "Failed to parse service label mapping")
 	}
 	if len(regexpLabels) > 0 {
-		for _, label := range labeler.ComputeLabels(maps.Keys(uniqueAffectedResources), regexpLabels) {
-			uniqueServiceLabels[label] = struct{}{}
-		}
+		serviceLabels = serviceLabelsForResources(maps.Keys(uniqueAffectedResources), regexpLabels)
 	}
 
 	// Add service labels to PR if it doesn't already have service labels
-	if len(uniqueServiceLabels) > 0 {
+	if len(serviceLabels) > 0 {
 		// short-circuit if service labels have already been added to the PR
 		hasServiceLabels := false
 		for _, label := range pullRequest.Labels {
@@ -432,53 +468,46 @@ func execGenerateComment(prNumber int, ghTokenMagicModules, buildId, buildStep,
 			}
 		}
 		if !hasServiceLabels {
-			serviceLabelsSlice := maps.Keys(uniqueServiceLabels)
-			sort.Strings(serviceLabelsSlice)
-			if len(serviceLabelsSlice) > 3 {
-				// Treat this as a cross-provider change
-				serviceLabelsSlice = []string{"service/terraform"}
-			}
-			if err = gh.AddLabels(strconv.Itoa(prNumber), serviceLabelsSlice); err != nil {
-				fmt.Printf("Error posting new service labels %q: %s", serviceLabelsSlice, err)
+			if err = gh.AddLabels(strconv.Itoa(prNumber), serviceLabels); err != nil {
+				fmt.Printf("Error posting new service labels %q: %s", serviceLabels, err)
 				errors["Other"] = append(errors["Other"], "Failed to update service labels")
 			}
 		}
 	}
 
-	// Update breaking changes status on PR
-	breakingState := "success"
-	if len(uniqueBreakingChanges) > 0 {
-		breakingState = "failure"
-		// If fetching the PR failed, Labels will be empty
-		for _, label := range pullRequest.Labels {
-			if label.Name == allowBreakingChangesLabel {
-				breakingState = "success"
-				break
-			}
+	// Report breaking changes as a check run with an annotation per finding
+	breakingChangesAllowed := false
+	// If fetching the PR failed, Labels will be empty
+	for _, label := range pullRequest.Labels {
+		if label.Name == allowBreakingChangesLabel {
+			breakingChangesAllowed = true
+			break
+		}
+	}
+	var prFiles []string
+	if len(data.BreakingChanges) > 0 {
+		if prFiles, err = gh.GetPullRequestFiles(strconv.Itoa(prNumber)); err != nil {
+			fmt.Printf("Error listing files for pr %d: %v\n", prNumber, err)
 		}
 	}
-	if err = gh.PostBuildStatus(strconv.Itoa(prNumber), "terraform-provider-breaking-change-test", breakingState, targetURL, commitSha); err != nil {
-		fmt.Printf("Error posting terraform-provider-breaking-change-test build status for pr %d commit %s: %v\n", prNumber, commitSha, err)
-		errors["Other"] = append(errors["Other"], "Failed to update breaking-change status check with state: "+breakingState)
+	checkRun := breakingChangesCheckRun(data.BreakingChanges, breakingChangesAllowed, prFiles, targetURL, commitSha)
+	if err = gh.CreateCheckRun(checkRun); err != nil {
+		// The checks API is only available to GitHub Apps, so fall back to a commit status
+		// and keep the findings in the comment.
+		fmt.Printf("Error creating %s check run for pr %d commit %s, falling back to a build status: %v\n", breakingChangeCheckName, prNumber, commitSha, err)
+		if err = gh.PostBuildStatus(strconv.Itoa(prNumber), breakingChangeCheckName, checkRun.Conclusion, targetURL, commitSha); err != nil {
+			fmt.Printf("Error posting %s build status for pr %d commit %s: %v\n", breakingChangeCheckName, prNumber, commitSha, err)
+			errors["Other"] = append(errors["Other"], "Failed to update breaking-change status check with state: "+checkRun.Conclusion)
+		}
+	} else {
+		data.BreakingChanges = nil
 	}
 
-	// Flag missing service labels for added resources
-	missingServiceLabels := []string{}
+	// Fail a check for added resources that the issue labeler can't route to a service
 	if len(regexpLabels) > 0 {
-		for resource, _ := range uniqueAddedResources {
-			found := false
-			for _, rl := range regexpLabels {
-				if rl.Regexp.MatchString(resource) {
-					found = true
-					break
-				}
-			}
-			if !found {
-				missingServiceLabels = append(missingServiceLabels, resource)
-			}
-		}
+		data.MissingServiceLabels = missingServiceLabels(maps.Keys(uniqueAddedResources), regexpLabels)
 		missingServiceLabelsState := "success"
-		if len(missingServiceLabels) > 0 {
+		if len(data.MissingServiceLabels) > 0 {
 			missingServiceLabelsState = "failure"
 			for _, label := range pullRequest.Labels {
 				if label.Name == allowMissingServiceLabelsLabel {
@@ -492,8 +521,21 @@ func execGenerateComment(prNumber int, ghTokenMagicModules, buildId, buildStep,
 			errors["Other"] = append(errors["Other"], "Failed to update missing-service-labels status check with state: "+missingServiceLabelsState)
 		}
 	}
-	sort.Strings(missingServiceLabels)
-	data.MissingServiceLabels = missingServiceLabels
+
+	// Check release notes against the resources changed; skipped if fetching the PR failed,
+	// since the body would be empty.
+	if fetchedPullRequest {
+		services := map[string]string{}
+		for _, repo := range []source.Repo{tpgRepo, tpgbRepo} {
+			for resource, service := range resourceServices(repo.ChangedFiles) {
+				services[resource] = service
+			}
+		}
+		data.ReleaseNotes = checkReleaseNotes(pullRequest.Body, maps.Keys(uniqueAddedResources), maps.Keys(uniqueModifiedResources), services, changelogEntries)
+		if breakingChangesAllowed {
+			data.ReleaseNotes = checkBreakingChangeReleaseNotes(data.ReleaseNotes, pullRequest.Body, breakingChangesSlice)
+		}
+	}
 
 	// Add errors to data as an ordered list
 	errorsList := []Errors{}
@@ -512,13 +554,25 @@ func execGenerateComment(prNumber int, ghTokenMagicModules, buildId, buildStep,
 		})
 	}
 	data.Errors = errorsList
+	data.CommitSHA = commitSha
 
-	// Post diff comment
+	// Post diff comment, updating the existing one rather than stacking a new one on each push
 	message, err := formatDiffComment(data)
 	if err != nil {
 		fmt.Printf("Data: %v\n", data)
 		return fmt.Errorf("error formatting message: %w", err)
 	}
+	comments, err := gh.GetPullRequestComments(strconv.Itoa(prNumber))
+	if err != nil {
+		fmt.Printf("Error listing comments on PR %d, posting a new diff comment: %v\n", prNumber, err)
+	}
+	if existing, ok := findDiffComment(comments); ok {
+		if err := gh.UpdateComment(strconv.Itoa(prNumber), message, existing.ID); err != nil {
+			fmt.Println("Comment: ", message)
+			return fmt.Errorf("error updating comment %d on PR %d: %w", existing.ID, prNumber, err)
+		}
+		return nil
+	}
 	if err := gh.PostComment(strconv.Itoa(prNumber), message); err != nil {
 		fmt.Println("Comment: ", message)
 		return fmt.Errorf("error posting comment to PR %d: %w", prNumber, err)
@@ -567,6 +621,103 @@ func computeBreakingChanges(diffProcessorPath string, rnr ExecRunner) ([]Breakin
 	return changes, rnr.PopDir()
 }
 
+// computeChangelogEntries returns the changelog entries the diff processor generates from the
+// schema diff, to suggest as release notes.
+func computeChangelogEntries(diffProcessorPath string, rnr ExecRunner) ([]ReleaseNote, error) {
+	if err := rnr.PushDir(diffProcessorPath); err != nil {
+		return nil, err
+	}
+	output, err := rnr.Run("bin/diff-processor", []string{"changelog-entries", "--json"}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if output == "" {
+		return nil, nil
+	}
+
+	var entries []ReleaseNote
+	if err = json.Unmarshal([]byte(output), &entries); err != nil {
+		return nil, err
+	}
+	return entries, rnr.PopDir()
+}
+
+// breakingChangesCheckRun builds the breaking change check run. Findings fail the check
+// unless they have been allowed with the override label, in which case they are warnings.
+func breakingChangesCheckRun(changes []BreakingChange, allowed bool, prFiles []string, detailsURL, commitSha string) github.CheckRun {
+	checkRun := github.CheckRun{
+		Name:       breakingChangeCheckName,
+		HeadSha:    commitSha,
+		DetailsURL: detailsURL,
+		Conclusion: "success",
+		Title:      "No breaking changes detected",
+		Summary:    "No breaking changes were detected within your pull request.",
+	}
+	if len(changes) == 0 {
+		return checkRun
+	}
+
+	level := "failure"
+	checkRun.Conclusion = "failure"
+	checkRun.Title = fmt.Sprintf("%d breaking change(s) detected", len(changes))
+	checkRun.Summary = "The breaking change(s) annotated below were detected within your pull request.\n\n" +
+		"If you believe this detection to be incorrect please raise the concern with your reviewer. " +
+		"If you intend to make this change you will need to wait for a [major release](https://www.terraform.io/plugin/sdkv2/best-practices/versioning#example-major-number-increments) window. " +
+		"An `" + allowBreakingChangesLabel + "` label can be added to allow merging."
+	if allowed {
+		level = "warning"
+		checkRun.Conclusion = "success"
+		checkRun.Summary = "The breaking change(s) annotated below were allowed with the `" + allowBreakingChangesLabel + "` label."
+	}
+
+	for _, change := range changes {
+		title := change.RuleName
+		if change.Field != "" {
+			title = fmt.Sprintf("%s: %s.%s", change.RuleName, change.Resource, change.Field)
+		} else if change.Resource != "" {
+			title = fmt.Sprintf("%s: %s", change.RuleName, change.Resource)
+		}
+		checkRun.Annotations = append(checkRun.Annotations, github.CheckRunAnnotation{
+			Path:    breakingChangePath(change.Resource, prFiles),
+			Level:   level,
+			Title:   title,
+			Message: change.Message,
+			RawDetails: fmt.Sprintf("rule: %s\nresource: %s\nfield: %s\nseverity: %s\ndocumentation: %s",
+				change.RuleName, change.Resource, change.Field, breakingChangeSeverity(change), change.DocumentationReference),
+		})
+	}
+	return checkRun
+}
+
+// breakingChangeSeverity returns the severity diff-processor assigned to a breaking change,
+// which is high unless it classified the change as less disruptive.
+func breakingChangeSeverity(change BreakingChange) string {
+	if change.Severity == "" {
+		return "high"
+	}
+	return change.Severity
+}
+
+// breakingChangePath returns the changed mmv1 product file defining resource, falling back
+// to the first changed file since annotations must be attached to a path.
+func breakingChangePath(resource string, prFiles []string) string {
+	want := strings.ReplaceAll(resource, "_", "")
+	for _, p := range prFiles {
+		m := productFileRegexp.FindStringSubmatch(p)
+		if m == nil || m[2] == "product" {
+			continue
+		}
+		if "google"+m[1]+strings.ToLower(strings.ReplaceAll(m[2], "_", "")) == want {
+			return p
+		}
+	}
+	if len(prFiles) > 0 {
+		return prFiles[0]
+	}
+	return "README.md"
+}
+
 func computeAffectedResources(diffProcessorPath string, rnr ExecRunner, repo source.Repo) (simpleSchemaDiff, error) {
 	if err := rnr.PushDir(diffProcessorPath); err != nil {
 		return simpleSchemaDiff{}, err
@@ -610,6 +761,25 @@ func detectMissingTests(diffProcessorPath, tpgbLocalPath string, rnr ExecRunner)
 	return missingTests, rnr.PopDir()
 }
 
+// Run the beta only test detector and return the changed GA fields that are only tested with the
+// beta provider, by resource.
+func detectBetaOnlyTests(diffProcessorPath, tpgbLocalPath string, rnr ExecRunner) (map[string][]string, error) {
+	if err := rnr.PushDir(diffProcessorPath); err != nil {
+		return nil, err
+	}
+
+	output, err := rnr.Run("bin/diff-processor", []string{"detect-beta-only-tests", fmt.Sprintf("%s/google-beta/services", tpgbLocalPath)}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var betaOnlyTests map[string][]string
+	if err = json.Unmarshal([]byte(output), &betaOnlyTests); err != nil {
+		return nil, err
+	}
+	return betaOnlyTests, rnr.PopDir()
+}
+
 // Run the missing doc detector and return the results.
 func detectMissingDocs(diffProcessorPath, tpgbLocalPath string, rnr ExecRunner) (*MissingDocsSummary, error) {
 	if err := rnr.PushDir(diffProcessorPath); err != nil {
@@ -641,6 +811,27 @@ func formatDiffComment(data diffCommentData) (string, error) {
 	return sb.String(), nil
 }
 
+// findDiffComment returns the newest diff comment posted by the magician, if any.
+func findDiffComment(comments []github.PullRequestComment) (github.PullRequestComment, bool) {
+	return findMagicianComment(comments, diffCommentMarker)
+}
+
+// findMagicianComment returns the newest comment posted by the magician containing marker, if any.
+func findMagicianComment(comments []github.PullRequestComment, marker string) (github.PullRequestComment, bool) {
+	var newest github.PullRequestComment
+	found := false
+	for _, comment := range comments {
+		if comment.User.Login != "modular-magician" || !strings.Contains(comment.Body, marker) {
+			continue
+		}
+		if !found || comment.CreatedAt.After(newest.CreatedAt) {
+			newest = comment
+			found = true
+		}
+	}
+	return newest, found
+}
+
 // addedMultipleResources returns a sorted slice of resource names that are considered "separate" resources.
 // In particular, IAM resources are merged with the parent resource as part of this check.
 func multipleResources(resources []string) []string {
@@ -673,6 +864,29 @@ func multipleResources(resources []string) []string {
 	return ret
 }
 
+// serviceLabelsForResources returns the sorted service labels for the resources a PR affects. PRs
+// affecting more than 3 services are treated as cross-provider changes and get service/terraform.
+func serviceLabelsForResources(resources []string, regexpLabels []labeler.RegexpLabel) []string {
+	labels := labeler.ComputeResourceLabels(resources, regexpLabels)
+	if len(labels) > 3 {
+		return []string{"service/terraform"}
+	}
+	return labels
+}
+
+// missingServiceLabels returns a sorted slice of the resources that don't match any resource
+// pattern in the issue labeler's enrolled teams, so issues filed against them couldn't be routed.
+func missingServiceLabels(resources []string, regexpLabels []labeler.RegexpLabel) []string {
+	var missing []string
+	for _, r := range resources {
+		if !slices.ContainsFunc(regexpLabels, func(rl labeler.RegexpLabel) bool { return rl.Regexp.MatchString(r) }) {
+			missing = append(missing, r)
+		}
+	}
+	slices.Sort(missing)
+	return missing
+}
+
 var resourceFileRegexp = regexp.MustCompile(`^.*/services/[^/]+/(?:data_source_|resource_|iam_)(.*?)(?:_test|_sweeper|_iam_test|_generated_test|_internal_test)?.go`)
 var resourceDocsRegexp = regexp.MustCompile(`^.*website/docs/(?:r|d)/(.*).html.markdown`)
 
@@ -21,9 +21,12 @@ import (
 	"path/filepath"
 	"reflect"
 	"testing"
+	"time"
 
+	"magician/github"
 	"magician/source"
 
+	"github.com/GoogleCloudPlatform/magic-modules/tools/issue-labeler/labeler"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -107,11 +110,13 @@ func TestExecGenerateComment(t *testing.T) {
 			{"/mock/dir/tfoics", "git", []string{"diff", "origin/auto-pr-123456-old", "origin/auto-pr-123456", "--shortstat"}, map[string]string(nil)},
 			{"/mock/dir/magic-modules/tools/diff-processor", "make", []string{"build"}, diffProcessorEnv},
 			{"/mock/dir/magic-modules/tools/diff-processor", "bin/diff-processor", []string{"breaking-changes"}, map[string]string(nil)},
+			{"/mock/dir/magic-modules/tools/diff-processor", "bin/diff-processor", []string{"detect-beta-only-tests", "/mock/dir/tpgb/google-beta/services"}, map[string]string(nil)},
 			{"/mock/dir/magic-modules/tools/diff-processor", "bin/diff-processor", []string{"schema-diff"}, map[string]string(nil)},
 			{"/mock/dir/magic-modules/tools/diff-processor", "make", []string{"build"}, diffProcessorEnv},
 			{"/mock/dir/magic-modules/tools/diff-processor", "bin/diff-processor", []string{"breaking-changes"}, map[string]string(nil)},
 			{"/mock/dir/magic-modules/tools/diff-processor", "bin/diff-processor", []string{"detect-missing-tests", "/mock/dir/tpgb/google-beta/services"}, map[string]string(nil)},
 			{"/mock/dir/magic-modules/tools/diff-processor", "bin/diff-processor", []string{"detect-missing-docs", "/mock/dir/tpgb"}, map[string]string(nil)},
+			{"/mock/dir/magic-modules/tools/diff-processor", "bin/diff-processor", []string{"changelog-entries", "--json"}, map[string]string(nil)},
 			{"/mock/dir/magic-modules/tools/diff-processor", "bin/diff-processor", []string{"schema-diff"}, map[string]string(nil)},
 		},
 	} {
@@ -131,11 +136,18 @@ func TestExecGenerateComment(t *testing.T) {
 	for method, expectedCalls := range map[string][][]any{
 		"PostBuildStatus": {
 			{"123456", "terraform-provider-multiple-resources", "success", "https://console.cloud.google.com/cloud-build/builds;region=global/build1;step=17?project=project1", "sha1"},
-			{"123456", "terraform-provider-breaking-change-test", "success", "https://console.cloud.google.com/cloud-build/builds;region=global/build1;step=17?project=project1", "sha1"},
 			{"123456", "terraform-provider-missing-service-labels", "success", "https://console.cloud.google.com/cloud-build/builds;region=global/build1;step=17?project=project1", "sha1"},
 		},
-		"PostComment": {{"123456", "Hi there, I'm the Modular magician. I've detected the following information about your changes:\n\n## Diff report\n\nYour PR generated some diffs in downstreams - here they are.\n\n`google` provider: [Diff](https://github.com/modular-magician/terraform-provider-google/compare/1a2a3a4a..1a2a3a4b) ( 2 files changed, 40 insertions(+))\n`google-beta` provider: [Diff](https://github.com/modular-magician/terraform-provider-google-beta/compare/1a2a3a4a..1a2a3a4b) ( 2 files changed, 40 insertions(+))\n`terraform-google-conversion`: [Diff](https://github.com/modular-magician/terraform-google-conversion/compare/1a2a3a4a..1a2a3a4b) ( 1 file changed, 10 insertions(+))\n\n\n\n## Missing test report\nYour PR includes resource fields which are not covered by any test.\n\nResource: `google_folder_access_approval_settings` (3 total tests)\nPlease add an acceptance test which includes these fields. The test should include the following:\n\n```hcl\nresource \"google_folder_access_approval_settings\" \"primary\" {\n  uncovered_field = # value needed\n}\n\n```\n\n\n"}},
+		"PostComment": {{"123456", "Hi there, I'm the Modular magician. I've detected the following information about your changes:\n\n## Diff report\n\nYour PR generated some diffs in downstreams - here they are.\n\n`google` provider: [Diff](https://github.com/modular-magician/terraform-provider-google/compare/1a2a3a4a..1a2a3a4b) ( 2 files changed, 40 insertions(+))\n`google-beta` provider: [Diff](https://github.com/modular-magician/terraform-provider-google-beta/compare/1a2a3a4a..1a2a3a4b) ( 2 files changed, 40 insertions(+))\n`terraform-google-conversion`: [Diff](https://github.com/modular-magician/terraform-google-conversion/compare/1a2a3a4a..1a2a3a4b) ( 1 file changed, 10 insertions(+))\n\n\n\n## Missing test report\nYour PR includes resource fields which are not covered by any test.\n\nResource: `google_folder_access_approval_settings` (3 total tests)\nPlease add an acceptance test which includes these fields. The following config sets them, along with the resource's required fields, using placeholder values based on the schema; replace the values with ones the API accepts:\n\n```hcl\nresource \"google_folder_access_approval_settings\" \"primary\" {\n  uncovered_field = # value needed\n}\n\n```\n\n\n\n## Release notes\n\nBased on the changes in this PR, consider adding these release notes to your PR description:\n\n````\n```release-note:new-resource\n`google_alloydb_instance`\n```\n````\n\nSee the [release notes guide](https://googlecloudplatform.github.io/magic-modules/contribute/release-notes/) for the expected format.\n\n_Last updated for commit sha1._\n<!-- modular-magician:diff-report -->\n"}},
 		"AddLabels":   {{"123456", []string{"service/alloydb"}}},
+		"CreateCheckRun": {{github.CheckRun{
+			Name:       "terraform-provider-breaking-change-test",
+			HeadSha:    "sha1",
+			DetailsURL: "https://console.cloud.google.com/cloud-build/builds;region=global/build1;step=17?project=project1",
+			Conclusion: "success",
+			Title:      "No breaking changes detected",
+			Summary:    "No breaking changes were detected within your pull request.",
+		}}},
 	} {
 		if actualCalls, ok := gh.calledMethods[method]; !ok {
 			t.Fatalf("Found no calls for %s", method)
@@ -159,14 +171,21 @@ func TestFormatDiffComment(t *testing.T) {
 	}{
 		"basic message": {
 			data:            diffCommentData{},
-			expectedStrings: []string{"## Diff report", "hasn't generated any diffs"},
+			expectedStrings: []string{"## Diff report", "hasn't generated any diffs", diffCommentMarker},
 			notExpectedStrings: []string{
 				"generated some diffs",
 				"## Breaking Change(s) Detected",
 				"## Errors",
 				"## Missing test report",
+				"Last updated for commit",
 			},
 		},
+		"commit is displayed": {
+			data: diffCommentData{
+				CommitSHA: "abc123",
+			},
+			expectedStrings: []string{"_Last updated for commit abc123._", diffCommentMarker},
+		},
 		"errors are displayed": {
 			data: diffCommentData{
 				Errors: []Errors{
@@ -228,6 +247,7 @@ func TestFormatDiffComment(t *testing.T) {
 					{
 						Message:                "Breaking change 2",
 						DocumentationReference: "doc2",
+						Severity:               "medium",
 					},
 				},
 			},
@@ -236,7 +256,7 @@ func TestFormatDiffComment(t *testing.T) {
 				"## Breaking Change(s) Detected",
 				"major release",
 				"`override-breaking-change`",
-				"- Breaking change 1 - [reference](doc1)\n- Breaking change 2 - [reference](doc2)\n",
+				"- Breaking change 1 - [reference](doc1)\n- Breaking change 2 (medium severity) - [reference](doc2)\n",
 			},
 			notExpectedStrings: []string{
 				"generated some diffs",
@@ -263,6 +283,24 @@ func TestFormatDiffComment(t *testing.T) {
 				"## Breaking Change(s) Detected",
 			},
 		},
+		"missing service labels are displayed": {
+			data: diffCommentData{
+				MissingServiceLabels: []string{"google_alloydb_cluster", "google_redis_instance"},
+			},
+			expectedStrings: []string{
+				"## Diff report",
+				"## Missing service labels",
+				"- `google_alloydb_cluster`\n- `google_redis_instance`\n",
+				"tools/issue-labeler/labeler/enrolled_teams.yml",
+				"`override-missing-service-labels`",
+			},
+			notExpectedStrings: []string{
+				"generated some diffs",
+				"## Errors",
+				"## Multiple resources added",
+				"## Breaking Change(s) Detected",
+			},
+		},
 		"missing tests are displayed": {
 			data: diffCommentData{
 				MissingTests: map[string]*MissingTestInfo{
@@ -282,6 +320,23 @@ func TestFormatDiffComment(t *testing.T) {
 				"## Errors",
 			},
 		},
+		"beta only tests are displayed": {
+			data: diffCommentData{
+				BetaOnlyTests: map[string][]string{
+					"google_x_resource": {"field_a", "block.field_b"},
+				},
+			},
+			expectedStrings: []string{
+				"## Diff report",
+				"## Fields only tested with the beta provider",
+				"- `google_x_resource`: `field_a`, `block.field_b`",
+			},
+			notExpectedStrings: []string{
+				"generated some diffs",
+				"## Missing test report",
+				"## Errors",
+			},
+		},
 		"missing docs are displayed": {
 			data: diffCommentData{
 				MissingDocs: &MissingDocsSummary{
@@ -418,6 +473,243 @@ func TestMultipleResources(t *testing.T) {
 	}
 }
 
+func TestServiceLabelsForResources(t *testing.T) {
+	regexpLabels, err := labeler.BuildRegexLabels([]byte(`
+service/aiplatform-featurestore:
+  resources:
+  - google_vertex_ai_featurestore.*
+service/aiplatform:
+  resources:
+  - google_vertex_ai_.*
+service/redis:
+  resources:
+  - google_redis_.*
+service/alloydb:
+  resources:
+  - google_alloydb_.*
+service/compute:
+  resources:
+  - google_compute_.*
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name      string
+		resources []string
+		want      []string
+	}{
+		{
+			name: "no resources",
+			want: []string{},
+		},
+		{
+			name:      "unmapped resource",
+			resources: []string{"google_spanner_instance"},
+			want:      []string{},
+		},
+		{
+			name:      "multiple services",
+			resources: []string{"google_redis_instance", "google_alloydb_cluster", "google_redis_cluster"},
+			want:      []string{"service/alloydb", "service/redis"},
+		},
+		{
+			// Issues only get the first label in label order whose pattern matches.
+			name:      "overlapping patterns",
+			resources: []string{"google_vertex_ai_featurestore_entitytype"},
+			want:      []string{"service/aiplatform"},
+		},
+		{
+			name:      "cross-provider change",
+			resources: []string{"google_redis_instance", "google_alloydb_cluster", "google_compute_instance", "google_vertex_ai_endpoint"},
+			want:      []string{"service/terraform"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := serviceLabelsForResources(tc.resources, regexpLabels)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestMissingServiceLabels(t *testing.T) {
+	regexpLabels, err := labeler.BuildRegexLabels([]byte(`
+service/redis:
+  resources:
+  - google_redis_.*
+service/alloydb:
+  resources:
+  - google_alloydb_cluster
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name      string
+		resources []string
+		want      []string
+	}{
+		{
+			name: "no resources",
+		},
+		{
+			name:      "all mapped",
+			resources: []string{"google_redis_instance", "google_alloydb_cluster"},
+		},
+		{
+			name:      "patterns are anchored",
+			resources: []string{"google_alloydb_cluster_iam_member", "google_redis_instance"},
+			want:      []string{"google_alloydb_cluster_iam_member"},
+		},
+		{
+			name:      "multiple unmapped",
+			resources: []string{"google_spanner_instance", "google_redis_instance", "google_bigtable_table"},
+			want:      []string{"google_bigtable_table", "google_spanner_instance"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := missingServiceLabels(tc.resources, regexpLabels)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestFindDiffComment(t *testing.T) {
+	older := github.PullRequestComment{
+		User:      github.User{Login: "modular-magician"},
+		Body:      "Old diff report\n" + diffCommentMarker,
+		ID:        1,
+		CreatedAt: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	newer := github.PullRequestComment{
+		User:      github.User{Login: "modular-magician"},
+		Body:      "New diff report\n" + diffCommentMarker,
+		ID:        2,
+		CreatedAt: time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC),
+	}
+	quoted := github.PullRequestComment{
+		User:      github.User{Login: "author"},
+		Body:      "> New diff report\n> " + diffCommentMarker,
+		ID:        3,
+		CreatedAt: time.Date(2026, 1, 3, 0, 0, 0, 0, time.UTC),
+	}
+	other := github.PullRequestComment{
+		User:      github.User{Login: "modular-magician"},
+		Body:      "Tests analytics",
+		ID:        4,
+		CreatedAt: time.Date(2026, 1, 4, 0, 0, 0, 0, time.UTC),
+	}
+
+	cases := map[string]struct {
+		comments  []github.PullRequestComment
+		wantFound bool
+		wantID    int
+	}{
+		"no comments": {},
+		"no diff comment": {
+			comments: []github.PullRequestComment{quoted, other},
+		},
+		"newest diff comment by the magician": {
+			comments:  []github.PullRequestComment{newer, older, quoted, other},
+			wantFound: true,
+			wantID:    2,
+		},
+	}
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+
+			got, found := findDiffComment(tc.comments)
+			assert.Equal(t, tc.wantFound, found)
+			assert.Equal(t, tc.wantID, got.ID)
+		})
+	}
+}
+
+func TestBreakingChangesCheckRun(t *testing.T) {
+	fieldChange := BreakingChange{
+		Resource:               "google_redis_instance",
+		Field:                  "tier",
+		Message:                "Field `tier` changed from optional to required on `google_redis_instance`",
+		DocumentationReference: "https://googlecloudplatform.github.io/magic-modules/breaking-changes/breaking-changes#field-optional-to-required",
+		RuleName:               "field-optional-to-required",
+		Severity:               "high",
+	}
+	resourceChange := BreakingChange{
+		Resource:               "google_alloydb_cluster",
+		Message:                "Resource `google_alloydb_cluster` was either removed or renamed",
+		DocumentationReference: "https://googlecloudplatform.github.io/magic-modules/breaking-changes/breaking-changes#resource-map-resource-removal-or-rename",
+		RuleName:               "resource-map-resource-removal-or-rename",
+	}
+	prFiles := []string{"mmv1/third_party/terraform/go.mod", "mmv1/products/redis/Instance.yaml"}
+
+	cases := map[string]struct {
+		changes         []BreakingChange
+		allowed         bool
+		wantConclusion  string
+		wantAnnotations []github.CheckRunAnnotation
+	}{
+		"no breaking changes": {
+			wantConclusion: "success",
+		},
+		"breaking changes fail the check": {
+			changes:        []BreakingChange{fieldChange, resourceChange},
+			wantConclusion: "failure",
+			wantAnnotations: []github.CheckRunAnnotation{
+				{
+					Path:       "mmv1/products/redis/Instance.yaml",
+					Level:      "failure",
+					Title:      "field-optional-to-required: google_redis_instance.tier",
+					Message:    fieldChange.Message,
+					RawDetails: "rule: field-optional-to-required\nresource: google_redis_instance\nfield: tier\nseverity: high\ndocumentation: " + fieldChange.DocumentationReference,
+				},
+				{
+					Path:       "mmv1/third_party/terraform/go.mod",
+					Level:      "failure",
+					Title:      "resource-map-resource-removal-or-rename: google_alloydb_cluster",
+					Message:    resourceChange.Message,
+					RawDetails: "rule: resource-map-resource-removal-or-rename\nresource: google_alloydb_cluster\nfield: \nseverity: high\ndocumentation: " + resourceChange.DocumentationReference,
+				},
+			},
+		},
+		"allowed breaking changes are warnings": {
+			changes:        []BreakingChange{fieldChange},
+			allowed:        true,
+			wantConclusion: "success",
+			wantAnnotations: []github.CheckRunAnnotation{
+				{
+					Path:       "mmv1/products/redis/Instance.yaml",
+					Level:      "warning",
+					Title:      "field-optional-to-required: google_redis_instance.tier",
+					Message:    fieldChange.Message,
+					RawDetails: "rule: field-optional-to-required\nresource: google_redis_instance\nfield: tier\nseverity: high\ndocumentation: " + fieldChange.DocumentationReference,
+				},
+			},
+		},
+	}
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+
+			got := breakingChangesCheckRun(tc.changes, tc.allowed, prFiles, "https://example.com/build", "sha1")
+			assert.Equal(t, "terraform-provider-breaking-change-test", got.Name)
+			assert.Equal(t, "sha1", got.HeadSha)
+			assert.Equal(t, tc.wantConclusion, got.Conclusion)
+			assert.Equal(t, tc.wantAnnotations, got.Annotations)
+		})
+	}
+}
+
 func TestFileToResource(t *testing.T) {
 	cases := map[string]struct {
 		path string
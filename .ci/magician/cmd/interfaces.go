@@ -18,19 +18,33 @@ package cmd
 import (
 	"magician/github"
 	"magician/teamcity"
+	"time"
 )
 
 type GithubClient interface {
 	GetPullRequest(prNumber string) (github.PullRequest, error)
 	GetPullRequests(state, base, sort, direction string) ([]github.PullRequest, error)
+	GetOpenPullRequests(base string) ([]github.PullRequest, error)
 	GetPullRequestRequestedReviewers(prNumber string) ([]github.User, error)
 	GetPullRequestPreviousReviewers(prNumber string) ([]github.User, error)
 	GetPullRequestComments(prNumber string) ([]github.PullRequestComment, error)
+	GetPullRequestReviews(prNumber string) ([]github.PullRequestReview, error)
+	GetPullRequestFiles(prNumber string) ([]string, error)
+	GetPullRequestFileChanges(prNumber string) ([]github.PullRequestFile, error)
 	GetCommitMessage(owner, repo, sha string) (string, error)
+	GetPendingRequiredChecks(branch, commitSha string) ([]string, error)
+	GetCommitDate(commitSha string) (time.Time, error)
 	GetUserType(user string) github.UserType
+	IsCoreContributor(user string) bool
+	IsMaintainer(user string) bool
 	GetTeamMembers(organization, team string) ([]github.User, error)
+	GetOpenReviewCounts(users []string) (map[string]int, error)
 	MergePullRequest(owner, repo, prNumber, commitSha string) error
+	MergeRepoPullRequest(prNumber, commitSha string) error
+	UpdatePullRequestBranch(prNumber, headSha string) error
+	CreatePullRequest(owner, repo, title, body, head, base string, draft bool) (int, error)
 	PostBuildStatus(prNumber, title, state, targetURL, commitSha string) error
+	CreateCheckRun(checkRun github.CheckRun) error
 	PostComment(prNumber, comment string) error
 	UpdateComment(prNumber, comment string, id int) error
 	RequestPullRequestReviewers(prNumber string, reviewers []string) error
@@ -42,6 +56,8 @@ type GithubClient interface {
 
 type CloudbuildClient interface {
 	ApproveDownstreamGenAndTest(prNumber, commitSha string) error
+	RetryBuild(triggerId, commitSha string) (string, error)
+	RunTrigger(triggerId, commitSha string, substitutions map[string]string) (string, error)
 }
 
 type CloudstorageClient interface {
@@ -0,0 +1,150 @@
+/*
+* Copyright 2026 Google LLC. All Rights Reserved.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+package cmd
+
+import (
+	"fmt"
+	"magician/github"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// Size labels in increasing order, with the most changed lines each allows.
+var prSizeLabels = []struct {
+	name     string
+	maxLines int
+}{
+	{"size/XS", 9},
+	{"size/S", 49},
+	{"size/M", 249},
+	{"size/L", 999},
+	{"size/XL", -1},
+}
+
+// Files that don't count towards a PR's size, since they're generated or test fixtures
+// rather than code to review.
+var generatedFileRegexps = []*regexp.Regexp{
+	regexp.MustCompile(`(^|/)go\.sum$`),
+	regexp.MustCompile(`(^|/)package-lock\.json$`),
+	regexp.MustCompile(`\.pb\.go$`),
+	regexp.MustCompile(`(^|/)testdata/`),
+}
+
+const largePRComment = "This PR changes %d lines, excluding generated files. Large PRs take much longer to review; " +
+	"if possible, please split it into smaller PRs that can be reviewed and merged separately."
+
+// labelPRSizeCmd represents the label-pr-size command
+var labelPRSizeCmd = &cobra.Command{
+	Use:   "label-pr-size PR_NUMBER",
+	Short: "Labels a PR with its size",
+	Long: `This command labels a PR with its size, from size/XS to size/XL.
+
+	The size is the number of lines added and deleted, excluding generated files and test
+	fixtures such as go.sum and testdata directories.
+
+	The command expects the following pull request details as arguments:
+	1. PR Number
+
+	It then performs the following operations:
+	1. Computes the PR's size from the files it changes.
+	2. Adds the matching size label and removes any other size labels.
+	3. The first time a PR is labeled size/XL, comments asking the author to split it up.
+	`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		prNumber := args[0]
+		fmt.Println("PR Number: ", prNumber)
+		githubToken, ok := os.LookupEnv("GITHUB_TOKEN")
+		if !ok {
+			return fmt.Errorf("did not provide GITHUB_TOKEN environment variable")
+		}
+		gh := github.NewClient(githubToken)
+		return execLabelPRSize(prNumber, gh)
+	},
+}
+
+func execLabelPRSize(prNumber string, gh GithubClient) error {
+	pullRequest, err := gh.GetPullRequest(prNumber)
+	if err != nil {
+		return err
+	}
+	files, err := gh.GetPullRequestFileChanges(prNumber)
+	if err != nil {
+		return err
+	}
+
+	lines := changedLines(files)
+	label := prSizeLabel(lines)
+	fmt.Printf("PR %s changes %d lines: %s\n", prNumber, lines, label)
+
+	hadLabel := false
+	for _, l := range pullRequest.Labels {
+		if l.Name == label {
+			hadLabel = true
+		} else if strings.HasPrefix(l.Name, "size/") {
+			if err := gh.RemoveLabel(prNumber, l.Name); err != nil {
+				return err
+			}
+		}
+	}
+	if hadLabel {
+		return nil
+	}
+	if err := gh.AddLabels(prNumber, []string{label}); err != nil {
+		return err
+	}
+	if label == prSizeLabels[len(prSizeLabels)-1].name {
+		return gh.PostComment(prNumber, fmt.Sprintf(largePRComment, lines))
+	}
+	return nil
+}
+
+// changedLines returns the lines added and deleted across files, excluding generated files.
+func changedLines(files []github.PullRequestFile) int {
+	lines := 0
+	for _, f := range files {
+		if isGeneratedFile(f.Filename) {
+			continue
+		}
+		lines += f.Additions + f.Deletions
+	}
+	return lines
+}
+
+func isGeneratedFile(path string) bool {
+	for _, r := range generatedFileRegexps {
+		if r.MatchString(path) {
+			return true
+		}
+	}
+	return false
+}
+
+func prSizeLabel(lines int) string {
+	for _, size := range prSizeLabels {
+		if size.maxLines < 0 || lines <= size.maxLines {
+			return size.name
+		}
+	}
+	return prSizeLabels[len(prSizeLabels)-1].name
+}
+
+func init() {
+	rootCmd.AddCommand(labelPRSizeCmd)
+}
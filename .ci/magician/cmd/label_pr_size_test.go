@@ -0,0 +1,117 @@
+/*
+* Copyright 2026 Google LLC. All Rights Reserved.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+package cmd
+
+import (
+	"fmt"
+	"testing"
+
+	"magician/github"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChangedLines(t *testing.T) {
+	files := []github.PullRequestFile{
+		{Filename: "mmv1/products/redis/Instance.yaml", Additions: 10, Deletions: 2},
+		{Filename: "mmv1/third_party/terraform/go.sum", Additions: 100, Deletions: 50},
+		{Filename: "tools/diff-processor/go.sum", Additions: 3},
+		{Filename: "mmv1/third_party/cai2hcl/services/compute/testdata/instance.json", Additions: 400},
+		{Filename: "docs/content/develop/resource.md", Deletions: 5},
+	}
+	assert.Equal(t, 17, changedLines(files))
+}
+
+func TestPRSizeLabel(t *testing.T) {
+	cases := map[int]string{
+		0:    "size/XS",
+		9:    "size/XS",
+		10:   "size/S",
+		49:   "size/S",
+		50:   "size/M",
+		249:  "size/M",
+		250:  "size/L",
+		999:  "size/L",
+		1000: "size/XL",
+		9999: "size/XL",
+	}
+	for lines, want := range cases {
+		t.Run(fmt.Sprint(lines), func(t *testing.T) {
+			assert.Equal(t, want, prSizeLabel(lines))
+		})
+	}
+}
+
+func TestExecLabelPRSize(t *testing.T) {
+	cases := map[string]struct {
+		labels        []github.Label
+		lines         int
+		expectMethods map[string][][]any
+	}{
+		"new PR is labeled": {
+			lines: 20,
+			expectMethods: map[string][][]any{
+				"AddLabels": {{"1", []string{"size/S"}}},
+			},
+		},
+		"label is replaced when the size changes": {
+			labels: []github.Label{{Name: "size/S"}, {Name: "service/compute"}},
+			lines:  300,
+			expectMethods: map[string][][]any{
+				"RemoveLabel": {{"1", "size/S"}},
+				"AddLabels":   {{"1", []string{"size/L"}}},
+			},
+		},
+		"unchanged size does nothing": {
+			labels:        []github.Label{{Name: "size/S"}},
+			lines:         20,
+			expectMethods: map[string][][]any{},
+		},
+		"huge PR is asked to split": {
+			lines: 2000,
+			expectMethods: map[string][][]any{
+				"AddLabels":   {{"1", []string{"size/XL"}}},
+				"PostComment": {{"1", fmt.Sprintf(largePRComment, 2000)}},
+			},
+		},
+		"huge PR is only asked to split once": {
+			labels:        []github.Label{{Name: "size/XL"}},
+			lines:         2500,
+			expectMethods: map[string][][]any{},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			gh := &mockGithub{
+				pullRequest: github.PullRequest{Labels: tc.labels},
+				fileChanges: []github.PullRequestFile{
+					{Filename: "mmv1/products/redis/Instance.yaml", Additions: tc.lines},
+					{Filename: "mmv1/third_party/terraform/go.sum", Additions: 5000},
+				},
+				calledMethods: make(map[string][][]any),
+			}
+
+			if err := execLabelPRSize("1", gh); err != nil {
+				t.Fatalf("execLabelPRSize failed: %v", err)
+			}
+
+			for _, method := range []string{"AddLabels", "RemoveLabel", "PostComment"} {
+				assert.Equal(t, tc.expectMethods[method], gh.calledMethods[method], method)
+			}
+		})
+	}
+}
@@ -23,3 +23,13 @@ func (m *mockCloudBuild) ApproveDownstreamGenAndTest(prNumber, commitSha string)
 	m.calledMethods["ApproveDownstreamGenAndTest"] = append(m.calledMethods["ApproveDownstreamGenAndTest"], []any{prNumber, commitSha})
 	return nil
 }
+
+func (m *mockCloudBuild) RetryBuild(triggerId, commitSha string) (string, error) {
+	m.calledMethods["RetryBuild"] = append(m.calledMethods["RetryBuild"], []any{triggerId, commitSha})
+	return "retried-build", nil
+}
+
+func (m *mockCloudBuild) RunTrigger(triggerId, commitSha string, substitutions map[string]string) (string, error) {
+	m.calledMethods["RunTrigger"] = append(m.calledMethods["RunTrigger"], []any{triggerId, commitSha, substitutions})
+	return "triggered-build", nil
+}
@@ -17,6 +17,8 @@ package cmd
 
 import (
 	"errors"
+	"slices"
+	"time"
 
 	"magician/github"
 )
@@ -24,10 +26,19 @@ import (
 type mockGithub struct {
 	pullRequest         github.PullRequest
 	userType            github.UserType
+	coreContributors    []string
+	maintainers         []string
 	requestedReviewers  []github.User
 	previousReviewers   []github.User
 	pullRequestComments []github.PullRequestComment
+	pullRequestFiles    []string
+	fileChanges         []github.PullRequestFile
+	reviews             []github.PullRequestReview
 	teamMembers         map[string][]github.User
+	openReviews         map[string]int
+	checkRunErr         error
+	pendingChecks       []string
+	commitDate          time.Time
 	calledMethods       map[string][][]any
 	commitMessage       string
 }
@@ -42,11 +53,26 @@ func (m *mockGithub) GetPullRequests(state, base, sort, direction string) ([]git
 	return []github.PullRequest{m.pullRequest}, nil
 }
 
+func (m *mockGithub) GetOpenPullRequests(base string) ([]github.PullRequest, error) {
+	m.calledMethods["GetOpenPullRequests"] = append(m.calledMethods["GetOpenPullRequests"], []any{base})
+	return []github.PullRequest{m.pullRequest}, nil
+}
+
 func (m *mockGithub) GetUserType(user string) github.UserType {
 	m.calledMethods["GetUserType"] = append(m.calledMethods["GetUserType"], []any{user})
 	return m.userType
 }
 
+func (m *mockGithub) IsCoreContributor(user string) bool {
+	m.calledMethods["IsCoreContributor"] = append(m.calledMethods["IsCoreContributor"], []any{user})
+	return github.IsCoreReviewer(user) || slices.Contains(m.coreContributors, user)
+}
+
+func (m *mockGithub) IsMaintainer(user string) bool {
+	m.calledMethods["IsMaintainer"] = append(m.calledMethods["IsMaintainer"], []any{user})
+	return slices.Contains(m.maintainers, user)
+}
+
 func (m *mockGithub) GetPullRequestRequestedReviewers(prNumber string) ([]github.User, error) {
 	m.calledMethods["GetPullRequestRequestedReviewers"] = append(m.calledMethods["GetPullRequestRequestedReviewers"], []any{prNumber})
 	return m.requestedReviewers, nil
@@ -62,6 +88,21 @@ func (m *mockGithub) GetPullRequestComments(prNumber string) ([]github.PullReque
 	return m.pullRequestComments, nil
 }
 
+func (m *mockGithub) GetPullRequestReviews(prNumber string) ([]github.PullRequestReview, error) {
+	m.calledMethods["GetPullRequestReviews"] = append(m.calledMethods["GetPullRequestReviews"], []any{prNumber})
+	return m.reviews, nil
+}
+
+func (m *mockGithub) GetPullRequestFiles(prNumber string) ([]string, error) {
+	m.calledMethods["GetPullRequestFiles"] = append(m.calledMethods["GetPullRequestFiles"], []any{prNumber})
+	return m.pullRequestFiles, nil
+}
+
+func (m *mockGithub) GetPullRequestFileChanges(prNumber string) ([]github.PullRequestFile, error) {
+	m.calledMethods["GetPullRequestFileChanges"] = append(m.calledMethods["GetPullRequestFileChanges"], []any{prNumber})
+	return m.fileChanges, nil
+}
+
 func (m *mockGithub) GetCommitMessage(owner, repo, sha string) (string, error) {
 	m.calledMethods["GetCommitMessage"] = append(m.calledMethods["GetCommitMessage"], []any{owner, repo, sha})
 	return m.commitMessage, nil
@@ -75,6 +116,11 @@ func (m *mockGithub) GetTeamMembers(organization, team string) ([]github.User, e
 	return m.teamMembers[team], nil
 }
 
+func (m *mockGithub) GetOpenReviewCounts(users []string) (map[string]int, error) {
+	m.calledMethods["GetOpenReviewCounts"] = append(m.calledMethods["GetOpenReviewCounts"], []any{users})
+	return m.openReviews, nil
+}
+
 func (m *mockGithub) RequestPullRequestReviewers(prNumber string, reviewers []string) error {
 	m.calledMethods["RequestPullRequestReviewers"] = append(m.calledMethods["RequestPullRequestReviewers"], []any{prNumber, reviewers})
 	return nil
@@ -110,12 +156,42 @@ func (m *mockGithub) PostBuildStatus(prNumber string, title string, state string
 	return nil
 }
 
+func (m *mockGithub) CreateCheckRun(checkRun github.CheckRun) error {
+	m.calledMethods["CreateCheckRun"] = append(m.calledMethods["CreateCheckRun"], []any{checkRun})
+	return m.checkRunErr
+}
+
 func (m *mockGithub) CreateWorkflowDispatchEvent(workflowFileName string, inputs map[string]any) error {
 	m.calledMethods["CreateWorkflowDispatchEvent"] = append(m.calledMethods["CreateWorkflowDispatchEvent"], []any{workflowFileName, inputs})
 	return nil
 }
 
+func (m *mockGithub) GetPendingRequiredChecks(branch, commitSha string) ([]string, error) {
+	m.calledMethods["GetPendingRequiredChecks"] = append(m.calledMethods["GetPendingRequiredChecks"], []any{branch, commitSha})
+	return m.pendingChecks, nil
+}
+
+func (m *mockGithub) GetCommitDate(commitSha string) (time.Time, error) {
+	m.calledMethods["GetCommitDate"] = append(m.calledMethods["GetCommitDate"], []any{commitSha})
+	return m.commitDate, nil
+}
+
+func (m *mockGithub) MergeRepoPullRequest(prNumber, commitSha string) error {
+	m.calledMethods["MergeRepoPullRequest"] = append(m.calledMethods["MergeRepoPullRequest"], []any{prNumber, commitSha})
+	return nil
+}
+
 func (m *mockGithub) MergePullRequest(owner, repo, prNumber, commitSha string) error {
 	m.calledMethods["MergePullRequest"] = append(m.calledMethods["MergePullRequest"], []any{owner, repo, prNumber, commitSha})
 	return nil
 }
+
+func (m *mockGithub) CreatePullRequest(owner, repo, title, body, head, base string, draft bool) (int, error) {
+	m.calledMethods["CreatePullRequest"] = append(m.calledMethods["CreatePullRequest"], []any{owner, repo, title, body, head, base, draft})
+	return 1, nil
+}
+
+func (m *mockGithub) UpdatePullRequestBranch(prNumber, headSha string) error {
+	m.calledMethods["UpdatePullRequestBranch"] = append(m.calledMethods["UpdatePullRequestBranch"], []any{prNumber, headSha})
+	return nil
+}
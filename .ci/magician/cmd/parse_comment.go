@@ -6,6 +6,7 @@ import (
 	"regexp"
 	"strings"
 
+	"magician/cloudbuild"
 	"magician/github"
 
 	"github.com/spf13/cobra"
@@ -20,6 +21,31 @@ var magicianInvocationRegex = regexp.MustCompile(`@modular-magician\s+([^\n\r]+)
 // Captures only valid GitHub usernames: [a-zA-Z0-9-_]
 var reassignReviewerRegex = regexp.MustCompile(`^(?:re)?assign[- ]?review(?:er)?\s*@?([a-zA-Z0-9-_]*)`)
 
+// Slash commands to retry builds, which must be on a line of their own: /retest or /retest-vcr
+var retestRegex = regexp.MustCompile(`(?m)^\s*/(retest(?:-vcr)?)\s*$`)
+
+// retestTriggers maps retest commands to the environment variables holding the ID of the
+// Cloud Build trigger whose build they retry.
+var retestTriggers = map[string]string{
+	"retest":     "DOWNSTREAM_GENERATION_AND_TEST_TRIGGER",
+	"retest-vcr": "VCR_TEST_TRIGGER",
+}
+
+// Slash command for a maintainer to approve held cloud tests for the commit they reviewed, which
+// must be on a line of its own: /gcbrun 1a2b3c4. The SHA is checked by handleGcbrun so that a
+// missing or malformed one gets a reply rather than being ignored.
+var gcbrunRegex = regexp.MustCompile(`(?m)^\s*/gcbrun(?:[ \t]+(\S+))?[ \t]*$`)
+
+// A full or abbreviated commit SHA
+var commitShaRegex = regexp.MustCompile(`^[0-9a-f]{7,40}$`)
+
+// Slash command to re-record the VCR cassettes of the tests matching a regex, which must be on a
+// line of its own: /record TestAccComputeInstance_.*
+var recordRegex = regexp.MustCompile(`(?m)^\s*/record\s+(\S+)\s*$`)
+
+// Environment variable holding the ID of the Cloud Build trigger that records VCR cassettes
+const recordTriggerEnv = "VCR_RECORD_TRIGGER"
+
 var parseCommentCmd = &cobra.Command{
 	Use:   "parse-comment PR_NUMBER COMMENT_AUTHOR",
 	Short: "Parses a comment from the COMMENT_BODY env var to execute magician commands",
@@ -30,6 +56,20 @@ var parseCommentCmd = &cobra.Command{
 	- Commands with spaces: reassign reviewer
 	- Optional prefixes and suffixes: assign-review, reassign-reviewer
 	- Optional @ prefix for usernames
+
+	It also supports slash commands on a line of their own, which are checked first:
+	- /retest retries the PR's downstream generation and test build
+	- /retest-vcr retries the PR's VCR test build
+	- /gcbrun COMMIT_SHA approves the PR's held downstream generation and test build for
+	  COMMIT_SHA, which must be the PR's head commit
+	- /record TEST_REGEX re-records the VCR cassettes of the tests matching TEST_REGEX
+	The retest commands retry the most recent build of the Cloud Build trigger whose ID is
+	in the DOWNSTREAM_GENERATION_AND_TEST_TRIGGER or VCR_TEST_TRIGGER environment variable
+	for the PR's head commit. /record starts a build of the Cloud Build trigger whose ID is in
+	the VCR_RECORD_TRIGGER environment variable.
+
+	Only core contributors may run commands, except /gcbrun, which may only be run by
+	members of the maintainer team.
 	
 	The command expects the comment body to be provided in the COMMENT_BODY environment variable and also requires:
 	1. PR_NUMBER - The pull request number
@@ -45,10 +85,6 @@ var parseCommentCmd = &cobra.Command{
 		}
 		gh := github.NewClient(githubToken)
 
-		if gh.GetUserType(author) != github.CoreContributorUserType {
-			return fmt.Errorf("comment author %s is not a core contributor", author)
-		}
-
 		comment, ok := os.LookupEnv("COMMENT_BODY")
 		if !ok {
 			return fmt.Errorf("did not provide COMMENT_BODY environment variable")
@@ -58,12 +94,42 @@ var parseCommentCmd = &cobra.Command{
 			return nil
 		}
 
-		return execParseComment(prNumber, comment, gh)
+		if err := authorizeCommentAuthor(author, comment, gh); err != nil {
+			return err
+		}
+
+		cb := cloudbuild.NewClient()
+		return execParseComment(prNumber, author, comment, gh, cb)
 	},
 }
 
+// authorizeCommentAuthor checks that author may run the commands in comment. /gcbrun starts
+// cloud tests for untrusted code, so it's limited to maintainers by live team membership.
+func authorizeCommentAuthor(author, comment string, gh GithubClient) error {
+	if gcbrunRegex.MatchString(comment) {
+		if !gh.IsMaintainer(author) {
+			return fmt.Errorf("comment author %s is not a maintainer", author)
+		}
+		return nil
+	}
+	if gh.GetUserType(author) != github.CoreContributorUserType {
+		return fmt.Errorf("comment author %s is not a core contributor", author)
+	}
+	return nil
+}
+
 // execParseComment is the main router that finds and executes the first command
-func execParseComment(prNumber, comment string, gh GithubClient) error {
+func execParseComment(prNumber, author, comment string, gh GithubClient, cb CloudbuildClient) error {
+	if match := gcbrunRegex.FindStringSubmatch(comment); match != nil {
+		return handleGcbrun(prNumber, match[1], gh, cb)
+	}
+	if match := retestRegex.FindStringSubmatch(comment); match != nil {
+		return handleRetest(prNumber, match[1], gh, cb)
+	}
+	if match := recordRegex.FindStringSubmatch(comment); match != nil {
+		return handleRecord(prNumber, match[1], gh, cb)
+	}
+
 	// Find the first @modular-magician invocation in the comment
 	match := magicianInvocationRegex.FindStringSubmatch(comment)
 
@@ -86,15 +152,15 @@ func execParseComment(prNumber, comment string, gh GithubClient) error {
 	fmt.Printf("Processing command: %q\n", commandLine)
 
 	// Route to appropriate handler based on command pattern
-	return routeCommand(prNumber, commandLine, gh)
+	return routeCommand(prNumber, author, commandLine, gh)
 }
 
 // routeCommand determines which command handler to call based on the command pattern
-func routeCommand(prNumber, commandLine string, gh GithubClient) error {
+func routeCommand(prNumber, author, commandLine string, gh GithubClient) error {
 	// Check for reassign-reviewer command variants
 	if matches := reassignReviewerRegex.FindStringSubmatch(commandLine); matches != nil {
 		reviewer := strings.TrimSpace(matches[1])
-		return handleReassignReviewer(prNumber, reviewer, gh)
+		return handleReassignReviewer(prNumber, reviewer, author, gh)
 	}
 
 	// Add more command patterns here as needed
@@ -108,7 +174,7 @@ func routeCommand(prNumber, commandLine string, gh GithubClient) error {
 }
 
 // handleReassignReviewer processes the reassign-reviewer command
-func handleReassignReviewer(prNumber, reviewer string, gh GithubClient) error {
+func handleReassignReviewer(prNumber, reviewer, author string, gh GithubClient) error {
 	// The regex already extracted just the username without @
 	// and only allows valid GitHub username characters [a-zA-Z0-9-_]
 
@@ -119,7 +185,108 @@ func handleReassignReviewer(prNumber, reviewer string, gh GithubClient) error {
 		fmt.Printf(" (selecting random reviewer)")
 	}
 	fmt.Println()
-	return execReassignReviewer(prNumber, reviewer, gh)
+	return execReassignReviewer(prNumber, reviewer, author, gh)
+}
+
+// handleRetest processes the /retest and /retest-vcr commands, commenting with the result
+func handleRetest(prNumber, command string, gh GithubClient, cb CloudbuildClient) error {
+	triggerEnv := retestTriggers[command]
+	triggerId, ok := os.LookupEnv(triggerEnv)
+	if !ok || triggerId == "" {
+		return fmt.Errorf("did not provide %s environment variable", triggerEnv)
+	}
+
+	pullRequest, err := gh.GetPullRequest(prNumber)
+	if err != nil {
+		return err
+	}
+	if pullRequest.HeadSha == "" {
+		return fmt.Errorf("no head commit found for PR #%s", prNumber)
+	}
+
+	fmt.Printf("Retrying %s build for PR #%s at %s\n", command, prNumber, pullRequest.HeadSha)
+	buildId, err := cb.RetryBuild(triggerId, pullRequest.HeadSha)
+	if err != nil {
+		comment := fmt.Sprintf("Unable to `/%s` commit %s: %s", command, pullRequest.HeadSha, err)
+		if postErr := gh.PostComment(prNumber, comment); postErr != nil {
+			fmt.Printf("Failed to post comment: %s\n", postErr)
+		}
+		return err
+	}
+
+	buildURL := fmt.Sprintf("https://console.cloud.google.com/cloud-build/builds;region=global/%s?project=%s", buildId, cloudbuild.PROJECT_ID)
+	return gh.PostComment(prNumber, fmt.Sprintf("Retrying the `/%s` build for commit %s: [%s](%s)", command, pullRequest.HeadSha, buildId, buildURL))
+}
+
+// handleGcbrun processes the /gcbrun command, approving the held build for the commit the maintainer
+// reviewed. It refuses if the PR's head commit has moved on, since the new commits haven't been reviewed.
+func handleGcbrun(prNumber, reviewedSha string, gh GithubClient, cb CloudbuildClient) error {
+	reviewedSha = strings.ToLower(reviewedSha)
+	if !commitShaRegex.MatchString(reviewedSha) {
+		return gh.PostComment(prNumber, "Unable to `/gcbrun`: comment `/gcbrun COMMIT_SHA` with the commit you reviewed to run its cloud tests.")
+	}
+
+	pullRequest, err := gh.GetPullRequest(prNumber)
+	if err != nil {
+		return err
+	}
+	if pullRequest.HeadSha == "" {
+		return fmt.Errorf("no head commit found for PR #%s", prNumber)
+	}
+	if !strings.HasPrefix(pullRequest.HeadSha, reviewedSha) {
+		fmt.Printf("Not approving cloud tests for PR #%s: %s is not the head commit %s\n", prNumber, reviewedSha, pullRequest.HeadSha)
+		return gh.PostComment(prNumber, fmt.Sprintf("Unable to `/gcbrun` commit %s: the latest commit on this PR is %s. "+
+			"Review the new changes and comment `/gcbrun %s` to run the tests.", reviewedSha, pullRequest.HeadSha, pullRequest.HeadSha))
+	}
+
+	fmt.Printf("Approving cloud tests for PR #%s at %s\n", prNumber, pullRequest.HeadSha)
+	if err := cb.ApproveDownstreamGenAndTest(prNumber, pullRequest.HeadSha); err != nil {
+		comment := fmt.Sprintf("Unable to `/gcbrun` commit %s: %s", pullRequest.HeadSha, err)
+		if postErr := gh.PostComment(prNumber, comment); postErr != nil {
+			fmt.Printf("Failed to post comment: %s\n", postErr)
+		}
+		return err
+	}
+	if err := gh.RemoveLabel(prNumber, awaitingApprovalLabel); err != nil {
+		fmt.Printf("Failed to remove %s label: %s\n", awaitingApprovalLabel, err)
+	}
+	return gh.PostComment(prNumber, fmt.Sprintf("Approved cloud tests for commit %s.", pullRequest.HeadSha))
+}
+
+// handleRecord processes the /record command, starting a build that re-records the cassettes of the
+// tests matching testRegex against the PR's head commit
+func handleRecord(prNumber, testRegex string, gh GithubClient, cb CloudbuildClient) error {
+	if _, err := regexp.Compile(testRegex); err != nil {
+		return gh.PostComment(prNumber, fmt.Sprintf("Unable to `/record`: `%s` is not a valid regular expression: %s", testRegex, err))
+	}
+	triggerId, ok := os.LookupEnv(recordTriggerEnv)
+	if !ok || triggerId == "" {
+		return fmt.Errorf("did not provide %s environment variable", recordTriggerEnv)
+	}
+
+	pullRequest, err := gh.GetPullRequest(prNumber)
+	if err != nil {
+		return err
+	}
+	if pullRequest.HeadSha == "" {
+		return fmt.Errorf("no head commit found for PR #%s", prNumber)
+	}
+
+	fmt.Printf("Recording tests matching %q for PR #%s at %s\n", testRegex, prNumber, pullRequest.HeadSha)
+	buildId, err := cb.RunTrigger(triggerId, pullRequest.HeadSha, map[string]string{
+		"_PR_NUMBER":  prNumber,
+		"_TEST_REGEX": testRegex,
+	})
+	if err != nil {
+		comment := fmt.Sprintf("Unable to `/record` commit %s: %s", pullRequest.HeadSha, err)
+		if postErr := gh.PostComment(prNumber, comment); postErr != nil {
+			fmt.Printf("Failed to post comment: %s\n", postErr)
+		}
+		return err
+	}
+
+	buildURL := fmt.Sprintf("https://console.cloud.google.com/cloud-build/builds;region=global/%s?project=%s", buildId, cloudbuild.PROJECT_ID)
+	return gh.PostComment(prNumber, fmt.Sprintf("Recording tests matching `%s` for commit %s: [%s](%s). The results will be posted here when it finishes.", testRegex, pullRequest.HeadSha, buildId, buildURL))
 }
 
 func init() {
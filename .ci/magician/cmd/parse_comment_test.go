@@ -319,7 +319,7 @@ func TestExecParseComment(t *testing.T) {
 				pullRequestComments: tc.existingComments,
 			}
 
-			err := execParseComment("1", tc.comment, gh)
+			err := execParseComment("1", "maintainer", tc.comment, gh, &mockCloudBuild{calledMethods: make(map[string][][]any)})
 			if err != nil {
 				t.Fatalf("execParseComment failed: %v", err)
 			}
@@ -374,3 +374,223 @@ func TestExecParseComment(t *testing.T) {
 		})
 	}
 }
+
+func TestExecParseCommentRetest(t *testing.T) {
+	t.Setenv("DOWNSTREAM_GENERATION_AND_TEST_TRIGGER", "downstream-trigger")
+	t.Setenv("VCR_TEST_TRIGGER", "vcr-trigger")
+
+	cases := map[string]struct {
+		comment       string
+		expectTrigger string
+	}{
+		"retest": {
+			comment:       "/retest",
+			expectTrigger: "downstream-trigger",
+		},
+		"retest-vcr": {
+			comment:       "/retest-vcr",
+			expectTrigger: "vcr-trigger",
+		},
+		"retest on its own line in a longer comment": {
+			comment:       "Looks like a flake.\n\n/retest-vcr\n",
+			expectTrigger: "vcr-trigger",
+		},
+		"retest within a sentence is ignored": {
+			comment: "Could you /retest this?",
+		},
+		"unknown slash command is ignored": {
+			comment: "/retest-all",
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			gh := &mockGithub{
+				pullRequest: github.PullRequest{
+					User:    github.User{Login: "author"},
+					HeadSha: "abc123",
+				},
+				calledMethods: make(map[string][][]any),
+			}
+			cb := &mockCloudBuild{calledMethods: make(map[string][][]any)}
+
+			if err := execParseComment("1", "maintainer", tc.comment, gh, cb); err != nil {
+				t.Fatalf("execParseComment failed: %v", err)
+			}
+
+			if tc.expectTrigger == "" {
+				assert.Empty(t, cb.calledMethods["RetryBuild"])
+				assert.Empty(t, gh.calledMethods["PostComment"])
+				return
+			}
+			assert.Equal(t, [][]any{{tc.expectTrigger, "abc123"}}, cb.calledMethods["RetryBuild"])
+			if assert.Len(t, gh.calledMethods["PostComment"], 1) {
+				assert.Contains(t, gh.calledMethods["PostComment"][0][1], "retried-build")
+			}
+		})
+	}
+}
+
+func TestExecParseCommentGcbrun(t *testing.T) {
+	cases := map[string]struct {
+		comment       string
+		expectApprove bool
+		expectComment string
+	}{
+		"gcbrun with the head commit": {
+			comment:       "LGTM\n/gcbrun 0123456789abcdef0123456789abcdef01234567\n",
+			expectApprove: true,
+			expectComment: "Approved cloud tests for commit 0123456789abcdef0123456789abcdef01234567.",
+		},
+		"gcbrun with an abbreviated head commit": {
+			comment:       "/gcbrun 0123456",
+			expectApprove: true,
+			expectComment: "Approved cloud tests for commit 0123456789abcdef0123456789abcdef01234567.",
+		},
+		"gcbrun with an older commit is refused": {
+			comment:       "/gcbrun fedcba9",
+			expectComment: "the latest commit on this PR is 0123456789abcdef0123456789abcdef01234567",
+		},
+		"gcbrun without a commit is refused": {
+			comment:       "/gcbrun",
+			expectComment: "comment `/gcbrun COMMIT_SHA`",
+		},
+		"gcbrun with something other than a commit is refused": {
+			comment:       "/gcbrun main",
+			expectComment: "comment `/gcbrun COMMIT_SHA`",
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			gh := &mockGithub{
+				pullRequest: github.PullRequest{
+					User:    github.User{Login: "author"},
+					HeadSha: "0123456789abcdef0123456789abcdef01234567",
+				},
+				calledMethods: make(map[string][][]any),
+			}
+			cb := &mockCloudBuild{calledMethods: make(map[string][][]any)}
+
+			if err := execParseComment("1", "maintainer", tc.comment, gh, cb); err != nil {
+				t.Fatalf("execParseComment failed: %v", err)
+			}
+
+			if tc.expectApprove {
+				assert.Equal(t, [][]any{{"1", "0123456789abcdef0123456789abcdef01234567"}}, cb.calledMethods["ApproveDownstreamGenAndTest"])
+				assert.Equal(t, [][]any{{"1", "awaiting-approval"}}, gh.calledMethods["RemoveLabel"])
+			} else {
+				assert.Empty(t, cb.calledMethods["ApproveDownstreamGenAndTest"])
+				assert.Empty(t, gh.calledMethods["RemoveLabel"])
+			}
+			if assert.Len(t, gh.calledMethods["PostComment"], 1) {
+				assert.Contains(t, gh.calledMethods["PostComment"][0][1], tc.expectComment)
+			}
+		})
+	}
+}
+
+func TestExecParseCommentRecord(t *testing.T) {
+	t.Setenv("VCR_RECORD_TRIGGER", "record-trigger")
+
+	cases := map[string]struct {
+		comment       string
+		expectRegex   string
+		expectComment string
+	}{
+		"record": {
+			comment:       "/record TestAccComputeInstance_basic",
+			expectRegex:   "TestAccComputeInstance_basic",
+			expectComment: "triggered-build",
+		},
+		"record with a regex on its own line": {
+			comment:       "These cassettes are stale.\n/record TestAccCompute(Instance|Disk)_.*\n",
+			expectRegex:   "TestAccCompute(Instance|Disk)_.*",
+			expectComment: "triggered-build",
+		},
+		"invalid regex is reported": {
+			comment:       "/record TestAcc(",
+			expectComment: "not a valid regular expression",
+		},
+		"record without a regex is ignored": {
+			comment: "/record",
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			gh := &mockGithub{
+				pullRequest: github.PullRequest{
+					User:    github.User{Login: "author"},
+					HeadSha: "abc123",
+				},
+				calledMethods: make(map[string][][]any),
+			}
+			cb := &mockCloudBuild{calledMethods: make(map[string][][]any)}
+
+			if err := execParseComment("1", "maintainer", tc.comment, gh, cb); err != nil {
+				t.Fatalf("execParseComment failed: %v", err)
+			}
+
+			if tc.expectRegex == "" {
+				assert.Empty(t, cb.calledMethods["RunTrigger"])
+			} else {
+				substitutions := map[string]string{"_PR_NUMBER": "1", "_TEST_REGEX": tc.expectRegex}
+				assert.Equal(t, [][]any{{"record-trigger", "abc123", substitutions}}, cb.calledMethods["RunTrigger"])
+			}
+			if tc.expectComment == "" {
+				assert.Empty(t, gh.calledMethods["PostComment"])
+			} else if assert.Len(t, gh.calledMethods["PostComment"], 1) {
+				assert.Contains(t, gh.calledMethods["PostComment"][0][1], tc.expectComment)
+			}
+		})
+	}
+}
+
+func TestAuthorizeCommentAuthor(t *testing.T) {
+	cases := map[string]struct {
+		author    string
+		comment   string
+		userType  github.UserType
+		wantError bool
+	}{
+		"maintainer can gcbrun": {
+			author:  "maintainer",
+			comment: "/gcbrun 0123456",
+		},
+		"core contributor outside the maintainer team cannot gcbrun": {
+			author:    "core-contributor",
+			comment:   "/gcbrun 0123456",
+			userType:  github.CoreContributorUserType,
+			wantError: true,
+		},
+		"core contributor can run other commands": {
+			author:   "core-contributor",
+			comment:  "@modular-magician reassign-reviewer",
+			userType: github.CoreContributorUserType,
+		},
+		"community contributor cannot run other commands": {
+			author:    "community-contributor",
+			comment:   "/retest",
+			userType:  github.CommunityUserType,
+			wantError: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			gh := &mockGithub{
+				userType:      tc.userType,
+				maintainers:   []string{"maintainer"},
+				calledMethods: make(map[string][][]any),
+			}
+
+			err := authorizeCommentAuthor(tc.author, tc.comment, gh)
+			if tc.wantError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
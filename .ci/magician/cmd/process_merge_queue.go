@@ -0,0 +1,232 @@
+/*
+* Copyright 2026 Google LLC. All Rights Reserved.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+package cmd
+
+import (
+	"fmt"
+	"magician/github"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	automergeLabel = "automerge"
+	mergeQueueBase = "main"
+)
+
+var mergeQueueMaxWait time.Duration
+
+type mergeQueueAction int
+
+const (
+	// The PR isn't ready yet; move on to the next one.
+	mergeQueueSkip mergeQueueAction = iota
+	// The PR is ready; merge it.
+	mergeQueueMerge
+	// The base branch has moved; update the PR's branch and wait for its checks.
+	mergeQueueUpdateBranch
+	// The PR can't be merged without the author's help; take it out of the queue.
+	mergeQueueDequeue
+	// The PR is approved but can't be merged yet; stop if its required checks are still running,
+	// so later PRs don't merge ahead of it.
+	mergeQueueWait
+)
+
+// processMergeQueueCmd represents the process-merge-queue command
+var processMergeQueueCmd = &cobra.Command{
+	Use:   "process-merge-queue",
+	Short: "Merges approved PRs with the automerge label in order",
+	Long: `This command merges open PRs against main that have the 'automerge' label, oldest first.
+
+	A PR is ready to merge once a core reviewer has approved it, no core reviewer has outstanding
+	requested changes, and GitHub reports that its checks have passed. At most one PR is merged
+	per run, since merging moves the base branch for the rest of the queue.
+
+	The command performs the following steps for each queued PR in order:
+	1. If it is ready to merge, squash merge it and stop.
+	2. If its branch is behind main, update the branch so its checks run against the new base,
+	   and stop until they finish.
+	3. If it has merge conflicts, remove the 'automerge' label and ask the author to rebase.
+	4. If it is approved and its required checks are still running, stop so that it keeps its
+	   place. PRs whose cloud tests are awaiting maintainer approval don't hold the queue, and
+	   neither do PRs whose head commit is older than --max-wait.
+	5. Otherwise, skip it so it doesn't hold up the rest of the queue.
+	`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		githubToken, ok := lookupGithubTokenOrFallback("GITHUB_TOKEN_MAGIC_MODULES")
+		if !ok {
+			return fmt.Errorf("did not provide GITHUB_TOKEN_MAGIC_MODULES or GITHUB_TOKEN environment variables")
+		}
+		gh := github.NewClient(githubToken)
+		return execProcessMergeQueue(gh, mergeQueueMaxWait, time.Now())
+	},
+}
+
+func execProcessMergeQueue(gh GithubClient, maxWait time.Duration, now time.Time) error {
+	pullRequests, err := gh.GetPullRequests("open", mergeQueueBase, "created", "asc")
+	if err != nil {
+		return err
+	}
+
+	for _, queued := range pullRequests {
+		if !hasLabel(queued.Labels, automergeLabel) {
+			continue
+		}
+		prNumber := strconv.Itoa(queued.Number)
+
+		// Listed PRs don't include their mergeable state.
+		pullRequest, err := gh.GetPullRequest(prNumber)
+		if err != nil {
+			return err
+		}
+		reviews, err := gh.GetPullRequestReviews(prNumber)
+		if err != nil {
+			return err
+		}
+
+		switch nextMergeQueueAction(pullRequest, reviews) {
+		case mergeQueueMerge:
+			fmt.Printf("PR %s: merging\n", prNumber)
+			return gh.MergeRepoPullRequest(prNumber, pullRequest.HeadSha)
+		case mergeQueueUpdateBranch:
+			fmt.Printf("PR %s: behind main, updating branch\n", prNumber)
+			if err := gh.UpdatePullRequestBranch(prNumber, pullRequest.HeadSha); err != nil {
+				// The branch can't be updated if the author doesn't allow edits by maintainers.
+				fmt.Printf("PR %s: failed to update branch: %s\n", prNumber, err)
+				return dequeue(prNumber, "couldn't be brought up to date with `main`. Please merge or rebase onto `main`", gh)
+			}
+			return nil
+		case mergeQueueDequeue:
+			fmt.Printf("PR %s: has merge conflicts, removing from the queue\n", prNumber)
+			if err := dequeue(prNumber, "has merge conflicts with `main`. Please rebase", gh); err != nil {
+				return err
+			}
+		case mergeQueueWait:
+			wait, err := waitForRequiredChecks(prNumber, pullRequest.HeadSha, maxWait, now, gh)
+			if err != nil {
+				return err
+			}
+			if wait {
+				return nil
+			}
+		default:
+			fmt.Printf("PR %s: not ready to merge (%s)\n", prNumber, pullRequest.MergeableState)
+		}
+	}
+	return nil
+}
+
+// nextMergeQueueAction decides what to do with a queued PR based on its reviews and GitHub's
+// summary of its mergeability.
+func nextMergeQueueAction(pullRequest github.PullRequest, reviews []github.PullRequestReview) mergeQueueAction {
+	if pullRequest.Draft {
+		return mergeQueueSkip
+	}
+	if pullRequest.MergeableState == "dirty" {
+		return mergeQueueDequeue
+	}
+	if !approvedByCoreReviewer(pullRequest.User.Login, reviews) {
+		return mergeQueueSkip
+	}
+	switch pullRequest.MergeableState {
+	case "clean":
+		return mergeQueueMerge
+	case "behind":
+		return mergeQueueUpdateBranch
+	}
+	// Held cloud tests only run once a maintainer approves them, which can take days.
+	if hasLabel(pullRequest.Labels, awaitingApprovalLabel) {
+		return mergeQueueSkip
+	}
+	return mergeQueueWait
+}
+
+// waitForRequiredChecks reports whether the queue should stop at an approved PR, because the
+// checks required to merge it are still running. A PR stops waiting once its head commit is
+// older than maxWait, so a stuck check can't hold up the rest of the queue.
+func waitForRequiredChecks(prNumber, headSha string, maxWait time.Duration, now time.Time, gh GithubClient) (bool, error) {
+	pending, err := gh.GetPendingRequiredChecks(mergeQueueBase, headSha)
+	if err != nil {
+		return false, err
+	}
+	if len(pending) == 0 {
+		fmt.Printf("PR %s: not ready to merge, required checks have finished\n", prNumber)
+		return false, nil
+	}
+	committed, err := gh.GetCommitDate(headSha)
+	if err != nil {
+		return false, err
+	}
+	if waited := now.Sub(committed); waited > maxWait {
+		fmt.Printf("PR %s: required checks still running after %s, skipping: %s\n", prNumber, waited.Round(time.Minute), strings.Join(pending, ", "))
+		return false, nil
+	}
+	fmt.Printf("PR %s: waiting for required checks to finish: %s\n", prNumber, strings.Join(pending, ", "))
+	return true, nil
+}
+
+// approvedByCoreReviewer reports whether a core reviewer other than the author has approved and
+// no core reviewer's latest review requests changes. reviews must be in submission order.
+func approvedByCoreReviewer(author string, reviews []github.PullRequestReview) bool {
+	latest := make(map[string]string)
+	for _, review := range reviews {
+		login := review.User.Login
+		if login == author || !github.IsCoreReviewer(login) {
+			continue
+		}
+		switch review.State {
+		case "APPROVED", "CHANGES_REQUESTED", "DISMISSED":
+			latest[login] = review.State
+		}
+	}
+
+	approved := false
+	for _, state := range latest {
+		switch state {
+		case "CHANGES_REQUESTED":
+			return false
+		case "APPROVED":
+			approved = true
+		}
+	}
+	return approved
+}
+
+func dequeue(prNumber, reason string, gh GithubClient) error {
+	if err := gh.RemoveLabel(prNumber, automergeLabel); err != nil {
+		return err
+	}
+	comment := fmt.Sprintf("This PR was removed from the merge queue because it %s, then add the `%s` label again.", reason, automergeLabel)
+	return gh.PostComment(prNumber, comment)
+}
+
+func hasLabel(labels []github.Label, name string) bool {
+	for _, label := range labels {
+		if label.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func init() {
+	rootCmd.AddCommand(processMergeQueueCmd)
+	processMergeQueueCmd.Flags().DurationVar(&mergeQueueMaxWait, "max-wait", 4*time.Hour, "Stop holding the queue for a PR's required checks once its head commit is older than this")
+}
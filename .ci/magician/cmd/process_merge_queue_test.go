@@ -0,0 +1,269 @@
+/*
+* Copyright 2026 Google LLC. All Rights Reserved.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"magician/github"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNextMergeQueueAction(t *testing.T) {
+	availableReviewers := github.AvailableReviewers(nil)
+	if len(availableReviewers) < 2 {
+		t.Fatalf("not enough available reviewers (%v) to run TestNextMergeQueueAction (need at least 2)", availableReviewers)
+	}
+	firstReviewer := availableReviewers[0]
+	secondReviewer := availableReviewers[1]
+
+	approved := []github.PullRequestReview{{User: github.User{Login: firstReviewer}, State: "APPROVED"}}
+	cases := map[string]struct {
+		pullRequest github.PullRequest
+		reviews     []github.PullRequestReview
+		want        mergeQueueAction
+	}{
+		"approved and clean is merged": {
+			pullRequest: github.PullRequest{MergeableState: "clean"},
+			reviews:     approved,
+			want:        mergeQueueMerge,
+		},
+		"approved and behind updates the branch": {
+			pullRequest: github.PullRequest{MergeableState: "behind"},
+			reviews:     approved,
+			want:        mergeQueueUpdateBranch,
+		},
+		"conflicts are dequeued": {
+			pullRequest: github.PullRequest{MergeableState: "dirty"},
+			want:        mergeQueueDequeue,
+		},
+		"approved and blocked waits for checks": {
+			pullRequest: github.PullRequest{MergeableState: "blocked"},
+			reviews:     approved,
+			want:        mergeQueueWait,
+		},
+		"approved with tests awaiting approval is skipped": {
+			pullRequest: github.PullRequest{MergeableState: "blocked", Labels: []github.Label{{Name: "awaiting-approval"}}},
+			reviews:     approved,
+			want:        mergeQueueSkip,
+		},
+		"unapproved and blocked is skipped": {
+			pullRequest: github.PullRequest{MergeableState: "blocked"},
+			want:        mergeQueueSkip,
+		},
+		"drafts are skipped": {
+			pullRequest: github.PullRequest{MergeableState: "clean", Draft: true},
+			reviews:     approved,
+			want:        mergeQueueSkip,
+		},
+		"unapproved is skipped": {
+			pullRequest: github.PullRequest{MergeableState: "clean"},
+			reviews:     []github.PullRequestReview{{User: github.User{Login: firstReviewer}, State: "COMMENTED"}},
+			want:        mergeQueueSkip,
+		},
+		"approval by a non-core reviewer is skipped": {
+			pullRequest: github.PullRequest{MergeableState: "clean"},
+			reviews:     []github.PullRequestReview{{User: github.User{Login: "someone"}, State: "APPROVED"}},
+			want:        mergeQueueSkip,
+		},
+		"approval by the author is skipped": {
+			pullRequest: github.PullRequest{MergeableState: "clean", User: github.User{Login: firstReviewer}},
+			reviews:     approved,
+			want:        mergeQueueSkip,
+		},
+		"outstanding requested changes are skipped": {
+			pullRequest: github.PullRequest{MergeableState: "clean"},
+			reviews: []github.PullRequestReview{
+				{User: github.User{Login: firstReviewer}, State: "APPROVED"},
+				{User: github.User{Login: secondReviewer}, State: "CHANGES_REQUESTED"},
+			},
+			want: mergeQueueSkip,
+		},
+		"later approval replaces requested changes": {
+			pullRequest: github.PullRequest{MergeableState: "clean"},
+			reviews: []github.PullRequestReview{
+				{User: github.User{Login: firstReviewer}, State: "CHANGES_REQUESTED"},
+				{User: github.User{Login: firstReviewer}, State: "COMMENTED"},
+				{User: github.User{Login: firstReviewer}, State: "APPROVED"},
+			},
+			want: mergeQueueMerge,
+		},
+		"dismissed approval is skipped": {
+			pullRequest: github.PullRequest{MergeableState: "clean"},
+			reviews: []github.PullRequestReview{
+				{User: github.User{Login: firstReviewer}, State: "APPROVED"},
+				{User: github.User{Login: firstReviewer}, State: "DISMISSED"},
+			},
+			want: mergeQueueSkip,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.want, nextMergeQueueAction(tc.pullRequest, tc.reviews))
+		})
+	}
+}
+
+func TestExecProcessMergeQueue(t *testing.T) {
+	availableReviewers := github.AvailableReviewers(nil)
+	if len(availableReviewers) < 1 {
+		t.Fatalf("not enough available reviewers (%v) to run TestExecProcessMergeQueue (need at least 1)", availableReviewers)
+	}
+	approved := []github.PullRequestReview{{User: github.User{Login: availableReviewers[0]}, State: "APPROVED"}}
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	cases := map[string]struct {
+		pullRequest   github.PullRequest
+		pendingChecks []string
+		commitDate    time.Time
+		expectMethods map[string][][]any
+	}{
+		"unlabeled PR is ignored": {
+			pullRequest:   github.PullRequest{Number: 1, MergeableState: "clean"},
+			expectMethods: map[string][][]any{},
+		},
+		"ready PR is merged": {
+			pullRequest: github.PullRequest{
+				Number:         1,
+				HeadSha:        "abc123",
+				MergeableState: "clean",
+				Labels:         []github.Label{{Name: "automerge"}},
+			},
+			expectMethods: map[string][][]any{
+				"MergeRepoPullRequest": {{"1", "abc123"}},
+			},
+		},
+		"behind PR is updated": {
+			pullRequest: github.PullRequest{
+				Number:         1,
+				HeadSha:        "abc123",
+				MergeableState: "behind",
+				Labels:         []github.Label{{Name: "automerge"}},
+			},
+			expectMethods: map[string][][]any{
+				"UpdatePullRequestBranch": {{"1", "abc123"}},
+			},
+		},
+		"approved PR with pending required checks holds the queue": {
+			pullRequest: github.PullRequest{
+				Number:         1,
+				HeadSha:        "abc123",
+				MergeableState: "blocked",
+				Labels:         []github.Label{{Name: "automerge"}},
+			},
+			pendingChecks: []string{"presubmit"},
+			commitDate:    now.Add(-time.Hour),
+			expectMethods: map[string][][]any{},
+		},
+		"approved PR without pending required checks is skipped": {
+			pullRequest: github.PullRequest{
+				Number:         1,
+				HeadSha:        "abc123",
+				MergeableState: "blocked",
+				Labels:         []github.Label{{Name: "automerge"}},
+			},
+			expectMethods: map[string][][]any{},
+		},
+		"approved PR waiting longer than the limit is skipped": {
+			pullRequest: github.PullRequest{
+				Number:         1,
+				HeadSha:        "abc123",
+				MergeableState: "blocked",
+				Labels:         []github.Label{{Name: "automerge"}},
+			},
+			pendingChecks: []string{"presubmit"},
+			commitDate:    now.Add(-5 * time.Hour),
+			expectMethods: map[string][][]any{},
+		},
+		"conflicting PR is dequeued": {
+			pullRequest: github.PullRequest{
+				Number:         1,
+				MergeableState: "dirty",
+				Labels:         []github.Label{{Name: "automerge"}},
+			},
+			expectMethods: map[string][][]any{
+				"RemoveLabel": {{"1", "automerge"}},
+				"PostComment": {{"1", "This PR was removed from the merge queue because it has merge conflicts with `main`. Please rebase, then add the `automerge` label again."}},
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			gh := &mockGithub{
+				pullRequest:   tc.pullRequest,
+				reviews:       approved,
+				pendingChecks: tc.pendingChecks,
+				commitDate:    tc.commitDate,
+				calledMethods: make(map[string][][]any),
+			}
+
+			if err := execProcessMergeQueue(gh, 4*time.Hour, now); err != nil {
+				t.Fatalf("execProcessMergeQueue failed: %v", err)
+			}
+
+			for _, method := range []string{"MergeRepoPullRequest", "UpdatePullRequestBranch", "RemoveLabel", "PostComment"} {
+				assert.Equal(t, tc.expectMethods[method], gh.calledMethods[method], method)
+			}
+		})
+	}
+}
+
+func TestWaitForRequiredChecks(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	cases := map[string]struct {
+		pendingChecks []string
+		commitDate    time.Time
+		want          bool
+	}{
+		"pending required checks wait": {
+			pendingChecks: []string{"presubmit"},
+			commitDate:    now.Add(-time.Hour),
+			want:          true,
+		},
+		"finished required checks don't wait": {
+			commitDate: now.Add(-time.Hour),
+			want:       false,
+		},
+		"pending required checks past the limit don't wait": {
+			pendingChecks: []string{"presubmit"},
+			commitDate:    now.Add(-5 * time.Hour),
+			want:          false,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			gh := &mockGithub{
+				pendingChecks: tc.pendingChecks,
+				commitDate:    tc.commitDate,
+				calledMethods: make(map[string][][]any),
+			}
+
+			got, err := waitForRequiredChecks("1", "abc123", 4*time.Hour, now, gh)
+			if err != nil {
+				t.Fatalf("waitForRequiredChecks failed: %v", err)
+			}
+			assert.Equal(t, tc.want, got)
+			assert.Equal(t, [][]any{{"main", "abc123"}}, gh.calledMethods["GetPendingRequiredChecks"])
+		})
+	}
+}
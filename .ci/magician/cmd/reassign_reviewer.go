@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"magician/github"
 	"os"
+	"time"
 
 	"github.com/spf13/cobra"
 )
@@ -27,7 +28,7 @@ import (
 // reassignReviewerCmd represents the reassignReviewer command
 var reassignReviewerCmd = &cobra.Command{
 	Use:   "reassign-reviewer PR_NUMBER [REVIEWER]",
-	Short: "Reassigns primary reviewer to the given reviewer or a random reviewer if none given",
+	Short: "Reassigns primary reviewer to the given reviewer or the least loaded reviewer if none given",
 	Long: `This command reassigns reviewers when invoked via a comment on a pull request.
 
 	The command expects the following PR details as arguments:
@@ -37,7 +38,8 @@ var reassignReviewerCmd = &cobra.Command{
 
 
 	It then performs the following operations:
-	1. Updates the reviewer comment to reflect the new primary reviewer.
+	1. Updates the reviewer comment to reflect the new primary reviewer, recording the
+	   reassignment and who requested it in the comment's assignment history.
 	2. Requests a review from the new primary reviewer.
 	`,
 	Args: cobra.MinimumNArgs(2),
@@ -60,11 +62,13 @@ var reassignReviewerCmd = &cobra.Command{
 		if len(args) > 2 {
 			newPrimaryReviewer = args[2]
 		}
-		return execReassignReviewer(prNumber, newPrimaryReviewer, gh)
+		return execReassignReviewer(prNumber, newPrimaryReviewer, author, gh)
 	},
 }
 
-func execReassignReviewer(prNumber, newPrimaryReviewer string, gh GithubClient) error {
+// execReassignReviewer reassigns the PR's primary reviewer on behalf of requester, overriding the
+// reviewer schedule.
+func execReassignReviewer(prNumber, newPrimaryReviewer, requester string, gh GithubClient) error {
 	pullRequest, err := gh.GetPullRequest(prNumber)
 	if err != nil {
 		return err
@@ -76,7 +80,7 @@ func execReassignReviewer(prNumber, newPrimaryReviewer string, gh GithubClient)
 
 	reviewerComment, currentReviewer := github.FindReviewerComment(comments)
 	if newPrimaryReviewer == "" {
-		newPrimaryReviewer = github.GetRandomReviewer([]string{currentReviewer, pullRequest.User.Login})
+		newPrimaryReviewer = github.ChooseLeastLoadedReviewer([]string{currentReviewer, pullRequest.User.Login}, gh.GetOpenReviewCounts)
 	}
 
 	if newPrimaryReviewer == "" {
@@ -86,8 +90,13 @@ func execReassignReviewer(prNumber, newPrimaryReviewer string, gh GithubClient)
 		return fmt.Errorf("primary reviewer is already %s", newPrimaryReviewer)
 	}
 
-	fmt.Println("New primary reviewer is ", newPrimaryReviewer)
-	comment := github.FormatReviewerComment(newPrimaryReviewer)
+	fmt.Printf("New primary reviewer is %s, requested by %s\n", newPrimaryReviewer, requester)
+	history := append(github.ReviewerAssignmentHistory(reviewerComment.Body), github.ReviewerAssignment{
+		Date:     time.Now(),
+		Reviewer: newPrimaryReviewer,
+		Reason:   "reassigned by " + requester,
+	})
+	comment := github.FormatReviewerComment(newPrimaryReviewer, history...)
 
 	if currentReviewer == "" {
 		fmt.Println("No reviewer comment found, creating one")
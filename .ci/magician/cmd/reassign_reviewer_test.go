@@ -72,7 +72,7 @@ func TestExecReassignReviewer(t *testing.T) {
 				pullRequestComments: tc.comments,
 			}
 
-			err := execReassignReviewer("1", tc.newPrimaryReviewer, gh)
+			err := execReassignReviewer("1", tc.newPrimaryReviewer, "maintainer", gh)
 			if err != nil {
 				t.Fatalf("execReassignReviewer failed: %v", err)
 			}
@@ -99,6 +99,21 @@ func TestExecReassignReviewer(t *testing.T) {
 					assert.Contains(t, tc.expectRemovedReviewers, reviewer)
 				}
 			}
+
+			var comments []any
+			for _, args := range gh.calledMethods["PostComment"] {
+				comments = append(comments, args[1])
+			}
+			for _, args := range gh.calledMethods["UpdateComment"] {
+				comments = append(comments, args[1])
+			}
+			if assert.Len(t, comments, 1) {
+				history := github.ReviewerAssignmentHistory(comments[0].(string))
+				if assert.Len(t, history, 1) {
+					assert.Equal(t, "reassigned by maintainer", history[0].Reason)
+					assert.Equal(t, assignedReviewers, []string{history[0].Reviewer})
+				}
+			}
 		})
 	}
 }
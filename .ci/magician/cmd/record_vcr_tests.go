@@ -0,0 +1,254 @@
+/*
+* Copyright 2026 Google LLC. All Rights Reserved.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+package cmd
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+
+	"github.com/spf13/cobra"
+
+	"magician/exec"
+	"magician/github"
+	"magician/provider"
+	"magician/source"
+	"magician/vcr"
+)
+
+// The most tests a single /record can re-record, so a broad regex can't start a full recording run.
+const maxRecordedTests = 50
+
+var accTestFuncRegexp = regexp.MustCompile(`(?m)^(.+_test\.go):func (TestAcc\w+)\(t \*testing\.T\)`)
+
+var recordVCRTestsCmd = &cobra.Command{
+	Use:   "record-vcr-tests",
+	Short: "Re-record VCR cassettes for tests matching a regex",
+	Long: `This command runs when a core contributor comments /record TEST_REGEX on a pull request.
+
+It expects the following arguments:
+	1. PR number
+	2. SHA of the latest magic-modules commit
+	3. Build ID
+	4. Project ID where Cloud Builds are located
+	5. Build step number
+
+It then performs the following operations:
+	1. Finds the beta provider acceptance tests whose names match the regex in the TEST_REGEX
+	   environment variable in the PR's generated code.
+	2. Runs them in RECORDING mode and uploads the new cassettes for the PR.
+	3. Runs the tests that passed in REPLAYING mode to check the new cassettes.
+	4. Reports the results in a comment and the VCR-record status check.
+
+The following environment variables are required:
+` + listTTVRequiredEnvironmentVariables() + `	TEST_REGEX
+`,
+	Args: cobra.ExactArgs(5),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		env := make(map[string]string)
+		for _, ev := range ttvRequiredEnvironmentVariables {
+			val, ok := os.LookupEnv(ev)
+			if !ok {
+				return fmt.Errorf("did not provide %s environment variable", ev)
+			}
+			env[ev] = val
+		}
+		for _, ev := range ttvOptionalEnvironmentVariables {
+			val, ok := os.LookupEnv(ev)
+			if ok {
+				env[ev] = val
+			} else {
+				fmt.Printf("🟡 Did not provide %s environment variable\n", ev)
+			}
+		}
+
+		for _, tokenName := range []string{"GITHUB_TOKEN_DOWNSTREAMS", "GITHUB_TOKEN_MAGIC_MODULES"} {
+			val, ok := lookupGithubTokenOrFallback(tokenName)
+			if !ok {
+				return fmt.Errorf("did not provide %s or GITHUB_TOKEN environment variable", tokenName)
+			}
+			env[tokenName] = val
+		}
+
+		testRegex, ok := os.LookupEnv("TEST_REGEX")
+		if !ok || testRegex == "" {
+			return fmt.Errorf("did not provide TEST_REGEX environment variable")
+		}
+
+		gh := github.NewClient(env["GITHUB_TOKEN_MAGIC_MODULES"])
+		rnr, err := exec.NewRunner()
+		if err != nil {
+			return fmt.Errorf("error creating a runner: %w", err)
+		}
+		ctlr := source.NewController(env["GOPATH"], "modular-magician", env["GITHUB_TOKEN_DOWNSTREAMS"], rnr)
+
+		vt, err := vcr.NewTester(env, "ci-vcr-cassettes", "ci-vcr-logs", rnr, true)
+		if err != nil {
+			return fmt.Errorf("error creating VCR tester: %w", err)
+		}
+
+		return execRecordVCRTests(args[0], args[1], args[2], args[3], args[4], testRegex, gh, rnr, ctlr, vt)
+	},
+}
+
+func execRecordVCRTests(prNumber, mmCommitSha, buildID, projectID, buildStep, testRegex string, gh GithubClient, rnr ExecRunner, ctlr *source.Controller, vt *vcr.Tester) error {
+	re, err := regexp.Compile(testRegex)
+	if err != nil {
+		return fmt.Errorf("invalid test regex %q: %w", testRegex, err)
+	}
+
+	newBranch := "auto-pr-" + prNumber
+	tpgbRepo := &source.Repo{
+		Name:   "terraform-provider-google-beta",
+		Owner:  "modular-magician",
+		Branch: newBranch,
+	}
+	ctlr.SetPath(tpgbRepo)
+	if err := ctlr.Clone(tpgbRepo); err != nil {
+		return fmt.Errorf("error cloning repo: %w", err)
+	}
+	vt.SetRepoPath(provider.Beta, tpgbRepo.Path)
+
+	if err := rnr.PushDir(tpgbRepo.Path); err != nil {
+		return fmt.Errorf("error changing to tpgbRepo dir: %w", err)
+	}
+	// grep exits with an error if nothing matches, which is reported below as no matching tests.
+	grepOutput, err := rnr.Run("grep", []string{"-rEo", "--include=*_test.go", `^func TestAcc\w+\(t \*testing\.T\)`, "google-beta"}, nil)
+	if err != nil {
+		fmt.Printf("Error finding acceptance tests: %s\n", err)
+	}
+	if err := rnr.PopDir(); err != nil {
+		return err
+	}
+
+	testDirs, tests := matchingAccTests(grepOutput, re)
+	if len(tests) == 0 {
+		return gh.PostComment(prNumber, fmt.Sprintf("`/record %s` didn't match any acceptance tests in the beta provider.", testRegex))
+	}
+	if len(tests) > maxRecordedTests {
+		return gh.PostComment(prNumber, fmt.Sprintf("`/record %s` matched %d acceptance tests; please use a regex that matches at most %d.", testRegex, len(tests), maxRecordedTests))
+	}
+	fmt.Printf("Recording %d tests in %v: %v\n", len(tests), testDirs, tests)
+
+	buildStatusTargetURL := fmt.Sprintf("https://console.cloud.google.com/cloud-build/builds;region=global/%s;step=%s?project=%s", buildID, buildStep, projectID)
+	if err := gh.PostBuildStatus(prNumber, "VCR-record", "pending", buildStatusTargetURL, mmCommitSha); err != nil {
+		return fmt.Errorf("error posting pending status: %w", err)
+	}
+
+	testState := "success"
+	recordingResult, recordingErr := vt.RunParallel(vcr.RunOptions{
+		Mode:             vcr.Recording,
+		Version:          provider.Beta,
+		TestDirs:         testDirs,
+		Tests:            tests,
+		UploadBranchName: newBranch,
+	})
+	if recordingErr != nil {
+		testState = "failure"
+	}
+
+	if err := vt.UploadCassettes(newBranch, provider.Beta); err != nil {
+		return fmt.Errorf("error uploading cassettes: %w", err)
+	}
+
+	if err := vt.UploadLogs(vcr.UploadLogsOptions{
+		Head:     newBranch,
+		BuildID:  buildID,
+		Parallel: true,
+		Mode:     vcr.Recording,
+		Version:  provider.Beta,
+	}); err != nil {
+		return fmt.Errorf("error uploading recording logs: %w", err)
+	}
+
+	if hasPanics, err := handlePanics(prNumber, buildID, "VCR-record", buildStatusTargetURL, mmCommitSha, recordingResult, vcr.Recording, gh); err != nil {
+		return fmt.Errorf("error handling panics: %w", err)
+	} else if hasPanics {
+		return nil
+	}
+
+	replayingAfterRecordingResult := vcr.Result{}
+	if len(recordingResult.PassedTests) > 0 {
+		var replayingAfterRecordingErr error
+		replayingAfterRecordingResult, replayingAfterRecordingErr = vt.RunParallel(vcr.RunOptions{
+			Mode:     vcr.Replaying,
+			Version:  provider.Beta,
+			TestDirs: testDirs,
+			Tests:    recordingResult.PassedTests,
+		})
+		if replayingAfterRecordingErr != nil {
+			testState = "failure"
+		}
+
+		if err := vt.UploadLogs(vcr.UploadLogsOptions{
+			Head:           newBranch,
+			BuildID:        buildID,
+			AfterRecording: true,
+			Parallel:       true,
+			Mode:           vcr.Replaying,
+			Version:        provider.Beta,
+		}); err != nil {
+			return fmt.Errorf("error uploading recording logs: %w", err)
+		}
+	}
+
+	hasTerminatedTests := (len(recordingResult.PassedTests) + len(recordingResult.FailedTests)) < len(tests)
+	recordReplayComment, err := formatRecordReplay(recordReplay{
+		RecordingResult:               subtestResult(recordingResult),
+		ReplayingAfterRecordingResult: subtestResult(replayingAfterRecordingResult),
+		RecordingErr:                  recordingErr,
+		HasTerminatedTests:            hasTerminatedTests,
+		AllRecordingPassed:            len(recordingResult.FailedTests) == 0 && !hasTerminatedTests && recordingErr == nil,
+		LogBucket:                     "ci-vcr-logs",
+		Version:                       provider.Beta.String(),
+		Head:                          newBranch,
+		BuildID:                       buildID,
+	})
+	if err != nil {
+		return fmt.Errorf("error formatting record replay comment: %w", err)
+	}
+	comment := fmt.Sprintf("Results of `/record %s` for commit %s:\n\n%s", testRegex, mmCommitSha, recordReplayComment)
+	if err := gh.PostComment(prNumber, comment); err != nil {
+		return fmt.Errorf("error posting comment: %w", err)
+	}
+
+	if err := gh.PostBuildStatus(prNumber, "VCR-record", testState, buildStatusTargetURL, mmCommitSha); err != nil {
+		return fmt.Errorf("error posting build status: %w", err)
+	}
+	return nil
+}
+
+// matchingAccTests returns the package directories of the acceptance tests found by grep whose
+// names match re, and the tests themselves, both sorted. Like go test -run, re is unanchored.
+func matchingAccTests(grepOutput string, re *regexp.Regexp) ([]string, []string) {
+	dirs := make(map[string]struct{})
+	tests := make(map[string]struct{})
+	for _, match := range accTestFuncRegexp.FindAllStringSubmatch(grepOutput, -1) {
+		if !re.MatchString(match[2]) {
+			continue
+		}
+		dirs["./"+filepath.Dir(match[1])] = struct{}{}
+		tests[match[2]] = struct{}{}
+	}
+	return slices.Sorted(maps.Keys(dirs)), slices.Sorted(maps.Keys(tests))
+}
+
+func init() {
+	rootCmd.AddCommand(recordVCRTestsCmd)
+}
@@ -0,0 +1,62 @@
+/*
+* Copyright 2026 Google LLC. All Rights Reserved.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+package cmd
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchingAccTests(t *testing.T) {
+	grepOutput := `google-beta/services/compute/resource_compute_instance_test.go:func TestAccComputeInstance_basic(t *testing.T)
+google-beta/services/compute/resource_compute_instance_test.go:func TestAccComputeInstance_update(t *testing.T)
+google-beta/services/compute/resource_compute_disk_test.go:func TestAccComputeDisk_basic(t *testing.T)
+google-beta/services/redis/resource_redis_instance_test.go:func TestAccRedisInstance_basic(t *testing.T)
+`
+	cases := map[string]struct {
+		regex       string
+		expectDirs  []string
+		expectTests []string
+	}{
+		"single test": {
+			regex:       "TestAccComputeInstance_basic",
+			expectDirs:  []string{"./google-beta/services/compute"},
+			expectTests: []string{"TestAccComputeInstance_basic"},
+		},
+		"regex across packages": {
+			regex:       "_basic$",
+			expectDirs:  []string{"./google-beta/services/compute", "./google-beta/services/redis"},
+			expectTests: []string{"TestAccComputeDisk_basic", "TestAccComputeInstance_basic", "TestAccRedisInstance_basic"},
+		},
+		"unanchored like go test -run": {
+			regex:       "ComputeInstance",
+			expectDirs:  []string{"./google-beta/services/compute"},
+			expectTests: []string{"TestAccComputeInstance_basic", "TestAccComputeInstance_update"},
+		},
+		"no matches": {
+			regex: "TestAccSql",
+		},
+	}
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			dirs, tests := matchingAccTests(grepOutput, regexp.MustCompile(tc.regex))
+			assert.Equal(t, tc.expectDirs, dirs)
+			assert.Equal(t, tc.expectTests, tests)
+		})
+	}
+}
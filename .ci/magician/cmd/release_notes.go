@@ -0,0 +1,233 @@
+/*
+* Copyright 2026 Google LLC. All Rights Reserved.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-changelog"
+)
+
+// releaseNoteTypes are the release note types accepted by tools/go-changelog, which builds
+// the downstream .changelog/<PR>.txt entries from the PR body.
+var releaseNoteTypes = changelog.TypeValues
+
+var (
+	releaseNoteBlockRegexp    = regexp.MustCompile("(?ms)^```release-?note(?::([^\r\n]*))?\r?\n(.*?)\r?\n?```")
+	releaseNoteResourceRegexp = regexp.MustCompile("`(google_[a-z0-9_]+)`")
+	// serviceFileRegexp captures the service package a provider file belongs to.
+	serviceFileRegexp = regexp.MustCompile(`/services/([^/]+)/`)
+)
+
+// maxSuggestedEnhancements is the most modified resources to suggest separate enhancement notes for.
+const maxSuggestedEnhancements = 3
+
+type ReleaseNote struct {
+	Type string `json:"type"`
+	Note string `json:"note"`
+}
+
+// ReleaseNoteReport lists problems with a PR's release notes and release notes suggested
+// for changes that don't have one.
+type ReleaseNoteReport struct {
+	Errors      []string
+	Suggestions []ReleaseNote
+}
+
+// parseReleaseNotes returns the release note blocks in a PR body.
+func parseReleaseNotes(body string) []ReleaseNote {
+	var notes []ReleaseNote
+	for _, m := range releaseNoteBlockRegexp.FindAllStringSubmatch(body, -1) {
+		notes = append(notes, ReleaseNote{
+			Type: strings.TrimSpace(m[1]),
+			Note: strings.TrimSpace(m[2]),
+		})
+	}
+	return notes
+}
+
+// checkReleaseNotes validates the release notes in a PR body against the resources the PR adds
+// and modifies. Every added resource needs its own new-resource note, and if there are no
+// release notes at all, notes are suggested for the added and modified resources, using the
+// changelog entries generated from the schema diff where there are any.
+// services maps resources to their service package, used to prefix suggested enhancements.
+func checkReleaseNotes(body string, addedResources, modifiedResources []string, services map[string]string, changelogEntries []ReleaseNote) ReleaseNoteReport {
+	var report ReleaseNoteReport
+	notes := parseReleaseNotes(body)
+
+	seen := make(map[ReleaseNote]bool)
+	documented := make(map[string]bool)
+	for _, note := range notes {
+		block := fmt.Sprintf("`release-note:%s` block %q", note.Type, note.Note)
+		if seen[note] {
+			report.Errors = append(report.Errors, fmt.Sprintf("%s is duplicated", block))
+			continue
+		}
+		seen[note] = true
+		// Validate with the same rules that go-changelog applies downstream
+		changelogNote := changelog.Note{Type: note.Type, Body: note.Note}
+		if err := changelogNote.Validate(); err != nil {
+			switch err.Code {
+			case changelog.EntryErrorUnknownTypes:
+				report.Errors = append(report.Errors, fmt.Sprintf("%s has an unknown type; use one of %s", block, strings.Join(releaseNoteTypes, ", ")))
+			case changelog.EntryErrorMultipleLines:
+				report.Errors = append(report.Errors, fmt.Sprintf("%s has multiple lines; use one block per change", block))
+			case changelog.EntryErrorInvalidNewReourceOrDatasourceFormat:
+				report.Errors = append(report.Errors, fmt.Sprintf("%s should name exactly one resource, formatted like `google_compute_instance`", block))
+			case changelog.EntryErrorInvalidEnhancementOrBugFixFormat:
+				report.Errors = append(report.Errors, fmt.Sprintf("%s should start with the service name, like `compute: `", block))
+			default:
+				report.Errors = append(report.Errors, fmt.Sprintf("%s is invalid: %s", block, err))
+			}
+			continue
+		}
+		if note.Type == "new-resource" || note.Type == "new-datasource" {
+			if len(releaseNoteResourceRegexp.FindAllString(note.Note, -1)) != 1 {
+				report.Errors = append(report.Errors, fmt.Sprintf("%s should name exactly one resource, formatted like `google_compute_instance`", block))
+				continue
+			}
+			documented[changelogNote.Parse().Resource] = true
+		}
+	}
+
+	added := slices.Clone(addedResources)
+	sort.Strings(added)
+	for _, resource := range added {
+		if len(notes) > 0 && !documented[resource] {
+			report.Errors = append(report.Errors, fmt.Sprintf("`%s` is a new resource but has no `release-note:new-resource` block", resource))
+		}
+		if !documented[resource] {
+			report.Suggestions = append(report.Suggestions, ReleaseNote{
+				Type: "new-resource",
+				Note: fmt.Sprintf("`%s`", resource),
+			})
+		}
+	}
+
+	if len(notes) == 0 {
+		var modified []string
+		for _, resource := range modifiedResources {
+			if !slices.Contains(addedResources, resource) {
+				modified = append(modified, resource)
+			}
+		}
+		sort.Strings(modified)
+		var undescribed []string
+		for _, resource := range modified {
+			entries := changelogEntriesFor(resource, changelogEntries, services)
+			if len(entries) == 0 {
+				undescribed = append(undescribed, resource)
+			}
+			report.Suggestions = append(report.Suggestions, entries...)
+		}
+		modified = undescribed
+		if len(modified) > maxSuggestedEnhancements {
+			// Treat this as a provider-wide change
+			report.Suggestions = append(report.Suggestions, ReleaseNote{
+				Type: "enhancement",
+				Note: "provider: DESCRIBE THE CHANGE",
+			})
+			modified = nil
+		}
+		for _, resource := range modified {
+			service := services[resource]
+			if parts := strings.Split(resource, "_"); service == "" && len(parts) > 1 {
+				// Guess from the resource name, e.g. compute for google_compute_instance.
+				service = parts[1]
+			}
+			report.Suggestions = append(report.Suggestions, ReleaseNote{
+				Type: "enhancement",
+				Note: fmt.Sprintf("%s: DESCRIBE THE CHANGE to `%s` resource", service, resource),
+			})
+		}
+	}
+	return report
+}
+
+// changelogEntriesFor returns the generated changelog entries about changes to a resource's fields,
+// prefixed with its service package if it's known. Entries for new resources and breaking changes
+// are suggested separately.
+func changelogEntriesFor(resource string, changelogEntries []ReleaseNote, services map[string]string) []ReleaseNote {
+	var entries []ReleaseNote
+	for _, entry := range changelogEntries {
+		switch entry.Type {
+		case "new-resource", "new-datasource", "breaking-change":
+			continue
+		}
+		m := releaseNoteResourceRegexp.FindStringSubmatch(entry.Note)
+		if m == nil || m[1] != resource {
+			continue
+		}
+		if service := services[resource]; service != "" {
+			if _, note, ok := strings.Cut(entry.Note, ": "); ok {
+				entry.Note = service + ": " + note
+			}
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// checkBreakingChangeReleaseNotes requires a `release-note:breaking-change` block on PRs that
+// merge high or medium severity breaking changes with the override label, so that they're
+// called out in the changelog. Each change gets a suggested note; low severity changes are
+// suggested as `release-note:note` blocks instead.
+func checkBreakingChangeReleaseNotes(report ReleaseNoteReport, body string, changes []BreakingChange) ReleaseNoteReport {
+	notes := parseReleaseNotes(body)
+	if slices.ContainsFunc(notes, func(note ReleaseNote) bool { return note.Type == "breaking-change" }) {
+		return report
+	}
+	required := false
+	for _, change := range changes {
+		noteType := "breaking-change"
+		if breakingChangeSeverity(change) == "low" {
+			noteType = "note"
+		} else {
+			required = true
+		}
+		service := "provider"
+		if parts := strings.Split(change.Resource, "_"); len(parts) > 1 {
+			service = parts[1]
+		}
+		report.Suggestions = append(report.Suggestions, ReleaseNote{
+			Type: noteType,
+			Note: fmt.Sprintf("%s: %s", service, change.Message),
+		})
+	}
+	if required && len(notes) > 0 {
+		report.Errors = append(report.Errors, "this PR makes breaking changes but has no `release-note:breaking-change` block")
+	}
+	return report
+}
+
+// resourceServices maps the resources of changed provider files to their service package.
+func resourceServices(changedFiles []string) map[string]string {
+	services := make(map[string]string)
+	for _, path := range changedFiles {
+		resource := fileToResource(path)
+		if resource == "" {
+			continue
+		}
+		if m := serviceFileRegexp.FindStringSubmatch(path); m != nil {
+			services[resource] = m[1]
+		}
+	}
+	return services
+}
@@ -0,0 +1,137 @@
+/*
+* Copyright 2026 Google LLC. All Rights Reserved.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"magician/exec"
+	"magician/source"
+
+	"github.com/GoogleCloudPlatform/magic-modules/tools/issue-labeler/labeler"
+	"github.com/hashicorp/go-changelog"
+	"github.com/spf13/cobra"
+)
+
+// pullRequestURL links release notes to the magic-modules PR they came from.
+const pullRequestURL = "https://github.com/GoogleCloudPlatform/magic-modules/pull/%s"
+
+// releaseNotesByServiceCmd represents the release-notes-by-service command
+var releaseNotesByServiceCmd = &cobra.Command{
+	Use:   "release-notes-by-service",
+	Short: "Prints the release notes between two refs of a downstream repo, grouped by service",
+	Long: `This command prints the release notes added to a downstream provider between two refs,
+	grouped by service, with breaking changes listed first.
+
+	It expects the following arguments:
+	1. Downstream repo name (terraform-provider-google or terraform-provider-google-beta)
+	2. The ref to list release notes since, like the previous release's tag
+	3. The ref to list release notes until, like HEAD
+
+	Notes are grouped by the service label the issue labeler gives the resource they name,
+	like compute-instances for google_compute_instance, or by the service they're prefixed
+	with if their resource isn't enrolled.
+
+	The following environment variables are required:
+	1. GITHUB_TOKEN_DOWNSTREAMS
+	2. GOPATH`,
+	Args: cobra.ExactArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		repoName := args[0]
+		if repoName != "terraform-provider-google" && repoName != "terraform-provider-google-beta" {
+			return fmt.Errorf("wrong repo name %q, expected terraform-provider-google or terraform-provider-google-beta", repoName)
+		}
+
+		githubToken, ok := lookupGithubTokenOrFallback("GITHUB_TOKEN_DOWNSTREAMS")
+		if !ok {
+			return fmt.Errorf("did not provide GITHUB_TOKEN_DOWNSTREAMS or GITHUB_TOKEN environment variables")
+		}
+		goPath, ok := os.LookupEnv("GOPATH")
+		if !ok {
+			return fmt.Errorf("did not provide GOPATH environment variable")
+		}
+
+		rnr, err := exec.NewRunner()
+		if err != nil {
+			return fmt.Errorf("error creating a runner: %w", err)
+		}
+		regexpLabels, err := labeler.BuildRegexLabels(labeler.EnrolledTeamsYaml)
+		if err != nil {
+			return fmt.Errorf("error building regexp labels: %w", err)
+		}
+		ctlr := source.NewController(goPath, "modular-magician", githubToken, rnr)
+		releaseNotes, err := execReleaseNotesByService(repoName, args[1], args[2], rnr, ctlr, regexpLabels)
+		if err != nil {
+			return err
+		}
+		fmt.Print(releaseNotes)
+		return nil
+	},
+}
+
+func execReleaseNotesByService(repoName, from, to string, rnr ExecRunner, ctlr *source.Controller, regexpLabels []labeler.RegexpLabel) (string, error) {
+	repo := &source.Repo{
+		Name:  repoName,
+		Owner: "hashicorp",
+	}
+	ctlr.SetPath(repo)
+	if err := ctlr.Clone(repo); err != nil {
+		return "", fmt.Errorf("error cloning %s: %w", repoName, err)
+	}
+	if err := rnr.PushDir(repo.Path); err != nil {
+		return "", err
+	}
+	entries, err := addedReleaseNotes(rnr, repo.Path, from, to)
+	if err != nil {
+		return "", err
+	}
+	if err := rnr.PopDir(); err != nil {
+		return "", err
+	}
+
+	var notes []changelog.ParsedNote
+	for _, e := range entries {
+		note := changelog.Note{Type: e.Type, Body: e.Note, Issue: e.PR}
+		notes = append(notes, note.Parse())
+	}
+	return changelog.GroupByService(notes, labelerServiceFunc(regexpLabels)).Markdown(pullRequestURL), nil
+}
+
+// labelerServiceFunc returns the service of a release note's resource, from the service label the
+// issue labeler gives it without its service/ prefix. Notes about resources that aren't enrolled
+// keep the service they're prefixed with, or if they have none, like new resources, the service
+// is guessed from the resource name.
+func labelerServiceFunc(regexpLabels []labeler.RegexpLabel) changelog.ServiceFunc {
+	return func(note changelog.ParsedNote) string {
+		if note.Resource == "" {
+			return ""
+		}
+		if labels := labeler.ComputeLabels([]string{note.Resource}, regexpLabels); len(labels) > 0 {
+			return strings.TrimPrefix(labels[0], "service/")
+		}
+		if parts := strings.Split(note.Resource, "_"); note.Service == "" && len(parts) > 1 {
+			// e.g. redis for google_redis_cluster
+			return parts[1]
+		}
+		return ""
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(releaseNotesByServiceCmd)
+}
@@ -0,0 +1,63 @@
+/*
+* Copyright 2026 Google LLC. All Rights Reserved.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+package cmd
+
+import (
+	"testing"
+
+	"magician/source"
+
+	"github.com/GoogleCloudPlatform/magic-modules/tools/issue-labeler/labeler"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecReleaseNotesByService(t *testing.T) {
+	repoPath := "/mock/dir/go/src/github.com/hashicorp/terraform-provider-google"
+	mr := NewMockRunner().(*mockRunner)
+	mr.cmdResults[repoPath+" git [log --diff-filter=A --name-only --format= v6.12.1..v6.13.0 -- .changelog] map[]"] = ".changelog/101.txt\n.changelog/102.txt\n.changelog/103.txt\n.changelog/104.txt\n"
+	mr.fileContents = map[string]string{
+		repoPath + "/.changelog/101.txt": "```release-note:enhancement\ncompute: added `foo` field to `google_compute_instance` resource\n```\n\n" +
+			"```release-note:breaking-change\nsql: removed `bar` field from `google_sql_database_instance` resource\n```",
+		repoPath + "/.changelog/102.txt": "```release-note:bug\ncompute: fixed a crash in `google_compute_instance`\n```",
+		repoPath + "/.changelog/103.txt": "```release-note:none\n\n```",
+		repoPath + "/.changelog/104.txt": "```release-note:new-resource\n`google_redis_cluster`\n```",
+	}
+	ctlr := source.NewController("/mock/dir/go", "modular-magician", "*******", mr)
+	regexpLabels, err := labeler.BuildRegexLabels([]byte(`
+service/compute-instances:
+  resources:
+  - google_compute_instance
+service/sqladmin:
+  resources:
+  - google_sql_.*
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := execReleaseNotesByService("terraform-provider-google", "v6.12.1", "v6.13.0", mr, ctlr, regexpLabels)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "BREAKING CHANGES:\n" +
+		"* sqladmin: removed `bar` field from `google_sql_database_instance` resource ([#101](https://github.com/GoogleCloudPlatform/magic-modules/pull/101))\n" +
+		"\ncompute-instances:\n" +
+		"* added `foo` field to `google_compute_instance` resource ([#101](https://github.com/GoogleCloudPlatform/magic-modules/pull/101))\n" +
+		"* **Fixed:** fixed a crash in `google_compute_instance` ([#102](https://github.com/GoogleCloudPlatform/magic-modules/pull/102))\n" +
+		"\nredis:\n" +
+		"* **New Resource:** `google_redis_cluster` ([#104](https://github.com/GoogleCloudPlatform/magic-modules/pull/104))\n"
+	assert.Equal(t, expected, got)
+}
@@ -0,0 +1,207 @@
+/*
+* Copyright 2026 Google LLC. All Rights Reserved.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCheckReleaseNotes(t *testing.T) {
+	cases := map[string]struct {
+		body              string
+		addedResources    []string
+		modifiedResources []string
+		services          map[string]string
+		changelogEntries  []ReleaseNote
+		want              ReleaseNoteReport
+	}{
+		"valid notes": {
+			body:              "Fixes #1\n\n```release-note:new-resource\n`google_compute_thing`\n```\n\n```release-note:enhancement\ncompute: added `foo` field to `google_compute_instance` resource\n```\n",
+			addedResources:    []string{"google_compute_thing"},
+			modifiedResources: []string{"google_compute_instance"},
+		},
+		"none is valid for changes without resources": {
+			body: "```release-note:none\n\n```",
+		},
+		"missing notes get suggestions": {
+			addedResources:    []string{"google_compute_thing"},
+			modifiedResources: []string{"google_compute_thing", "google_compute_instance", "google_storage_bucket"},
+			services:          map[string]string{"google_compute_instance": "compute"},
+			want: ReleaseNoteReport{
+				Suggestions: []ReleaseNote{
+					{Type: "new-resource", Note: "`google_compute_thing`"},
+					{Type: "enhancement", Note: "compute: DESCRIBE THE CHANGE to `google_compute_instance` resource"},
+					{Type: "enhancement", Note: "storage: DESCRIBE THE CHANGE to `google_storage_bucket` resource"},
+				},
+			},
+		},
+		"missing notes get generated changelog entries": {
+			modifiedResources: []string{"google_compute_instance", "google_storage_bucket"},
+			services:          map[string]string{"google_compute_instance": "compute", "google_storage_bucket": "storage"},
+			changelogEntries: []ReleaseNote{
+				{Type: "enhancement", Note: "compute: added `labels` field to `google_compute_instance` resource"},
+				{Type: "bug", Note: "compute: fixed a permadiff on `hostname` field in `google_compute_instance` resource"},
+				{Type: "enhancement", Note: "compute: added `labels` field to `google_compute_instance` data source"},
+				{Type: "breaking-change", Note: "compute: Field `zone` changed from optional to required on `google_compute_instance`"},
+				{Type: "new-resource", Note: "`google_compute_thing`"},
+			},
+			want: ReleaseNoteReport{
+				Suggestions: []ReleaseNote{
+					{Type: "enhancement", Note: "compute: added `labels` field to `google_compute_instance` resource"},
+					{Type: "bug", Note: "compute: fixed a permadiff on `hostname` field in `google_compute_instance` resource"},
+					{Type: "enhancement", Note: "compute: added `labels` field to `google_compute_instance` data source"},
+					{Type: "enhancement", Note: "storage: DESCRIBE THE CHANGE to `google_storage_bucket` resource"},
+				},
+			},
+		},
+		"generated changelog entries use the service package": {
+			modifiedResources: []string{"google_folder_access_approval_settings"},
+			services:          map[string]string{"google_folder_access_approval_settings": "accessapproval"},
+			changelogEntries: []ReleaseNote{
+				{Type: "enhancement", Note: "folder: made `notification_emails` field in `google_folder_access_approval_settings` resource updatable"},
+			},
+			want: ReleaseNoteReport{
+				Suggestions: []ReleaseNote{
+					{Type: "enhancement", Note: "accessapproval: made `notification_emails` field in `google_folder_access_approval_settings` resource updatable"},
+				},
+			},
+		},
+		"many modified resources get a provider-wide suggestion": {
+			modifiedResources: []string{"google_a_a", "google_b_b", "google_c_c", "google_d_d"},
+			want: ReleaseNoteReport{
+				Suggestions: []ReleaseNote{{Type: "enhancement", Note: "provider: DESCRIBE THE CHANGE"}},
+			},
+		},
+		"invalid notes": {
+			body: "```release-note:enhancment\ncompute: added field\n```\n" +
+				"```release-note:bug\nfixed a crash\n```\n" +
+				"```release-note:bug\ncompute: fixed a crash\nand another\n```\n" +
+				"```release-note:new-resource\ngoogle_compute_thing\n```\n",
+			want: ReleaseNoteReport{
+				Errors: []string{
+					"`release-note:enhancment` block \"compute: added field\" has an unknown type; use one of enhancement, bug, note, none, new-resource, new-datasource, deprecation, breaking-change",
+					"`release-note:bug` block \"fixed a crash\" should start with the service name, like `compute: `",
+					"`release-note:bug` block \"compute: fixed a crash\\nand another\" has multiple lines; use one block per change",
+					"`release-note:new-resource` block \"google_compute_thing\" should name exactly one resource, formatted like `google_compute_instance`",
+				},
+			},
+		},
+		"one new-resource note per resource": {
+			body:           "```release-note:new-resource\n`google_compute_thing`\n```\n```release-note:new-resource\n`google_compute_thing`\n```\n",
+			addedResources: []string{"google_compute_thing", "google_compute_other_thing"},
+			want: ReleaseNoteReport{
+				Errors: []string{
+					"`release-note:new-resource` block \"`google_compute_thing`\" is duplicated",
+					"`google_compute_other_thing` is a new resource but has no `release-note:new-resource` block",
+				},
+				Suggestions: []ReleaseNote{{Type: "new-resource", Note: "`google_compute_other_thing`"}},
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		tc := tc
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+			got := checkReleaseNotes(tc.body, tc.addedResources, tc.modifiedResources, tc.services, tc.changelogEntries)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("checkReleaseNotes() = %#v; want %#v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCheckBreakingChangeReleaseNotes(t *testing.T) {
+	typeChange := BreakingChange{
+		Resource: "google_redis_instance",
+		Message:  "Field `tier` changed from TypeString to TypeInt on `google_redis_instance`",
+		Severity: "high",
+	}
+	widening := BreakingChange{
+		Resource: "google_compute_disk",
+		Message:  "Field `size` changed from TypeInt to TypeFloat on `google_compute_disk`",
+		Severity: "low",
+	}
+	cases := map[string]struct {
+		body    string
+		changes []BreakingChange
+		want    ReleaseNoteReport
+	}{
+		"no breaking changes": {
+			body: "```release-note:bug\nredis: fixed a crash\n```",
+		},
+		"suggested without release notes": {
+			changes: []BreakingChange{typeChange, widening},
+			want: ReleaseNoteReport{
+				Suggestions: []ReleaseNote{
+					{Type: "breaking-change", Note: "redis: Field `tier` changed from TypeString to TypeInt on `google_redis_instance`"},
+					{Type: "note", Note: "compute: Field `size` changed from TypeInt to TypeFloat on `google_compute_disk`"},
+				},
+			},
+		},
+		"required with other release notes": {
+			body:    "```release-note:enhancement\nredis: changed `tier` to an integer\n```",
+			changes: []BreakingChange{typeChange},
+			want: ReleaseNoteReport{
+				Errors: []string{"this PR makes breaking changes but has no `release-note:breaking-change` block"},
+				Suggestions: []ReleaseNote{
+					{Type: "breaking-change", Note: "redis: Field `tier` changed from TypeString to TypeInt on `google_redis_instance`"},
+				},
+			},
+		},
+		"low severity isn't required": {
+			body:    "```release-note:enhancement\ncompute: allowed fractional `size`\n```",
+			changes: []BreakingChange{widening},
+			want: ReleaseNoteReport{
+				Suggestions: []ReleaseNote{
+					{Type: "note", Note: "compute: Field `size` changed from TypeInt to TypeFloat on `google_compute_disk`"},
+				},
+			},
+		},
+		"breaking change release note present": {
+			body:    "```release-note:breaking-change\nredis: changed `tier` to an integer\n```",
+			changes: []BreakingChange{typeChange},
+		},
+	}
+
+	for tn, tc := range cases {
+		tc := tc
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+			got := checkBreakingChangeReleaseNotes(ReleaseNoteReport{}, tc.body, tc.changes)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("checkBreakingChangeReleaseNotes() = %#v; want %#v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestResourceServices(t *testing.T) {
+	got := resourceServices([]string{
+		"google-beta/services/compute/resource_compute_instance.go",
+		"google-beta/services/storage/data_source_storage_bucket_object.go",
+		"website/docs/r/compute_instance.html.markdown",
+		"go.mod",
+	})
+	want := map[string]string{
+		"google_compute_instance":      "compute",
+		"google_storage_bucket_object": "storage",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("resourceServices() = %v; want %v", got, want)
+	}
+}
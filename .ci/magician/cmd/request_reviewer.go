@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"magician/github"
 	"os"
+	"time"
 
 	"github.com/spf13/cobra"
 )
@@ -36,8 +37,12 @@ var requestReviewerCmd = &cobra.Command{
 	1. Determines the author of the pull request
 	2. If the author is not a core contributor:
 			a. Identifies the initially requested reviewer and those who previously reviewed this PR.
-			b. Determines and requests reviewers based on the above.
+			b. Determines and requests reviewers based on the above. A new primary reviewer is this
+			   week's reviewer from the reviewer schedule, or if nobody in it is available, the
+			   available reviewer with the fewest open review requests.
 			c. As appropriate, posts a welcome comment on the PR.
+			d. If this is the author's first contribution, posts a checklist tailored to the
+			   files the PR changes (generated resources, handwritten code, tests and docs).
 	`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -59,7 +64,7 @@ func execRequestReviewer(prNumber string, gh GithubClient) error {
 	}
 
 	author := pullRequest.User.Login
-	if !github.IsCoreContributor(author) {
+	if !gh.IsCoreContributor(author) {
 		fmt.Println("Not core contributor - assigning reviewer")
 
 		requestedReviewers, err := gh.GetPullRequestRequestedReviewers(prNumber)
@@ -72,7 +77,7 @@ func execRequestReviewer(prNumber string, gh GithubClient) error {
 			return err
 		}
 
-		reviewersToRequest, newPrimaryReviewer := github.ChooseCoreReviewers(requestedReviewers, previousReviewers)
+		reviewersToRequest, newPrimaryReviewer, reason := github.ChooseCoreReviewers(requestedReviewers, previousReviewers, gh.GetOpenReviewCounts)
 
 		if len(reviewersToRequest) > 0 {
 			err = gh.RequestPullRequestReviewers(prNumber, reviewersToRequest)
@@ -82,12 +87,23 @@ func execRequestReviewer(prNumber string, gh GithubClient) error {
 		}
 
 		if newPrimaryReviewer != "" {
-			comment := github.FormatReviewerComment(newPrimaryReviewer)
+			fmt.Printf("Assigned %s as primary reviewer (%s)\n", newPrimaryReviewer, reason)
+			comment := github.FormatReviewerComment(newPrimaryReviewer, github.ReviewerAssignment{
+				Date:     time.Now(),
+				Reviewer: newPrimaryReviewer,
+				Reason:   reason,
+			})
 			err = gh.PostComment(prNumber, comment)
 			if err != nil {
 				return err
 			}
 		}
+
+		if isFirstTimeContributor(pullRequest) {
+			if err := postWelcomeComment(prNumber, pullRequest, gh); err != nil {
+				return err
+			}
+		}
 	}
 	return nil
 }
@@ -28,11 +28,21 @@ func TestExecRequestReviewer(t *testing.T) {
 	if len(availableReviewers) < 3 {
 		t.Fatalf("not enough available reviewers (%v) to run TestExecRequestReviewer (need at least 3)", availableReviewers)
 	}
+	onCall := github.OnCallReviewer(nil)
+	if onCall == "" {
+		t.Fatal("nobody in the reviewer schedule is available to run TestExecRequestReviewer")
+	}
+	idle := availableReviewers[0]
+	if idle == onCall {
+		idle = availableReviewers[1]
+	}
 	cases := map[string]struct {
 		pullRequest             github.PullRequest
+		coreContributors        []string
 		requestedReviewers      []string
 		previousReviewers       []string
 		teamMembers             map[string][]string
+		openReviews             map[string]int
 		expectSpecificReviewers []string
 		expectReviewersFromList []string
 	}{
@@ -49,12 +59,26 @@ func TestExecRequestReviewer(t *testing.T) {
 			previousReviewers:       []string{availableReviewers[1]},
 			expectSpecificReviewers: []string{},
 		},
+		"core contributor team member doesn't get a new reviewer": {
+			pullRequest: github.PullRequest{
+				User: github.User{Login: "author"},
+			},
+			coreContributors:        []string{"author"},
+			expectSpecificReviewers: []string{},
+		},
 		"non-core-contributor author gets a new reviewer with no previous reviewers": {
 			pullRequest: github.PullRequest{
 				User: github.User{Login: "author"},
 			},
 			expectReviewersFromList: availableReviewers,
 		},
+		"non-core-contributor author gets the on-call reviewer rather than the least loaded reviewer": {
+			pullRequest: github.PullRequest{
+				User: github.User{Login: "author"},
+			},
+			openReviews:             openReviewsExcept(availableReviewers, idle),
+			expectSpecificReviewers: []string{onCall},
+		},
 		"non-core-contributor author doesn't get a new reviewer (but does get re-request) with previous reviewers": {
 			pullRequest: github.PullRequest{
 				User: github.User{Login: "author"},
@@ -82,8 +106,10 @@ func TestExecRequestReviewer(t *testing.T) {
 			}
 			gh := &mockGithub{
 				pullRequest:        tc.pullRequest,
+				coreContributors:   tc.coreContributors,
 				requestedReviewers: requestedReviewers,
 				previousReviewers:  previousReviewers,
+				openReviews:        tc.openReviews,
 				calledMethods:      make(map[string][][]any),
 			}
 
@@ -108,3 +134,14 @@ func TestExecRequestReviewer(t *testing.T) {
 		})
 	}
 }
+
+// openReviewsExcept gives every reviewer but the idle one some open reviews.
+func openReviewsExcept(reviewers []string, idle string) map[string]int {
+	openReviews := make(map[string]int)
+	for _, reviewer := range reviewers {
+		if reviewer != idle {
+			openReviews[reviewer] = 3
+		}
+	}
+	return openReviews
+}
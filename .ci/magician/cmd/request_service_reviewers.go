@@ -19,6 +19,8 @@ import (
 	"fmt"
 	"magician/github"
 	"math/rand"
+	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/GoogleCloudPlatform/magic-modules/tools/issue-labeler/labeler"
@@ -32,6 +34,10 @@ var requestServiceReviewersCmd = &cobra.Command{
 	Short: "Assigns reviewers based on the PR's service labels.",
 	Long: `This command requests (or re-requests) review based on the PR's service labels.
 
+	Services owning mmv1 products changed by the PR (files under mmv1/products/<product>/)
+	are treated like service labels. When a service team's review is requested only because
+	of changed product files, a comment explains which files caused it.
+
 	If a PR has more than 3 service labels, the command will not do anything.
 	`,
 	Args: cobra.ExactArgs(1),
@@ -50,7 +56,87 @@ var requestServiceReviewersCmd = &cobra.Command{
 
 // TODO: Switch to labeler.LabelData after a soak period.
 type LabelData struct {
-	Team string `yaml:"team,omitempty"`
+	Team      string   `yaml:"team,omitempty"`
+	Resources []string `yaml:"resources"`
+}
+
+var productFileRegexp = regexp.MustCompile(`^mmv1/products/([^/]+)/([^/]+)\.yaml$`)
+
+// serviceLabelsForPaths returns the enrolled service labels owning the mmv1 products changed at
+// the given paths, along with the paths mapping to each label. A resource file
+// mmv1/products/<product>/<Resource>.yaml belongs to the first label (in label order) with a
+// resource pattern matching google_<product>_<resource>. Underscores are ignored when matching
+// since product directories and resource file names don't have them. Files with no match, such
+// as product.yaml, fall back to the service/<product> label if it is enrolled.
+func serviceLabelsForPaths(paths []string, enrolledTeams map[string]LabelData) (map[string][]string, error) {
+	type labelPattern struct {
+		label   string
+		pattern *regexp.Regexp
+	}
+	var patterns []labelPattern
+	for label, data := range enrolledTeams {
+		for _, resource := range data.Resources {
+			pattern, err := regexp.Compile(fmt.Sprintf("^%s$", strings.ReplaceAll(resource, "_", "")))
+			if err != nil {
+				return nil, fmt.Errorf("error compiling resource %q for %s: %w", resource, label, err)
+			}
+			patterns = append(patterns, labelPattern{label: label, pattern: pattern})
+		}
+	}
+	sort.SliceStable(patterns, func(i, j int) bool {
+		return patterns[i].label < patterns[j].label
+	})
+
+	labelPaths := make(map[string][]string)
+	for _, p := range paths {
+		m := productFileRegexp.FindStringSubmatch(p)
+		if m == nil {
+			continue
+		}
+		product, file := m[1], m[2]
+		resource := "google" + product + strings.ToLower(strings.ReplaceAll(file, "_", ""))
+
+		label := ""
+		if file != "product" {
+			for _, lp := range patterns {
+				if lp.pattern.MatchString(resource) {
+					label = lp.label
+					break
+				}
+			}
+		}
+		if label == "" {
+			if _, ok := enrolledTeams["service/"+product]; ok {
+				label = "service/" + product
+			}
+		}
+		if label != "" && label != "service/terraform" {
+			labelPaths[label] = append(labelPaths[label], p)
+		}
+	}
+	return labelPaths, nil
+}
+
+// formatServiceReviewerComment explains why service team members were requested for review
+// based on the product files changed by a PR.
+func formatServiceReviewerComment(requested map[string]string, teamPaths map[string][]string) string {
+	teams := make([]string, 0, len(requested))
+	for team := range requested {
+		teams = append(teams, team)
+	}
+	sort.Strings(teams)
+
+	sb := new(strings.Builder)
+	sb.WriteString("Service team reviews were requested because this PR changes products they own:\n")
+	for _, team := range teams {
+		fmt.Fprintf(sb, "\n@%s from GoogleCloudPlatform/%s:\n", requested[team], team)
+		paths := teamPaths[team]
+		sort.Strings(paths)
+		for _, p := range paths {
+			fmt.Fprintf(sb, "- `%s`\n", p)
+		}
+	}
+	return sb.String()
 }
 
 func execRequestServiceReviewers(prNumber string, gh GithubClient, enrolledTeamsYaml []byte) error {
@@ -74,20 +160,46 @@ func execRequestServiceReviewers(prNumber string, gh GithubClient, enrolledTeams
 		return err
 	}
 
+	files, err := gh.GetPullRequestFiles(prNumber)
+	if err != nil {
+		return err
+	}
+
+	pathLabels, err := serviceLabelsForPaths(files, enrolledTeams)
+	if err != nil {
+		return err
+	}
+
 	// If more than three service labels are impacted, don't request reviews.
 	// Only request reviews from unique service teams.
 	githubTeamsSet := make(map[string]struct{})
-	teamCount := 0
+	serviceLabels := make(map[string]struct{})
 	for _, label := range pullRequest.Labels {
 		if !strings.HasPrefix(label.Name, "service/") || label.Name == "service/terraform" {
 			continue
 		}
-		teamCount += 1
+		serviceLabels[label.Name] = struct{}{}
 		if labelData, ok := enrolledTeams[label.Name]; ok && labelData.Team != "" {
 			githubTeamsSet[labelData.Team] = struct{}{}
 		}
 	}
 
+	// Teams only reached through changed product files, with the files that reached them.
+	teamPaths := make(map[string][]string)
+	for label, paths := range pathLabels {
+		serviceLabels[label] = struct{}{}
+		team := enrolledTeams[label].Team
+		if team == "" {
+			continue
+		}
+		if _, ok := githubTeamsSet[team]; ok && len(teamPaths[team]) == 0 {
+			continue
+		}
+		githubTeamsSet[team] = struct{}{}
+		teamPaths[team] = append(teamPaths[team], paths...)
+	}
+	teamCount := len(serviceLabels)
+
 	if teamCount > 3 {
 		fmt.Println("Provider-wide change (>3 services impacted); not requesting service team reviews")
 		return nil
@@ -107,6 +219,8 @@ func execRequestServiceReviewers(prNumber string, gh GithubClient, enrolledTeams
 	}
 
 	exitCode := 0
+	// New reviewers requested for teams in teamPaths, by team.
+	pathReviewers := make(map[string]string)
 	for githubTeam := range githubTeamsSet {
 		members, err := gh.GetTeamMembers("GoogleCloudPlatform", githubTeam)
 		if err != nil {
@@ -134,7 +248,11 @@ func execRequestServiceReviewers(prNumber string, gh GithubClient, enrolledTeams
 		}
 
 		if !hasReviewer && len(reviewerPool) > 0 {
-			reviewersToRequest = append(reviewersToRequest, reviewerPool[rand.Intn(len(reviewerPool))])
+			reviewer := reviewerPool[rand.Intn(len(reviewerPool))]
+			reviewersToRequest = append(reviewersToRequest, reviewer)
+			if _, ok := teamPaths[githubTeam]; ok {
+				pathReviewers[githubTeam] = reviewer
+			}
 		}
 	}
 
@@ -142,6 +260,11 @@ func execRequestServiceReviewers(prNumber string, gh GithubClient, enrolledTeams
 	if err != nil {
 		fmt.Println(err)
 		exitCode = 1
+	} else if len(pathReviewers) > 0 {
+		if err := gh.PostComment(prNumber, formatServiceReviewerComment(pathReviewers, teamPaths)); err != nil {
+			fmt.Println(err)
+			exitCode = 1
+		}
 	}
 	if exitCode != 0 {
 		return fmt.Errorf("exit code = %d", exitCode)
@@ -3,6 +3,7 @@ package cmd
 import (
 	"github.com/stretchr/testify/assert"
 	"magician/github"
+	"strings"
 	"testing"
 )
 
@@ -26,7 +27,9 @@ func TestExecRequestServiceReviewersMembershipChecker(t *testing.T) {
 		requestedReviewers      []string
 		previousReviewers       []string
 		teamMembers             map[string][]string
+		pullRequestFiles        []string
 		expectSpecificReviewers []string
+		expectComment           bool
 	}{
 		"no service labels means no service team reviewers": {
 			pullRequest: github.PullRequest{
@@ -107,6 +110,42 @@ func TestExecRequestServiceReviewersMembershipChecker(t *testing.T) {
 			teamMembers:             map[string][]string{"google-x": []string{"googler_team_member"}, "google-y": []string{"googler_y_team_member"}},
 			expectSpecificReviewers: []string{"googler_team_member", "googler_y_team_member"},
 		},
+		"changed product files request the owning team": {
+			pullRequest: github.PullRequest{
+				User: github.User{Login: "googler_author"},
+			},
+			pullRequestFiles:        []string{"mmv1/products/x/Resource.yaml", "docs/content/_index.md"},
+			teamMembers:             map[string][]string{"google-x": []string{"googler_team_member"}},
+			expectSpecificReviewers: []string{"googler_team_member"},
+			expectComment:           true,
+		},
+		"changed product files for a labeled service don't add a comment": {
+			pullRequest: github.PullRequest{
+				User:   github.User{Login: "googler_author"},
+				Labels: []github.Label{{Name: "service/google-x"}},
+			},
+			pullRequestFiles:        []string{"mmv1/products/x/Resource.yaml"},
+			teamMembers:             map[string][]string{"google-x": []string{"googler_team_member"}},
+			expectSpecificReviewers: []string{"googler_team_member"},
+		},
+		"changed product files re-request previous reviewers without a comment": {
+			pullRequest: github.PullRequest{
+				User: github.User{Login: "googler_author"},
+			},
+			pullRequestFiles:        []string{"mmv1/products/x/Resource.yaml"},
+			previousReviewers:       []string{"googler_team_member"},
+			teamMembers:             map[string][]string{"google-x": []string{"googler_team_member", "googler_team_member_2"}},
+			expectSpecificReviewers: []string{"googler_team_member"},
+		},
+		"changed product files count toward the service limit": {
+			pullRequest: github.PullRequest{
+				User:   github.User{Login: "googler_author"},
+				Labels: []github.Label{{Name: "service/google-x"}, {Name: "service/google-z"}, {Name: "service/google-a"}},
+			},
+			pullRequestFiles:        []string{"mmv1/products/y/Resource.yaml"},
+			teamMembers:             map[string][]string{"google-x": []string{"googler_team_member"}, "google-y": []string{"googler_y_team_member"}},
+			expectSpecificReviewers: []string{},
+		},
 		">3 service teams will not be requested": {
 			pullRequest: github.PullRequest{
 				User:   github.User{Login: "googler_author"},
@@ -138,6 +177,7 @@ func TestExecRequestServiceReviewersMembershipChecker(t *testing.T) {
 				requestedReviewers: requestedReviewers,
 				previousReviewers:  previousReviewers,
 				teamMembers:        teamMembers,
+				pullRequestFiles:   tc.pullRequestFiles,
 				calledMethods:      make(map[string][][]any),
 			}
 
@@ -151,6 +191,68 @@ func TestExecRequestServiceReviewersMembershipChecker(t *testing.T) {
 			if tc.expectSpecificReviewers != nil {
 				assert.ElementsMatch(t, tc.expectSpecificReviewers, actualReviewers)
 			}
+			if tc.expectComment {
+				if assert.Len(t, gh.calledMethods["PostComment"], 1) {
+					comment := gh.calledMethods["PostComment"][0][1].(string)
+					assert.True(t, strings.Contains(comment, "GoogleCloudPlatform/google-x"), "comment %q should name the team", comment)
+				}
+			} else {
+				assert.Len(t, gh.calledMethods["PostComment"], 0)
+			}
+		})
+	}
+}
+
+func TestServiceLabelsForPaths(t *testing.T) {
+	enrolledTeams := map[string]LabelData{
+		"service/compute-instances": {Team: "compute", Resources: []string{"google_compute_instance.*"}},
+		"service/compute-network":   {Resources: []string{"google_compute_address"}},
+		"service/accesscontextmanager": {
+			Resources: []string{"google_access_context_manager_.*"},
+		},
+		"service/storage": {Resources: []string{"google_storage_bucket"}},
+	}
+	cases := map[string]struct {
+		paths []string
+		want  map[string][]string
+	}{
+		"non-product files are ignored": {
+			paths: []string{"mmv1/third_party/terraform/go.mod", "mmv1/products/compute/go_test.go", "docs/index.md"},
+			want:  map[string][]string{},
+		},
+		"resource files match resource patterns": {
+			paths: []string{"mmv1/products/compute/Address.yaml", "mmv1/products/compute/InstanceGroup.yaml"},
+			want: map[string][]string{
+				"service/compute-network":   {"mmv1/products/compute/Address.yaml"},
+				"service/compute-instances": {"mmv1/products/compute/InstanceGroup.yaml"},
+			},
+		},
+		"underscores are ignored": {
+			paths: []string{"mmv1/products/accesscontextmanager/AccessLevel.yaml"},
+			want: map[string][]string{
+				"service/accesscontextmanager": {"mmv1/products/accesscontextmanager/AccessLevel.yaml"},
+			},
+		},
+		"product files fall back to the product's label": {
+			paths: []string{"mmv1/products/storage/product.yaml", "mmv1/products/storage/ManagedFolder.yaml"},
+			want: map[string][]string{
+				"service/storage": {"mmv1/products/storage/product.yaml", "mmv1/products/storage/ManagedFolder.yaml"},
+			},
+		},
+		"unknown products are ignored": {
+			paths: []string{"mmv1/products/unknown/product.yaml"},
+			want:  map[string][]string{},
+		},
+	}
+	for tn, tc := range cases {
+		tc := tc
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+			got, err := serviceLabelsForPaths(tc.paths, enrolledTeams)
+			if err != nil {
+				t.Fatalf("serviceLabelsForPaths() error: %v", err)
+			}
+			assert.Equal(t, tc.want, got)
 		})
 	}
 }
@@ -16,9 +16,12 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"math"
+	"net/http"
 	"os"
 	"strings"
 	"text/template"
@@ -36,7 +39,8 @@ import (
 
 var (
 	// used for flags
-	dryRun bool
+	dryRun         bool
+	escalationDays int
 
 	//go:embed templates/SCHEDULED_PR_WAITING_FOR_CONTRIBUTOR.md.tmpl
 	waitingForContributorTemplate string
@@ -46,6 +50,9 @@ var (
 
 	//go:embed templates/SCHEDULED_PR_WAITING_FOR_REVIEW.md.tmpl
 	waitingForReviewTemplate string
+
+	//go:embed templates/SCHEDULED_PR_ESCALATION.md.tmpl
+	escalationTemplate string
 )
 
 type reminderCommentData struct {
@@ -54,12 +61,25 @@ type reminderCommentData struct {
 	CoreReviewers []string
 }
 
+type escalationMessageData struct {
+	Number        int
+	Title         string
+	URL           string
+	State         string
+	SinceDays     int
+	CoreReviewers []string
+}
+
 // scheduledPrReminders sends automated PR notifications and closes stale PRs
 var scheduledPrReminders = &cobra.Command{
 	Use:   "scheduled-pr-reminders [--dry-run]",
 	Short: "Sends automated PR notifications and closes stale PRs",
-	Long:  "Sends automated PR notifications and closes stale PRs",
-	Args:  cobra.NoArgs,
+	Long: `Sends automated PR notifications and closes stale PRs.
+
+	PRs waiting on the team for --escalation-days weekdays or more are escalated weekly to the
+	team's chat space through the webhook in the PR_ESCALATION_WEBHOOK_URL environment variable,
+	if it is set.`,
+	Args: cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		githubToken, ok := os.LookupEnv("GITHUB_TOKEN")
 		if !ok {
@@ -67,11 +87,11 @@ var scheduledPrReminders = &cobra.Command{
 		}
 		gh := github.NewClient(nil).WithAuthToken(githubToken)
 		mgh := membership.NewClient(githubToken)
-		return execScheduledPrReminders(gh, mgh)
+		return execScheduledPrReminders(gh, mgh, os.Getenv("PR_ESCALATION_WEBHOOK_URL"))
 	},
 }
 
-func execScheduledPrReminders(gh *github.Client, mgh GithubClient) error {
+func execScheduledPrReminders(gh *github.Client, mgh GithubClient, escalationWebhookURL string) error {
 	ctx := context.Background()
 	opt := &github.PullRequestListOptions{
 		State:       "open",
@@ -202,6 +222,36 @@ func execScheduledPrReminders(gh *github.Client, mgh GithubClient) error {
 			}
 		}
 
+		if escalationWebhookURL != "" && shouldEscalate(pr, state, sinceDays, escalationDays) {
+			comments, err := mgh.GetPullRequestComments(fmt.Sprintf("%d", *pr.Number))
+			if err != nil {
+				return err
+			}
+			_, currentReviewer := membership.FindReviewerComment(comments)
+
+			message, err := formatEscalationMessage(pr, state, sinceDays, currentReviewer)
+			if err != nil {
+				fmt.Printf(
+					"%d/%d: PR %d: error rendering escalation: %s\n",
+					index+1,
+					len(allPulls),
+					*pr.Number,
+					err,
+				)
+			} else if dryRun {
+				fmt.Printf("DRY RUN: Would escalate: %s\n", message)
+			} else if err := postChatMessage(escalationWebhookURL, message); err != nil {
+				// Escalation is best effort; keep processing the remaining PRs.
+				fmt.Printf(
+					"%d/%d: PR %d: error escalating: %s\n",
+					index+1,
+					len(allPulls),
+					*pr.Number,
+					err,
+				)
+			}
+		}
+
 		if shouldClose(pr, state, sinceDays) {
 			if dryRun {
 				fmt.Printf("DRY RUN: Would close PR %d\n", *pr.Number)
@@ -533,6 +583,68 @@ func formatReminderComment(pullRequest *github.PullRequest, state pullRequestRev
 	return sb.String(), nil
 }
 
+// shouldEscalate reports whether a PR waiting on the team should be escalated to the team's
+// chat space. PRs are escalated once they've waited escalationDays weekdays, then weekly.
+func shouldEscalate(pr *github.PullRequest, state pullRequestReviewState, sinceDays, escalationDays int) bool {
+	for _, label := range pr.Labels {
+		if *label.Name == "disable-review-reminders" {
+			return false
+		}
+	}
+	switch state {
+	case waitingForReviewerAssignment, waitingForReview, waitingForMerge:
+		return escalationDays > 0 && sinceDays >= escalationDays && (sinceDays-escalationDays)%5 == 0
+	}
+	return false
+}
+
+func formatEscalationMessage(pullRequest *github.PullRequest, state pullRequestReviewState, sinceDays int, currentReviewer string) (string, error) {
+	tmpl, err := template.New("").Funcs(template.FuncMap{
+		"weekdaysToWeeks": func(a int) int {
+			return a / 5
+		},
+	}).Parse(escalationTemplate)
+	if err != nil {
+		panic(fmt.Sprintf("Unable to parse escalation template: %s", err))
+	}
+
+	var coreReviewers []string
+	if currentReviewer != "" {
+		coreReviewers = append(coreReviewers, currentReviewer)
+	}
+	data := escalationMessageData{
+		Number:        pullRequest.GetNumber(),
+		Title:         pullRequest.GetTitle(),
+		URL:           pullRequest.GetHTMLURL(),
+		State:         strings.ToLower(state.String()),
+		SinceDays:     sinceDays,
+		CoreReviewers: coreReviewers,
+	}
+
+	sb := new(strings.Builder)
+	if err := tmpl.Execute(sb, data); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(sb.String()), nil
+}
+
+// postChatMessage sends a text message to a chat space's incoming webhook.
+func postChatMessage(webhookURL, text string) error {
+	body, err := json.Marshal(map[string]string{"text": text})
+	if err != nil {
+		return err
+	}
+	resp, err := http.Post(webhookURL, "application/json; charset=UTF-8", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("chat webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
 func shouldClose(pr *github.PullRequest, state pullRequestReviewState, sinceDays int) bool {
 	for _, label := range pr.Labels {
 		if *label.Name == "disable-automatic-closure" {
@@ -545,4 +657,5 @@ func shouldClose(pr *github.PullRequest, state pullRequestReviewState, sinceDays
 func init() {
 	rootCmd.AddCommand(scheduledPrReminders)
 	scheduledPrReminders.Flags().BoolVar(&dryRun, "dry-run", false, "Only log write actions instead of updating PRs")
+	scheduledPrReminders.Flags().IntVar(&escalationDays, "escalation-days", 10, "Weekdays a PR can wait on the team before it is escalated to the team's chat space; 0 disables escalation")
 }
@@ -1344,6 +1344,107 @@ func TestFormatReminderComment(t *testing.T) {
 	}
 }
 
+func TestShouldEscalate(t *testing.T) {
+	cases := map[string]struct {
+		pullRequest *github.PullRequest
+		state       pullRequestReviewState
+		sinceDays   int
+		want        bool
+	}{
+		"waitingForReview too early": {
+			pullRequest: &github.PullRequest{},
+			state:       waitingForReview,
+			sinceDays:   9,
+			want:        false,
+		},
+		"waitingForReview at threshold": {
+			pullRequest: &github.PullRequest{},
+			state:       waitingForReview,
+			sinceDays:   10,
+			want:        true,
+		},
+		"waitingForReview between weekly escalations": {
+			pullRequest: &github.PullRequest{},
+			state:       waitingForReview,
+			sinceDays:   12,
+			want:        false,
+		},
+		"waitingForReview a week after threshold": {
+			pullRequest: &github.PullRequest{},
+			state:       waitingForReview,
+			sinceDays:   15,
+			want:        true,
+		},
+		"waitingForMerge at threshold": {
+			pullRequest: &github.PullRequest{},
+			state:       waitingForMerge,
+			sinceDays:   10,
+			want:        true,
+		},
+		"waitingForReviewerAssignment at threshold": {
+			pullRequest: &github.PullRequest{},
+			state:       waitingForReviewerAssignment,
+			sinceDays:   10,
+			want:        true,
+		},
+		"waitingForContributor is not escalated": {
+			pullRequest: &github.PullRequest{},
+			state:       waitingForContributor,
+			sinceDays:   10,
+			want:        false,
+		},
+		"skip with label": {
+			pullRequest: &github.PullRequest{
+				Labels: []*github.Label{{Name: github.String("disable-review-reminders")}},
+			},
+			state:     waitingForReview,
+			sinceDays: 10,
+			want:      false,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			got := shouldEscalate(tc.pullRequest, tc.state, tc.sinceDays, 10)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestFormatEscalationMessage(t *testing.T) {
+	pullRequest := &github.PullRequest{
+		Number:  github.Int(123),
+		Title:   github.String("Add google_foo_bar"),
+		HTMLURL: github.String("https://github.com/GoogleCloudPlatform/magic-modules/pull/123"),
+	}
+	cases := map[string]struct {
+		state           pullRequestReviewState
+		sinceDays       int
+		currentReviewer string
+		want            string
+	}{
+		"waitingForReview with reviewer": {
+			state:           waitingForReview,
+			sinceDays:       10,
+			currentReviewer: "reviewer",
+			want:            "<https://github.com/GoogleCloudPlatform/magic-modules/pull/123|PR #123: Add google_foo_bar> has been waiting for review for 2 weeks (reviewer: reviewer). Can someone on the team take a look?",
+		},
+		"waitingForReviewerAssignment without reviewer": {
+			state:     waitingForReviewerAssignment,
+			sinceDays: 3,
+			want:      "<https://github.com/GoogleCloudPlatform/magic-modules/pull/123|PR #123: Add google_foo_bar> has been waiting for reviewer assignment for 3 weekdays. Can someone on the team take a look?",
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			got, err := formatEscalationMessage(pullRequest, tc.state, tc.sinceDays, tc.currentReviewer)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
 func TestShouldClose(t *testing.T) {
 	cases := map[string]struct {
 		pullRequest *github.PullRequest
@@ -0,0 +1,108 @@
+/*
+* Copyright 2026 Google LLC. All Rights Reserved.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+
+	"magician/github"
+
+	_ "embed"
+)
+
+var (
+	//go:embed templates/WELCOME_COMMENT.md.tmpl
+	welcomeCommentTemplate string
+)
+
+// welcomeCommentMarker identifies the welcome comment, so that a contributor only gets it once per PR.
+const welcomeCommentMarker = "<!-- modular-magician:welcome -->"
+
+// welcomeChecklist records which kinds of files a PR changes, each of which gets its own section
+// in the welcome comment.
+type welcomeChecklist struct {
+	Author      string
+	Marker      string
+	Generated   bool
+	Handwritten bool
+	Tests       bool
+	Docs        bool
+}
+
+// isFirstTimeContributor returns whether a PR is its author's first contribution to the repository.
+func isFirstTimeContributor(pullRequest github.PullRequest) bool {
+	return pullRequest.AuthorAssociation == "FIRST_TIME_CONTRIBUTOR" || pullRequest.AuthorAssociation == "FIRST_TIMER"
+}
+
+// welcomeChecklistForFiles sorts the files a PR changes into generated resources, handwritten
+// code, tests and docs. Test files count only as tests, and mmv1 examples count as both
+// generated resources and tests since they generate the resource's tests.
+func welcomeChecklistForFiles(author string, files []string) welcomeChecklist {
+	checklist := welcomeChecklist{Author: author, Marker: welcomeCommentMarker}
+	for _, f := range files {
+		switch {
+		case strings.HasPrefix(f, "docs/") || strings.HasPrefix(f, "mmv1/third_party/terraform/website/"):
+			checklist.Docs = true
+		case strings.HasSuffix(f, "_test.go") || strings.HasSuffix(f, "_test.go.tmpl"):
+			checklist.Tests = true
+		case strings.HasPrefix(f, "mmv1/templates/terraform/examples/"):
+			checklist.Generated = true
+			checklist.Tests = true
+		case strings.HasPrefix(f, "mmv1/products/") || strings.HasPrefix(f, "mmv1/templates/"):
+			checklist.Generated = true
+		case strings.HasPrefix(f, "mmv1/third_party/"):
+			checklist.Handwritten = true
+		}
+	}
+	return checklist
+}
+
+func formatWelcomeComment(checklist welcomeChecklist) (string, error) {
+	tmpl, err := template.New("WELCOME_COMMENT.md.tmpl").Parse(welcomeCommentTemplate)
+	if err != nil {
+		return "", fmt.Errorf("unable to parse template WELCOME_COMMENT.md.tmpl: %s", err)
+	}
+	sb := new(strings.Builder)
+	if err := tmpl.Execute(sb, checklist); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+// postWelcomeComment posts the welcome checklist on a first-time contributor's PR, unless the PR
+// already has one.
+func postWelcomeComment(prNumber string, pullRequest github.PullRequest, gh GithubClient) error {
+	comments, err := gh.GetPullRequestComments(prNumber)
+	if err != nil {
+		return err
+	}
+	for _, c := range comments {
+		if strings.Contains(c.Body, welcomeCommentMarker) {
+			return nil
+		}
+	}
+	files, err := gh.GetPullRequestFiles(prNumber)
+	if err != nil {
+		return err
+	}
+	comment, err := formatWelcomeComment(welcomeChecklistForFiles(pullRequest.User.Login, files))
+	if err != nil {
+		return err
+	}
+	return gh.PostComment(prNumber, comment)
+}
@@ -0,0 +1,160 @@
+/*
+* Copyright 2026 Google LLC. All Rights Reserved.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"magician/github"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWelcomeChecklistForFiles(t *testing.T) {
+	cases := map[string]struct {
+		files    []string
+		expected welcomeChecklist
+	}{
+		"generated resource": {
+			files:    []string{"mmv1/products/redis/Instance.yaml", "mmv1/templates/terraform/custom_expand/redis.go.tmpl"},
+			expected: welcomeChecklist{Generated: true},
+		},
+		"example": {
+			files:    []string{"mmv1/templates/terraform/examples/redis_instance_basic.tf.tmpl"},
+			expected: welcomeChecklist{Generated: true, Tests: true},
+		},
+		"handwritten resource and test": {
+			files: []string{
+				"mmv1/third_party/terraform/services/compute/resource_compute_instance.go.tmpl",
+				"mmv1/third_party/terraform/services/compute/resource_compute_instance_test.go.tmpl",
+			},
+			expected: welcomeChecklist{Handwritten: true, Tests: true},
+		},
+		"resource docs and docsite": {
+			files:    []string{"mmv1/third_party/terraform/website/docs/r/compute_instance.html.markdown", "docs/content/develop/add-fields.md"},
+			expected: welcomeChecklist{Docs: true},
+		},
+		"other files": {
+			files: []string{".ci/magician/cmd/root.go", "README.md"},
+		},
+	}
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			tc.expected.Author = "author"
+			tc.expected.Marker = welcomeCommentMarker
+			assert.Equal(t, tc.expected, welcomeChecklistForFiles("author", tc.files))
+		})
+	}
+}
+
+func TestFormatWelcomeComment(t *testing.T) {
+	cases := map[string]struct {
+		checklist          welcomeChecklist
+		expectedStrings    []string
+		notExpectedStrings []string
+	}{
+		"generated and tests": {
+			checklist: welcomeChecklist{Author: "author", Marker: welcomeCommentMarker, Generated: true, Tests: true},
+			expectedStrings: []string{
+				"Welcome @author",
+				"**Generated resources**",
+				"PRODUCT=<product>",
+				"**Tests**",
+				"make testacc",
+				"release notes",
+				welcomeCommentMarker,
+			},
+			notExpectedStrings: []string{"**Handwritten code**", "**Documentation**"},
+		},
+		"handwritten and docs": {
+			checklist: welcomeChecklist{Author: "author", Marker: welcomeCommentMarker, Handwritten: true, Docs: true},
+			expectedStrings: []string{
+				"**Handwritten code**",
+				"PRODUCT=doesnotexist",
+				"**Documentation**",
+				"hugo server",
+			},
+			notExpectedStrings: []string{"**Generated resources**", "**Tests**"},
+		},
+	}
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			comment, err := formatWelcomeComment(tc.checklist)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, s := range tc.expectedStrings {
+				if !strings.Contains(comment, s) {
+					t.Errorf("expected comment to contain %q:\n%s", s, comment)
+				}
+			}
+			for _, s := range tc.notExpectedStrings {
+				if strings.Contains(comment, s) {
+					t.Errorf("expected comment not to contain %q:\n%s", s, comment)
+				}
+			}
+		})
+	}
+}
+
+func TestExecRequestReviewerWelcomeComment(t *testing.T) {
+	cases := map[string]struct {
+		authorAssociation string
+		comments          []github.PullRequestComment
+		expectWelcome     bool
+	}{
+		"first-time contributor": {
+			authorAssociation: "FIRST_TIME_CONTRIBUTOR",
+			expectWelcome:     true,
+		},
+		"first-time GitHub user": {
+			authorAssociation: "FIRST_TIMER",
+			expectWelcome:     true,
+		},
+		"already welcomed": {
+			authorAssociation: "FIRST_TIME_CONTRIBUTOR",
+			comments:          []github.PullRequestComment{{Body: "Welcome!\n" + welcomeCommentMarker}},
+		},
+		"returning contributor": {
+			authorAssociation: "CONTRIBUTOR",
+		},
+	}
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			gh := &mockGithub{
+				pullRequest: github.PullRequest{
+					User:              github.User{Login: "author"},
+					AuthorAssociation: tc.authorAssociation,
+				},
+				pullRequestComments: tc.comments,
+				pullRequestFiles:    []string{"mmv1/products/redis/Instance.yaml"},
+				calledMethods:       make(map[string][][]any),
+			}
+			if err := execRequestReviewer("1", gh); err != nil {
+				t.Fatal(err)
+			}
+			welcomed := false
+			for _, args := range gh.calledMethods["PostComment"] {
+				if strings.Contains(args[1].(string), welcomeCommentMarker) {
+					welcomed = true
+					assert.Contains(t, args[1].(string), "**Generated resources**")
+				}
+			}
+			assert.Equal(t, tc.expectWelcome, welcomed)
+		})
+	}
+}
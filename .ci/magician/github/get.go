@@ -16,7 +16,10 @@
 package github
 
 import (
+	"errors"
 	"fmt"
+	"net/http"
+	"sort"
 	"strconv"
 	"time"
 
@@ -38,14 +41,30 @@ type Label struct {
 }
 
 type PullRequest struct {
-	HTMLUrl        string  `json:"html_url"`
-	Number         int     `json:"number"`
-	Title          string  `json:"title"`
-	User           User    `json:"user"`
-	Body           string  `json:"body"`
-	Labels         []Label `json:"labels"`
-	MergeCommitSha string  `json:"merge_commit_sha"`
-	Merged         bool    `json:"merged"`
+	HTMLUrl           string  `json:"html_url"`
+	Number            int     `json:"number"`
+	Title             string  `json:"title"`
+	User              User    `json:"user"`
+	Body              string  `json:"body"`
+	Labels            []Label `json:"labels"`
+	MergeCommitSha    string  `json:"merge_commit_sha"`
+	HeadSha           string  `json:"head_sha"`
+	Merged            bool    `json:"merged"`
+	Draft             bool    `json:"draft"`
+	MergeableState    string  `json:"mergeable_state"`
+	AuthorAssociation string  `json:"author_association"`
+}
+
+type PullRequestFile struct {
+	Filename  string `json:"filename"`
+	Additions int    `json:"additions"`
+	Deletions int    `json:"deletions"`
+}
+
+type PullRequestReview struct {
+	User        User      `json:"user"`
+	State       string    `json:"state"`
+	SubmittedAt time.Time `json:"submitted_at"`
 }
 
 type PullRequestComment struct {
@@ -92,6 +111,33 @@ func (c *Client) GetPullRequests(state, base, sort, direction string) ([]PullReq
 	return result, nil
 }
 
+// GetOpenPullRequests fetches all open pull requests against base, oldest first, handling pagination
+func (c *Client) GetOpenPullRequests(base string) ([]PullRequest, error) {
+	opts := &gh.PullRequestListOptions{
+		State:       "open",
+		Base:        base,
+		Sort:        "created",
+		Direction:   "asc",
+		ListOptions: gh.ListOptions{PerPage: 100},
+	}
+
+	var result []PullRequest
+	for {
+		prs, resp, err := c.gh.PullRequests.List(c.ctx, defaultOwner, defaultRepo, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, pr := range prs {
+			result = append(result, convertGHPullRequest(pr))
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return result, nil
+}
+
 // GetPullRequestRequestedReviewers gets requested reviewers for a PR
 func (c *Client) GetPullRequestRequestedReviewers(prNumber string) ([]User, error) {
 	num, err := strconv.Atoi(prNumber)
@@ -107,6 +153,35 @@ func (c *Client) GetPullRequestRequestedReviewers(prNumber string) ([]User, erro
 	return convertGHUsers(reviewers.Users), nil
 }
 
+// GetPullRequestReviews gets all reviews on a PR in submission order, handling pagination
+func (c *Client) GetPullRequestReviews(prNumber string) ([]PullRequestReview, error) {
+	num, err := strconv.Atoi(prNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	var reviews []PullRequestReview
+	opts := &gh.ListOptions{PerPage: 100}
+	for {
+		page, resp, err := c.gh.PullRequests.ListReviews(c.ctx, defaultOwner, defaultRepo, num, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, review := range page {
+			reviews = append(reviews, PullRequestReview{
+				User:        convertGHUser(review.User),
+				State:       review.GetState(),
+				SubmittedAt: review.GetSubmittedAt().Time,
+			})
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return reviews, nil
+}
+
 // GetPullRequestPreviousReviewers gets previous reviewers for a PR
 func (c *Client) GetPullRequestPreviousReviewers(prNumber string) ([]User, error) {
 	num, err := strconv.Atoi(prNumber)
@@ -137,6 +212,57 @@ func (c *Client) GetPullRequestPreviousReviewers(prNumber string) ([]User, error
 	return convertGHUsers(reviewers), nil
 }
 
+// GetPullRequestFiles gets the paths of all files changed by a PR, handling pagination
+func (c *Client) GetPullRequestFiles(prNumber string) ([]string, error) {
+	changes, err := c.GetPullRequestFileChanges(prNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]string, len(changes))
+	for i, f := range changes {
+		files[i] = f.Filename
+	}
+	return files, nil
+}
+
+// GetPullRequestFileChanges gets the files changed in a PR with their line counts, handling pagination
+func (c *Client) GetPullRequestFileChanges(prNumber string) ([]PullRequestFile, error) {
+	num, err := strconv.Atoi(prNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []PullRequestFile
+	opts := &gh.ListOptions{
+		PerPage: 100,
+	}
+
+	for {
+		commitFiles, resp, err := c.gh.PullRequests.ListFiles(c.ctx, defaultOwner, defaultRepo, num, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, f := range commitFiles {
+			files = append(files, PullRequestFile{
+				Filename:  f.GetFilename(),
+				Additions: f.GetAdditions(),
+				Deletions: f.GetDeletions(),
+			})
+		}
+
+		if resp.NextPage == 0 {
+			break // No more pages
+		}
+
+		// Set up for the next page
+		opts.Page = resp.NextPage
+	}
+
+	return files, nil
+}
+
 // GetCommitMessage gets a commit message
 func (c *Client) GetCommitMessage(owner, repo, sha string) (string, error) {
 	commit, _, err := c.gh.Repositories.GetCommit(c.ctx, owner, repo, sha, nil)
@@ -151,6 +277,82 @@ func (c *Client) GetCommitMessage(owner, repo, sha string) (string, error) {
 	return "", fmt.Errorf("no commit message found")
 }
 
+// GetPendingRequiredChecks returns the checks required to merge into a branch that haven't
+// finished on a commit, including those that haven't reported yet. Checks that aren't required
+// are left out.
+func (c *Client) GetPendingRequiredChecks(branch, commitSha string) ([]string, error) {
+	required, _, err := c.gh.Repositories.GetRequiredStatusChecks(c.ctx, defaultOwner, defaultRepo, branch)
+	if errors.Is(err, gh.ErrBranchNotProtected) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	// finished records whether each required check has finished on the commit.
+	finished := make(map[string]bool)
+	for _, check := range required.GetChecks() {
+		finished[check.Context] = false
+	}
+	for _, context := range required.GetContexts() {
+		finished[context] = false
+	}
+	if len(finished) == 0 {
+		return nil, nil
+	}
+
+	statusOpts := &gh.ListOptions{PerPage: 100}
+	for {
+		status, resp, err := c.gh.Repositories.GetCombinedStatus(c.ctx, defaultOwner, defaultRepo, commitSha, statusOpts)
+		if err != nil {
+			return nil, err
+		}
+		for _, repoStatus := range status.Statuses {
+			if _, ok := finished[repoStatus.GetContext()]; ok {
+				finished[repoStatus.GetContext()] = repoStatus.GetState() != "pending"
+			}
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		statusOpts.Page = resp.NextPage
+	}
+
+	checkRunOpts := &gh.ListCheckRunsOptions{ListOptions: gh.ListOptions{PerPage: 100}}
+	for {
+		results, resp, err := c.gh.Checks.ListCheckRunsForRef(c.ctx, defaultOwner, defaultRepo, commitSha, checkRunOpts)
+		if err != nil {
+			return nil, err
+		}
+		for _, checkRun := range results.CheckRuns {
+			if _, ok := finished[checkRun.GetName()]; ok {
+				finished[checkRun.GetName()] = checkRun.GetStatus() == "completed"
+			}
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		checkRunOpts.Page = resp.NextPage
+	}
+
+	var pending []string
+	for name, done := range finished {
+		if !done {
+			pending = append(pending, name)
+		}
+	}
+	sort.Strings(pending)
+	return pending, nil
+}
+
+// GetCommitDate returns when a commit was committed
+func (c *Client) GetCommitDate(commitSha string) (time.Time, error) {
+	commit, _, err := c.gh.Repositories.GetCommit(c.ctx, defaultOwner, defaultRepo, commitSha, nil)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return commit.GetCommit().GetCommitter().GetDate().Time, nil
+}
+
 // GetPullRequestComments gets all comments on a PR, handling pagination
 func (c *Client) GetPullRequestComments(prNumber string) ([]PullRequestComment, error) {
 	num, err := strconv.Atoi(prNumber)
@@ -212,9 +414,29 @@ func (c *Client) GetTeamMembers(organization, team string) ([]User, error) {
 	return convertGHUsers(allMembers), nil
 }
 
+// GetOpenReviewCounts returns how many open pull requests each user has a pending review request on
+func (c *Client) GetOpenReviewCounts(users []string) (map[string]int, error) {
+	counts := make(map[string]int, len(users))
+	opts := &gh.SearchOptions{
+		ListOptions: gh.ListOptions{
+			PerPage: 1, // Only the total count is needed
+		},
+	}
+	for _, user := range users {
+		query := fmt.Sprintf("repo:%s/%s is:pr is:open review-requested:%s", defaultOwner, defaultRepo, user)
+		result, _, err := c.gh.Search.Issues(c.ctx, query, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count open reviews for %s: %w", user, err)
+		}
+		counts[user] = result.GetTotal()
+	}
+
+	return counts, nil
+}
+
 // IsOrgMember checks if a user is a member of an organization
 func (c *Client) IsOrgMember(username, org string) bool {
-	isMember, _, err := c.gh.Organizations.IsMember(c.ctx, org, username)
+	isMember, err := c.orgMembership(org, username)
 	if err != nil {
 		return false
 	}
@@ -224,10 +446,66 @@ func (c *Client) IsOrgMember(username, org string) bool {
 
 // IsTeamMember checks if a user is a member of a team
 func (c *Client) IsTeamMember(organization, teamSlug, username string) bool {
-	membership, _, err := c.gh.Teams.GetTeamMembershipBySlug(c.ctx, organization, teamSlug, username)
+	isMember, err := c.teamMembership(organization, teamSlug, username)
 	if err != nil {
 		return false
 	}
 
-	return membership != nil && membership.State != nil && *membership.State == "active"
+	return isMember
+}
+
+// orgMembership checks if a user is a member of an organization, returning an error only if
+// GitHub couldn't answer. Answers are cached.
+func (c *Client) orgMembership(org, username string) (bool, error) {
+	key := "org/" + org + "/" + username
+	if isMember, ok := c.cachedMembership(key); ok {
+		return isMember, nil
+	}
+
+	isMember, _, err := c.gh.Organizations.IsMember(c.ctx, org, username)
+	if err != nil {
+		return false, err
+	}
+
+	c.cacheMembership(key, isMember)
+	return isMember, nil
+}
+
+// teamMembership checks if a user is an active member of a team, returning an error only if
+// GitHub couldn't answer. Answers are cached.
+func (c *Client) teamMembership(organization, teamSlug, username string) (bool, error) {
+	key := "team/" + organization + "/" + teamSlug + "/" + username
+	if isMember, ok := c.cachedMembership(key); ok {
+		return isMember, nil
+	}
+
+	membership, _, err := c.gh.Teams.GetTeamMembershipBySlug(c.ctx, organization, teamSlug, username)
+	var errResp *gh.ErrorResponse
+	if errors.As(err, &errResp) && errResp.Response != nil && errResp.Response.StatusCode == http.StatusNotFound {
+		// GitHub responds with 404 for users who aren't on the team.
+		membership, err = nil, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	isMember := membership != nil && membership.State != nil && *membership.State == "active"
+	c.cacheMembership(key, isMember)
+	return isMember, nil
+}
+
+func (c *Client) cachedMembership(key string) (bool, bool) {
+	c.membershipsMu.Lock()
+	defer c.membershipsMu.Unlock()
+	isMember, ok := c.memberships[key]
+	return isMember, ok
+}
+
+func (c *Client) cacheMembership(key string, isMember bool) {
+	c.membershipsMu.Lock()
+	defer c.membershipsMu.Unlock()
+	if c.memberships == nil {
+		c.memberships = make(map[string]bool)
+	}
+	c.memberships[key] = isMember
 }
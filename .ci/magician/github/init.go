@@ -20,6 +20,7 @@ import (
 	"context"
 	"io"
 	"net/http"
+	"sync"
 
 	utils "magician/utility"
 
@@ -31,6 +32,10 @@ type Client struct {
 	token string
 	gh    *gh.Client
 	ctx   context.Context
+
+	// memberships caches organization and team membership lookups for the life of the client.
+	membershipsMu sync.Mutex
+	memberships   map[string]bool
 }
 
 // retryTransport is a custom RoundTripper that adds retry and logging
@@ -45,7 +45,7 @@ func (ut UserType) String() string {
 }
 
 func (gh *Client) GetUserType(user string) UserType {
-	if IsCoreContributor(user) {
+	if gh.IsCoreContributor(user) {
 		fmt.Println("User is a core contributor")
 		return CoreContributorUserType
 	}
@@ -70,10 +70,35 @@ func (gh *Client) GetUserType(user string) UserType {
 	return CommunityUserType
 }
 
-// Check if a user is team member to not request a random reviewer
-func IsCoreContributor(user string) bool {
-	_, isTrustedContributor := trustedContributors[user]
-	return IsCoreReviewer(user) || isTrustedContributor
+// Membership of these is queried live to decide who maintains the repository.
+const (
+	trustedOrganization = "GoogleCloudPlatform"
+	maintainerTeam      = "terraform"
+)
+
+// coreContributorTeam is the team in trustedOrganization whose members are treated as core
+// contributors without being in the review rotation, e.g. maintainers who are onboarding.
+const coreContributorTeam = "terraform-core-contributors"
+
+// IsMaintainer reports whether user is an active member of the maintainer team.
+func (gh *Client) IsMaintainer(user string) bool {
+	return gh.IsTeamMember(trustedOrganization, maintainerTeam, user)
+}
+
+// IsCoreContributor reports whether user is a core reviewer or a member of the core contributor
+// team, so that their PRs don't get a random reviewer. If the team can't be queried, the static
+// trustedContributors list is used instead.
+func (gh *Client) IsCoreContributor(user string) bool {
+	if IsCoreReviewer(user) {
+		return true
+	}
+	isMember, err := gh.teamMembership(trustedOrganization, coreContributorTeam, user)
+	if err != nil {
+		fmt.Printf("Error checking %s team membership for %s, falling back to the static list: %s\n", coreContributorTeam, user, err)
+		_, isTrustedContributor := trustedContributors[user]
+		return isTrustedContributor
+	}
+	return isMember
 }
 
 func IsCoreReviewer(user string) bool {
@@ -88,6 +113,34 @@ func GetRandomReviewer(excludedReviewers []string) string {
 	return reviewer
 }
 
+// GetLeastLoadedReviewer returns the available reviewer with the fewest open review requests
+// (optionally excluding some people from the reviewer pool), choosing randomly between ties.
+// Reviewers missing from openReviews are treated as having none. Returns "" if nobody is available.
+func GetLeastLoadedReviewer(excludedReviewers []string, openReviews map[string]int) string {
+	candidates := leastLoaded(AvailableReviewers(excludedReviewers), openReviews)
+	if len(candidates) == 0 {
+		return ""
+	}
+	return candidates[rand.Intn(len(candidates))]
+}
+
+// leastLoaded returns the reviewers tied for the fewest open review requests.
+func leastLoaded(reviewers []string, openReviews map[string]int) []string {
+	var ret []string
+	lowest := -1
+	for _, reviewer := range reviewers {
+		count := openReviews[reviewer]
+		switch {
+		case lowest == -1 || count < lowest:
+			lowest = count
+			ret = []string{reviewer}
+		case count == lowest:
+			ret = append(ret, reviewer)
+		}
+	}
+	return ret
+}
+
 func AvailableReviewers(excludedReviewers []string) []string {
 	return available(time.Now(), reviewerRotation, excludedReviewers)
 }
@@ -177,7 +177,8 @@ var (
 		},
 	}
 
-	// This is for new team members who are onboarding
+	// Fallback for the terraform-core-contributors team, used when GitHub can't be queried. New
+	// team members who are onboarding should be added to the team rather than here.
 	trustedContributors = map[string]struct{}{
 		"bbasata": struct{}{},
 	}
@@ -16,10 +16,15 @@
 package github
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	gh "github.com/google/go-github/v68/github"
 )
 
 func TestTrustedContributors(t *testing.T) {
@@ -253,3 +258,159 @@ func TestAvailable(t *testing.T) {
 	}
 
 }
+
+func TestLeastLoaded(t *testing.T) {
+	tests := []struct {
+		name        string
+		reviewers   []string
+		openReviews map[string]int
+		want        []string
+	}{
+		{
+			name:        "single least loaded reviewer",
+			reviewers:   []string{"id1", "id2", "id3"},
+			openReviews: map[string]int{"id1": 5, "id2": 2, "id3": 7},
+			want:        []string{"id2"},
+		},
+		{
+			name:        "ties are all returned",
+			reviewers:   []string{"id1", "id2", "id3"},
+			openReviews: map[string]int{"id1": 3, "id2": 5, "id3": 3},
+			want:        []string{"id1", "id3"},
+		},
+		{
+			name:        "missing counts are treated as zero",
+			reviewers:   []string{"id1", "id2"},
+			openReviews: map[string]int{"id1": 1},
+			want:        []string{"id2"},
+		},
+		{
+			name:        "no counts means everyone is tied",
+			reviewers:   []string{"id1", "id2"},
+			openReviews: nil,
+			want:        []string{"id1", "id2"},
+		},
+		{
+			name:      "no reviewers",
+			reviewers: nil,
+			want:      nil,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := leastLoaded(test.reviewers, test.openReviews)
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("leastLoaded(%v, %v) got diff: %s", test.reviewers, test.openReviews, diff)
+			}
+		})
+	}
+}
+
+// newTestClient returns a client that sends GitHub API requests to handler.
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	ghClient := gh.NewClient(nil)
+	ghClient.BaseURL, _ = url.Parse(server.URL + "/")
+	return &Client{gh: ghClient, ctx: context.Background()}
+}
+
+func TestIsCoreContributor(t *testing.T) {
+	tests := []struct {
+		name         string
+		user         string
+		status       int
+		body         string
+		want         bool
+		wantRequests int
+	}{
+		{
+			name:         "core reviewers aren't looked up",
+			user:         "melinath",
+			want:         true,
+			wantRequests: 0,
+		},
+		{
+			name:         "active team member",
+			user:         "author",
+			status:       http.StatusOK,
+			body:         `{"state": "active"}`,
+			want:         true,
+			wantRequests: 1,
+		},
+		{
+			name:         "pending team member",
+			user:         "author",
+			status:       http.StatusOK,
+			body:         `{"state": "pending"}`,
+			want:         false,
+			wantRequests: 1,
+		},
+		{
+			name:         "not a team member",
+			user:         "author",
+			status:       http.StatusNotFound,
+			body:         `{"message": "Not Found"}`,
+			want:         false,
+			wantRequests: 1,
+		},
+		{
+			name:         "falls back to the static list and doesn't cache errors",
+			user:         "bbasata",
+			status:       http.StatusInternalServerError,
+			want:         true,
+			wantRequests: 2,
+		},
+		{
+			name:         "not on the static list",
+			user:         "author",
+			status:       http.StatusInternalServerError,
+			want:         false,
+			wantRequests: 2,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			requests := 0
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				if r.URL.Path != "/orgs/GoogleCloudPlatform/teams/terraform-core-contributors/memberships/"+tc.user {
+					t.Errorf("unexpected request to %s", r.URL.Path)
+				}
+				w.WriteHeader(tc.status)
+				w.Write([]byte(tc.body))
+			})
+			for i := 0; i < 2; i++ {
+				if got := client.IsCoreContributor(tc.user); got != tc.want {
+					t.Errorf("IsCoreContributor(%q) = %v, want %v", tc.user, got, tc.want)
+				}
+			}
+			if requests != tc.wantRequests {
+				t.Errorf("got %d requests, want %d", requests, tc.wantRequests)
+			}
+		})
+	}
+}
+
+func TestIsOrgMemberCached(t *testing.T) {
+	requests := 0
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path == "/orgs/GoogleCloudPlatform/members/member" {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	})
+	for i := 0; i < 2; i++ {
+		if !client.IsOrgMember("member", "GoogleCloudPlatform") {
+			t.Errorf("expected member to be an org member")
+		}
+		if client.IsOrgMember("other", "GoogleCloudPlatform") {
+			t.Errorf("expected other not to be an org member")
+		}
+	}
+	if requests != 2 {
+		t.Errorf("got %d requests, want 2", requests)
+	}
+}
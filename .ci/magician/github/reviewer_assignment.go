@@ -20,6 +20,7 @@ import (
 	"regexp"
 	"strings"
 	"text/template"
+	"time"
 
 	_ "embed"
 )
@@ -29,8 +30,24 @@ var (
 	reviewerAssignmentComment string
 )
 
-// Returns a list of users to request review from, as well as a new primary reviewer if this is the first run.
-func ChooseCoreReviewers(requestedReviewers, previousReviewers []User) (reviewersToRequest []string, newPrimaryReviewer string) {
+// Reasons a reviewer was assigned, recorded in the reviewer comment's assignment history.
+const (
+	AssignedByRotation    = "weekly rotation"
+	AssignedByLeastLoaded = "fewest open reviews"
+)
+
+// ReviewerAssignment is an entry in the assignment history of a PR's primary reviewer.
+type ReviewerAssignment struct {
+	Date     time.Time
+	Reviewer string
+	Reason   string
+}
+
+// Returns a list of users to request review from, as well as a new primary reviewer and the reason they
+// were chosen if this is the first run. The new primary reviewer is this week's reviewer from the reviewer
+// schedule, or if nobody in it is available, the available reviewer with the fewest open review requests,
+// as reported by openReviews. If openReviews is nil or fails, a random available reviewer is chosen instead.
+func ChooseCoreReviewers(requestedReviewers, previousReviewers []User, openReviews func(reviewers []string) (map[string]int, error)) (reviewersToRequest []string, newPrimaryReviewer, reason string) {
 	hasPrimaryReviewer := false
 	newPrimaryReviewer = ""
 
@@ -49,14 +66,41 @@ func ChooseCoreReviewers(requestedReviewers, previousReviewers []User) (reviewer
 	}
 
 	if !hasPrimaryReviewer {
-		newPrimaryReviewer = GetRandomReviewer(nil)
+		newPrimaryReviewer, reason = ChooseNewPrimaryReviewer(nil, openReviews)
 		reviewersToRequest = append(reviewersToRequest, newPrimaryReviewer)
 	}
 
-	return reviewersToRequest, newPrimaryReviewer
+	return reviewersToRequest, newPrimaryReviewer, reason
+}
+
+// ChooseNewPrimaryReviewer picks the on-call reviewer from the reviewer schedule (optionally excluding some
+// people from the reviewer pool), falling back to the least loaded available reviewer. It also returns the
+// reason the reviewer was chosen.
+func ChooseNewPrimaryReviewer(excludedReviewers []string, openReviews func(reviewers []string) (map[string]int, error)) (string, string) {
+	if reviewer := OnCallReviewer(excludedReviewers); reviewer != "" {
+		return reviewer, AssignedByRotation
+	}
+	return ChooseLeastLoadedReviewer(excludedReviewers, openReviews), AssignedByLeastLoaded
+}
+
+// ChooseLeastLoadedReviewer picks the least loaded available reviewer (optionally excluding some people
+// from the reviewer pool), falling back to a random one if review counts can't be loaded.
+func ChooseLeastLoadedReviewer(excludedReviewers []string, openReviews func(reviewers []string) (map[string]int, error)) string {
+	if openReviews == nil {
+		return GetRandomReviewer(excludedReviewers)
+	}
+	counts, err := openReviews(AvailableReviewers(excludedReviewers))
+	if err != nil {
+		fmt.Printf("Failed to get open review counts, choosing a random reviewer: %s\n", err)
+		return GetRandomReviewer(excludedReviewers)
+	}
+	fmt.Printf("Open review counts: %v\n", counts)
+	return GetLeastLoadedReviewer(excludedReviewers, counts)
 }
 
-func FormatReviewerComment(newPrimaryReviewer string) string {
+// FormatReviewerComment returns the comment announcing newPrimaryReviewer, followed by the PR's
+// reviewer assignment history (oldest first) if any is given.
+func FormatReviewerComment(newPrimaryReviewer string, history ...ReviewerAssignment) string {
 	tmpl, err := template.New("REVIEWER_ASSIGNMENT_COMMENT.md").Parse(reviewerAssignmentComment)
 	if err != nil {
 		panic(fmt.Sprintf("Unable to parse REVIEWER_ASSIGNMENT_COMMENT.md: %s", err))
@@ -64,10 +108,32 @@ func FormatReviewerComment(newPrimaryReviewer string) string {
 	sb := new(strings.Builder)
 	tmpl.Execute(sb, map[string]any{
 		"reviewer": newPrimaryReviewer,
+		"history":  history,
 	})
 	return sb.String()
 }
 
+// Matches an entry of the assignment history in a reviewer comment, like "- 2026-01-05 `trodge`: weekly rotation"
+var reviewerAssignmentRegex = regexp.MustCompile("(?m)^- (\\d{4}-\\d{2}-\\d{2}) `([a-zA-Z0-9-_]+)`: (.+)$")
+
+// ReviewerAssignmentHistory returns the assignment history recorded in a reviewer comment, oldest first.
+// Entries that can't be parsed are skipped.
+func ReviewerAssignmentHistory(comment string) []ReviewerAssignment {
+	var history []ReviewerAssignment
+	for _, match := range reviewerAssignmentRegex.FindAllStringSubmatch(comment, -1) {
+		date, err := time.Parse(scheduleDateLayout, match[1])
+		if err != nil {
+			continue
+		}
+		history = append(history, ReviewerAssignment{
+			Date:     date,
+			Reviewer: match[2],
+			Reason:   strings.TrimSpace(match[3]),
+		})
+	}
+	return history
+}
+
 var reviewerCommentRegex = regexp.MustCompile("@(?P<reviewer>[^,]*), a repository maintainer, has been assigned")
 
 // FindReviewerComment returns the comment which mentions the current primary reviewer and the reviewer's login,
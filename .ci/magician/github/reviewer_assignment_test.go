@@ -82,7 +82,7 @@ func TestChooseCoreReviewers(t *testing.T) {
 		tc := tc
 		t.Run(tn, func(t *testing.T) {
 			t.Parallel()
-			reviewers, primaryReviewer := ChooseCoreReviewers(tc.RequestedReviewers, tc.PreviousReviewers)
+			reviewers, primaryReviewer, _ := ChooseCoreReviewers(tc.RequestedReviewers, tc.PreviousReviewers, nil)
 			if tc.ExpectPrimaryReviewer && primaryReviewer == "" {
 				t.Error("wanted primary reviewer to be returned; got none")
 			}
@@ -207,3 +207,20 @@ func TestFindReviewerComment(t *testing.T) {
 		})
 	}
 }
+
+func TestReviewerAssignmentHistory(t *testing.T) {
+	history := []ReviewerAssignment{
+		{Date: time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC), Reviewer: "trodge", Reason: AssignedByRotation},
+		{Date: time.Date(2026, 1, 7, 0, 0, 0, 0, time.UTC), Reviewer: "c2thorn", Reason: "reassigned by melinath"},
+	}
+	comment := FormatReviewerComment("c2thorn", history...)
+	if _, reviewer := FindReviewerComment([]PullRequestComment{{Body: comment}}); reviewer != "c2thorn" {
+		t.Errorf("wanted reviewer to be c2thorn; got %s", reviewer)
+	}
+	if got := ReviewerAssignmentHistory(comment); !slices.Equal(got, history) {
+		t.Errorf("wanted history %v; got %v", history, got)
+	}
+	if got := ReviewerAssignmentHistory(FormatReviewerComment("trodge")); len(got) != 0 {
+		t.Errorf("wanted no history; got %v", got)
+	}
+}
@@ -0,0 +1,176 @@
+/*
+* Copyright 2026 Google LLC. All Rights Reserved.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+package github
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	_ "embed"
+
+	"gopkg.in/yaml.v2"
+)
+
+var (
+	//go:embed reviewer_schedule.yaml
+	reviewerScheduleYaml []byte
+)
+
+const scheduleDateLayout = "2006-01-02"
+
+// scheduleFile is the format of reviewer_schedule.yaml.
+type scheduleFile struct {
+	Start    string `yaml:"start"`
+	Timezone string `yaml:"timezone"`
+	Rotation []struct {
+		Login     string `yaml:"login"`
+		Timezone  string `yaml:"timezone"`
+		Vacations []struct {
+			Start string `yaml:"start"`
+			End   string `yaml:"end"`
+		} `yaml:"vacations"`
+	} `yaml:"rotation"`
+}
+
+type reviewerSchedule struct {
+	// start is the first day of the first week of the rotation, in the schedule's timezone.
+	start time.Time
+	// rotation lists reviewers in rotation order.
+	rotation []string
+	// reviewers holds the timezone and vacations of each reviewer in the rotation.
+	reviewers map[string]ReviewerConfig
+}
+
+func parseReviewerSchedule(data []byte) (reviewerSchedule, error) {
+	var file scheduleFile
+	if err := yaml.UnmarshalStrict(data, &file); err != nil {
+		return reviewerSchedule{}, fmt.Errorf("error unmarshalling reviewer schedule: %w", err)
+	}
+
+	timezone, err := loadTimezone(file.Timezone)
+	if err != nil {
+		return reviewerSchedule{}, err
+	}
+	start, err := time.ParseInLocation(scheduleDateLayout, file.Start, timezone)
+	if err != nil {
+		return reviewerSchedule{}, fmt.Errorf("invalid start date %q: %w", file.Start, err)
+	}
+	if start.Weekday() != time.Monday {
+		return reviewerSchedule{}, fmt.Errorf("start date %s is a %s, not a Monday", file.Start, start.Weekday())
+	}
+	if len(file.Rotation) == 0 {
+		return reviewerSchedule{}, fmt.Errorf("rotation is empty")
+	}
+
+	schedule := reviewerSchedule{
+		start:     start,
+		reviewers: make(map[string]ReviewerConfig, len(file.Rotation)),
+	}
+	for _, r := range file.Rotation {
+		if !IsCoreReviewer(r.Login) {
+			return reviewerSchedule{}, fmt.Errorf("%q is not a core reviewer", r.Login)
+		}
+		if _, ok := schedule.reviewers[r.Login]; ok {
+			return reviewerSchedule{}, fmt.Errorf("%q is in the rotation more than once", r.Login)
+		}
+		config := ReviewerConfig{}
+		if r.Timezone != "" {
+			if config.timezone, err = loadTimezone(r.Timezone); err != nil {
+				return reviewerSchedule{}, fmt.Errorf("%s: %w", r.Login, err)
+			}
+		}
+		for _, v := range r.Vacations {
+			vacation, err := parseVacation(v.Start, v.End)
+			if err != nil {
+				return reviewerSchedule{}, fmt.Errorf("%s: %w", r.Login, err)
+			}
+			config.vacations = append(config.vacations, vacation)
+		}
+		schedule.rotation = append(schedule.rotation, r.Login)
+		schedule.reviewers[r.Login] = config
+	}
+	return schedule, nil
+}
+
+func loadTimezone(name string) (*time.Location, error) {
+	if name == "" {
+		return usPacific, nil
+	}
+	timezone, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone %q: %w", name, err)
+	}
+	return timezone, nil
+}
+
+func parseVacation(start, end string) (Vacation, error) {
+	startDate, err := time.Parse(scheduleDateLayout, start)
+	if err != nil {
+		return Vacation{}, fmt.Errorf("invalid vacation start date %q: %w", start, err)
+	}
+	endDate, err := time.Parse(scheduleDateLayout, end)
+	if err != nil {
+		return Vacation{}, fmt.Errorf("invalid vacation end date %q: %w", end, err)
+	}
+	if endDate.Before(startDate) {
+		return Vacation{}, fmt.Errorf("vacation ends (%s) before it starts (%s)", end, start)
+	}
+	return Vacation{
+		startDate: newDate(startDate.Year(), int(startDate.Month()), startDate.Day()),
+		endDate:   newDate(endDate.Year(), int(endDate.Month()), endDate.Day()),
+	}, nil
+}
+
+// week returns the number of whole weeks from the start of the rotation to nowTime, or -1 if the
+// rotation hasn't started yet. Weeks are counted in calendar days so daylight saving changes don't
+// move the boundary.
+func (s reviewerSchedule) week(nowTime time.Time) int {
+	y, m, d := nowTime.In(s.start.Location()).Date()
+	days := int(time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Sub(time.Date(s.start.Year(), s.start.Month(), s.start.Day(), 0, 0, 0, 0, time.UTC)).Hours() / 24)
+	if days < 0 {
+		return -1
+	}
+	return days / 7
+}
+
+// onCall returns the reviewer whose week it is at nowTime, or if they're unavailable the next
+// available reviewer in rotation order. Returns "" if nobody in the rotation is available.
+func (s reviewerSchedule) onCall(nowTime time.Time, availableReviewers []string) string {
+	week := s.week(nowTime)
+	if week < 0 {
+		return ""
+	}
+	away := onVacation(nowTime, s.reviewers)
+	for i := range s.rotation {
+		reviewer := s.rotation[(week+i)%len(s.rotation)]
+		if slices.Contains(availableReviewers, reviewer) && !slices.Contains(away, reviewer) {
+			return reviewer
+		}
+	}
+	return ""
+}
+
+// OnCallReviewer returns this week's reviewer from the reviewer schedule (optionally excluding some
+// people from the reviewer pool), or "" if the schedule can't be loaded or nobody in it is available.
+func OnCallReviewer(excludedReviewers []string) string {
+	schedule, err := parseReviewerSchedule(reviewerScheduleYaml)
+	if err != nil {
+		fmt.Printf("Failed to load reviewer schedule: %s\n", err)
+		return ""
+	}
+	return schedule.onCall(time.Now(), AvailableReviewers(excludedReviewers))
+}
@@ -0,0 +1,156 @@
+/*
+* Copyright 2026 Google LLC. All Rights Reserved.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+package github
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReviewerScheduleFile(t *testing.T) {
+	if _, err := parseReviewerSchedule(reviewerScheduleYaml); err != nil {
+		t.Fatalf("reviewer_schedule.yaml is invalid: %s", err)
+	}
+}
+
+func TestParseReviewerScheduleErrors(t *testing.T) {
+	cases := map[string]struct {
+		yaml        string
+		expectError string
+	}{
+		"start is not a Monday": {
+			yaml:        "start: 2026-01-06\nrotation:\n  - login: trodge\n",
+			expectError: "not a Monday",
+		},
+		"empty rotation": {
+			yaml:        "start: 2026-01-05\n",
+			expectError: "rotation is empty",
+		},
+		"not a core reviewer": {
+			yaml:        "start: 2026-01-05\nrotation:\n  - login: octocat\n",
+			expectError: "not a core reviewer",
+		},
+		"duplicate reviewer": {
+			yaml:        "start: 2026-01-05\nrotation:\n  - login: trodge\n  - login: trodge\n",
+			expectError: "more than once",
+		},
+		"invalid timezone": {
+			yaml:        "start: 2026-01-05\nrotation:\n  - login: trodge\n    timezone: Mars/Olympus_Mons\n",
+			expectError: "invalid timezone",
+		},
+		"vacation ends before it starts": {
+			yaml:        "start: 2026-01-05\nrotation:\n  - login: trodge\n    vacations:\n      - start: 2026-02-10\n        end: 2026-02-01\n",
+			expectError: "before it starts",
+		},
+		"unknown field": {
+			yaml:        "start: 2026-01-05\nrotation:\n  - login: trodge\n    vacation: []\n",
+			expectError: "vacation",
+		},
+	}
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			_, err := parseReviewerSchedule([]byte(tc.yaml))
+			if err == nil || !strings.Contains(err.Error(), tc.expectError) {
+				t.Errorf("wanted error containing %q; got %v", tc.expectError, err)
+			}
+		})
+	}
+}
+
+func TestReviewerScheduleOnCall(t *testing.T) {
+	schedule, err := parseReviewerSchedule([]byte(`
+start: 2026-01-05
+timezone: US/Pacific
+rotation:
+  - login: trodge
+  - login: melinath
+    timezone: Europe/London
+    vacations:
+      - start: 2026-01-12
+        end: 2026-01-13
+  - login: slevenick
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	all := []string{"melinath", "slevenick", "trodge"}
+
+	cases := map[string]struct {
+		now       time.Time
+		available []string
+		expected  string
+	}{
+		"before the rotation starts": {
+			now:       time.Date(2026, 1, 4, 12, 0, 0, 0, usPacific),
+			available: all,
+			expected:  "",
+		},
+		"first week": {
+			now:       time.Date(2026, 1, 5, 0, 0, 0, 0, usPacific),
+			available: all,
+			expected:  "trodge",
+		},
+		"last moment of the first week": {
+			now:       time.Date(2026, 1, 11, 23, 59, 0, 0, usPacific),
+			available: all,
+			expected:  "trodge",
+		},
+		"week boundary is in the schedule timezone": {
+			now:       time.Date(2026, 1, 12, 7, 0, 0, 0, time.UTC),
+			available: all,
+			expected:  "trodge",
+		},
+		"reviewer on vacation is skipped": {
+			now:       time.Date(2026, 1, 13, 12, 0, 0, 0, usPacific),
+			available: all,
+			expected:  "slevenick",
+		},
+		"vacation ends in the reviewer's timezone": {
+			// 2026-01-14 00:30 in London
+			now:       time.Date(2026, 1, 13, 16, 30, 0, 0, usPacific),
+			available: all,
+			expected:  "melinath",
+		},
+		"rotation wraps around": {
+			now:       time.Date(2026, 1, 26, 12, 0, 0, 0, usPacific),
+			available: all,
+			expected:  "trodge",
+		},
+		"unavailable reviewer is skipped": {
+			now:       time.Date(2026, 1, 5, 12, 0, 0, 0, usPacific),
+			available: []string{"melinath", "slevenick"},
+			expected:  "melinath",
+		},
+		"nobody available": {
+			now:       time.Date(2026, 1, 5, 12, 0, 0, 0, usPacific),
+			available: []string{"c2thorn"},
+			expected:  "",
+		},
+		"week after daylight saving starts": {
+			now:       time.Date(2026, 3, 16, 0, 30, 0, 0, usPacific),
+			available: all,
+			expected:  "melinath",
+		},
+	}
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			if got := schedule.onCall(tc.now, tc.available); got != tc.expected {
+				t.Errorf("wanted on-call reviewer %q; got %q", tc.expected, got)
+			}
+		})
+	}
+}
@@ -16,6 +16,7 @@
 package github
 
 import (
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -43,6 +44,120 @@ func (c *Client) PostBuildStatus(prNumber, title, state, targetURL, commitSha st
 	return nil
 }
 
+// CheckRunAnnotation is a single finding attached to a check run.
+type CheckRunAnnotation struct {
+	Path       string
+	Level      string // one of notice, warning or failure
+	Title      string
+	Message    string
+	RawDetails string
+}
+
+// CheckRun is a completed check run with its findings.
+type CheckRun struct {
+	Name        string
+	HeadSha     string
+	DetailsURL  string
+	Conclusion  string
+	Title       string
+	Summary     string
+	Annotations []CheckRunAnnotation
+}
+
+// GitHub accepts at most this many annotations per check run request.
+const maxAnnotationsPerRequest = 50
+
+// CreateCheckRun creates a completed check run for a specific SHA. Annotations beyond
+// the per-request limit are added by updating the check run.
+func (c *Client) CreateCheckRun(checkRun CheckRun) error {
+	annotations := make([]*gh.CheckRunAnnotation, 0, len(checkRun.Annotations))
+	for _, a := range checkRun.Annotations {
+		annotations = append(annotations, &gh.CheckRunAnnotation{
+			Path:            gh.Ptr(a.Path),
+			StartLine:       gh.Ptr(1),
+			EndLine:         gh.Ptr(1),
+			AnnotationLevel: gh.Ptr(a.Level),
+			Title:           gh.Ptr(a.Title),
+			Message:         gh.Ptr(a.Message),
+			RawDetails:      gh.Ptr(a.RawDetails),
+		})
+	}
+	output := func(batch []*gh.CheckRunAnnotation) *gh.CheckRunOutput {
+		return &gh.CheckRunOutput{
+			Title:       gh.Ptr(checkRun.Title),
+			Summary:     gh.Ptr(checkRun.Summary),
+			Annotations: batch,
+		}
+	}
+	batch := annotations[:min(len(annotations), maxAnnotationsPerRequest)]
+	annotations = annotations[len(batch):]
+
+	run, _, err := c.gh.Checks.CreateCheckRun(c.ctx, defaultOwner, defaultRepo, gh.CreateCheckRunOptions{
+		Name:        checkRun.Name,
+		HeadSHA:     checkRun.HeadSha,
+		DetailsURL:  gh.Ptr(checkRun.DetailsURL),
+		Status:      gh.Ptr("completed"),
+		Conclusion:  gh.Ptr(checkRun.Conclusion),
+		CompletedAt: &gh.Timestamp{Time: time.Now()},
+		Output:      output(batch),
+	})
+	if err != nil {
+		return err
+	}
+
+	for len(annotations) > 0 {
+		batch = annotations[:min(len(annotations), maxAnnotationsPerRequest)]
+		annotations = annotations[len(batch):]
+		if _, _, err := c.gh.Checks.UpdateCheckRun(c.ctx, defaultOwner, defaultRepo, run.GetID(), gh.UpdateCheckRunOptions{
+			Name:   checkRun.Name,
+			Output: output(batch),
+		}); err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("Successfully created check run %s for commit %s\n", checkRun.Name, checkRun.HeadSha)
+	return nil
+}
+
+// UpdatePullRequestBranch brings a pull request's branch up to date with its base branch
+func (c *Client) UpdatePullRequestBranch(prNumber, headSha string) error {
+	num, err := strconv.Atoi(prNumber)
+	if err != nil {
+		return err
+	}
+
+	_, _, err = c.gh.PullRequests.UpdateBranch(c.ctx, defaultOwner, defaultRepo, num, &gh.PullRequestBranchUpdateOptions{
+		ExpectedHeadSHA: gh.Ptr(headSha),
+	})
+	// The update happens asynchronously, which is reported as an AcceptedError.
+	var acceptedErr *gh.AcceptedError
+	if err != nil && !errors.As(err, &acceptedErr) {
+		return err
+	}
+
+	fmt.Printf("Successfully requested a branch update for pull request %s\n", prNumber)
+	return nil
+}
+
+// CreatePullRequest opens a pull request from head into base in the given repository and returns
+// its number.
+func (c *Client) CreatePullRequest(owner, repo, title, body, head, base string, draft bool) (int, error) {
+	pr, _, err := c.gh.PullRequests.Create(c.ctx, owner, repo, &gh.NewPullRequest{
+		Title: gh.Ptr(title),
+		Body:  gh.Ptr(body),
+		Head:  gh.Ptr(head),
+		Base:  gh.Ptr(base),
+		Draft: gh.Ptr(draft),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	fmt.Printf("Successfully created pull request %d in %s/%s\n", pr.GetNumber(), owner, repo)
+	return pr.GetNumber(), nil
+}
+
 // PostComment adds a comment to a pull request
 func (c *Client) PostComment(prNumber, comment string) error {
 	num, err := strconv.Atoi(prNumber)
@@ -178,6 +293,11 @@ func (c *Client) CreateWorkflowDispatchEvent(workflowFileName string, inputs map
 	return nil
 }
 
+// MergeRepoPullRequest squash merges a PR in the client's repository
+func (gh *Client) MergeRepoPullRequest(prNumber, commitSha string) error {
+	return gh.MergePullRequest(defaultOwner, defaultRepo, prNumber, commitSha)
+}
+
 func (gh *Client) MergePullRequest(owner, repo, prNumber, commitSha string) error {
 	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/pulls/%s/merge", owner, repo, prNumber)
 
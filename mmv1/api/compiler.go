@@ -16,11 +16,55 @@ package api
 import (
 	"log"
 	"os"
+	"reflect"
 
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/product"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/resource"
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
 )
 
+// YamlSchema lists the required keys and allowed values in product and resource YAML files
+// that their Go types can't express. Validate checks the same constraints after loading, but
+// checking them upfront reports every problem with its position in the file.
+var YamlSchema = google.YamlSchema{
+	Required: map[reflect.Type][]string{
+		reflect.TypeOf(Product{}):                 {"name", "versions"},
+		reflect.TypeOf(product.Version{}):         {"name", "base_url"},
+		reflect.TypeOf(Resource{}):                {"name", "description"},
+		reflect.TypeOf(resource.Examples{}):       {"name"},
+		reflect.TypeOf(resource.CustomEndpoint{}): {"name", "base_url"},
+	},
+	Enums: map[reflect.Type]map[string][]string{
+		reflect.TypeOf(product.Version{}): {
+			"name": product.ORDER,
+		},
+		reflect.TypeOf(Resource{}): {
+			"create_verb": {"POST", "PUT", "PATCH"},
+			"read_verb":   {"GET", "POST"},
+			"update_verb": {"POST", "PUT", "PATCH"},
+			"delete_verb": {"POST", "PUT", "PATCH", "DELETE"},
+		},
+		reflect.TypeOf(Async{}): {
+			"type": {"OpAsync", "PollAsync"},
+		},
+	},
+}
+
+// Compile loads a product or resource YAML file into obj, exiting with the position of each
+// problem in the file if it's invalid.
 func Compile(yamlPath string, obj interface{}) {
+	compile(yamlPath, obj, YamlSchema)
+}
+
+// CompileOverride loads a YAML file that's merged over a base file into obj. Overrides only set
+// the keys they change, so required keys aren't checked.
+func CompileOverride(yamlPath string, obj interface{}) {
+	schema := YamlSchema
+	schema.Required = nil
+	compile(yamlPath, obj, schema)
+}
+
+func compile(yamlPath string, obj interface{}, schema google.YamlSchema) {
 	objYaml, err := os.ReadFile(yamlPath)
 
 	if err != nil {
@@ -28,5 +72,11 @@ func Compile(yamlPath string, obj interface{}) {
 	}
 
 	yamlValidator := google.YamlValidator{}
+	if problems := yamlValidator.Validate(objYaml, obj, yamlPath, schema); len(problems) > 0 {
+		for _, problem := range problems {
+			log.Print(problem)
+		}
+		log.Fatalf("Found %d problems in %s", len(problems), yamlPath)
+	}
 	yamlValidator.Parse(objYaml, obj, yamlPath)
 }
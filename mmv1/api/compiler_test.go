@@ -0,0 +1,84 @@
+package api
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+)
+
+func TestProductYamlFilesAreValid(t *testing.T) {
+	t.Parallel()
+
+	// Get the path where this test file is located
+	_, testFilePath, _, ok := runtime.Caller(0)
+	if !ok {
+		t.Fatal("Failed to get current test file path")
+	}
+	productsDir := filepath.Join(filepath.Dir(filepath.Dir(testFilePath)), "products")
+
+	files, err := filepath.Glob(filepath.Join(productsDir, "*", "*.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		t.Fatal("found no product files")
+	}
+	v := google.YamlValidator{}
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var obj interface{} = &Resource{}
+		if filepath.Base(file) == "product.yaml" {
+			obj = &Product{}
+		}
+		for _, problem := range v.Validate(content, obj, file, YamlSchema) {
+			t.Error(problem)
+		}
+	}
+}
+
+func TestYamlSchemaProblems(t *testing.T) {
+	t.Parallel()
+
+	content := `name: 'Widget'
+description: A widget.
+create_verb: 'GET'
+async:
+  type: 'Async'
+  operation:
+    base_url: '{{op_id}}'
+    result:
+      resource_inside_response: false
+properties:
+  - name: 'size'
+    type: Integer
+    required: 'yes'
+examples:
+  - primary_resource_id: 'example'
+`
+	v := google.YamlValidator{}
+	var got []string
+	for _, problem := range v.Validate([]byte(content), &Resource{}, "Widget.yaml", YamlSchema) {
+		got = append(got, problem.String())
+	}
+	expected := []string{
+		"Widget.yaml:3:14: invalid value \"GET\" for \"create_verb\", expected one of POST, PUT, PATCH",
+		"Widget.yaml:5:9: invalid value \"Async\" for \"type\", expected one of OpAsync, PollAsync",
+		"Widget.yaml:8:5: unknown key \"result\"",
+		"Widget.yaml:13:15: expected true or false, got \"yes\"",
+		"Widget.yaml:15:5: missing required key \"name\"",
+	}
+	if len(got) != len(expected) {
+		t.Fatalf("expected %q, got %q", expected, got)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Errorf("expected %q, got %q", expected[i], got[i])
+		}
+	}
+}
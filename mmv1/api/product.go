@@ -80,6 +80,9 @@ type Product struct {
 
 	ClientName string `yaml:"client_name,omitempty"`
 
+	// Settings for the product's nightly acceptance test build in TeamCity
+	TeamCity *product.TeamCity `yaml:"teamcity,omitempty"`
+
 	// The compiler to generate the downstream files, for example "terraformgoogleconversion-codegen".
 	Compiler string `yaml:"-"`
 }
@@ -128,6 +131,10 @@ func (p *Product) Validate() {
 	if p.Async != nil {
 		p.Async.Validate()
 	}
+
+	if p.TeamCity != nil {
+		p.TeamCity.Validate(p.Name)
+	}
 }
 
 // ====================
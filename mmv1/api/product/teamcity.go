@@ -0,0 +1,45 @@
+// Copyright 2025 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package product
+
+import (
+	"log"
+	"regexp"
+)
+
+var teamCityEnvVarRegexp = regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`)
+
+// Settings for the nightly acceptance test build of a product's service
+// package in TeamCity. These are read by tools/teamcity-generator, which
+// generates the TeamCity configuration for every product.
+type TeamCity struct {
+	// The number of tests to run in parallel, if the service's tests can't
+	// run with the default parallelism; e.g. because of low quota
+	Parallelism int `yaml:"parallelism,omitempty"`
+
+	// Environment variables to set when running the service's tests, in
+	// addition to the ones set for every service
+	Environment map[string]string `yaml:"environment,omitempty"`
+}
+
+func (t *TeamCity) Validate(pName string) {
+	if t.Parallelism < 0 {
+		log.Fatalf("`teamcity.parallelism` must not be negative for product %s", pName)
+	}
+	for name := range t.Environment {
+		if !teamCityEnvVarRegexp.MatchString(name) {
+			log.Fatalf("`teamcity.environment` variable %q must be upper case for product %s", name, pName)
+		}
+	}
+}
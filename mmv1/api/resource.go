@@ -145,6 +145,11 @@ type Resource struct {
 	// the decoder will be included within the code handling the nested query.
 	NestedQuery *resource.NestedQuery `yaml:"nested_query,omitempty"`
 
+	// [Optional] (Api::Resource::CustomEndpoint) Serves the resource from its
+	// own base URL with its own provider-level custom endpoint, instead of the
+	// product's.
+	CustomEndpoint *resource.CustomEndpoint `yaml:"custom_endpoint,omitempty"`
+
 	// ====================
 	// IAM Configuration
 	// ====================
@@ -559,6 +564,10 @@ func (r *Resource) Validate() {
 		r.NestedQuery.Validate(r.Name)
 	}
 
+	if r.CustomEndpoint != nil {
+		r.CustomEndpoint.Validate(r.Name)
+	}
+
 	for _, example := range r.Examples {
 		if err := example.Validate(r.Name); err != nil {
 			log.Fatalln(err)
@@ -1109,7 +1118,7 @@ func (r *Resource) ExcludeIfNotInVersion(version *product.Version) {
 // In newer resources there is much less standardisation in terms of value.
 // Generally for them though, it's the product.base_url + resource.name
 func (r Resource) SelfLinkUrl() string {
-	s := []string{r.ProductMetadata.BaseUrl, r.SelfLinkUri()}
+	s := []string{r.BaseUrlForEndpoint(), r.SelfLinkUri()}
 	return strings.Join(s, "")
 }
 
@@ -1127,7 +1136,7 @@ func (r Resource) SelfLinkUri() string {
 }
 
 func (r Resource) CollectionUrl() string {
-	s := []string{r.ProductMetadata.BaseUrl, r.collectionUri()}
+	s := []string{r.BaseUrlForEndpoint(), r.collectionUri()}
 	return strings.Join(s, "")
 }
 
@@ -1793,7 +1802,7 @@ func (r Resource) IamImportFormat() string {
 	importFormat := r.IamImportFormatTemplate()
 
 	importFormat = regexp.MustCompile(`\{\{%?(\w+)\}\}`).ReplaceAllString(importFormat, "%s")
-	return strings.ReplaceAll(importFormat, r.ProductMetadata.BaseUrl, "")
+	return strings.ReplaceAll(importFormat, r.BaseUrlForEndpoint(), "")
 }
 
 func (r Resource) IamImportParams() []string {
@@ -1971,7 +1980,24 @@ func (r Resource) ListUrlTemplate() string {
 }
 
 func (r Resource) DeleteUrlTemplate() string {
-	return fmt.Sprintf("%s%s", r.ProductMetadata.BaseUrl, r.DeleteUri())
+	return fmt.Sprintf("%s%s", r.BaseUrlForEndpoint(), r.DeleteUri())
+}
+
+// BasePathName returns the name of the config base path the resource's URLs
+// start with, which is the product's unless the resource has a custom endpoint.
+func (r Resource) BasePathName() string {
+	if r.CustomEndpoint != nil {
+		return r.CustomEndpoint.Name
+	}
+	return r.ProductMetadata.Name
+}
+
+// BaseUrlForEndpoint returns the default base URL of the resource's endpoint.
+func (r Resource) BaseUrlForEndpoint() string {
+	if r.CustomEndpoint != nil {
+		return r.CustomEndpoint.BaseUrl
+	}
+	return r.ProductMetadata.BaseUrl
 }
 
 func (r Resource) LastNestedQueryKey() string {
@@ -2464,13 +2490,15 @@ func (r Resource) DatasourceOptionalFields() []string {
 	return optionalFields
 }
 
+// The URL parameters that sweepers fill in without configuration.
+var sweeperUrlKeys = []string{"project", "region", "location", "zone", "billing_account"}
+
 func (r Resource) ShouldGenerateSweepers() bool {
 	if !r.ExcludeSweeper && !utils.IsEmpty(r.Sweeper) {
 		return true
 	}
 
-	allowedKeys := []string{"project", "region", "location", "zone", "billing_account"}
-	if !urlContainsOnlyAllowedKeys(r.ListUrlTemplate(), allowedKeys) {
+	if !urlContainsOnlyAllowedKeys(r.ListUrlTemplate(), sweeperUrlKeys) {
 		return false
 	}
 	if r.ExcludeSweeper || r.CustomCode.CustomDelete != "" || r.CustomCode.PreDelete != "" || r.CustomCode.PostDelete != "" || r.ExcludeDelete {
@@ -2479,6 +2507,54 @@ func (r Resource) ShouldGenerateSweepers() bool {
 	return true
 }
 
+// InferSweeperParent configures the sweeper of a resource that is listed under another
+// resource of the same product, like a database under an instance, to list the parent's
+// resources first. It only applies to resources that would get a sweeper generated if it
+// wasn't for the parent in their URL, and returns whether the sweeper was configured.
+func (r *Resource) InferSweeperParent(resources []*Resource) bool {
+	if r.IsExcluded() || r.ExcludeSweeper || !utils.IsEmpty(r.Sweeper) || r.ShouldGenerateSweepers() {
+		return false
+	}
+	if r.CustomCode.CustomDelete != "" || r.CustomCode.PreDelete != "" || r.CustomCode.PostDelete != "" || r.ExcludeDelete {
+		return false
+	}
+
+	listUri := strings.Split(r.collectionUri(), "?")[0]
+	var parentKeys []string
+	for _, match := range regexp.MustCompile(`{{\s*([^}]+)\s*}}`).FindAllStringSubmatch(listUri, -1) {
+		if key := strings.TrimSpace(match[1]); !slices.Contains(sweeperUrlKeys, key) {
+			parentKeys = append(parentKeys, key)
+		}
+	}
+	if len(parentKeys) != 1 {
+		return false
+	}
+	childField := parentKeys[0]
+
+	for _, parent := range resources {
+		if parent == r || parent.IsExcluded() || !parent.ShouldGenerateSweepers() {
+			continue
+		}
+		// The parent's self link, up to its own name, must be where the child is listed.
+		// Self links that are only a name, like {{name}}, don't say where that is.
+		selfLink := parent.SelfLinkUri()
+		nameStart := strings.LastIndex(selfLink, "{{")
+		if nameStart <= 0 || !strings.HasSuffix(selfLink, "}}") {
+			continue
+		}
+		if strings.HasPrefix(listUri, selfLink[:nameStart]+"{{"+childField+"}}/") {
+			r.Sweeper.Parent = &resource.ParentResource{
+				ResourceType:           parent.TerraformName(),
+				ParentField:            "name",
+				ParentFieldExtractName: true,
+				ChildField:             childField,
+			}
+			return true
+		}
+	}
+	return false
+}
+
 func (r Resource) GithubURL() string {
 	return GITHUB_BASE_URL + r.SourceYamlFile
 }
@@ -0,0 +1,153 @@
+// Copyright 2025 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Types of dependencies that can be bootstrapped for an example's test
+const (
+	BootstrapNetwork                     = "network"
+	BootstrapSubnetwork                  = "subnetwork"
+	BootstrapServiceNetworkingConnection = "service_networking_connection"
+	BootstrapKMSKey                      = "kms_key"
+	BootstrapServiceAccount              = "service_account"
+)
+
+var bootstrapTypes = []string{
+	BootstrapNetwork,
+	BootstrapSubnetwork,
+	BootstrapServiceNetworkingConnection,
+	BootstrapKMSKey,
+	BootstrapServiceAccount,
+}
+
+// The location of KMS keys that don't specify one, which is where most tests run
+const defaultBootstrapLocation = "us-central1"
+
+// BootstrapDependency is a resource that an example's test reuses across runs rather than
+// creating, because it's slow to create, limited per project, or can't be deleted cleanly,
+// like a network with a service networking connection. In tests, the example variable is set
+// to the dependency, which is created in the test project the first time it's needed. The
+// documentation keeps the variable's value from vars.
+type BootstrapDependency struct {
+	// The name of the example variable, from vars, that refers to the dependency
+	Var string
+
+	// The kind of dependency, one of network, subnetwork, service_networking_connection,
+	// kms_key or service_account
+	Type string
+
+	// Identifies the dependency, so that tests using the same name share it. Tests that
+	// modify a dependency, like by creating a service networking connection in a network,
+	// should use a name of their own. Not used by kms_key, whose keys are shared per location.
+	Name string `yaml:"name,omitempty"`
+
+	// The var of the network or service_networking_connection dependency that a subnetwork
+	// is created in
+	Network string `yaml:"network,omitempty"`
+
+	// The location of a kms_key. Defaults to us-central1.
+	Location string `yaml:"location,omitempty"`
+}
+
+// BootstrapTestVars returns the Go expressions that set each bootstrapped variable in tests,
+// keyed by variable, in the same form as test_vars_overrides. It returns an error if the
+// dependencies aren't valid.
+func (e *Examples) BootstrapTestVars() (map[string]string, error) {
+	vars := make(map[string]string)
+	types := make(map[string]string)
+	for _, d := range e.Bootstrap {
+		if d.Var == "" {
+			return nil, fmt.Errorf("missing `var` for a bootstrapped dependency in example %s", e.Name)
+		}
+		if _, ok := e.Vars[d.Var]; !ok {
+			return nil, fmt.Errorf("bootstrapped dependency %s in example %s isn't one of the example's vars", d.Var, e.Name)
+		}
+		if _, ok := vars[d.Var]; ok {
+			return nil, fmt.Errorf("var %s is bootstrapped more than once in example %s", d.Var, e.Name)
+		}
+		if !slices.Contains(bootstrapTypes, d.Type) {
+			return nil, fmt.Errorf("bootstrapped dependency %s in example %s has type %q, should be one of %v", d.Var, e.Name, d.Type, bootstrapTypes)
+		}
+		if d.Type == BootstrapKMSKey {
+			if d.Name != "" {
+				return nil, fmt.Errorf("bootstrapped kms_key %s in example %s can't have a name; keys are shared per location", d.Var, e.Name)
+			}
+		} else if d.Name == "" {
+			return nil, fmt.Errorf("missing `name` for bootstrapped %s %s in example %s", d.Type, d.Var, e.Name)
+		}
+		if d.Location != "" && d.Type != BootstrapKMSKey {
+			return nil, fmt.Errorf("bootstrapped %s %s in example %s can't have a location", d.Type, d.Var, e.Name)
+		}
+		if d.Type == BootstrapSubnetwork {
+			if t := types[d.Network]; t != BootstrapNetwork && t != BootstrapServiceNetworkingConnection {
+				return nil, fmt.Errorf("bootstrapped subnetwork %s in example %s should set `network` to the var of a network bootstrapped before it", d.Var, e.Name)
+			}
+		} else if d.Network != "" {
+			return nil, fmt.Errorf("bootstrapped %s %s in example %s can't have a network", d.Type, d.Var, e.Name)
+		}
+
+		vars[d.Var] = d.testVar(vars)
+		types[d.Var] = d.Type
+		// Once added, the override is the bootstrapped dependency's own
+		if override, ok := e.TestVarsOverrides[d.Var]; ok && override != vars[d.Var] {
+			return nil, fmt.Errorf("var %s in example %s is both bootstrapped and in test_vars_overrides", d.Var, e.Name)
+		}
+	}
+	return vars, nil
+}
+
+// testVar returns the expression that bootstraps the dependency, given the expressions of
+// the dependencies declared before it.
+func (d BootstrapDependency) testVar(previous map[string]string) string {
+	switch d.Type {
+	case BootstrapNetwork:
+		return fmt.Sprintf("acctest.BootstrapSharedTestNetwork(t, %q)", d.Name)
+	case BootstrapSubnetwork:
+		return fmt.Sprintf("acctest.BootstrapSubnet(t, %q, %s)", d.Name, previous[d.Network])
+	case BootstrapServiceNetworkingConnection:
+		return fmt.Sprintf("acctest.BootstrapSharedServiceNetworkingConnection(t, %q)", d.Name)
+	case BootstrapKMSKey:
+		location := d.Location
+		if location == "" {
+			location = defaultBootstrapLocation
+		}
+		return fmt.Sprintf("acctest.BootstrapKMSKeyInLocation(t, %q).CryptoKey.Name", location)
+	case BootstrapServiceAccount:
+		return fmt.Sprintf("acctest.BootstrapServiceAccount(t, %q, envvar.GetTestServiceAccountFromEnv(t))", d.Name)
+	}
+	return ""
+}
+
+// addBootstrapTestVars adds the example's bootstrapped dependencies to its test_vars_overrides,
+// so that tests set them like any other overridden variable.
+func (e *Examples) addBootstrapTestVars() error {
+	if len(e.Bootstrap) == 0 {
+		return nil
+	}
+	vars, err := e.BootstrapTestVars()
+	if err != nil {
+		return err
+	}
+	if e.TestVarsOverrides == nil {
+		e.TestVarsOverrides = make(map[string]string)
+	}
+	for k, v := range vars {
+		e.TestVarsOverrides[k] = v
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+package resource
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestExamplesBootstrapTestVars(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name      string
+		example   Examples
+		want      map[string]string
+		wantError string
+	}{
+		{
+			name:    "none",
+			example: Examples{Name: "basic"},
+			want:    map[string]string{},
+		},
+		{
+			name: "every type",
+			example: Examples{
+				Name: "basic",
+				Vars: map[string]string{"network_name": "my-network", "subnet_name": "my-subnet", "psa_network": "my-psa-network", "kms_key_name": "my-key", "sa_email": "my-sa"},
+				Bootstrap: []BootstrapDependency{
+					{Var: "network_name", Type: "network", Name: "basic-network"},
+					{Var: "subnet_name", Type: "subnetwork", Name: "basic-subnet", Network: "network_name"},
+					{Var: "psa_network", Type: "service_networking_connection", Name: "basic-psa"},
+					{Var: "kms_key_name", Type: "kms_key", Location: "us-east1"},
+					{Var: "sa_email", Type: "service_account", Name: "basic-sa"},
+				},
+			},
+			want: map[string]string{
+				"network_name": `acctest.BootstrapSharedTestNetwork(t, "basic-network")`,
+				"subnet_name":  `acctest.BootstrapSubnet(t, "basic-subnet", acctest.BootstrapSharedTestNetwork(t, "basic-network"))`,
+				"psa_network":  `acctest.BootstrapSharedServiceNetworkingConnection(t, "basic-psa")`,
+				"kms_key_name": `acctest.BootstrapKMSKeyInLocation(t, "us-east1").CryptoKey.Name`,
+				"sa_email":     `acctest.BootstrapServiceAccount(t, "basic-sa", envvar.GetTestServiceAccountFromEnv(t))`,
+			},
+		},
+		{
+			name: "default kms location",
+			example: Examples{
+				Name:      "basic",
+				Vars:      map[string]string{"kms_key_name": "my-key"},
+				Bootstrap: []BootstrapDependency{{Var: "kms_key_name", Type: "kms_key"}},
+			},
+			want: map[string]string{"kms_key_name": `acctest.BootstrapKMSKeyInLocation(t, "us-central1").CryptoKey.Name`},
+		},
+		{
+			name: "not a var",
+			example: Examples{
+				Name:      "basic",
+				Bootstrap: []BootstrapDependency{{Var: "network_name", Type: "network", Name: "basic"}},
+			},
+			wantError: "isn't one of the example's vars",
+		},
+		{
+			name: "unknown type",
+			example: Examples{
+				Name:      "basic",
+				Vars:      map[string]string{"network_name": "my-network"},
+				Bootstrap: []BootstrapDependency{{Var: "network_name", Type: "vpc", Name: "basic"}},
+			},
+			wantError: `has type "vpc"`,
+		},
+		{
+			name: "missing name",
+			example: Examples{
+				Name:      "basic",
+				Vars:      map[string]string{"network_name": "my-network"},
+				Bootstrap: []BootstrapDependency{{Var: "network_name", Type: "network"}},
+			},
+			wantError: "missing `name`",
+		},
+		{
+			name: "subnetwork before its network",
+			example: Examples{
+				Name: "basic",
+				Vars: map[string]string{"network_name": "my-network", "subnet_name": "my-subnet"},
+				Bootstrap: []BootstrapDependency{
+					{Var: "subnet_name", Type: "subnetwork", Name: "basic-subnet", Network: "network_name"},
+					{Var: "network_name", Type: "network", Name: "basic-network"},
+				},
+			},
+			wantError: "should set `network`",
+		},
+		{
+			name: "bootstrapped twice",
+			example: Examples{
+				Name: "basic",
+				Vars: map[string]string{"network_name": "my-network"},
+				Bootstrap: []BootstrapDependency{
+					{Var: "network_name", Type: "network", Name: "basic"},
+					{Var: "network_name", Type: "network", Name: "other"},
+				},
+			},
+			wantError: "more than once",
+		},
+		{
+			name: "conflicting override",
+			example: Examples{
+				Name:              "basic",
+				Vars:              map[string]string{"network_name": "my-network"},
+				TestVarsOverrides: map[string]string{"network_name": `"default"`},
+				Bootstrap:         []BootstrapDependency{{Var: "network_name", Type: "network", Name: "basic"}},
+			},
+			wantError: "both bootstrapped and in test_vars_overrides",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tc.example.BootstrapTestVars()
+			if tc.wantError != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantError) {
+					t.Fatalf("BootstrapTestVars() error = %v, want an error containing %q", err, tc.wantError)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("BootstrapTestVars() returned an unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("BootstrapTestVars() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestExamplesAddBootstrapTestVars(t *testing.T) {
+	t.Parallel()
+
+	e := Examples{
+		Name:              "basic",
+		Vars:              map[string]string{"network_name": "my-network", "instance_name": "my-instance"},
+		TestVarsOverrides: map[string]string{"instance_name": `"fixed"`},
+		Bootstrap:         []BootstrapDependency{{Var: "network_name", Type: "network", Name: "basic"}},
+	}
+	want := map[string]string{
+		"instance_name": `"fixed"`,
+		"network_name":  `acctest.BootstrapSharedTestNetwork(t, "basic")`,
+	}
+	// Adding the test vars again, like when an example is validated after loading, doesn't conflict
+	for i := 0; i < 2; i++ {
+		if err := e.addBootstrapTestVars(); err != nil {
+			t.Fatalf("addBootstrapTestVars() returned an unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(e.TestVarsOverrides, want) {
+			t.Errorf("TestVarsOverrides = %v, want %v", e.TestVarsOverrides, want)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright 2025 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"log"
+	"regexp"
+	"strings"
+)
+
+var customEndpointNameRegexp = regexp.MustCompile(`^[A-Z][A-Za-z0-9]*$`)
+
+// CustomEndpoint gives a resource its own base path and provider-level custom
+// endpoint, for resources that are served from a different host or API version
+// than the rest of their product. The generator adds the provider field, the
+// environment variable and the base path to the provider config, so the endpoint
+// can be overridden like a product endpoint and follows the universe domain.
+type CustomEndpoint struct {
+	// Name of the endpoint in UpperCamelCase, like TagsLocation. It names the
+	// config.TagsLocationBasePath base path, the tags_location_custom_endpoint
+	// provider field and the GOOGLE_TAGS_LOCATION_CUSTOM_ENDPOINT environment
+	// variable. Resources that share an endpoint use the same name and base URL.
+	Name string `yaml:"name"`
+
+	// Base URL of the API the resource is served from, ending in a slash, like
+	// https://{{location}}-cloudresourcemanager.googleapis.com/v3/
+	BaseUrl string `yaml:"base_url"`
+}
+
+func (e *CustomEndpoint) Validate(rName string) {
+	if !customEndpointNameRegexp.MatchString(e.Name) {
+		log.Fatalf("`name` for `custom_endpoint` in resource %s must be UpperCamelCase, got %q", rName, e.Name)
+	}
+	if !strings.HasPrefix(e.BaseUrl, "https://") || !strings.HasSuffix(e.BaseUrl, "/") {
+		log.Fatalf("`base_url` for `custom_endpoint` in resource %s must start with https:// and end with /, got %q", rName, e.BaseUrl)
+	}
+}
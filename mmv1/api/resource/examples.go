@@ -58,6 +58,11 @@ type Examples struct {
 	// be provisioned with standard terraform resources.
 	BootstrapIam []IamMember `yaml:"bootstrap_iam,omitempty"`
 
+	// Bootstrap declares shared dependencies, like networks and KMS keys, that
+	// tests reuse rather than create. Each one sets one of the example's vars
+	// in tests, like an entry of test_vars_overrides would.
+	Bootstrap []BootstrapDependency `yaml:"bootstrap,omitempty"`
+
 	// The version name of of the example's version if it's different than the
 	// resource version, eg. `beta`
 	//
@@ -231,6 +236,9 @@ func (e *Examples) Validate(rName string) error {
 	if e.Name == "" {
 		return fmt.Errorf("missing `name` for one example in resource %s", rName)
 	}
+	if _, err := e.BootstrapTestVars(); err != nil {
+		return err
+	}
 	return e.ValidateExternalProviders()
 }
 
@@ -258,6 +266,10 @@ func (e *Examples) ValidateExternalProviders() error {
 
 // Executes example templates for documentation and tests
 func (e *Examples) LoadHCLText(sysfs fs.FS) (err error) {
+	if err := e.addBootstrapTestVars(); err != nil {
+		return err
+	}
+
 	originalVars := e.Vars
 	originalTestEnvVars := e.TestEnvVars
 	docTestEnvVars := make(map[string]string)
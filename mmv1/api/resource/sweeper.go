@@ -26,8 +26,9 @@ import (
 // test resources that were not properly cleaned up.
 //
 // Sweeper generation is enabled by default, except for resources with custom
-// deletion code, parent-child relationships (unless configured via Parent), or
-// complex URL parameters. Defining the sweeper block overrides these exclusions.
+// deletion code, parent-child relationships (unless configured via Parent or
+// inferred from the parent's self link), or complex URL parameters. Defining the
+// sweeper block overrides these exclusions.
 type Sweeper struct {
 	// IdentifierField specifies which field in the resource object should be used
 	// to identify resources for deletion. If not specified, defaults to "name"
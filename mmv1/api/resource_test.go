@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/product"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/resource"
 )
 
 func TestResourceMinVersionObj(t *testing.T) {
@@ -208,6 +209,177 @@ func TestResourceServiceVersion(t *testing.T) {
 // the RELATIVE_MAGICIAN_LOCATION ("mmv1/") directory structure. This ensures that references
 // to files relative to this location will remain valid even if the repository structure
 // changes or the source is downloaded without git metadata.
+func TestResourceCustomEndpoint(t *testing.T) {
+	t.Parallel()
+	p := Product{
+		Name:    "Tags",
+		BaseUrl: "https://cloudresourcemanager.googleapis.com/v3/",
+	}
+
+	cases := []struct {
+		description      string
+		obj              Resource
+		wantBasePathName string
+		wantSelfLinkUrl  string
+	}{
+		{
+			description: "resource uses the product endpoint",
+			obj: Resource{
+				Name:            "TagKey",
+				BaseUrl:         "tagKeys",
+				ProductMetadata: &p,
+			},
+			wantBasePathName: "Tags",
+			wantSelfLinkUrl:  "https://cloudresourcemanager.googleapis.com/v3/tagKeys/{{name}}",
+		},
+		{
+			description: "resource has a custom endpoint",
+			obj: Resource{
+				Name:            "LocationTagBinding",
+				BaseUrl:         "tagBindings",
+				ProductMetadata: &p,
+				CustomEndpoint: &resource.CustomEndpoint{
+					Name:    "TagsLocation",
+					BaseUrl: "https://{{location}}-cloudresourcemanager.googleapis.com/v3/",
+				},
+			},
+			wantBasePathName: "TagsLocation",
+			wantSelfLinkUrl:  "https://{{location}}-cloudresourcemanager.googleapis.com/v3/tagBindings/{{name}}",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tc.obj.BasePathName(); got != tc.wantBasePathName {
+				t.Errorf("BasePathName() = %q, want %q", got, tc.wantBasePathName)
+			}
+			if got := tc.obj.SelfLinkUrl(); got != tc.wantSelfLinkUrl {
+				t.Errorf("SelfLinkUrl() = %q, want %q", got, tc.wantSelfLinkUrl)
+			}
+		})
+	}
+}
+
+func TestResourceInferSweeperParent(t *testing.T) {
+	t.Parallel()
+	p := Product{
+		Name:    "Filestore",
+		BaseUrl: "https://file.googleapis.com/v1/",
+	}
+	instance := &Resource{
+		Name:            "Instance",
+		BaseUrl:         "projects/{{project}}/locations/{{location}}/instances",
+		SelfLink:        "projects/{{project}}/locations/{{location}}/instances/{{name}}",
+		ProductMetadata: &p,
+	}
+	nameOnly := &Resource{
+		Name:            "Entry",
+		BaseUrl:         "{{entry_group}}/entries",
+		SelfLink:        "{{name}}",
+		ProductMetadata: &p,
+		Sweeper:         resource.Sweeper{IdentifierField: "name"},
+	}
+	explicitParent := &resource.ParentResource{ResourceType: "google_filestore_backup", ParentField: "id", ChildField: "instance"}
+
+	cases := []struct {
+		description string
+		obj         Resource
+		parents     []*Resource
+		want        *resource.ParentResource
+	}{
+		{
+			description: "listed under a parent resource",
+			obj: Resource{
+				Name:            "Snapshot",
+				BaseUrl:         "projects/{{project}}/locations/{{location}}/instances/{{instance}}/snapshots",
+				ProductMetadata: &p,
+			},
+			parents: []*Resource{instance},
+			want: &resource.ParentResource{
+				ResourceType:           "google_filestore_instance",
+				ParentField:            "name",
+				ParentFieldExtractName: true,
+				ChildField:             "instance",
+			},
+		},
+		{
+			description: "listed under a resource without a sweeper",
+			obj: Resource{
+				Name:            "Snapshot",
+				BaseUrl:         "projects/{{project}}/locations/{{location}}/instances/{{instance}}/snapshots",
+				ProductMetadata: &p,
+			},
+			parents: []*Resource{{
+				Name:            "Instance",
+				BaseUrl:         "projects/{{project}}/locations/{{location}}/instances",
+				ProductMetadata: &p,
+				ExcludeSweeper:  true,
+			}},
+		},
+		{
+			description: "listed under a parent that is only a name",
+			obj: Resource{
+				Name:            "Tag",
+				BaseUrl:         "{{parent}}/tags",
+				ProductMetadata: &p,
+			},
+			parents: []*Resource{nameOnly},
+		},
+		{
+			description: "listed under several parameters",
+			obj: Resource{
+				Name:            "Snapshot",
+				BaseUrl:         "projects/{{project}}/locations/{{location}}/instances/{{instance}}/shares/{{share}}/snapshots",
+				ProductMetadata: &p,
+			},
+			parents: []*Resource{instance},
+		},
+		{
+			description: "custom delete",
+			obj: Resource{
+				Name:            "Snapshot",
+				BaseUrl:         "projects/{{project}}/locations/{{location}}/instances/{{instance}}/snapshots",
+				ProductMetadata: &p,
+				CustomCode:      resource.CustomCode{CustomDelete: "templates/terraform/custom_delete/snapshot.go.tmpl"},
+			},
+			parents: []*Resource{instance},
+		},
+		{
+			description: "sweeper already configured",
+			obj: Resource{
+				Name:            "Snapshot",
+				BaseUrl:         "projects/{{project}}/locations/{{location}}/instances/{{instance}}/snapshots",
+				ProductMetadata: &p,
+				Sweeper:         resource.Sweeper{Parent: explicitParent},
+			},
+			parents: []*Resource{instance},
+			want:    explicitParent,
+		},
+		{
+			description: "swept without a parent",
+			obj:         *instance,
+			parents:     []*Resource{instance},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			tc.obj.InferSweeperParent(append(tc.parents, &tc.obj))
+			if !reflect.DeepEqual(tc.obj.Sweeper.Parent, tc.want) {
+				t.Errorf("Sweeper.Parent = %#v, want %#v", tc.obj.Sweeper.Parent, tc.want)
+			}
+		})
+	}
+}
+
 func TestMagicianLocation(t *testing.T) {
 	// Get the path where this test file is located
 	_, testFilePath, _, ok := runtime.Caller(0)
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// otherFilesGroup groups the changed files that aren't in a service package.
+const otherFilesGroup = "other"
+
+// GenerationDiff lists the files that a generation run would add, change and delete in a
+// downstream checkout, grouped by service package.
+type GenerationDiff struct {
+	Groups map[string]*GenerationDiffGroup
+}
+
+// GenerationDiffGroup lists changed file paths, relative to the checkout.
+type GenerationDiffGroup struct {
+	Added   []string
+	Changed []string
+	Deleted []string
+}
+
+// DiffGeneratedFiles compares the files generated into generatedDir with the downstream
+// checkout in checkoutDir. Only files in directories that were generated into are reported
+// as deleted, since the checkout also has files from other generators and handwritten files.
+func DiffGeneratedFiles(generatedDir, checkoutDir string) (GenerationDiff, error) {
+	result := GenerationDiff{Groups: make(map[string]*GenerationDiffGroup)}
+	generated := make(map[string]bool)
+	generatedDirs := make(map[string]bool)
+	err := filepath.WalkDir(generatedDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(generatedDir, path)
+		if err != nil {
+			return err
+		}
+		generated[rel] = true
+		generatedDirs[filepath.Dir(rel)] = true
+
+		newContent, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		oldContent, err := os.ReadFile(filepath.Join(checkoutDir, rel))
+		switch {
+		case os.IsNotExist(err):
+			result.group(rel).Added = append(result.group(rel).Added, rel)
+		case err != nil:
+			return err
+		case !bytes.Equal(oldContent, newContent):
+			result.group(rel).Changed = append(result.group(rel).Changed, rel)
+		}
+		return nil
+	})
+	if err != nil {
+		return result, fmt.Errorf("reading generated files: %w", err)
+	}
+
+	for dir := range generatedDirs {
+		entries, err := os.ReadDir(filepath.Join(checkoutDir, dir))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return result, fmt.Errorf("reading checkout: %w", err)
+		}
+		for _, entry := range entries {
+			rel := filepath.Join(dir, entry.Name())
+			if entry.IsDir() || generated[rel] {
+				continue
+			}
+			result.group(rel).Deleted = append(result.group(rel).Deleted, rel)
+		}
+	}
+
+	for _, group := range result.Groups {
+		sort.Strings(group.Added)
+		sort.Strings(group.Changed)
+		sort.Strings(group.Deleted)
+	}
+	return result, nil
+}
+
+func (d GenerationDiff) group(path string) *GenerationDiffGroup {
+	name := serviceGroup(path)
+	if d.Groups[name] == nil {
+		d.Groups[name] = &GenerationDiffGroup{}
+	}
+	return d.Groups[name]
+}
+
+// serviceGroup returns the service package of a downstream file, like compute for
+// google/services/compute/resource_compute_instance.go.
+func serviceGroup(path string) string {
+	parts := strings.Split(filepath.ToSlash(path), "/")
+	for i := 0; i+2 < len(parts); i++ {
+		if parts[i] == "services" {
+			return parts[i+1]
+		}
+	}
+	return otherFilesGroup
+}
+
+// String summarizes the diff with a line per changed file, grouped by service package.
+func (d GenerationDiff) String() string {
+	var names []string
+	for name := range d.Groups {
+		if name != otherFilesGroup {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	if d.Groups[otherFilesGroup] != nil {
+		names = append(names, otherFilesGroup)
+	}
+
+	var b strings.Builder
+	var total GenerationDiffGroup
+	for _, name := range names {
+		group := d.Groups[name]
+		fmt.Fprintf(&b, "%s: %s\n", name, group.counts())
+		for _, path := range group.Added {
+			fmt.Fprintf(&b, "  A %s\n", path)
+		}
+		for _, path := range group.Changed {
+			fmt.Fprintf(&b, "  M %s\n", path)
+		}
+		for _, path := range group.Deleted {
+			fmt.Fprintf(&b, "  D %s\n", path)
+		}
+		total.Added = append(total.Added, group.Added...)
+		total.Changed = append(total.Changed, group.Changed...)
+		total.Deleted = append(total.Deleted, group.Deleted...)
+	}
+	fmt.Fprintf(&b, "Total: %s\n", total.counts())
+	return b.String()
+}
+
+func (g GenerationDiffGroup) counts() string {
+	return fmt.Sprintf("%d added, %d changed, %d deleted", len(g.Added), len(g.Changed), len(g.Deleted))
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestDiffGeneratedFiles(t *testing.T) {
+	generated := t.TempDir()
+	checkout := t.TempDir()
+	writeFiles(t, generated, map[string]string{
+		"google/services/compute/resource_compute_address.go": "new",
+		"google/services/compute/resource_compute_network.go": "same",
+		"google/services/compute/resource_compute_router.go":  "added",
+		"google/services/pubsub/resource_pubsub_topic.go":     "same",
+		"website/docs/r/compute_address.html.markdown":        "new",
+		"google/provider/provider_mmv1_resources.go":          "same",
+	})
+	writeFiles(t, checkout, map[string]string{
+		"google/services/compute/resource_compute_address.go": "old",
+		"google/services/compute/resource_compute_network.go": "same",
+		"google/services/compute/resource_compute_old.go":     "deleted",
+		"google/services/pubsub/resource_pubsub_topic.go":     "same",
+		"website/docs/r/compute_address.html.markdown":        "old",
+		"google/provider/provider_mmv1_resources.go":          "same",
+		"google/services/handwritten/resource_handwritten.go": "not generated",
+	})
+
+	got, err := DiffGeneratedFiles(generated, checkout)
+	if err != nil {
+		t.Fatalf("DiffGeneratedFiles() returned error: %s", err)
+	}
+	want := GenerationDiff{Groups: map[string]*GenerationDiffGroup{
+		"compute": {
+			Added:   []string{"google/services/compute/resource_compute_router.go"},
+			Changed: []string{"google/services/compute/resource_compute_address.go"},
+			Deleted: []string{"google/services/compute/resource_compute_old.go"},
+		},
+		"other": {
+			Changed: []string{"website/docs/r/compute_address.html.markdown"},
+		},
+	}}
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("DiffGeneratedFiles() = %v, want %v", got, want)
+	}
+
+	wantSummary := `compute: 1 added, 1 changed, 1 deleted
+  A google/services/compute/resource_compute_router.go
+  M google/services/compute/resource_compute_address.go
+  D google/services/compute/resource_compute_old.go
+other: 0 added, 1 changed, 0 deleted
+  M website/docs/r/compute_address.html.markdown
+Total: 1 added, 2 changed, 1 deleted
+`
+	if got := got.String(); got != wantSummary {
+		t.Errorf("String() = %q, want %q", got, wantSummary)
+	}
+}
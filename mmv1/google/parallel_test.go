@@ -0,0 +1,47 @@
+package google
+
+import (
+	"sync/atomic"
+	"testing"
+)
+
+func TestParallelEach(t *testing.T) {
+	cases := []struct {
+		name        string
+		n           int
+		parallelism int
+		wantMax     int32
+	}{
+		{name: "no work", n: 0, parallelism: 4, wantMax: 0},
+		{name: "one worker", n: 10, parallelism: 1, wantMax: 1},
+		{name: "non-positive parallelism", n: 10, parallelism: 0, wantMax: 1},
+		{name: "more workers than work", n: 3, parallelism: 8, wantMax: 3},
+		{name: "bounded workers", n: 50, parallelism: 4, wantMax: 4},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			results := make([]int, tc.n)
+			var running, maxRunning int32
+			ParallelEach(tc.n, tc.parallelism, func(i int) {
+				current := atomic.AddInt32(&running, 1)
+				for {
+					max := atomic.LoadInt32(&maxRunning)
+					if current <= max || atomic.CompareAndSwapInt32(&maxRunning, max, current) {
+						break
+					}
+				}
+				results[i] = i * i
+				atomic.AddInt32(&running, -1)
+			})
+
+			for i, got := range results {
+				if got != i*i {
+					t.Errorf("results[%d] = %d, want %d", i, got, i*i)
+				}
+			}
+			if maxRunning > tc.wantMax {
+				t.Errorf("ran %d calls at once, want at most %d", maxRunning, tc.wantMax)
+			}
+		})
+	}
+}
@@ -15,7 +15,12 @@ package google
 
 import (
 	"bytes"
+	"fmt"
 	"log"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
 
 	"gopkg.in/yaml.v3"
 )
@@ -32,3 +37,193 @@ func (v *YamlValidator) Parse(content []byte, obj interface{}, yamlPath string)
 		log.Fatalf("Cannot unmarshal data from file %s: %v", yamlPath, err)
 	}
 }
+
+// YamlProblem is a problem found in a YAML file, at the position of the node that caused it.
+type YamlProblem struct {
+	File    string
+	Line    int
+	Column  int
+	Message string
+}
+
+func (p YamlProblem) String() string {
+	return fmt.Sprintf("%s:%d:%d: %s", p.File, p.Line, p.Column, p.Message)
+}
+
+// YamlSchema lists the constraints on YAML content that the Go types it's decoded into
+// can't express.
+type YamlSchema struct {
+	// Required lists the keys that must be set on objects decoded into each type.
+	Required map[reflect.Type][]string
+	// Enums lists the allowed values of keys on objects decoded into each type.
+	Enums map[reflect.Type]map[string][]string
+}
+
+// Validate checks YAML content against the fields of obj's type and the schema before it's
+// decoded, and returns every problem found: unknown keys, values of the wrong type, values
+// that aren't in an enum and missing required keys. Parse stops at the first problem, and
+// later steps fail with errors that don't point at the file.
+func (v *YamlValidator) Validate(content []byte, obj interface{}, yamlPath string, schema YamlSchema) []YamlProblem {
+	var root yaml.Node
+	if err := yaml.Unmarshal(content, &root); err != nil {
+		// Syntax errors already include the line.
+		return []YamlProblem{{File: yamlPath, Message: err.Error()}}
+	}
+	if len(root.Content) == 0 {
+		return nil
+	}
+	w := &yamlWalker{file: yamlPath, schema: schema}
+	w.walk(root.Content[0], reflect.TypeOf(obj))
+	sort.SliceStable(w.problems, func(i, j int) bool {
+		if w.problems[i].Line != w.problems[j].Line {
+			return w.problems[i].Line < w.problems[j].Line
+		}
+		return w.problems[i].Column < w.problems[j].Column
+	})
+	return w.problems
+}
+
+type yamlWalker struct {
+	file     string
+	schema   YamlSchema
+	problems []YamlProblem
+}
+
+func (w *yamlWalker) problem(node *yaml.Node, format string, args ...interface{}) {
+	w.problems = append(w.problems, YamlProblem{
+		File:    w.file,
+		Line:    node.Line,
+		Column:  node.Column,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+func (w *yamlWalker) walk(node *yaml.Node, t reflect.Type) {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if node.Kind == yaml.ScalarNode && node.ShortTag() == "!!null" {
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		if node.Kind != yaml.MappingNode {
+			w.problem(node, "expected an object, got %s", describeNode(node))
+			return
+		}
+		w.walkStruct(node, t, make(map[string]bool))
+	case reflect.Map:
+		if node.Kind != yaml.MappingNode {
+			w.problem(node, "expected a map, got %s", describeNode(node))
+			return
+		}
+		for i := 1; i < len(node.Content); i += 2 {
+			w.walk(node.Content[i], t.Elem())
+		}
+	case reflect.Slice, reflect.Array:
+		if node.Kind != yaml.SequenceNode {
+			w.problem(node, "expected a list, got %s", describeNode(node))
+			return
+		}
+		for _, item := range node.Content {
+			w.walk(item, t.Elem())
+		}
+	case reflect.String:
+		if node.Kind != yaml.ScalarNode {
+			w.problem(node, "expected a string, got %s", describeNode(node))
+		}
+	case reflect.Bool:
+		if node.Kind != yaml.ScalarNode || node.ShortTag() != "!!bool" {
+			w.problem(node, "expected true or false, got %s", describeNode(node))
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if node.Kind != yaml.ScalarNode || node.ShortTag() != "!!int" {
+			w.problem(node, "expected an integer, got %s", describeNode(node))
+		}
+	case reflect.Float32, reflect.Float64:
+		if node.Kind != yaml.ScalarNode || (node.ShortTag() != "!!int" && node.ShortTag() != "!!float") {
+			w.problem(node, "expected a number, got %s", describeNode(node))
+		}
+	}
+}
+
+// walkStruct checks the keys of a mapping decoded into the struct type t. seen collects the keys
+// set so far, so merged mappings count towards the required keys.
+func (w *yamlWalker) walkStruct(node *yaml.Node, t reflect.Type, seen map[string]bool) {
+	fields := yamlFields(t)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		if key.ShortTag() == "!!merge" {
+			if value.Kind == yaml.AliasNode {
+				value = value.Alias
+			}
+			if value.Kind == yaml.MappingNode {
+				w.walkStruct(value, t, seen)
+			}
+			continue
+		}
+		fieldType, ok := fields[key.Value]
+		if !ok {
+			w.problem(key, "unknown key %q", key.Value)
+			continue
+		}
+		if seen[key.Value] {
+			w.problem(key, "key %q is already set", key.Value)
+		}
+		seen[key.Value] = true
+		if allowed, ok := w.schema.Enums[t][key.Value]; ok && value.Kind == yaml.ScalarNode && value.ShortTag() != "!!null" {
+			if !slices.Contains(allowed, value.Value) {
+				w.problem(value, "invalid value %q for %q, expected one of %s", value.Value, key.Value, strings.Join(allowed, ", "))
+			}
+		}
+		w.walk(value, fieldType)
+	}
+	for _, key := range w.schema.Required[t] {
+		if !seen[key] {
+			w.problem(node, "missing required key %q", key)
+		}
+	}
+}
+
+// yamlFields maps the YAML keys of a struct type to the types of their fields, following the
+// naming rules of gopkg.in/yaml.v3.
+func yamlFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("yaml")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+		if slices.Contains(strings.Split(options, ","), "inline") {
+			for key, fieldType := range yamlFields(field.Type) {
+				fields[key] = fieldType
+			}
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+		fields[name] = field.Type
+	}
+	return fields
+}
+
+func describeNode(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.MappingNode:
+		return "an object"
+	case yaml.SequenceNode:
+		return "a list"
+	}
+	return fmt.Sprintf("%q", node.Value)
+}
@@ -0,0 +1,130 @@
+package google
+
+import (
+	"reflect"
+	"testing"
+)
+
+type testYamlItem struct {
+	Name     string
+	Kind     string            `yaml:"kind,omitempty"`
+	Count    int               `yaml:"count,omitempty"`
+	Ratio    float64           `yaml:"ratio,omitempty"`
+	Enabled  bool              `yaml:"enabled,omitempty"`
+	Labels   map[string]string `yaml:"labels,omitempty"`
+	Internal string            `yaml:"-"`
+}
+
+type testYamlInline struct {
+	Timeout string `yaml:"timeout,omitempty"`
+}
+
+type testYamlRoot struct {
+	Name           string          `yaml:"name"`
+	Items          []*testYamlItem `yaml:"items,omitempty"`
+	Parent         *testYamlItem   `yaml:"parent,omitempty"`
+	testYamlInline `yaml:",inline"`
+}
+
+func TestYamlValidatorValidate(t *testing.T) {
+	t.Parallel()
+
+	schema := YamlSchema{
+		Required: map[reflect.Type][]string{
+			reflect.TypeOf(testYamlItem{}): {"name"},
+		},
+		Enums: map[reflect.Type]map[string][]string{
+			reflect.TypeOf(testYamlItem{}): {"kind": {"A", "B"}},
+		},
+	}
+
+	cases := []struct {
+		description string
+		content     string
+		expected    []string
+	}{
+		{
+			description: "valid",
+			content: `name: root
+timeout: 10s
+items:
+  - name: first
+    kind: A
+    count: 1
+    ratio: 2
+    enabled: true
+    labels:
+      a: b
+  - &second
+    name: second
+parent:
+  <<: *second
+  kind: B
+`,
+		},
+		{
+			description: "empty",
+			content:     "",
+		},
+		{
+			description: "every problem is reported with its position",
+			content: `name: root
+items:
+  - name: first
+    kind: C
+    count: many
+  - kind: A
+    enabled: yes
+    color: red
+parent: first
+name: again
+`,
+			expected: []string{
+				"test.yaml:4:11: invalid value \"C\" for \"kind\", expected one of A, B",
+				"test.yaml:5:12: expected an integer, got \"many\"",
+				"test.yaml:6:5: missing required key \"name\"",
+				"test.yaml:7:14: expected true or false, got \"yes\"",
+				"test.yaml:8:5: unknown key \"color\"",
+				"test.yaml:9:9: expected an object, got \"first\"",
+				"test.yaml:10:1: key \"name\" is already set",
+			},
+		},
+		{
+			description: "wrong collection types",
+			content: `name: [root]
+items:
+  name: first
+parent:
+  name: parent
+  labels: [a, b]
+`,
+			expected: []string{
+				"test.yaml:1:7: expected a string, got a list",
+				"test.yaml:3:3: expected a list, got an object",
+				"test.yaml:6:11: expected a map, got a list",
+			},
+		},
+		{
+			description: "syntax error",
+			content:     "name: [root\n",
+			expected:    []string{"test.yaml:0:0: yaml: line 1: did not find expected ',' or ']'"},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			v := YamlValidator{}
+			var got []string
+			for _, problem := range v.Validate([]byte(tc.content), &testYamlRoot{}, "test.yaml", schema) {
+				got = append(got, problem.String())
+			}
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
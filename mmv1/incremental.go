@@ -0,0 +1,255 @@
+// Copyright 2025 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// examplesFolder holds example configs, named after the example that uses them.
+const examplesFolder = "templates/terraform/examples"
+
+// Words in product yaml that may name a template, like a custom code path or an example name.
+var yamlWordRegexp = regexp.MustCompile(`[\w./-]+`)
+
+// GenerationState records hashes of the inputs of an incremental generation run, so that the
+// next run can regenerate only the products whose inputs changed.
+type GenerationState struct {
+	// Hash of the generator binary
+	Generator string `json:"generator"`
+	// Hash of the templates that aren't specific to a product
+	Templates string `json:"templates"`
+	// Hash of each product's yaml and the templates it references, keyed by package path
+	Products map[string]string `json:"products"`
+}
+
+// HashGenerationInputs hashes the inputs of each product in fsys. A template is an input of
+// every product whose yaml references it, either by path or, for example configs, by example
+// name. Templates that no product references are shared, and are hashed together.
+func HashGenerationInputs(fsys fs.FS, productPaths []string) (GenerationState, error) {
+	state := GenerationState{Products: make(map[string]string)}
+	hashers := make(map[string]*inputHasher)
+	referencedBy := make(map[string][]string)
+	for _, p := range productPaths {
+		hashers[p] = newInputHasher()
+		err := fs.WalkDir(fsys, p, func(filePath string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return err
+			}
+			content, err := fs.ReadFile(fsys, filePath)
+			if err != nil {
+				return err
+			}
+			hashers[p].add(filePath, content)
+			for _, word := range yamlWordRegexp.FindAllString(string(content), -1) {
+				if refs := referencedBy[word]; len(refs) == 0 || refs[len(refs)-1] != p {
+					referencedBy[word] = append(refs, p)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return state, fmt.Errorf("hashing %s: %w", p, err)
+		}
+	}
+
+	shared := newInputHasher()
+	err := fs.WalkDir(fsys, "templates", func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		content, err := fs.ReadFile(fsys, filePath)
+		if err != nil {
+			return err
+		}
+		products := referencedBy[filePath]
+		if path.Dir(filePath) == examplesFolder {
+			exampleName, _, _ := strings.Cut(path.Base(filePath), ".")
+			products = append(products, referencedBy[exampleName]...)
+		}
+		if len(products) == 0 {
+			shared.add(filePath, content)
+		}
+		for _, p := range products {
+			hashers[p].add(filePath, content)
+		}
+		return nil
+	})
+	if err != nil {
+		return state, fmt.Errorf("hashing templates: %w", err)
+	}
+
+	state.Templates = shared.sum()
+	for p, h := range hashers {
+		state.Products[p] = h.sum()
+	}
+	return state, nil
+}
+
+// ChangedProducts returns the products in current whose inputs differ from previous, in
+// sorted order. Every product has changed if the generator or the shared templates have.
+func ChangedProducts(previous, current GenerationState) []string {
+	allChanged := previous.Generator != current.Generator || previous.Templates != current.Templates
+	var changed []string
+	for p, hash := range current.Products {
+		if allChanged || previous.Products[p] != hash {
+			changed = append(changed, p)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+// hashGenerator hashes the running binary, so that changes to the generator's code
+// regenerate everything.
+func hashGenerator() (string, error) {
+	executable, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+	f, err := os.Open(executable)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// generationStatePath returns where the state of incremental generation with these options is
+// kept. It's outside the output folder so that it doesn't show up in the downstream checkout.
+func generationStatePath(outputPath, providerName, version string, generateCode, generateDocs bool) (string, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	absOutputPath, err := filepath.Abs(outputPath)
+	if err != nil {
+		return "", err
+	}
+	key := sha256.Sum256([]byte(fmt.Sprintf("%s|%s|%s|%t|%t", absOutputPath, providerName, version, generateCode, generateDocs)))
+	return filepath.Join(cacheDir, "magic-modules", "mmv1-incremental", hex.EncodeToString(key[:])+".json"), nil
+}
+
+// readGenerationState returns an empty state if no generation has been recorded yet.
+func readGenerationState(statePath string) (GenerationState, error) {
+	var state GenerationState
+	content, err := os.ReadFile(statePath)
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return state, err
+	}
+	err = json.Unmarshal(content, &state)
+	return state, err
+}
+
+func writeGenerationState(statePath string, state GenerationState) error {
+	content, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(statePath), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(statePath, content, 0644)
+}
+
+// inputHasher hashes a set of files, including their paths so that renames count as changes.
+type inputHasher struct {
+	files []string
+	sums  map[string][sha256.Size]byte
+}
+
+func newInputHasher() *inputHasher {
+	return &inputHasher{sums: make(map[string][sha256.Size]byte)}
+}
+
+func (h *inputHasher) add(filePath string, content []byte) {
+	if _, ok := h.sums[filePath]; !ok {
+		h.files = append(h.files, filePath)
+	}
+	h.sums[filePath] = sha256.Sum256(content)
+}
+
+func (h *inputHasher) sum() string {
+	sort.Strings(h.files)
+	total := sha256.New()
+	for _, f := range h.files {
+		sum := h.sums[f]
+		fmt.Fprintf(total, "%s\x00%x\n", f, sum)
+	}
+	return hex.EncodeToString(total.Sum(nil))
+}
+
+// selectChangedProducts narrows productsToGenerate to the products whose inputs changed since
+// the last incremental generation with the same options, and returns the current state to
+// record once generation succeeds.
+func selectChangedProducts(fsys fs.FS, productPaths, productsToGenerate []string, statePath string) (GenerationState, []string, error) {
+	current, err := HashGenerationInputs(fsys, productPaths)
+	if err != nil {
+		return current, nil, err
+	}
+	if current.Generator, err = hashGenerator(); err != nil {
+		return current, nil, fmt.Errorf("hashing the generator: %w", err)
+	}
+	previous, err := readGenerationState(statePath)
+	if err != nil {
+		return current, nil, fmt.Errorf("reading %s: %w", statePath, err)
+	}
+
+	var changed []string
+	for _, p := range ChangedProducts(previous, current) {
+		if slices.Contains(productsToGenerate, p) {
+			changed = append(changed, p)
+		}
+	}
+	return current, changed, nil
+}
+
+// recordGeneration saves the hashes of the products that were generated. When only some
+// products were requested, the others keep their previous hashes, as do the generator and
+// shared templates, so that products that weren't regenerated are still picked up next time.
+func recordGeneration(statePath string, current GenerationState, generated []string, allRequested bool) error {
+	if allRequested {
+		return writeGenerationState(statePath, current)
+	}
+	previous, err := readGenerationState(statePath)
+	if err != nil {
+		return err
+	}
+	if previous.Products == nil {
+		previous.Products = make(map[string]string)
+	}
+	for _, p := range generated {
+		previous.Products[p] = current.Products[p]
+	}
+	return writeGenerationState(statePath, previous)
+}
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+func TestHashGenerationInputs(t *testing.T) {
+	base := fstest.MapFS{
+		"products/pubsub/product.yaml":                            {Data: []byte("name: 'Pubsub'")},
+		"products/pubsub/Topic.yaml":                              {Data: []byte("examples:\n  - name: 'pubsub_topic_basic'\n")},
+		"products/redis/Instance.yaml":                            {Data: []byte("custom_code:\n  pre_create: 'templates/terraform/pre_create/redis.go.tmpl'\n")},
+		"templates/terraform/examples/pubsub_topic_basic.tf.tmpl": {Data: []byte("resource {}")},
+		"templates/terraform/pre_create/redis.go.tmpl":            {Data: []byte("// pre create")},
+		"templates/terraform/resource.go.tmpl":                    {Data: []byte("// resource")},
+	}
+	products := []string{"products/pubsub", "products/redis"}
+	baseState, err := HashGenerationInputs(base, products)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[string]struct {
+		file string
+		want []string
+	}{
+		"product yaml": {
+			file: "products/pubsub/product.yaml",
+			want: []string{"products/pubsub"},
+		},
+		"example referenced by name": {
+			file: "templates/terraform/examples/pubsub_topic_basic.tf.tmpl",
+			want: []string{"products/pubsub"},
+		},
+		"template referenced by path": {
+			file: "templates/terraform/pre_create/redis.go.tmpl",
+			want: []string{"products/redis"},
+		},
+		"shared template": {
+			file: "templates/terraform/resource.go.tmpl",
+			want: []string{"products/pubsub", "products/redis"},
+		},
+		"new shared template": {
+			file: "templates/terraform/new.go.tmpl",
+			want: []string{"products/pubsub", "products/redis"},
+		},
+		"new product file": {
+			file: "products/redis/Cluster.yaml",
+			want: []string{"products/redis"},
+		},
+	}
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			changed := fstest.MapFS{}
+			for name, file := range base {
+				changed[name] = file
+			}
+			changed[tc.file] = &fstest.MapFile{Data: []byte("changed")}
+
+			state, err := HashGenerationInputs(changed, products)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := ChangedProducts(baseState, state); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("ChangedProducts() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestChangedProducts(t *testing.T) {
+	previous := GenerationState{
+		Generator: "g",
+		Templates: "t",
+		Products:  map[string]string{"products/pubsub": "a", "products/redis": "b"},
+	}
+	cases := map[string]struct {
+		previous GenerationState
+		current  GenerationState
+		want     []string
+	}{
+		"unchanged": {
+			previous: previous,
+			current:  previous,
+			want:     nil,
+		},
+		"changed product": {
+			previous: previous,
+			current: GenerationState{
+				Generator: "g",
+				Templates: "t",
+				Products:  map[string]string{"products/pubsub": "a", "products/redis": "c"},
+			},
+			want: []string{"products/redis"},
+		},
+		"new product": {
+			previous: previous,
+			current: GenerationState{
+				Generator: "g",
+				Templates: "t",
+				Products:  map[string]string{"products/pubsub": "a", "products/redis": "b", "products/spanner": "d"},
+			},
+			want: []string{"products/spanner"},
+		},
+		"changed generator": {
+			previous: previous,
+			current: GenerationState{
+				Generator: "h",
+				Templates: "t",
+				Products:  map[string]string{"products/pubsub": "a", "products/redis": "b"},
+			},
+			want: []string{"products/pubsub", "products/redis"},
+		},
+		"no previous generation": {
+			previous: GenerationState{},
+			current: GenerationState{
+				Generator: "g",
+				Templates: "t",
+				Products:  map[string]string{"products/pubsub": "a"},
+			},
+			want: []string{"products/pubsub"},
+		},
+	}
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			if got := ChangedProducts(tc.previous, tc.current); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("ChangedProducts() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRecordGeneration(t *testing.T) {
+	statePath := filepath.Join(t.TempDir(), "state", "output.json")
+	previous := GenerationState{
+		Generator: "g",
+		Templates: "t",
+		Products:  map[string]string{"products/pubsub": "a", "products/redis": "b"},
+	}
+	if err := recordGeneration(statePath, previous, nil, true); err != nil {
+		t.Fatal(err)
+	}
+
+	// Only redis was requested, so the pubsub and shared hashes must stay as they were.
+	current := GenerationState{
+		Generator: "h",
+		Templates: "u",
+		Products:  map[string]string{"products/pubsub": "c", "products/redis": "d"},
+	}
+	if err := recordGeneration(statePath, current, []string{"products/redis"}, false); err != nil {
+		t.Fatal(err)
+	}
+	got, err := readGenerationState(statePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := GenerationState{
+		Generator: "g",
+		Templates: "t",
+		Products:  map[string]string{"products/pubsub": "a", "products/redis": "d"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("readGenerationState() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,205 @@
+// Copyright 2025 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/lint"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/loader"
+)
+
+// resourceFile is a resource yaml file, by its path relative to the base or overrides
+// directory, like products/pubsub/Topic.yaml.
+type resourceFile struct {
+	Rel  string
+	Path string
+}
+
+// Lint checks resource definitions against the lint rules and prints the findings. It
+// returns the exit code: 1 if any finding is an error.
+//
+// Example usage: go run . lint --fix products/pubsub/Topic.yaml
+func Lint(args []string) int {
+	flags := flag.NewFlagSet("lint", flag.ExitOnError)
+	fix := flags.Bool("fix", false, "fix the findings of fixable rules in place before linting")
+	listRules := flags.Bool("rules", false, "list the lint rules and exit")
+	baseDirectory := flags.String("base", "", "optional directory containing mmv1 products/ and templates/ directories. Empty value defaults to GetCwd().")
+	overrideDirectory := flags.String("overrides", "", "optional directory containing yaml overrides")
+	version := flags.String("version", "beta", "version to load resources at")
+	parallelism := flags.Int("parallelism", runtime.NumCPU(), "maximum number of products to load at the same time")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: lint [flags] [resource yaml files]\n\nLints every resource if no files are given.\n\n")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	if *listRules {
+		for _, rule := range lint.Rules {
+			fixable := ""
+			if rule.Fixable {
+				fixable = " (fixable)"
+			}
+			fmt.Printf("%s (%s)%s: %s\n", rule.Name, rule.Severity, fixable, rule.Description)
+		}
+		return 0
+	}
+
+	if *baseDirectory == "" {
+		var err error
+		if *baseDirectory, err = os.Getwd(); err != nil {
+			log.Fatalf("Cannot get the working directory: %s", err)
+		}
+	}
+	files, err := lintTargets(flags.Args(), *baseDirectory, *overrideDirectory)
+	if err != nil {
+		log.Fatalf("Cannot find the files to lint: %s", err)
+	}
+
+	if *fix {
+		for _, f := range files {
+			if err := fixFile(f.Path); err != nil {
+				log.Fatalf("Cannot fix %s: %s", f.Rel, err)
+			}
+		}
+	}
+
+	ofs, err := google.NewOverlayFS(*overrideDirectory, *baseDirectory)
+	if err != nil {
+		log.Fatalf("Cannot read %s: %s", *baseDirectory, err)
+	}
+	l := loader.NewLoader(loader.Config{Version: *version, BaseDirectory: *baseDirectory, OverrideDirectory: *overrideDirectory, Sysfs: ofs, Parallelism: *parallelism})
+	l.LoadProducts()
+	var products []*api.Product
+	for _, p := range l.Products {
+		products = append(products, p)
+	}
+
+	linted := make(map[string]bool)
+	var findings []lint.Finding
+	for _, f := range files {
+		linted[f.Rel] = true
+		content, err := os.ReadFile(f.Path)
+		if err != nil {
+			log.Fatalf("Cannot read %s: %s", f.Rel, err)
+		}
+		findings = append(findings, lint.LintFile(f.Rel, content)...)
+	}
+	for _, f := range lint.Lint(products) {
+		if linted[f.File] {
+			findings = append(findings, f)
+		}
+	}
+	lint.SortFindings(findings)
+
+	errors, warnings := 0, 0
+	for _, f := range findings {
+		fmt.Println(f)
+		if f.Severity == lint.Error {
+			errors++
+		} else {
+			warnings++
+		}
+	}
+	fmt.Printf("%d errors, %d warnings in %d files\n", errors, warnings, len(files))
+	if errors > 0 {
+		return 1
+	}
+	return 0
+}
+
+// lintTargets returns the resource files given as arguments, or every resource file in the
+// base and overrides directories if none are.
+func lintTargets(args []string, baseDirectory, overrideDirectory string) ([]resourceFile, error) {
+	var files []resourceFile
+	if len(args) == 0 {
+		for _, dir := range []string{baseDirectory, overrideDirectory} {
+			if dir == "" {
+				continue
+			}
+			paths, err := filepath.Glob(filepath.Join(dir, "products", "*", "*.yaml"))
+			if err != nil {
+				return nil, err
+			}
+			for _, p := range paths {
+				if filepath.Base(p) == "product.yaml" {
+					continue
+				}
+				rel, err := filepath.Rel(dir, p)
+				if err != nil {
+					return nil, err
+				}
+				files = append(files, resourceFile{Rel: filepath.ToSlash(rel), Path: p})
+			}
+		}
+		return files, nil
+	}
+
+	for _, arg := range args {
+		path, err := filepath.Abs(arg)
+		if err != nil {
+			return nil, err
+		}
+		if filepath.Base(path) == "product.yaml" {
+			continue
+		}
+		rel, err := relativeTo(path, overrideDirectory, baseDirectory)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, resourceFile{Rel: rel, Path: path})
+	}
+	return files, nil
+}
+
+// relativeTo returns path relative to the first of dirs that contains it.
+func relativeTo(path string, dirs ...string) (string, error) {
+	for _, dir := range dirs {
+		if dir == "" {
+			continue
+		}
+		absDir, err := filepath.Abs(dir)
+		if err != nil {
+			return "", err
+		}
+		if rel, err := filepath.Rel(absDir, path); err == nil && !strings.HasPrefix(rel, "..") {
+			return filepath.ToSlash(rel), nil
+		}
+	}
+	return "", fmt.Errorf("%s isn't in the base or overrides directory", path)
+}
+
+func fixFile(path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	fixed, err := lint.Fix(content)
+	if err != nil {
+		return err
+	}
+	if bytes.Equal(content, fixed) {
+		return nil
+	}
+	return os.WriteFile(path, fixed, 0644)
+}
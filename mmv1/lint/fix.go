@@ -0,0 +1,115 @@
+// Copyright 2025 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lint
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LintFile checks the contents of a resource's yaml file for the rules about its text
+// rather than its definition.
+func LintFile(file string, content []byte) []Finding {
+	var findings []Finding
+	for i, line := range strings.Split(string(content), "\n") {
+		if strings.TrimRight(line, " \t") != line {
+			findings = append(findings, Finding{
+				File:     file,
+				Rule:     "trailing-whitespace",
+				Severity: Warning,
+				Path:     fmt.Sprintf("line %d", i+1),
+				Message:  "line ends with whitespace",
+			})
+		}
+	}
+	return findings
+}
+
+// Fix corrects the findings of fixable rules in the contents of a resource's yaml file,
+// by removing trailing whitespace and ending descriptions with a period. It only edits
+// the affected lines, so that the rest of the file keeps its formatting.
+func Fix(content []byte) ([]byte, error) {
+	lines := strings.Split(string(content), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(strings.Join(lines, "\n")), &doc); err != nil {
+		return nil, err
+	}
+	var walk func(n *yaml.Node)
+	walk = func(n *yaml.Node) {
+		if n.Kind == yaml.MappingNode {
+			for i := 0; i+1 < len(n.Content); i += 2 {
+				key, value := n.Content[i], n.Content[i+1]
+				if key.Value == "description" && value.Kind == yaml.ScalarNode {
+					endDescription(lines, key, value)
+				}
+			}
+		}
+		for _, c := range n.Content {
+			walk(c)
+		}
+	}
+	walk(&doc)
+	return []byte(strings.Join(lines, "\n")), nil
+}
+
+// endDescription adds a period to the end of a description that ends mid-sentence.
+func endDescription(lines []string, key, value *yaml.Node) {
+	description := strings.TrimSpace(value.Value)
+	if !unfinishedDescriptionRegexp.MatchString(description) {
+		return
+	}
+	lastChar := description[len(description)-1:]
+
+	switch value.Style {
+	case yaml.SingleQuotedStyle, yaml.DoubleQuotedStyle:
+		// Only descriptions that fit on one line, where the closing quote is easy to find
+		line := lines[value.Line-1]
+		quote := "'"
+		if value.Style == yaml.DoubleQuotedStyle {
+			quote = `"`
+		}
+		end := strings.LastIndex(line, quote)
+		if end <= value.Column-1 || !strings.HasSuffix(line[:end], lastChar) {
+			return
+		}
+		lines[value.Line-1] = line[:end] + "." + line[end:]
+	case yaml.LiteralStyle, yaml.FoldedStyle, 0:
+		// Block and plain descriptions continue on the lines that are indented more than the key
+		start := value.Line - 1
+		if value.Style != 0 {
+			start = value.Line
+		}
+		last := -1
+		for i := start; i < len(lines); i++ {
+			trimmed := strings.TrimLeft(lines[i], " ")
+			if trimmed == "" {
+				continue
+			}
+			if i > value.Line-1 && len(lines[i])-len(trimmed) <= key.Column-1 {
+				break
+			}
+			last = i
+		}
+		if last == -1 || strings.Contains(lines[last], " #") || !strings.HasSuffix(lines[last], lastChar) {
+			return
+		}
+		lines[last] += "."
+	}
+}
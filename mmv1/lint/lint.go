@@ -0,0 +1,275 @@
+// Copyright 2025 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package lint checks resource definitions for the conventions that aren't
+// enforced when they are loaded, like naming and description style.
+package lint
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+)
+
+type Severity string
+
+const (
+	// Errors fail the lint run.
+	Error Severity = "error"
+	// Warnings are reported, but usually flag older resources that don't follow the current conventions.
+	Warning Severity = "warning"
+)
+
+// Rule is a convention that resource definitions are checked for.
+type Rule struct {
+	Name        string
+	Severity    Severity
+	Description string
+	// Whether Fix corrects the rule's findings.
+	Fixable bool
+	check   func(p *api.Product, r *api.Resource) []Finding
+}
+
+// Finding is a place where a resource definition doesn't follow a rule.
+type Finding struct {
+	// The resource's yaml file, relative to the mmv1 directory
+	File     string
+	Rule     string
+	Severity Severity
+	// The resource or field the finding is about, like Instance.nodeConfig.diskSize
+	Path    string
+	Message string
+}
+
+func (f Finding) String() string {
+	return fmt.Sprintf("%s: %s [%s] %s: %s", f.File, f.Severity, f.Rule, f.Path, f.Message)
+}
+
+var (
+	resourceNameRegexp = regexp.MustCompile(`^[A-Z][A-Za-z0-9]*$`)
+	propertyNameRegexp = regexp.MustCompile(`^[a-z][A-Za-z0-9_]*$`)
+	exampleNameRegexp  = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+	// Descriptions that end mid-sentence, rather than with punctuation, a list or a code block
+	unfinishedDescriptionRegexp = regexp.MustCompile(`[A-Za-z0-9]$`)
+)
+
+var Rules = []Rule{
+	{
+		Name:        "resource-name",
+		Severity:    Error,
+		Description: "Resource names are UpperCamelCase, like the API's resource type.",
+		check:       checkResourceName,
+	},
+	{
+		Name:        "property-name",
+		Severity:    Error,
+		Description: "Property names start with a lowercase letter and only contain letters, digits and underscores. Use api_name when the API's name differs.",
+		check:       checkPropertyNames,
+	},
+	{
+		Name:        "description",
+		Severity:    Error,
+		Description: "Resources and their fields have a description, which is used in the documentation.",
+		check:       checkDescriptionPresence,
+	},
+	{
+		Name:        "description-style",
+		Severity:    Warning,
+		Description: "Descriptions are sentences that start with a capital letter and end with a period.",
+		Fixable:     true,
+		check:       checkDescriptionStyle,
+	},
+	{
+		Name:        "example-name",
+		Severity:    Error,
+		Description: "Example names are snake_case, since they name the example's template and test.",
+		check:       checkExampleNames,
+	},
+	{
+		Name:        "example-name-prefix",
+		Severity:    Warning,
+		Description: "Example names start with the product's name, like pubsub_topic_basic, so that tests are grouped by product.",
+		check:       checkExampleNamePrefix,
+	},
+	{
+		Name:        "test-coverage",
+		Severity:    Error,
+		Description: "Resources have at least one example or sample, so that an acceptance test is generated. Use exclude_test on an example that can't be run in CI.",
+		check:       checkTestCoverage,
+	},
+	{
+		Name:        "sweeper-coverage",
+		Severity:    Warning,
+		Description: "Resources that don't get a sweeper generated by default configure one with sweeper, or set exclude_sweeper to declare that none is needed.",
+		check:       checkSweeperCoverage,
+	},
+	{
+		Name:        "trailing-whitespace",
+		Severity:    Warning,
+		Description: "Lines in resource definitions don't end with whitespace.",
+		Fixable:     true,
+		// Checked on the file contents by LintFile
+	},
+}
+
+// Lint checks the resources of the given products against every rule. Findings are
+// sorted by file, then by resource or field.
+func Lint(products []*api.Product) []Finding {
+	var findings []Finding
+	for _, p := range products {
+		for _, r := range p.Objects {
+			if r.IsExcluded() {
+				continue
+			}
+			for _, rule := range Rules {
+				if rule.check == nil {
+					continue
+				}
+				for _, f := range rule.check(p, r) {
+					f.File = r.SourceYamlFile
+					f.Rule = rule.Name
+					f.Severity = rule.Severity
+					findings = append(findings, f)
+				}
+			}
+		}
+	}
+	SortFindings(findings)
+	return findings
+}
+
+// SortFindings sorts findings by file, then by resource or field, then by rule.
+func SortFindings(findings []Finding) {
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].File != findings[j].File {
+			return findings[i].File < findings[j].File
+		}
+		if findings[i].Path != findings[j].Path {
+			return findings[i].Path < findings[j].Path
+		}
+		return findings[i].Rule < findings[j].Rule
+	})
+}
+
+func checkResourceName(p *api.Product, r *api.Resource) []Finding {
+	if resourceNameRegexp.MatchString(r.Name) {
+		return nil
+	}
+	return []Finding{{Path: r.Name, Message: fmt.Sprintf("resource name %q isn't UpperCamelCase", r.Name)}}
+}
+
+func checkPropertyNames(p *api.Product, r *api.Resource) []Finding {
+	var findings []Finding
+	walkProperties(r, func(path string, prop *api.Type) {
+		if !propertyNameRegexp.MatchString(prop.Name) {
+			findings = append(findings, Finding{Path: path, Message: fmt.Sprintf("property name %q should be lowerCamelCase", prop.Name)})
+		}
+	})
+	return findings
+}
+
+func checkDescriptionPresence(p *api.Product, r *api.Resource) []Finding {
+	var findings []Finding
+	if strings.TrimSpace(r.Description) == "" {
+		findings = append(findings, Finding{Path: r.Name, Message: "resource has no description"})
+	}
+	walkProperties(r, func(path string, prop *api.Type) {
+		if strings.TrimSpace(prop.Description) == "" {
+			findings = append(findings, Finding{Path: path, Message: "field has no description"})
+		}
+	})
+	return findings
+}
+
+func checkDescriptionStyle(p *api.Product, r *api.Resource) []Finding {
+	var findings []Finding
+	check := func(path, description string) {
+		description = strings.TrimSpace(description)
+		if description == "" {
+			return
+		}
+		if first := description[0]; first >= 'a' && first <= 'z' {
+			findings = append(findings, Finding{Path: path, Message: "description should start with a capital letter"})
+		}
+		if unfinishedDescriptionRegexp.MatchString(description) {
+			findings = append(findings, Finding{Path: path, Message: "description should end with a period"})
+		}
+	}
+	check(r.Name, r.Description)
+	walkProperties(r, func(path string, prop *api.Type) {
+		check(path, prop.Description)
+	})
+	return findings
+}
+
+func checkExampleNames(p *api.Product, r *api.Resource) []Finding {
+	var findings []Finding
+	for _, e := range r.Examples {
+		if !exampleNameRegexp.MatchString(e.Name) {
+			findings = append(findings, Finding{Path: r.Name, Message: fmt.Sprintf("example name %q isn't snake_case", e.Name)})
+		}
+	}
+	return findings
+}
+
+func checkExampleNamePrefix(p *api.Product, r *api.Resource) []Finding {
+	var findings []Finding
+	prefix := google.Underscore(p.Name) + "_"
+	for _, e := range r.Examples {
+		if !strings.HasPrefix(e.Name, prefix) {
+			findings = append(findings, Finding{Path: r.Name, Message: fmt.Sprintf("example name %q should start with %q", e.Name, prefix)})
+		}
+	}
+	return findings
+}
+
+func checkTestCoverage(p *api.Product, r *api.Resource) []Finding {
+	if len(r.Examples) > 0 || len(r.Samples) > 0 {
+		return nil
+	}
+	return []Finding{{Path: r.Name, Message: "resource has no examples or samples, so no acceptance test is generated for it"}}
+}
+
+func checkSweeperCoverage(p *api.Product, r *api.Resource) []Finding {
+	if r.ExcludeSweeper || r.ShouldGenerateSweepers() {
+		return nil
+	}
+	return []Finding{{Path: r.Name, Message: "no sweeper is generated for this resource; configure one with sweeper or set exclude_sweeper: true"}}
+}
+
+// walkProperties calls f for each field of the resource that isn't excluded, with its
+// path, like Instance.nodeConfig.diskSize.
+func walkProperties(r *api.Resource, f func(path string, prop *api.Type)) {
+	var walk func(parent string, props []*api.Type)
+	walk = func(parent string, props []*api.Type) {
+		for _, prop := range props {
+			if prop.Exclude {
+				continue
+			}
+			path := parent + "." + prop.Name
+			f(path, prop)
+			walk(path, prop.Properties)
+			if prop.ItemType != nil {
+				walk(path, prop.ItemType.Properties)
+			}
+			if prop.ValueType != nil {
+				walk(path, prop.ValueType.Properties)
+			}
+		}
+	}
+	walk(r.Name, r.AllUserProperties())
+}
@@ -0,0 +1,170 @@
+package lint
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/resource"
+)
+
+func TestLint(t *testing.T) {
+	cases := map[string]struct {
+		resource *api.Resource
+		want     []string
+	}{
+		"conventional": {
+			resource: &api.Resource{
+				Name:           "Topic",
+				Description:    "A named resource to which messages are sent.",
+				ExcludeSweeper: true,
+				Examples:       []*resource.Examples{{Name: "pubsub_topic_basic"}},
+				Properties: []*api.Type{
+					{Name: "messageRetentionDuration", Description: "Minimum duration to retain a message."},
+				},
+			},
+		},
+		"resource name": {
+			resource: &api.Resource{
+				Name:           "topic",
+				Description:    "A topic.",
+				ExcludeSweeper: true,
+				Examples:       []*resource.Examples{{Name: "pubsub_topic_basic"}},
+			},
+			want: []string{"resource-name"},
+		},
+		"nested property name and description": {
+			resource: &api.Resource{
+				Name:           "Topic",
+				Description:    "A topic.",
+				ExcludeSweeper: true,
+				Examples:       []*resource.Examples{{Name: "pubsub_topic_basic"}},
+				Properties: []*api.Type{
+					{
+						Name:        "schemaSettings",
+						Description: "Settings for validating messages.",
+						Properties: []*api.Type{
+							{Name: "Schema"},
+							{Name: "excluded", Exclude: true},
+						},
+					},
+				},
+			},
+			want: []string{"description", "property-name"},
+		},
+		"description style": {
+			resource: &api.Resource{
+				Name:           "Topic",
+				Description:    "a topic",
+				ExcludeSweeper: true,
+				Examples:       []*resource.Examples{{Name: "pubsub_topic_basic"}},
+				Properties: []*api.Type{
+					{Name: "labels", Description: "Labels, like:\n```\nkey = value\n```"},
+				},
+			},
+			want: []string{"description-style", "description-style"},
+		},
+		"example names": {
+			resource: &api.Resource{
+				Name:           "Topic",
+				Description:    "A topic.",
+				ExcludeSweeper: true,
+				Examples:       []*resource.Examples{{Name: "Topic_Basic"}, {Name: "topic_cmek"}},
+			},
+			want: []string{"example-name", "example-name-prefix", "example-name-prefix"},
+		},
+		"no examples": {
+			resource: &api.Resource{
+				Name:           "Topic",
+				Description:    "A topic.",
+				ExcludeSweeper: true,
+			},
+			want: []string{"test-coverage"},
+		},
+		"excluded resource": {
+			resource: &api.Resource{
+				Name:    "topic",
+				Exclude: true,
+			},
+		},
+	}
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			p := &api.Product{Name: "Pubsub", Objects: []*api.Resource{tc.resource}}
+			var got []string
+			for _, f := range Lint([]*api.Product{p}) {
+				got = append(got, f.Rule)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("Lint() rules = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestLintFile(t *testing.T) {
+	content := []byte("name: 'Topic' \ndescription: 'A topic.'\nexamples:\t\n")
+	var got []string
+	for _, f := range LintFile("products/pubsub/Topic.yaml", content) {
+		got = append(got, f.Path)
+	}
+	want := []string{"line 1", "line 3"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("LintFile() paths = %v, want %v", got, want)
+	}
+}
+
+func TestFix(t *testing.T) {
+	cases := map[string]struct {
+		content string
+		want    string
+	}{
+		"single quoted": {
+			content: "description: 'A topic'\n",
+			want:    "description: 'A topic.'\n",
+		},
+		"double quoted with a comment": {
+			content: "description: \"A topic\" # comment\n",
+			want:    "description: \"A topic.\" # comment\n",
+		},
+		"plain": {
+			content: "description: A topic\n",
+			want:    "description: A topic.\n",
+		},
+		"plain over several lines": {
+			content: "properties:\n  - name: 'a'\n    description: A long\n      topic\n  - name: 'b'\n",
+			want:    "properties:\n  - name: 'a'\n    description: A long\n      topic.\n  - name: 'b'\n",
+		},
+		"literal block": {
+			content: "properties:\n  - name: 'a'\n    description: |\n      A topic.\n\n      Its name\n\n  - name: 'b'\n",
+			want:    "properties:\n  - name: 'a'\n    description: |\n      A topic.\n\n      Its name.\n\n  - name: 'b'\n",
+		},
+		"already finished": {
+			content: "description: |\n  See:\n  ```\n  code\n  ```\n",
+			want:    "description: |\n  See:\n  ```\n  code\n  ```\n",
+		},
+		"quoted over several lines is left alone": {
+			content: "description: \"A long\n  topic\"\n",
+			want:    "description: \"A long\n  topic\"\n",
+		},
+		"trailing whitespace": {
+			content: "name: 'Topic'  \nexamples:\t\n  - name: 'a'\n",
+			want:    "name: 'Topic'\nexamples:\n  - name: 'a'\n",
+		},
+		"other keys": {
+			content: "name: topic\nimmutable: true\n",
+			want:    "name: topic\nimmutable: true\n",
+		},
+	}
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			got, err := Fix([]byte(tc.content))
+			if err != nil {
+				t.Fatalf("Fix() error = %v", err)
+			}
+			if string(got) != tc.want {
+				t.Errorf("Fix() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -6,8 +6,8 @@ import (
 	"log"
 	"path/filepath"
 	"reflect"
+	"runtime"
 	"strings"
-	"sync"
 
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
@@ -22,6 +22,7 @@ type Loader struct {
 	Products          map[string]*api.Product
 	version           string
 	sysfs             google.ReadDirReadFileFS
+	parallelism       int
 }
 
 type Config struct {
@@ -29,6 +30,7 @@ type Config struct {
 	OverrideDirectory string                   // optional
 	Version           string                   // required
 	Sysfs             google.ReadDirReadFileFS // required
+	Parallelism       int                      // optional, defaults to the number of CPUs
 }
 
 // NewLoader creates a new Loader instance, applying any
@@ -44,11 +46,15 @@ func NewLoader(config Config) *Loader {
 	if config.Sysfs == nil {
 		panic("sysfs is required")
 	}
+	if config.Parallelism == 0 {
+		config.Parallelism = runtime.NumCPU()
+	}
 	l := &Loader{
 		baseDirectory:     config.BaseDirectory,
 		overrideDirectory: config.OverrideDirectory,
 		version:           config.Version,
 		sysfs:             config.Sysfs,
+		parallelism:       config.Parallelism,
 	}
 
 	return l
@@ -104,33 +110,19 @@ func (l *Loader) batchLoadProducts(productNames []string) map[string]*api.Produc
 		err     error
 	}
 
-	// Buffered channel to prevent goroutine blocking
-	productChan := make(chan loadResult, len(productNames))
-
-	// Use WaitGroup for proper synchronization
-	var wg sync.WaitGroup
-
-	// Launch all goroutines
-	for _, productName := range productNames {
-		wg.Add(1)
-		go func(name string) {
-			defer wg.Done()
-
-			product, err := l.LoadProduct(name)
-			productChan <- loadResult{
-				name:    name,
-				product: product,
-				err:     err,
-			}
-		}(productName)
-	}
-
-	wg.Wait()
-	close(productChan)
+	// Store results by index so that errors are reported in the same order on every run
+	results := make([]loadResult, len(productNames))
+	google.ParallelEach(len(productNames), l.parallelism, func(i int) {
+		product, err := l.LoadProduct(productNames[i])
+		results[i] = loadResult{
+			name:    productNames[i],
+			product: product,
+			err:     err,
+		}
+	})
 
-	// Collect results as they complete
 	loadFailureCount := 0
-	for result := range productChan {
+	for _, result := range results {
 		if result.err != nil {
 			// Check if the error is the specific "version not found" error
 			var versionErr *ErrProductVersionNotFound
@@ -176,7 +168,7 @@ func (l *Loader) LoadProduct(productName string) (*api.Product, error) {
 		if baseProductExists {
 			api.Compile(baseProductPath, p)
 			overrideApiProduct := &api.Product{}
-			api.Compile(productOverridePath, overrideApiProduct)
+			api.CompileOverride(productOverridePath, overrideApiProduct)
 			api.Merge(reflect.ValueOf(p).Elem(), reflect.ValueOf(*overrideApiProduct), l.version)
 		} else {
 			api.Compile(productOverridePath, p)
@@ -198,6 +190,13 @@ func (l *Loader) LoadProduct(productName string) (*api.Product, error) {
 	}
 
 	p.Objects = resources
+	// Resources can be configured after their parent, so repeat until there are no changes
+	for inferred := true; inferred; {
+		inferred = false
+		for _, r := range resources {
+			inferred = r.InferSweeperParent(resources) || inferred
+		}
+	}
 	p.Validate()
 
 	return p, nil
@@ -291,7 +290,7 @@ func (l *Loader) loadResource(product *api.Product, baseResourcePath string, ove
 			// Merge base and override
 			api.Compile(baseResourcePath, resource)
 			overrideResource := &api.Resource{}
-			api.Compile(overrideResourcePath, overrideResource)
+			api.CompileOverride(overrideResourcePath, overrideResource)
 			api.Merge(reflect.ValueOf(resource).Elem(), reflect.ValueOf(*overrideResource), l.version)
 		} else {
 			// Override only
@@ -6,8 +6,9 @@ import (
 	"io/fs"
 	"log"
 	"os"
+	"path/filepath"
+	"runtime"
 	"strings"
-	"sync"
 	"time"
 
 	"golang.org/x/exp/slices"
@@ -19,8 +20,6 @@ import (
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/provider"
 )
 
-var wg sync.WaitGroup
-
 // TODO rewrite: additional flags
 
 // Example usage: --output $GOPATH/src/github.com/terraform-providers/terraform-provider-google-beta
@@ -43,10 +42,20 @@ var doNotGenerateDocs = flag.Bool("no-docs", false, "do not generate docs")
 
 var providerFlag = flag.String("provider", "", "optional provider name. If specified, a non-default provider will be used.")
 
+var parallelismFlag = flag.Int("parallelism", runtime.NumCPU(), "maximum number of products to load and generate at the same time")
+
+var incrementalFlag = flag.Bool("incremental", false, "only generate the products whose yaml or templates changed since the last --incremental run into --output")
+
+var dryRunFlag = flag.Bool("dry-run", false, "generate into a temporary directory and print a summary of the files that would be added, changed and deleted in --output, without writing to it")
+
 var openapiGenerate = flag.Bool("openapi-generate", false, "Generate MMv1 YAML from openapi directory (Experimental)")
 
 func main() {
 
+	if len(os.Args) > 1 && os.Args[1] == "lint" {
+		os.Exit(Lint(os.Args[2:]))
+	}
+
 	// Handle all flags in main. Other functions must not access flag values directly.
 	flag.Parse()
 
@@ -61,10 +70,49 @@ func main() {
 		return
 	}
 
-	GenerateProducts(*productFlag, *resourceFlag, *providerFlag, *versionFlag, *outputPathFlag, *baseDirectoryFlag, *overrideDirectoryFlag, !*doNotGenerateCode, !*doNotGenerateDocs)
+	if *incrementalFlag && *resourceFlag != "" {
+		log.Fatalf("--incremental can't be used with --resource")
+	}
+	if *incrementalFlag && *dryRunFlag {
+		log.Fatalf("--incremental can't be used with --dry-run")
+	}
+
+	if *dryRunFlag {
+		DryRun(*productFlag, *resourceFlag, *providerFlag, *versionFlag, *outputPathFlag, *baseDirectoryFlag, *overrideDirectoryFlag, !*doNotGenerateCode, !*doNotGenerateDocs, *parallelismFlag)
+		return
+	}
+
+	GenerateProducts(*productFlag, *resourceFlag, *providerFlag, *versionFlag, *outputPathFlag, *baseDirectoryFlag, *overrideDirectoryFlag, !*doNotGenerateCode, !*doNotGenerateDocs, *parallelismFlag, *incrementalFlag)
+}
+
+// DryRun generates into a temporary directory and prints the differences from the downstream
+// checkout at outputPath, leaving the checkout untouched.
+func DryRun(product, resource, providerName, version, outputPath, baseDirectory, overrideDirectory string, generateCode, generateDocs bool, parallelism int) {
+	tempDir, err := os.MkdirTemp("", "mmv1-dry-run")
+	if err != nil {
+		log.Fatalf("Cannot create a temporary directory: %s", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	// Use the same folder name as the checkout, since the generator uses it to decide which
+	// downstream it's generating, e.g. to add copyright headers.
+	generatedPath := filepath.Join(tempDir, filepath.Base(filepath.Clean(outputPath)))
+	if err := os.Mkdir(generatedPath, 0755); err != nil {
+		log.Fatalf("Cannot create %s: %s", generatedPath, err)
+	}
+	GenerateProducts(product, resource, providerName, version, generatedPath, baseDirectory, overrideDirectory, generateCode, generateDocs, parallelism, false)
+
+	generationDiff, err := DiffGeneratedFiles(generatedPath, outputPath)
+	if err != nil {
+		log.Fatalf("Cannot compare generated files with %s: %s", outputPath, err)
+	}
+	fmt.Print(generationDiff)
 }
 
-func GenerateProducts(product, resource, providerName, version, outputPath, baseDirectory, overrideDirectory string, generateCode, generateDocs bool) {
+// GenerateProducts generates the requested products into outputPath. When incremental is set,
+// only the products whose inputs changed since the last incremental run are regenerated,
+// although files shared by every product, like the provider, are always regenerated.
+func GenerateProducts(product, resource, providerName, version, outputPath, baseDirectory, overrideDirectory string, generateCode, generateDocs bool, parallelism int, incremental bool) {
 	if version == "" {
 		log.Printf("No version specified, assuming ga")
 		version = "ga"
@@ -89,7 +137,7 @@ func GenerateProducts(product, resource, providerName, version, outputPath, base
 		panic(err)
 	}
 
-	loader := loader.NewLoader(loader.Config{Version: version, BaseDirectory: baseDirectory, OverrideDirectory: overrideDirectory, Sysfs: ofs})
+	loader := loader.NewLoader(loader.Config{Version: version, BaseDirectory: baseDirectory, OverrideDirectory: overrideDirectory, Sysfs: ofs, Parallelism: parallelism})
 	loader.LoadProducts()
 	loader.AddExtraFields()
 	loader.Validate()
@@ -105,11 +153,23 @@ func GenerateProducts(product, resource, providerName, version, outputPath, base
 		productsToGenerate = []string{productToGenerate}
 	}
 
-	for _, productApi := range loadedProducts {
-		wg.Add(1)
-		go GenerateProduct(version, providerName, productApi, outputPath, startTime, ofs, productsToGenerate, resource, generateCode, generateDocs)
+	var statePath string
+	var currentState GenerationState
+	if incremental {
+		if statePath, err = generationStatePath(outputPath, providerName, version, generateCode, generateDocs); err != nil {
+			log.Fatalf("Cannot find where to keep the incremental generation state: %s", err)
+		}
+		var productPaths []string
+		for _, p := range loadedProducts {
+			productPaths = append(productPaths, p.PackagePath)
+		}
+		requested := len(productsToGenerate)
+		currentState, productsToGenerate, err = selectChangedProducts(ofs, productPaths, productsToGenerate, statePath)
+		if err != nil {
+			log.Fatalf("Cannot find the changed products: %s", err)
+		}
+		log.Printf("Incremental generation: %d of %d products changed", len(productsToGenerate), requested)
 	}
-	wg.Wait()
 
 	var productsForVersion []*api.Product
 	for _, p := range loadedProducts {
@@ -119,6 +179,11 @@ func GenerateProducts(product, resource, providerName, version, outputPath, base
 		return strings.Compare(strings.ToLower(p1.Name), strings.ToLower(p2.Name))
 	})
 
+	// Products are handed to the workers in name order so that runs schedule work the same way.
+	google.ParallelEach(len(productsForVersion), parallelism, func(i int) {
+		GenerateProduct(version, providerName, productsForVersion[i], outputPath, startTime, ofs, productsToGenerate, resource, generateCode, generateDocs)
+	})
+
 	// In order to only copy/compile files once per provider this must be called outside
 	// of the products loop. Create an MMv1 provider with an arbitrary product (the first loaded).
 	providerToGenerate := newProvider(providerName, version, productsForVersion[0], startTime, ofs)
@@ -127,6 +192,15 @@ func GenerateProducts(product, resource, providerName, version, outputPath, base
 	if generateCode {
 		providerToGenerate.CompileCommonFiles(outputPath, productsForVersion, "")
 	}
+	if terraform, ok := providerToGenerate.(provider.Terraform); ok && generateDocs {
+		terraform.GenerateDocsIndex(outputPath, productsForVersion)
+	}
+
+	if incremental {
+		if err := recordGeneration(statePath, currentState, productsToGenerate, product == ""); err != nil {
+			log.Fatalf("Cannot record the incremental generation state: %s", err)
+		}
+	}
 }
 
 // GenerateProduct generates code and documentation for a product
@@ -134,15 +208,15 @@ func GenerateProducts(product, resource, providerName, version, outputPath, base
 func GenerateProduct(version, providerName string, productApi *api.Product, outputPath string,
 	startTime time.Time, fsys fs.FS, productsToGenerate []string, resourceToGenerate string,
 	generateCode, generateDocs bool) {
-	defer wg.Done()
-
+	// Creating the provider sets up the product for the version, like its base URL, which
+	// the common files need even for products that aren't generated.
+	providerToGenerate := newProvider(providerName, version, productApi, startTime, fsys)
 	if !slices.Contains(productsToGenerate, productApi.PackagePath) {
 		log.Printf("%s not specified, skipping generation", productApi.PackagePath)
 		return
 	}
 
 	log.Printf("%s: Generating files", productApi.PackagePath)
-	providerToGenerate := newProvider(providerName, version, productApi, startTime, fsys)
 	providerToGenerate.Generate(outputPath, resourceToGenerate, generateCode, generateDocs)
 }
 
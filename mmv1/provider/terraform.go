@@ -1031,3 +1031,36 @@ type ProviderWithProducts struct {
 	Compiler string
 	Products []*api.Product
 }
+
+// CustomEndpoints returns the custom endpoints of the resources in the target
+// version, sorted by name, for the provider config to add alongside the product
+// endpoints.
+func (p ProviderWithProducts) CustomEndpoints() []resource.CustomEndpoint {
+	productNames := make(map[string]bool)
+	for _, productDefinition := range p.Products {
+		productNames[productDefinition.Name] = true
+	}
+
+	endpoints := make(map[string]resource.CustomEndpoint)
+	for _, productDefinition := range p.Products {
+		for _, object := range productDefinition.Objects {
+			if object.CustomEndpoint == nil || object.Exclude || object.NotInVersion(productDefinition.VersionObjOrClosest(p.TargetVersionName)) {
+				continue
+			}
+			endpoint := *object.CustomEndpoint
+			if productNames[endpoint.Name] {
+				log.Fatalf("custom_endpoint %s in resource %s has the same name as a product", endpoint.Name, object.Name)
+			}
+			if existing, ok := endpoints[endpoint.Name]; ok && existing.BaseUrl != endpoint.BaseUrl {
+				log.Fatalf("custom_endpoint %s has different base URLs %q and %q", endpoint.Name, existing.BaseUrl, endpoint.BaseUrl)
+			}
+			endpoints[endpoint.Name] = endpoint
+		}
+	}
+
+	var sorted []resource.CustomEndpoint
+	for _, name := range slices.Sorted(maps.Keys(endpoints)) {
+		sorted = append(sorted, endpoints[name])
+	}
+	return sorted
+}
@@ -0,0 +1,262 @@
+// Copyright 2025 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"fmt"
+	"io/fs"
+	"log"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+)
+
+const handwrittenDocsFolder = "third_party/terraform/website/docs"
+
+var (
+	// Registrations like "google_compute_instance": compute.ResourceComputeInstance(),
+	registeredNameRegexp = regexp.MustCompile(`"(google_\w+)":\s*(\w+)\.`)
+	// Plugin framework type names like resp.TypeName = req.ProviderTypeName + "_storage_notification"
+	frameworkTypeNameRegexp = regexp.MustCompile(`ProviderTypeName \+ "(_\w+)"`)
+	// Handwritten metadata like resource: 'google_compute_instance'
+	metadataResourceRegexp = regexp.MustCompile(`(?m)^resource: '?(google_\w+)`)
+)
+
+// DocsPage is a handwritten documentation page for a resource or data source.
+type DocsPage struct {
+	// Path relative to the downstream repository, like website/docs/r/compute_instance.html.markdown
+	Path string
+	// Terraform name the page documents, like google_compute_instance
+	Name        string
+	Subcategory string
+}
+
+// DocsIndex is the sidebar grouping of the handwritten documentation pages.
+type DocsIndex struct {
+	// Pages that had no subcategory, mapped to the subcategory found for them
+	FilledSubcategories map[string]string
+	// Pages without a subcategory where none could be found
+	MissingSubcategories []string
+	// Pages for a name that isn't a resource or data source in the provider
+	Orphaned []string
+}
+
+// GenerateDocsIndex fills in the subcategory of handwritten documentation pages that
+// don't set one, using the display name of the product their resource belongs to, so
+// that pages are grouped in the registry sidebar without hand-editing frontmatter.
+// It also logs pages that don't document a resource or data source in the provider.
+func (t Terraform) GenerateDocsIndex(outputFolder string, products []*api.Product) {
+	index, err := BuildDocsIndex(t.templateFS, products)
+	if err != nil {
+		log.Fatalf("Cannot build documentation index: %s", err)
+	}
+
+	for _, page := range slices.Sorted(maps.Keys(index.FilledSubcategories)) {
+		targetFile := filepath.Join(outputFolder, page)
+		content, err := os.ReadFile(targetFile)
+		if err != nil {
+			log.Fatalf("Cannot read %s to add a subcategory: %s", targetFile, err)
+		}
+		content, err = addSubcategory(content, index.FilledSubcategories[page])
+		if err != nil {
+			log.Fatalf("Cannot add a subcategory to %s: %s", targetFile, err)
+		}
+		if err := os.WriteFile(targetFile, content, 0644); err != nil {
+			log.Fatalf("Cannot write %s: %s", targetFile, err)
+		}
+	}
+	for _, page := range index.MissingSubcategories {
+		log.Printf("WARNING: %s has no subcategory and none could be found from its resource; add one to its frontmatter", page)
+	}
+	for _, page := range index.Orphaned {
+		log.Printf("WARNING: %s doesn't document a resource or data source in the provider", page)
+	}
+}
+
+// BuildDocsIndex reads the handwritten documentation pages and resource registrations in fsys,
+// and finds the subcategory of each page that doesn't set one.
+func BuildDocsIndex(fsys fs.FS, products []*api.Product) (DocsIndex, error) {
+	index := DocsIndex{FilledSubcategories: make(map[string]string)}
+
+	displayNames := make(map[string]string)
+	services := make(map[string]string)
+	for _, p := range products {
+		displayNames[p.ApiName] = p.DisplayName
+		for _, r := range p.Objects {
+			services[r.TerraformName()] = p.ApiName
+			if r.IamPolicy != nil {
+				services[r.IamTerraformName()] = p.ApiName
+			}
+		}
+	}
+	if err := readHandwrittenNames(fsys, services); err != nil {
+		return index, err
+	}
+
+	pages, err := readDocsPages(fsys)
+	if err != nil {
+		return index, err
+	}
+	names := slices.Sorted(maps.Keys(services))
+	for _, page := range pages {
+		service, ok := serviceForName(services, names, page.Name)
+		if !ok {
+			index.Orphaned = append(index.Orphaned, page.Path)
+		}
+		if page.Subcategory != "" {
+			continue
+		}
+		if displayName := displayNames[service]; displayName != "" {
+			index.FilledSubcategories[page.Path] = displayName
+		} else {
+			index.MissingSubcategories = append(index.MissingSubcategories, page.Path)
+		}
+	}
+	return index, nil
+}
+
+// readHandwrittenNames adds the names of handwritten resources and data sources to services,
+// mapped to their service package, or to "" when it isn't known.
+func readHandwrittenNames(fsys fs.FS, services map[string]string) error {
+	add := func(name, service string) {
+		if services[name] == "" {
+			services[name] = service
+		}
+	}
+	return fs.WalkDir(fsys, "third_party/terraform", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || strings.Contains(d.Name(), "_test.go") || !(strings.HasSuffix(p, ".go") || strings.HasSuffix(p, ".tmpl") || strings.HasSuffix(p, ".yaml")) {
+			return nil
+		}
+		content, err := fs.ReadFile(fsys, p)
+		if err != nil {
+			return err
+		}
+
+		service := ""
+		if parts := strings.Split(p, "/"); len(parts) > 4 && parts[2] == "services" {
+			service = parts[3]
+		}
+		if strings.Contains(d.Name(), "_meta.yaml") {
+			if m := metadataResourceRegexp.FindSubmatch(content); m != nil {
+				add(string(m[1]), service)
+			}
+		}
+		for _, m := range frameworkTypeNameRegexp.FindAllSubmatch(content, -1) {
+			add("google"+string(m[1]), service)
+		}
+		if strings.HasPrefix(p, "third_party/terraform/provider/") || strings.HasPrefix(p, "third_party/terraform/fwprovider/") {
+			for _, m := range registeredNameRegexp.FindAllSubmatch(content, -1) {
+				registeredService := string(m[2])
+				if strings.HasPrefix(registeredService, "tpg") {
+					// Shared helpers like tpgiamresource.ResourceIamMember don't name the service
+					registeredService = ""
+				}
+				add(string(m[1]), registeredService)
+			}
+		}
+		return nil
+	})
+}
+
+func readDocsPages(fsys fs.FS) ([]DocsPage, error) {
+	var pages []DocsPage
+	for _, kind := range []string{"r", "d"} {
+		entries, err := fs.ReadDir(fsys, path.Join(handwrittenDocsFolder, kind))
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".html.markdown") {
+				continue
+			}
+			content, err := fs.ReadFile(fsys, path.Join(handwrittenDocsFolder, kind, entry.Name()))
+			if err != nil {
+				return nil, err
+			}
+			name := strings.TrimSuffix(entry.Name(), ".html.markdown")
+			if !strings.HasPrefix(name, "google_") {
+				name = "google_" + name
+			}
+			pages = append(pages, DocsPage{
+				Path:        path.Join("website/docs", kind, entry.Name()),
+				Name:        name,
+				Subcategory: frontmatterSubcategory(string(content)),
+			})
+		}
+	}
+	return pages, nil
+}
+
+// serviceForName returns the service of the resource or data source a page documents. Pages
+// may also document a group of resources, like google_project_iam for google_project_iam_member.
+func serviceForName(services map[string]string, sortedNames []string, name string) (string, bool) {
+	if service, ok := services[name]; ok {
+		return service, true
+	}
+	found := false
+	service := ""
+	for _, n := range sortedNames {
+		if strings.HasPrefix(n, name+"_") {
+			found = true
+			if service = services[n]; service != "" {
+				break
+			}
+		}
+	}
+	return service, found
+}
+
+// frontmatterEnd returns the index of the line that closes the page's frontmatter, or -1.
+func frontmatterEnd(content string) int {
+	if !strings.HasPrefix(content, "---\n") {
+		return -1
+	}
+	end := strings.Index(content[len("---"):], "\n---")
+	if end == -1 {
+		return -1
+	}
+	return end + len("---\n")
+}
+
+func frontmatterSubcategory(content string) string {
+	end := frontmatterEnd(content)
+	if end == -1 {
+		return ""
+	}
+	for _, line := range strings.Split(content[len("---\n"):end], "\n") {
+		if value, ok := strings.CutPrefix(line, "subcategory:"); ok {
+			return strings.Trim(strings.TrimSpace(value), `"'`)
+		}
+	}
+	return ""
+}
+
+// addSubcategory adds the subcategory as the last line of the page's frontmatter.
+func addSubcategory(content []byte, subcategory string) ([]byte, error) {
+	s := string(content)
+	end := frontmatterEnd(s)
+	if end == -1 {
+		return nil, fmt.Errorf("page has no frontmatter")
+	}
+	return []byte(s[:end] + fmt.Sprintf("subcategory: %q\n", subcategory) + s[end:]), nil
+}
@@ -0,0 +1,91 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+	"testing/fstest"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+)
+
+func TestBuildDocsIndex(t *testing.T) {
+	pubsub := &api.Product{Name: "Pubsub", ApiName: "pubsub", DisplayName: "Cloud Pub/Sub"}
+	pubsub.Objects = []*api.Resource{{Name: "Topic", ProductMetadata: pubsub}}
+	compute := &api.Product{Name: "Compute", ApiName: "compute", DisplayName: "Compute Engine"}
+
+	fsys := fstest.MapFS{
+		"third_party/terraform/provider/provider_mmv1_resources.go.tmpl": {Data: []byte(`
+var handwrittenResources = map[string]*schema.Resource{
+	"google_compute_instance":        compute.ResourceComputeInstance(),
+	"google_project_iam_member":      tpgiamresource.ResourceIamMember(resourcemanager.IamProjectSchema),
+	"google_unknown_service_thing":   unknown.ResourceThing(),
+}`)},
+		"third_party/terraform/services/compute/resource_compute_disk_meta.yaml":     {Data: []byte("resource: 'google_compute_disk'\n")},
+		"third_party/terraform/services/storage/fw_resource_storage_notification.go": {Data: []byte(`resp.TypeName = req.ProviderTypeName + "_storage_notification"`)},
+		"third_party/terraform/website/docs/r/compute_instance.html.markdown":        {Data: []byte("---\nsubcategory: \"Compute Engine\"\n---\n# google_compute_instance\n")},
+		"third_party/terraform/website/docs/r/compute_disk.html.markdown":            {Data: []byte("---\ndescription: A disk\n---\n# google_compute_disk\n")},
+		"third_party/terraform/website/docs/r/pubsub_topic.html.markdown":            {Data: []byte("---\n---\n# google_pubsub_topic\n")},
+		"third_party/terraform/website/docs/r/google_project_iam.html.markdown":      {Data: []byte("---\nsubcategory: \"Cloud Platform\"\n---\n")},
+		"third_party/terraform/website/docs/r/storage_notification.html.markdown":    {Data: []byte("---\n---\n")},
+		"third_party/terraform/website/docs/r/unknown_service_thing.html.markdown":   {Data: []byte("---\n---\n")},
+		"third_party/terraform/website/docs/d/compute_removed.html.markdown":         {Data: []byte("---\nsubcategory: 'Compute Engine'\n---\n")},
+	}
+
+	got, err := BuildDocsIndex(fsys, []*api.Product{compute, pubsub})
+	if err != nil {
+		t.Fatalf("BuildDocsIndex() returned error: %s", err)
+	}
+	want := DocsIndex{
+		FilledSubcategories: map[string]string{
+			"website/docs/r/compute_disk.html.markdown": "Compute Engine",
+			"website/docs/r/pubsub_topic.html.markdown": "Cloud Pub/Sub",
+		},
+		MissingSubcategories: []string{
+			"website/docs/r/storage_notification.html.markdown",
+			"website/docs/r/unknown_service_thing.html.markdown",
+		},
+		Orphaned: []string{"website/docs/d/compute_removed.html.markdown"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("BuildDocsIndex() = %#v, want %#v", got, want)
+	}
+}
+
+func TestAddSubcategory(t *testing.T) {
+	cases := []struct {
+		name    string
+		content string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "empty frontmatter",
+			content: "---\n---\n# google_pubsub_topic\n",
+			want:    "---\nsubcategory: \"Cloud Pub/Sub\"\n---\n# google_pubsub_topic\n",
+		},
+		{
+			name:    "existing frontmatter",
+			content: "---\n# header\ndescription: |-\n  A topic.\n---\n# google_pubsub_topic\n",
+			want:    "---\n# header\ndescription: |-\n  A topic.\nsubcategory: \"Cloud Pub/Sub\"\n---\n# google_pubsub_topic\n",
+		},
+		{
+			name:    "no frontmatter",
+			content: "# google_pubsub_topic\n",
+			wantErr: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := addSubcategory([]byte(tc.content), "Cloud Pub/Sub")
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("addSubcategory() returned error %v, want error: %t", err, tc.wantErr)
+			}
+			if string(got) != tc.want {
+				t.Errorf("addSubcategory() = %q, want %q", got, tc.want)
+			}
+			if !tc.wantErr && frontmatterSubcategory(string(got)) != "Cloud Pub/Sub" {
+				t.Errorf("frontmatterSubcategory() = %q after adding it", frontmatterSubcategory(string(got)))
+			}
+		})
+	}
+}
@@ -10,6 +10,7 @@ package builds
 import ArtifactRules
 import DefaultBuildTimeoutDuration
 import DefaultParallelism
+import generated.ServiceEnvironment
 import generated.ServiceParallelism
 import jetbrains.buildServer.configs.kotlin.BuildType
 import jetbrains.buildServer.configs.kotlin.failureConditions.BuildFailureOnText
@@ -101,6 +102,7 @@ class PackageDetails(private val packageName: String, private val displayName: S
                 terraformShouldPanicForSchemaErrors()
                 readOnlySettings()
                 workingDirectory(path)
+                serviceEnvironmentVariables(ServiceEnvironment.getOrDefault(packageName, mapOf()))
             }
 
             artifactRules = ArtifactRules
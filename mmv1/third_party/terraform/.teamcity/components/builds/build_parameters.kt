@@ -308,6 +308,14 @@ fun ParametrizedWithType.workingDirectory(path: String) {
     text("PACKAGE_PATH", path, "", "The path at which to run - automatically updated", ParameterDisplay.HIDDEN)
 }
 
+// ParametrizedWithType.serviceEnvironmentVariables sets the environment variables a service's tests need, which are
+// set in the service's product.yaml file and generated into per_service_environment.kt
+fun ParametrizedWithType.serviceEnvironmentVariables(environment: Map<String, String>) {
+    environment.forEach { (name, value) ->
+        text("env.$name", value)
+    }
+}
+
 fun ParametrizedWithType.hiddenVariable(name: String, value: String, description: String) {
     text(name, value, "", description, ParameterDisplay.HIDDEN)
 }
@@ -0,0 +1,11 @@
+/*
+ * Copyright (c) HashiCorp, Inc.
+ * SPDX-License-Identifier: MPL-2.0
+ */
+
+// this file is auto-generated by magic-modules/tools/teamcity-generator from the products in mmv1/products, any changes made here will be overwritten
+
+package generated
+
+var ServiceEnvironment = mapOf<String, Map<String, String>>(
+)
@@ -3,10 +3,10 @@
  * SPDX-License-Identifier: MPL-2.0
  */
 
-// This file is maintained in the GoogleCloudPlatform/magic-modules repository and copied into the downstream provider repositories. Any changes to this file in the downstream will be overwritten.
+// this file is auto-generated by magic-modules/tools/teamcity-generator from the products in mmv1/products, any changes made here will be overwritten
 
 package generated
 
-var ServiceParallelism = mapOf(
+var ServiceParallelism = mapOf<String, Int>(
     "looker" to 1
-)
\ No newline at end of file
+)
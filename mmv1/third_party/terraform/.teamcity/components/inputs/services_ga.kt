@@ -3,42 +3,34 @@
  * SPDX-License-Identifier: MPL-2.0
  */
 
-// This file is maintained in the GoogleCloudPlatform/magic-modules repository and copied into the downstream provider repositories. Any changes to this file in the downstream will be overwritten.
-
-/*
-  NOTE: This file is manually curated - any new service packages added to the provider codebase will need to be accompanied with a change in this file.
-        When adding new entires make sure to ensure the path value is correct for TPG or TPGB
-
-        For this file, services_ga.kt, please ensure paths are like:
-            "./google/services/accessapproval"
-*/
+// this file is auto-generated by magic-modules/tools/teamcity-generator from the products in mmv1/products, any changes made here will be overwritten
 
 package generated
 
 var ServicesListGa = mapOf(
     "accessapproval" to mapOf(
         "name" to "accessapproval",
-        "displayName" to "Accessapproval",
+        "displayName" to "Access Approval",
         "path" to "./google/services/accessapproval"
     ),
     "accesscontextmanager" to mapOf(
         "name" to "accesscontextmanager",
-        "displayName" to "Accesscontextmanager",
+        "displayName" to "Access Context Manager (VPC Service Controls)",
         "path" to "./google/services/accesscontextmanager"
     ),
     "activedirectory" to mapOf(
         "name" to "activedirectory",
-        "displayName" to "Activedirectory",
+        "displayName" to "Managed Microsoft Active Directory",
         "path" to "./google/services/activedirectory"
     ),
     "alloydb" to mapOf(
         "name" to "alloydb",
-        "displayName" to "Alloydb",
+        "displayName" to "AlloyDB",
         "path" to "./google/services/alloydb"
     ),
     "apigateway" to mapOf(
         "name" to "apigateway",
-        "displayName" to "Apigateway",
+        "displayName" to "API Gateway",
         "path" to "./google/services/apigateway"
     ),
     "apigee" to mapOf(
@@ -48,7 +40,7 @@ var ServicesListGa = mapOf(
     ),
     "apihub" to mapOf(
         "name" to "apihub",
-        "displayName" to "Apihub",
+        "displayName" to "API Hub",
         "path" to "./google/services/apihub"
     ),
     "apikeys" to mapOf(
@@ -58,17 +50,17 @@ var ServicesListGa = mapOf(
     ),
     "appengine" to mapOf(
         "name" to "appengine",
-        "displayName" to "Appengine",
+        "displayName" to "App Engine",
         "path" to "./google/services/appengine"
     ),
     "apphub" to mapOf(
         "name" to "apphub",
-        "displayName" to "Apphub",
+        "displayName" to "App Hub",
         "path" to "./google/services/apphub"
     ),
     "artifactregistry" to mapOf(
         "name" to "artifactregistry",
-        "displayName" to "Artifactregistry",
+        "displayName" to "Artifact Registry",
         "path" to "./google/services/artifactregistry"
     ),
     "assuredworkloads" to mapOf(
@@ -78,12 +70,12 @@ var ServicesListGa = mapOf(
     ),
     "backupdr" to mapOf(
         "name" to "backupdr",
-        "displayName" to "Backupdr",
+        "displayName" to "Backup and DR Service",
         "path" to "./google/services/backupdr"
     ),
     "beyondcorp" to mapOf(
         "name" to "beyondcorp",
-        "displayName" to "Beyondcorp",
+        "displayName" to "BeyondCorp",
         "path" to "./google/services/beyondcorp"
     ),
     "biglake" to mapOf(
@@ -93,72 +85,72 @@ var ServicesListGa = mapOf(
     ),
     "biglakeiceberg" to mapOf(
         "name" to "biglakeiceberg",
-        "displayName" to "BiglakeIceberg",
+        "displayName" to "Biglake",
         "path" to "./google/services/biglakeiceberg"
     ),
     "bigquery" to mapOf(
         "name" to "bigquery",
-        "displayName" to "Bigquery",
+        "displayName" to "BigQuery",
         "path" to "./google/services/bigquery"
     ),
     "bigqueryanalyticshub" to mapOf(
         "name" to "bigqueryanalyticshub",
-        "displayName" to "Bigqueryanalyticshub",
+        "displayName" to "BigQuery Analytics Hub",
         "path" to "./google/services/bigqueryanalyticshub"
     ),
     "bigqueryconnection" to mapOf(
         "name" to "bigqueryconnection",
-        "displayName" to "Bigqueryconnection",
+        "displayName" to "BigQuery Connection",
         "path" to "./google/services/bigqueryconnection"
     ),
     "bigquerydatapolicy" to mapOf(
         "name" to "bigquerydatapolicy",
-        "displayName" to "Bigquerydatapolicy",
+        "displayName" to "BigQuery Data Policy",
         "path" to "./google/services/bigquerydatapolicy"
     ),
     "bigquerydatapolicyv2" to mapOf(
         "name" to "bigquerydatapolicyv2",
-        "displayName" to "Bigquerydatapolicyv2",
+        "displayName" to "BigQuery Data Policy V2",
         "path" to "./google/services/bigquerydatapolicyv2"
     ),
     "bigquerydatatransfer" to mapOf(
         "name" to "bigquerydatatransfer",
-        "displayName" to "Bigquerydatatransfer",
+        "displayName" to "BigQuery Data Transfer",
         "path" to "./google/services/bigquerydatatransfer"
     ),
     "bigqueryreservation" to mapOf(
         "name" to "bigqueryreservation",
-        "displayName" to "Bigqueryreservation",
+        "displayName" to "BigQuery Reservation",
         "path" to "./google/services/bigqueryreservation"
     ),
     "bigtable" to mapOf(
         "name" to "bigtable",
-        "displayName" to "Bigtable",
+        "displayName" to "Cloud Bigtable",
         "path" to "./google/services/bigtable"
     ),
     "billing" to mapOf(
         "name" to "billing",
-        "displayName" to "Billing",
+        "displayName" to "Cloud Billing",
         "path" to "./google/services/billing"
     ),
     "binaryauthorization" to mapOf(
         "name" to "binaryauthorization",
-        "displayName" to "Binaryauthorization",
+        "displayName" to "Binary Authorization",
         "path" to "./google/services/binaryauthorization"
     ),
     "blockchainnodeengine" to mapOf(
         "name" to "blockchainnodeengine",
-        "displayName" to "Blockchainnodeengine",
+        "displayName" to "Blockchain Node Engine",
         "path" to "./google/services/blockchainnodeengine"
     ),
     "certificatemanager" to mapOf(
         "name" to "certificatemanager",
-        "displayName" to "Certificatemanager",
+        "displayName" to "Certificate Manager",
         "path" to "./google/services/certificatemanager"
     ),
     "ces" to mapOf(
         "name" to "ces",
-        "displayName" to "Customerengagementsuite",
+        "displayName" to "Customer Engagement Suite",
         "path" to "./google/services/ces"
     ),
     "chronicle" to mapOf(
@@ -168,92 +160,92 @@ var ServicesListGa = mapOf(
     ),
     "cloudasset" to mapOf(
         "name" to "cloudasset",
-        "displayName" to "Cloudasset",
+        "displayName" to "Cloud Asset Inventory",
         "path" to "./google/services/cloudasset"
     ),
     "cloudbuild" to mapOf(
         "name" to "cloudbuild",
-        "displayName" to "Cloudbuild",
+        "displayName" to "Cloud Build",
         "path" to "./google/services/cloudbuild"
     ),
     "cloudbuildv2" to mapOf(
         "name" to "cloudbuildv2",
-        "displayName" to "Cloudbuildv2",
+        "displayName" to "Cloud Build v2",
         "path" to "./google/services/cloudbuildv2"
     ),
     "clouddeploy" to mapOf(
         "name" to "clouddeploy",
-        "displayName" to "Clouddeploy",
+        "displayName" to "Cloud Deploy",
         "path" to "./google/services/clouddeploy"
     ),
     "clouddomains" to mapOf(
         "name" to "clouddomains",
-        "displayName" to "Clouddomains",
+        "displayName" to "Cloud Domains",
         "path" to "./google/services/clouddomains"
     ),
     "cloudfunctions" to mapOf(
         "name" to "cloudfunctions",
-        "displayName" to "Cloudfunctions",
+        "displayName" to "Cloud Functions",
         "path" to "./google/services/cloudfunctions"
     ),
     "cloudfunctions2" to mapOf(
         "name" to "cloudfunctions2",
-        "displayName" to "Cloudfunctions2",
+        "displayName" to "Cloud Functions (2nd gen)",
         "path" to "./google/services/cloudfunctions2"
     ),
     "cloudidentity" to mapOf(
         "name" to "cloudidentity",
-        "displayName" to "Cloudidentity",
+        "displayName" to "Cloud Identity",
         "path" to "./google/services/cloudidentity"
     ),
     "cloudids" to mapOf(
         "name" to "cloudids",
-        "displayName" to "Cloudids",
+        "displayName" to "Cloud Intrusion Detection Service",
         "path" to "./google/services/cloudids"
     ),
     "cloudquotas" to mapOf(
         "name" to "cloudquotas",
-        "displayName" to "Cloudquotas",
+        "displayName" to "Cloud Quotas",
         "path" to "./google/services/cloudquotas"
     ),
     "cloudrun" to mapOf(
         "name" to "cloudrun",
-        "displayName" to "Cloudrun",
+        "displayName" to "Cloud Run",
         "path" to "./google/services/cloudrun"
     ),
     "cloudrunv2" to mapOf(
         "name" to "cloudrunv2",
-        "displayName" to "Cloudrunv2",
+        "displayName" to "Cloud Run (v2 API)",
         "path" to "./google/services/cloudrunv2"
     ),
     "cloudscheduler" to mapOf(
         "name" to "cloudscheduler",
-        "displayName" to "Cloudscheduler",
+        "displayName" to "Cloud Scheduler",
         "path" to "./google/services/cloudscheduler"
     ),
-     "cloudsecuritycompliance" to mapOf(
+    "cloudsecuritycompliance" to mapOf(
         "name" to "cloudsecuritycompliance",
         "displayName" to "Cloud Security Compliance",
         "path" to "./google/services/cloudsecuritycompliance"
     ),
     "cloudtasks" to mapOf(
         "name" to "cloudtasks",
-        "displayName" to "Cloudtasks",
+        "displayName" to "Cloud Tasks",
         "path" to "./google/services/cloudtasks"
     ),
     "colab" to mapOf(
         "name" to "colab",
-        "displayName" to "Colab",
+        "displayName" to "Colab Enterprise",
         "path" to "./google/services/colab"
     ),
     "composer" to mapOf(
         "name" to "composer",
-        "displayName" to "Composer",
+        "displayName" to "Cloud Composer",
         "path" to "./google/services/composer"
     ),
     "compute" to mapOf(
         "name" to "compute",
-        "displayName" to "Compute",
+        "displayName" to "Compute Engine",
         "path" to "./google/services/compute"
     ),
     "contactcenterinsights" to mapOf(
@@ -268,12 +260,12 @@ var ServicesListGa = mapOf(
     ),
     "containeranalysis" to mapOf(
         "name" to "containeranalysis",
-        "displayName" to "Containeranalysis",
+        "displayName" to "Container Registry",
         "path" to "./google/services/containeranalysis"
     ),
     "containerattached" to mapOf(
         "name" to "containerattached",
-        "displayName" to "Containerattached",
+        "displayName" to "ContainerAttached",
         "path" to "./google/services/containerattached"
     ),
     "containeraws" to mapOf(
@@ -288,17 +280,17 @@ var ServicesListGa = mapOf(
     ),
     "corebilling" to mapOf(
         "name" to "corebilling",
-        "displayName" to "Corebilling",
+        "displayName" to "Cloud Billing",
         "path" to "./google/services/corebilling"
     ),
     "databasemigrationservice" to mapOf(
         "name" to "databasemigrationservice",
-        "displayName" to "Databasemigrationservice",
+        "displayName" to "DatabaseMigrationService",
         "path" to "./google/services/databasemigrationservice"
     ),
     "datacatalog" to mapOf(
         "name" to "datacatalog",
-        "displayName" to "Datacatalog",
+        "displayName" to "Data Catalog",
         "path" to "./google/services/datacatalog"
     ),
     "dataflow" to mapOf(
@@ -313,17 +305,17 @@ var ServicesListGa = mapOf(
     ),
     "datafusion" to mapOf(
         "name" to "datafusion",
-        "displayName" to "Datafusion",
+        "displayName" to "Cloud Data Fusion",
         "path" to "./google/services/datafusion"
     ),
     "datalossprevention" to mapOf(
         "name" to "datalossprevention",
-        "displayName" to "Datalossprevention",
+        "displayName" to "Data Loss Prevention",
         "path" to "./google/services/datalossprevention"
     ),
     "datapipeline" to mapOf(
         "name" to "datapipeline",
-        "displayName" to "Datapipeline",
+        "displayName" to "DataPipeline",
         "path" to "./google/services/datapipeline"
     ),
     "dataplex" to mapOf(
@@ -343,7 +335,7 @@ var ServicesListGa = mapOf(
     ),
     "dataprocmetastore" to mapOf(
         "name" to "dataprocmetastore",
-        "displayName" to "Dataprocmetastore",
+        "displayName" to "Dataproc Metastore",
         "path" to "./google/services/dataprocmetastore"
     ),
     "datastream" to mapOf(
@@ -353,12 +345,12 @@ var ServicesListGa = mapOf(
     ),
     "deploymentmanager" to mapOf(
         "name" to "deploymentmanager",
-        "displayName" to "Deploymentmanager",
+        "displayName" to "Cloud Deployment Manager",
         "path" to "./google/services/deploymentmanager"
     ),
     "developerconnect" to mapOf(
         "name" to "developerconnect",
-        "displayName" to "Developerconnect",
+        "displayName" to "Developer Connect",
         "path" to "./google/services/developerconnect"
     ),
     "dialogflow" to mapOf(
@@ -368,42 +360,42 @@ var ServicesListGa = mapOf(
     ),
     "dialogflowcx" to mapOf(
         "name" to "dialogflowcx",
-        "displayName" to "Dialogflowcx",
+        "displayName" to "Dialogflow CX",
         "path" to "./google/services/dialogflowcx"
     ),
     "discoveryengine" to mapOf(
         "name" to "discoveryengine",
-        "displayName" to "Discoveryengine",
+        "displayName" to "Discovery Engine",
         "path" to "./google/services/discoveryengine"
     ),
     "dns" to mapOf(
         "name" to "dns",
-        "displayName" to "Dns",
+        "displayName" to "Cloud DNS",
         "path" to "./google/services/dns"
     ),
     "documentai" to mapOf(
         "name" to "documentai",
-        "displayName" to "Documentai",
+        "displayName" to "Document AI",
         "path" to "./google/services/documentai"
     ),
     "documentaiwarehouse" to mapOf(
         "name" to "documentaiwarehouse",
-        "displayName" to "Documentaiwarehouse",
+        "displayName" to "Document AI Warehouse",
         "path" to "./google/services/documentaiwarehouse"
     ),
     "edgecontainer" to mapOf(
         "name" to "edgecontainer",
-        "displayName" to "Edgecontainer",
+        "displayName" to "Google Distributed Cloud Edge",
         "path" to "./google/services/edgecontainer"
     ),
     "edgenetwork" to mapOf(
         "name" to "edgenetwork",
-        "displayName" to "Edgenetwork",
+        "displayName" to "Distributed Cloud Edge Network",
         "path" to "./google/services/edgenetwork"
     ),
     "essentialcontacts" to mapOf(
         "name" to "essentialcontacts",
-        "displayName" to "Essentialcontacts",
+        "displayName" to "Essential Contacts",
         "path" to "./google/services/essentialcontacts"
     ),
     "eventarc" to mapOf(
@@ -428,7 +420,7 @@ var ServicesListGa = mapOf(
     ),
     "firebaseappcheck" to mapOf(
         "name" to "firebaseappcheck",
-        "displayName" to "Firebaseappcheck",
+        "displayName" to "Firebase App Check",
         "path" to "./google/services/firebaseappcheck"
     ),
     "firebaseapphosting" to mapOf(
@@ -438,22 +430,22 @@ var ServicesListGa = mapOf(
     ),
     "firebasedatabase" to mapOf(
         "name" to "firebasedatabase",
-        "displayName" to "Firebasedatabase",
+        "displayName" to "Firebase Realtime Database",
         "path" to "./google/services/firebasedatabase"
     ),
     "firebasedataconnect" to mapOf(
         "name" to "firebasedataconnect",
-        "displayName" to "Firebasedataconnect",
+        "displayName" to "Firebase Data Connect",
         "path" to "./google/services/firebasedataconnect"
     ),
     "firebaseextensions" to mapOf(
         "name" to "firebaseextensions",
-        "displayName" to "Firebaseextensions",
+        "displayName" to "Firebase Extensions",
         "path" to "./google/services/firebaseextensions"
     ),
     "firebasehosting" to mapOf(
         "name" to "firebasehosting",
-        "displayName" to "Firebasehosting",
+        "displayName" to "Firebase Hosting",
         "path" to "./google/services/firebasehosting"
     ),
     "firebaserules" to mapOf(
@@ -468,97 +460,97 @@ var ServicesListGa = mapOf(
     ),
     "gemini" to mapOf(
         "name" to "gemini",
-        "displayName" to "Gemini",
+        "displayName" to "Gemini for Google Cloud",
         "path" to "./google/services/gemini"
     ),
     "gkebackup" to mapOf(
         "name" to "gkebackup",
-        "displayName" to "Gkebackup",
+        "displayName" to "Backup for GKE",
         "path" to "./google/services/gkebackup"
     ),
     "gkehub" to mapOf(
         "name" to "gkehub",
-        "displayName" to "Gkehub",
+        "displayName" to "GKEHub",
         "path" to "./google/services/gkehub"
     ),
     "gkehub2" to mapOf(
         "name" to "gkehub2",
-        "displayName" to "Gkehub2",
+        "displayName" to "GKEHub",
         "path" to "./google/services/gkehub2"
     ),
     "gkeonprem" to mapOf(
         "name" to "gkeonprem",
-        "displayName" to "Gkeonprem",
+        "displayName" to "Anthos On-Prem",
         "path" to "./google/services/gkeonprem"
     ),
     "healthcare" to mapOf(
         "name" to "healthcare",
-        "displayName" to "Healthcare",
+        "displayName" to "Cloud Healthcare",
         "path" to "./google/services/healthcare"
     ),
     "iam2" to mapOf(
         "name" to "iam2",
-        "displayName" to "Iam2",
+        "displayName" to "Cloud IAM",
         "path" to "./google/services/iam2"
     ),
     "iam3" to mapOf(
         "name" to "iam3",
-        "displayName" to "Iam3",
+        "displayName" to "Cloud IAM",
         "path" to "./google/services/iam3"
     ),
     "iambeta" to mapOf(
         "name" to "iambeta",
-        "displayName" to "Iambeta",
+        "displayName" to "Cloud IAM",
         "path" to "./google/services/iambeta"
     ),
     "iamworkforcepool" to mapOf(
         "name" to "iamworkforcepool",
-        "displayName" to "Iamworkforcepool",
+        "displayName" to "Cloud IAM",
         "path" to "./google/services/iamworkforcepool"
     ),
     "iap" to mapOf(
         "name" to "iap",
-        "displayName" to "Iap",
+        "displayName" to "Identity-Aware Proxy",
         "path" to "./google/services/iap"
     ),
     "identityplatform" to mapOf(
         "name" to "identityplatform",
-        "displayName" to "Identityplatform",
+        "displayName" to "Identity Platform",
         "path" to "./google/services/identityplatform"
     ),
     "integrationconnectors" to mapOf(
         "name" to "integrationconnectors",
-        "displayName" to "Integrationconnectors",
+        "displayName" to "Integration Connectors",
         "path" to "./google/services/integrationconnectors"
     ),
     "integrations" to mapOf(
         "name" to "integrations",
-        "displayName" to "Applicationintegration",
+        "displayName" to "Application Integration",
         "path" to "./google/services/integrations"
     ),
     "kms" to mapOf(
         "name" to "kms",
-        "displayName" to "Kms",
+        "displayName" to "Cloud Key Management Service",
         "path" to "./google/services/kms"
     ),
     "logging" to mapOf(
         "name" to "logging",
-        "displayName" to "Logging",
+        "displayName" to "Cloud (Stackdriver) Logging",
         "path" to "./google/services/logging"
     ),
     "looker" to mapOf(
         "name" to "looker",
-        "displayName" to "Looker",
+        "displayName" to "Looker (Google Cloud core)",
         "path" to "./google/services/looker"
     ),
     "lustre" to mapOf(
         "name" to "lustre",
-        "displayName" to "GoogleCloudManagedLustre",
+        "displayName" to "Google Cloud Managed Lustre",
         "path" to "./google/services/lustre"
     ),
     "managedkafka" to mapOf(
         "name" to "managedkafka",
-        "displayName" to "Managedkafka",
+        "displayName" to "Managed Kafka",
         "path" to "./google/services/managedkafka"
     ),
     "memcache" to mapOf(
@@ -573,62 +565,62 @@ var ServicesListGa = mapOf(
     ),
     "migrationcenter" to mapOf(
         "name" to "migrationcenter",
-        "displayName" to "Migrationcenter",
+        "displayName" to "Migration Center",
         "path" to "./google/services/migrationcenter"
     ),
     "mlengine" to mapOf(
         "name" to "mlengine",
-        "displayName" to "Mlengine",
+        "displayName" to "ML Engine",
         "path" to "./google/services/mlengine"
     ),
     "modelarmor" to mapOf(
         "name" to "modelarmor",
-        "displayName" to "ModelArmor",
+        "displayName" to "Model Armor",
         "path" to "./google/services/modelarmor"
     ),
     "modelarmorglobal" to mapOf(
         "name" to "modelarmorglobal",
-        "displayName" to "ModelArmorGlobal",
+        "displayName" to "Model Armor",
         "path" to "./google/services/modelarmorglobal"
     ),
     "monitoring" to mapOf(
         "name" to "monitoring",
-        "displayName" to "Monitoring",
+        "displayName" to "Cloud (Stackdriver) Monitoring",
         "path" to "./google/services/monitoring"
     ),
     "netapp" to mapOf(
         "name" to "netapp",
-        "displayName" to "Netapp",
+        "displayName" to "Google Cloud NetApp Volumes",
         "path" to "./google/services/netapp"
     ),
     "networkconnectivity" to mapOf(
         "name" to "networkconnectivity",
-        "displayName" to "Networkconnectivity",
+        "displayName" to "Network Connectivity",
         "path" to "./google/services/networkconnectivity"
     ),
     "networkconnectivityv1" to mapOf(
         "name" to "networkconnectivityv1",
-        "displayName" to "Networkconnectivityv1",
+        "displayName" to "Network Connectivity",
         "path" to "./google/services/networkconnectivityv1"
     ),
     "networkmanagement" to mapOf(
         "name" to "networkmanagement",
-        "displayName" to "Networkmanagement",
+        "displayName" to "Network Management",
         "path" to "./google/services/networkmanagement"
     ),
     "networksecurity" to mapOf(
         "name" to "networksecurity",
-        "displayName" to "Networksecurity",
+        "displayName" to "Network Security",
         "path" to "./google/services/networksecurity"
     ),
     "networkservices" to mapOf(
         "name" to "networkservices",
-        "displayName" to "Networkservices",
+        "displayName" to "Network Services",
         "path" to "./google/services/networkservices"
     ),
     "notebooks" to mapOf(
         "name" to "notebooks",
-        "displayName" to "Notebooks",
+        "displayName" to "Cloud AI Notebooks",
         "path" to "./google/services/notebooks"
     ),
     "observability" to mapOf(
@@ -638,27 +630,27 @@ var ServicesListGa = mapOf(
     ),
     "oracledatabase" to mapOf(
         "name" to "oracledatabase",
-        "displayName" to "OracleDatabase",
+        "displayName" to "Oracle Database",
         "path" to "./google/services/oracledatabase"
     ),
     "orgpolicy" to mapOf(
         "name" to "orgpolicy",
-        "displayName" to "Orgpolicy",
+        "displayName" to "Organization Policy",
         "path" to "./google/services/orgpolicy"
     ),
     "osconfig" to mapOf(
         "name" to "osconfig",
-        "displayName" to "Osconfig",
+        "displayName" to "OS Config",
         "path" to "./google/services/osconfig"
     ),
     "osconfigv2" to mapOf(
         "name" to "osconfigv2",
-        "displayName" to "Osconfigv2",
+        "displayName" to "OS Config v2",
         "path" to "./google/services/osconfigv2"
     ),
     "oslogin" to mapOf(
         "name" to "oslogin",
-        "displayName" to "Oslogin",
+        "displayName" to "OS Login",
         "path" to "./google/services/oslogin"
     ),
     "parallelstore" to mapOf(
@@ -668,37 +660,37 @@ var ServicesListGa = mapOf(
     ),
     "parametermanager" to mapOf(
         "name" to "parametermanager",
-        "displayName" to "Parametermanager",
+        "displayName" to "Parameter Manager",
         "path" to "./google/services/parametermanager"
     ),
     "parametermanagerregional" to mapOf(
         "name" to "parametermanagerregional",
-        "displayName" to "Parametermanagerregional",
+        "displayName" to "Parameter Manager",
         "path" to "./google/services/parametermanagerregional"
     ),
     "privateca" to mapOf(
         "name" to "privateca",
-        "displayName" to "Privateca",
+        "displayName" to "Certificate Authority Service",
         "path" to "./google/services/privateca"
     ),
     "privilegedaccessmanager" to mapOf(
         "name" to "privilegedaccessmanager",
-        "displayName" to "Privilegedaccessmanager",
+        "displayName" to "Privileged Access Manager",
         "path" to "./google/services/privilegedaccessmanager"
     ),
     "publicca" to mapOf(
         "name" to "publicca",
-        "displayName" to "Publicca",
+        "displayName" to "Public CA",
         "path" to "./google/services/publicca"
     ),
     "pubsub" to mapOf(
         "name" to "pubsub",
-        "displayName" to "Pubsub",
+        "displayName" to "Cloud Pub/Sub",
         "path" to "./google/services/pubsub"
     ),
     "pubsublite" to mapOf(
         "name" to "pubsublite",
-        "displayName" to "Pubsublite",
+        "displayName" to "Cloud Pub/Sub",
         "path" to "./google/services/pubsublite"
     ),
     "recaptchaenterprise" to mapOf(
@@ -708,22 +700,22 @@ var ServicesListGa = mapOf(
     ),
     "redis" to mapOf(
         "name" to "redis",
-        "displayName" to "Redis",
+        "displayName" to "Memorystore (Redis)",
         "path" to "./google/services/redis"
     ),
     "resourcemanager" to mapOf(
         "name" to "resourcemanager",
-        "displayName" to "Resourcemanager",
+        "displayName" to "Resource Manager",
         "path" to "./google/services/resourcemanager"
     ),
     "resourcemanager3" to mapOf(
         "name" to "resourcemanager3",
-        "displayName" to "Resourcemanager3",
+        "displayName" to "Resource Manager",
         "path" to "./google/services/resourcemanager3"
     ),
     "runtimeconfig" to mapOf(
         "name" to "runtimeconfig",
-        "displayName" to "Runtimeconfig",
+        "displayName" to "Runtime Configurator",
         "path" to "./google/services/runtimeconfig"
     ),
     "saasruntime" to mapOf(
@@ -733,112 +725,107 @@ var ServicesListGa = mapOf(
     ),
     "secretmanager" to mapOf(
         "name" to "secretmanager",
-        "displayName" to "Secretmanager",
+        "displayName" to "Secret Manager",
         "path" to "./google/services/secretmanager"
     ),
     "secretmanagerregional" to mapOf(
         "name" to "secretmanagerregional",
-        "displayName" to "Secretmanagerregional",
+        "displayName" to "Secret Manager",
         "path" to "./google/services/secretmanagerregional"
     ),
     "securesourcemanager" to mapOf(
         "name" to "securesourcemanager",
-        "displayName" to "Securesourcemanager",
+        "displayName" to "Secure Source Manager",
         "path" to "./google/services/securesourcemanager"
     ),
     "securitycenter" to mapOf(
         "name" to "securitycenter",
-        "displayName" to "Securitycenter",
+        "displayName" to "Security Command Center (SCC)",
         "path" to "./google/services/securitycenter"
     ),
-    "securitycenterv2" to mapOf(
-        "name" to "securitycenterv2",
-        "displayName" to "securitycenterv2",
-        "path" to "./google/services/securitycenterv2"
-    ),
     "securitycentermanagement" to mapOf(
         "name" to "securitycentermanagement",
-        "displayName" to "Securitycentermanagement",
+        "displayName" to "Security Command Center Management (SCC)",
         "path" to "./google/services/securitycentermanagement"
     ),
     "securitycenterv2" to mapOf(
         "name" to "securitycenterv2",
-        "displayName" to "securitycenterv2",
+        "displayName" to "Security Command Center (SCC) v2 API",
         "path" to "./google/services/securitycenterv2"
     ),
     "securityposture" to mapOf(
         "name" to "securityposture",
-        "displayName" to "Securityposture",
+        "displayName" to "Security Posture",
         "path" to "./google/services/securityposture"
     ),
     "securityscanner" to mapOf(
         "name" to "securityscanner",
-        "displayName" to "Securityscanner",
+        "displayName" to "Cloud Security Scanner",
         "path" to "./google/services/securityscanner"
     ),
     "servicedirectory" to mapOf(
         "name" to "servicedirectory",
-        "displayName" to "Servicedirectory",
+        "displayName" to "Service Directory",
         "path" to "./google/services/servicedirectory"
     ),
     "servicemanagement" to mapOf(
         "name" to "servicemanagement",
-        "displayName" to "Servicemanagement",
+        "displayName" to "Cloud Endpoints",
         "path" to "./google/services/servicemanagement"
     ),
     "servicenetworking" to mapOf(
         "name" to "servicenetworking",
-        "displayName" to "Servicenetworking",
+        "displayName" to "Service Networking",
         "path" to "./google/services/servicenetworking"
     ),
     "serviceusage" to mapOf(
         "name" to "serviceusage",
-        "displayName" to "Serviceusage",
+        "displayName" to "Service Usage",
         "path" to "./google/services/serviceusage"
     ),
     "siteverification" to mapOf(
         "name" to "siteverification",
-        "displayName" to "Siteverification",
+        "displayName" to "Site Verification",
         "path" to "./google/services/siteverification"
     ),
     "sourcerepo" to mapOf(
         "name" to "sourcerepo",
-        "displayName" to "Sourcerepo",
+        "displayName" to "Cloud Source Repositories",
         "path" to "./google/services/sourcerepo"
     ),
     "spanner" to mapOf(
         "name" to "spanner",
-        "displayName" to "Spanner",
+        "displayName" to "Cloud Spanner",
         "path" to "./google/services/spanner"
     ),
     "sql" to mapOf(
         "name" to "sql",
-        "displayName" to "Sql",
+        "displayName" to "Cloud SQL",
         "path" to "./google/services/sql"
     ),
     "storage" to mapOf(
         "name" to "storage",
-        "displayName" to "Storage",
+        "displayName" to "Cloud Storage",
         "path" to "./google/services/storage"
     ),
     "storagebatchoperations" to mapOf(
         "name" to "storagebatchoperations",
-        "displayName" to "StorageBatchOperations",
+        "displayName" to "Cloud Storage Batch Operations",
         "path" to "./google/services/storagebatchoperations"
     ),
     "storagecontrol" to mapOf(
         "name" to "storagecontrol",
-        "displayName" to "Storagecontrol",
+        "displayName" to "Cloud Storage Control",
         "path" to "./google/services/storagecontrol"
     ),
     "storageinsights" to mapOf(
         "name" to "storageinsights",
-        "displayName" to "Storageinsights",
+        "displayName" to "Cloud Storage Insights",
         "path" to "./google/services/storageinsights"
     ),
     "storagetransfer" to mapOf(
         "name" to "storagetransfer",
-        "displayName" to "Storagetransfer",
+        "displayName" to "Storage Transfer Service",
         "path" to "./google/services/storagetransfer"
     ),
     "tags" to mapOf(
@@ -848,7 +835,7 @@ var ServicesListGa = mapOf(
     ),
     "tpuv2" to mapOf(
         "name" to "tpuv2",
-        "displayName" to "Tpuv2",
+        "displayName" to "Cloud TPU v2",
         "path" to "./google/services/tpuv2"
     ),
     "transcoder" to mapOf(
@@ -858,22 +845,22 @@ var ServicesListGa = mapOf(
     ),
     "vertexai" to mapOf(
         "name" to "vertexai",
-        "displayName" to "Vertexai",
+        "displayName" to "Vertex AI",
         "path" to "./google/services/vertexai"
     ),
     "vmwareengine" to mapOf(
         "name" to "vmwareengine",
-        "displayName" to "Vmwareengine",
+        "displayName" to "Cloud VMware Engine",
         "path" to "./google/services/vmwareengine"
     ),
     "vpcaccess" to mapOf(
         "name" to "vpcaccess",
-        "displayName" to "Vpcaccess",
+        "displayName" to "Serverless VPC Access",
         "path" to "./google/services/vpcaccess"
     ),
     "workbench" to mapOf(
         "name" to "workbench",
-        "displayName" to "Workbench",
+        "displayName" to "Vertex AI Workbench",
         "path" to "./google/services/workbench"
     ),
     "workflows" to mapOf(
@@ -883,7 +870,7 @@ var ServicesListGa = mapOf(
     ),
     "workstations" to mapOf(
         "name" to "workstations",
-        "displayName" to "Workstations",
+        "displayName" to "Cloud Workstations",
         "path" to "./google/services/workstations"
     )
 )
@@ -0,0 +1,186 @@
+package api_coverage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// DiscoveryDoc is the subset of a Google API discovery document used to compute coverage.
+// See https://developers.google.com/discovery/v1/reference/apis.
+type DiscoveryDoc struct {
+	Name    string                     `json:"name"`
+	Version string                     `json:"version"`
+	Schemas map[string]DiscoverySchema `json:"schemas"`
+}
+
+// DiscoverySchema is a schema or property in a discovery document.
+type DiscoverySchema struct {
+	ID                   string                     `json:"id"`
+	Type                 string                     `json:"type"`
+	Ref                  string                     `json:"$ref"`
+	Properties           map[string]DiscoverySchema `json:"properties"`
+	Items                *DiscoverySchema           `json:"items"`
+	AdditionalProperties *DiscoverySchema           `json:"additionalProperties"`
+}
+
+// ProductCoverage lists the coverage of the provider resources matched to a discovery document.
+type ProductCoverage struct {
+	Product   string             `json:"product"`
+	Version   string             `json:"version"`
+	Resources []ResourceCoverage `json:"resources"`
+}
+
+// ResourceCoverage lists the fields of an API schema that a provider resource doesn't expose.
+// Fields are in snake_case, with paths like `parent.child`. Fields under a missing field aren't
+// listed separately.
+type ResourceCoverage struct {
+	Resource      string   `json:"resource"`
+	Schema        string   `json:"schema"`
+	APIFields     int      `json:"api_fields"`
+	MissingFields []string `json:"missing_fields"`
+}
+
+// ignoredProperties are API properties that providers never expose as fields.
+var ignoredProperties = map[string]bool{
+	"kind": true,
+}
+
+// ReadDiscoveryDocs reads the discovery documents (*.json) in dir.
+func ReadDiscoveryDocs(dir string) ([]DiscoveryDoc, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	var docs []DiscoveryDoc
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("error reading discovery document %s: %w", path, err)
+		}
+		var doc DiscoveryDoc
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("error parsing discovery document %s: %w", path, err)
+		}
+		docs = append(docs, doc)
+	}
+	return docs, nil
+}
+
+// ComputeCoverage compares provider resources against the discovery documents and returns the
+// coverage of each product that has a matching resource, sorted by product. A resource matches
+// a schema if its name is google_<product>_<schema> with the schema in snake_case, for example
+// google_compute_instance and the compute API's Instance schema.
+func ComputeCoverage(resources map[string]*schema.Resource, docs []DiscoveryDoc) []ProductCoverage {
+	var products []ProductCoverage
+	for _, doc := range docs {
+		schemaNames := make(map[string]string)
+		for name := range doc.Schemas {
+			schemaNames[toSnakeCase(name)] = name
+		}
+		product := ProductCoverage{Product: doc.Name, Version: doc.Version}
+		for resourceName, resource := range resources {
+			suffix, ok := strings.CutPrefix(resourceName, "google_"+doc.Name+"_")
+			if !ok {
+				continue
+			}
+			schemaName, ok := schemaNames[suffix]
+			if !ok {
+				continue
+			}
+			coverage := ResourceCoverage{Resource: resourceName, Schema: schemaName, MissingFields: []string{}}
+			compareProperties(&coverage, "", doc.Schemas[schemaName].Properties, resource.Schema, doc.Schemas, map[string]bool{schemaName: true})
+			sort.Strings(coverage.MissingFields)
+			product.Resources = append(product.Resources, coverage)
+		}
+		if len(product.Resources) == 0 {
+			continue
+		}
+		sort.Slice(product.Resources, func(i, j int) bool {
+			return product.Resources[i].Resource < product.Resources[j].Resource
+		})
+		products = append(products, product)
+	}
+	sort.Slice(products, func(i, j int) bool {
+		if products[i].Product != products[j].Product {
+			return products[i].Product < products[j].Product
+		}
+		return products[i].Version < products[j].Version
+	})
+	return products
+}
+
+// compareProperties records the API properties missing from fields, recursing into nested
+// objects that the provider exposes as blocks. visiting holds the schemas being compared, so
+// recursive schemas are only followed once.
+func compareProperties(coverage *ResourceCoverage, prefix string, properties map[string]DiscoverySchema, fields map[string]*schema.Schema, schemas map[string]DiscoverySchema, visiting map[string]bool) {
+	for name, property := range properties {
+		if ignoredProperties[name] {
+			continue
+		}
+		fieldName := toSnakeCase(name)
+		coverage.APIFields++
+		field, ok := fields[fieldName]
+		if !ok && property.Type == "array" {
+			// Repeated blocks are usually singular in the provider, like network_interface.
+			if singular := strings.TrimSuffix(fieldName, "s"); fields[singular] != nil {
+				fieldName, field, ok = singular, fields[singular], true
+			}
+		}
+		if !ok {
+			coverage.MissingFields = append(coverage.MissingFields, prefix+fieldName)
+			continue
+		}
+		block, ok := field.Elem.(*schema.Resource)
+		if !ok {
+			continue
+		}
+		nested, ref := objectProperties(property, schemas)
+		if ref != "" {
+			if visiting[ref] {
+				continue
+			}
+			visiting[ref] = true
+		}
+		compareProperties(coverage, prefix+fieldName+".", nested, block.Schema, schemas, visiting)
+		if ref != "" {
+			delete(visiting, ref)
+		}
+	}
+}
+
+// objectProperties returns the properties of an object property, or of the items of an array
+// property, along with the name of the schema they came from if it was a reference.
+func objectProperties(property DiscoverySchema, schemas map[string]DiscoverySchema) (map[string]DiscoverySchema, string) {
+	if property.Items != nil {
+		property = *property.Items
+	}
+	if property.Ref != "" {
+		return schemas[property.Ref].Properties, property.Ref
+	}
+	return property.Properties, ""
+}
+
+// toSnakeCase converts an API name like networkInterfaces or IPv4Range to snake_case.
+func toSnakeCase(name string) string {
+	// IPv4 and IPv6 are written as ipv4 and ipv6 rather than i_pv4 and i_pv6.
+	runes := []rune(strings.ReplaceAll(name, "IPv", "Ipv"))
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 {
+			prev := runes[i-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextIsLower) {
+				b.WriteRune('_')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
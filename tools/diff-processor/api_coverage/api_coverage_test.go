@@ -0,0 +1,142 @@
+package api_coverage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const computeDiscoveryDoc = `{
+  "name": "compute",
+  "version": "v1",
+  "schemas": {
+    "Instance": {
+      "id": "Instance",
+      "type": "object",
+      "properties": {
+        "kind": {"type": "string"},
+        "name": {"type": "string"},
+        "machineType": {"type": "string"},
+        "labels": {"type": "object", "additionalProperties": {"type": "string"}},
+        "networkInterfaces": {"type": "array", "items": {"$ref": "NetworkInterface"}},
+        "shieldedInstanceConfig": {"$ref": "ShieldedInstanceConfig"},
+        "reservationAffinity": {"$ref": "ReservationAffinity"}
+      }
+    },
+    "NetworkInterface": {
+      "id": "NetworkInterface",
+      "type": "object",
+      "properties": {
+        "network": {"type": "string"},
+        "ipv6Address": {"type": "string"},
+        "aliasIpRanges": {"type": "array", "items": {"type": "object", "properties": {"ipCidrRange": {"type": "string"}, "subnetworkRangeName": {"type": "string"}}}}
+      }
+    },
+    "ShieldedInstanceConfig": {
+      "id": "ShieldedInstanceConfig",
+      "type": "object",
+      "properties": {"enableSecureBoot": {"type": "boolean"}}
+    },
+    "ReservationAffinity": {
+      "id": "ReservationAffinity",
+      "type": "object",
+      "properties": {"key": {"type": "string"}}
+    },
+    "Disk": {
+      "id": "Disk",
+      "type": "object",
+      "properties": {"name": {"type": "string"}, "sizeGb": {"type": "string"}}
+    },
+    "Folder": {
+      "id": "Folder",
+      "type": "object",
+      "properties": {"name": {"type": "string"}, "parent": {"$ref": "Folder"}}
+    }
+  }
+}`
+
+func TestComputeCoverage(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "compute.v1.json"), []byte(computeDiscoveryDoc), 0644); err != nil {
+		t.Fatal(err)
+	}
+	docs, err := ReadDiscoveryDocs(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resources := map[string]*schema.Resource{
+		"google_compute_instance": {Schema: map[string]*schema.Schema{
+			"name":         {Type: schema.TypeString, Required: true},
+			"machine_type": {Type: schema.TypeString, Required: true},
+			"network_interface": {Type: schema.TypeList, Required: true, Elem: &schema.Resource{Schema: map[string]*schema.Schema{
+				"network": {Type: schema.TypeString, Optional: true},
+				"alias_ip_range": {Type: schema.TypeList, Optional: true, Elem: &schema.Resource{Schema: map[string]*schema.Schema{
+					"ip_cidr_range": {Type: schema.TypeString, Required: true},
+				}}},
+			}}},
+			"shielded_instance_config": {Type: schema.TypeList, Optional: true, Elem: &schema.Resource{Schema: map[string]*schema.Schema{
+				"enable_secure_boot": {Type: schema.TypeBool, Optional: true},
+			}}},
+		}},
+		"google_compute_disk": {Schema: map[string]*schema.Schema{
+			"name":    {Type: schema.TypeString, Required: true},
+			"size_gb": {Type: schema.TypeInt, Optional: true},
+		}},
+		"google_compute_folder": {Schema: map[string]*schema.Schema{
+			"name":   {Type: schema.TypeString, Required: true},
+			"parent": {Type: schema.TypeList, Optional: true, Elem: &schema.Resource{Schema: map[string]*schema.Schema{}}},
+		}},
+		"google_compute_network": {Schema: map[string]*schema.Schema{
+			"name": {Type: schema.TypeString, Required: true},
+		}},
+		"google_storage_bucket": {Schema: map[string]*schema.Schema{
+			"name": {Type: schema.TypeString, Required: true},
+		}},
+	}
+
+	want := []ProductCoverage{
+		{
+			Product: "compute",
+			Version: "v1",
+			Resources: []ResourceCoverage{
+				{Resource: "google_compute_disk", Schema: "Disk", APIFields: 2, MissingFields: []string{}},
+				// Recursive schemas aren't followed.
+				{Resource: "google_compute_folder", Schema: "Folder", APIFields: 2, MissingFields: []string{}},
+				{
+					Resource:  "google_compute_instance",
+					Schema:    "Instance",
+					APIFields: 12,
+					MissingFields: []string{
+						"labels",
+						"network_interface.alias_ip_range.subnetwork_range_name",
+						"network_interface.ipv6_address",
+						"reservation_affinity",
+					},
+				},
+			},
+		},
+	}
+	if d := cmp.Diff(want, ComputeCoverage(resources, docs)); d != "" {
+		t.Errorf("ComputeCoverage() diff (-want, +got):\n%s", d)
+	}
+}
+
+func TestToSnakeCase(t *testing.T) {
+	for name, want := range map[string]string{
+		"name":              "name",
+		"networkInterfaces": "network_interfaces",
+		"sizeGb":            "size_gb",
+		"IPv4Range":         "ipv4_range",
+		"ipv6Address":       "ipv6_address",
+		"HTTPHealthCheck":   "http_health_check",
+		"TargetHttpProxy":   "target_http_proxy",
+	} {
+		if got := toSnakeCase(name); got != want {
+			t.Errorf("toSnakeCase(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
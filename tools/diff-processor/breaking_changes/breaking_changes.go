@@ -1,8 +1,6 @@
 package breaking_changes
 
 import (
-	"fmt"
-
 	"github.com/GoogleCloudPlatform/magic-modules/tools/diff-processor/diff"
 )
 
@@ -12,14 +10,34 @@ type BreakingChange struct {
 	Message                string
 	DocumentationReference string
 	RuleName               string
+	Severity               Severity
 }
 
+// Severity is how disruptive a breaking change is likely to be for existing users.
+type Severity string
+
+const (
+	// SeverityHigh changes make existing configurations or state invalid.
+	SeverityHigh Severity = "high"
+	// SeverityMedium changes keep existing configurations valid, but can break expressions
+	// that reference the field, such as indexing into a list that became a set.
+	SeverityMedium Severity = "medium"
+	// SeverityLow changes accept every previously valid value, but may still cause diffs.
+	SeverityLow Severity = "low"
+)
+
 const breakingChangesPath = "breaking-changes/breaking-changes"
 
-func NewBreakingChange(message, identifier string) BreakingChange {
+// NewBreakingChange builds a high severity BreakingChange for the given rule. field is
+// the field path the rule fired on, or empty for resource-level rules.
+func NewBreakingChange(resource, field, message, identifier string) BreakingChange {
 	return BreakingChange{
+		Resource:               resource,
+		Field:                  field,
 		Message:                message,
-		DocumentationReference: fmt.Sprintf("https://googlecloudplatform.github.io/magic-modules/%s#%s", breakingChangesPath, identifier),
+		RuleName:               identifier,
+		Severity:               SeverityHigh,
+		DocumentationReference: ruleDocumentationReference(identifier),
 	}
 }
 
@@ -28,7 +46,9 @@ func ComputeBreakingChanges(schemaDiff diff.SchemaDiff) []BreakingChange {
 	for resource, resourceDiff := range schemaDiff {
 		for _, rule := range ResourceConfigDiffRules {
 			for _, message := range rule.Messages(resource, resourceDiff.ResourceConfig) {
-				breakingChanges = append(breakingChanges, NewBreakingChange(message, rule.Identifier))
+				breakingChange := NewBreakingChange(resource, "", message, rule.Identifier)
+				breakingChange.Severity = rule.Severity
+				breakingChanges = append(breakingChanges, breakingChange)
 			}
 		}
 
@@ -39,7 +59,9 @@ func ComputeBreakingChanges(schemaDiff diff.SchemaDiff) []BreakingChange {
 
 		for _, rule := range ResourceDiffRules {
 			for _, message := range rule.Messages(resource, resourceDiff) {
-				breakingChanges = append(breakingChanges, NewBreakingChange(message, rule.Identifier))
+				breakingChange := NewBreakingChange(resource, "", message, rule.Identifier)
+				breakingChange.Severity = rule.Severity
+				breakingChanges = append(breakingChanges, breakingChange)
 			}
 		}
 
@@ -47,7 +69,12 @@ func ComputeBreakingChanges(schemaDiff diff.SchemaDiff) []BreakingChange {
 			for _, rule := range FieldDiffRules {
 				rd := schemaDiff[resource]
 				for _, message := range rule.Messages(resource, field, fieldDiff, rd) {
-					breakingChanges = append(breakingChanges, NewBreakingChange(message, rule.Identifier))
+					breakingChange := NewBreakingChange(resource, field, message, rule.Identifier)
+					breakingChange.Severity = rule.Severity
+					if rule.ClassifySeverity != nil {
+						breakingChange.Severity = rule.ClassifySeverity(fieldDiff)
+					}
+					breakingChanges = append(breakingChanges, breakingChange)
 				}
 			}
 		}
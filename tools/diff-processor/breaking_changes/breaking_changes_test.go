@@ -93,8 +93,11 @@ func TestComputeBreakingChanges(t *testing.T) {
 			newResourceMap: map[string]*schema.Resource{},
 			wantViolations: []BreakingChange{
 				{
+					Resource:               "google-x",
 					Message:                "Resource `google-x` was either removed or renamed",
 					DocumentationReference: "https://googlecloudplatform.github.io/magic-modules/breaking-changes/breaking-changes#resource-map-resource-removal-or-rename",
+					RuleName:               "resource-map-resource-removal-or-rename",
+					Severity:               SeverityHigh,
 				},
 			},
 		},
@@ -117,8 +120,11 @@ func TestComputeBreakingChanges(t *testing.T) {
 			},
 			wantViolations: []BreakingChange{
 				{
+					Resource:               "google-x",
 					Message:                "Field `field-b` within resource `google-x` was either removed or renamed",
 					DocumentationReference: "https://googlecloudplatform.github.io/magic-modules/breaking-changes/breaking-changes#resource-schema-field-removal-or-rename",
+					RuleName:               "resource-schema-field-removal-or-rename",
+					Severity:               SeverityHigh,
 				},
 			},
 		},
@@ -142,8 +148,12 @@ func TestComputeBreakingChanges(t *testing.T) {
 			},
 			wantViolations: []BreakingChange{
 				{
+					Resource:               "google-x",
+					Field:                  "field-a",
 					Message:                "Field `field-a` changed from optional to required on `google-x`",
 					DocumentationReference: "https://googlecloudplatform.github.io/magic-modules/breaking-changes/breaking-changes#field-optional-to-required",
+					RuleName:               "field-optional-to-required",
+					Severity:               SeverityHigh,
 				},
 			},
 		},
@@ -166,12 +176,19 @@ func TestComputeBreakingChanges(t *testing.T) {
 			},
 			wantViolations: []BreakingChange{
 				{
+					Resource:               "google-x",
+					Field:                  "field-a",
 					Message:                "Field `field-a` changed from optional to required on `google-x`",
 					DocumentationReference: "https://googlecloudplatform.github.io/magic-modules/breaking-changes/breaking-changes#field-optional-to-required",
+					RuleName:               "field-optional-to-required",
+					Severity:               SeverityHigh,
 				},
 				{
+					Resource:               "google-x",
 					Message:                "Field `field-b` within resource `google-x` was either removed or renamed",
 					DocumentationReference: "https://googlecloudplatform.github.io/magic-modules/breaking-changes/breaking-changes#resource-schema-field-removal-or-rename",
+					RuleName:               "resource-schema-field-removal-or-rename",
+					Severity:               SeverityHigh,
 				},
 			},
 		},
@@ -199,16 +216,26 @@ func TestComputeBreakingChanges(t *testing.T) {
 			},
 			wantViolations: []BreakingChange{
 				{
+					Resource:               "google-x",
+					Field:                  "field-a",
 					Message:                "Field `field-a` changed from optional to required on `google-x`",
 					DocumentationReference: "https://googlecloudplatform.github.io/magic-modules/breaking-changes/breaking-changes#field-optional-to-required",
+					RuleName:               "field-optional-to-required",
+					Severity:               SeverityHigh,
 				},
 				{
+					Resource:               "google-x",
 					Message:                "Field `field-b` within resource `google-x` was either removed or renamed",
 					DocumentationReference: "https://googlecloudplatform.github.io/magic-modules/breaking-changes/breaking-changes#resource-schema-field-removal-or-rename",
+					RuleName:               "resource-schema-field-removal-or-rename",
+					Severity:               SeverityHigh,
 				},
 				{
+					Resource:               "google-y",
 					Message:                "Resource `google-y` was either removed or renamed",
 					DocumentationReference: "https://googlecloudplatform.github.io/magic-modules/breaking-changes/breaking-changes#resource-map-resource-removal-or-rename",
+					RuleName:               "resource-map-resource-removal-or-rename",
+					Severity:               SeverityHigh,
 				},
 			},
 		},
@@ -253,8 +280,11 @@ func TestComputeBreakingChanges(t *testing.T) {
 			},
 			wantViolations: []BreakingChange{
 				{
+					Resource:               "google-x",
 					Message:                "Field `field-a.sub-field-2` within resource `google-x` was either removed or renamed",
 					DocumentationReference: "https://googlecloudplatform.github.io/magic-modules/breaking-changes/breaking-changes#resource-schema-field-removal-or-rename",
+					RuleName:               "resource-schema-field-removal-or-rename",
+					Severity:               SeverityHigh,
 				},
 			},
 		},
@@ -298,8 +328,12 @@ func TestComputeBreakingChanges(t *testing.T) {
 			},
 			wantViolations: []BreakingChange{
 				{
+					Resource:               "google-x",
+					Field:                  "field-a.sub-field-1",
 					Message:                "Field `field-a.sub-field-1` MaxItems went from 100 to 25 on `google-x`",
 					DocumentationReference: "https://googlecloudplatform.github.io/magic-modules/breaking-changes/breaking-changes#field-shrinking-max",
+					RuleName:               "field-shrinking-max",
+					Severity:               SeverityHigh,
 				},
 			},
 		},
@@ -343,8 +377,12 @@ func TestComputeBreakingChanges(t *testing.T) {
 			},
 			wantViolations: []BreakingChange{
 				{
+					Resource:               "google-x",
+					Field:                  "field-a.sub-field-1",
 					Message:                "Field `field-a.sub-field-1` MaxItems went from 100 to 25 on `google-x`",
 					DocumentationReference: "https://googlecloudplatform.github.io/magic-modules/breaking-changes/breaking-changes#field-shrinking-max",
+					RuleName:               "field-shrinking-max",
+					Severity:               SeverityHigh,
 				},
 			},
 		},
@@ -374,8 +412,39 @@ func TestComputeBreakingChanges(t *testing.T) {
 			},
 			wantViolations: []BreakingChange{
 				{
+					Resource:               "google-x",
+					Field:                  "field-a",
 					Message:                "Field `field-a` MinItems went from 1 to 4 on `google-x`",
 					DocumentationReference: "https://googlecloudplatform.github.io/magic-modules/breaking-changes/breaking-changes#field-growing-min",
+					RuleName:               "field-growing-min",
+					Severity:               SeverityHigh,
+				},
+			},
+		},
+		{
+			name: "set to list",
+			oldResourceMap: map[string]*schema.Resource{
+				"google-x": {
+					Schema: map[string]*schema.Schema{
+						"field-a": {Type: schema.TypeSet, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}},
+					},
+				},
+			},
+			newResourceMap: map[string]*schema.Resource{
+				"google-x": {
+					Schema: map[string]*schema.Schema{
+						"field-a": {Type: schema.TypeList, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}},
+					},
+				},
+			},
+			wantViolations: []BreakingChange{
+				{
+					Resource:               "google-x",
+					Field:                  "field-a",
+					Message:                "Field `field-a` changed from TypeSet to TypeList on `google-x`",
+					DocumentationReference: "https://googlecloudplatform.github.io/magic-modules/breaking-changes/breaking-changes#field-changing-type",
+					RuleName:               "field-changing-type",
+					Severity:               SeverityMedium,
 				},
 			},
 		},
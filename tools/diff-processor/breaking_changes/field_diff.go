@@ -12,8 +12,15 @@ import (
 // FieldDiffRule provides structure for rules
 // regarding field attribute changes
 type FieldDiffRule struct {
-	Identifier string
-	Messages   func(resource, field string, fieldDiff diff.FieldDiff, resourceDiff diff.ResourceDiffInterface) []string
+	Identifier  string
+	Description string
+	Messages    func(resource, field string, fieldDiff diff.FieldDiff, resourceDiff diff.ResourceDiffInterface) []string
+	// Severity is the severity of the rule's breaking changes, or the highest one for rules
+	// with ClassifySeverity.
+	Severity Severity
+	// ClassifySeverity classifies each of the rule's breaking changes. Rules without it report
+	// Severity for every change.
+	ClassifySeverity func(fieldDiff diff.FieldDiff) Severity
 }
 
 // FieldDiffRules is a list of FieldDiffRule
@@ -32,8 +39,11 @@ var FieldDiffRules = []FieldDiffRule{
 }
 
 var FieldChangingType = FieldDiffRule{
-	Identifier: "field-changing-type",
-	Messages:   FieldChangingTypeMessages,
+	Identifier:       "field-changing-type",
+	Description:      "Changing a field's type or element type",
+	Messages:         FieldChangingTypeMessages,
+	Severity:         SeverityHigh,
+	ClassifySeverity: FieldChangingTypeSeverity,
 }
 
 func FieldChangingTypeMessages(resource, field string, fieldDiff diff.FieldDiff, _ diff.ResourceDiffInterface) []string {
@@ -48,20 +58,56 @@ func FieldChangingTypeMessages(resource, field string, fieldDiff diff.FieldDiff,
 		return []string{fmt.Sprintf(tmpl, field, oldType, newType, resource)}
 	}
 
-	oldCasted, _ := fieldDiff.Old.Elem.(*schema.Schema)
-	newCasted, _ := fieldDiff.New.Elem.(*schema.Schema)
-	if oldCasted != nil && newCasted != nil && oldCasted.Type != newCasted.Type {
-		oldType := getValueType(fieldDiff.Old.Type) + "." + getValueType(oldCasted.Type)
-		newType := getValueType(fieldDiff.New.Type) + "." + getValueType(newCasted.Type)
+	oldElem, newElem := getElemType(fieldDiff.Old.Elem), getElemType(fieldDiff.New.Elem)
+	if oldElem != "" && newElem != "" && oldElem != newElem {
+		oldType := getValueType(fieldDiff.Old.Type) + "." + oldElem
+		newType := getValueType(fieldDiff.New.Type) + "." + newElem
 		return []string{fmt.Sprintf(tmpl, field, oldType, newType, resource)}
 	}
 
 	return nil
 }
 
+// FieldChangingTypeSeverity classifies a field type change. Switching between lists and
+// sets of the same elements keeps configurations valid and widening integers to floats
+// accepts every existing value. Any other change, including between nested blocks and
+// primitive elements, invalidates existing configurations.
+func FieldChangingTypeSeverity(fieldDiff diff.FieldDiff) Severity {
+	if fieldDiff.Old == nil || fieldDiff.New == nil {
+		return SeverityHigh
+	}
+	oldType, newType := fieldDiff.Old.Type, fieldDiff.New.Type
+	if oldType != newType {
+		if isCollectionType(oldType) && isCollectionType(newType) && getElemType(fieldDiff.Old.Elem) == getElemType(fieldDiff.New.Elem) {
+			return SeverityMedium
+		}
+		return widenedSeverity(oldType, newType)
+	}
+	oldElem, oldOk := fieldDiff.Old.Elem.(*schema.Schema)
+	newElem, newOk := fieldDiff.New.Elem.(*schema.Schema)
+	if !oldOk || !newOk {
+		return SeverityHigh
+	}
+	return widenedSeverity(oldElem.Type, newElem.Type)
+}
+
+// widenedSeverity is low for integers becoming floats and high for other type changes.
+func widenedSeverity(oldType, newType schema.ValueType) Severity {
+	if oldType == schema.TypeInt && newType == schema.TypeFloat {
+		return SeverityLow
+	}
+	return SeverityHigh
+}
+
+func isCollectionType(valueType schema.ValueType) bool {
+	return valueType == schema.TypeList || valueType == schema.TypeSet
+}
+
 var FieldBecomingRequired = FieldDiffRule{
-	Identifier: "field-optional-to-required",
-	Messages:   FieldBecomingRequiredMessages,
+	Identifier:  "field-optional-to-required",
+	Description: "Making an optional field required",
+	Messages:    FieldBecomingRequiredMessages,
+	Severity:    SeverityHigh,
 }
 
 func FieldBecomingRequiredMessages(resource, field string, fieldDiff diff.FieldDiff, _ diff.ResourceDiffInterface) []string {
@@ -78,8 +124,10 @@ func FieldBecomingRequiredMessages(resource, field string, fieldDiff diff.FieldD
 }
 
 var FieldBecomingComputedOnly = FieldDiffRule{
-	Identifier: "field-becoming-computed",
-	Messages:   FieldBecomingComputedOnlyMessages,
+	Identifier:  "field-becoming-computed",
+	Description: "Making a settable field read-only",
+	Messages:    FieldBecomingComputedOnlyMessages,
+	Severity:    SeverityHigh,
 }
 
 func FieldBecomingComputedOnlyMessages(resource, field string, fieldDiff diff.FieldDiff, _ diff.ResourceDiffInterface) []string {
@@ -101,8 +149,10 @@ func FieldBecomingComputedOnlyMessages(resource, field string, fieldDiff diff.Fi
 }
 
 var FieldOptionalComputedToOptional = FieldDiffRule{
-	Identifier: "field-oc-to-c",
-	Messages:   FieldOptionalComputedToOptionalMessages,
+	Identifier:  "field-oc-to-c",
+	Description: "Removing support for API-side defaults",
+	Messages:    FieldOptionalComputedToOptionalMessages,
+	Severity:    SeverityLow,
 }
 
 func FieldOptionalComputedToOptionalMessages(resource, field string, fieldDiff diff.FieldDiff, _ diff.ResourceDiffInterface) []string {
@@ -118,8 +168,10 @@ func FieldOptionalComputedToOptionalMessages(resource, field string, fieldDiff d
 }
 
 var FieldDefaultModification = FieldDiffRule{
-	Identifier: "field-changing-default-value",
-	Messages:   FieldDefaultModificationMessages,
+	Identifier:  "field-changing-default-value",
+	Description: "Adding or changing a default value",
+	Messages:    FieldDefaultModificationMessages,
+	Severity:    SeverityLow,
 }
 
 func FieldDefaultModificationMessages(resource, field string, fieldDiff diff.FieldDiff, _ diff.ResourceDiffInterface) []string {
@@ -153,8 +205,10 @@ func formatDefaultValue(value interface{}) string {
 }
 
 var FieldGrowingMin = FieldDiffRule{
-	Identifier: "field-growing-min",
-	Messages:   FieldGrowingMinMessages,
+	Identifier:  "field-growing-min",
+	Description: "Increasing the minimum number of items in an array",
+	Messages:    FieldGrowingMinMessages,
+	Severity:    SeverityHigh,
 }
 
 func FieldGrowingMinMessages(resource, field string, fieldDiff diff.FieldDiff, _ diff.ResourceDiffInterface) []string {
@@ -175,8 +229,10 @@ func FieldGrowingMinMessages(resource, field string, fieldDiff diff.FieldDiff, _
 }
 
 var FieldShrinkingMax = FieldDiffRule{
-	Identifier: "field-shrinking-max",
-	Messages:   FieldShrinkingMaxMessages,
+	Identifier:  "field-shrinking-max",
+	Description: "Decreasing the maximum number of items in an array",
+	Messages:    FieldShrinkingMaxMessages,
+	Severity:    SeverityHigh,
 }
 
 func FieldShrinkingMaxMessages(resource, field string, fieldDiff diff.FieldDiff, _ diff.ResourceDiffInterface) []string {
@@ -200,8 +256,10 @@ func FieldShrinkingMaxMessages(resource, field string, fieldDiff diff.FieldDiff,
 }
 
 var FieldRemovingDiffSuppress = FieldDiffRule{
-	Identifier: "field-removing-diff-suppress",
-	Messages:   FieldRemovingDiffSuppressMessages,
+	Identifier:  "field-removing-diff-suppress",
+	Description: "Removing diff suppression from a field",
+	Messages:    FieldRemovingDiffSuppressMessages,
+	Severity:    SeverityLow,
 }
 
 func FieldRemovingDiffSuppressMessages(resource, field string, fieldDiff diff.FieldDiff, _ diff.ResourceDiffInterface) []string {
@@ -218,8 +276,10 @@ func FieldRemovingDiffSuppressMessages(resource, field string, fieldDiff diff.Fi
 }
 
 var FieldNewRequired = FieldDiffRule{
-	Identifier: "no-new-required",
-	Messages:   FieldNewRequiredMessages,
+	Identifier:  "no-new-required",
+	Description: "Adding a required field to a pre-existing resource",
+	Messages:    FieldNewRequiredMessages,
+	Severity:    SeverityHigh,
 }
 
 func FieldNewRequiredMessages(resource, field string, fieldDiff diff.FieldDiff, resourceDiff diff.ResourceDiffInterface) []string {
@@ -238,8 +298,10 @@ func FieldNewRequiredMessages(resource, field string, fieldDiff diff.FieldDiff,
 }
 
 var FieldNewOptionalFieldWithDefault = FieldDiffRule{
-	Identifier: "no-new-optional-default",
-	Messages:   FieldNewOptionalFieldWithDefaultMessages,
+	Identifier:  "no-new-optional-default",
+	Description: "Adding an optional field with a default value to a pre-existing resource",
+	Messages:    FieldNewOptionalFieldWithDefaultMessages,
+	Severity:    SeverityLow,
 }
 
 func FieldNewOptionalFieldWithDefaultMessages(resource, field string, fieldDiff diff.FieldDiff, resourceDiff diff.ResourceDiffInterface) []string {
@@ -300,6 +300,117 @@ var FieldChangingTypeTestCases = []fieldTestCase{
 		},
 		expectedViolation: true,
 	},
+	{
+		name: "field transition set -> list",
+		oldField: &schema.Schema{
+			Type: schema.TypeSet,
+			Elem: &schema.Schema{Type: schema.TypeString},
+		},
+		newField: &schema.Schema{
+			Type: schema.TypeList,
+			Elem: &schema.Schema{Type: schema.TypeString},
+		},
+		expectedViolation: true,
+		messageRegex:      "changed from TypeSet to TypeList",
+	},
+	{
+		name: "field transition nested block -> primitive elements",
+		oldField: &schema.Schema{
+			Type: schema.TypeList,
+			Elem: &schema.Resource{Schema: map[string]*schema.Schema{"name": {Type: schema.TypeString}}},
+		},
+		newField: &schema.Schema{
+			Type: schema.TypeList,
+			Elem: &schema.Schema{Type: schema.TypeString},
+		},
+		expectedViolation: true,
+		messageRegex:      "changed from TypeList.Resource to TypeList.TypeString",
+	},
+	{
+		name: "field nested block control",
+		oldField: &schema.Schema{
+			Type: schema.TypeList,
+			Elem: &schema.Resource{Schema: map[string]*schema.Schema{"name": {Type: schema.TypeString}}},
+		},
+		newField: &schema.Schema{
+			Type: schema.TypeList,
+			Elem: &schema.Resource{Schema: map[string]*schema.Schema{"name": {Type: schema.TypeInt}}},
+		},
+		expectedViolation: false,
+	},
+}
+
+func TestFieldChangingTypeSeverity(t *testing.T) {
+	stringElem := &schema.Schema{Type: schema.TypeString}
+	block := &schema.Resource{Schema: map[string]*schema.Schema{"name": {Type: schema.TypeString}}}
+	cases := []struct {
+		name     string
+		oldField *schema.Schema
+		newField *schema.Schema
+		want     Severity
+	}{
+		{
+			name:     "string -> int",
+			oldField: &schema.Schema{Type: schema.TypeString},
+			newField: &schema.Schema{Type: schema.TypeInt},
+			want:     SeverityHigh,
+		},
+		{
+			name:     "int -> float",
+			oldField: &schema.Schema{Type: schema.TypeInt},
+			newField: &schema.Schema{Type: schema.TypeFloat},
+			want:     SeverityLow,
+		},
+		{
+			name:     "float -> int",
+			oldField: &schema.Schema{Type: schema.TypeFloat},
+			newField: &schema.Schema{Type: schema.TypeInt},
+			want:     SeverityHigh,
+		},
+		{
+			name:     "set -> list",
+			oldField: &schema.Schema{Type: schema.TypeSet, Elem: stringElem},
+			newField: &schema.Schema{Type: schema.TypeList, Elem: stringElem},
+			want:     SeverityMedium,
+		},
+		{
+			name:     "set of blocks -> list of blocks",
+			oldField: &schema.Schema{Type: schema.TypeSet, Elem: block},
+			newField: &schema.Schema{Type: schema.TypeList, Elem: block},
+			want:     SeverityMedium,
+		},
+		{
+			name:     "set -> list with different elements",
+			oldField: &schema.Schema{Type: schema.TypeSet, Elem: stringElem},
+			newField: &schema.Schema{Type: schema.TypeList, Elem: &schema.Schema{Type: schema.TypeInt}},
+			want:     SeverityHigh,
+		},
+		{
+			name:     "list elements int -> float",
+			oldField: &schema.Schema{Type: schema.TypeList, Elem: &schema.Schema{Type: schema.TypeInt}},
+			newField: &schema.Schema{Type: schema.TypeList, Elem: &schema.Schema{Type: schema.TypeFloat}},
+			want:     SeverityLow,
+		},
+		{
+			name:     "nested block -> primitive elements",
+			oldField: &schema.Schema{Type: schema.TypeList, Elem: block},
+			newField: &schema.Schema{Type: schema.TypeList, Elem: stringElem},
+			want:     SeverityHigh,
+		},
+		{
+			name:     "list -> string",
+			oldField: &schema.Schema{Type: schema.TypeList, Elem: stringElem},
+			newField: &schema.Schema{Type: schema.TypeString},
+			want:     SeverityHigh,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := FieldChangingTypeSeverity(diff.FieldDiff{Old: tc.oldField, New: tc.newField}); got != tc.want {
+				t.Errorf("FieldChangingTypeSeverity() = %q, want %q", got, tc.want)
+			}
+		})
+	}
 }
 
 func TestFieldDefaultModification(t *testing.T) {
@@ -0,0 +1,107 @@
+package breaking_changes
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// overrideDateFormat is the format of Override.Expires.
+const overrideDateFormat = "2006-01-02"
+
+// ExpiredOverrideRuleName is the RuleName of breaking changes reported for expired overrides.
+const ExpiredOverrideRuleName = "expired-override"
+
+const makeBreakingChangePath = "breaking-changes/make-a-breaking-change"
+
+// Override allows a specific breaking change to pass until it expires, for example
+// while a change targeted at the next major version is being prepared.
+type Override struct {
+	// Rule is the identifier of the rule the override applies to.
+	Rule string `yaml:"rule"`
+	// Resource is the resource the breaking change is in.
+	Resource string `yaml:"resource"`
+	// Field is the field path the rule fired on. Leave empty for resource-level rules.
+	Field string `yaml:"field,omitempty"`
+	// Expires is the last day (in UTC) that the override is valid, as YYYY-MM-DD.
+	Expires string `yaml:"expires"`
+	// Justification explains why the breaking change is intentional.
+	Justification string `yaml:"justification"`
+}
+
+// ParseOverrides parses a list of overrides and checks that each one is complete and names a
+// rule in the registry.
+func ParseOverrides(data []byte) ([]Override, error) {
+	var overrides []Override
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&overrides); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("error parsing overrides: %w", err)
+	}
+	for i, override := range overrides {
+		if override.Rule == "" || override.Resource == "" || override.Justification == "" {
+			return nil, fmt.Errorf("override %d must set rule, resource and justification", i)
+		}
+		if _, ok := RuleByID(override.Rule); !ok {
+			return nil, fmt.Errorf("override %d uses unknown rule %q", i, override.Rule)
+		}
+		if _, err := time.Parse(overrideDateFormat, override.Expires); err != nil {
+			return nil, fmt.Errorf("override %d has invalid expiry date %q, expected YYYY-MM-DD", i, override.Expires)
+		}
+	}
+	return overrides, nil
+}
+
+// ApplyOverrides returns the breaking changes that aren't covered by an unexpired override.
+// Expired overrides don't cover anything and are reported as breaking changes themselves, so
+// that they fail the check until they are removed.
+func ApplyOverrides(breakingChanges []BreakingChange, overrides []Override, now time.Time) []BreakingChange {
+	var active []Override
+	var remaining []BreakingChange
+	for _, override := range overrides {
+		if !override.expired(now) {
+			active = append(active, override)
+			continue
+		}
+		remaining = append(remaining, BreakingChange{
+			Resource:               override.Resource,
+			Field:                  override.Field,
+			Message:                fmt.Sprintf("Override for %s on `%s` expired on %s and must be removed", override.Rule, override.path(), override.Expires),
+			DocumentationReference: fmt.Sprintf("https://googlecloudplatform.github.io/magic-modules/%s#allow-a-breaking-change-to-pass-ci", makeBreakingChangePath),
+			RuleName:               ExpiredOverrideRuleName,
+			Severity:               SeverityHigh,
+		})
+	}
+	for _, breakingChange := range breakingChanges {
+		if !overridden(breakingChange, active) {
+			remaining = append(remaining, breakingChange)
+		}
+	}
+	return remaining
+}
+
+func overridden(breakingChange BreakingChange, overrides []Override) bool {
+	for _, override := range overrides {
+		if override.Rule == breakingChange.RuleName && override.Resource == breakingChange.Resource && override.Field == breakingChange.Field {
+			return true
+		}
+	}
+	return false
+}
+
+func (o Override) expired(now time.Time) bool {
+	// Expires was validated when parsing.
+	expires, _ := time.Parse(overrideDateFormat, o.Expires)
+	return !now.UTC().Before(expires.AddDate(0, 0, 1))
+}
+
+func (o Override) path() string {
+	if o.Field == "" {
+		return o.Resource
+	}
+	return o.Resource + "." + o.Field
+}
@@ -0,0 +1,186 @@
+package breaking_changes
+
+import (
+	"os"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseOverrides(t *testing.T) {
+	cases := []struct {
+		name          string
+		data          string
+		wantOverrides []Override
+		wantErr       string
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name: "valid",
+			data: `
+- rule: field-changing-type
+  resource: google_x
+  field: field_a
+  expires: 2026-11-30
+  justification: Targeted at 8.0.0.
+- rule: resource-map-resource-removal-or-rename
+  resource: google_y
+  expires: 2026-11-30
+  justification: Removed in 8.0.0.
+`,
+			wantOverrides: []Override{
+				{Rule: "field-changing-type", Resource: "google_x", Field: "field_a", Expires: "2026-11-30", Justification: "Targeted at 8.0.0."},
+				{Rule: "resource-map-resource-removal-or-rename", Resource: "google_y", Expires: "2026-11-30", Justification: "Removed in 8.0.0."},
+			},
+		},
+		{
+			name: "missing justification",
+			data: `
+- rule: field-changing-type
+  resource: google_x
+  field: field_a
+  expires: 2026-11-30
+`,
+			wantErr: "must set rule, resource and justification",
+		},
+		{
+			name: "unknown rule",
+			data: `
+- rule: field_changing_type
+  resource: google_x
+  field: field_a
+  expires: 2026-11-30
+  justification: Targeted at 8.0.0.
+`,
+			wantErr: `unknown rule "field_changing_type"`,
+		},
+		{
+			name: "invalid expiry date",
+			data: `
+- rule: field-changing-type
+  resource: google_x
+  expires: next year
+  justification: Targeted at 8.0.0.
+`,
+			wantErr: "invalid expiry date",
+		},
+		{
+			name: "unknown key",
+			data: `
+- rule: field-changing-type
+  resource: google_x
+  path: field_a
+  expires: 2026-11-30
+  justification: Targeted at 8.0.0.
+`,
+			wantErr: "field path not found",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			overrides, err := ParseOverrides([]byte(tc.data))
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("ParseOverrides() error = %v, want error containing %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseOverrides() error = %v", err)
+			}
+			if d := cmp.Diff(tc.wantOverrides, overrides); d != "" {
+				t.Errorf("ParseOverrides() diff (-want, +got):\n%s", d)
+			}
+		})
+	}
+}
+
+func TestApplyOverrides(t *testing.T) {
+	fieldChange := NewBreakingChange("google_x", "field_a", "Field `field_a` changed type", "field-changing-type")
+	otherFieldChange := NewBreakingChange("google_x", "field_b", "Field `field_b` changed type", "field-changing-type")
+	resourceChange := NewBreakingChange("google_y", "", "Resource `google_y` was removed", "resource-map-resource-removal-or-rename")
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name      string
+		overrides []Override
+		want      []BreakingChange
+	}{
+		{
+			name: "no overrides",
+			want: []BreakingChange{fieldChange, otherFieldChange, resourceChange},
+		},
+		{
+			name: "field and resource overrides",
+			overrides: []Override{
+				{Rule: "field-changing-type", Resource: "google_x", Field: "field_a", Expires: "2026-10-16", Justification: "Targeted at 8.0.0."},
+				{Rule: "resource-map-resource-removal-or-rename", Resource: "google_y", Expires: "2027-01-01", Justification: "Removed in 8.0.0."},
+			},
+			want: []BreakingChange{otherFieldChange},
+		},
+		{
+			name: "override for a different rule",
+			overrides: []Override{
+				{Rule: "field-optional-to-required", Resource: "google_x", Field: "field_a", Expires: "2027-01-01", Justification: "Targeted at 8.0.0."},
+			},
+			want: []BreakingChange{fieldChange, otherFieldChange, resourceChange},
+		},
+		{
+			name: "expired override",
+			overrides: []Override{
+				{Rule: "field-changing-type", Resource: "google_x", Field: "field_a", Expires: "2026-10-15", Justification: "Targeted at 8.0.0."},
+				{Rule: "resource-map-resource-removal-or-rename", Resource: "google_z", Expires: "2026-01-01", Justification: "Removed in 8.0.0."},
+			},
+			want: []BreakingChange{
+				{
+					Resource:               "google_x",
+					Field:                  "field_a",
+					Message:                "Override for field-changing-type on `google_x.field_a` expired on 2026-10-15 and must be removed",
+					DocumentationReference: "https://googlecloudplatform.github.io/magic-modules/breaking-changes/make-a-breaking-change#allow-a-breaking-change-to-pass-ci",
+					RuleName:               ExpiredOverrideRuleName,
+					Severity:               SeverityHigh,
+				},
+				{
+					Resource:               "google_z",
+					Message:                "Override for resource-map-resource-removal-or-rename on `google_z` expired on 2026-01-01 and must be removed",
+					DocumentationReference: "https://googlecloudplatform.github.io/magic-modules/breaking-changes/make-a-breaking-change#allow-a-breaking-change-to-pass-ci",
+					RuleName:               ExpiredOverrideRuleName,
+					Severity:               SeverityHigh,
+				},
+				fieldChange,
+				otherFieldChange,
+				resourceChange,
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := ApplyOverrides([]BreakingChange{fieldChange, otherFieldChange, resourceChange}, tc.overrides, now)
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("ApplyOverrides() diff (-want, +got):\n%s", d)
+			}
+		})
+	}
+}
+
+func TestOverridesFile(t *testing.T) {
+	data, err := os.ReadFile("../breaking_change_overrides.yaml")
+	if err != nil {
+		t.Fatalf("Failed to read overrides file: %v", err)
+	}
+	overrides, err := ParseOverrides(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	identifiers := getArrayOfIdentifiers()
+	for _, override := range overrides {
+		if !slices.Contains(identifiers, override.Rule) {
+			t.Errorf("Override for %s uses unknown rule %q", override.path(), override.Rule)
+		}
+	}
+}
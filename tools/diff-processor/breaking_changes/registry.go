@@ -0,0 +1,58 @@
+package breaking_changes
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Rule is the metadata of a breaking change rule. The checks, the overrides file and the
+// documentation all identify rules by ID.
+type Rule struct {
+	// ID is the stable identifier of the rule, used as BreakingChange.RuleName, in overrides
+	// and as the anchor of the rule in the documentation.
+	ID          string `json:"id"`
+	Description string `json:"description"`
+	// Severity is the severity of the rule's breaking changes. Rules that classify each change
+	// report this as the highest severity.
+	Severity               Severity `json:"severity"`
+	DocumentationReference string   `json:"documentation_reference"`
+}
+
+// Rules returns the registry of breaking change rules, sorted by ID.
+func Rules() []Rule {
+	var rules []Rule
+	for _, r := range ResourceConfigDiffRules {
+		rules = append(rules, newRule(r.Identifier, r.Description, r.Severity))
+	}
+	for _, r := range ResourceDiffRules {
+		rules = append(rules, newRule(r.Identifier, r.Description, r.Severity))
+	}
+	for _, r := range FieldDiffRules {
+		rules = append(rules, newRule(r.Identifier, r.Description, r.Severity))
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].ID < rules[j].ID })
+	return rules
+}
+
+// RuleByID returns the rule with the given ID, and false if there isn't one.
+func RuleByID(id string) (Rule, bool) {
+	for _, rule := range Rules() {
+		if rule.ID == id {
+			return rule, true
+		}
+	}
+	return Rule{}, false
+}
+
+func newRule(id, description string, severity Severity) Rule {
+	return Rule{
+		ID:                     id,
+		Description:            description,
+		Severity:               severity,
+		DocumentationReference: ruleDocumentationReference(id),
+	}
+}
+
+func ruleDocumentationReference(id string) string {
+	return fmt.Sprintf("https://googlecloudplatform.github.io/magic-modules/%s#%s", breakingChangesPath, id)
+}
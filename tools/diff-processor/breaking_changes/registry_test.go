@@ -0,0 +1,55 @@
+package breaking_changes
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestRules(t *testing.T) {
+	rules := Rules()
+	if got, want := len(rules), len(getArrayOfIdentifiers()); got != want {
+		t.Errorf("Rules() returned %d rules, want %d", got, want)
+	}
+	if !sort.SliceIsSorted(rules, func(i, j int) bool { return rules[i].ID < rules[j].ID }) {
+		t.Errorf("Rules() isn't sorted by ID")
+	}
+	for _, rule := range rules {
+		if rule.Description == "" {
+			t.Errorf("Rule %s has no description", rule.ID)
+		}
+		if rule.Severity == "" {
+			t.Errorf("Rule %s has no severity", rule.ID)
+		}
+		if want := "https://googlecloudplatform.github.io/magic-modules/breaking-changes/breaking-changes#" + rule.ID; rule.DocumentationReference != want {
+			t.Errorf("Rule %s has documentation reference %q, want %q", rule.ID, rule.DocumentationReference, want)
+		}
+	}
+}
+
+func TestRuleByID(t *testing.T) {
+	rule, ok := RuleByID("field-changing-type")
+	if !ok || rule.ID != "field-changing-type" {
+		t.Errorf("RuleByID(%q) = %v, %v, want the field-changing-type rule", "field-changing-type", rule, ok)
+	}
+	if _, ok := RuleByID("field_changing_type"); ok {
+		t.Errorf("RuleByID(%q) found a rule, want none", "field_changing_type")
+	}
+}
+
+func TestRuleSeverities(t *testing.T) {
+	for id, want := range map[string]Severity{
+		"resource-map-resource-removal-or-rename": SeverityHigh,
+		"field-changing-type":                     SeverityHigh,
+		"field-changing-default-value":            SeverityLow,
+		"field-removing-diff-suppress":            SeverityLow,
+	} {
+		rule, ok := RuleByID(id)
+		if !ok {
+			t.Errorf("RuleByID(%q) found no rule", id)
+			continue
+		}
+		if rule.Severity != want {
+			t.Errorf("Rule %s has severity %q, want %q", id, rule.Severity, want)
+		}
+	}
+}
@@ -9,8 +9,10 @@ import (
 // ResourceConfigDiffRule provides
 // structure for rules regarding resource config changes
 type ResourceConfigDiffRule struct {
-	Identifier string
-	Messages   func(resource string, resourceConfigDiff diff.ResourceConfigDiff) []string
+	Identifier  string
+	Description string
+	Messages    func(resource string, resourceConfigDiff diff.ResourceConfigDiff) []string
+	Severity    Severity
 }
 
 // ResourceConfigDiffRules is a list of ResourceConfigDiffRule
@@ -18,8 +20,10 @@ type ResourceConfigDiffRule struct {
 var ResourceConfigDiffRules = []ResourceConfigDiffRule{ResourceConfigRemovingAResource}
 
 var ResourceConfigRemovingAResource = ResourceConfigDiffRule{
-	Identifier: "resource-map-resource-removal-or-rename",
-	Messages:   ResourceConfigRemovingAResourceMessages,
+	Identifier:  "resource-map-resource-removal-or-rename",
+	Description: "Removing or renaming a resource or data source",
+	Messages:    ResourceConfigRemovingAResourceMessages,
+	Severity:    SeverityHigh,
 }
 
 func ResourceConfigRemovingAResourceMessages(resource string, resourceConfigDiff diff.ResourceConfigDiff) []string {
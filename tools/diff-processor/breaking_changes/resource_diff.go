@@ -9,16 +9,20 @@ import (
 
 // ResourceDiffRule is a rule that operates on an entire ResourceDiff
 type ResourceDiffRule struct {
-	Identifier string
-	Messages   func(resource string, resourceDiff diff.ResourceDiff) []string
+	Identifier  string
+	Description string
+	Messages    func(resource string, resourceDiff diff.ResourceDiff) []string
+	Severity    Severity
 }
 
 // ResourceDiffRules is a list of all ResourceDiff rules
 var ResourceDiffRules = []ResourceDiffRule{RemovingAField, AddingExactlyOneOf}
 
 var RemovingAField = ResourceDiffRule{
-	Identifier: "resource-schema-field-removal-or-rename",
-	Messages:   RemovingAFieldMessages,
+	Identifier:  "resource-schema-field-removal-or-rename",
+	Description: "Removing or renaming a field",
+	Messages:    RemovingAFieldMessages,
+	Severity:    SeverityHigh,
 }
 
 // TODO: Make field removal a FieldDiffRule b/300124253
@@ -30,17 +34,28 @@ func RemovingAFieldMessages(resource string, resourceDiff diff.ResourceDiff) []s
 		}
 	}
 
+	renames := make(map[string]diff.FieldRename)
+	for _, rename := range diff.ProbableRenames(resourceDiff) {
+		renames[rename.Old] = rename
+	}
+
 	tmpl := "Field `%s` within resource `%s` was either removed or renamed"
 	var messages []string
 	for _, field := range fieldsRemoved {
-		messages = append(messages, fmt.Sprintf(tmpl, field, resource))
+		message := fmt.Sprintf(tmpl, field, resource)
+		if rename, ok := renames[field]; ok {
+			message += fmt.Sprintf("; it was probably renamed to `%s` (%s confidence)", rename.New, rename.Confidence)
+		}
+		messages = append(messages, message)
 	}
 	return messages
 }
 
 var AddingExactlyOneOf = ResourceDiffRule{
-	Identifier: "resource-schema-field-addition-of-exactly-one-of",
-	Messages:   AddingExactlyOneOfMessages,
+	Identifier:  "resource-schema-field-addition-of-exactly-one-of",
+	Description: "Adding an ExactlyOneOf constraint that makes previously optional fields required or conflicting",
+	Messages:    AddingExactlyOneOfMessages,
+	Severity:    SeverityHigh,
 }
 
 func AddingExactlyOneOfMessages(resource string, resourceDiff diff.ResourceDiff) []string {
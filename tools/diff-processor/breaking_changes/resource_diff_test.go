@@ -1,6 +1,7 @@
 package breaking_changes
 
 import (
+	"reflect"
 	"sort"
 	"strings"
 	"testing"
@@ -28,6 +29,27 @@ func TestRemovingAFieldMessages(t *testing.T) {
 	}
 }
 
+func TestRemovingAFieldMessagesProbableRename(t *testing.T) {
+	schemaDiff := diff.ComputeSchemaDiff(
+		map[string]*schema.Resource{"resource": {Schema: map[string]*schema.Schema{
+			"node_count": {Type: schema.TypeInt, Optional: true, Description: "The number of nodes."},
+			"zone":       {Type: schema.TypeString, Optional: true},
+		}}},
+		map[string]*schema.Resource{"resource": {Schema: map[string]*schema.Schema{
+			"initial_node_count": {Type: schema.TypeInt, Optional: true, Description: "The number of nodes."},
+		}}},
+	)
+	gotMessages := RemovingAFieldMessages("resource", schemaDiff["resource"])
+	sort.Strings(gotMessages)
+	wantMessages := []string{
+		"Field `node_count` within resource `resource` was either removed or renamed; it was probably renamed to `initial_node_count` (high confidence)",
+		"Field `zone` within resource `resource` was either removed or renamed",
+	}
+	if !reflect.DeepEqual(gotMessages, wantMessages) {
+		t.Errorf("RemovingAFieldMessages() = %q, want %q", gotMessages, wantMessages)
+	}
+}
+
 func TestAddingExactlyOneOfMessages(t *testing.T) {
 	for _, tc := range resourceSchemaRule_AddingExactlyOneOf_TestCases {
 		gotMessages := AddingExactlyOneOfMessages("resource", tc.resourceDiff)
@@ -23,3 +23,18 @@ func getValueType(valueType schema.ValueType) string {
 	}
 	return "TypeUndefined"
 }
+
+// getElemType describes a collection field's elements, or returns "" if it has none.
+func getElemType(elem interface{}) string {
+	switch e := elem.(type) {
+	case *schema.Schema:
+		if e != nil {
+			return getValueType(e.Type)
+		}
+	case *schema.Resource:
+		if e != nil {
+			return "Resource"
+		}
+	}
+	return ""
+}
@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	newProvider "google/provider/new/google/provider"
+
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/GoogleCloudPlatform/magic-modules/tools/diff-processor/api_coverage"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/spf13/cobra"
+)
+
+const apiCoverageDesc = `Compare the new provider's resources against the Google API discovery documents (*.json) in DISCOVERY_DIR.
+
+Lists the fields each API exposes that the matching resource doesn't, grouped by product. Resources
+are matched to API schemas by name, so google_compute_instance is compared against the compute API's
+Instance schema; resources and schemas that don't follow that pattern aren't reported.`
+
+type apiCoverageOptions struct {
+	rootOptions *rootOptions
+	resourceMap func() map[string]*schema.Resource
+	stdout      io.Writer
+}
+
+func newAPICoverageCmd(rootOptions *rootOptions) *cobra.Command {
+	o := &apiCoverageOptions{
+		rootOptions: rootOptions,
+		resourceMap: newProvider.ResourceMap,
+		stdout:      os.Stdout,
+	}
+	return &cobra.Command{
+		Use:   "api-coverage DISCOVERY_DIR",
+		Short: "List the API fields that provider resources don't expose.",
+		Long:  apiCoverageDesc,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			return o.run(args)
+		},
+	}
+}
+
+func (o *apiCoverageOptions) run(args []string) error {
+	docs, err := api_coverage.ReadDiscoveryDocs(args[0])
+	if err != nil {
+		return err
+	}
+	coverage := api_coverage.ComputeCoverage(o.resourceMap(), docs)
+	if err := json.NewEncoder(o.stdout).Encode(coverage); err != nil {
+		return fmt.Errorf("error encoding json: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/GoogleCloudPlatform/magic-modules/tools/diff-processor/breaking_changes"
+	"github.com/spf13/cobra"
+)
+
+const breakingChangeRulesDesc = `List the breaking change rules as JSON, with each rule's ID, description, severity and
+documentation reference.`
+
+type breakingChangeRulesOptions struct {
+	rootOptions *rootOptions
+	stdout      io.Writer
+}
+
+func newBreakingChangeRulesCmd(rootOptions *rootOptions) *cobra.Command {
+	o := &breakingChangeRulesOptions{
+		rootOptions: rootOptions,
+		stdout:      os.Stdout,
+	}
+	return &cobra.Command{
+		Use:   "breaking-change-rules",
+		Short: "List the breaking change rules as JSON.",
+		Long:  breakingChangeRulesDesc,
+		Args:  cobra.NoArgs,
+		RunE: func(c *cobra.Command, args []string) error {
+			return o.run()
+		},
+	}
+}
+
+func (o *breakingChangeRulesOptions) run() error {
+	if err := json.NewEncoder(o.stdout).Encode(breaking_changes.Rules()); err != nil {
+		return fmt.Errorf("error encoding json: %w", err)
+	}
+	return nil
+}
@@ -2,11 +2,14 @@ package cmd
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 
 	"io"
+	"io/fs"
 	"os"
 	"sort"
+	"time"
 
 	"github.com/GoogleCloudPlatform/magic-modules/tools/diff-processor/breaking_changes"
 	"github.com/GoogleCloudPlatform/magic-modules/tools/diff-processor/diff"
@@ -15,9 +18,14 @@ import (
 
 const breakingChangesDesc = `Check for breaking changes between the new / old Terraform provider versions.`
 
+// defaultOverridesPath is the file listing intentional breaking changes that are allowed to pass.
+const defaultOverridesPath = "breaking_change_overrides.yaml"
+
 type breakingChangesOptions struct {
 	rootOptions       *rootOptions
 	computeSchemaDiff func() diff.SchemaDiff
+	overridesPath     string
+	now               func() time.Time
 	stdout            io.Writer
 }
 
@@ -27,6 +35,7 @@ func newBreakingChangesCmd(rootOptions *rootOptions) *cobra.Command {
 		computeSchemaDiff: func() diff.SchemaDiff {
 			return schemaDiff
 		},
+		now:    time.Now,
 		stdout: os.Stdout,
 	}
 	cmd := &cobra.Command{
@@ -37,11 +46,19 @@ func newBreakingChangesCmd(rootOptions *rootOptions) *cobra.Command {
 			return o.run()
 		},
 	}
+	cmd.Flags().StringVar(&o.overridesPath, "overrides", defaultOverridesPath, "path to a file of breaking changes to allow until they expire; ignored if it doesn't exist")
 	return cmd
 }
 func (o *breakingChangesOptions) run() error {
 	schemaDiff := o.computeSchemaDiff()
 	breakingChanges := breaking_changes.ComputeBreakingChanges(schemaDiff)
+	overrides, err := o.readOverrides()
+	if err != nil {
+		return err
+	}
+	if len(overrides) > 0 {
+		breakingChanges = breaking_changes.ApplyOverrides(breakingChanges, overrides, o.now())
+	}
 	sort.Slice(breakingChanges, func(i, j int) bool {
 		return breakingChanges[i].Message < breakingChanges[j].Message
 	})
@@ -50,3 +67,17 @@ func (o *breakingChangesOptions) run() error {
 	}
 	return nil
 }
+
+func (o *breakingChangesOptions) readOverrides() ([]breaking_changes.Override, error) {
+	if o.overridesPath == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(o.overridesPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading overrides: %w", err)
+	}
+	return breaking_changes.ParseOverrides(data)
+}
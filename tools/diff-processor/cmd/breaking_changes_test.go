@@ -3,7 +3,10 @@ package cmd
 import (
 	"bytes"
 	"encoding/json"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/GoogleCloudPlatform/magic-modules/tools/diff-processor/breaking_changes"
 	"github.com/GoogleCloudPlatform/magic-modules/tools/diff-processor/diff"
@@ -105,3 +108,86 @@ func TestBreakingChangesCmd(t *testing.T) {
 		})
 	}
 }
+
+func TestBreakingChangesCmdOverrides(t *testing.T) {
+	oldResourceMap := map[string]*schema.Resource{
+		"google-x": {
+			Schema: map[string]*schema.Schema{
+				"field-a": {Description: "beep", Optional: true},
+				"field-b": {Description: "beep", Optional: true},
+			},
+		},
+	}
+	newResourceMap := map[string]*schema.Resource{
+		"google-x": {
+			Schema: map[string]*schema.Schema{
+				"field-a": {Description: "beep", Required: true},
+			},
+		},
+	}
+	cases := map[string]struct {
+		overrides          string
+		expectedViolations int
+	}{
+		"no overrides file": {
+			expectedViolations: 2,
+		},
+		"override one change": {
+			overrides: `
+- rule: field-optional-to-required
+  resource: google-x
+  field: field-a
+  expires: 2026-11-30
+  justification: Targeted at the next major release.
+`,
+			expectedViolations: 1,
+		},
+		"expired override": {
+			overrides: `
+- rule: field-optional-to-required
+  resource: google-x
+  field: field-a
+  expires: 2026-10-01
+  justification: Targeted at the next major release.
+`,
+			expectedViolations: 3,
+		},
+	}
+
+	for tn, tc := range cases {
+		tc := tc
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+
+			overridesPath := filepath.Join(t.TempDir(), "overrides.yaml")
+			if tc.overrides != "" {
+				if err := os.WriteFile(overridesPath, []byte(tc.overrides), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			var buf bytes.Buffer
+			o := breakingChangesOptions{
+				computeSchemaDiff: func() diff.SchemaDiff {
+					return diff.ComputeSchemaDiff(oldResourceMap, newResourceMap)
+				},
+				overridesPath: overridesPath,
+				now: func() time.Time {
+					return time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+				},
+				stdout: &buf,
+			}
+
+			if err := o.run(); err != nil {
+				t.Fatalf("Error running command: %s", err)
+			}
+
+			var got []breaking_changes.BreakingChange
+			if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+				t.Fatalf("Failed to unmarshall output: %s", err)
+			}
+			if len(got) != tc.expectedViolations {
+				t.Errorf("Unexpected number of violations. Want %d, got %d. Output: %s", tc.expectedViolations, len(got), buf.String())
+			}
+		})
+	}
+}
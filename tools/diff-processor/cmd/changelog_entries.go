@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	newProvider "google/provider/new/google/provider"
+	oldProvider "google/provider/old/google/provider"
+
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/GoogleCloudPlatform/magic-modules/tools/diff-processor/breaking_changes"
+	"github.com/GoogleCloudPlatform/magic-modules/tools/diff-processor/diff"
+	"github.com/GoogleCloudPlatform/magic-modules/tools/diff-processor/release_notes"
+	"github.com/spf13/cobra"
+)
+
+const changelogEntriesDesc = `Generate candidate changelog entries for the changes in this build, as the
+release-note blocks that PR descriptions use.
+
+Entries are based on the schema diff: new resources, data sources and fields, fields that became
+updatable or optional, fixed permadiffs, deprecations and breaking changes. With --json, return
+the entries as a JSON list of {"type", "note"} objects instead.`
+
+type changelogEntriesOptions struct {
+	rootOptions                 *rootOptions
+	computeSchemaDiff           func() diff.SchemaDiff
+	computeDatasourceSchemaDiff func() diff.SchemaDiff
+	json                        bool
+	stdout                      io.Writer
+}
+
+func newChangelogEntriesCmd(rootOptions *rootOptions) *cobra.Command {
+	o := &changelogEntriesOptions{
+		rootOptions: rootOptions,
+		computeSchemaDiff: func() diff.SchemaDiff {
+			return schemaDiff
+		},
+		computeDatasourceSchemaDiff: func() diff.SchemaDiff {
+			return diff.ComputeSchemaDiff(oldProvider.DatasourceMap(), newProvider.DatasourceMap())
+		},
+		stdout: os.Stdout,
+	}
+	cmd := &cobra.Command{
+		Use:   "changelog-entries",
+		Short: "Generate candidate changelog entries for the changes in this build.",
+		Long:  changelogEntriesDesc,
+		Args:  cobra.NoArgs,
+		RunE: func(c *cobra.Command, args []string) error {
+			return o.run()
+		},
+	}
+	cmd.Flags().BoolVar(&o.json, "json", false, "return the entries as JSON")
+	return cmd
+}
+
+func (o *changelogEntriesOptions) run() error {
+	schemaDiff := o.computeSchemaDiff()
+	report := diff.NewReport(schemaDiff, o.computeDatasourceSchemaDiff())
+	entries := release_notes.ChangelogEntries(report, breaking_changes.ComputeBreakingChanges(schemaDiff))
+	if o.json {
+		if entries == nil {
+			entries = []release_notes.ReleaseNote{}
+		}
+		if err := json.NewEncoder(o.stdout).Encode(entries); err != nil {
+			return fmt.Errorf("error encoding json: %w", err)
+		}
+		return nil
+	}
+	if _, err := fmt.Fprint(o.stdout, release_notes.FormatChangelog(entries)); err != nil {
+		return fmt.Errorf("error writing changelog entries: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/GoogleCloudPlatform/magic-modules/tools/diff-processor/detector"
+	"github.com/GoogleCloudPlatform/magic-modules/tools/test-reader/reader"
+	"github.com/golang/glog"
+	"github.com/spf13/cobra"
+)
+
+const detectBetaOnlyTestsDesc = "List the changed fields that are only tested with the beta provider, using the given services directory"
+
+type detectBetaOnlyTestsOptions struct {
+	rootOptions *rootOptions
+	stdout      io.Writer
+}
+
+func newDetectBetaOnlyTestsCmd(rootOptions *rootOptions) *cobra.Command {
+	o := &detectBetaOnlyTestsOptions{
+		rootOptions: rootOptions,
+		stdout:      os.Stdout,
+	}
+	return &cobra.Command{
+		Use:   "detect-beta-only-tests SERVICES_DIR",
+		Short: detectBetaOnlyTestsDesc,
+		Long:  detectBetaOnlyTestsDesc,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			return o.run(args)
+		},
+	}
+}
+
+func (o *detectBetaOnlyTestsOptions) run(args []string) error {
+	allTests, errs := reader.ReadAllTests(args[0])
+	for path, err := range errs {
+		glog.Infof("error reading path: %s, err: %v", path, err)
+	}
+
+	betaOnlyTests, err := detector.DetectBetaOnlyTests(schemaDiff, allTests)
+	if err != nil {
+		return fmt.Errorf("error detecting beta only tests: %v", err)
+	}
+	if err := json.NewEncoder(o.stdout).Encode(betaOnlyTests); err != nil {
+		return fmt.Errorf("error encoding json: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	newProvider "google/provider/new/google/provider"
+
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/GoogleCloudPlatform/magic-modules/tools/diff-processor/generation_coverage"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/spf13/cobra"
+)
+
+const generationCoverageDesc = `Report whether each of the new provider's resources is generated by MMv1, generated by
+tpgtools from the DCL, or handwritten, using the resource metadata files (*_meta.yaml) in SERVICES_DIR.
+
+SERVICES_DIR is the services directory of the downstream provider, like google-beta/services. The JSON
+report has the number of resources per generation type and the resources of each service package by
+generation type. Resources without a metadata file are reported as "unknown".`
+
+type generationCoverageOptions struct {
+	rootOptions *rootOptions
+	resourceMap func() map[string]*schema.Resource
+	stdout      io.Writer
+}
+
+func newGenerationCoverageCmd(rootOptions *rootOptions) *cobra.Command {
+	o := &generationCoverageOptions{
+		rootOptions: rootOptions,
+		resourceMap: newProvider.ResourceMap,
+		stdout:      os.Stdout,
+	}
+	return &cobra.Command{
+		Use:   "generation-coverage SERVICES_DIR",
+		Short: "Report which resources are generated by MMv1, tpgtools (DCL), or handwritten.",
+		Long:  generationCoverageDesc,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			return o.run(args)
+		},
+	}
+}
+
+func (o *generationCoverageOptions) run(args []string) error {
+	metadata, err := generation_coverage.ReadMetadata(args[0])
+	if err != nil {
+		return err
+	}
+	report := generation_coverage.ComputeReport(o.resourceMap(), metadata)
+	if err := json.NewEncoder(o.stdout).Encode(report); err != nil {
+		return fmt.Errorf("error encoding json: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	newProvider "google/provider/new/google/provider"
+	oldProvider "google/provider/old/google/provider"
+
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/GoogleCloudPlatform/magic-modules/tools/diff-processor/diff"
+	"github.com/GoogleCloudPlatform/magic-modules/tools/diff-processor/release_notes"
+	"github.com/spf13/cobra"
+)
+
+const releaseNotesDesc = `Summarize the resources, data sources and fields added in this build as release note bullets.
+
+The old and new builds can be a PR's base and head, or two provider versions.`
+
+type releaseNotesOptions struct {
+	rootOptions                 *rootOptions
+	computeSchemaDiff           func() diff.SchemaDiff
+	computeDatasourceSchemaDiff func() diff.SchemaDiff
+	stdout                      io.Writer
+}
+
+func newReleaseNotesCmd(rootOptions *rootOptions) *cobra.Command {
+	o := &releaseNotesOptions{
+		rootOptions: rootOptions,
+		computeSchemaDiff: func() diff.SchemaDiff {
+			return schemaDiff
+		},
+		computeDatasourceSchemaDiff: func() diff.SchemaDiff {
+			return diff.ComputeSchemaDiff(oldProvider.DatasourceMap(), newProvider.DatasourceMap())
+		},
+		stdout: os.Stdout,
+	}
+	cmd := &cobra.Command{
+		Use:   "release-notes",
+		Short: "Summarize the resources, data sources and fields added in this build as release note bullets.",
+		Long:  releaseNotesDesc,
+		Args:  cobra.NoArgs,
+		RunE: func(c *cobra.Command, args []string) error {
+			return o.run()
+		},
+	}
+	return cmd
+}
+
+func (o *releaseNotesOptions) run() error {
+	report := diff.NewReport(o.computeSchemaDiff(), o.computeDatasourceSchemaDiff())
+	if _, err := fmt.Fprint(o.stdout, release_notes.FormatBullets(release_notes.NewFeatureNotes(report))); err != nil {
+		return fmt.Errorf("error writing release notes: %w", err)
+	}
+	return nil
+}
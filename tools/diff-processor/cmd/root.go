@@ -22,9 +22,15 @@ func newRootCmd() (*cobra.Command, *rootOptions, error) {
 		SilenceErrors: true,
 	}
 	cmd.AddCommand(newBreakingChangesCmd(o))
+	cmd.AddCommand(newBreakingChangeRulesCmd(o))
 	cmd.AddCommand(newDetectMissingTestsCmd(o))
+	cmd.AddCommand(newDetectBetaOnlyTestsCmd(o))
 	cmd.AddCommand(newSchemaDiffCmd(o))
 	cmd.AddCommand(newDetectMissingDocsCmd(o))
+	cmd.AddCommand(newReleaseNotesCmd(o))
+	cmd.AddCommand(newChangelogEntriesCmd(o))
+	cmd.AddCommand(newAPICoverageCmd(o))
+	cmd.AddCommand(newGenerationCoverageCmd(o))
 	return cmd, o, nil
 }
 
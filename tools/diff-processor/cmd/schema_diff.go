@@ -14,7 +14,10 @@ import (
 	"github.com/spf13/cobra"
 )
 
-const schemaDiffDesc = `Return a simple summary of the schema diff for this build.`
+const schemaDiffDesc = `Return a simple summary of the schema diff for this build.
+
+With --detailed, return the versioned JSON report of the schema diff for resources and
+data sources instead, including the attributes of each added, removed and changed field.`
 
 var schemaDiff = diff.ComputeSchemaDiff(oldProvider.ResourceMap(), newProvider.ResourceMap())
 
@@ -23,9 +26,11 @@ type simpleSchemaDiff struct {
 }
 
 type schemaDiffOptions struct {
-	rootOptions       *rootOptions
-	computeSchemaDiff func() diff.SchemaDiff
-	stdout            io.Writer
+	rootOptions                 *rootOptions
+	computeSchemaDiff           func() diff.SchemaDiff
+	computeDatasourceSchemaDiff func() diff.SchemaDiff
+	detailed                    bool
+	stdout                      io.Writer
 }
 
 func newSchemaDiffCmd(rootOptions *rootOptions) *cobra.Command {
@@ -34,22 +39,34 @@ func newSchemaDiffCmd(rootOptions *rootOptions) *cobra.Command {
 		computeSchemaDiff: func() diff.SchemaDiff {
 			return schemaDiff
 		},
+		computeDatasourceSchemaDiff: func() diff.SchemaDiff {
+			return diff.ComputeSchemaDiff(oldProvider.DatasourceMap(), newProvider.DatasourceMap())
+		},
 		stdout: os.Stdout,
 	}
 	cmd := &cobra.Command{
 		Use:   "schema-diff",
-		Short: schemaDiffDesc,
+		Short: "Return a simple summary of the schema diff for this build.",
 		Long:  schemaDiffDesc,
 		Args:  cobra.NoArgs,
 		RunE: func(c *cobra.Command, args []string) error {
 			return o.run()
 		},
 	}
+	cmd.Flags().BoolVar(&o.detailed, "detailed", false, "return the versioned JSON report of the schema diff")
 	return cmd
 }
 func (o *schemaDiffOptions) run() error {
 	schemaDiff := o.computeSchemaDiff()
 
+	if o.detailed {
+		report := diff.NewReport(schemaDiff, o.computeDatasourceSchemaDiff())
+		if err := json.NewEncoder(o.stdout).Encode(report); err != nil {
+			return fmt.Errorf("Error encoding json: %w", err)
+		}
+		return nil
+	}
+
 	simple := simpleSchemaDiff{}
 
 	for k, d := range schemaDiff {
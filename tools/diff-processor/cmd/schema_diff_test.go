@@ -280,3 +280,60 @@ func TestSchemaDiffCmdRun(t *testing.T) {
 		})
 	}
 }
+
+func TestSchemaDiffCmdRunDetailed(t *testing.T) {
+	var buf bytes.Buffer
+	o := schemaDiffOptions{
+		computeSchemaDiff: func() diff.SchemaDiff {
+			return diff.ComputeSchemaDiff(
+				map[string]*schema.Resource{
+					"google_x_resource": {Schema: map[string]*schema.Schema{"field_a": {Type: schema.TypeString, Optional: true}}},
+				},
+				map[string]*schema.Resource{
+					"google_x_resource": {Schema: map[string]*schema.Schema{
+						"field_a": {Type: schema.TypeString, Optional: true},
+						"field_b": {Type: schema.TypeBool, Optional: true},
+					}},
+				},
+			)
+		},
+		computeDatasourceSchemaDiff: func() diff.SchemaDiff {
+			return diff.ComputeSchemaDiff(map[string]*schema.Resource{}, map[string]*schema.Resource{
+				"google_x_resources": {Schema: map[string]*schema.Schema{"filter": {Type: schema.TypeString, Optional: true}}},
+			})
+		},
+		detailed: true,
+		stdout:   &buf,
+	}
+
+	if err := o.run(); err != nil {
+		t.Fatalf("Error running command: %s", err)
+	}
+
+	var got diff.Report
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("Unable to unmarshal report (%q): %s", buf.String(), err)
+	}
+	want := diff.Report{
+		Version: diff.ReportVersion,
+		Resources: diff.KindReport{
+			Added:   []string{},
+			Removed: []string{},
+			Modified: []diff.ResourceReport{{
+				Name:          "google_x_resource",
+				AddedFields:   []diff.FieldReport{{Path: "field_b", Attributes: diff.FieldAttributes{Type: "bool", Optional: true}}},
+				RemovedFields: []diff.FieldReport{},
+				ChangedFields: []diff.FieldChangeReport{},
+				RenamedFields: []diff.FieldRenameReport{},
+			}},
+		},
+		DataSources: diff.KindReport{
+			Added:    []string{"google_x_resources"},
+			Removed:  []string{},
+			Modified: []diff.ResourceReport{},
+		},
+	}
+	if !cmp.Equal(want, got) {
+		t.Errorf("Unexpected report (-want, +got):\n%s", cmp.Diff(want, got))
+	}
+}
@@ -48,7 +48,7 @@ type MissingDocDetails struct {
 // Return a map of resource names to missing test info about that resource.
 func DetectMissingTests(schemaDiff diff.SchemaDiff, allTests []*reader.Test) (map[string]*MissingTestInfo, error) {
 	changedFields := getChangedFieldsFromSchemaDiff(schemaDiff)
-	return getMissingTestsForChanges(changedFields, allTests)
+	return getMissingTestsForChanges(changedFields, allTests, schemaDiff)
 }
 
 // Convert SchemaDiff object to map of ResourceChanges objects.
@@ -91,7 +91,9 @@ func getChangedFieldsFromSchemaDiff(schemaDiff diff.SchemaDiff) map[string]Resou
 	return changedFields
 }
 
-func getMissingTestsForChanges(changedFields map[string]ResourceChanges, allTests []*reader.Test) (map[string]*MissingTestInfo, error) {
+// schemaDiff provides the new schemas used to fill in the suggested tests. It may be nil, in
+// which case the suggested tests only include the untested fields, without values.
+func getMissingTestsForChanges(changedFields map[string]ResourceChanges, allTests []*reader.Test, schemaDiff diff.SchemaDiff) (map[string]*MissingTestInfo, error) {
 	resourceNamesToTests := make(map[string][]string)
 	for _, test := range allTests {
 		for _, step := range test.Steps {
@@ -115,7 +117,7 @@ func getMissingTestsForChanges(changedFields map[string]ResourceChanges, allTest
 		if len(untested) > 0 {
 			missingTests[resourceName] = &MissingTestInfo{
 				UntestedFields: untested,
-				SuggestedTest:  suggestedTest(resourceName, untested),
+				SuggestedTest:  suggestedTest(resourceName, untested, schemaDiff[resourceName].FlattenedSchema.New),
 				Tests:          resourceNamesToTests[resourceName],
 			}
 		}
@@ -123,6 +125,48 @@ func getMissingTestsForChanges(changedFields map[string]ResourceChanges, allTest
 	return missingTests, nil
 }
 
+// DetectBetaOnlyTests returns a map of resource names to the changed fields that are only set
+// in tests that run against the beta provider. Given the GA provider's schema diff and the beta
+// provider's tests, these are fields that exist in GA but aren't tested with the GA provider.
+func DetectBetaOnlyTests(schemaDiff diff.SchemaDiff, allTests []*reader.Test) (map[string][]string, error) {
+	allCoverage := getChangedFieldsFromSchemaDiff(schemaDiff)
+	gaCoverage := getChangedFieldsFromSchemaDiff(schemaDiff)
+	for _, test := range allTests {
+		for _, step := range test.Steps {
+			for resourceName, resourceMap := range step {
+				if _, ok := allCoverage[resourceName]; !ok {
+					continue
+				}
+				for _, resourceConfig := range resourceMap {
+					if err := markCoverage(allCoverage[resourceName], resourceConfig); err != nil {
+						return nil, err
+					}
+					if test.Beta {
+						continue
+					}
+					if err := markCoverage(gaCoverage[resourceName], resourceConfig); err != nil {
+						return nil, err
+					}
+				}
+			}
+		}
+	}
+	betaOnly := make(map[string][]string)
+	for resourceName, fieldCoverage := range allCoverage {
+		var fields []string
+		for fieldName, field := range fieldCoverage {
+			if field.Tested && !gaCoverage[resourceName][fieldName].Tested {
+				fields = append(fields, fieldName)
+			}
+		}
+		if len(fields) > 0 {
+			sort.Strings(fields)
+			betaOnly[resourceName] = fields
+		}
+	}
+	return betaOnly, nil
+}
+
 func markCoverage(fieldCoverage ResourceChanges, config reader.Resource) error {
 	for fieldName := range config {
 		if field, ok := fieldCoverage[fieldName]; ok {
@@ -142,11 +186,15 @@ func untestedFields(fieldCoverage ResourceChanges) []string {
 	return fields
 }
 
-func suggestedTest(resourceName string, untested []string) string {
+// suggestedTest returns a config for the resource that sets the untested fields, along with
+// the required fields of the resource and of any blocks it includes. Fields are given
+// placeholder values based on their schema in flattenedSchema, or left for the author to fill
+// in if the schema is unknown.
+func suggestedTest(resourceName string, untested []string, flattenedSchema map[string]*schema.Schema) string {
 	f := hclwrite.NewEmptyFile()
 	rootBody := f.Body()
 	resourceBlock := rootBody.AppendNewBlock("resource", []string{resourceName, "primary"})
-	for _, field := range untested {
+	for _, field := range withRequiredFields(untested, flattenedSchema) {
 		body := resourceBlock.Body()
 		path := strings.Split(field, ".")
 		for i, step := range path {
@@ -156,14 +204,93 @@ func suggestedTest(resourceName string, untested []string) string {
 					block = body.AppendNewBlock(step, nil)
 				}
 				body = block.Body()
+			} else if isBlock(flattenedSchema[field]) {
+				// Required blocks are added here in case none of their fields are set.
+				if body.FirstMatchingBlock(step, nil) == nil {
+					body.AppendNewBlock(step, nil)
+				}
 			} else {
-				body.SetAttributeValue(step, cty.StringVal("VALUE"))
+				body.SetAttributeValue(step, placeholderValue(step, flattenedSchema[field]))
 			}
 		}
 	}
 	return strings.ReplaceAll(string(f.Bytes()), `"VALUE"`, "# value needed")
 }
 
+// withRequiredFields returns the untested fields plus the required fields at the top level
+// of the resource or within a block the config will include, in sorted order.
+func withRequiredFields(untested []string, flattenedSchema map[string]*schema.Schema) []string {
+	fields := make(map[string]bool)
+	blocks := map[string]bool{"": true}
+	for _, field := range untested {
+		fields[field] = true
+		for i := strings.LastIndex(field, "."); i >= 0; i = strings.LastIndex(field[:i], ".") {
+			blocks[field[:i]] = true
+		}
+	}
+	for added := true; added; {
+		added = false
+		for field, fieldSchema := range flattenedSchema {
+			parent := ""
+			if i := strings.LastIndex(field, "."); i >= 0 {
+				parent = field[:i]
+			}
+			if !fieldSchema.Required || !blocks[parent] || fields[field] {
+				continue
+			}
+			fields[field] = true
+			added = true
+			if isBlock(fieldSchema) {
+				blocks[field] = true
+			}
+		}
+	}
+	sorted := make([]string, 0, len(fields))
+	for field := range fields {
+		sorted = append(sorted, field)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
+
+func isBlock(fieldSchema *schema.Schema) bool {
+	if fieldSchema == nil {
+		return false
+	}
+	_, ok := fieldSchema.Elem.(*schema.Resource)
+	return ok
+}
+
+// placeholderValue returns an example value of the field's type. Unknown types get a
+// sentinel value that suggestedTest replaces with a comment.
+func placeholderValue(name string, fieldSchema *schema.Schema) cty.Value {
+	if fieldSchema == nil {
+		return cty.StringVal("VALUE")
+	}
+	switch fieldSchema.Type {
+	case schema.TypeBool:
+		// Use the non-default value so the test exercises the field.
+		return cty.BoolVal(fieldSchema.Default != true)
+	case schema.TypeInt:
+		return cty.NumberIntVal(1)
+	case schema.TypeFloat:
+		return cty.NumberFloatVal(1.5)
+	case schema.TypeString:
+		return cty.StringVal("test-" + strings.ReplaceAll(name, "_", "-"))
+	case schema.TypeList, schema.TypeSet:
+		if elem, ok := fieldSchema.Elem.(*schema.Schema); ok {
+			return cty.TupleVal([]cty.Value{placeholderValue(name, elem)})
+		}
+	case schema.TypeMap:
+		elem, _ := fieldSchema.Elem.(*schema.Schema)
+		if elem == nil {
+			elem = &schema.Schema{Type: schema.TypeString}
+		}
+		return cty.ObjectVal(map[string]cty.Value{"key": placeholderValue("value", elem)})
+	}
+	return cty.StringVal("VALUE")
+}
+
 // DetectMissingDocs detect new fields that are missing docs given the schema diffs.
 // Return a map of resource names to missing doc info.
 // It parses the document to see if the field is present within the resource document file,
@@ -173,7 +173,7 @@ func TestGetMissingTestsForChanges(t *testing.T) {
 			},
 		},
 	} {
-		missingTests, err := getMissingTestsForChanges(test.changedFields, allTests)
+		missingTests, err := getMissingTestsForChanges(test.changedFields, allTests, nil)
 		if err != nil {
 			t.Errorf("error detecting missing tests for %s: %s", test.name, err)
 		}
@@ -204,6 +204,106 @@ func TestGetMissingTestsForChanges(t *testing.T) {
 	}
 }
 
+func TestDetectMissingTestsSuggestedTest(t *testing.T) {
+	oldResourceMap := map[string]*schema.Resource{
+		"google_x": {
+			Schema: map[string]*schema.Schema{
+				"name": {Type: schema.TypeString, Required: true},
+			},
+		},
+	}
+	newResourceMap := map[string]*schema.Resource{
+		"google_x": {
+			Schema: map[string]*schema.Schema{
+				"name":         {Type: schema.TypeString, Required: true},
+				"display_name": {Type: schema.TypeString, Optional: true},
+				"enabled":      {Type: schema.TypeBool, Optional: true, Default: true},
+				"size":         {Type: schema.TypeInt, Optional: true},
+				"ratio":        {Type: schema.TypeFloat, Optional: true},
+				"tags":         {Type: schema.TypeSet, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}},
+				"labels":       {Type: schema.TypeMap, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}},
+				"config": {
+					Type:     schema.TypeList,
+					Optional: true,
+					Elem: &schema.Resource{Schema: map[string]*schema.Schema{
+						"mode":    {Type: schema.TypeString, Required: true},
+						"timeout": {Type: schema.TypeInt, Optional: true},
+						"other":   {Type: schema.TypeString, Optional: true},
+					}},
+				},
+				"network": {
+					Type:     schema.TypeList,
+					Required: true,
+					Elem: &schema.Resource{Schema: map[string]*schema.Schema{
+						"subnet": {Type: schema.TypeString, Optional: true},
+					}},
+				},
+			},
+		},
+	}
+	missingTests, err := DetectMissingTests(diff.ComputeSchemaDiff(oldResourceMap, newResourceMap), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `resource "google_x" "primary" {
+  config {
+    mode    = "test-mode"
+    other   = "test-other"
+    timeout = 1
+  }
+  display_name = "test-display-name"
+  enabled      = false
+  labels = {
+    key = "test-value"
+  }
+  name = "test-name"
+  network {
+    subnet = "test-subnet"
+  }
+  ratio = 1.5
+  size  = 1
+  tags  = ["test-tags"]
+}
+`
+	if missingTests["google_x"] == nil {
+		t.Fatalf("expected missing tests for google_x, got %v", missingTests)
+	}
+	if diff := cmp.Diff(want, missingTests["google_x"].SuggestedTest); diff != "" {
+		t.Errorf("unexpected suggested test (-want, +got):\n%s", diff)
+	}
+}
+
+func TestDetectBetaOnlyTests(t *testing.T) {
+	allTests, errs := reader.ReadAllTests("../../test-reader/reader/testdata")
+	if len(errs) > 0 {
+		t.Errorf("errors reading tests before testing detect beta only tests: %v", errs)
+	}
+	schemaDiff := diff.SchemaDiff{
+		"beta_resource": diff.ResourceDiff{
+			Fields: map[string]diff.FieldDiff{
+				// Set in GA and beta tests.
+				"field_one": {New: &schema.Schema{Optional: true}},
+				// Only set in a test using the beta provider factories.
+				"field_two": {New: &schema.Schema{Optional: true}},
+				// Not set in any test.
+				"field_three": {New: &schema.Schema{Optional: true}},
+			},
+		},
+		"covered_resource": diff.ResourceDiff{
+			Fields: map[string]diff.FieldDiff{
+				"field_one": {New: &schema.Schema{Optional: true}},
+			},
+		},
+	}
+	betaOnly, err := DetectBetaOnlyTests(schemaDiff, allTests)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(map[string][]string{"beta_resource": {"field_two"}}, betaOnly); diff != "" {
+		t.Errorf("unexpected beta only fields (-want, +got):\n%s", diff)
+	}
+}
+
 func TestDetectMissingDocs(t *testing.T) {
 	// If repo is not temp dir, then the doc file points to tools/diff-processor/testdata/website/docs/r/a_resource.html.markdown.
 	for _, test := range []struct {
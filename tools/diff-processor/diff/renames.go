@@ -0,0 +1,205 @@
+package diff
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// RenameConfidence is how likely a FieldRename is to be a real rename.
+type RenameConfidence string
+
+const (
+	RenameConfidenceHigh   RenameConfidence = "high"
+	RenameConfidenceMedium RenameConfidence = "medium"
+	RenameConfidenceLow    RenameConfidence = "low"
+)
+
+// FieldRename is a removed field that was probably renamed to an added field.
+type FieldRename struct {
+	Old        string
+	New        string
+	Confidence RenameConfidence
+}
+
+// ProbableRenames pairs up fields removed from a resource with fields added in the same block
+// that have the same type, and returns the pairs that are likely to be renames. Each field is
+// in at most one pair. The result is sorted by the old field path.
+func ProbableRenames(resourceDiff ResourceDiff) []FieldRename {
+	removed := make(map[string][]string)
+	added := make(map[string][]string)
+	for field, fieldDiff := range resourceDiff.Fields {
+		parent := parentPath(field)
+		if !existsInBoth(parent, resourceDiff) {
+			// Fields in a renamed block are covered by the block's rename.
+			continue
+		}
+		switch {
+		case fieldDiff.New == nil:
+			removed[parent] = append(removed[parent], field)
+		case fieldDiff.Old == nil:
+			added[parent] = append(added[parent], field)
+		}
+	}
+
+	type candidate struct {
+		old, new string
+		score    int
+	}
+	var candidates []candidate
+	for parent, removedFields := range removed {
+		for _, oldField := range removedFields {
+			for _, newField := range added[parent] {
+				oldSchema, newSchema := resourceDiff.Fields[oldField].Old, resourceDiff.Fields[newField].New
+				if !sameType(oldSchema, newSchema) {
+					continue
+				}
+				score := renameScore(oldField, newField, oldSchema, newSchema)
+				if onlyCandidate(oldSchema, removedFields, added[parent], resourceDiff) {
+					score++
+				}
+				if score >= minRenameScore {
+					candidates = append(candidates, candidate{old: oldField, new: newField, score: score})
+				}
+			}
+		}
+	}
+	// Pair the strongest candidates first; break ties by path so the result is deterministic.
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].score != candidates[j].score {
+			return candidates[i].score > candidates[j].score
+		}
+		if candidates[i].old != candidates[j].old {
+			return candidates[i].old < candidates[j].old
+		}
+		return candidates[i].new < candidates[j].new
+	})
+
+	var renames []FieldRename
+	pairedOld, pairedNew := make(map[string]bool), make(map[string]bool)
+	for _, c := range candidates {
+		if pairedOld[c.old] || pairedNew[c.new] {
+			continue
+		}
+		pairedOld[c.old] = true
+		pairedNew[c.new] = true
+		renames = append(renames, FieldRename{Old: c.old, New: c.new, Confidence: renameConfidence(c.score)})
+	}
+	sort.Slice(renames, func(i, j int) bool { return renames[i].Old < renames[j].Old })
+	return renames
+}
+
+// minRenameScore is the lowest score reported as a rename. Fields that only share a type and
+// attributes, in a block where several fields of that type changed, aren't reported.
+const minRenameScore = 2
+
+// renameScore scores how similar two fields of the same type are.
+func renameScore(oldField, newField string, oldSchema, newSchema *schema.Schema) int {
+	score := 0
+	if oldSchema.Description != "" && oldSchema.Description == newSchema.Description {
+		score += 2
+	}
+	if namesOverlap(leafName(oldField), leafName(newField)) {
+		score++
+	}
+	if oldSchema.Required == newSchema.Required && oldSchema.Optional == newSchema.Optional &&
+		oldSchema.Computed == newSchema.Computed && oldSchema.ForceNew == newSchema.ForceNew &&
+		oldSchema.Sensitive == newSchema.Sensitive {
+		score++
+	}
+	return score
+}
+
+func renameConfidence(score int) RenameConfidence {
+	switch {
+	case score >= 4:
+		return RenameConfidenceHigh
+	case score == 3:
+		return RenameConfidenceMedium
+	}
+	return RenameConfidenceLow
+}
+
+// onlyCandidate returns true if the removed field is the only removed field of its type in its
+// block, and exactly one field of that type was added there.
+func onlyCandidate(oldSchema *schema.Schema, removedFields, addedFields []string, resourceDiff ResourceDiff) bool {
+	removedCount, addedCount := 0, 0
+	for _, field := range removedFields {
+		if sameType(oldSchema, resourceDiff.Fields[field].Old) {
+			removedCount++
+		}
+	}
+	for _, field := range addedFields {
+		if sameType(oldSchema, resourceDiff.Fields[field].New) {
+			addedCount++
+		}
+	}
+	return removedCount == 1 && addedCount == 1
+}
+
+// sameType returns true if the fields have the same type and element type. Nested blocks are
+// compared by the names and types of their fields.
+func sameType(a, b *schema.Schema) bool {
+	if a.Type != b.Type {
+		return false
+	}
+	switch aElem := a.Elem.(type) {
+	case *schema.Schema:
+		bElem, ok := b.Elem.(*schema.Schema)
+		return ok && sameType(aElem, bElem)
+	case *schema.Resource:
+		bElem, ok := b.Elem.(*schema.Resource)
+		if !ok || len(aElem.Schema) != len(bElem.Schema) {
+			return false
+		}
+		for name, aField := range aElem.Schema {
+			bField, ok := bElem.Schema[name]
+			if !ok || !sameType(aField, bField) {
+				return false
+			}
+		}
+		return true
+	}
+	return reflect.TypeOf(a.Elem) == reflect.TypeOf(b.Elem)
+}
+
+// namesOverlap returns true if the field names share a word, or one contains the other.
+func namesOverlap(a, b string) bool {
+	if strings.Contains(a, b) || strings.Contains(b, a) {
+		return true
+	}
+	words := make(map[string]bool)
+	for _, word := range strings.Split(a, "_") {
+		words[word] = true
+	}
+	for _, word := range strings.Split(b, "_") {
+		if words[word] {
+			return true
+		}
+	}
+	return false
+}
+
+func existsInBoth(path string, resourceDiff ResourceDiff) bool {
+	if path == "" {
+		return true
+	}
+	if fieldDiff, ok := resourceDiff.Fields[path]; ok {
+		return fieldDiff.Old != nil && fieldDiff.New != nil
+	}
+	// Unchanged fields aren't in Fields.
+	return true
+}
+
+func parentPath(field string) string {
+	if i := strings.LastIndex(field, "."); i >= 0 {
+		return field[:i]
+	}
+	return ""
+}
+
+func leafName(field string) string {
+	return field[strings.LastIndex(field, ".")+1:]
+}
@@ -0,0 +1,132 @@
+package diff
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestProbableRenames(t *testing.T) {
+	cases := []struct {
+		name      string
+		oldSchema map[string]*schema.Schema
+		newSchema map[string]*schema.Schema
+		want      []FieldRename
+	}{
+		{
+			name: "no changes",
+			oldSchema: map[string]*schema.Schema{
+				"name": {Type: schema.TypeString, Required: true},
+			},
+			newSchema: map[string]*schema.Schema{
+				"name": {Type: schema.TypeString, Required: true},
+			},
+		},
+		{
+			name: "same description and similar name",
+			oldSchema: map[string]*schema.Schema{
+				"node_count": {Type: schema.TypeInt, Optional: true, Description: "The number of nodes."},
+			},
+			newSchema: map[string]*schema.Schema{
+				"initial_node_count": {Type: schema.TypeInt, Optional: true, Description: "The number of nodes."},
+			},
+			want: []FieldRename{{Old: "node_count", New: "initial_node_count", Confidence: RenameConfidenceHigh}},
+		},
+		{
+			name: "only field of its type with a similar name",
+			oldSchema: map[string]*schema.Schema{
+				"node_count": {Type: schema.TypeInt, Optional: true},
+			},
+			newSchema: map[string]*schema.Schema{
+				"total_node_count": {Type: schema.TypeInt, Optional: true},
+			},
+			want: []FieldRename{{Old: "node_count", New: "total_node_count", Confidence: RenameConfidenceMedium}},
+		},
+		{
+			name: "only field of its type with a different name",
+			oldSchema: map[string]*schema.Schema{
+				"size": {Type: schema.TypeInt, Optional: true},
+			},
+			newSchema: map[string]*schema.Schema{
+				"capacity": {Type: schema.TypeInt, Optional: true},
+			},
+			want: []FieldRename{{Old: "size", New: "capacity", Confidence: RenameConfidenceLow}},
+		},
+		{
+			name: "different type",
+			oldSchema: map[string]*schema.Schema{
+				"node_count": {Type: schema.TypeInt, Optional: true, Description: "The number of nodes."},
+			},
+			newSchema: map[string]*schema.Schema{
+				"node_counts": {Type: schema.TypeString, Optional: true, Description: "The number of nodes."},
+			},
+		},
+		{
+			name: "several unrelated fields of the same type",
+			oldSchema: map[string]*schema.Schema{
+				"zone":   {Type: schema.TypeString, Optional: true},
+				"region": {Type: schema.TypeString, Optional: true},
+			},
+			newSchema: map[string]*schema.Schema{
+				"network":    {Type: schema.TypeString, Optional: true},
+				"subnetwork": {Type: schema.TypeString, Optional: true},
+			},
+		},
+		{
+			name: "each field is paired once, strongest first",
+			oldSchema: map[string]*schema.Schema{
+				"display_name": {Type: schema.TypeString, Optional: true, Description: "The display name."},
+				"description":  {Type: schema.TypeString, Optional: true, Description: "A description."},
+			},
+			newSchema: map[string]*schema.Schema{
+				"friendly_name":    {Type: schema.TypeString, Optional: true, Description: "The display name."},
+				"long_description": {Type: schema.TypeString, Optional: true},
+				"display_title":    {Type: schema.TypeString, Optional: true},
+			},
+			want: []FieldRename{
+				{Old: "description", New: "long_description", Confidence: RenameConfidenceLow},
+				{Old: "display_name", New: "friendly_name", Confidence: RenameConfidenceHigh},
+			},
+		},
+		{
+			name: "nested field in an existing block",
+			oldSchema: map[string]*schema.Schema{
+				"config": {Type: schema.TypeList, Optional: true, Elem: &schema.Resource{Schema: map[string]*schema.Schema{
+					"disk_size": {Type: schema.TypeInt, Optional: true},
+				}}},
+			},
+			newSchema: map[string]*schema.Schema{
+				"config": {Type: schema.TypeList, Optional: true, Elem: &schema.Resource{Schema: map[string]*schema.Schema{
+					"disk_size_gb": {Type: schema.TypeInt, Optional: true},
+				}}},
+			},
+			want: []FieldRename{{Old: "config.disk_size", New: "config.disk_size_gb", Confidence: RenameConfidenceMedium}},
+		},
+		{
+			name: "renamed block",
+			oldSchema: map[string]*schema.Schema{
+				"settings": {Type: schema.TypeList, Optional: true, Elem: &schema.Resource{Schema: map[string]*schema.Schema{
+					"tier": {Type: schema.TypeString, Optional: true},
+				}}},
+			},
+			newSchema: map[string]*schema.Schema{
+				"instance_settings": {Type: schema.TypeList, Optional: true, Elem: &schema.Resource{Schema: map[string]*schema.Schema{
+					"tier": {Type: schema.TypeString, Optional: true},
+				}}},
+			},
+			want: []FieldRename{{Old: "settings", New: "instance_settings", Confidence: RenameConfidenceMedium}},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			schemaDiff := ComputeSchemaDiff(
+				map[string]*schema.Resource{"google_x": {Schema: tc.oldSchema}},
+				map[string]*schema.Resource{"google_x": {Schema: tc.newSchema}},
+			)
+			if d := cmp.Diff(tc.want, ProbableRenames(schemaDiff["google_x"])); d != "" {
+				t.Errorf("ProbableRenames() diff (-want, +got):\n%s", d)
+			}
+		})
+	}
+}
@@ -0,0 +1,212 @@
+package diff
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ReportVersion identifies the format of Report. It is incremented whenever a field is removed
+// or changes meaning. New fields may be added without incrementing it, so consumers should
+// ignore fields they don't recognize.
+//
+// Version 1 has since gained renamed_fields on each modified resource.
+const ReportVersion = 1
+
+// Report is the stable JSON representation of the schema diff between two provider builds.
+// Lists are always present (empty rather than null) and sorted.
+type Report struct {
+	Version     int        `json:"version"`
+	Resources   KindReport `json:"resources"`
+	DataSources KindReport `json:"data_sources"`
+}
+
+// KindReport summarizes the changes to either resources or data sources.
+type KindReport struct {
+	Added    []string         `json:"added"`
+	Removed  []string         `json:"removed"`
+	Modified []ResourceReport `json:"modified"`
+}
+
+// ResourceReport lists the field changes within a resource or data source that exists in both
+// builds. Nested fields are included separately, with paths like `parent.child`.
+type ResourceReport struct {
+	Name          string              `json:"name"`
+	AddedFields   []FieldReport       `json:"added_fields"`
+	RemovedFields []FieldReport       `json:"removed_fields"`
+	ChangedFields []FieldChangeReport `json:"changed_fields"`
+	// RenamedFields pairs removed and added fields that are probably renames. The fields are
+	// still listed in RemovedFields and AddedFields, since a rename is breaking either way.
+	RenamedFields []FieldRenameReport `json:"renamed_fields"`
+}
+
+// FieldReport is a field that was added or removed.
+type FieldReport struct {
+	Path       string          `json:"path"`
+	Attributes FieldAttributes `json:"attributes"`
+}
+
+// FieldChangeReport is a field that exists in both builds with different schemas.
+type FieldChangeReport struct {
+	Path string          `json:"path"`
+	Old  FieldAttributes `json:"old"`
+	New  FieldAttributes `json:"new"`
+	// ChangedAttributes lists the json names of the attributes that differ, or "other" if the
+	// change is to something FieldAttributes doesn't capture, like validation or conflicts.
+	ChangedAttributes []string `json:"changed_attributes"`
+}
+
+// FieldRenameReport is a removed field that was probably renamed to an added field.
+type FieldRenameReport struct {
+	OldPath    string           `json:"old_path"`
+	NewPath    string           `json:"new_path"`
+	Confidence RenameConfidence `json:"confidence"`
+}
+
+// FieldAttributes are the parts of a field's schema that are included in the report.
+type FieldAttributes struct {
+	Type        string `json:"type"`
+	ElemType    string `json:"elem_type,omitempty"`
+	Required    bool   `json:"required"`
+	Optional    bool   `json:"optional"`
+	Computed    bool   `json:"computed"`
+	ForceNew    bool   `json:"force_new"`
+	Sensitive   bool   `json:"sensitive"`
+	Default     any    `json:"default,omitempty"`
+	MinItems    int    `json:"min_items,omitempty"`
+	MaxItems    int    `json:"max_items,omitempty"`
+	Deprecated  string `json:"deprecated,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// NewReport builds a Report from the schema diffs of resources and data sources.
+func NewReport(resourceDiff, dataSourceDiff SchemaDiff) Report {
+	return Report{
+		Version:     ReportVersion,
+		Resources:   newKindReport(resourceDiff),
+		DataSources: newKindReport(dataSourceDiff),
+	}
+}
+
+func newKindReport(schemaDiff SchemaDiff) KindReport {
+	report := KindReport{
+		Added:    []string{},
+		Removed:  []string{},
+		Modified: []ResourceReport{},
+	}
+	for name, resourceDiff := range schemaDiff {
+		switch {
+		case resourceDiff.ResourceConfig.Old == nil:
+			report.Added = append(report.Added, name)
+		case resourceDiff.ResourceConfig.New == nil:
+			report.Removed = append(report.Removed, name)
+		default:
+			report.Modified = append(report.Modified, newResourceReport(name, resourceDiff))
+		}
+	}
+	sort.Strings(report.Added)
+	sort.Strings(report.Removed)
+	sort.Slice(report.Modified, func(i, j int) bool {
+		return report.Modified[i].Name < report.Modified[j].Name
+	})
+	return report
+}
+
+func newResourceReport(name string, resourceDiff ResourceDiff) ResourceReport {
+	report := ResourceReport{
+		Name:          name,
+		AddedFields:   []FieldReport{},
+		RemovedFields: []FieldReport{},
+		ChangedFields: []FieldChangeReport{},
+		RenamedFields: []FieldRenameReport{},
+	}
+	for _, rename := range ProbableRenames(resourceDiff) {
+		report.RenamedFields = append(report.RenamedFields, FieldRenameReport{OldPath: rename.Old, NewPath: rename.New, Confidence: rename.Confidence})
+	}
+	for path, fieldDiff := range resourceDiff.Fields {
+		switch {
+		case fieldDiff.Old == nil:
+			report.AddedFields = append(report.AddedFields, FieldReport{Path: path, Attributes: fieldAttributes(fieldDiff.New)})
+		case fieldDiff.New == nil:
+			report.RemovedFields = append(report.RemovedFields, FieldReport{Path: path, Attributes: fieldAttributes(fieldDiff.Old)})
+		default:
+			oldAttributes, newAttributes := fieldAttributes(fieldDiff.Old), fieldAttributes(fieldDiff.New)
+			report.ChangedFields = append(report.ChangedFields, FieldChangeReport{
+				Path:              path,
+				Old:               oldAttributes,
+				New:               newAttributes,
+				ChangedAttributes: changedAttributes(oldAttributes, newAttributes),
+			})
+		}
+	}
+	sort.Slice(report.AddedFields, func(i, j int) bool { return report.AddedFields[i].Path < report.AddedFields[j].Path })
+	sort.Slice(report.RemovedFields, func(i, j int) bool { return report.RemovedFields[i].Path < report.RemovedFields[j].Path })
+	sort.Slice(report.ChangedFields, func(i, j int) bool { return report.ChangedFields[i].Path < report.ChangedFields[j].Path })
+	return report
+}
+
+func fieldAttributes(field *schema.Schema) FieldAttributes {
+	attributes := FieldAttributes{
+		Type:        valueTypeName(field.Type),
+		Required:    field.Required,
+		Optional:    field.Optional,
+		Computed:    field.Computed,
+		ForceNew:    field.ForceNew,
+		Sensitive:   field.Sensitive,
+		Default:     field.Default,
+		MinItems:    field.MinItems,
+		MaxItems:    field.MaxItems,
+		Deprecated:  field.Deprecated,
+		Description: field.Description,
+	}
+	switch elem := field.Elem.(type) {
+	case *schema.Schema:
+		attributes.ElemType = valueTypeName(elem.Type)
+	case *schema.Resource:
+		attributes.ElemType = "object"
+	}
+	return attributes
+}
+
+func valueTypeName(valueType schema.ValueType) string {
+	switch valueType {
+	case schema.TypeBool:
+		return "bool"
+	case schema.TypeInt:
+		return "int"
+	case schema.TypeFloat:
+		return "float"
+	case schema.TypeString:
+		return "string"
+	case schema.TypeList:
+		return "list"
+	case schema.TypeMap:
+		return "map"
+	case schema.TypeSet:
+		return "set"
+	}
+	return "invalid"
+}
+
+// changedAttributes returns the json names of the attributes that differ between old and new.
+func changedAttributes(old, new FieldAttributes) []string {
+	var changed []string
+	oldValue, newValue := reflect.ValueOf(old), reflect.ValueOf(new)
+	attributesType := oldValue.Type()
+	for i := 0; i < attributesType.NumField(); i++ {
+		if !reflect.DeepEqual(oldValue.Field(i).Interface(), newValue.Field(i).Interface()) {
+			changed = append(changed, jsonName(attributesType.Field(i)))
+		}
+	}
+	if len(changed) == 0 {
+		return []string{"other"}
+	}
+	return changed
+}
+
+func jsonName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	return name
+}
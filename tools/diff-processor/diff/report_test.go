@@ -0,0 +1,145 @@
+package diff
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestNewReport(t *testing.T) {
+	oldResources := map[string]*schema.Resource{
+		"google_x_resource": {
+			Schema: map[string]*schema.Schema{
+				"field_a": {Type: schema.TypeString, Optional: true},
+				"field_b": {Type: schema.TypeString, Optional: true},
+				"field_c": {Type: schema.TypeInt, Optional: true},
+				"field_e": {Type: schema.TypeBool, Optional: true, Description: "Whether it's enabled."},
+				"block": {
+					Type:     schema.TypeList,
+					Optional: true,
+					Elem: &schema.Resource{Schema: map[string]*schema.Schema{
+						"name": {Type: schema.TypeString, Required: true},
+					}},
+				},
+			},
+		},
+		"google_z_resource": {
+			Schema: map[string]*schema.Schema{
+				"field_a": {Type: schema.TypeString, Optional: true},
+			},
+		},
+		"google_unchanged_resource": {
+			Schema: map[string]*schema.Schema{
+				"field_a": {Type: schema.TypeString, Optional: true},
+			},
+		},
+	}
+	newResources := map[string]*schema.Resource{
+		"google_x_resource": {
+			Schema: map[string]*schema.Schema{
+				"field_a":       {Type: schema.TypeString, Required: true, ForceNew: true},
+				"field_c":       {Type: schema.TypeInt, Optional: true, ValidateFunc: func(interface{}, string) ([]string, []error) { return nil, nil }},
+				"field_enabled": {Type: schema.TypeBool, Optional: true, Description: "Whether it's enabled."},
+				"block": {
+					Type:     schema.TypeList,
+					Optional: true,
+					Elem: &schema.Resource{Schema: map[string]*schema.Schema{
+						"name":  {Type: schema.TypeString, Required: true},
+						"value": {Type: schema.TypeSet, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}},
+					}},
+				},
+			},
+		},
+		"google_y_resource": {
+			Schema: map[string]*schema.Schema{
+				"field_a": {Type: schema.TypeString, Optional: true},
+			},
+		},
+		"google_unchanged_resource": {
+			Schema: map[string]*schema.Schema{
+				"field_a": {Type: schema.TypeString, Optional: true},
+			},
+		},
+	}
+	oldDataSources := map[string]*schema.Resource{}
+	newDataSources := map[string]*schema.Resource{
+		"google_x_resources": {
+			Schema: map[string]*schema.Schema{
+				"filter": {Type: schema.TypeString, Optional: true},
+			},
+		},
+	}
+
+	got := NewReport(ComputeSchemaDiff(oldResources, newResources), ComputeSchemaDiff(oldDataSources, newDataSources))
+	want := Report{
+		Version: ReportVersion,
+		Resources: KindReport{
+			Added:   []string{"google_y_resource"},
+			Removed: []string{"google_z_resource"},
+			Modified: []ResourceReport{
+				{
+					Name: "google_x_resource",
+					AddedFields: []FieldReport{
+						{Path: "block.value", Attributes: FieldAttributes{Type: "set", ElemType: "string", Optional: true}},
+						{Path: "field_enabled", Attributes: FieldAttributes{Type: "bool", Optional: true, Description: "Whether it's enabled."}},
+					},
+					RemovedFields: []FieldReport{
+						{Path: "field_b", Attributes: FieldAttributes{Type: "string", Optional: true}},
+						{Path: "field_e", Attributes: FieldAttributes{Type: "bool", Optional: true, Description: "Whether it's enabled."}},
+					},
+					ChangedFields: []FieldChangeReport{
+						{
+							Path:              "field_a",
+							Old:               FieldAttributes{Type: "string", Optional: true},
+							New:               FieldAttributes{Type: "string", Required: true, ForceNew: true},
+							ChangedAttributes: []string{"required", "optional", "force_new"},
+						},
+						{
+							Path:              "field_c",
+							Old:               FieldAttributes{Type: "int", Optional: true},
+							New:               FieldAttributes{Type: "int", Optional: true},
+							ChangedAttributes: []string{"other"},
+						},
+					},
+					RenamedFields: []FieldRenameReport{
+						{OldPath: "field_e", NewPath: "field_enabled", Confidence: RenameConfidenceHigh},
+					},
+				},
+			},
+		},
+		DataSources: KindReport{
+			Added:    []string{"google_x_resources"},
+			Removed:  []string{},
+			Modified: []ResourceReport{},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("NewReport() diff (-want, +got):\n%s", diff)
+	}
+}
+
+func TestReportJSON(t *testing.T) {
+	report := NewReport(SchemaDiff{}, SchemaDiff{})
+	out, err := json.Marshal(report)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"version":1,` +
+		`"resources":{"added":[],"removed":[],"modified":[]},` +
+		`"data_sources":{"added":[],"removed":[],"modified":[]}}`
+	if string(out) != want {
+		t.Errorf("unexpected JSON for an empty report.\nwant: %s\ngot:  %s", want, out)
+	}
+
+	attributes, err := json.Marshal(FieldAttributes{Type: "list", ElemType: "object", Optional: true, MaxItems: 1, Default: "x"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = `{"type":"list","elem_type":"object","required":false,"optional":true,"computed":false,` +
+		`"force_new":false,"sensitive":false,"default":"x","max_items":1}`
+	if string(attributes) != want {
+		t.Errorf("unexpected JSON for field attributes.\nwant: %s\ngot:  %s", want, attributes)
+	}
+}
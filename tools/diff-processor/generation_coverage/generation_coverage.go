@@ -0,0 +1,110 @@
+package generation_coverage
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"gopkg.in/yaml.v3"
+)
+
+// Generation types recorded in resource metadata files.
+const (
+	MMv1        = "mmv1"
+	DCL         = "dcl"
+	Handwritten = "handwritten"
+	// Unknown is used for resources without a metadata file.
+	Unknown = "unknown"
+)
+
+// Metadata is the subset of a resource's metadata file (*_meta.yaml) used to compute coverage.
+type Metadata struct {
+	Resource       string `yaml:"resource"`
+	GenerationType string `yaml:"generation_type"`
+	ApiServiceName string `yaml:"api_service_name"`
+	// Service package the metadata file is in, like compute.
+	Service string `yaml:"-"`
+}
+
+// Report lists where each of the provider's resources is generated from.
+type Report struct {
+	// Number of resources per generation type.
+	Totals map[string]int `json:"totals"`
+	// Coverage per service package, sorted by service.
+	Services []ServiceCoverage `json:"services"`
+}
+
+// ServiceCoverage lists the resources of a service package per generation type.
+type ServiceCoverage struct {
+	Service   string              `json:"service"`
+	Resources map[string][]string `json:"resources"`
+}
+
+// ReadMetadata reads the resource metadata files in servicesDir, like google-beta/services in a
+// downstream provider, keyed by resource name.
+func ReadMetadata(servicesDir string) (map[string]Metadata, error) {
+	metadata := make(map[string]Metadata)
+	err := filepath.WalkDir(servicesDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(d.Name(), "_meta.yaml") {
+			return nil
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		var m Metadata
+		if err := yaml.Unmarshal(content, &m); err != nil {
+			return fmt.Errorf("error parsing %s: %w", path, err)
+		}
+		if m.Resource == "" {
+			return nil
+		}
+		m.Service = filepath.Base(filepath.Dir(path))
+		metadata[m.Resource] = m
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error reading metadata from %s: %w", servicesDir, err)
+	}
+	return metadata, nil
+}
+
+// ComputeReport groups the resources in resourceMap by service and generation type, using
+// their metadata. Resources without metadata are reported as Unknown, in an "unknown" service.
+func ComputeReport(resourceMap map[string]*schema.Resource, metadata map[string]Metadata) Report {
+	report := Report{Totals: make(map[string]int)}
+	services := make(map[string]map[string][]string)
+	for name := range resourceMap {
+		m, ok := metadata[name]
+		generationType, service := m.GenerationType, m.Service
+		if !ok || generationType == "" {
+			generationType = Unknown
+		}
+		if service == "" {
+			service = Unknown
+		}
+		report.Totals[generationType]++
+		if services[service] == nil {
+			services[service] = make(map[string][]string)
+		}
+		services[service][generationType] = append(services[service][generationType], name)
+	}
+
+	for service, resources := range services {
+		for _, names := range resources {
+			sort.Strings(names)
+		}
+		report.Services = append(report.Services, ServiceCoverage{Service: service, Resources: resources})
+	}
+	sort.Slice(report.Services, func(i, j int) bool {
+		return report.Services[i].Service < report.Services[j].Service
+	})
+	return report
+}
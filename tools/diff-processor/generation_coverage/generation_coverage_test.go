@@ -0,0 +1,75 @@
+package generation_coverage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestReadMetadata(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"compute/resource_compute_instance_meta.yaml":          "resource: 'google_compute_instance'\ngeneration_type: 'handwritten'\napi_service_name: 'compute.googleapis.com'\nfields:\n  - api_field: 'name'\n",
+		"compute/resource_compute_address_meta.yaml":           "resource: 'google_compute_address'\ngeneration_type: 'mmv1'\n",
+		"compute/resource_compute_address.go":                  "package compute\n",
+		"cloudbuild/resource_cloudbuild_worker_pool_meta.yaml": "resource: 'google_cloudbuild_worker_pool'\ngeneration_type: 'dcl'\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got, err := ReadMetadata(dir)
+	if err != nil {
+		t.Fatalf("ReadMetadata() returned error: %s", err)
+	}
+	want := map[string]Metadata{
+		"google_compute_instance":       {Resource: "google_compute_instance", GenerationType: Handwritten, ApiServiceName: "compute.googleapis.com", Service: "compute"},
+		"google_compute_address":        {Resource: "google_compute_address", GenerationType: MMv1, Service: "compute"},
+		"google_cloudbuild_worker_pool": {Resource: "google_cloudbuild_worker_pool", GenerationType: DCL, Service: "cloudbuild"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ReadMetadata() returned unexpected metadata (-want +got):\n%s", diff)
+	}
+}
+
+func TestComputeReport(t *testing.T) {
+	resourceMap := map[string]*schema.Resource{
+		"google_compute_instance":       {},
+		"google_compute_address":        {},
+		"google_compute_disk":           {},
+		"google_cloudbuild_worker_pool": {},
+		"google_no_metadata":            {},
+	}
+	metadata := map[string]Metadata{
+		"google_compute_instance":       {Resource: "google_compute_instance", GenerationType: Handwritten, Service: "compute"},
+		"google_compute_address":        {Resource: "google_compute_address", GenerationType: MMv1, Service: "compute"},
+		"google_compute_disk":           {Resource: "google_compute_disk", GenerationType: MMv1, Service: "compute"},
+		"google_cloudbuild_worker_pool": {Resource: "google_cloudbuild_worker_pool", GenerationType: DCL, Service: "cloudbuild"},
+		"google_not_in_provider":        {Resource: "google_not_in_provider", GenerationType: MMv1, Service: "other"},
+	}
+
+	got := ComputeReport(resourceMap, metadata)
+	want := Report{
+		Totals: map[string]int{MMv1: 2, DCL: 1, Handwritten: 1, Unknown: 1},
+		Services: []ServiceCoverage{
+			{Service: "cloudbuild", Resources: map[string][]string{DCL: {"google_cloudbuild_worker_pool"}}},
+			{Service: "compute", Resources: map[string][]string{
+				MMv1:        {"google_compute_address", "google_compute_disk"},
+				Handwritten: {"google_compute_instance"},
+			}},
+			{Service: Unknown, Resources: map[string][]string{Unknown: {"google_no_metadata"}}},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ComputeReport() returned unexpected report (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,70 @@
+package release_notes
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/magic-modules/tools/diff-processor/breaking_changes"
+	"github.com/GoogleCloudPlatform/magic-modules/tools/diff-processor/diff"
+)
+
+// ChangelogEntries returns candidate .changelog entries for the changes in the report: new
+// resources, data sources and fields, fields that became updatable or optional, fixed permadiffs
+// and deprecations, plus a breaking-change entry for each breaking change. Entries describe what
+// changed in the schema, not why, so authors should review them before using them.
+func ChangelogEntries(report diff.Report, breakingChanges []breaking_changes.BreakingChange) []ReleaseNote {
+	notes := NewFeatureNotes(report)
+	notes = append(notes, changedFieldNotes(report.Resources.Modified, "resource")...)
+	notes = append(notes, changedFieldNotes(report.DataSources.Modified, "data source")...)
+
+	var breaking []ReleaseNote
+	for _, change := range breakingChanges {
+		breaking = append(breaking, ReleaseNote{
+			Type: "breaking-change",
+			Text: fmt.Sprintf("%s: %s", service(change.Resource), change.Message),
+		})
+	}
+	sort.Slice(breaking, func(i, j int) bool { return breaking[i].Text < breaking[j].Text })
+	return append(notes, breaking...)
+}
+
+func changedFieldNotes(modified []diff.ResourceReport, kind string) []ReleaseNote {
+	var notes []ReleaseNote
+	for _, resource := range modified {
+		for _, field := range resource.ChangedFields {
+			target := fmt.Sprintf("`%s` field in `%s` %s", field.Path, resource.Name, kind)
+			changed := func(attribute string) bool {
+				return slices.Contains(field.ChangedAttributes, attribute)
+			}
+			var note ReleaseNote
+			switch {
+			case changed("deprecated") && field.New.Deprecated != "":
+				note = ReleaseNote{Type: "deprecation", Text: "deprecated " + target}
+			case changed("force_new") && !field.New.ForceNew:
+				note = ReleaseNote{Type: "enhancement", Text: "made " + target + " updatable"}
+			case changed("required") && field.Old.Required && !field.New.Required:
+				note = ReleaseNote{Type: "enhancement", Text: "made " + target + " optional"}
+			case changed("computed") && field.New.Computed:
+				note = ReleaseNote{Type: "bug", Text: "fixed a permadiff on " + target}
+			default:
+				// Other changes, like newly required fields, are breaking changes.
+				continue
+			}
+			note.Text = service(resource.Name) + ": " + note.Text
+			notes = append(notes, note)
+		}
+	}
+	return notes
+}
+
+// FormatChangelog formats release notes as the release-note blocks that tools/go-changelog
+// reads from PR descriptions.
+func FormatChangelog(notes []ReleaseNote) string {
+	var blocks []string
+	for _, note := range notes {
+		blocks = append(blocks, fmt.Sprintf("```release-note:%s\n%s\n```\n", note.Type, note.Text))
+	}
+	return strings.Join(blocks, "")
+}
@@ -0,0 +1,90 @@
+package release_notes
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/magic-modules/tools/diff-processor/breaking_changes"
+	"github.com/GoogleCloudPlatform/magic-modules/tools/diff-processor/diff"
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestChangelogEntries(t *testing.T) {
+	cases := []struct {
+		name              string
+		oldResourceMap    map[string]*schema.Resource
+		newResourceMap    map[string]*schema.Resource
+		wantReleaseNotes  []ReleaseNote
+		wantFormattedText string
+	}{
+		{
+			name: "no changes",
+		},
+		{
+			name: "changed fields",
+			oldResourceMap: map[string]*schema.Resource{
+				"google_compute_instance": {Schema: map[string]*schema.Schema{
+					"name":        {Type: schema.TypeString, Required: true, ForceNew: true},
+					"description": {Type: schema.TypeString, Optional: true, ForceNew: true},
+					"zone":        {Type: schema.TypeString, Required: true},
+					"hostname":    {Type: schema.TypeString, Optional: true},
+					"metadata":    {Type: schema.TypeMap, Optional: true},
+					"labels":      {Type: schema.TypeMap, Optional: true},
+				}},
+			},
+			newResourceMap: map[string]*schema.Resource{
+				"google_compute_instance": {Schema: map[string]*schema.Schema{
+					"name":        {Type: schema.TypeString, Required: true, ForceNew: true},
+					"description": {Type: schema.TypeString, Optional: true},
+					"zone":        {Type: schema.TypeString, Optional: true},
+					"hostname":    {Type: schema.TypeString, Optional: true, Computed: true},
+					"metadata":    {Type: schema.TypeMap, Optional: true, Deprecated: "Use `labels` instead."},
+					"labels":      {Type: schema.TypeMap, Required: true},
+				}},
+			},
+			wantReleaseNotes: []ReleaseNote{
+				{Type: "enhancement", Text: "compute: made `description` field in `google_compute_instance` resource updatable"},
+				{Type: "bug", Text: "compute: fixed a permadiff on `hostname` field in `google_compute_instance` resource"},
+				{Type: "deprecation", Text: "compute: deprecated `metadata` field in `google_compute_instance` resource"},
+				{Type: "enhancement", Text: "compute: made `zone` field in `google_compute_instance` resource optional"},
+				{Type: "breaking-change", Text: "compute: Field `labels` changed from optional to required on `google_compute_instance`"},
+			},
+			wantFormattedText: "```release-note:enhancement\n" +
+				"compute: made `description` field in `google_compute_instance` resource updatable\n" +
+				"```\n" +
+				"```release-note:bug\n" +
+				"compute: fixed a permadiff on `hostname` field in `google_compute_instance` resource\n" +
+				"```\n" +
+				"```release-note:deprecation\n" +
+				"compute: deprecated `metadata` field in `google_compute_instance` resource\n" +
+				"```\n" +
+				"```release-note:enhancement\n" +
+				"compute: made `zone` field in `google_compute_instance` resource optional\n" +
+				"```\n" +
+				"```release-note:breaking-change\n" +
+				"compute: Field `labels` changed from optional to required on `google_compute_instance`\n" +
+				"```\n",
+		},
+		{
+			name:           "new resource",
+			newResourceMap: map[string]*schema.Resource{"google_redis_cluster": {}},
+			wantReleaseNotes: []ReleaseNote{
+				{Type: "new-resource", Text: "`google_redis_cluster`"},
+			},
+			wantFormattedText: "```release-note:new-resource\n`google_redis_cluster`\n```\n",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			schemaDiff := diff.ComputeSchemaDiff(tc.oldResourceMap, tc.newResourceMap)
+			report := diff.NewReport(schemaDiff, diff.SchemaDiff{})
+			notes := ChangelogEntries(report, breaking_changes.ComputeBreakingChanges(schemaDiff))
+			if d := cmp.Diff(tc.wantReleaseNotes, notes); d != "" {
+				t.Errorf("ChangelogEntries() diff (-want, +got):\n%s", d)
+			}
+			if got := FormatChangelog(notes); got != tc.wantFormattedText {
+				t.Errorf("FormatChangelog() = %q, want %q", got, tc.wantFormattedText)
+			}
+		})
+	}
+}
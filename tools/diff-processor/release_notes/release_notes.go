@@ -0,0 +1,104 @@
+package release_notes
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/magic-modules/tools/diff-processor/diff"
+)
+
+// ReleaseNote is a release note in the format used by the provider changelogs.
+type ReleaseNote struct {
+	// Type is the release note type: new-resource, new-datasource or enhancement.
+	Type string `json:"type"`
+	Text string `json:"note"`
+}
+
+// NewFeatureNotes returns release notes for the resources, data sources and fields added in
+// the report. Fields nested in an added block are covered by the block's note.
+func NewFeatureNotes(report diff.Report) []ReleaseNote {
+	var notes []ReleaseNote
+	for _, name := range report.Resources.Added {
+		notes = append(notes, ReleaseNote{Type: "new-resource", Text: fmt.Sprintf("`%s`", name)})
+	}
+	for _, name := range report.DataSources.Added {
+		notes = append(notes, ReleaseNote{Type: "new-datasource", Text: fmt.Sprintf("`%s`", name)})
+	}
+	notes = append(notes, addedFieldNotes(report.Resources.Modified, "resource")...)
+	notes = append(notes, addedFieldNotes(report.DataSources.Modified, "data source")...)
+	return notes
+}
+
+func addedFieldNotes(modified []diff.ResourceReport, kind string) []ReleaseNote {
+	var notes []ReleaseNote
+	for _, resource := range modified {
+		added := make(map[string]bool)
+		for _, field := range resource.AddedFields {
+			added[field.Path] = true
+		}
+		for _, field := range resource.AddedFields {
+			if parentAdded(field.Path, added) {
+				continue
+			}
+			notes = append(notes, ReleaseNote{
+				Type: "enhancement",
+				Text: fmt.Sprintf("%s: added `%s` field to `%s` %s", service(resource.Name), field.Path, resource.Name, kind),
+			})
+		}
+	}
+	return notes
+}
+
+// parentAdded reports whether any block containing the field at path was also added.
+func parentAdded(path string, added map[string]bool) bool {
+	for i := strings.LastIndex(path, "."); i >= 0; i = strings.LastIndex(path, ".") {
+		path = path[:i]
+		if added[path] {
+			return true
+		}
+	}
+	return false
+}
+
+// service guesses the service of a resource from its name, e.g. compute for
+// google_compute_instance.
+func service(name string) string {
+	parts := strings.Split(name, "_")
+	if len(parts) < 3 {
+		return "provider"
+	}
+	return parts[1]
+}
+
+// FormatBullets formats release notes as changelog bullets, grouped into sections in the same
+// order and style as the provider changelogs.
+func FormatBullets(notes []ReleaseNote) string {
+	var features, improvements []string
+	for _, note := range notes {
+		switch note.Type {
+		case "new-resource":
+			features = append(features, "**New Resource:** "+note.Text)
+		case "new-datasource":
+			features = append(features, "**New Data Source:** "+note.Text)
+		default:
+			improvements = append(improvements, note.Text)
+		}
+	}
+
+	var sections []string
+	for _, section := range []struct {
+		title   string
+		bullets []string
+	}{
+		{"FEATURES:", features},
+		{"IMPROVEMENTS:", improvements},
+	} {
+		if len(section.bullets) == 0 {
+			continue
+		}
+		sort.Strings(section.bullets)
+		sections = append(sections, section.title+"\n* "+strings.Join(section.bullets, "\n* ")+"\n")
+	}
+	return strings.Join(sections, "\n")
+}
@@ -0,0 +1,128 @@
+package release_notes
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/magic-modules/tools/diff-processor/diff"
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestNewFeatureNotes(t *testing.T) {
+	cases := []struct {
+		name              string
+		oldResourceMap    map[string]*schema.Resource
+		newResourceMap    map[string]*schema.Resource
+		oldDataSourceMap  map[string]*schema.Resource
+		newDataSourceMap  map[string]*schema.Resource
+		wantReleaseNotes  []ReleaseNote
+		wantFormattedText string
+	}{
+		{
+			name: "no changes",
+		},
+		{
+			name:           "resource and data source added",
+			newResourceMap: map[string]*schema.Resource{"google_redis_cluster": {}},
+			newDataSourceMap: map[string]*schema.Resource{
+				"google_redis_clusters": {},
+			},
+			wantReleaseNotes: []ReleaseNote{
+				{Type: "new-resource", Text: "`google_redis_cluster`"},
+				{Type: "new-datasource", Text: "`google_redis_clusters`"},
+			},
+			wantFormattedText: "FEATURES:\n" +
+				"* **New Data Source:** `google_redis_clusters`\n" +
+				"* **New Resource:** `google_redis_cluster`\n",
+		},
+		{
+			name: "fields added",
+			oldResourceMap: map[string]*schema.Resource{
+				"google_compute_instance": {Schema: map[string]*schema.Schema{
+					"name": {Type: schema.TypeString, Required: true},
+				}},
+			},
+			newResourceMap: map[string]*schema.Resource{
+				"google_compute_instance": {Schema: map[string]*schema.Schema{
+					"name":   {Type: schema.TypeString, Required: true},
+					"labels": {Type: schema.TypeMap, Optional: true},
+					"params": {
+						Type:     schema.TypeList,
+						Optional: true,
+						Elem: &schema.Resource{Schema: map[string]*schema.Schema{
+							"tags": {Type: schema.TypeMap, Optional: true},
+						}},
+					},
+				}},
+				"google_redis_cluster": {},
+			},
+			oldDataSourceMap: map[string]*schema.Resource{
+				"google_compute_instance": {Schema: map[string]*schema.Schema{
+					"name": {Type: schema.TypeString, Required: true},
+				}},
+			},
+			newDataSourceMap: map[string]*schema.Resource{
+				"google_compute_instance": {Schema: map[string]*schema.Schema{
+					"name":   {Type: schema.TypeString, Required: true},
+					"labels": {Type: schema.TypeMap, Computed: true},
+				}},
+			},
+			wantReleaseNotes: []ReleaseNote{
+				{Type: "new-resource", Text: "`google_redis_cluster`"},
+				{Type: "enhancement", Text: "compute: added `labels` field to `google_compute_instance` resource"},
+				{Type: "enhancement", Text: "compute: added `params` field to `google_compute_instance` resource"},
+				{Type: "enhancement", Text: "compute: added `labels` field to `google_compute_instance` data source"},
+			},
+			wantFormattedText: "FEATURES:\n" +
+				"* **New Resource:** `google_redis_cluster`\n" +
+				"\n" +
+				"IMPROVEMENTS:\n" +
+				"* compute: added `labels` field to `google_compute_instance` data source\n" +
+				"* compute: added `labels` field to `google_compute_instance` resource\n" +
+				"* compute: added `params` field to `google_compute_instance` resource\n",
+		},
+		{
+			name: "nested field added to existing block",
+			oldResourceMap: map[string]*schema.Resource{
+				"google_compute_instance": {Schema: map[string]*schema.Schema{
+					"params": {
+						Type:     schema.TypeList,
+						Optional: true,
+						Elem:     &schema.Resource{Schema: map[string]*schema.Schema{}},
+					},
+				}},
+			},
+			newResourceMap: map[string]*schema.Resource{
+				"google_compute_instance": {Schema: map[string]*schema.Schema{
+					"params": {
+						Type:     schema.TypeList,
+						Optional: true,
+						Elem: &schema.Resource{Schema: map[string]*schema.Schema{
+							"tags": {Type: schema.TypeMap, Optional: true},
+						}},
+					},
+				}},
+			},
+			wantReleaseNotes: []ReleaseNote{
+				{Type: "enhancement", Text: "compute: added `params.tags` field to `google_compute_instance` resource"},
+			},
+			wantFormattedText: "IMPROVEMENTS:\n" +
+				"* compute: added `params.tags` field to `google_compute_instance` resource\n",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			report := diff.NewReport(
+				diff.ComputeSchemaDiff(tc.oldResourceMap, tc.newResourceMap),
+				diff.ComputeSchemaDiff(tc.oldDataSourceMap, tc.newDataSourceMap),
+			)
+			notes := NewFeatureNotes(report)
+			if d := cmp.Diff(tc.wantReleaseNotes, notes); d != "" {
+				t.Errorf("NewFeatureNotes() diff (-want, +got):\n%s", d)
+			}
+			if got := FormatBullets(notes); got != tc.wantFormattedText {
+				t.Errorf("FormatBullets() = %q, want %q", got, tc.wantFormattedText)
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package changelog
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// DefaultService is the service of notes that don't name one, like
+// provider-wide changes.
+const DefaultService = "provider"
+
+// kindOrder is the order notes are listed in within a service.
+var kindOrder = []string{
+	"breaking-change",
+	"deprecation",
+	"new-resource",
+	"new-datasource",
+	"enhancement",
+	"bug",
+	"note",
+}
+
+// ServiceFunc returns the service a note belongs to, or "" to fall back to
+// the service the note is prefixed with.
+type ServiceFunc func(note ParsedNote) string
+
+// ServiceSection is the release notes for one service.
+type ServiceSection struct {
+	Service string       `json:"service"`
+	Notes   []ParsedNote `json:"notes"`
+}
+
+// ServiceNotes is release notes grouped by service, with breaking changes
+// hoisted out of their services so that they can be listed first.
+type ServiceNotes struct {
+	Breaking []ParsedNote     `json:"breaking,omitempty"`
+	Services []ServiceSection `json:"services,omitempty"`
+}
+
+// GroupByService groups notes by the service that serviceOf returns for
+// them. Notes that it doesn't know the service of are grouped by the service
+// they're prefixed with, or under DefaultService if they have none. Notes
+// of kind none are left out. serviceOf may be nil.
+//
+// Each note's Service is set to the service it's grouped under. Services
+// are sorted by name, and their notes by kind and then message.
+func GroupByService(notes []ParsedNote, serviceOf ServiceFunc) ServiceNotes {
+	var grouped ServiceNotes
+	byService := make(map[string][]ParsedNote)
+	for _, note := range notes {
+		if note.Kind == "none" {
+			continue
+		}
+		service := ""
+		if serviceOf != nil {
+			service = serviceOf(note)
+		}
+		if service == "" {
+			service = note.Service
+		}
+		if service == "" {
+			service = DefaultService
+		}
+		note.Service = service
+		if note.Kind == "breaking-change" {
+			grouped.Breaking = append(grouped.Breaking, note)
+			continue
+		}
+		byService[service] = append(byService[service], note)
+	}
+
+	sortNotes(grouped.Breaking)
+	for service, notes := range byService {
+		sortNotes(notes)
+		grouped.Services = append(grouped.Services, ServiceSection{Service: service, Notes: notes})
+	}
+	sort.Slice(grouped.Services, func(i, j int) bool {
+		return grouped.Services[i].Service < grouped.Services[j].Service
+	})
+	return grouped
+}
+
+func sortNotes(notes []ParsedNote) {
+	sort.SliceStable(notes, func(i, j int) bool {
+		if notes[i].Service != notes[j].Service {
+			return notes[i].Service < notes[j].Service
+		}
+		if ki, kj := kindRank(notes[i].Kind), kindRank(notes[j].Kind); ki != kj {
+			return ki < kj
+		}
+		if notes[i].Message != notes[j].Message {
+			return notes[i].Message < notes[j].Message
+		}
+		return notes[i].Issue < notes[j].Issue
+	})
+}
+
+// kindRank orders kinds that aren't in kindOrder, like misspelled ones,
+// last.
+func kindRank(kind string) int {
+	for i, k := range kindOrder {
+		if k == kind {
+			return i
+		}
+	}
+	return len(kindOrder)
+}
+
+// Markdown renders the release notes, starting with the breaking changes.
+// issueURL is a format string for links to each note's issue, like
+// "https://github.com/owner/repo/pull/%s"; if it's empty, issues aren't
+// linked.
+func (sn ServiceNotes) Markdown(issueURL string) string {
+	var sb strings.Builder
+	if len(sn.Breaking) > 0 {
+		sb.WriteString("BREAKING CHANGES:\n")
+		for _, note := range sn.Breaking {
+			fmt.Fprintf(&sb, "* %s: %s%s\n", note.Service, note.Message, issueLink(note.Issue, issueURL))
+		}
+	}
+	for _, section := range sn.Services {
+		if sb.Len() > 0 {
+			sb.WriteString("\n")
+		}
+		fmt.Fprintf(&sb, "%s:\n", section.Service)
+		for _, note := range section.Notes {
+			prefix := ""
+			switch note.Kind {
+			case "new-resource":
+				prefix = "**New Resource:** "
+			case "new-datasource":
+				prefix = "**New Data Source:** "
+			case "deprecation":
+				prefix = "**Deprecated:** "
+			case "bug":
+				prefix = "**Fixed:** "
+			case "note", "enhancement":
+			default:
+				prefix = fmt.Sprintf("**Unknown type %s:** ", note.Kind)
+			}
+			fmt.Fprintf(&sb, "* %s%s%s\n", prefix, note.Message, issueLink(note.Issue, issueURL))
+		}
+	}
+	return sb.String()
+}
+
+func issueLink(issue, issueURL string) string {
+	if issue == "" {
+		return ""
+	}
+	if issueURL == "" {
+		return fmt.Sprintf(" (#%s)", issue)
+	}
+	return fmt.Sprintf(" ([#%s](%s))", issue, fmt.Sprintf(issueURL, issue))
+}
@@ -0,0 +1,119 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package changelog
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGroupByService(t *testing.T) {
+	notes := []ParsedNote{
+		{Kind: "bug", Service: "compute", Resource: "google_compute_instance", Message: "fixed a crash", Issue: "3"},
+		{Kind: "enhancement", Service: "compute", Resource: "google_compute_instance", Message: "added `foo` field", Issue: "2"},
+		{Kind: "breaking-change", Service: "sql", Resource: "google_sql_database_instance", Message: "removed `bar` field", Issue: "4"},
+		{Kind: "new-resource", Resource: "google_compute_thing", Message: "`google_compute_thing`", Issue: "1"},
+		{Kind: "none", Message: "internal change", Issue: "5"},
+		{Kind: "note", Message: "updated the docs", Issue: "6"},
+	}
+	// Maps resources to services like the labeler would
+	serviceOf := func(note ParsedNote) string {
+		switch note.Resource {
+		case "google_compute_instance":
+			return "compute-instances"
+		case "google_sql_database_instance":
+			return "sqladmin"
+		}
+		return ""
+	}
+
+	expected := ServiceNotes{
+		Breaking: []ParsedNote{
+			{Kind: "breaking-change", Service: "sqladmin", Resource: "google_sql_database_instance", Message: "removed `bar` field", Issue: "4"},
+		},
+		Services: []ServiceSection{
+			{
+				Service: "compute-instances",
+				Notes: []ParsedNote{
+					{Kind: "enhancement", Service: "compute-instances", Resource: "google_compute_instance", Message: "added `foo` field", Issue: "2"},
+					{Kind: "bug", Service: "compute-instances", Resource: "google_compute_instance", Message: "fixed a crash", Issue: "3"},
+				},
+			},
+			{
+				Service: "provider",
+				Notes: []ParsedNote{
+					{Kind: "new-resource", Service: "provider", Resource: "google_compute_thing", Message: "`google_compute_thing`", Issue: "1"},
+					{Kind: "note", Service: "provider", Message: "updated the docs", Issue: "6"},
+				},
+			},
+		},
+	}
+	got := GroupByService(notes, serviceOf)
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("GroupByService() = %+v, want %+v", got, expected)
+	}
+
+	// Without a ServiceFunc, notes are grouped by their prefix
+	got = GroupByService(notes[:2], nil)
+	if len(got.Services) != 1 || got.Services[0].Service != "compute" {
+		t.Errorf("GroupByService() without a ServiceFunc = %+v, want one compute section", got)
+	}
+}
+
+func TestServiceNotesMarkdown(t *testing.T) {
+	notes := ServiceNotes{
+		Breaking: []ParsedNote{
+			{Kind: "breaking-change", Service: "sqladmin", Message: "removed `bar` field", Issue: "4"},
+		},
+		Services: []ServiceSection{
+			{
+				Service: "compute",
+				Notes: []ParsedNote{
+					{Kind: "new-resource", Service: "compute", Message: "`google_compute_thing`", Issue: "1"},
+					{Kind: "enhancement", Service: "compute", Message: "added `foo` field", Issue: "2"},
+					{Kind: "bug", Service: "compute", Message: "fixed a crash", Issue: "3"},
+				},
+			},
+		},
+	}
+
+	cases := []struct {
+		name     string
+		issueURL string
+		expected string
+	}{
+		{
+			name:     "linked",
+			issueURL: "https://github.com/owner/repo/pull/%s",
+			expected: "BREAKING CHANGES:\n" +
+				"* sqladmin: removed `bar` field ([#4](https://github.com/owner/repo/pull/4))\n" +
+				"\n" +
+				"compute:\n" +
+				"* **New Resource:** `google_compute_thing` ([#1](https://github.com/owner/repo/pull/1))\n" +
+				"* added `foo` field ([#2](https://github.com/owner/repo/pull/2))\n" +
+				"* **Fixed:** fixed a crash ([#3](https://github.com/owner/repo/pull/3))\n",
+		},
+		{
+			name: "not linked",
+			expected: "BREAKING CHANGES:\n" +
+				"* sqladmin: removed `bar` field (#4)\n" +
+				"\n" +
+				"compute:\n" +
+				"* **New Resource:** `google_compute_thing` (#1)\n" +
+				"* added `foo` field (#2)\n" +
+				"* **Fixed:** fixed a crash (#3)\n",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := notes.Markdown(tc.issueURL); got != tc.expected {
+				t.Errorf("Markdown() = %q, want %q", got, tc.expected)
+			}
+		})
+	}
+
+	if got := (ServiceNotes{}).Markdown(""); got != "" {
+		t.Errorf("Markdown() of no notes = %q, want empty", got)
+	}
+}
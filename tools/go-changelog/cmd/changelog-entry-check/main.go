@@ -0,0 +1,101 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/go-changelog"
+)
+
+func main() {
+	jsonOutput := flag.Bool("json", false, "print the results as JSON")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: changelog-entry-check [-json] [FILE...]\n\nReads standard input if no files are given.\n\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	files := flag.Args()
+	if len(files) == 0 {
+		files = []string{"-"}
+	}
+
+	var results []changelog.EntryResult
+	valid := true
+	for _, file := range files {
+		entry, err := readEntry(file)
+		if err != nil {
+			log.Fatalf("Error reading %s: %s", file, err)
+		}
+		result := changelog.ValidateEntry(entry)
+		if !result.Valid() {
+			valid = false
+		}
+		results = append(results, result)
+	}
+
+	if *jsonOutput {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(results); err != nil {
+			log.Fatalf("Error encoding results: %s", err)
+		}
+	} else {
+		for _, result := range results {
+			printResult(os.Stdout, result)
+		}
+	}
+
+	if !valid {
+		os.Exit(1)
+	}
+}
+
+// readEntry reads a changelog entry from a file, or from standard input if
+// file is "-". Entries read from files are named after the file, like
+// .changelog/1234.txt is entry 1234.
+func readEntry(file string) (changelog.Entry, error) {
+	var contents []byte
+	var err error
+	issue := "stdin"
+	if file == "-" {
+		contents, err = io.ReadAll(os.Stdin)
+	} else {
+		contents, err = os.ReadFile(file)
+		issue = strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+	}
+	if err != nil {
+		return changelog.Entry{}, err
+	}
+	return changelog.Entry{Issue: issue, Body: string(contents)}, nil
+}
+
+func printResult(w io.Writer, result changelog.EntryResult) {
+	status := "ok"
+	if !result.Valid() {
+		status = fmt.Sprintf("%d errors", len(result.Errors))
+	}
+	fmt.Fprintf(w, "%s: %s\n", result.Issue, status)
+	for _, note := range result.Notes {
+		subject := note.Kind
+		if note.Service != "" {
+			subject += " " + note.Service
+		}
+		if note.Resource != "" {
+			subject += " " + note.Resource
+		}
+		fmt.Fprintf(w, "  %s: %s\n", subject, note.Message)
+	}
+	for _, err := range result.Errors {
+		fmt.Fprintf(w, "  error %s: %s\n", err.Code, err)
+	}
+}
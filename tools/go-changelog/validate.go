@@ -0,0 +1,79 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package changelog
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// ParsedNote is a changelog note split into the parts that release tooling
+// works with.
+type ParsedNote struct {
+	// Kind is the note's type, like enhancement or new-resource.
+	Kind string `json:"kind"`
+	// Service is the service the note starts with, like compute in
+	// "compute: added field", if any.
+	Service string `json:"service,omitempty"`
+	// Resource is the first resource or data source the note names, like
+	// google_compute_instance, if any.
+	Resource string `json:"resource,omitempty"`
+	// Message is the note without its service prefix.
+	Message string `json:"message"`
+	Issue   string `json:"issue,omitempty"`
+}
+
+// Parse splits a note into its kind, service, resource and message. It
+// doesn't check that the note is valid.
+func (n *Note) Parse() ParsedNote {
+	body := strings.TrimSpace(n.Body)
+	parsed := ParsedNote{
+		Kind:    n.Type,
+		Message: body,
+		Issue:   n.Issue,
+	}
+	if m := enhancementOrBugFixRegexp.FindStringSubmatch(body); m != nil {
+		parsed.Service = m[1]
+		parsed.Message = m[2]
+	}
+	if m := newResourceOrDatasourceRegexp.FindString(body); m != "" {
+		parsed.Resource = strings.Trim(m, "`")
+	}
+	return parsed
+}
+
+// EntryResult is the result of validating an Entry: the notes it contains
+// and the problems found with them.
+type EntryResult struct {
+	Issue  string                  `json:"issue,omitempty"`
+	Notes  []ParsedNote            `json:"notes"`
+	Errors []*EntryValidationError `json:"errors,omitempty"`
+}
+
+// Valid reports whether the entry has at least one note and every note is
+// properly formatted.
+func (r EntryResult) Valid() bool {
+	return len(r.Errors) == 0
+}
+
+// ValidateEntry parses the notes in an Entry and validates them. Invalid
+// notes are still included in Notes, so that callers can report on them.
+func ValidateEntry(e Entry) EntryResult {
+	result := EntryResult{Issue: e.Issue}
+	for _, note := range NotesFromEntry(e) {
+		result.Notes = append(result.Notes, note.Parse())
+	}
+	result.Errors = e.Validate()
+	return result
+}
+
+// MarshalJSON includes the error's message, so that results can be reported
+// by tools that don't use this package.
+func (e *EntryValidationError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Code    EntryErrorCode         `json:"code"`
+		Message string                 `json:"message"`
+		Details map[string]interface{} `json:"details,omitempty"`
+	}{e.Code, e.message, e.Details})
+}
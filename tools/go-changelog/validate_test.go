@@ -0,0 +1,116 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package changelog
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestParseNote(t *testing.T) {
+	cases := []struct {
+		name     string
+		note     Note
+		expected ParsedNote
+	}{
+		{
+			name: "enhancement",
+			note: Note{Type: "enhancement", Body: "compute: added `foo` field to `google_compute_instance` resource", Issue: "123"},
+			expected: ParsedNote{
+				Kind:     "enhancement",
+				Service:  "compute",
+				Resource: "google_compute_instance",
+				Message:  "added `foo` field to `google_compute_instance` resource",
+				Issue:    "123",
+			},
+		},
+		{
+			name: "new resource",
+			note: Note{Type: "new-resource", Body: "`google_compute_thing`"},
+			expected: ParsedNote{
+				Kind:     "new-resource",
+				Resource: "google_compute_thing",
+				Message:  "`google_compute_thing`",
+			},
+		},
+		{
+			name: "no service or resource",
+			note: Note{Type: "note", Body: " Updated the documentation. "},
+			expected: ParsedNote{
+				Kind:    "note",
+				Message: "Updated the documentation.",
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.note.Parse()
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("Parse() = %+v, want %+v", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestValidateEntry(t *testing.T) {
+	cases := []struct {
+		name          string
+		body          string
+		expectedNotes []ParsedNote
+		expectedCodes []EntryErrorCode
+	}{
+		{
+			name: "valid",
+			body: "```release-note:bug\ncompute: fixed a crash\n```\n\n```release-note:new-resource\n`google_compute_thing`\n```\n",
+			expectedNotes: []ParsedNote{
+				{Kind: "bug", Service: "compute", Message: "fixed a crash", Issue: "1"},
+				{Kind: "new-resource", Resource: "google_compute_thing", Message: "`google_compute_thing`", Issue: "1"},
+			},
+		},
+		{
+			name: "invalid notes are still parsed",
+			body: "```release-note:bug\nfixed a crash\n```\n",
+			expectedNotes: []ParsedNote{
+				{Kind: "bug", Message: "fixed a crash", Issue: "1"},
+			},
+			expectedCodes: []EntryErrorCode{EntryErrorInvalidEnhancementOrBugFixFormat},
+		},
+		{
+			name:          "no notes",
+			body:          "no release note here",
+			expectedCodes: []EntryErrorCode{EntryErrorNotFound},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := ValidateEntry(Entry{Issue: "1", Body: tc.body})
+			if !reflect.DeepEqual(result.Notes, tc.expectedNotes) {
+				t.Errorf("Notes = %+v, want %+v", result.Notes, tc.expectedNotes)
+			}
+			var codes []EntryErrorCode
+			for _, err := range result.Errors {
+				codes = append(codes, err.Code)
+			}
+			if !reflect.DeepEqual(codes, tc.expectedCodes) {
+				t.Errorf("error codes = %v, want %v", codes, tc.expectedCodes)
+			}
+			if result.Valid() != (len(tc.expectedCodes) == 0) {
+				t.Errorf("Valid() = %v with errors %v", result.Valid(), codes)
+			}
+		})
+	}
+}
+
+func TestEntryValidationErrorJSON(t *testing.T) {
+	err := &EntryValidationError{message: "bad note", Code: EntryErrorMultipleLines}
+	got, jsonErr := json.Marshal(err)
+	if jsonErr != nil {
+		t.Fatal(jsonErr)
+	}
+	expected := `{"code":"MULTIPLE_LINES","message":"bad note"}`
+	if string(got) != expected {
+		t.Errorf("json.Marshal() = %s, want %s", got, expected)
+	}
+}
@@ -0,0 +1,131 @@
+/*
+* Copyright 2026 Google LLC. All Rights Reserved.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+// Command cloudrun serves the issue labeler webhook handler on Cloud Run.
+//
+// Configuration comes from the environment: PORT (set by Cloud Run), GITHUB_TOKEN,
+// GITHUB_WEBHOOK_SECRET and optionally LABELER_DRY_RUN=true and OTEL_EXPORTER_OTLP_ENDPOINT.
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/GoogleCloudPlatform/magic-modules/tools/issue-labeler/labeler"
+)
+
+// Cloud Run gives instances 10 seconds between SIGTERM and SIGKILL.
+const shutdownTimeout = 9 * time.Second
+
+func main() {
+	slog.SetDefault(slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
+		ReplaceAttr: cloudLoggingAttr,
+	})))
+
+	if err := run(); err != nil {
+		slog.Error("labeler exited", "error", err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	port := os.Getenv("PORT")
+	if port == "" {
+		port = "8080"
+	}
+	if _, err := strconv.Atoi(port); err != nil {
+		return fmt.Errorf("invalid PORT %q: %w", port, err)
+	}
+	if os.Getenv("GITHUB_TOKEN") == "" {
+		return fmt.Errorf("did not provide GITHUB_TOKEN environment variable")
+	}
+	dryRun := false
+	if v := os.Getenv("LABELER_DRY_RUN"); v != "" {
+		var err error
+		if dryRun, err = strconv.ParseBool(v); err != nil {
+			return fmt.Errorf("invalid LABELER_DRY_RUN %q: %w", v, err)
+		}
+	}
+
+	shutdownTracing, err := labeler.InitTracing(context.Background(), os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT"))
+	if err != nil {
+		return err
+	}
+	defer shutdownTracing(context.Background())
+
+	handler, err := labeler.NewWebhookHandler(labeler.EnrolledTeamsYaml, []byte(os.Getenv("GITHUB_WEBHOOK_SECRET")), dryRun)
+	if err != nil {
+		return fmt.Errorf("validating config: %w", err)
+	}
+	srv := &http.Server{
+		Addr:              ":" + port,
+		Handler:           labeler.NewWebhookMux(handler),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	errc := make(chan error, 1)
+	go func() {
+		slog.Info("listening", "port", port, "dry_run", dryRun, "rules", len(handler.RegexpLabels))
+		errc <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errc:
+		return err
+	case <-ctx.Done():
+	}
+
+	slog.Info("shutting down")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+	if err := <-errc; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// cloudLoggingAttr renames slog's default keys to the ones Cloud Logging recognizes.
+func cloudLoggingAttr(groups []string, a slog.Attr) slog.Attr {
+	if len(groups) > 0 {
+		return a
+	}
+	switch a.Key {
+	case slog.MessageKey:
+		a.Key = "message"
+	case slog.LevelKey:
+		a.Key = "severity"
+		if level, ok := a.Value.Any().(slog.Level); ok && level == slog.LevelWarn {
+			a.Value = slog.StringValue("WARNING")
+		}
+	case slog.TimeKey:
+		a.Key = "timestamp"
+	}
+	return a
+}
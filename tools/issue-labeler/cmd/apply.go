@@ -0,0 +1,118 @@
+/*
+* Copyright 2026 Google LLC. All Rights Reserved.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/spf13/cobra"
+
+	"github.com/GoogleCloudPlatform/magic-modules/tools/issue-labeler/labeler"
+)
+
+var apply = &cobra.Command{
+	Use:   "apply PLAN_FILE [--dry-run] [--failures-file=PATH]",
+	Short: "Applies the label updates in a plan written by plan",
+	Long: `Applies exactly the label updates in a plan file written by plan, and nothing else.
+
+Each issue is read again first. Issues whose labels changed since the plan was written are
+skipped rather than overwritten, and the run fails once the rest are applied so the plan can be
+regenerated. Issues that already have their planned labels are skipped too, so an interrupted
+apply can simply be run again.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireGitHubToken(); err != nil {
+			return err
+		}
+		return execApply(cmd.Context(), args[0])
+	},
+}
+
+func execApply(ctx context.Context, path string) (err error) {
+	ctx, endRun := labeler.StartRun(ctx, "apply")
+	defer func() { endRun(err) }()
+
+	p, err := labeler.LoadPlan(path)
+	if err != nil {
+		return err
+	}
+	slog.Info("applying plan", "path", path, "created_at", p.CreatedAt, "updates", len(p.Updates))
+
+	ctx, saveFailures := openFailureReport(ctx)
+	defer saveFailures(&err)
+
+	var repos []labeler.RepositoryUpdates
+	stale := 0
+	for _, repo := range p.Repositories() {
+		current := labeler.RepositoryUpdates{Repository: repo.Repository}
+		for _, update := range repo.Updates {
+			issue, err := labeler.GetIssue(ctx, repo.Repository, update.Number)
+			if err != nil {
+				return fmt.Errorf("getting github issue: %w", err)
+			}
+			var labels []string
+			for _, l := range issue.Labels {
+				labels = append(labels, l.GetName())
+			}
+			if labeler.PlannedAgainst(update, labels) {
+				current.Updates = append(current.Updates, update)
+				continue
+			}
+			if labeler.AppliedTo(update, labels) {
+				slog.Info("issue already has the planned labels", "repo", repo.Repository, "number", update.Number)
+				continue
+			}
+			slog.Warn("skipping issue whose labels changed since the plan", "repo", repo.Repository, "number", update.Number, "planned_against", update.OldLabels, "labels", labels)
+			stale++
+		}
+		repos = append(repos, current)
+	}
+
+	ctx, closeAudit, err := openAuditLog(ctx)
+	if err != nil {
+		return err
+	}
+	defer closeAudit(&err)
+
+	failed := 0
+	for _, repo := range repos {
+		if err := labeler.UpdateIssues(ctx, repo.Repository, repo.Updates, dryRun); err != nil {
+			if errors.Is(err, labeler.ErrInterrupted) {
+				slog.Warn("apply interrupted, run it again to apply the rest of the plan", "path", path)
+				return err
+			}
+			slog.Error("applying plan failed", "repo", repo.Repository, "error", err)
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("applying plan failed for %d / %d repositories", failed, len(repos))
+	}
+	if stale > 0 {
+		return fmt.Errorf("skipped %d issues whose labels changed since the plan was written, run plan again to update them", stale)
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(apply)
+	addRetryFlags(apply)
+	addAuditLogFlag(apply)
+	addFailuresFileFlag(apply)
+}
@@ -0,0 +1,694 @@
+/*
+* Copyright 2024 Google LLC. All Rights Reserved.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v68/github"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+
+	"github.com/GoogleCloudPlatform/magic-modules/tools/issue-labeler/labeler"
+)
+
+var (
+	// used for flags
+	checkpointFile  string
+	resume          bool
+	reposConfig     string
+	issueFilter     labeler.IssueFilter
+	until           string
+	check           bool
+	syncLabelsFirst bool
+	streamPages     bool
+	streamBuffer    int
+	notifyConfig    string
+	driftHistory    string
+	driftConfig     labeler.DriftConfig
+)
+
+// Exit codes for --check.
+const (
+	exitPendingUpdates = 2
+	exitUnroutable     = 3
+)
+
+var backfill = &cobra.Command{
+	Use:     "backfill [--repo=owner/name]... [--repos-config=repos.yml] [--dry-run [--output=json|csv]] [--check] [--interactive] [--since=1973-01-01] [--until=YYYY-MM-DD] [--window=6h] [--state=open] [--label=name]... [--resume] [--sync-labels] [--stream [--stream-buffer=2]]",
+	Aliases: []string{"backfill-issue-labels"},
+	Short:   "Backfills labels on old issues",
+	Long: `Backfills labels on old issues. Progress is saved to --checkpoint-file as issues are
+updated; if a run is interrupted, rerun it with --resume to skip the updates already applied.
+
+Several repositories can be backfilled in one run by repeating --repo, or by listing them in
+--repos-config, which can also point each repository at its own enrolled teams config:
+
+  repositories:
+  - name: hashicorp/terraform-provider-google
+  - name: hashicorp/terraform-provider-google-beta
+    config: beta_enrolled_teams.yml
+
+A summary of every repository is logged at the end, and the run fails if any repository did.
+
+--state, --label, --exclude-label, --author and --until narrow down the issues considered, for
+targeted relabeling. Filtered runs don't advance the --store last sync. --until takes a date or
+an RFC 3339 timestamp.
+
+With --store, --window makes each run also re-examine issues updated that long before the last
+sync, so issues whose webhook deliveries were dropped still get labeled. Issues that already
+have their labels are left alone, so the overlap is safe.
+
+--sync-labels makes sure every label the rules can apply exists in each repository first.
+
+--stream labels and routes each page of issues as soon as it's fetched instead of listing every
+issue first, so multi-year backfills run in constant memory. --stream-buffer bounds how many
+pages can be waiting between fetching, computing and applying. It can't be combined with
+--output, --interactive or --drift-history, which need every issue at once.
+
+--check runs in dry-run mode and exits 2 if any issue has pending label updates, or 3 if none
+do but some open issues can't be routed to a service, so CI can alert on a triage backlog.`,
+	Args:        cobra.NoArgs,
+	Annotations: map[string]string{annotationMultiRepo: "true"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if check {
+			dryRun = true
+		}
+		if err := checkOutputFlag(); err != nil {
+			return err
+		}
+		// For now actual usage is handled inside UpdateIssues. This is just a new quick check.
+		if err := requireGitHubToken(); err != nil {
+			return err
+		}
+		if err := checkIssueFilterFlags(); err != nil {
+			return err
+		}
+		if err := checkStreamFlags(); err != nil {
+			return err
+		}
+		targets, err := loadRepoTargets(cmd.Flags().Changed("repo"))
+		if err != nil {
+			return err
+		}
+		return execBackfill(cmd.Context(), targets)
+	},
+}
+
+// checkIssueFilterFlags validates the flags added by addIssueFilterFlags and sets
+// issueFilter.Until from --until.
+func checkIssueFilterFlags() error {
+	if err := issueFilter.Validate(); err != nil {
+		return err
+	}
+	if until != "" {
+		t, err := parseUntil(until)
+		if err != nil {
+			return err
+		}
+		issueFilter.Until = t
+	}
+	return nil
+}
+
+// parseUntil parses --until as a date or, for finer bounds on incremental runs, an RFC 3339
+// timestamp.
+func parseUntil(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid until time format, must be YYYY-MM-DD or RFC 3339: %w", err)
+	}
+	return t, nil
+}
+
+// checkStreamFlags rejects --stream alongside the flags that need every issue at once.
+func checkStreamFlags() error {
+	if !streamPages {
+		return nil
+	}
+	if output != "" || interactive || driftHistory != "" {
+		return fmt.Errorf("--stream can't be combined with --output, --interactive or --drift-history")
+	}
+	if streamBuffer < 1 {
+		return fmt.Errorf("--stream-buffer must be at least 1")
+	}
+	return nil
+}
+
+// repoTarget is a repository to backfill and the enrolled teams config to use for it.
+type repoTarget struct {
+	Name   string `yaml:"name"`
+	Config string `yaml:"config,omitempty"`
+}
+
+// loadRepoTargets returns the repositories from --repos-config, plus any given with --repo.
+func loadRepoTargets(repoFlagSet bool) ([]repoTarget, error) {
+	var targets []repoTarget
+	if reposConfig != "" {
+		b, err := os.ReadFile(reposConfig)
+		if err != nil {
+			return nil, fmt.Errorf("reading repos config: %w", err)
+		}
+		var cfg struct {
+			Repositories []repoTarget `yaml:"repositories"`
+		}
+		if err := yaml.UnmarshalStrict(b, &cfg); err != nil {
+			return nil, fmt.Errorf("parsing repos config: %w", err)
+		}
+		for _, t := range cfg.Repositories {
+			// Configs are relative to the repos config file.
+			if t.Config != "" && !filepath.IsAbs(t.Config) {
+				t.Config = filepath.Join(filepath.Dir(reposConfig), t.Config)
+			}
+			targets = append(targets, t)
+		}
+	}
+	if reposConfig == "" || repoFlagSet {
+		for _, repo := range repositories {
+			targets = append(targets, repoTarget{Name: repo})
+		}
+	}
+
+	seen := make(map[string]struct{})
+	for _, t := range targets {
+		if _, ok := seen[t.Name]; ok {
+			return nil, fmt.Errorf("repository %s listed more than once", t.Name)
+		}
+		seen[t.Name] = struct{}{}
+	}
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("no repositories to backfill")
+	}
+	return targets, nil
+}
+
+// backfillResult summarizes the backfill of one repository.
+type backfillResult struct {
+	repository string
+	issues     int
+	updates    int
+	summary    labeler.RunSummary
+	err        error
+}
+
+func execBackfill(ctx context.Context, targets []repoTarget) (err error) {
+	ctx, endRun := labeler.StartRun(ctx, "backfill")
+	defer func() { endRun(err) }()
+
+	ctx, saveFailures := openFailureReport(ctx)
+	defer saveFailures(&err)
+
+	store, err := openStore()
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	var results []backfillResult
+	var proposed []labeler.RepositoryUpdates
+	for _, target := range targets {
+		updates, result := backfillRepository(ctx, store, target, len(targets) > 1)
+		results = append(results, result)
+		proposed = append(proposed, labeler.RepositoryUpdates{Repository: target.Name, Updates: updates})
+		// Repositories after an interrupted one aren't started.
+		if errors.Is(result.err, labeler.ErrInterrupted) {
+			break
+		}
+	}
+	if output != "" {
+		if err := labeler.WriteIssueUpdates(os.Stdout, output, proposed); err != nil {
+			return err
+		}
+	}
+
+	failed, issues, updates := 0, 0, 0
+	for _, r := range results {
+		logger := slog.With("repo", r.repository, "issues", r.issues, "updates", r.updates, "unroutable", len(r.summary.NeedsReview))
+		if r.err != nil {
+			logger.Error("backfill failed", "error", r.err)
+			failed++
+			continue
+		}
+		logger.Info("backfill succeeded")
+		issues += r.issues
+		updates += r.updates
+	}
+	slog.Info("backfill summary", "repositories", len(results), "failed", failed, "issues", issues, "updates", updates)
+	if err := writeStepSummary(results); err != nil {
+		slog.Error("writing job summary failed", "error", err)
+	}
+	if last := results[len(results)-1]; errors.Is(last.err, labeler.ErrInterrupted) {
+		if !dryRun {
+			slog.Warn("backfill interrupted, rerun the same command with --resume to pick up where it stopped", "repo", last.repository, "checkpoint_file", checkpointFile, "remaining_repositories", len(targets)-len(results))
+		}
+		return labeler.ErrInterrupted
+	}
+	if failed > 0 {
+		return fmt.Errorf("backfill failed for %d / %d repositories", failed, len(results))
+	}
+	if check {
+		return checkBacklog(results)
+	}
+	return nil
+}
+
+// checkBacklog fails with a distinct exit code if the backfill found work left to do.
+func checkBacklog(results []backfillResult) error {
+	pending, unroutable := 0, 0
+	for _, r := range results {
+		pending += len(r.summary.Updates)
+		unroutable += len(r.summary.NeedsReview)
+	}
+	if pending > 0 {
+		return &exitError{exitPendingUpdates, fmt.Errorf("%d issues have pending label updates", pending)}
+	}
+	if unroutable > 0 {
+		return &exitError{exitUnroutable, fmt.Errorf("%d open issues can't be routed to a service", unroutable)}
+	}
+	return nil
+}
+
+// writeStepSummary appends a Markdown summary of the backfill to the GitHub Actions job
+// summary when running in Actions.
+func writeStepSummary(results []backfillResult) error {
+	path := os.Getenv("GITHUB_STEP_SUMMARY")
+	if path == "" {
+		return nil
+	}
+	var repos []labeler.StepSummaryRepository
+	for _, r := range results {
+		repos = append(repos, labeler.StepSummaryRepository{Repository: r.repository, Issues: r.issues, Summary: r.summary, Err: r.err})
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if err := labeler.WriteStepSummary(f, repos, dryRun); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// backfillRepository labels one repository. In --output mode the proposed updates are
+// returned instead of applied. With a store, only issues updated since the last sync under the
+// same rules are listed, and those already processed are skipped.
+func backfillRepository(ctx context.Context, store *labeler.Store, target repoTarget, multi bool) ([]labeler.IssueUpdate, backfillResult) {
+	result := backfillResult{repository: target.Name}
+	teamsYaml, err := loadConfigFile(target.Config)
+	if err != nil {
+		result.err = err
+		return nil, result
+	}
+	if syncLabelsFirst {
+		if result.err = execSyncLabels(ctx, target.Name, teamsYaml); result.err != nil {
+			return nil, result
+		}
+	}
+
+	var checkpoint *labeler.Checkpoint
+	if !dryRun {
+		path := checkpointFile
+		if multi {
+			path = repoCheckpointFile(checkpointFile, target.Name)
+		}
+		if resume {
+			checkpoint, err = labeler.LoadCheckpoint(path, target.Name, since)
+			if err != nil {
+				result.err = err
+				return nil, result
+			}
+			slog.Info("resuming backfill", "repo", target.Name, "last_page", checkpoint.LastPage, "applied", len(checkpoint.Applied))
+		} else {
+			checkpoint = labeler.NewCheckpoint(path, target.Name, since)
+		}
+		ctx = labeler.WithCheckpoint(ctx, checkpoint)
+	}
+
+	rs, err := store.Repository(target.Name, labeler.RulesHash(teamsYaml))
+	if err != nil {
+		result.err = err
+		return nil, result
+	}
+	ctx = labeler.WithRepoStore(ctx, rs)
+
+	start := time.Now()
+	var sinceTime time.Time
+	if rs != nil && !rs.LastSync.IsZero() {
+		sinceTime = labeler.WindowStart(rs.LastSync, start, window)
+		slog.Info("syncing issues updated since the last sync", "repo", target.Name, "last_sync", rs.LastSync, "since", sinceTime)
+	} else if sinceTime, err = time.Parse("2006-01-02", since); err != nil {
+		result.err = fmt.Errorf("invalid since time format: %w", err)
+		return nil, result
+	}
+	if streamPages {
+		if result.err = streamRepository(ctx, rs, &result, teamsYaml, sinceTime, start); result.err != nil {
+			return nil, result
+		}
+		result.err = checkpoint.Remove()
+		return nil, result
+	}
+
+	issues, err := labeler.GetFilteredIssues(ctx, target.Name, sinceTime, issueFilter)
+	if err != nil {
+		result.err = fmt.Errorf("getting github issues: %w", err)
+		return nil, result
+	}
+	issues = rs.Unprocessed(issues)
+	result.issues = len(issues)
+
+	updates, routing, err := computeIssueUpdates(ctx, teamsYaml, issues)
+	if err != nil {
+		result.err = err
+		return nil, result
+	}
+	result.updates = len(updates)
+	result.summary = labeler.ComputeRunSummary(target.Name, issues, updates, nil)
+	if output != "" {
+		return updates, result
+	}
+	if result.summary, result.err = applyIssueUpdates(ctx, target.Name, issues, updates, routing); result.err != nil {
+		return nil, result
+	}
+	if result.err = finishSync(rs, issues, start); result.err != nil {
+		return nil, result
+	}
+	result.err = checkpoint.Remove()
+	return nil, result
+}
+
+// streamRepository labels a repository with --stream: each page of issues is labeled and
+// routed as soon as it's fetched, then marked processed in the store, and the run's summary is
+// posted once every page is done.
+func streamRepository(ctx context.Context, rs *labeler.RepoStore, result *backfillResult, teamsYaml []byte, sinceTime, start time.Time) (err error) {
+	regexpLabels, routing, err := loadTeamRouting(teamsYaml)
+	if err != nil {
+		return err
+	}
+	ctx, closeAudit, err := openAuditLog(ctx)
+	if err != nil {
+		return err
+	}
+	defer closeAudit(&err)
+	cfg, err := loadNotifyConfig()
+	if err != nil {
+		return err
+	}
+
+	result.summary = labeler.RunSummary{Repository: result.repository}
+	err = labeler.StreamIssueUpdates(ctx, result.repository, sinceTime, issueFilter, regexpLabels, streamBuffer, func(ctx context.Context, batch labeler.IssueBatch) error {
+		slog.Info("labeling page", "repo", result.repository, "page", batch.Page, "issues", len(batch.Issues), "updates", len(batch.Updates))
+		stats := &labeler.RunStats{}
+		rerr := routeIssueUpdates(labeler.WithRunStats(ctx, stats), result.repository, batch.Updates, routing, stats)
+		result.issues += len(batch.Issues)
+		result.updates += len(batch.Updates)
+		result.summary.Merge(labeler.ComputeRunSummary(result.repository, batch.Issues, batch.Updates, stats))
+		if rerr != nil {
+			return rerr
+		}
+		return markProcessed(rs, batch.Issues)
+	})
+	notifyRun(ctx, cfg, result.summary)
+	if err != nil {
+		return err
+	}
+	return finishSync(rs, nil, start)
+}
+
+// finishSync records a completed run in the store: every listed issue is processed, including
+// those that needed no update, and unless the run was filtered the next one starts from start.
+func finishSync(rs *labeler.RepoStore, issues []*github.Issue, start time.Time) error {
+	if err := markProcessed(rs, issues); err != nil {
+		return err
+	}
+	if dryRun || !issueFilter.IsZero() {
+		return nil
+	}
+	return rs.FinishSync(start)
+}
+
+// markProcessed records issues as processed in the store, unless this is a dry run.
+func markProcessed(rs *labeler.RepoStore, issues []*github.Issue) error {
+	if dryRun {
+		return nil
+	}
+	var numbers []int
+	for _, issue := range issues {
+		numbers = append(numbers, issue.GetNumber())
+	}
+	return rs.MarkProcessed(time.Now(), numbers...)
+}
+
+// repoCheckpointFile gives each repository in a multi-repository run its own checkpoint file.
+func repoCheckpointFile(path, repo string) string {
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + "-" + strings.ReplaceAll(repo, "/", "_") + ext
+}
+
+// labelIssues computes and applies label updates for the given issues in --repo, then adds
+// newly routed issues to their team's project board.
+func labelIssues(ctx context.Context, issues []*github.Issue) error {
+	teamsYaml, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	updates, routing, err := computeIssueUpdates(ctx, teamsYaml, issues)
+	if err != nil {
+		return err
+	}
+	if output != "" {
+		return labeler.WriteIssueUpdates(os.Stdout, output, []labeler.RepositoryUpdates{{Repository: repository, Updates: updates}})
+	}
+	_, err = applyIssueUpdates(ctx, repository, issues, updates, routing)
+	return err
+}
+
+// teamRouting is what the enrolled teams config says to do with newly routed issues.
+type teamRouting struct {
+	// projects maps service labels to the project boards their issues are added to.
+	projects map[string]string
+	// triage holds the comment templates for --triage-comments.
+	triage map[string]labeler.TriageTemplate
+	// teams maps service labels to the GitHub teams --mention-teams notifies.
+	teams map[string]string
+}
+
+// computeIssueUpdates computes the label updates for issues under the given enrolled teams
+// config, returning them along with how the config routes newly labeled issues.
+func computeIssueUpdates(ctx context.Context, teamsYaml []byte, issues []*github.Issue) ([]labeler.IssueUpdate, teamRouting, error) {
+	regexpLabels, routing, err := loadTeamRouting(teamsYaml)
+	if err != nil {
+		return nil, routing, err
+	}
+	start := time.Now()
+	updates := labeler.ComputeIssueUpdates(ctx, issues, regexpLabels)
+	slog.Info("computed issue updates", "issues", len(issues), "updates", len(updates), "rules", len(regexpLabels), "matching_time", time.Since(start))
+	return updates, routing, nil
+}
+
+// loadTeamRouting builds the rules of the enrolled teams config and how it routes newly
+// labeled issues.
+func loadTeamRouting(teamsYaml []byte) ([]labeler.RegexpLabel, teamRouting, error) {
+	var routing teamRouting
+	regexpLabels, err := labeler.BuildRegexLabels(teamsYaml)
+	if err != nil {
+		return nil, routing, fmt.Errorf("building regex labels: %w", err)
+	}
+	if routing.projects, err = labeler.BuildLabelProjects(teamsYaml); err != nil {
+		return nil, routing, fmt.Errorf("building label projects: %w", err)
+	}
+	if triageComments {
+		if routing.triage, err = labeler.BuildTriageTemplates(teamsYaml, labeler.DefaultTriageComment); err != nil {
+			return nil, routing, fmt.Errorf("building triage comments: %w", err)
+		}
+	}
+	if mentionTeams {
+		if routing.teams, err = labeler.BuildLabelTeams(teamsYaml); err != nil {
+			return nil, routing, fmt.Errorf("building label teams: %w", err)
+		}
+	}
+	return regexpLabels, routing, nil
+}
+
+// checkDrift compares the run's label distribution with recent runs in --drift-history and
+// warns in the logs, the metrics and the --notify-config default channel if it drifted. Dry
+// runs are checked but not added to the history.
+func checkDrift(ctx context.Context, repo string, issues []*github.Issue, issueUpdates []labeler.IssueUpdate, cfg labeler.NotifyConfig) error {
+	history, err := labeler.LoadDriftHistory(driftHistory)
+	if err != nil {
+		return err
+	}
+	current := labeler.ComputeLabelDistribution(issues, issueUpdates, time.Now().UTC())
+	alerts := labeler.DetectDrift(history.Repositories[repo], current, driftConfig)
+	labeler.ObserveDistribution(current, alerts)
+	for _, a := range alerts {
+		slog.Warn("label distribution drifted", "repo", repo, "label", a.Label, "share", a.Share, "baseline", a.Baseline)
+	}
+	if err := labeler.NotifyDrift(ctx, cfg, repo, alerts); err != nil {
+		return err
+	}
+	if dryRun || current.Issues == 0 {
+		return nil
+	}
+	history.Add(repo, current)
+	return history.Save(driftHistory)
+}
+
+// applyIssueUpdates applies label updates, after review with --interactive, adds newly routed
+// issues to project boards, comments next steps on newly forwarded ones with --triage-comments
+// and mentions the owning GitHub teams with --mention-teams, then posts a summary of the run if
+// --notify-config is set. The summary is returned even if applying fails part way.
+func applyIssueUpdates(ctx context.Context, repo string, issues []*github.Issue, issueUpdates []labeler.IssueUpdate, routing teamRouting) (summary labeler.RunSummary, err error) {
+	ctx, closeAudit, err := openAuditLog(ctx)
+	if err != nil {
+		return summary, err
+	}
+	defer closeAudit(&err)
+
+	cfg, err := loadNotifyConfig()
+	if err != nil {
+		return summary, err
+	}
+	stats := &labeler.RunStats{}
+	ctx = labeler.WithRunStats(ctx, stats)
+	defer func() {
+		summary = labeler.ComputeRunSummary(repo, issues, issueUpdates, stats)
+		if driftHistory != "" {
+			if derr := checkDrift(ctx, repo, issues, issueUpdates, cfg); derr != nil {
+				slog.Error("checking label distribution failed", "repo", repo, "error", derr)
+			}
+		}
+		notifyRun(ctx, cfg, summary)
+	}()
+
+	if interactive {
+		issueUpdates, err = labeler.ReviewIssueUpdates(os.Stdin, os.Stderr, repo, issues, issueUpdates)
+		if err != nil {
+			return summary, fmt.Errorf("reviewing updates: %w", err)
+		}
+	}
+
+	return summary, routeIssueUpdates(ctx, repo, issueUpdates, routing, stats)
+}
+
+// loadNotifyConfig reads --notify-config, unless this is a dry run, which posts nothing.
+func loadNotifyConfig() (labeler.NotifyConfig, error) {
+	if notifyConfig == "" || dryRun {
+		return labeler.NotifyConfig{}, nil
+	}
+	return labeler.LoadNotifyConfig(notifyConfig)
+}
+
+// notifyRun posts the summary of a run if --notify-config is set.
+func notifyRun(ctx context.Context, cfg labeler.NotifyConfig, summary labeler.RunSummary) {
+	if notifyConfig == "" || dryRun {
+		return
+	}
+	if err := labeler.Notify(ctx, cfg, summary); err != nil {
+		slog.Error("sending notifications failed", "repo", summary.Repository, "error", err)
+	}
+}
+
+// routeIssueUpdates applies label updates, recording their outcomes in stats, then adds newly
+// routed issues to project boards, comments on them with --triage-comments and mentions their
+// teams with --mention-teams.
+func routeIssueUpdates(ctx context.Context, repo string, issueUpdates []labeler.IssueUpdate, routing teamRouting, stats *labeler.RunStats) error {
+	err := labeler.UpdateIssues(ctx, repo, issueUpdates, dryRun)
+	if err != nil {
+		return fmt.Errorf("updating github issues: %w", err)
+	}
+	// Transferred issues can't be added to projects or commented on from this repository.
+	issueUpdates = stats.WithoutTransferred(issueUpdates)
+
+	projectItems := labeler.ComputeProjectItems(issueUpdates, routing.projects)
+	err = labeler.AddProjectItems(ctx, repo, projectItems, dryRun)
+	if err != nil {
+		return fmt.Errorf("adding issues to projects: %w", err)
+	}
+
+	if routing.triage != nil {
+		comments, err := labeler.ComputeTriageComments(issueUpdates, routing.triage)
+		if err != nil {
+			return err
+		}
+		if err := labeler.PostTriageComments(ctx, repo, comments, dryRun); err != nil {
+			return fmt.Errorf("posting triage comments: %w", err)
+		}
+	}
+
+	if routing.teams != nil {
+		mentions := labeler.ComputeTeamMentions(issueUpdates, routing.teams)
+		if err := labeler.PostTeamMentions(ctx, repo, mentions, dryRun); err != nil {
+			return fmt.Errorf("mentioning teams: %w", err)
+		}
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(backfill)
+	addSinceFlag(backfill)
+	addWindowFlag(backfill)
+	addOutputFlag(backfill)
+	addInteractiveFlag(backfill)
+	backfill.Flags().StringVar(&checkpointFile, "checkpoint-file", "labeler-checkpoint.json", "File recording the progress of the backfill")
+	addReposConfigFlag(backfill)
+	addIssueFilterFlags(backfill)
+	backfill.Flags().BoolVar(&resume, "resume", false, "Resume an interrupted backfill from --checkpoint-file, skipping updates it already applied")
+	backfill.Flags().BoolVar(&check, "check", false, "Exit 2 if there are pending label updates or 3 if there are unroutable issues, without applying anything (implies --dry-run)")
+	backfill.Flags().BoolVar(&syncLabelsFirst, "sync-labels", false, "Create or update the labels the rules can apply in each repository before backfilling, like sync-labels")
+	backfill.Flags().BoolVar(&streamPages, "stream", false, "Label and route each page of issues as soon as it's fetched, so large backfills run in constant memory")
+	backfill.Flags().IntVar(&streamBuffer, "stream-buffer", labeler.DefaultStreamBuffer, "Pages of issues that can wait between fetching, computing and applying with --stream")
+	addStoreFlag(backfill)
+	addPageConcurrencyFlag(backfill)
+	addConflictFlags(backfill)
+	addAutomationFlags(backfill)
+	addRoutingFlags(backfill)
+	addRetryFlags(backfill)
+	addAuditLogFlag(backfill)
+	addFailuresFileFlag(backfill)
+	backfill.Flags().StringVar(&notifyConfig, "notify-config", "", "YAML file mapping Slack or Google Chat webhooks to post run summaries to")
+	backfill.MarkFlagFilename("notify-config", "yml", "yaml")
+	backfill.Flags().StringVar(&driftHistory, "drift-history", "", "JSON file keeping each run's label distribution, to warn when a service label's share spikes or too many issues match no service")
+	backfill.Flags().Float64Var(&driftConfig.SpikeFactor, "drift-spike-factor", 3, "Warn when a service label's share of a run's issues is more than this many times its recent share")
+	backfill.Flags().Float64Var(&driftConfig.MaxUnmatched, "drift-max-unmatched", 0.5, "Warn when more than this share of a run's issues match no service")
+	backfill.Flags().IntVar(&driftConfig.MinIssues, "drift-min-issues", 20, "Don't check runs with fewer issues than this for drift")
+}
+
+func addReposConfigFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&reposConfig, "repos-config", "", "YAML file listing the repositories to run on, with optional per-repository configs")
+	cmd.MarkFlagFilename("repos-config", "yml", "yaml")
+}
+
+// addIssueFilterFlags adds the flags narrowing down the issues a run considers, which
+// checkIssueFilterFlags validates.
+func addIssueFilterFlags(cmd *cobra.Command) {
+	addStateFlag(cmd, &issueFilter.State, "all")
+	cmd.Flags().StringSliceVar(&issueFilter.Labels, "label", nil, "Only consider issues with this label (repeatable; issues must have all of them)")
+	cmd.Flags().StringSliceVar(&issueFilter.ExcludeLabels, "exclude-label", nil, "Skip issues with this label (repeatable)")
+	cmd.Flags().StringVar(&issueFilter.Author, "author", "", "Only consider issues opened by this GitHub user")
+	cmd.Flags().StringVar(&until, "until", "", "Only consider issues updated before given date (YYYY-MM-DD) or time (RFC 3339)")
+}
@@ -0,0 +1,88 @@
+/*
+* Copyright 2026 Google LLC. All Rights Reserved.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/GoogleCloudPlatform/magic-modules/tools/issue-labeler/labeler"
+)
+
+var (
+	// used for flags
+	cleanupLabels []string
+	cleanupState  string
+)
+
+var cleanup = &cobra.Command{
+	Use:   "cleanup [--repo=owner/name] [--since=1973-01-01] [--state=closed] [--remove-label=name]... [--dry-run]",
+	Short: "Removes workflow labels from closed issues",
+	Long: `Sweeps closed issues updated since --since and removes workflow labels that no longer apply
+once an issue is closed, so label-based queries only count live issues. Service labels are
+kept. Updates are applied in batches like migrate, waiting out the rate limit when it runs low.
+
+--state=open or --state=all sweeps open issues too, for example to drop a retired label from
+every issue.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireGitHubToken(); err != nil {
+			return err
+		}
+		if err := (labeler.IssueFilter{State: cleanupState}).Validate(); err != nil {
+			return err
+		}
+		return execCleanup(cmd.Context())
+	},
+}
+
+func execCleanup(ctx context.Context) (err error) {
+	ctx, endRun := labeler.StartRun(ctx, "cleanup")
+	defer func() { endRun(err) }()
+
+	sinceTime, err := time.Parse("2006-01-02", since)
+	if err != nil {
+		return fmt.Errorf("invalid since time format: %w", err)
+	}
+	issues, err := labeler.GetFilteredIssues(ctx, repository, sinceTime, labeler.IssueFilter{State: cleanupState})
+	if err != nil {
+		return fmt.Errorf("getting github issues: %w", err)
+	}
+	updates := labeler.ComputeCleanupUpdates(issues, cleanupLabels, cleanupState)
+
+	ctx, closeAudit, err := openAuditLog(ctx)
+	if err != nil {
+		return err
+	}
+	defer closeAudit(&err)
+
+	return labeler.MigrateLabels(ctx, repository, updates, migrateBatchSize, migrateMinRate, dryRun)
+}
+
+func init() {
+	rootCmd.AddCommand(cleanup)
+	addSinceFlag(cleanup)
+	addStateFlag(cleanup, &cleanupState, "closed")
+	cleanup.Flags().StringSliceVar(&cleanupLabels, "remove-label", labeler.DefaultCleanupLabels, "Label to remove from closed issues (repeatable)")
+	cleanup.Flags().IntVar(&migrateBatchSize, "batch-size", 100, "Number of issues to update between rate limit checks")
+	cleanup.Flags().IntVar(&migrateMinRate, "min-rate-remaining", 500, "Wait for the rate limit to reset when fewer requests than this are left")
+	addPageConcurrencyFlag(cleanup)
+	addRetryFlags(cleanup)
+	addAuditLogFlag(cleanup)
+}
@@ -26,27 +26,43 @@ import (
 	"github.com/GoogleCloudPlatform/magic-modules/tools/issue-labeler/labeler"
 )
 
+var (
+	// used for flags
+	computeBodyFile string
+	computeAuthor   string
+	computeTitle    string
+)
+
 var computeNewLabels = &cobra.Command{
-	Use:   "compute-new-labels",
+	Use:   "compute-new-labels [--body-file=issue.md] [--title=title] [--author=login]",
 	Short: "Computes labels that should be added to an issue based on its body",
-	Long:  "Computes labels that should be added to an issue based on its body",
-	Args:  cobra.NoArgs,
+	Long: `Computes labels that should be added to an issue based on its body. Issues opened by an
+--automation-author get --automation-label instead of forward/review, and issues about the
+provider's documentation also get the documentation label.`,
+	Args: cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return execComputeNewLabels()
 	},
 }
 
 func execComputeNewLabels() error {
-	regexpLabels, err := labeler.BuildRegexLabels(labeler.EnrolledTeamsYaml)
+	regexpLabels, err := loadRegexLabels()
 	if err != nil {
-		return fmt.Errorf("building regex labels: %w", err)
+		return err
 	}
+	// ISSUE_BODY is still read when --body-file is unset for older callers.
 	issueBody := os.Getenv("ISSUE_BODY")
-	affectedResources := labeler.ExtractAffectedResources(issueBody)
-	labels := labeler.ComputeLabels(affectedResources, regexpLabels)
+	if computeBodyFile != "" {
+		b, err := os.ReadFile(computeBodyFile)
+		if err != nil {
+			return fmt.Errorf("reading issue body: %w", err)
+		}
+		issueBody = string(b)
+	}
+	labels := labeler.ComputeIssueLabels(issueBody, regexpLabels)
 
 	// If there are more than 3 service labels, treat this as a cross-provider issue.
-	// Note that labeler.ComputeLabels() currently only returns service labels, but
+	// Note that labeler.ComputeIssueLabels() currently only returns service labels, but
 	// the logic here remains defensive in case that changes.
 	var serviceLabels []string
 	var nonServiceLabels []string
@@ -61,9 +77,16 @@ func execComputeNewLabels() error {
 		serviceLabels = []string{"service/terraform"}
 	}
 	labels = append(nonServiceLabels, serviceLabels...)
+	if labeler.IsDocumentationIssue(computeTitle, issueBody) {
+		labels = append(labels, labeler.LabelDocumentation)
+	}
 
-	if len(labels) > 0 {
+	if automationPolicy.IsAuthor(computeAuthor) {
+		labels = append(labels, automationPolicy.Label)
+	} else if len(labels) > 0 {
 		labels = append(labels, "forward/review")
+	}
+	if len(labels) > 0 {
 		sort.Strings(labels)
 		fmt.Println(`["` + strings.Join(labels, `", "`) + `"]`)
 	}
@@ -72,4 +95,8 @@ func execComputeNewLabels() error {
 
 func init() {
 	rootCmd.AddCommand(computeNewLabels)
+	computeNewLabels.Flags().StringVar(&computeBodyFile, "body-file", "", "File containing the issue body (defaults to the ISSUE_BODY environment variable)")
+	computeNewLabels.Flags().StringVar(&computeTitle, "title", "", "The issue's title")
+	computeNewLabels.Flags().StringVar(&computeAuthor, "author", "", "Login of the issue's author")
+	addAutomationFlags(computeNewLabels)
 }
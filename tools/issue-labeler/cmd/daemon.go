@@ -0,0 +1,254 @@
+/*
+* Copyright 2026 Google LLC. All Rights Reserved.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/robfig/cron/v3"
+	"github.com/spf13/cobra"
+
+	"github.com/GoogleCloudPlatform/magic-modules/tools/issue-labeler/labeler"
+)
+
+var (
+	// used for flags
+	daemonSchedule  string
+	daemonStateFile string
+	daemonAddr      string
+)
+
+// The daemon reports itself unhealthy after this many failed runs in a row.
+const maxConsecutiveFailures = 3
+
+var daemon = &cobra.Command{
+	Use:   `daemon --schedule="0 * * * *" [--state-file=labeler-state.json] [--window=6h] [--addr=:8080]`,
+	Short: "Runs incremental labeling on a cron schedule",
+	Long: `Runs labeling on the given cron schedule, only looking at issues updated since the last
+successful run. The last successful run time is kept in --state-file so restarts pick up where
+they left off; --since is used when there is no previous run. /healthz reports run status and /metrics
+exposes Prometheus metrics.
+
+--window makes each run also re-examine issues updated that long before the last successful
+run, so issues whose webhook deliveries were dropped still get labeled. Issues that already
+have their labels are left alone, so the overlap is safe.
+
+--config is reread at the start of every run, so rule changes apply without a restart; the
+rules added and removed since the previous run are logged.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireGitHubToken(); err != nil {
+			return err
+		}
+		schedule, err := cron.ParseStandard(daemonSchedule)
+		if err != nil {
+			return fmt.Errorf("invalid schedule %q: %w", daemonSchedule, err)
+		}
+		return execDaemon(schedule)
+	},
+}
+
+// daemonStatus tracks scheduled runs and serves them as the liveness endpoint.
+type daemonStatus struct {
+	mu                  sync.Mutex
+	LastSuccess         time.Time `json:"last_success"`
+	LastError           string    `json:"last_error,omitempty"`
+	ConsecutiveFailures int       `json:"consecutive_failures"`
+	NextRun             time.Time `json:"next_run"`
+}
+
+func (s *daemonStatus) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	if s.ConsecutiveFailures >= maxConsecutiveFailures {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	json.NewEncoder(w).Encode(s)
+}
+
+func (s *daemonStatus) record(start time.Time, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err != nil {
+		s.LastError = err.Error()
+		s.ConsecutiveFailures++
+		return
+	}
+	s.LastSuccess = start
+	s.LastError = ""
+	s.ConsecutiveFailures = 0
+}
+
+func execDaemon(schedule cron.Schedule) error {
+	state, err := labeler.LoadRunState(daemonStateFile)
+	if err != nil {
+		return err
+	}
+	if state.LastSuccess.IsZero() {
+		if state.LastSuccess, err = time.Parse("2006-01-02", since); err != nil {
+			return fmt.Errorf("invalid since time format: %w", err)
+		}
+	}
+	status := &daemonStatus{LastSuccess: state.LastSuccess}
+
+	store, err := openStore()
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /healthz", status)
+	mux.Handle("GET /metrics", promhttp.Handler())
+	srv := &http.Server{
+		Addr:              daemonAddr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			slog.Error("health endpoint stopped", "error", err)
+		}
+	}()
+	defer srv.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	for {
+		next := schedule.Next(time.Now())
+		status.mu.Lock()
+		status.NextRun = next
+		status.mu.Unlock()
+		slog.Info("waiting for next run", "next_run", next)
+
+		select {
+		case <-ctx.Done():
+			slog.Info("shutting down")
+			return nil
+		case <-time.After(time.Until(next)):
+		}
+
+		status.mu.Lock()
+		lastSuccess := status.LastSuccess
+		status.mu.Unlock()
+
+		start := time.Now()
+		// A signal mid-run lets the run finish its updates in flight and save its progress
+		// rather than cutting it off.
+		err := runIncremental(labeler.WithShutdown(context.Background(), ctx.Done()), store, lastSuccess, start)
+		labeler.ObserveRun("daemon", start)
+		if err != nil {
+			slog.Error("run failed", "error", err)
+		}
+		status.record(start, err)
+	}
+}
+
+// runIncremental labels issues updated since lastSuccess and records start as the new
+// high-water mark, so issues updated while this run was in progress are seen next time. With a
+// store, its last sync under the current rules takes precedence over lastSuccess, and issues
+// processed by an interrupted run are skipped.
+func runIncremental(ctx context.Context, store *labeler.Store, lastSuccess, start time.Time) (err error) {
+	ctx, endRun := labeler.StartRun(ctx, "daemon")
+	defer func() { endRun(err) }()
+
+	ctx, saveFailures := openFailureReport(ctx)
+	defer saveFailures(&err)
+
+	teamsYaml, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	logRuleChanges(teamsYaml)
+	rs, err := store.Repository(repository, labeler.RulesHash(teamsYaml))
+	if err != nil {
+		return err
+	}
+	if rs != nil {
+		// A rules change resets the last sync, so everything is relabeled under the new rules.
+		lastSuccess = rs.LastSync
+		if lastSuccess.IsZero() {
+			if lastSuccess, err = time.Parse("2006-01-02", since); err != nil {
+				return fmt.Errorf("invalid since time format: %w", err)
+			}
+		}
+	}
+	ctx = labeler.WithRepoStore(ctx, rs)
+
+	sinceTime := labeler.WindowStart(lastSuccess, start, window)
+	slog.Info("labeling issues", "repo", repository, "last_success", lastSuccess, "since", sinceTime)
+	issues, err := labeler.GetIssuesSince(ctx, repository, sinceTime)
+	if err != nil {
+		return fmt.Errorf("getting github issues: %w", err)
+	}
+	issues = rs.Unprocessed(issues)
+	if err := labelIssues(ctx, issues); err != nil {
+		return err
+	}
+	if dryRun {
+		return nil
+	}
+	if err := finishSync(rs, issues, start); err != nil {
+		return err
+	}
+	return labeler.SaveRunState(daemonStateFile, labeler.RunState{LastSuccess: start})
+}
+
+// daemonRules are the rules the previous run used, to log what changed between runs.
+var daemonRules []labeler.RegexpLabel
+
+func logRuleChanges(teamsYaml []byte) {
+	rules, err := labeler.BuildRegexLabels(teamsYaml)
+	if err != nil {
+		// labelIssues reports the error.
+		return
+	}
+	if daemonRules != nil {
+		if diff := labeler.DiffRules(daemonRules, rules); !diff.Empty() {
+			slog.Info("config changed", "rules_added", diff.Added, "rules_removed", diff.Removed)
+		}
+	}
+	daemonRules = rules
+}
+
+func init() {
+	rootCmd.AddCommand(daemon)
+	addSinceFlag(daemon)
+	addWindowFlag(daemon)
+	daemon.Flags().StringVar(&daemonSchedule, "schedule", "", "Cron expression controlling when runs start, e.g. \"0 * * * *\"")
+	daemon.Flags().StringVar(&daemonStateFile, "state-file", "labeler-state.json", "File recording the last successful run time")
+	daemon.Flags().StringVar(&daemonAddr, "addr", ":8080", "Address for the /healthz endpoint")
+	daemon.MarkFlagRequired("schedule")
+	addStoreFlag(daemon)
+	addPageConcurrencyFlag(daemon)
+	addConflictFlags(daemon)
+	addAutomationFlags(daemon)
+	addRoutingFlags(daemon)
+	addRetryFlags(daemon)
+	addFailuresFileFlag(daemon)
+}
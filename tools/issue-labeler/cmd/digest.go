@@ -0,0 +1,112 @@
+/*
+* Copyright 2026 Google LLC. All Rights Reserved.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/GoogleCloudPlatform/magic-modules/tools/issue-labeler/labeler"
+)
+
+var (
+	// used for flags
+	digestDays       int
+	digestFrom       string
+	smtpAddr         string
+	useSendGrid      bool
+	sendGridEndpoint string
+)
+
+var digest = &cobra.Command{
+	Use:   "digest [--repo=owner/name] [--days=1] --from=address (--smtp-addr=host:port | --sendgrid) [--dry-run]",
+	Short: "Emails each team a digest of its newly forwarded issues",
+	Long: `Sends one email per service label listing the open issues in forward/review that were opened
+in the last --days days, with their title, age and an excerpt. Recipients are the digest
+addresses of each label in the enrolled teams config; labels without any are skipped.
+
+Mail is sent through --smtp-addr, authenticating with SMTP_USERNAME and SMTP_PASSWORD if set,
+or through SendGrid with --sendgrid and SENDGRID_API_KEY.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireGitHubToken(); err != nil {
+			return err
+		}
+		if digestDays <= 0 {
+			return fmt.Errorf("--days must be positive")
+		}
+		mailer, err := newMailer()
+		if err != nil {
+			return err
+		}
+		return execDigest(cmd.Context(), mailer)
+	},
+}
+
+func newMailer() (labeler.Mailer, error) {
+	if useSendGrid == (smtpAddr != "") {
+		return nil, fmt.Errorf("exactly one of --smtp-addr and --sendgrid is required")
+	}
+	if useSendGrid {
+		key, ok := os.LookupEnv("SENDGRID_API_KEY")
+		if !ok {
+			return nil, fmt.Errorf("did not provide SENDGRID_API_KEY environment variable")
+		}
+		return labeler.SendGridMailer{APIKey: key, Endpoint: sendGridEndpoint}, nil
+	}
+	return labeler.SMTPMailer{
+		Addr:     smtpAddr,
+		Username: os.Getenv("SMTP_USERNAME"),
+		Password: os.Getenv("SMTP_PASSWORD"),
+	}, nil
+}
+
+func execDigest(ctx context.Context, mailer labeler.Mailer) (err error) {
+	ctx, endRun := labeler.StartRun(ctx, "digest")
+	defer func() { endRun(err) }()
+
+	teamsYaml, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	labelDigests, err := labeler.BuildLabelDigests(teamsYaml)
+	if err != nil {
+		return fmt.Errorf("building label digests: %w", err)
+	}
+	now := time.Now()
+	cutoff := now.Add(-time.Duration(digestDays) * 24 * time.Hour)
+	issues, err := labeler.GetIssues(ctx, repository, cutoff.Format("2006-01-02"))
+	if err != nil {
+		return fmt.Errorf("getting github issues: %w", err)
+	}
+	digests := labeler.ComputeDigests(repository, issues, labelDigests, cutoff, now)
+	return labeler.SendDigests(ctx, mailer, digestFrom, digests, dryRun)
+}
+
+func init() {
+	rootCmd.AddCommand(digest)
+	digest.Flags().IntVar(&digestDays, "days", 1, "Include issues opened in the last this many days")
+	digest.Flags().StringVar(&digestFrom, "from", "", "Sender address for the digests")
+	digest.MarkFlagRequired("from")
+	digest.Flags().StringVar(&smtpAddr, "smtp-addr", "", "SMTP server to send mail through, as host:port")
+	digest.Flags().BoolVar(&useSendGrid, "sendgrid", false, "Send mail through the SendGrid API instead of SMTP")
+	digest.Flags().StringVar(&sendGridEndpoint, "sendgrid-endpoint", labeler.DefaultSendGridEndpoint, "SendGrid mail send endpoint")
+	addPageConcurrencyFlag(digest)
+}
@@ -0,0 +1,131 @@
+/*
+* Copyright 2026 Google LLC. All Rights Reserved.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/GoogleCloudPlatform/magic-modules/tools/issue-labeler/labeler"
+)
+
+var (
+	// used for flags
+	corpusPath     string
+	baselineConfig string
+	corpusState    string
+)
+
+var eval = &cobra.Command{
+	Use:   "eval --corpus=corpus.jsonl [--config=enrolled_teams.yml] [--baseline=old_enrolled_teams.yml]",
+	Short: "Measures the rules against previously triaged issues",
+	Long: `Replays the rules from --config against a corpus of triaged issues and reports precision and
+recall per service label. With --baseline, issues the baseline rules labeled correctly but the
+new rules don't are reported as regressions and make the command fail, so rule changes can be
+gated in CI.
+
+The corpus has one JSON object per line with "number", "body" and "labels" fields; build one
+with "eval export-corpus".`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return execEval()
+	},
+}
+
+func execEval() error {
+	f, err := os.Open(corpusPath)
+	if err != nil {
+		return fmt.Errorf("opening corpus: %w", err)
+	}
+	defer f.Close()
+	corpus, err := labeler.ReadCorpus(f)
+	if err != nil {
+		return err
+	}
+
+	rules, err := loadRegexLabels()
+	if err != nil {
+		return err
+	}
+	var baseline []labeler.RegexpLabel
+	if baselineConfig != "" {
+		b, err := os.ReadFile(baselineConfig)
+		if err != nil {
+			return fmt.Errorf("reading baseline config: %w", err)
+		}
+		if baseline, err = labeler.BuildRegexLabels(b); err != nil {
+			return fmt.Errorf("building baseline regex labels: %w", err)
+		}
+	}
+
+	evaluation := labeler.Evaluate(corpus, rules, baseline)
+	if err := evaluation.Write(os.Stdout); err != nil {
+		return err
+	}
+	if n := evaluation.Regressions(); n > 0 {
+		return fmt.Errorf("found %d regressions against the baseline rules", n)
+	}
+	return nil
+}
+
+var exportCorpus = &cobra.Command{
+	Use:   "export-corpus [--repo=owner/name] [--since=1973-01-01] [--state=all] > corpus.jsonl",
+	Short: "Writes triaged issues as an eval corpus",
+	Long: `Writes every issue updated since the given date that has a service label to stdout as an eval
+corpus, keeping only its service labels. --state=closed limits the corpus to closed issues,
+whose labels have usually been checked by the time they're closed.`,
+	Args:        cobra.NoArgs,
+	Annotations: map[string]string{annotationStdoutData: "true"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireGitHubToken(); err != nil {
+			return err
+		}
+		if err := (labeler.IssueFilter{State: corpusState}).Validate(); err != nil {
+			return err
+		}
+		return execExportCorpus(cmd.Context())
+	},
+}
+
+func execExportCorpus(ctx context.Context) error {
+	sinceTime, err := time.Parse("2006-01-02", since)
+	if err != nil {
+		return fmt.Errorf("invalid since time format: %w", err)
+	}
+	issues, err := labeler.GetFilteredIssues(ctx, repository, sinceTime, labeler.IssueFilter{State: corpusState})
+	if err != nil {
+		return fmt.Errorf("getting github issues: %w", err)
+	}
+	return labeler.WriteCorpus(os.Stdout, issues)
+}
+
+func init() {
+	rootCmd.AddCommand(eval)
+	eval.Flags().StringVar(&corpusPath, "corpus", "", "Corpus of triaged issues, one JSON object per line")
+	eval.Flags().StringVar(&baselineConfig, "baseline", "", "Enrolled teams config to compare against for regressions")
+	eval.MarkFlagRequired("corpus")
+	eval.MarkFlagFilename("corpus", "jsonl", "json")
+	eval.MarkFlagFilename("baseline", "yml", "yaml")
+
+	eval.AddCommand(exportCorpus)
+	addSinceFlag(exportCorpus)
+	addStateFlag(exportCorpus, &corpusState, "all")
+	addPageConcurrencyFlag(exportCorpus)
+}
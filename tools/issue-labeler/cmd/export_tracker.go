@@ -0,0 +1,85 @@
+/*
+* Copyright 2026 Google LLC. All Rights Reserved.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/GoogleCloudPlatform/magic-modules/tools/issue-labeler/labeler"
+)
+
+var (
+	// used for flags
+	trackerEndpoint string
+)
+
+var exportTracker = &cobra.Command{
+	Use:   "export-tracker [--repo=owner/name] [--since=1973-01-01] [--dry-run]",
+	Short: "Mirrors routed issues into the internal issue tracker",
+	Long: `Files an internal tracker bug for each open issue in forward/review whose service label has a
+component in the enrolled teams config, comments the bug link on the GitHub issue and replaces
+forward/review with forward/linked. Authenticates to the tracker with application default
+credentials.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireGitHubToken(); err != nil {
+			return err
+		}
+		return execExportTracker(cmd.Context())
+	},
+}
+
+func execExportTracker(ctx context.Context) (err error) {
+	ctx, endRun := labeler.StartRun(ctx, "export-tracker")
+	defer func() { endRun(err) }()
+
+	teamsYaml, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	labelComponents, err := labeler.BuildLabelComponents(teamsYaml)
+	if err != nil {
+		return fmt.Errorf("building label components: %w", err)
+	}
+	issues, err := labeler.GetIssues(ctx, repository, since)
+	if err != nil {
+		return fmt.Errorf("getting github issues: %w", err)
+	}
+	exports := labeler.ComputeTrackerExports(repository, issues, labelComponents)
+
+	tracker, err := labeler.NewTrackerClient(ctx, trackerEndpoint)
+	if err != nil {
+		return err
+	}
+	ctx, closeAudit, err := openAuditLog(ctx)
+	if err != nil {
+		return err
+	}
+	defer closeAudit(&err)
+
+	return labeler.ExportToTracker(ctx, repository, tracker, exports, dryRun)
+}
+
+func init() {
+	rootCmd.AddCommand(exportTracker)
+	addSinceFlag(exportTracker)
+	exportTracker.Flags().StringVar(&trackerEndpoint, "tracker-endpoint", labeler.DefaultTrackerEndpoint, "Base URL of the issue tracker API")
+	addPageConcurrencyFlag(exportTracker)
+	addAuditLogFlag(exportTracker)
+}
@@ -0,0 +1,80 @@
+/*
+* Copyright 2026 Google LLC. All Rights Reserved.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/google/go-github/v68/github"
+	"github.com/spf13/cobra"
+
+	"github.com/GoogleCloudPlatform/magic-modules/tools/issue-labeler/labeler"
+)
+
+var label = &cobra.Command{
+	Use:   "label ISSUE_NUMBER... [--repo=owner/name] [--dry-run [--output=json|csv]] [--interactive]",
+	Short: "Labels specific issues",
+	Long:  "Computes and applies labels for the given issues, the same way backfill does for every issue",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := checkOutputFlag(); err != nil {
+			return err
+		}
+		if err := requireGitHubToken(); err != nil {
+			return err
+		}
+		var numbers []int
+		for _, arg := range args {
+			n, err := strconv.Atoi(arg)
+			if err != nil {
+				return fmt.Errorf("invalid issue number %q", arg)
+			}
+			numbers = append(numbers, n)
+		}
+		return execLabel(cmd.Context(), numbers)
+	},
+}
+
+func execLabel(ctx context.Context, numbers []int) (err error) {
+	ctx, endRun := labeler.StartRun(ctx, "label")
+	defer func() { endRun(err) }()
+
+	ctx, saveFailures := openFailureReport(ctx)
+	defer saveFailures(&err)
+
+	var issues []*github.Issue
+	for _, n := range numbers {
+		issue, err := labeler.GetIssue(ctx, repository, n)
+		if err != nil {
+			return fmt.Errorf("getting github issue: %w", err)
+		}
+		issues = append(issues, issue)
+	}
+	return labelIssues(ctx, issues)
+}
+
+func init() {
+	rootCmd.AddCommand(label)
+	addOutputFlag(label)
+	addInteractiveFlag(label)
+	addConflictFlags(label)
+	addAutomationFlags(label)
+	addRoutingFlags(label)
+	addRetryFlags(label)
+	addFailuresFileFlag(label)
+}
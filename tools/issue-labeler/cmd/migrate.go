@@ -0,0 +1,87 @@
+/*
+* Copyright 2026 Google LLC. All Rights Reserved.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/GoogleCloudPlatform/magic-modules/tools/issue-labeler/labeler"
+)
+
+var (
+	// used for flags
+	migrateFrom       string
+	migrateTo         []string
+	migrateIncludePRs bool
+	migrateBatchSize  int
+	migrateMinRate    int
+)
+
+var migrate = &cobra.Command{
+	Use:   "migrate --from=service/old --to=service/new [--to=...] [--include-prs] [--dry-run]",
+	Short: "Replaces a label across all issues",
+	Long: `Replaces the --from label with the --to labels on every issue carrying it, open or closed.
+Pass --to more than once when splitting a label. Pull requests are only updated with
+--include-prs. Updates are applied in batches of --batch-size, waiting for the rate limit to
+reset whenever fewer than --min-rate-remaining requests are left.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireGitHubToken(); err != nil {
+			return err
+		}
+		for _, to := range migrateTo {
+			if to == migrateFrom {
+				return fmt.Errorf("--to must differ from --from")
+			}
+		}
+		return execMigrate(cmd.Context())
+	},
+}
+
+func execMigrate(ctx context.Context) (err error) {
+	ctx, endRun := labeler.StartRun(ctx, "migrate")
+	defer func() { endRun(err) }()
+
+	issues, err := labeler.ListIssuesWithLabel(ctx, repository, migrateFrom)
+	if err != nil {
+		return fmt.Errorf("getting github issues: %w", err)
+	}
+	updates := labeler.ComputeMigrationUpdates(issues, migrateFrom, migrateTo, migrateIncludePRs)
+
+	ctx, closeAudit, err := openAuditLog(ctx)
+	if err != nil {
+		return err
+	}
+	defer closeAudit(&err)
+
+	return labeler.MigrateLabels(ctx, repository, updates, migrateBatchSize, migrateMinRate, dryRun)
+}
+
+func init() {
+	rootCmd.AddCommand(migrate)
+	migrate.Flags().StringVar(&migrateFrom, "from", "", "Label to replace")
+	migrate.Flags().StringSliceVar(&migrateTo, "to", nil, "Label to apply instead (repeatable)")
+	migrate.Flags().BoolVar(&migrateIncludePRs, "include-prs", false, "Also relabel pull requests")
+	migrate.Flags().IntVar(&migrateBatchSize, "batch-size", 100, "Number of issues to update between rate limit checks")
+	migrate.Flags().IntVar(&migrateMinRate, "min-rate-remaining", 500, "Wait for the rate limit to reset when fewer requests than this are left")
+	migrate.MarkFlagRequired("from")
+	migrate.MarkFlagRequired("to")
+	addRetryFlags(migrate)
+	addAuditLogFlag(migrate)
+}
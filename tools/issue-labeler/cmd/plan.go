@@ -0,0 +1,107 @@
+/*
+* Copyright 2026 Google LLC. All Rights Reserved.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/GoogleCloudPlatform/magic-modules/tools/issue-labeler/labeler"
+)
+
+var (
+	// used for flags
+	planOut string
+)
+
+var plan = &cobra.Command{
+	Use:   "plan [--repo=owner/name]... [--repos-config=repos.yml] [--out=labeler-plan.json] [--since=1973-01-01] [--until=YYYY-MM-DD] [--state=all] [--label=name]...",
+	Short: "Writes the label updates a backfill would make to a plan file for review",
+	Long: `Computes the label updates a backfill with the same flags would make and writes them to a
+plan file, without changing any issues. The changes are also printed, one issue per line.
+
+Once the plan is reviewed, apply it with apply, which makes exactly the updates in the plan:
+
+  issue-labeler plan --repo=owner/name --out=plan.json
+  issue-labeler apply plan.json`,
+	Args:        cobra.NoArgs,
+	Annotations: map[string]string{annotationMultiRepo: "true"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireGitHubToken(); err != nil {
+			return err
+		}
+		if err := checkIssueFilterFlags(); err != nil {
+			return err
+		}
+		targets, err := loadRepoTargets(cmd.Flags().Changed("repo"))
+		if err != nil {
+			return err
+		}
+		return execPlan(cmd.Context(), targets)
+	},
+}
+
+func execPlan(ctx context.Context, targets []repoTarget) (err error) {
+	ctx, endRun := labeler.StartRun(ctx, "plan")
+	defer func() { endRun(err) }()
+
+	sinceTime, err := time.Parse("2006-01-02", since)
+	if err != nil {
+		return fmt.Errorf("invalid since time format: %w", err)
+	}
+	var repos []labeler.RepositoryUpdates
+	for _, target := range targets {
+		teamsYaml, err := loadConfigFile(target.Config)
+		if err != nil {
+			return err
+		}
+		issues, err := labeler.GetFilteredIssues(ctx, target.Name, sinceTime, issueFilter)
+		if err != nil {
+			return fmt.Errorf("getting github issues for %s: %w", target.Name, err)
+		}
+		updates, _, err := computeIssueUpdates(ctx, teamsYaml, issues)
+		if err != nil {
+			return err
+		}
+		repos = append(repos, labeler.RepositoryUpdates{Repository: target.Name, Updates: updates})
+	}
+
+	p := labeler.NewPlan(time.Now().UTC(), repos)
+	if err := p.Save(planOut); err != nil {
+		return err
+	}
+	if err := p.WriteSummary(os.Stdout); err != nil {
+		return err
+	}
+	slog.Info("wrote plan, review it and apply it with apply", "path", planOut, "updates", len(p.Updates))
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(plan)
+	addSinceFlag(plan)
+	addReposConfigFlag(plan)
+	addIssueFilterFlags(plan)
+	plan.Flags().StringVar(&planOut, "out", "labeler-plan.json", "File to write the plan to")
+	addPageConcurrencyFlag(plan)
+	addConflictFlags(plan)
+	addAutomationFlags(plan)
+}
@@ -0,0 +1,153 @@
+/*
+* Copyright 2026 Google LLC. All Rights Reserved.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/google/go-github/v68/github"
+	"github.com/spf13/cobra"
+
+	"github.com/GoogleCloudPlatform/magic-modules/tools/issue-labeler/labeler"
+)
+
+var (
+	// used for flags
+	reportFormat    string
+	analyticsFile   string
+	analyticsFormat string
+)
+
+var report = &cobra.Command{
+	Use:   "report [--repo=owner/name] [--since=1973-01-01] [--format=text|markdown|csv] [--analytics-file=path [--analytics-format=json|csv]]",
+	Short: "Summarizes issues by service label",
+	Long: `Summarizes issues opened since the given date: counts per service label, how many have no
+service label, the median time from an issue being opened to getting its first service label,
+and the resources most often listed as affected.
+
+--analytics-file also exports per service numbers for dashboards like Looker Studio or Sheets:
+how many issues are open now and how old they are, and how many were opened and closed since
+the given date.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireGitHubToken(); err != nil {
+			return err
+		}
+		if analyticsFile != "" && !slices.Contains(labeler.AnalyticsFormats, analyticsFormat) {
+			return fmt.Errorf("invalid analytics format %q, must be json or csv", analyticsFormat)
+		}
+		return execReport(cmd.Context())
+	},
+}
+
+func execReport(ctx context.Context) error {
+	sinceTime, err := time.Parse("2006-01-02", since)
+	if err != nil {
+		return fmt.Errorf("invalid since time format: %w", err)
+	}
+	updated, err := labeler.GetIssuesSince(ctx, repository, sinceTime)
+	if err != nil {
+		return fmt.Errorf("getting github issues: %w", err)
+	}
+	var issues []*github.Issue
+	for _, issue := range updated {
+		if !issue.GetCreatedAt().Before(sinceTime) {
+			issues = append(issues, issue)
+		}
+	}
+	labeledAt, err := labeler.ServiceLabelTimes(ctx, repository, issues)
+	if err != nil {
+		return err
+	}
+	if err := labeler.ComputeReport(issues, labeledAt).WriteFormat(os.Stdout, reportFormat); err != nil {
+		return err
+	}
+	if analyticsFile == "" {
+		return nil
+	}
+	return writeAnalytics(ctx, updated, sinceTime)
+}
+
+// writeAnalytics exports per service analytics to --analytics-file. Issues open now are listed
+// separately, since those not updated since sinceTime aren't in updated.
+func writeAnalytics(ctx context.Context, updated []*github.Issue, sinceTime time.Time) error {
+	open, err := labeler.GetFilteredIssues(ctx, repository, time.Time{}, labeler.IssueFilter{State: "open"})
+	if err != nil {
+		return fmt.Errorf("getting open github issues: %w", err)
+	}
+	analytics := labeler.ComputeAnalytics(repository, open, updated, sinceTime, time.Now())
+	f, err := os.Create(analyticsFile)
+	if err != nil {
+		return err
+	}
+	if err := analytics.WriteFormat(f, analyticsFormat); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+var (
+	// used for flags
+	slaDays int
+)
+
+var reportSLA = &cobra.Command{
+	Use:   "sla [--repo=owner/name] [--days=14]",
+	Short: "Lists issues waiting in forward/review past the SLA",
+	Long: `Lists open issues that have carried forward/review for at least --days days without being
+linked or exempted, grouped by service label and oldest first.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireGitHubToken(); err != nil {
+			return err
+		}
+		return execReportSLA(cmd.Context())
+	},
+}
+
+func execReportSLA(ctx context.Context) error {
+	issues, err := labeler.GetIssues(ctx, repository, since)
+	if err != nil {
+		return fmt.Errorf("getting github issues: %w", err)
+	}
+	labeledAt, err := labeler.ForwardReviewTimes(ctx, repository, issues)
+	if err != nil {
+		return err
+	}
+	threshold := time.Duration(slaDays) * 24 * time.Hour
+	return labeler.ComputeSLAReport(issues, labeledAt, threshold, time.Now()).Write(os.Stdout)
+}
+
+func init() {
+	rootCmd.AddCommand(report)
+	addSinceFlag(report)
+	report.Flags().StringVar(&reportFormat, "format", "text", "Report format: text, markdown or csv")
+	report.RegisterFlagCompletionFunc("format", cobra.FixedCompletions(labeler.ReportFormats, cobra.ShellCompDirectiveNoFileComp))
+	report.Flags().StringVar(&analyticsFile, "analytics-file", "", "Also write per service analytics for dashboards to this file")
+	report.Flags().StringVar(&analyticsFormat, "analytics-format", "json", "Analytics format: json or csv")
+	report.RegisterFlagCompletionFunc("analytics-format", cobra.FixedCompletions(labeler.AnalyticsFormats, cobra.ShellCompDirectiveNoFileComp))
+
+	report.AddCommand(reportSLA)
+	addSinceFlag(reportSLA)
+	reportSLA.Flags().IntVar(&slaDays, "days", 14, "Report issues that have been in forward/review for at least this many days")
+	addPageConcurrencyFlag(report)
+	addPageConcurrencyFlag(reportSLA)
+}
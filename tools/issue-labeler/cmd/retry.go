@@ -0,0 +1,111 @@
+/*
+* Copyright 2026 Google LLC. All Rights Reserved.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/spf13/cobra"
+
+	"github.com/GoogleCloudPlatform/magic-modules/tools/issue-labeler/labeler"
+)
+
+var (
+	// used for flags
+	retryFrom string
+)
+
+var retry = &cobra.Command{
+	Use:   "retry --from=PATH [--dry-run] [--failures-file=PATH]",
+	Short: "Reapplies the label updates a previous run failed to apply",
+	Long: `Reads the updates recorded in a --failures-file report and applies just those again, across
+whichever repositories they belong to. Issues that already have the recorded labels are skipped.
+Pass the same path as --failures-file to leave only the updates that still fail.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireGitHubToken(); err != nil {
+			return err
+		}
+		return execRetry(cmd.Context(), retryFrom)
+	},
+}
+
+func execRetry(ctx context.Context, from string) (err error) {
+	ctx, endRun := labeler.StartRun(ctx, "retry")
+	defer func() { endRun(err) }()
+
+	failures, err := labeler.LoadFailures(from)
+	if err != nil {
+		return err
+	}
+	if len(failures) == 0 {
+		slog.Info("no failed updates to retry", "path", from)
+		return nil
+	}
+
+	ctx, saveFailures := openFailureReport(ctx)
+	defer saveFailures(&err)
+
+	var repositories []string
+	updates := make(map[string][]labeler.IssueUpdate)
+	for _, failure := range failures {
+		issue, err := labeler.GetIssue(ctx, failure.Repository, failure.Number)
+		if err != nil {
+			return fmt.Errorf("getting github issue: %w", err)
+		}
+		var current []string
+		for _, l := range issue.Labels {
+			current = append(current, l.GetName())
+		}
+		if failure.AppliedTo(current) {
+			slog.Info("issue already has the recorded labels", "repo", failure.Repository, "number", failure.Number)
+			continue
+		}
+		if _, ok := updates[failure.Repository]; !ok {
+			repositories = append(repositories, failure.Repository)
+		}
+		updates[failure.Repository] = append(updates[failure.Repository], failure.Update())
+	}
+
+	ctx, closeAudit, err := openAuditLog(ctx)
+	if err != nil {
+		return err
+	}
+	defer closeAudit(&err)
+
+	failed := 0
+	for _, repo := range repositories {
+		if err := labeler.UpdateIssues(ctx, repo, updates[repo], dryRun); err != nil {
+			slog.Error("retrying updates failed", "repo", repo, "error", err)
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("retry failed for %d / %d repositories", failed, len(repositories))
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(retry)
+	retry.Flags().StringVar(&retryFrom, "from", "", "Failure report written by a previous run's --failures-file")
+	retry.MarkFlagRequired("from")
+	addRetryFlags(retry)
+	addAuditLogFlag(retry)
+	addFailuresFileFlag(retry)
+}
@@ -0,0 +1,109 @@
+/*
+* Copyright 2026 Google LLC. All Rights Reserved.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/spf13/cobra"
+
+	"github.com/GoogleCloudPlatform/magic-modules/tools/issue-labeler/labeler"
+)
+
+var (
+	// used for flags
+	rollbackRunID string
+)
+
+var rollback = &cobra.Command{
+	Use:   "rollback --run=RUN_ID --audit-log=PATH [--dry-run]",
+	Short: "Reverts the label changes made by a previous run",
+	Long: `Reads the changes a run recorded in the audit log and reverts them, restoring the labels
+each issue had before the run. Labels changed by anyone else since the run are kept. Use
+--dry-run to preview the changes first. The rollback itself is recorded as a new run.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if auditLogPath == "" {
+			return fmt.Errorf("--audit-log is required")
+		}
+		if err := requireGitHubToken(); err != nil {
+			return err
+		}
+		return execRollback(cmd.Context(), rollbackRunID)
+	},
+}
+
+func execRollback(ctx context.Context, runID string) (err error) {
+	ctx, endRun := labeler.StartRun(ctx, "rollback")
+	defer func() { endRun(err) }()
+
+	ctx, saveFailures := openFailureReport(ctx)
+	defer saveFailures(&err)
+
+	entries, err := labeler.ReadAuditLog(ctx, auditLogPath, runID)
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		return fmt.Errorf("no applied changes found for run %s", runID)
+	}
+
+	var repositories []string
+	updates := make(map[string][]labeler.IssueUpdate)
+	for _, change := range labeler.ComputeRollbackChanges(entries) {
+		issue, err := labeler.GetIssue(ctx, change.Repository, change.Number)
+		if err != nil {
+			return fmt.Errorf("getting github issue: %w", err)
+		}
+		var current []string
+		for _, l := range issue.Labels {
+			current = append(current, l.GetName())
+		}
+		update, ok := change.Revert(current)
+		if !ok {
+			slog.Info("issue already reverted", "repo", change.Repository, "number", change.Number)
+			continue
+		}
+		if _, ok := updates[change.Repository]; !ok {
+			repositories = append(repositories, change.Repository)
+		}
+		updates[change.Repository] = append(updates[change.Repository], update)
+	}
+
+	ctx, closeAudit, err := openAuditLog(ctx)
+	if err != nil {
+		return err
+	}
+	defer closeAudit(&err)
+
+	for _, repo := range repositories {
+		if err := labeler.UpdateIssues(ctx, repo, updates[repo], dryRun); err != nil {
+			return fmt.Errorf("updating github issues: %w", err)
+		}
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(rollback)
+	rollback.Flags().StringVar(&rollbackRunID, "run", "", "ID of the run to revert, as recorded in the audit log")
+	rollback.MarkFlagRequired("run")
+	addRetryFlags(rollback)
+	addAuditLogFlag(rollback)
+	addFailuresFileFlag(rollback)
+}
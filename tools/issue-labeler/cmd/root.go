@@ -16,10 +16,56 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"log/slog"
 	"os"
+	"time"
 
 	"github.com/spf13/cobra"
+
+	"github.com/GoogleCloudPlatform/magic-modules/tools/issue-labeler/labeler"
+)
+
+const defaultRepository = "hashicorp/terraform-provider-google"
+
+// annotationMultiRepo marks commands that accept --repo more than once.
+const annotationMultiRepo = "multi-repo"
+
+// annotationStdoutData marks commands that write data to stdout, so logs go to stderr instead.
+const annotationStdoutData = "stdout-data"
+
+var (
+	// used for persistent flags shared by all subcommands
+	repositories []string
+	dryRun       bool
+	// repository is the first --repo, for commands that work on a single repository
+	repository string
+	configPath string
+	otlpURL    string
+	logFormat  string
+	logLevel   string
+
+	// used for the flags of the subcommands that list, label and update issues. They keep their
+	// defaults for the subcommands that don't add the flags.
+	auditLogPath     string
+	failuresPath     string
+	storePath        string
+	pageConcurrency  = labeler.DefaultPageConcurrency
+	triageComments   bool
+	mentionTeams     bool
+	conflictPolicy   = labeler.ConflictPolicy{TriageLabel: labeler.DefaultTriageLabel}
+	automationPolicy = labeler.AutomationPolicy{Authors: labeler.DefaultAutomationAuthors, Label: labeler.DefaultAutomationLabel}
+	retryPolicy      = labeler.DefaultRetryPolicy
+
+	// used for --since and --window by the subcommands that list issues
+	since  string
+	window time.Duration
+
+	// used for --output and --interactive by the subcommands that compute issue updates
+	output      string
+	interactive bool
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -28,14 +74,261 @@ var rootCmd = &cobra.Command{
 	Use:   "issue-labeler",
 	Short: "Tool for interacting with issue labels (specifically for services)",
 	Long:  `Tool for interacting with issue labels (specifically for services)`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if len(repositories) == 0 {
+			return fmt.Errorf("--repo must not be empty")
+		}
+		if len(repositories) > 1 && cmd.Annotations[annotationMultiRepo] != "true" {
+			return fmt.Errorf("%s only supports a single --repo", cmd.Name())
+		}
+		repository = repositories[0]
+
+		// Keep stdout clean for machine-readable output.
+		logOut := os.Stdout
+		if output != "" || reportFormat != "text" || cmd.Annotations[annotationStdoutData] == "true" {
+			logOut = os.Stderr
+		}
+		logger, err := labeler.NewLogger(logOut, logFormat, logLevel)
+		if err != nil {
+			return err
+		}
+		slog.SetDefault(logger)
+
+		shutdown, err := labeler.InitTracing(cmd.Context(), otlpURL)
+		if err != nil {
+			return err
+		}
+		shutdownTracing = shutdown
+
+		if pageConcurrency < 1 {
+			return fmt.Errorf("--page-concurrency must be at least 1")
+		}
+		if err := conflictPolicy.Validate(); err != nil {
+			return err
+		}
+		if err := automationPolicy.Validate(); err != nil {
+			return err
+		}
+		if err := retryPolicy.Validate(); err != nil {
+			return err
+		}
+		ctx := labeler.WithPageConcurrency(cmd.Context(), pageConcurrency)
+		ctx = labeler.WithAutomationPolicy(ctx, automationPolicy)
+		ctx = labeler.WithRetryPolicy(ctx, retryPolicy)
+		cmd.SetContext(labeler.WithConflictPolicy(ctx, conflictPolicy))
+		return nil
+	},
+}
+
+// shutdownTracing flushes pending spans; it is replaced once tracing is initialized.
+var shutdownTracing = func(context.Context) error { return nil }
+
+// loadConfig returns the enrolled teams config from --config, or the embedded copy if unset.
+func loadConfig() ([]byte, error) {
+	return loadConfigFile(configPath)
+}
+
+// loadConfigFile returns the enrolled teams config at path, a local file or gs://bucket/object,
+// falling back to --config and then the embedded copy when path is empty.
+func loadConfigFile(path string) ([]byte, error) {
+	if path == "" {
+		path = configPath
+	}
+	if path == "" {
+		return labeler.EnrolledTeamsYaml, nil
+	}
+	return labeler.ReadConfig(context.Background(), path)
+}
+
+// loadRegexLabels builds the labeling rules from the configured enrolled teams file.
+func loadRegexLabels() ([]labeler.RegexpLabel, error) {
+	teamsYaml, err := loadConfig()
+	if err != nil {
+		return nil, err
+	}
+	regexpLabels, err := labeler.BuildRegexLabels(teamsYaml)
+	if err != nil {
+		return nil, fmt.Errorf("building regex labels: %w", err)
+	}
+	return regexpLabels, nil
+}
+
+// openAuditLog starts a new run in the --audit-log destination, if set, and returns a context
+// recording to it. The returned func closes the log, reporting failures through err.
+func openAuditLog(ctx context.Context) (context.Context, func(err *error), error) {
+	audit, err := labeler.OpenAuditLog(auditLogPath, labeler.NewRunID())
+	if err != nil {
+		return ctx, nil, err
+	}
+	if audit != nil {
+		slog.Info("recording audit log", "run", audit.RunID, "destination", auditLogPath)
+	}
+	closeAudit := func(err *error) {
+		if cerr := audit.Close(ctx); cerr != nil && *err == nil {
+			*err = fmt.Errorf("closing audit log: %w", cerr)
+		}
+	}
+	return labeler.WithAuditLog(ctx, audit), closeAudit, nil
+}
+
+// openFailureReport returns a context recording updates that fail to apply in --failures-file,
+// if set. The returned func saves the report, reporting failures through err.
+func openFailureReport(ctx context.Context) (context.Context, func(err *error)) {
+	var report *labeler.FailureReport
+	if failuresPath != "" {
+		report = labeler.NewFailureReport(failuresPath)
+	}
+	saveReport := func(err *error) {
+		if serr := report.Save(); serr != nil && *err == nil {
+			*err = serr
+		}
+		if report != nil && len(report.Failures) > 0 {
+			slog.Info("wrote failed updates, reapply them with retry", "path", failuresPath, "failures", len(report.Failures))
+		}
+	}
+	return labeler.WithFailureReport(ctx, report), saveReport
+}
+
+// openStore opens the --store database, returning nil if it isn't set.
+func openStore() (*labeler.Store, error) {
+	if storePath == "" {
+		return nil, nil
+	}
+	return labeler.OpenStore(storePath)
+}
+
+// addAuditLogFlag adds --audit-log, for the subcommands that apply label changes.
+func addAuditLogFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&auditLogPath, "audit-log", "", "Append every applied label change to this JSONL file, or upload each run's changes under gs://bucket/prefix")
+}
+
+// addFailuresFileFlag adds --failures-file, for the subcommands whose failed updates can be
+// retried.
+func addFailuresFileFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&failuresPath, "failures-file", "", "Write the updates that failed to apply to this JSON file, for the retry command")
+}
+
+// addStoreFlag adds --store, for the subcommands that only look at issues changed since their
+// last sync.
+func addStoreFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&storePath, "store", "", "Embedded database recording processed issues and the last sync, so runs only look at what changed since")
+}
+
+// addPageConcurrencyFlag adds --page-concurrency, for the subcommands that list issues.
+func addPageConcurrencyFlag(cmd *cobra.Command) {
+	cmd.Flags().IntVar(&pageConcurrency, "page-concurrency", labeler.DefaultPageConcurrency, "Maximum pages of issues to fetch at once when listing issues")
+}
+
+// addRetryFlags adds the flags controlling how failed label updates are retried.
+func addRetryFlags(cmd *cobra.Command) {
+	cmd.Flags().IntVar(&retryPolicy.Retries, "retries", labeler.DefaultRetryPolicy.Retries, "Retry label updates that fail with rate limits, server or network errors this many times; permanent failures like missing issues aren't retried")
+	cmd.Flags().DurationVar(&retryPolicy.MaxDelay, "max-retry-delay", labeler.DefaultRetryPolicy.MaxDelay, "Longest to wait before retrying a failed label update; failures GitHub asks to wait longer for aren't retried")
+}
+
+// addConflictFlags adds the flags deciding which service labels issues matching several
+// services get.
+func addConflictFlags(cmd *cobra.Command) {
+	cmd.Flags().IntVar(&conflictPolicy.MaxLabels, "max-service-labels", 0, "Add at most this many service labels to an issue, preferring the services with the most matched resources (0 for no limit)")
+	cmd.Flags().IntVar(&conflictPolicy.TriageAbove, "triage-above", 0, "Add --triage-label instead of service labels to issues matching more than this many services (0 to disable)")
+	cmd.Flags().StringVar(&conflictPolicy.TriageLabel, "triage-label", labeler.DefaultTriageLabel, "Label for issues whose service can't be decided under --max-service-labels or --triage-above")
+	cmd.Flags().StringVar(&conflictPolicy.UnroutableLabel, "unroutable-label", "", "Label for issues the rules can't route to any service, e.g. service/unknown or needs-triage; they're listed as unroutable in run summaries either way")
+}
+
+// addAutomationFlags adds the flags deciding which issues are treated as opened by automation.
+func addAutomationFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSliceVar(&automationPolicy.Authors, "automation-author", labeler.DefaultAutomationAuthors, "Login of a bot whose issues get --automation-label instead of being forwarded for review; repeat for several, or pass an empty value for none")
+	cmd.Flags().StringVar(&automationPolicy.Label, "automation-label", labeler.DefaultAutomationLabel, "Label for issues opened by an --automation-author")
+}
+
+// addRoutingFlags adds the flags for what happens to issues newly routed to a service.
+func addRoutingFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&triageComments, "triage-comments", false, "Comment next steps for the owning team on issues newly labeled forward/review, using each service's triage_comment template")
+	cmd.Flags().BoolVar(&mentionTeams, "mention-teams", false, "@-mention each service's github_team once when its issues are first routed to it")
+}
+
+func addSinceFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&since, "since", "1973-01-01", "Only consider issues updated after given date (YYYY-MM-DD)")
+}
+
+// addStateFlag adds --state, which narrows a command down to open or closed issues.
+func addStateFlag(cmd *cobra.Command, state *string, value string) {
+	cmd.Flags().StringVar(state, "state", value, "Only consider issues in this state: open, closed or all")
+	cmd.RegisterFlagCompletionFunc("state", cobra.FixedCompletions([]string{"open", "closed", "all"}, cobra.ShellCompDirectiveNoFileComp))
+}
+
+func addWindowFlag(cmd *cobra.Command) {
+	cmd.Flags().DurationVar(&window, "window", 0, "Re-examine issues updated this long before the last sync on every incremental run, to catch missed events (e.g. 6h)")
+}
+
+func addOutputFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&output, "output", "", "Write the proposed updates to stdout instead of logging them: json or csv (requires --dry-run)")
+	cmd.RegisterFlagCompletionFunc("output", cobra.FixedCompletions(labeler.OutputFormats, cobra.ShellCompDirectiveNoFileComp))
+}
+
+func addInteractiveFlag(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&interactive, "interactive", false, "Review each proposed update and accept, skip or edit it before applying")
+}
+
+// checkOutputFlag rejects --output outside of dry-run, where updates are applied rather than
+// proposed, and alongside --interactive, which reviews them instead.
+func checkOutputFlag() error {
+	if output != "" && !dryRun {
+		return fmt.Errorf("--output requires --dry-run")
+	}
+	if output != "" && interactive {
+		return fmt.Errorf("--output can't be combined with --interactive")
+	}
+	return nil
+}
+
+func requireGitHubToken() error {
+	if _, ok := os.LookupEnv("GITHUB_TOKEN"); !ok {
+		return fmt.Errorf("did not provide GITHUB_TOKEN environment variable")
+	}
+	return nil
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
-	err := rootCmd.Execute()
+	ctx, stop := shutdownContext()
+	err := rootCmd.ExecuteContext(ctx)
+	stop()
+	if serr := shutdownTracing(context.Background()); serr != nil {
+		slog.Error("flushing traces failed", "error", serr)
+	}
 	if err != nil {
-		fmt.Println(err)
+		slog.Error(err.Error())
+		var exitErr *exitError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.code)
+		}
+		if errors.Is(err, labeler.ErrInterrupted) {
+			os.Exit(exitInterrupted)
+		}
 		os.Exit(1)
 	}
 }
+
+// exitError is an error that exits with a specific code rather than 1, for results that
+// callers such as CI jobs need to tell apart from failures.
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string { return e.err.Error() }
+
+func (e *exitError) Unwrap() error { return e.err }
+
+func init() {
+	rootCmd.PersistentFlags().StringSliceVar(&repositories, "repo", []string{defaultRepository}, "Repository to operate on, in owner/name form (backfill accepts it more than once)")
+	rootCmd.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "Only log write actions instead of updating issues")
+	rootCmd.PersistentFlags().StringVar(&configPath, "config", "", "Path or gs://bucket/object of an enrolled teams config (defaults to the embedded enrolled_teams.yml)")
+	rootCmd.MarkPersistentFlagFilename("config", "yml", "yaml")
+	rootCmd.PersistentFlags().StringVar(&logFormat, "log-format", "text", "Log output format: text or json")
+	rootCmd.PersistentFlags().StringVar(&logLevel, "log-level", "info", "Minimum log level: debug, info, warn or error")
+	rootCmd.RegisterFlagCompletionFunc("log-format", cobra.FixedCompletions([]string{"text", "json"}, cobra.ShellCompDirectiveNoFileComp))
+	rootCmd.RegisterFlagCompletionFunc("log-level", cobra.FixedCompletions([]string{"debug", "info", "warn", "error"}, cobra.ShellCompDirectiveNoFileComp))
+	rootCmd.PersistentFlags().StringVar(&otlpURL, "otlp-endpoint", "", "OTLP/HTTP endpoint to export traces to, e.g. http://localhost:4318 (tracing is off when unset)")
+}
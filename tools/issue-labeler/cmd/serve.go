@@ -0,0 +1,143 @@
+/*
+* Copyright 2026 Google LLC. All Rights Reserved.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/GoogleCloudPlatform/magic-modules/tools/issue-labeler/labeler"
+)
+
+var (
+	// used for flags
+	serveAddr      string
+	reloadInterval time.Duration
+)
+
+const shutdownTimeout = 30 * time.Second
+
+var serve = &cobra.Command{
+	Use:   "serve [--addr=:8080] [--dry-run]",
+	Short: "Labels issues in real time from GitHub webhooks",
+	Long: `Listens for issues.opened and issues.edited webhook deliveries on /webhook and labels
+the issue immediately. Deliveries are verified against GITHUB_WEBHOOK_SECRET. /healthz reports
+whether the server is up and /metrics exposes Prometheus metrics.
+
+When an issue's body is edited, the labels derived from the old and new body are compared and
+only the difference is applied, so labels changed by hand since are left alone.
+
+With --reload-interval, --config is polled for changes and the rules are reloaded without a
+restart, logging which rules were added and removed. A config that fails to load is logged and
+the current rules stay in place.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireGitHubToken(); err != nil {
+			return err
+		}
+		secret, ok := os.LookupEnv("GITHUB_WEBHOOK_SECRET")
+		if !ok || secret == "" {
+			return fmt.Errorf("did not provide GITHUB_WEBHOOK_SECRET environment variable")
+		}
+		if reloadInterval > 0 && configPath == "" {
+			return fmt.Errorf("--reload-interval requires --config")
+		}
+		return execServe([]byte(secret))
+	},
+}
+
+func execServe(secret []byte) (err error) {
+	teamsYaml, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	handler, err := labeler.NewWebhookHandler(teamsYaml, secret, dryRun)
+	if err != nil {
+		return err
+	}
+	handler.Policy = conflictPolicy
+	handler.Automation = automationPolicy
+	handler.Audit, err = labeler.OpenAuditLog(auditLogPath, labeler.NewRunID())
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := handler.Audit.Close(context.Background()); cerr != nil && err == nil {
+			err = fmt.Errorf("closing audit log: %w", cerr)
+		}
+	}()
+	srv := &http.Server{
+		Addr:              serveAddr,
+		Handler:           labeler.NewWebhookMux(handler),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if reloadInterval > 0 {
+		go labeler.WatchConfig(ctx, configPath, reloadInterval, teamsYaml, func(b []byte) error {
+			diff, err := handler.Reload(b)
+			if err != nil {
+				return err
+			}
+			slog.Info("reloaded config", "config", configPath, "rules_added", diff.Added, "rules_removed", diff.Removed)
+			return nil
+		})
+	}
+
+	errc := make(chan error, 1)
+	go func() {
+		slog.Info("listening", "addr", serveAddr)
+		errc <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errc:
+		return fmt.Errorf("serving: %w", err)
+	case <-ctx.Done():
+	}
+
+	slog.Info("shutting down, waiting for in-flight deliveries")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("shutting down: %w", err)
+	}
+	if err := <-errc; !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("serving: %w", err)
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(serve)
+	serve.Flags().StringVar(&serveAddr, "addr", ":8080", "Address to listen on")
+	serve.Flags().DurationVar(&reloadInterval, "reload-interval", 0, "Poll --config this often and reload changed rules without restarting (0 disables)")
+	addConflictFlags(serve)
+	addAutomationFlags(serve)
+	addRetryFlags(serve)
+	addAuditLogFlag(serve)
+}
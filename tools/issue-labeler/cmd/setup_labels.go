@@ -16,9 +16,6 @@
 package cmd
 
 import (
-	"flag"
-	"fmt"
-
 	"github.com/spf13/cobra"
 
 	"github.com/GoogleCloudPlatform/magic-modules/tools/issue-labeler/constants"
@@ -26,20 +23,24 @@ import (
 )
 
 var setupLabels = &cobra.Command{
-	Use:   "setup-labels",
+	Use:   "setup-labels [--repo=owner/name]",
 	Short: "Sets up labels for the relevant services",
-	Long:  "Computes labels that should be added to an issue based on its body",
+	Long:  "Creates or recolors the service labels used by the enrolled teams config",
+	Args:  cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		repo := args[0]
+		repo := repository
+		// Positional repository is still accepted for older callers.
+		if len(args) == 1 {
+			repo = args[0]
+		}
 		return execSetupLabels(repo)
 	},
 }
 
 func execSetupLabels(repo string) error {
-	flag.Set("logtostderr", "true")
-	regexpLabels, err := labeler.BuildRegexLabels(labeler.EnrolledTeamsYaml)
+	regexpLabels, err := loadRegexLabels()
 	if err != nil {
-		return fmt.Errorf("building regex labels: %w", err)
+		return err
 	}
 	var serviceLabels = make([]string, 0, len(regexpLabels))
 	var serviceLabelMap = map[string]bool{}
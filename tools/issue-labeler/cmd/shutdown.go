@@ -0,0 +1,59 @@
+/*
+* Copyright 2026 Google LLC. All Rights Reserved.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+package cmd
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/GoogleCloudPlatform/magic-modules/tools/issue-labeler/labeler"
+)
+
+// exitInterrupted is the exit code of runs stopped by SIGINT or SIGTERM, like a shell's.
+const exitInterrupted = 130
+
+// shutdownContext returns the context commands run in. The first SIGINT or SIGTERM asks runs
+// to finish the updates in flight and stop, returning labeler.ErrInterrupted so checkpoints,
+// audit logs and failure reports are flushed as usual; a second one cancels the context,
+// cutting off requests in flight. Call stop once the command is done.
+func shutdownContext() (ctx context.Context, stop func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	sigs := make(chan os.Signal, 2)
+	signal.Notify(sigs, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		select {
+		case sig := <-sigs:
+			slog.Warn("shutting down once the work in flight finishes, signal again to stop immediately", "signal", sig.String())
+			close(done)
+		case <-ctx.Done():
+			return
+		}
+		select {
+		case sig := <-sigs:
+			slog.Warn("stopping immediately", "signal", sig.String())
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return labeler.WithShutdown(ctx, done), func() {
+		signal.Stop(sigs)
+		cancel()
+	}
+}
@@ -0,0 +1,111 @@
+/*
+* Copyright 2026 Google LLC. All Rights Reserved.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/template"
+	"time"
+
+	"github.com/google/go-github/v68/github"
+	"github.com/spf13/cobra"
+
+	"github.com/GoogleCloudPlatform/magic-modules/tools/issue-labeler/labeler"
+)
+
+var (
+	// used for flags
+	daysUntilStale  int
+	daysUntilClose  int
+	staleLabel      string
+	exemptLabels    []string
+	staleCommentTpl string
+)
+
+var stale = &cobra.Command{
+	Use:   "stale [--repo=owner/name] [--dry-run] [--days-until-stale=365] [--days-until-close=30]",
+	Short: "Marks inactive issues stale and closes them after a grace period",
+	Long: `Labels open issues with no activity for --days-until-stale days and posts a warning comment.
+Stale issues are closed as not planned once --days-until-close days pass without a reply, and
+unlabeled if anyone comments in the meantime. Issues with any --exempt-label are left alone.
+
+--comment-template is a Go text/template file rendered with .Number, .Author, .Label,
+.DaysUntilStale and .DaysUntilClose.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireGitHubToken(); err != nil {
+			return err
+		}
+		if daysUntilStale <= 0 || daysUntilClose <= 0 {
+			return fmt.Errorf("--days-until-stale and --days-until-close must be positive")
+		}
+		text := labeler.DefaultStaleComment
+		if staleCommentTpl != "" {
+			b, err := os.ReadFile(staleCommentTpl)
+			if err != nil {
+				return fmt.Errorf("reading comment template: %w", err)
+			}
+			text = string(b)
+		}
+		tpl, err := template.New("stale").Option("missingkey=error").Parse(text)
+		if err != nil {
+			return fmt.Errorf("parsing comment template: %w", err)
+		}
+		return execStale(cmd.Context(), labeler.StaleConfig{
+			StaleAfter:   time.Duration(daysUntilStale) * 24 * time.Hour,
+			CloseAfter:   time.Duration(daysUntilClose) * 24 * time.Hour,
+			Label:        staleLabel,
+			ExemptLabels: exemptLabels,
+			Comment:      tpl,
+		})
+	},
+}
+
+func execStale(ctx context.Context, cfg labeler.StaleConfig) (err error) {
+	ctx, endRun := labeler.StartRun(ctx, "stale")
+	defer func() { endRun(err) }()
+
+	allIssues, err := labeler.GetIssues(ctx, repository, since)
+	if err != nil {
+		return fmt.Errorf("getting github issues: %w", err)
+	}
+	var issues []*github.Issue
+	for _, issue := range allIssues {
+		if issue.GetState() == "open" && !issue.IsPullRequest() {
+			issues = append(issues, issue)
+		}
+	}
+
+	actions, err := labeler.ComputeStaleActions(ctx, repository, issues, cfg, time.Now())
+	if err != nil {
+		return err
+	}
+	return labeler.ApplyStaleActions(ctx, repository, actions, cfg.Label, dryRun)
+}
+
+func init() {
+	rootCmd.AddCommand(stale)
+	addSinceFlag(stale)
+	stale.Flags().IntVar(&daysUntilStale, "days-until-stale", 365, "Days without activity before an issue is marked stale")
+	stale.Flags().IntVar(&daysUntilClose, "days-until-close", 30, "Days a stale issue stays open without a reply before it is closed")
+	stale.Flags().StringVar(&staleLabel, "stale-label", "stale", "Label applied to stale issues")
+	stale.Flags().StringSliceVar(&exemptLabels, "exempt-label", []string{"forward/exempt", "pinned", "security"}, "Labels that exempt an issue from being marked stale (repeatable)")
+	stale.Flags().StringVar(&staleCommentTpl, "comment-template", "", "Go text/template file for the warning comment (defaults to a built-in message)")
+	stale.MarkFlagFilename("comment-template")
+	addPageConcurrencyFlag(stale)
+}
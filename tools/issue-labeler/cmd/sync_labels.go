@@ -0,0 +1,74 @@
+/*
+* Copyright 2026 Google LLC. All Rights Reserved.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/spf13/cobra"
+
+	"github.com/GoogleCloudPlatform/magic-modules/tools/issue-labeler/labeler"
+)
+
+var syncLabels = &cobra.Command{
+	Use:   "sync-labels [--repo=owner/name]... [--dry-run]",
+	Short: "Ensures every label the rules can apply exists",
+	Long: `Creates or updates every label the labeler can apply in each --repo: the service labels in the
+enrolled teams config, with their configured color and description, and the workflow labels
+like forward/review, --triage-label and --automation-label.
+
+Run it before a backfill, or pass --sync-labels to backfill, so no update fails on a missing
+label.`,
+	Args:        cobra.NoArgs,
+	Annotations: map[string]string{annotationMultiRepo: "true"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireGitHubToken(); err != nil {
+			return err
+		}
+		teamsYaml, err := loadConfig()
+		if err != nil {
+			return err
+		}
+		for _, repo := range repositories {
+			if err := execSyncLabels(cmd.Context(), repo, teamsYaml); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+// execSyncLabels syncs the labels the given enrolled teams config can apply to repo.
+func execSyncLabels(ctx context.Context, repo string, teamsYaml []byte) error {
+	labels, err := labeler.EmittedLabels(teamsYaml, conflictPolicy, automationPolicy)
+	if err != nil {
+		return err
+	}
+	changes, err := labeler.SyncLabels(ctx, repo, labels, dryRun)
+	if err != nil {
+		return fmt.Errorf("syncing labels in %s: %w", repo, err)
+	}
+	slog.Info("synced labels", "repo", repo, "labels", len(labels), "changes", len(changes))
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(syncLabels)
+	addConflictFlags(syncLabels)
+	addAutomationFlags(syncLabels)
+}
@@ -0,0 +1,124 @@
+/*
+* Copyright 2026 Google LLC. All Rights Reserved.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/GoogleCloudPlatform/magic-modules/tools/issue-labeler/labeler"
+)
+
+var (
+	// used for flags
+	testRulesBody string
+)
+
+var testRules = &cobra.Command{
+	Use:   "test-rules [--body=issue.md] [--config=enrolled_teams.yml]",
+	Short: "Shows how the rules label a single issue body",
+	Long: `Prints the resources found in an issue body, which rule matched each of them, the resources
+no rule matched and the resulting labels. Reads the body from --body, or from stdin when --body
+is unset or "-". Useful for debugging why an issue wasn't routed.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return execTestRules(cmd.InOrStdin(), cmd.OutOrStdout())
+	},
+}
+
+func execTestRules(stdin io.Reader, w io.Writer) error {
+	regexpLabels, err := loadRegexLabels()
+	if err != nil {
+		return err
+	}
+	var body []byte
+	if testRulesBody == "" || testRulesBody == "-" {
+		body, err = io.ReadAll(stdin)
+	} else {
+		body, err = os.ReadFile(testRulesBody)
+	}
+	if err != nil {
+		return fmt.Errorf("reading issue body: %w", err)
+	}
+
+	form := labeler.ParseIssueForm(string(body))
+	sections := make(map[string][]string)
+	sections[labeler.SectionAffectedResources] = labeler.ExtractAffectedResources(string(body))
+	for section := range form {
+		if section == labeler.SectionAffectedResources {
+			continue
+		}
+		if resources := form.Resources(section); len(resources) > 0 {
+			sections[section] = resources
+		}
+	}
+	var names []string
+	for section := range sections {
+		names = append(names, section)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, section := range names {
+		fmt.Fprintf(&b, "Resources in %s:\n", section)
+		if len(sections[section]) == 0 {
+			b.WriteString("  (none)\n")
+		}
+		for _, resource := range sections[section] {
+			fmt.Fprintf(&b, "  %s\n", resource)
+		}
+	}
+
+	matches := labeler.MatchIssueLabels(string(body), regexpLabels)
+	matched := make(map[string]struct{})
+	b.WriteString("Matches:\n")
+	if len(matches) == 0 {
+		b.WriteString("  (none)\n")
+	}
+	for _, m := range matches {
+		matched[m.Resource] = struct{}{}
+		fmt.Fprintf(&b, "  %s <- %s (%s, pattern %s)\n", m.Label, m.Resource, m.Section, m.Pattern)
+	}
+
+	var unmatched []string
+	for _, resource := range sections[labeler.SectionAffectedResources] {
+		if _, ok := matched[resource]; !ok {
+			unmatched = append(unmatched, resource)
+		}
+	}
+	if len(unmatched) > 0 {
+		b.WriteString("Unmatched affected resources:\n")
+		for _, resource := range unmatched {
+			fmt.Fprintf(&b, "  %s\n", resource)
+		}
+	}
+
+	labels := labeler.ComputeIssueLabels(string(body), regexpLabels)
+	fmt.Fprintf(&b, "Labels: %s\n", strings.Join(labels, ", "))
+	_, err = io.WriteString(w, b.String())
+	return err
+}
+
+func init() {
+	rootCmd.AddCommand(testRules)
+	testRules.Flags().StringVar(&testRulesBody, "body", "", `File containing the issue body, or "-" for stdin (the default)`)
+	testRules.MarkFlagFilename("body", "md", "txt")
+}
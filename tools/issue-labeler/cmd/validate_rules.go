@@ -0,0 +1,54 @@
+/*
+* Copyright 2026 Google LLC. All Rights Reserved.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/GoogleCloudPlatform/magic-modules/tools/issue-labeler/labeler"
+)
+
+var validateRules = &cobra.Command{
+	Use:   "validate-rules [--config=enrolled_teams.yml]",
+	Short: "Checks the enrolled teams config for mistakes",
+	Long:  "Checks the enrolled teams config for invalid patterns, duplicate resources and malformed entries",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return execValidateRules()
+	},
+}
+
+func execValidateRules() error {
+	teamsYaml, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	errs := labeler.ValidateRules(teamsYaml)
+	for _, err := range errs {
+		fmt.Println(err)
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("found %d problems in rules", len(errs))
+	}
+	fmt.Println("Rules are valid")
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(validateRules)
+}
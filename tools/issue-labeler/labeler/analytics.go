@@ -0,0 +1,171 @@
+package labeler
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v68/github"
+)
+
+// AnalyticsFormats are the formats Analytics can be written in.
+var AnalyticsFormats = []string{"json", "csv"}
+
+// analyticsAgeBuckets group open issues by age. The last bucket has no upper bound.
+var analyticsAgeBuckets = []struct {
+	Name string
+	Max  time.Duration
+}{
+	{"0_7d", 7 * 24 * time.Hour},
+	{"8_30d", 30 * 24 * time.Hour},
+	{"31_90d", 90 * 24 * time.Hour},
+	{"91_365d", 365 * 24 * time.Hour},
+	{"over_365d", 0},
+}
+
+// Analytics is per service issue data for dashboards, like Looker Studio or Sheets. Each
+// service is a flat row so it can be ingested as is.
+type Analytics struct {
+	Repository  string             `json:"repository"`
+	GeneratedAt time.Time          `json:"generated_at"`
+	Since       time.Time          `json:"since"`
+	Services    []ServiceAnalytics `json:"services"`
+}
+
+// ServiceAnalytics are the numbers for one service label. Issues without a service label are
+// grouped under "(no service label)", and issues with several are counted under each.
+type ServiceAnalytics struct {
+	Service string `json:"service"`
+	// Open is how many issues are open now, and OpenByAge how many of them fall in each age
+	// bucket, keyed like "8_30d".
+	Open      int            `json:"open"`
+	OpenByAge map[string]int `json:"open_by_age"`
+	// Opened and Closed are the inflow and outflow: issues opened and closed since Since.
+	Opened int `json:"opened"`
+	Closed int `json:"closed"`
+}
+
+// ComputeAnalytics computes per service analytics from all open issues and the issues updated
+// since since, which include every issue opened or closed since then. Pull requests are
+// skipped.
+func ComputeAnalytics(repository string, open, updated []*github.Issue, since, now time.Time) Analytics {
+	byService := make(map[string]*ServiceAnalytics)
+	service := func(name string) *ServiceAnalytics {
+		s, ok := byService[name]
+		if !ok {
+			s = &ServiceAnalytics{Service: name, OpenByAge: make(map[string]int)}
+			for _, b := range analyticsAgeBuckets {
+				s.OpenByAge[b.Name] = 0
+			}
+			byService[name] = s
+		}
+		return s
+	}
+
+	for _, issue := range open {
+		if issue.IsPullRequest() {
+			continue
+		}
+		bucket := ageBucket(now.Sub(issue.GetCreatedAt().Time))
+		for _, label := range issueServices(issue) {
+			s := service(label)
+			s.Open++
+			s.OpenByAge[bucket]++
+		}
+	}
+	for _, issue := range updated {
+		if issue.IsPullRequest() {
+			continue
+		}
+		opened := !issue.GetCreatedAt().Time.Before(since)
+		closed := issue.ClosedAt != nil && !issue.GetClosedAt().Time.Before(since)
+		if !opened && !closed {
+			continue
+		}
+		for _, label := range issueServices(issue) {
+			s := service(label)
+			if opened {
+				s.Opened++
+			}
+			if closed {
+				s.Closed++
+			}
+		}
+	}
+
+	analytics := Analytics{Repository: repository, GeneratedAt: now, Since: since, Services: []ServiceAnalytics{}}
+	for _, s := range byService {
+		analytics.Services = append(analytics.Services, *s)
+	}
+	sort.Slice(analytics.Services, func(i, j int) bool {
+		return analytics.Services[i].Service < analytics.Services[j].Service
+	})
+	return analytics
+}
+
+// issueServices returns an issue's service labels, or noServiceLabel if it has none.
+func issueServices(issue *github.Issue) []string {
+	var services []string
+	for _, label := range issue.Labels {
+		if strings.HasPrefix(label.GetName(), "service/") {
+			services = append(services, label.GetName())
+		}
+	}
+	if len(services) == 0 {
+		return []string{noServiceLabel}
+	}
+	return services
+}
+
+func ageBucket(age time.Duration) string {
+	for _, b := range analyticsAgeBuckets {
+		if b.Max == 0 || age <= b.Max {
+			return b.Name
+		}
+	}
+	return analyticsAgeBuckets[len(analyticsAgeBuckets)-1].Name
+}
+
+// WriteFormat writes the analytics as json or csv.
+func (a Analytics) WriteFormat(w io.Writer, format string) error {
+	switch format {
+	case "json":
+		return a.WriteJSON(w)
+	case "csv":
+		return a.WriteCSV(w)
+	}
+	return fmt.Errorf("invalid analytics format %q, must be one of %s", format, strings.Join(AnalyticsFormats, ", "))
+}
+
+// WriteJSON writes the analytics as an indented JSON object.
+func (a Analytics) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(a)
+}
+
+// WriteCSV writes one row per service, with a column per age bucket, dated so rows from
+// successive runs can be appended to the same sheet.
+func (a Analytics) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	header := []string{"date", "repository", "service", "open"}
+	for _, b := range analyticsAgeBuckets {
+		header = append(header, "open_"+b.Name)
+	}
+	cw.Write(append(header, "opened", "closed"))
+	date := a.GeneratedAt.Format("2006-01-02")
+	for _, s := range a.Services {
+		row := []string{date, a.Repository, s.Service, strconv.Itoa(s.Open)}
+		for _, b := range analyticsAgeBuckets {
+			row = append(row, strconv.Itoa(s.OpenByAge[b.Name]))
+		}
+		cw.Write(append(row, strconv.Itoa(s.Opened), strconv.Itoa(s.Closed)))
+	}
+	cw.Flush()
+	return cw.Error()
+}
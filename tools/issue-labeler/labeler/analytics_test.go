@@ -0,0 +1,83 @@
+package labeler
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v68/github"
+)
+
+func TestComputeAnalytics(t *testing.T) {
+	now := time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC)
+	since := now.Add(-30 * 24 * time.Hour)
+	day := 24 * time.Hour
+	issue := func(number int, created time.Duration, closed time.Duration, labels ...string) *github.Issue {
+		i := &github.Issue{Number: github.Ptr(number), CreatedAt: &github.Timestamp{Time: now.Add(-created)}}
+		if closed > 0 {
+			i.ClosedAt = &github.Timestamp{Time: now.Add(-closed)}
+		}
+		for _, l := range labels {
+			i.Labels = append(i.Labels, &github.Label{Name: github.Ptr(l)})
+		}
+		return i
+	}
+	open := []*github.Issue{
+		issue(1, 2*day, 0, "service/service1", "forward/review"),
+		issue(2, 60*day, 0, "service/service1", "service/service2"),
+		issue(3, 400*day, 0),
+		{Number: github.Ptr(4), PullRequestLinks: &github.PullRequestLinks{}},
+	}
+	updated := []*github.Issue{
+		open[0],
+		// Opened before since and closed after
+		issue(5, 100*day, 5*day, "service/service2"),
+		// Opened and closed since
+		issue(6, 10*day, 1*day, "service/service1"),
+		// Updated since, but opened and closed before
+		issue(7, 100*day, 40*day, "service/service1"),
+	}
+
+	got := ComputeAnalytics("owner/repo", open, updated, since, now)
+	ages := func(counts ...int) map[string]int {
+		m := make(map[string]int)
+		for i, b := range analyticsAgeBuckets {
+			m[b.Name] = counts[i]
+		}
+		return m
+	}
+	want := Analytics{
+		Repository:  "owner/repo",
+		GeneratedAt: now,
+		Since:       since,
+		Services: []ServiceAnalytics{
+			{Service: "(no service label)", Open: 1, OpenByAge: ages(0, 0, 0, 0, 1)},
+			{Service: "service/service1", Open: 2, OpenByAge: ages(1, 0, 1, 0, 0), Opened: 2, Closed: 1},
+			{Service: "service/service2", Open: 1, OpenByAge: ages(0, 0, 1, 0, 0), Closed: 1},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ComputeAnalytics() = %+v; want %+v", got, want)
+	}
+
+	var buf bytes.Buffer
+	if err := got.WriteFormat(&buf, "csv"); err != nil {
+		t.Fatalf("WriteFormat(csv) error = %v", err)
+	}
+	wantCSV := "date,repository,service,open,open_0_7d,open_8_30d,open_31_90d,open_91_365d,open_over_365d,opened,closed\n" +
+		"2024-06-30,owner/repo,(no service label),1,0,0,0,0,1,0,0\n" +
+		"2024-06-30,owner/repo,service/service1,2,1,0,1,0,0,2,1\n" +
+		"2024-06-30,owner/repo,service/service2,1,0,0,1,0,0,0,1\n"
+	if buf.String() != wantCSV {
+		t.Errorf("WriteFormat(csv) want\n%s\ngot\n%s", wantCSV, buf.String())
+	}
+
+	buf.Reset()
+	if err := got.WriteFormat(&buf, "json"); err != nil {
+		t.Fatalf("WriteFormat(json) error = %v", err)
+	}
+	if err := got.WriteFormat(&buf, "xml"); err == nil {
+		t.Errorf("WriteFormat(xml) succeeded; want an error")
+	}
+}
@@ -0,0 +1,182 @@
+package labeler
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v68/github"
+	"golang.org/x/oauth2/google"
+)
+
+const gcsScope = "https://www.googleapis.com/auth/devstorage.read_write"
+
+// AuditEntry records a single label change applied (or attempted) on an issue.
+type AuditEntry struct {
+	RunID        string    `json:"run_id"`
+	Time         time.Time `json:"time"`
+	Repository   string    `json:"repository"`
+	Number       int       `json:"number"`
+	LabelsBefore []string  `json:"labels_before"`
+	LabelsAfter  []string  `json:"labels_after"`
+	// Status and RequestID come from the GitHub API response; Error is set if the call failed.
+	Status    int    `json:"status,omitempty"`
+	RequestID string `json:"github_request_id,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// AuditLog appends AuditEntry records as JSON lines. A nil *AuditLog discards everything.
+//
+// Local paths are opened in append mode. gs://bucket/prefix destinations are buffered and
+// uploaded on Close as a new object named after the run ID, since GCS objects can't be appended to.
+type AuditLog struct {
+	RunID string
+
+	mu     sync.Mutex
+	w      io.Writer
+	file   *os.File
+	bucket string
+	object string
+	buf    bytes.Buffer
+}
+
+// NewRunID returns an ID that sorts by start time, e.g. 20240102T150405Z-1a2b3c4d.
+func NewRunID() string {
+	b := make([]byte, 4)
+	rand.Read(b)
+	return time.Now().UTC().Format("20060102T150405Z") + "-" + hex.EncodeToString(b)
+}
+
+// OpenAuditLog opens the audit log at dest, a local file path or gs://bucket/prefix. An empty
+// dest returns a nil log.
+func OpenAuditLog(dest, runID string) (*AuditLog, error) {
+	if dest == "" {
+		return nil, nil
+	}
+	a := &AuditLog{RunID: runID}
+	if rest, ok := strings.CutPrefix(dest, "gs://"); ok {
+		bucket, prefix, _ := strings.Cut(rest, "/")
+		if bucket == "" {
+			return nil, fmt.Errorf("invalid audit log destination %q", dest)
+		}
+		a.bucket = bucket
+		a.object = path.Join(prefix, runID+".jsonl")
+		a.w = &a.buf
+		return a, nil
+	}
+	f, err := os.OpenFile(dest, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("opening audit log: %w", err)
+	}
+	a.file = f
+	a.w = f
+	return a, nil
+}
+
+// Record appends an entry, filling in the run ID and timestamp if unset.
+func (a *AuditLog) Record(entry AuditEntry) error {
+	if a == nil {
+		return nil
+	}
+	if entry.RunID == "" {
+		entry.RunID = a.RunID
+	}
+	if entry.Time.IsZero() {
+		entry.Time = time.Now().UTC()
+	}
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if _, err := a.w.Write(append(b, '\n')); err != nil {
+		return fmt.Errorf("writing audit log: %w", err)
+	}
+	return nil
+}
+
+// Close closes the local file or uploads the buffered entries to GCS. Nothing is uploaded
+// for runs that made no changes.
+func (a *AuditLog) Close(ctx context.Context) error {
+	if a == nil {
+		return nil
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.file != nil {
+		return a.file.Close()
+	}
+	if a.buf.Len() == 0 {
+		return nil
+	}
+	return uploadGCSObject(ctx, a.bucket, a.object, a.buf.Bytes())
+}
+
+func uploadGCSObject(ctx context.Context, bucket, object string, data []byte) error {
+	client, err := google.DefaultClient(ctx, gcsScope)
+	if err != nil {
+		return fmt.Errorf("getting GCS credentials: %w", err)
+	}
+	u := fmt.Sprintf("https://storage.googleapis.com/upload/storage/v1/b/%s/o?uploadType=media&name=%s",
+		url.PathEscape(bucket), url.QueryEscape(object))
+	req, err := http.NewRequestWithContext(ctx, "POST", u, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("uploading audit log: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("uploading audit log to gs://%s/%s: %s: %s", bucket, object, resp.Status, body)
+	}
+	return nil
+}
+
+type auditLogKey struct{}
+
+// WithAuditLog returns a context whose label updates are recorded to a.
+func WithAuditLog(ctx context.Context, a *AuditLog) context.Context {
+	return context.WithValue(ctx, auditLogKey{}, a)
+}
+
+func auditLogFrom(ctx context.Context) *AuditLog {
+	a, _ := ctx.Value(auditLogKey{}).(*AuditLog)
+	return a
+}
+
+// auditEntry describes an IssueUpdate and the GitHub response to applying it.
+func auditEntry(repository string, update IssueUpdate, resp *github.Response, err error) AuditEntry {
+	entry := AuditEntry{
+		Repository:   repository,
+		Number:       update.Number,
+		LabelsBefore: update.OldLabels,
+		LabelsAfter:  update.Labels,
+	}
+	if entry.LabelsBefore == nil {
+		entry.LabelsBefore = []string{}
+	}
+	if resp != nil {
+		entry.Status = resp.StatusCode
+		entry.RequestID = resp.Header.Get("X-GitHub-Request-Id")
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	return entry
+}
@@ -0,0 +1,85 @@
+package labeler
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v68/github"
+)
+
+func TestAuditLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	resp := &github.Response{Response: &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"X-Github-Request-Id": []string{"ABCD:1234"}},
+	}}
+	now := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+
+	// Two runs appending to the same file.
+	for _, runID := range []string{"run-1", "run-2"} {
+		a, err := OpenAuditLog(path, runID)
+		if err != nil {
+			t.Fatalf("OpenAuditLog() error = %v", err)
+		}
+		entry := auditEntry("owner/repo", IssueUpdate{Number: 1, Labels: []string{"service/service1"}}, resp, nil)
+		entry.Time = now
+		if err := a.Record(entry); err != nil {
+			t.Fatalf("Record() error = %v", err)
+		}
+		if err := a.Close(context.Background()); err != nil {
+			t.Fatalf("Close() error = %v", err)
+		}
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var got []AuditEntry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var entry AuditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			t.Fatalf("invalid audit line %q: %v", scanner.Text(), err)
+		}
+		got = append(got, entry)
+	}
+
+	var want []AuditEntry
+	for _, runID := range []string{"run-1", "run-2"} {
+		want = append(want, AuditEntry{
+			RunID:        runID,
+			Time:         now,
+			Repository:   "owner/repo",
+			Number:       1,
+			LabelsBefore: []string{},
+			LabelsAfter:  []string{"service/service1"},
+			Status:       http.StatusOK,
+			RequestID:    "ABCD:1234",
+		})
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want %+v; got %+v", want, got)
+	}
+}
+
+func TestNilAuditLog(t *testing.T) {
+	a, err := OpenAuditLog("", "run")
+	if err != nil || a != nil {
+		t.Fatalf("OpenAuditLog(\"\") = %v, %v; want nil, nil", a, err)
+	}
+	if err := a.Record(AuditEntry{}); err != nil {
+		t.Errorf("Record() on nil log error = %v", err)
+	}
+	if err := a.Close(context.Background()); err != nil {
+		t.Errorf("Close() on nil log error = %v", err)
+	}
+}
@@ -0,0 +1,65 @@
+package labeler
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-github/v68/github"
+)
+
+// DefaultAutomationLabel is applied to issues opened by automation.
+const DefaultAutomationLabel = "automated"
+
+// DefaultAutomationAuthors are the accounts whose issues are treated as automation-authored
+// unless configured otherwise: the nightly test failure bot and dependency update tools.
+var DefaultAutomationAuthors = []string{"modular-magician", "dependabot[bot]", "renovate[bot]", "github-actions[bot]"}
+
+// AutomationPolicy decides which issues were opened by automation. Those get Label alongside
+// their service labels, but aren't forwarded for review: the automation that opened them, or
+// the people running it, already track them. The zero value treats every issue as filed by a
+// person.
+type AutomationPolicy struct {
+	// Authors are the logins of the automation accounts, matched case-insensitively.
+	Authors []string
+	Label   string
+}
+
+// Validate checks that a label is set if any authors are.
+func (p AutomationPolicy) Validate() error {
+	if len(p.Authors) > 0 && p.Label == "" {
+		return fmt.Errorf("an automation label is required when automation authors are set")
+	}
+	return nil
+}
+
+// Authored reports whether issue was opened by one of the policy's automation accounts.
+func (p AutomationPolicy) Authored(issue *github.Issue) bool {
+	return p.IsAuthor(issue.GetUser().GetLogin())
+}
+
+// IsAuthor reports whether login is one of the policy's automation accounts.
+func (p AutomationPolicy) IsAuthor(login string) bool {
+	if login == "" {
+		return false
+	}
+	for _, author := range p.Authors {
+		if strings.EqualFold(author, login) {
+			return true
+		}
+	}
+	return false
+}
+
+type automationPolicyKey struct{}
+
+// WithAutomationPolicy returns a context whose issue updates classify automation-authored
+// issues with p.
+func WithAutomationPolicy(ctx context.Context, p AutomationPolicy) context.Context {
+	return context.WithValue(ctx, automationPolicyKey{}, p)
+}
+
+func automationPolicyFrom(ctx context.Context) AutomationPolicy {
+	p, _ := ctx.Value(automationPolicyKey{}).(AutomationPolicy)
+	return p
+}
@@ -0,0 +1,107 @@
+package labeler
+
+import (
+	"context"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/google/go-github/v68/github"
+)
+
+func TestAutomationPolicyValidate(t *testing.T) {
+	cases := map[string]struct {
+		policy  AutomationPolicy
+		wantErr bool
+	}{
+		"zero value":    {},
+		"default":       {policy: AutomationPolicy{Authors: DefaultAutomationAuthors, Label: DefaultAutomationLabel}},
+		"missing label": {policy: AutomationPolicy{Authors: []string{"modular-magician"}}, wantErr: true},
+	}
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			if err := tc.policy.Validate(); (err != nil) != tc.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestAutomationPolicyAuthored(t *testing.T) {
+	policy := AutomationPolicy{Authors: []string{"modular-magician", "dependabot[bot]"}, Label: "automated"}
+	author := func(login string) *github.Issue {
+		return &github.Issue{User: &github.User{Login: github.Ptr(login)}}
+	}
+	cases := map[string]struct {
+		policy AutomationPolicy
+		issue  *github.Issue
+		want   bool
+	}{
+		"bot":               {policy: policy, issue: author("dependabot[bot]"), want: true},
+		"different case":    {policy: policy, issue: author("Modular-Magician"), want: true},
+		"person":            {policy: policy, issue: author("octocat")},
+		"unlisted bot":      {policy: policy, issue: author("renovate[bot]")},
+		"no author":         {policy: policy, issue: &github.Issue{}},
+		"policy zero value": {issue: author("modular-magician")},
+	}
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			if got := tc.policy.Authored(tc.issue); got != tc.want {
+				t.Errorf("Authored() = %v; want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestComputeIssueUpdatesAutomation(t *testing.T) {
+	rules := []RegexpLabel{
+		{Regexp: regexp.MustCompile("^google_service1_.*$"), Label: "service/service1"},
+	}
+	issue := func(number int, author, body string, labels ...string) *github.Issue {
+		i := &github.Issue{Number: github.Ptr(number), User: &github.User{Login: github.Ptr(author)}, Body: github.Ptr(body)}
+		for _, l := range labels {
+			i.Labels = append(i.Labels, &github.Label{Name: github.Ptr(l)})
+		}
+		return i
+	}
+	body := "### Affected Resource(s)\n\ngoogle_service1_resource1\n"
+	issues := []*github.Issue{
+		issue(1, "modular-magician", body),
+		issue(2, "octocat", body),
+		// Automation issues are labeled even if no service matches
+		issue(3, "dependabot[bot]", "Bump a dependency"),
+		issue(4, "dependabot[bot]", "Bump a dependency", "automated"),
+	}
+	ctx := WithAutomationPolicy(context.Background(), AutomationPolicy{Authors: []string{"modular-magician", "dependabot[bot]"}, Label: "automated"})
+
+	got := make(map[int][]string)
+	for _, u := range ComputeIssueUpdates(ctx, issues, rules) {
+		got[u.Number] = u.Labels
+	}
+	want := map[int][]string{
+		1: {"automated", "service/service1"},
+		2: {"forward/review", "service/service1"},
+		3: {"automated"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ComputeIssueUpdates() labels = %v; want %v", got, want)
+	}
+}
+
+func TestComputeEditDeltaAutomation(t *testing.T) {
+	rules := []RegexpLabel{
+		{Regexp: regexp.MustCompile("^google_service1_.*$"), Label: "service/service1"},
+	}
+	issue := &github.Issue{
+		Number: github.Ptr(1),
+		User:   &github.User{Login: github.Ptr("modular-magician")},
+		Body:   github.Ptr("### Affected Resource(s)\n\ngoogle_service1_resource1\n"),
+	}
+	policy := AutomationPolicy{Authors: []string{"modular-magician"}, Label: "automated"}
+
+	got, ok := ComputeEditDelta(issue, "### Affected Resource(s)\n\n_No response_\n", rules, ConflictPolicy{}, policy)
+	want := LabelDelta{Number: 1, Labels: []string{}, Add: []string{"automated", "service/service1"}}
+	if !ok || !reflect.DeepEqual(got, want) {
+		t.Errorf("ComputeEditDelta() = %+v, %v; want %+v, true", got, ok, want)
+	}
+}
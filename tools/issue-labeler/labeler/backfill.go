@@ -3,13 +3,16 @@ package labeler
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"net/http"
+	"slices"
 	"sort"
 	"strings"
 	"time"
 
-	"github.com/golang/glog"
 	"github.com/google/go-github/v68/github"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type Label struct {
@@ -18,93 +21,258 @@ type Label struct {
 
 type IssueUpdate struct {
 	Number    int
+	NodeID    string
 	Labels    []string
 	OldLabels []string
+	// Matches records the rule matches the new labels were computed from.
+	Matches []LabelMatch
+	// Unroutable is set when the update gives the issue the policy's UnroutableLabel.
+	Unroutable bool
 }
 
-func GetIssues(repository, since string) ([]*github.Issue, error) {
-	client := newGitHubClient()
-	owner, repo, err := splitRepository(repository)
-	if err != nil {
-		return nil, fmt.Errorf("invalid repository format: %w", err)
-	}
-
+func GetIssues(ctx context.Context, repository, since string) ([]*github.Issue, error) {
 	sinceTime, err := time.Parse("2006-01-02", since) // input format YYYY-MM-DD
 	if err != nil {
 		return nil, fmt.Errorf("invalid since time format: %w", err)
 	}
+	return GetIssuesSince(ctx, repository, sinceTime)
+}
+
+// GetIssuesSince lists all issues and pull requests updated at or after sinceTime.
+func GetIssuesSince(ctx context.Context, repository string, sinceTime time.Time) ([]*github.Issue, error) {
+	return GetFilteredIssues(ctx, repository, sinceTime, IssueFilter{})
+}
+
+// IssueFilter narrows down the issues listed for a run. State, Labels and Author are passed to
+// the API; ExcludeLabels and Until aren't supported by it and are applied to each page.
+type IssueFilter struct {
+	// State is open, closed or all; empty means all.
+	State         string
+	Labels        []string
+	ExcludeLabels []string
+	Author        string
+	// Until excludes issues updated at or after it, if set.
+	Until time.Time
+}
+
+// IsZero reports whether the filter lets every issue through.
+func (f IssueFilter) IsZero() bool {
+	return (f.State == "" || f.State == "all") && len(f.Labels) == 0 && len(f.ExcludeLabels) == 0 && f.Author == "" && f.Until.IsZero()
+}
+
+// Validate checks the filter's state.
+func (f IssueFilter) Validate() error {
+	switch f.State {
+	case "", "open", "closed", "all":
+		return nil
+	}
+	return fmt.Errorf("invalid state %q, must be open, closed or all", f.State)
+}
+
+// Matches applies the parts of the filter the API can't to an issue.
+func (f IssueFilter) Matches(issue *github.Issue) bool {
+	if !f.Until.IsZero() && !issue.GetUpdatedAt().Time.Before(f.Until) {
+		return false
+	}
+	for _, l := range issue.Labels {
+		for _, excluded := range f.ExcludeLabels {
+			if l.GetName() == excluded {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (f IssueFilter) filter(issues []*github.Issue) []*github.Issue {
+	var matched []*github.Issue
+	for _, issue := range issues {
+		if f.Matches(issue) {
+			matched = append(matched, issue)
+		}
+	}
+	return matched
+}
 
-	opt := &github.IssueListByRepoOptions{
+// listOptions returns the options listing issues updated at or after sinceTime, narrowed down
+// server side as far as filter allows. The rest of it is applied with filter.filter.
+func (f IssueFilter) listOptions(sinceTime time.Time) *github.IssueListByRepoOptions {
+	state := f.State
+	if state == "" {
+		state = "all"
+	}
+	return &github.IssueListByRepoOptions{
 		Since:     sinceTime,
-		State:     "all",
+		State:     state,
+		Labels:    f.Labels,
+		Creator:   f.Author,
 		Sort:      "updated",
 		Direction: "desc",
 		ListOptions: github.ListOptions{
 			PerPage: 100,
 		},
 	}
+}
 
-	var allIssues []*github.Issue
-	ctx := context.Background()
+// GetFilteredIssues lists the issues and pull requests updated at or after sinceTime that
+// match filter.
+func GetFilteredIssues(ctx context.Context, repository string, sinceTime time.Time, filter IssueFilter) (allIssues []*github.Issue, err error) {
+	ctx, span := tracer.Start(ctx, "GetIssues", trace.WithAttributes(
+		attribute.String("repository", repository),
+		attribute.String("since", sinceTime.Format(time.RFC3339)),
+	))
+	defer func() {
+		span.SetAttributes(attribute.Int("issues", len(allIssues)))
+		endSpan(span, err)
+	}()
 
-	issues, resp, err := client.Issues.ListByRepo(ctx, owner, repo, opt)
+	client := newGitHubClient()
+	owner, repo, err := splitRepository(repository)
+	if err != nil {
+		return nil, fmt.Errorf("invalid repository format: %w", err)
+	}
+
+	opt := filter.listOptions(sinceTime)
+	issues, resp, err := fetchIssuesPage(ctx, 1, func(ctx context.Context) ([]*github.Issue, *github.Response, error) {
+		return client.Issues.ListByRepo(ctx, owner, repo, opt)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("listing issues: %w", err)
 	}
-	allIssues = append(allIssues, issues...)
+	allIssues = append(allIssues, filter.filter(issues)...)
+	checkpoint := checkpointFrom(ctx)
+	if err := checkpoint.MarkPage(1); err != nil {
+		slog.Warn("saving checkpoint failed", "error", err)
+	}
+
+	page, next := 2, parseNextLink(resp.Response)
+	// Listings with a page-numbered last link can fetch the remaining pages concurrently.
+	if links := pageLinks(resp.Response); len(links) > 0 {
+		pages, pagesNext, err := fetchIssuePages(ctx, client, links, pageConcurrencyFrom(ctx))
+		for i, issues := range pages {
+			allIssues = append(allIssues, filter.filter(issues)...)
+			if err := checkpoint.MarkPage(i + 2); err != nil {
+				slog.Warn("saving checkpoint failed", "error", err)
+			}
+		}
+		if !pageNumbersRejected(err) {
+			return allIssues, err
+		}
+		slog.Warn("page-numbered links rejected, following next links instead", "page", page+len(pages), "error", err)
+		if len(pages) > 0 {
+			page, next = page+len(pages), pagesNext
+		}
+	}
 
-	for {
+	for ; next != ""; page++ {
 		// use link headers instead of page parameter based pagination as
 		// it is not supported for large datasets
 
-		next := parseNextLink(resp.Response)
-		if next == "" {
-			break
+		if shuttingDown(ctx) {
+			return allIssues, ErrInterrupted
 		}
 
-		req, err := client.NewRequest("GET", next, nil)
+		issues, resp, err = fetchIssuesPage(ctx, page, func(ctx context.Context) ([]*github.Issue, *github.Response, error) {
+			return getIssuesPage(ctx, client, next)
+		})
 		if err != nil {
 			return allIssues, err
 		}
-		req.Header.Set("Accept", "application/vnd.github.raw+json")
 
-		var issues []*github.Issue
-		resp, err = client.Do(ctx, req, &issues)
-		if err != nil {
-			return allIssues, err
+		allIssues = append(allIssues, filter.filter(issues)...)
+		if err := checkpoint.MarkPage(page); err != nil {
+			slog.Warn("saving checkpoint failed", "error", err)
 		}
-
-		allIssues = append(allIssues, issues...)
+		next = parseNextLink(resp.Response)
 	}
 
 	return allIssues, nil
 }
 
+// fetchIssuesPage runs a single page request inside its own span and records metrics for it.
+func fetchIssuesPage(ctx context.Context, page int, fetch func(context.Context) ([]*github.Issue, *github.Response, error)) ([]*github.Issue, *github.Response, error) {
+	ctx, span := tracer.Start(ctx, "GetIssues.page", trace.WithAttributes(attribute.Int("page", page)))
+	issues, resp, err := fetch(ctx)
+	observeResponse(resp)
+	if err = apiError("list_issues", resp, err); err == nil {
+		issuesFetched.Add(float64(len(issues)))
+		span.SetAttributes(attribute.Int("issues", len(issues)))
+	}
+	endSpan(span, err)
+	return issues, resp, err
+}
+
+// GetIssue fetches a single issue by number.
+func GetIssue(ctx context.Context, repository string, number int) (*github.Issue, error) {
+	ctx, span := tracer.Start(ctx, "GetIssue", trace.WithAttributes(
+		attribute.String("repository", repository),
+		attribute.Int("issue.number", number),
+	))
+	client := newGitHubClient()
+	owner, repo, err := splitRepository(repository)
+	if err != nil {
+		endSpan(span, err)
+		return nil, fmt.Errorf("invalid repository format: %w", err)
+	}
+
+	issue, resp, err := client.Issues.Get(ctx, owner, repo, number)
+	observeResponse(resp)
+	endSpan(span, err)
+	if err != nil {
+		return nil, fmt.Errorf("getting issue %d: %w", number, apiError("get_issue", resp, err))
+	}
+	issuesFetched.Inc()
+	return issue, nil
+}
+
 // parseNextLink finds the next page for a GitHub API request by parsing the previous response's Link header.
 // https://docs.github.com/en/rest/using-the-rest-api/using-pagination-in-the-rest-api?apiVersion=2022-11-28#using-link-headers
 func parseNextLink(resp *http.Response) string {
-	var next string
+	return parseLink(resp, "next")
+}
+
+// parseLink finds the link with the given rel in a response's Link header.
+func parseLink(resp *http.Response, rel string) string {
+	want := fmt.Sprintf("rel=%q", rel)
+	var link string
 	for _, hdr := range resp.Header.Values("Link") {
 		links := strings.Split(hdr, ",")
-		for _, link := range links {
-			pair := strings.Split(strings.TrimSpace(link), ";")
+		for _, l := range links {
+			pair := strings.Split(strings.TrimSpace(l), ";")
 			if len(pair) == 2 {
-				if strings.TrimSpace(pair[0]) == `rel="next"` {
-					next = strings.Trim(pair[1], "<> ")
-				} else if strings.TrimSpace(pair[1]) == `rel="next"` {
-					next = strings.Trim(pair[0], "<> ")
+				if strings.TrimSpace(pair[0]) == want {
+					link = strings.Trim(pair[1], "<> ")
+				} else if strings.TrimSpace(pair[1]) == want {
+					link = strings.Trim(pair[0], "<> ")
 				}
-				if next != "" {
+				if link != "" {
 					break
 				}
 			}
 		}
 	}
-	return next
+	return link
 }
 
-// ComputeIssueUpdates remains the same as it doesn't interact with GitHub API
-func ComputeIssueUpdates(issues []*github.Issue, regexpLabels []RegexpLabel) []IssueUpdate {
+// ComputeIssueUpdates remains the same as it doesn't interact with GitHub API. The rules are
+// matched with one Matcher for the whole run, and the time spent computing updates is recorded
+// on the span and in the matching duration metric.
+func ComputeIssueUpdates(ctx context.Context, issues []*github.Issue, regexpLabels []RegexpLabel) []IssueUpdate {
+	start := time.Now()
+	issueUpdates := computeIssueUpdates(ctx, issues, NewMatcher(regexpLabels))
+	matchingDuration.Observe(time.Since(start).Seconds())
+	return issueUpdates
+}
+
+// computeIssueUpdates computes the updates for issues with matcher, which can be shared by
+// calls for different pages of the same run.
+func computeIssueUpdates(ctx context.Context, issues []*github.Issue, matcher *Matcher) []IssueUpdate {
+	ctx, span := tracer.Start(ctx, "ComputeIssueUpdates", trace.WithAttributes(attribute.Int("issues", len(issues))))
+	defer span.End()
+	policy := conflictPolicyFrom(ctx)
+	automation := automationPolicyFrom(ctx)
+	start := time.Now()
+
 	var issueUpdates []IssueUpdate
 
 	for _, issue := range issues {
@@ -113,90 +281,207 @@ func ComputeIssueUpdates(issues []*github.Issue, regexpLabels []RegexpLabel) []I
 			continue
 		}
 
-		desired := make(map[string]struct{})
-		for _, existing := range issue.Labels {
-			desired[*existing.Name] = struct{}{}
+		_, issueSpan := tracer.Start(ctx, "ComputeIssueUpdates.issue", trace.WithAttributes(attribute.Int("issue.number", issue.GetNumber())))
+		issueUpdate, ok := computeIssueUpdate(issue, matcher, policy, automation)
+		issueSpan.SetAttributes(attribute.Bool("update", ok), attribute.StringSlice("labels", issueUpdate.Labels))
+		issueSpan.End()
+		if ok {
+			issueUpdates = append(issueUpdates, issueUpdate)
 		}
+	}
 
-		_, terraform := desired["service/terraform"]
-		_, linked := desired["forward/linked"]
-		_, exempt := desired["forward/exempt"]
-		_, testfailure := desired["test-failure"]
-		if terraform || exempt {
-			continue
-		}
+	span.SetAttributes(attribute.Int("updates", len(issueUpdates)), attribute.Int64("matching_ms", time.Since(start).Milliseconds()))
+	return issueUpdates
+}
 
-		// Decision was made to no longer add new service labels to linked tickets, because it is
-		// more difficult to know which teams have received those tickets and which haven't.
-		// Forwarding a ticket to a different service team should involve removing the old service
-		// label and `linked` label.
-		if linked {
-			continue
-		}
+// computeIssueUpdate returns the label update for a single issue, if one is needed. Matched
+// service labels are resolved with policy when there are more than it allows, and issues
+// matching none get its UnroutableLabel without being forwarded for review. Issues opened by
+// automation get its label and aren't forwarded for review, and documentation issues get
+// LabelDocumentation, which is only forwarded for review alongside another new label.
+func computeIssueUpdate(issue *github.Issue, matcher *Matcher, policy ConflictPolicy, automation AutomationPolicy) (IssueUpdate, bool) {
+	desired := make(map[string]struct{})
+	for _, existing := range issue.Labels {
+		desired[*existing.Name] = struct{}{}
+	}
 
-		var issueUpdate IssueUpdate
-		for label := range desired {
-			issueUpdate.OldLabels = append(issueUpdate.OldLabels, label)
-		}
-		sort.Strings(issueUpdate.OldLabels)
+	_, testfailure := desired[LabelTestFailure]
+	if exemptFromLabeling(desired) {
+		return IssueUpdate{}, false
+	}
+
+	var issueUpdate IssueUpdate
+	for label := range desired {
+		issueUpdate.OldLabels = append(issueUpdate.OldLabels, label)
+	}
+	sort.Strings(issueUpdate.OldLabels)
 
-		affectedResources := ExtractAffectedResources(issue.GetBody())
-		for _, needed := range ComputeLabels(affectedResources, regexpLabels) {
-			desired[needed] = struct{}{}
+	automated := automation.Authored(issue)
+	if automated {
+		desired[automation.Label] = struct{}{}
+	}
+	_, hadDocumentation := desired[LabelDocumentation]
+	if IsDocumentationIssue(issue.GetTitle(), issue.GetBody()) {
+		desired[LabelDocumentation] = struct{}{}
+	}
+	matches := matcher.MatchIssue(issue.GetBody())
+	kept, triage := policy.Resolve(matches)
+	if triage {
+		desired[policy.TriageLabel] = struct{}{}
+	}
+	for _, label := range kept {
+		desired[label] = struct{}{}
+	}
+	for _, m := range matches {
+		if slices.Contains(kept, m.Label) {
+			issueUpdate.Matches = append(issueUpdate.Matches, m)
 		}
+	}
+	_, hadUnroutable := desired[policy.UnroutableLabel]
+	routedNow := hadUnroutable && len(kept) > 0
+	if routedNow {
+		delete(desired, policy.UnroutableLabel)
+	}
+	if len(kept) == 0 && !triage && policy.unroutable(desired) {
+		desired[policy.UnroutableLabel] = struct{}{}
+		issueUpdate.Unroutable = true
+	}
 
-		if len(desired) > len(issueUpdate.OldLabels) {
-			// Forwarding test failure ticket directly
-			if !linked && !testfailure {
-				issueUpdate.Labels = append(issueUpdate.Labels, "forward/review")
-			}
-			for label := range desired {
-				issueUpdate.Labels = append(issueUpdate.Labels, label)
-			}
-			sort.Strings(issueUpdate.Labels)
+	if len(desired) <= len(issueUpdate.OldLabels) && !routedNow {
+		return IssueUpdate{}, false
+	}
 
-			issueUpdate.Number = issue.GetNumber()
-			if issueUpdate.Number > 0 {
-				issueUpdates = append(issueUpdates, issueUpdate)
-			}
-		}
+	// The documentation label doesn't say which team owns an issue, so adding only it
+	// doesn't forward the issue for review.
+	_, hasDocumentation := desired[LabelDocumentation]
+	documentationOnly := !hadDocumentation && hasDocumentation && !routedNow && len(desired) == len(issueUpdate.OldLabels)+1
+
+	// Forwarding test failure and automation tickets directly, and there is no one to forward
+	// unroutable or documentation-only tickets to.
+	if !testfailure && !automated && !issueUpdate.Unroutable && !documentationOnly {
+		issueUpdate.Labels = append(issueUpdate.Labels, "forward/review")
+	}
+	for label := range desired {
+		issueUpdate.Labels = append(issueUpdate.Labels, label)
 	}
+	sort.Strings(issueUpdate.Labels)
 
-	return issueUpdates
+	issueUpdate.Number = issue.GetNumber()
+	issueUpdate.NodeID = issue.GetNodeID()
+	return issueUpdate, issueUpdate.Number > 0
 }
 
-func UpdateIssues(repository string, issueUpdates []IssueUpdate, dryRun bool) error {
+// exemptFromLabeling reports whether an issue with the given labels is left alone.
+func exemptFromLabeling(labels map[string]struct{}) bool {
+	_, terraform := labels["service/terraform"]
+	_, linked := labels["forward/linked"]
+	_, exempt := labels["forward/exempt"]
+
+	// Decision was made to no longer add new service labels to linked tickets, because it is
+	// more difficult to know which teams have received those tickets and which haven't.
+	// Forwarding a ticket to a different service team should involve removing the old service
+	// label and `linked` label.
+	return terraform || exempt || linked
+}
+
+// UpdateIssues applies the label updates, recording each attempt to the context's audit log.
+// Retryable failures, like rate limits and server errors, are retried with the context's
+// retry policy; permanent ones, like missing issues, aren't. If the context's shutdown is
+// requested, the update in flight is finished and ErrInterrupted returned.
+func UpdateIssues(ctx context.Context, repository string, issueUpdates []IssueUpdate, dryRun bool) (err error) {
+	ctx, span := tracer.Start(ctx, "UpdateIssues", trace.WithAttributes(
+		attribute.String("repository", repository),
+		attribute.Int("updates", len(issueUpdates)),
+		attribute.Bool("dry_run", dryRun),
+	))
+	defer func() { endSpan(span, err) }()
+
 	client := newGitHubClient()
 	owner, repo, err := splitRepository(repository)
 	if err != nil {
 		return fmt.Errorf("invalid repository format: %w", err)
 	}
 
-	ctx := context.Background()
-	failed := 0
-
-	for _, update := range issueUpdates {
-		fmt.Printf("Existing labels: %v\n", update.OldLabels)
-		fmt.Printf("New labels: %v\n", update.Labels)
-		fmt.Printf("Updating issue: https://github.com/%s/issues/%d\n", repository, update.Number)
+	failed, permanent := 0, 0
+	retry := retryPolicyFrom(ctx)
+	audit := auditLogFrom(ctx)
+	checkpoint := checkpointFrom(ctx)
+	stats := runStatsFrom(ctx)
+	store := repoStoreFrom(ctx)
+	failures := failureReportFrom(ctx)
+
+	for i, update := range issueUpdates {
+		if !dryRun && shuttingDown(ctx) {
+			slog.Warn("shutting down before the remaining updates", "repo", repository, "remaining", len(issueUpdates)-i)
+			return fmt.Errorf("%w after %d / %d updates", ErrInterrupted, i, len(issueUpdates))
+		}
+		added, removed := diffLabels(update.OldLabels, update.Labels)
+		logger := slog.With(
+			"repo", repository,
+			"number", update.Number,
+			"url", fmt.Sprintf("https://github.com/%s/issues/%d", repository, update.Number),
+			"labels_added", added,
+			"labels_removed", removed,
+		)
 		if dryRun {
+			logger.Info("would update issue", "labels", update.Labels)
+			continue
+		}
+		if checkpoint.IsApplied(update.Number) {
+			logger.Info("skipping issue updated by an earlier attempt")
 			continue
 		}
-		_, _, err := client.Issues.Edit(ctx, owner, repo, int(update.Number), &github.IssueRequest{
-			Labels: &update.Labels,
+		issueCtx, issueSpan := tracer.Start(ctx, "UpdateIssues.issue", trace.WithAttributes(
+			attribute.Int("issue.number", update.Number),
+			attribute.StringSlice("labels", update.Labels),
+		))
+		var resp *github.Response
+		err := retry.do(issueCtx, logger, func() error {
+			var err error
+			_, resp, err = client.Issues.Edit(issueCtx, owner, repo, int(update.Number), &github.IssueRequest{
+				Labels: &update.Labels,
+			})
+			observeResponse(resp)
+			if aerr := audit.Record(auditEntry(repository, update, resp, err)); aerr != nil {
+				logger.Error("recording audit entry failed", "error", aerr)
+			}
+			return apiError("edit_issue", resp, err)
 		})
+		endSpan(issueSpan, err)
+		if err != nil && mayBeTransferred(resp) {
+			transfer, ok, terr := findTransfer(ctx, client, owner, repo, update.Number)
+			if terr != nil {
+				logger.Warn("checking whether issue was transferred failed", "error", terr)
+			} else if ok {
+				logger.Info("skipping issue transferred to another repository", "destination", transfer.Destination, "destination_url", transfer.URL)
+				stats.recordTransfer(transfer)
+				continue
+			}
+		}
+		stats.record(update, err)
 
 		if err != nil {
-			glog.Errorf("Error updating issue %d: %v", update.Number, err)
+			logger.Error("updating issue failed", "error", err, "retryable", IsRetryable(err))
+			failures.record(repository, update, err)
 			failed++
+			if IsPermanent(err) {
+				permanent++
+			}
 			continue
 		}
+		updatesApplied.Inc()
+		if err := checkpoint.MarkApplied(update.Number); err != nil {
+			logger.Warn("saving checkpoint failed", "error", err)
+		}
+		if err := store.MarkProcessed(time.Now(), update.Number); err != nil {
+			logger.Warn("recording processed issue failed", "error", err)
+		}
 
-		fmt.Printf("GitHub Issue %s %d updated successfully\n", repository, update.Number)
+		logger.Info("updated issue", "labels", update.Labels)
 	}
 
 	if failed > 0 {
-		return fmt.Errorf("failed to update %d / %d issues", failed, len(issueUpdates))
+		return fmt.Errorf("failed to update %d / %d issues, %d permanently", failed, len(issueUpdates), permanent)
 	}
 	return nil
 }
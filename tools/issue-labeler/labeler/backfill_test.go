@@ -1,11 +1,13 @@
 package labeler
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"regexp"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-github/v68/github"
 )
@@ -118,10 +120,16 @@ func TestComputeIssueUpdates(t *testing.T) {
 				{
 					Number: 1,
 					Labels: []string{"forward/review", "service/service1"},
+					Matches: []LabelMatch{
+						{Label: "service/service1", Resource: "google_service1_resource1", Section: SectionAffectedResources, Pattern: "google_service1_.*"},
+					},
 				},
 				{
 					Number: 2,
 					Labels: []string{"forward/review", "service/service2-subteam1"},
+					Matches: []LabelMatch{
+						{Label: "service/service2-subteam1", Resource: "google_service2_resource1", Section: SectionAffectedResources, Pattern: "google_service2_resource1"},
+					},
 				},
 			},
 		},
@@ -164,11 +172,17 @@ func TestComputeIssueUpdates(t *testing.T) {
 					Number:    1,
 					Labels:    []string{"forward/review", "service/service1", "service/service2-subteam1"},
 					OldLabels: []string{"service/service2-subteam1"},
+					Matches: []LabelMatch{
+						{Label: "service/service1", Resource: "google_service1_resource1", Section: SectionAffectedResources, Pattern: "google_service1_.*"},
+					},
 				},
 				{
 					Number:    2,
 					Labels:    []string{"forward/review", "service/service1", "service/service2-subteam2"},
 					OldLabels: []string{"service/service1"},
+					Matches: []LabelMatch{
+						{Label: "service/service2-subteam2", Resource: "google_service2_resource2", Section: SectionAffectedResources, Pattern: "google_service2_resource2"},
+					},
 				},
 			},
 		},
@@ -206,6 +220,9 @@ func TestComputeIssueUpdates(t *testing.T) {
 					Number:    1,
 					Labels:    []string{"service/service1", "test-failure", "test-failure-100"},
 					OldLabels: []string{"test-failure", "test-failure-100"},
+					Matches: []LabelMatch{
+						{Label: "service/service1", Resource: "google_service1_resource1", Section: SectionAffectedResources, Pattern: "google_service1_.*"},
+					},
 				},
 			},
 		},
@@ -216,7 +233,7 @@ func TestComputeIssueUpdates(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
 
-			issueUpdates := ComputeIssueUpdates(tc.issues, tc.regexpLabels)
+			issueUpdates := ComputeIssueUpdates(context.Background(), tc.issues, tc.regexpLabels)
 			if !issueUpdatesEqual(issueUpdates, tc.expectedIssueUpdates) {
 				t.Errorf("ComputeIssueUpdates(%s) expected %v, got %v", tc.name, tc.expectedIssueUpdates, issueUpdates)
 			}
@@ -265,6 +282,82 @@ func TestSplitRepository(t *testing.T) {
 	}
 }
 
+func TestIssueFilterMatches(t *testing.T) {
+	until := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	issue := func(updated time.Time, labels ...string) *github.Issue {
+		issue := &github.Issue{UpdatedAt: &github.Timestamp{Time: updated}}
+		for _, l := range labels {
+			issue.Labels = append(issue.Labels, &github.Label{Name: github.Ptr(l)})
+		}
+		return issue
+	}
+
+	cases := map[string]struct {
+		filter IssueFilter
+		issue  *github.Issue
+		want   bool
+	}{
+		"no filter": {
+			issue: issue(until, "bug"),
+			want:  true,
+		},
+		"updated before until": {
+			filter: IssueFilter{Until: until},
+			issue:  issue(until.Add(-time.Second)),
+			want:   true,
+		},
+		"updated at until": {
+			filter: IssueFilter{Until: until},
+			issue:  issue(until),
+			want:   false,
+		},
+		"excluded label": {
+			filter: IssueFilter{ExcludeLabels: []string{"forward/exempt", "stale"}},
+			issue:  issue(until, "bug", "stale"),
+			want:   false,
+		},
+		"no excluded label": {
+			filter: IssueFilter{ExcludeLabels: []string{"forward/exempt", "stale"}},
+			issue:  issue(until, "bug"),
+			want:   true,
+		},
+	}
+
+	for tn, tc := range cases {
+		tc := tc
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+			if got := tc.filter.Matches(tc.issue); got != tc.want {
+				t.Errorf("Matches() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestIssueFilterIsZero(t *testing.T) {
+	cases := map[string]struct {
+		filter IssueFilter
+		want   bool
+	}{
+		"empty":      {filter: IssueFilter{}, want: true},
+		"all states": {filter: IssueFilter{State: "all"}, want: true},
+		"open":       {filter: IssueFilter{State: "open"}, want: false},
+		"label":      {filter: IssueFilter{Labels: []string{"bug"}}, want: false},
+		"author":     {filter: IssueFilter{Author: "octocat"}, want: false},
+		"until":      {filter: IssueFilter{Until: time.Now()}, want: false},
+	}
+
+	for tn, tc := range cases {
+		tc := tc
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+			if got := tc.filter.IsZero(); got != tc.want {
+				t.Errorf("IsZero() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
 // Helper function to compare issue updates while handling nil/empty slice equality
 func issueUpdatesEqual(a, b []IssueUpdate) bool {
 	if len(a) == 0 && len(b) == 0 {
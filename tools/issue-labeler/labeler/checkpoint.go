@@ -0,0 +1,130 @@
+package labeler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sort"
+	"sync"
+)
+
+// Checkpoint records the progress of a backfill so an interrupted run can be resumed without
+// reapplying updates. It is saved after every page fetched and every issue updated.
+//
+// Resuming still relists issues from the start, since applying labels bumps an issue's
+// updated time and reorders the listing; LastPage is kept to report how far the last run got.
+type Checkpoint struct {
+	Repository string `json:"repository"`
+	Since      string `json:"since"`
+	LastPage   int    `json:"last_page"`
+	Applied    []int  `json:"applied"`
+
+	path    string
+	mu      sync.Mutex
+	applied map[int]struct{}
+}
+
+// NewCheckpoint starts a fresh checkpoint for a run, saved to path.
+func NewCheckpoint(path, repository, since string) *Checkpoint {
+	return &Checkpoint{
+		Repository: repository,
+		Since:      since,
+		Applied:    []int{},
+		path:       path,
+		applied:    make(map[int]struct{}),
+	}
+}
+
+// LoadCheckpoint reads the checkpoint left by an interrupted run. A missing file yields a fresh
+// checkpoint; one for a different repository or since date is an error.
+func LoadCheckpoint(path, repository, since string) (*Checkpoint, error) {
+	cp := NewCheckpoint(path, repository, since)
+	b, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return cp, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading checkpoint: %w", err)
+	}
+	if err := json.Unmarshal(b, cp); err != nil {
+		return nil, fmt.Errorf("parsing checkpoint: %w", err)
+	}
+	if cp.Repository != repository || cp.Since != since {
+		return nil, fmt.Errorf("checkpoint %s is for --repo=%s --since=%s", path, cp.Repository, cp.Since)
+	}
+	for _, n := range cp.Applied {
+		cp.applied[n] = struct{}{}
+	}
+	return cp, nil
+}
+
+// IsApplied reports whether the update for an issue was applied by an earlier attempt.
+func (cp *Checkpoint) IsApplied(number int) bool {
+	if cp == nil {
+		return false
+	}
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	_, ok := cp.applied[number]
+	return ok
+}
+
+// MarkApplied records an applied update and saves the checkpoint.
+func (cp *Checkpoint) MarkApplied(number int) error {
+	if cp == nil {
+		return nil
+	}
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	if _, ok := cp.applied[number]; ok {
+		return nil
+	}
+	cp.applied[number] = struct{}{}
+	cp.Applied = append(cp.Applied, number)
+	sort.Ints(cp.Applied)
+	return cp.save()
+}
+
+// MarkPage records that a page of issues was fetched and saves the checkpoint.
+func (cp *Checkpoint) MarkPage(page int) error {
+	if cp == nil {
+		return nil
+	}
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	cp.LastPage = page
+	return cp.save()
+}
+
+// Remove deletes the checkpoint file once the run has completed.
+func (cp *Checkpoint) Remove() error {
+	if cp == nil {
+		return nil
+	}
+	if err := os.Remove(cp.path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("removing checkpoint: %w", err)
+	}
+	return nil
+}
+
+func (cp *Checkpoint) save() error {
+	if err := writeJSONAtomic(cp.path, cp); err != nil {
+		return fmt.Errorf("writing checkpoint: %w", err)
+	}
+	return nil
+}
+
+type checkpointKey struct{}
+
+// WithCheckpoint returns a context whose issue listing and updates are tracked in cp.
+func WithCheckpoint(ctx context.Context, cp *Checkpoint) context.Context {
+	return context.WithValue(ctx, checkpointKey{}, cp)
+}
+
+func checkpointFrom(ctx context.Context) *Checkpoint {
+	cp, _ := ctx.Value(checkpointKey{}).(*Checkpoint)
+	return cp
+}
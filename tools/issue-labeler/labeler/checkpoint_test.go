@@ -0,0 +1,64 @@
+package labeler
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestCheckpointResume(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoint.json")
+
+	cp := NewCheckpoint(path, "owner/repo", "2024-01-01")
+	if err := cp.MarkPage(3); err != nil {
+		t.Fatalf("MarkPage() error = %v", err)
+	}
+	for _, n := range []int{7, 2, 7} {
+		if err := cp.MarkApplied(n); err != nil {
+			t.Fatalf("MarkApplied() error = %v", err)
+		}
+	}
+
+	resumed, err := LoadCheckpoint(path, "owner/repo", "2024-01-01")
+	if err != nil {
+		t.Fatalf("LoadCheckpoint() error = %v", err)
+	}
+	if resumed.LastPage != 3 {
+		t.Errorf("LastPage = %d; want 3", resumed.LastPage)
+	}
+	if want := []int{2, 7}; !reflect.DeepEqual(resumed.Applied, want) {
+		t.Errorf("Applied = %v; want %v", resumed.Applied, want)
+	}
+	if !resumed.IsApplied(7) || resumed.IsApplied(3) {
+		t.Errorf("IsApplied(7), IsApplied(3) = %v, %v; want true, false", resumed.IsApplied(7), resumed.IsApplied(3))
+	}
+
+	if _, err := LoadCheckpoint(path, "owner/other", "2024-01-01"); err == nil {
+		t.Errorf("LoadCheckpoint() for a different repository succeeded; want error")
+	}
+
+	if err := resumed.Remove(); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("checkpoint still exists after Remove(): %v", err)
+	}
+	fresh, err := LoadCheckpoint(path, "owner/other", "2024-01-01")
+	if err != nil || len(fresh.Applied) != 0 {
+		t.Errorf("LoadCheckpoint() with no file = %+v, %v; want empty checkpoint", fresh, err)
+	}
+}
+
+func TestNilCheckpoint(t *testing.T) {
+	var cp *Checkpoint
+	if cp.IsApplied(1) {
+		t.Errorf("nil checkpoint reports issue as applied")
+	}
+	if err := cp.MarkApplied(1); err != nil {
+		t.Errorf("MarkApplied() on nil checkpoint error = %v", err)
+	}
+	if err := cp.Remove(); err != nil {
+		t.Errorf("Remove() on nil checkpoint error = %v", err)
+	}
+}
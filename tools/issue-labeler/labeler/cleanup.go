@@ -0,0 +1,49 @@
+package labeler
+
+import (
+	"sort"
+
+	"github.com/google/go-github/v68/github"
+)
+
+// DefaultCleanupLabels are the workflow labels that stop meaning anything once an issue closes.
+var DefaultCleanupLabels = []string{labelForwardReview, "waiting-response", "stale"}
+
+// ComputeCleanupUpdates removes the given labels from issues in state carrying any of them.
+// state is open, closed or all; cleanups normally only sweep closed issues, where workflow
+// labels no longer apply. Pull requests are skipped.
+func ComputeCleanupUpdates(issues []*github.Issue, remove []string, state string) []IssueUpdate {
+	removeSet := make(map[string]struct{}, len(remove))
+	for _, l := range remove {
+		removeSet[l] = struct{}{}
+	}
+
+	var updates []IssueUpdate
+	for _, issue := range issues {
+		if issue.IsPullRequest() || (state != "all" && issue.GetState() != state) {
+			continue
+		}
+		labels := make(map[string]struct{})
+		var oldLabels []string
+		found := false
+		for _, l := range issue.Labels {
+			oldLabels = append(oldLabels, l.GetName())
+			if _, ok := removeSet[l.GetName()]; ok {
+				found = true
+				continue
+			}
+			labels[l.GetName()] = struct{}{}
+		}
+		if !found {
+			continue
+		}
+		sort.Strings(oldLabels)
+		updates = append(updates, IssueUpdate{
+			Number:    issue.GetNumber(),
+			NodeID:    issue.GetNodeID(),
+			Labels:    sortedKeys(labels),
+			OldLabels: oldLabels,
+		})
+	}
+	return updates
+}
@@ -0,0 +1,78 @@
+package labeler
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/go-github/v68/github"
+)
+
+func TestComputeCleanupUpdates(t *testing.T) {
+	issue := func(number int, state string, labels ...string) *github.Issue {
+		issue := &github.Issue{Number: github.Ptr(number), State: github.Ptr(state)}
+		for _, l := range labels {
+			issue.Labels = append(issue.Labels, &github.Label{Name: github.Ptr(l)})
+		}
+		return issue
+	}
+
+	cases := map[string]struct {
+		issues []*github.Issue
+		state  string
+		want   []IssueUpdate
+	}{
+		"closed with workflow labels": {
+			issues: []*github.Issue{issue(1, "closed", "bug", "forward/review", "service/service1", "stale")},
+			want: []IssueUpdate{{
+				Number:    1,
+				Labels:    []string{"bug", "service/service1"},
+				OldLabels: []string{"bug", "forward/review", "service/service1", "stale"},
+			}},
+		},
+		"all labels removed": {
+			issues: []*github.Issue{issue(2, "closed", "waiting-response")},
+			want:   []IssueUpdate{{Number: 2, Labels: []string{}, OldLabels: []string{"waiting-response"}}},
+		},
+		"open issue": {
+			issues: []*github.Issue{issue(3, "open", "forward/review")},
+		},
+		"open issue in all states": {
+			issues: []*github.Issue{issue(3, "open", "forward/review"), issue(6, "closed", "stale")},
+			state:  "all",
+			want: []IssueUpdate{
+				{Number: 3, Labels: []string{}, OldLabels: []string{"forward/review"}},
+				{Number: 6, Labels: []string{}, OldLabels: []string{"stale"}},
+			},
+		},
+		"closed issue in open state": {
+			issues: []*github.Issue{issue(7, "closed", "stale")},
+			state:  "open",
+		},
+		"closed without workflow labels": {
+			issues: []*github.Issue{issue(4, "closed", "bug", "forward/linked")},
+		},
+		"pull request": {
+			issues: []*github.Issue{{
+				Number:           github.Ptr(5),
+				State:            github.Ptr("closed"),
+				Labels:           []*github.Label{{Name: github.Ptr("stale")}},
+				PullRequestLinks: &github.PullRequestLinks{},
+			}},
+		},
+	}
+
+	for tn, tc := range cases {
+		tc := tc
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+			state := tc.state
+			if state == "" {
+				state = "closed"
+			}
+			got := ComputeCleanupUpdates(tc.issues, DefaultCleanupLabels, state)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("want %+v, got %+v", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package labeler
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// DefaultTriageLabel is applied instead of service labels to issues that match too many
+// services to route.
+const DefaultTriageLabel = "needs-triage"
+
+// ConflictPolicy decides which service labels an issue gets when its body matches several
+// services, as happens when a whole module is pasted in, or none. The zero value applies every
+// label and leaves issues matching no service alone.
+type ConflictPolicy struct {
+	// MaxLabels caps the service labels added to an issue, keeping the services with the most
+	// matched resources. 0 means no cap.
+	MaxLabels int
+	// TriageAbove applies TriageLabel instead of any service label when more than this many
+	// services match. 0 disables it.
+	TriageAbove int
+	TriageLabel string
+	// UnroutableLabel, if set, is applied to issues matching no service, so they can be found
+	// and triaged by hand instead of sitting unlabeled. It's removed once the rules route them.
+	UnroutableLabel string
+}
+
+// unroutable reports whether an issue with the given labels, whose body routes to no service,
+// should get UnroutableLabel: it isn't set, or someone already gave the issue a service label.
+func (p ConflictPolicy) unroutable(labels map[string]struct{}) bool {
+	if p.UnroutableLabel == "" {
+		return false
+	}
+	for l := range labels {
+		if strings.HasPrefix(l, "service/") && l != p.UnroutableLabel {
+			return false
+		}
+	}
+	return true
+}
+
+// Validate checks that the limits aren't negative and that a triage label is set if needed.
+func (p ConflictPolicy) Validate() error {
+	if p.MaxLabels < 0 || p.TriageAbove < 0 {
+		return fmt.Errorf("service label limits must not be negative")
+	}
+	if (p.MaxLabels > 0 || p.TriageAbove > 0) && p.TriageLabel == "" {
+		return fmt.Errorf("a triage label is required when limiting service labels")
+	}
+	return nil
+}
+
+// Resolve returns the labels to apply out of those matched, or triage if the issue should get
+// TriageLabel instead. Services are ranked by how many distinct resources matched them. When
+// the cap falls between services matched equally often there is nothing to prefer one by, so
+// the issue goes to triage too.
+func (p ConflictPolicy) Resolve(matches []LabelMatch) (labels []string, triage bool) {
+	resources := make(map[string]map[string]struct{})
+	for _, m := range matches {
+		if resources[m.Label] == nil {
+			resources[m.Label] = make(map[string]struct{})
+		}
+		resources[m.Label][m.Resource] = struct{}{}
+	}
+	for label := range resources {
+		labels = append(labels, label)
+	}
+	sort.Slice(labels, func(i, j int) bool {
+		if len(resources[labels[i]]) != len(resources[labels[j]]) {
+			return len(resources[labels[i]]) > len(resources[labels[j]])
+		}
+		return labels[i] < labels[j]
+	})
+
+	if p.TriageAbove > 0 && len(labels) > p.TriageAbove {
+		return nil, true
+	}
+	if p.MaxLabels > 0 && len(labels) > p.MaxLabels {
+		if len(resources[labels[p.MaxLabels-1]]) == len(resources[labels[p.MaxLabels]]) {
+			return nil, true
+		}
+		labels = labels[:p.MaxLabels]
+	}
+	return labels, false
+}
+
+type conflictPolicyKey struct{}
+
+// WithConflictPolicy returns a context whose issue updates resolve conflicting service
+// labels with p.
+func WithConflictPolicy(ctx context.Context, p ConflictPolicy) context.Context {
+	return context.WithValue(ctx, conflictPolicyKey{}, p)
+}
+
+func conflictPolicyFrom(ctx context.Context) ConflictPolicy {
+	p, _ := ctx.Value(conflictPolicyKey{}).(ConflictPolicy)
+	return p
+}
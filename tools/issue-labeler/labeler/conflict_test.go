@@ -0,0 +1,158 @@
+package labeler
+
+import (
+	"context"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/google/go-github/v68/github"
+)
+
+func TestConflictPolicyResolve(t *testing.T) {
+	matches := func(labelResources ...string) []LabelMatch {
+		var ms []LabelMatch
+		for i := 0; i < len(labelResources); i += 2 {
+			ms = append(ms, LabelMatch{Label: labelResources[i], Resource: labelResources[i+1], Section: SectionAffectedResources})
+		}
+		return ms
+	}
+	cases := map[string]struct {
+		policy     ConflictPolicy
+		matches    []LabelMatch
+		wantLabels []string
+		wantTriage bool
+	}{
+		"no policy": {
+			matches:    matches("service/b", "google_b", "service/a", "google_a"),
+			wantLabels: []string{"service/a", "service/b"},
+		},
+		"under cap": {
+			policy:     ConflictPolicy{MaxLabels: 2, TriageLabel: "needs-triage"},
+			matches:    matches("service/a", "google_a"),
+			wantLabels: []string{"service/a"},
+		},
+		"cap keeps most matched": {
+			policy:     ConflictPolicy{MaxLabels: 1, TriageLabel: "needs-triage"},
+			matches:    matches("service/a", "google_a", "service/b", "google_b1", "service/b", "google_b2"),
+			wantLabels: []string{"service/b"},
+		},
+		"repeated resource counts once": {
+			policy: ConflictPolicy{MaxLabels: 1, TriageLabel: "needs-triage"},
+			matches: append(matches("service/a", "google_a", "service/b", "google_b1", "service/b", "google_b2"),
+				LabelMatch{Label: "service/a", Resource: "google_a", Section: SectionConfig}),
+			wantLabels: []string{"service/b"},
+		},
+		"tie at cap": {
+			policy:     ConflictPolicy{MaxLabels: 1, TriageLabel: "needs-triage"},
+			matches:    matches("service/a", "google_a", "service/b", "google_b"),
+			wantTriage: true,
+		},
+		"too many services": {
+			policy:     ConflictPolicy{MaxLabels: 2, TriageAbove: 3, TriageLabel: "needs-triage"},
+			matches:    matches("service/a", "google_a", "service/a", "google_a2", "service/b", "google_b", "service/c", "google_c", "service/d", "google_d"),
+			wantTriage: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		tc := tc
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+			labels, triage := tc.policy.Resolve(tc.matches)
+			if triage != tc.wantTriage {
+				t.Errorf("Resolve() triage = %v; want %v", triage, tc.wantTriage)
+			}
+			if !reflect.DeepEqual(labels, tc.wantLabels) {
+				t.Errorf("Resolve() labels = %v; want %v", labels, tc.wantLabels)
+			}
+		})
+	}
+}
+
+func TestComputeIssueUpdatesConflictPolicy(t *testing.T) {
+	rules := []RegexpLabel{
+		{Regexp: regexp.MustCompile("^google_a_.*$"), Label: "service/a"},
+		{Regexp: regexp.MustCompile("^google_b_.*$"), Label: "service/b"},
+		{Regexp: regexp.MustCompile("^google_c_.*$"), Label: "service/c"},
+	}
+	issue := &github.Issue{
+		Number: github.Ptr(1),
+		Body:   github.Ptr("### Affected Resource(s)\n\ngoogle_a_one\ngoogle_b_one\ngoogle_b_two\ngoogle_c_one\n"),
+	}
+	ctx := WithConflictPolicy(context.Background(), ConflictPolicy{MaxLabels: 1, TriageAbove: 3, TriageLabel: "needs-triage"})
+	updates := ComputeIssueUpdates(ctx, []*github.Issue{issue}, rules)
+	if len(updates) != 1 {
+		t.Fatalf("ComputeIssueUpdates() = %v; want 1 update", updates)
+	}
+	if want := []string{"forward/review", "service/b"}; !reflect.DeepEqual(updates[0].Labels, want) {
+		t.Errorf("Labels = %v; want %v", updates[0].Labels, want)
+	}
+	for _, m := range updates[0].Matches {
+		if m.Label != "service/b" {
+			t.Errorf("Matches include dropped label %s", m.Label)
+		}
+	}
+
+	ctx = WithConflictPolicy(context.Background(), ConflictPolicy{TriageAbove: 2, TriageLabel: "needs-triage"})
+	updates = ComputeIssueUpdates(ctx, []*github.Issue{issue}, rules)
+	if len(updates) != 1 {
+		t.Fatalf("ComputeIssueUpdates() = %v; want 1 update", updates)
+	}
+	if want := []string{"forward/review", "needs-triage"}; !reflect.DeepEqual(updates[0].Labels, want) {
+		t.Errorf("Labels = %v; want %v", updates[0].Labels, want)
+	}
+}
+
+func TestComputeIssueUpdatesUnroutable(t *testing.T) {
+	rules := []RegexpLabel{{Regexp: regexp.MustCompile("^google_a_.*$"), Label: "service/a"}}
+	issue := func(resource string, labels ...string) *github.Issue {
+		i := &github.Issue{Number: github.Ptr(1), Body: github.Ptr("### Affected Resource(s)\n\n" + resource + "\n")}
+		for _, l := range labels {
+			i.Labels = append(i.Labels, &github.Label{Name: github.Ptr(l)})
+		}
+		return i
+	}
+	policy := ConflictPolicy{UnroutableLabel: "service/unknown"}
+
+	cases := map[string]struct {
+		issue  *github.Issue
+		policy ConflictPolicy
+		want   []IssueUpdate
+	}{
+		"unroutable": {
+			issue:  issue("google_z_one", "bug"),
+			policy: policy,
+			want:   []IssueUpdate{{Number: 1, OldLabels: []string{"bug"}, Labels: []string{"bug", "service/unknown"}, Unroutable: true}},
+		},
+		"no unroutable label": {
+			issue: issue("google_z_one", "bug"),
+		},
+		"already unroutable": {
+			issue:  issue("google_z_one", "service/unknown"),
+			policy: policy,
+		},
+		"routed by hand": {
+			issue:  issue("google_z_one", "service/b"),
+			policy: policy,
+		},
+		"routed now": {
+			issue:  issue("google_a_one", "service/unknown"),
+			policy: policy,
+			want: []IssueUpdate{{
+				Number:    1,
+				OldLabels: []string{"service/unknown"},
+				Labels:    []string{"forward/review", "service/a"},
+				Matches:   []LabelMatch{{Label: "service/a", Resource: "google_a_one", Section: SectionAffectedResources, Pattern: "^google_a_.*$"}},
+			}},
+		},
+	}
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			ctx := WithConflictPolicy(context.Background(), tc.policy)
+			if got := ComputeIssueUpdates(ctx, []*github.Issue{tc.issue}, rules); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("ComputeIssueUpdates() = %+v; want %+v", got, tc.want)
+			}
+		})
+	}
+}
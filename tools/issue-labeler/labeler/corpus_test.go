@@ -0,0 +1,177 @@
+package labeler
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v68/github"
+	"gopkg.in/yaml.v2"
+)
+
+// Run with -update to rewrite the golden file after an intended routing change, then review
+// the diff.
+var updateGolden = flag.Bool("update", false, "rewrite testdata/corpus/golden.yml with the current results")
+
+const corpusDir = "testdata/corpus"
+
+// corpusResult is how an issue body from the corpus is routed under the enrolled teams config.
+type corpusResult struct {
+	Resources []string `yaml:"resources"`
+	// Labels are what a new issue with the body gets.
+	Labels []string `yaml:"labels"`
+}
+
+// readCorpus returns the anonymized issue bodies in the corpus, keyed by file name without
+// the .md extension.
+func readCorpus(t testing.TB) map[string]string {
+	files, err := filepath.Glob(filepath.Join(corpusDir, "*.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	corpus := make(map[string]string)
+	for _, f := range files {
+		b, err := os.ReadFile(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		corpus[strings.TrimSuffix(filepath.Base(f), ".md")] = string(b)
+	}
+	return corpus
+}
+
+func routeCorpusBody(body string, rules []RegexpLabel) corpusResult {
+	result := corpusResult{Resources: ExtractAffectedResources(body)}
+	issue := &github.Issue{Number: github.Ptr(1), Body: github.Ptr(body)}
+	if update, ok := computeIssueUpdate(issue, NewMatcher(rules), ConflictPolicy{}, AutomationPolicy{}); ok {
+		result.Labels = update.Labels
+	}
+	return result
+}
+
+// TestCorpusRouting checks that real issue bodies are still routed as recorded in the golden
+// file, so changes to the extraction and matching can't silently change routing.
+func TestCorpusRouting(t *testing.T) {
+	rules, err := BuildRegexLabels(EnrolledTeamsYaml)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make(map[string]corpusResult)
+	for name, body := range readCorpus(t) {
+		got[name] = routeCorpusBody(body, rules)
+	}
+
+	goldenPath := filepath.Join(corpusDir, "golden.yml")
+	if *updateGolden {
+		b, err := yaml.Marshal(got)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(goldenPath, b, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	b, err := os.ReadFile(goldenPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := make(map[string]corpusResult)
+	if err := yaml.UnmarshalStrict(b, &want); err != nil {
+		t.Fatal(err)
+	}
+	for name := range want {
+		if _, ok := got[name]; !ok {
+			t.Errorf("%s is in the golden file but not the corpus", name)
+		}
+	}
+	for name, result := range got {
+		w, ok := want[name]
+		if !ok {
+			t.Errorf("%s is missing from the golden file, run the test with -update", name)
+			continue
+		}
+		if !reflect.DeepEqual(normalizeCorpusResult(result), normalizeCorpusResult(w)) {
+			t.Errorf("%s: got %+v; want %+v", name, result, w)
+		}
+	}
+}
+
+// normalizeCorpusResult treats empty and missing lists alike, as the golden file does.
+func normalizeCorpusResult(r corpusResult) corpusResult {
+	if len(r.Resources) == 0 {
+		r.Resources = nil
+	}
+	if len(r.Labels) == 0 {
+		r.Labels = nil
+	}
+	return r
+}
+
+// addCorpusSeeds seeds a fuzz target with the corpus and a few unusual bodies.
+func addCorpusSeeds(f *testing.F) {
+	for _, body := range readCorpus(f) {
+		f.Add(body)
+	}
+	for _, body := range []string{
+		"",
+		"### Affected Resource(s)",
+		"### Affected Resource(s)\n\n```\ngoogle_",
+		"### Affected Resource(s)\n\n<!-- google_compute_instance",
+		"### Affected Resource(s)\r\n\r\ngoogle_compute_instance\r\n",
+		"### Affected Resource(s)\n\n```\nresource \"aws_instance\" \"x\" {\n{\n```\ngoogle_storage_bucket",
+	} {
+		f.Add(body)
+	}
+}
+
+var extractedResourceRegexp = regexp.MustCompile(`^google_[\w*.]+$`)
+
+func FuzzExtractAffectedResources(f *testing.F) {
+	addCorpusSeeds(f)
+	f.Fuzz(func(t *testing.T, body string) {
+		resources := ExtractAffectedResources(body)
+		for _, r := range resources {
+			if !extractedResourceRegexp.MatchString(r) {
+				t.Errorf("ExtractAffectedResources() returned %q, which isn't a resource name", r)
+			}
+		}
+		if again := ExtractAffectedResources(body); !reflect.DeepEqual(resources, again) {
+			t.Errorf("ExtractAffectedResources() = %v, then %v for the same body", resources, again)
+		}
+	})
+}
+
+func FuzzMatchIssueLabels(f *testing.F) {
+	rules, err := BuildRegexLabels(EnrolledTeamsYaml)
+	if err != nil {
+		f.Fatal(err)
+	}
+	patterns := make(map[string]*regexp.Regexp)
+	for _, rule := range rules {
+		patterns[rule.Regexp.String()] = rule.Regexp
+	}
+	addCorpusSeeds(f)
+	f.Fuzz(func(t *testing.T, body string) {
+		seen := make(map[string]bool)
+		for _, m := range MatchIssueLabels(body, rules) {
+			if !strings.HasPrefix(m.Label, "service/") {
+				t.Errorf("MatchIssueLabels() matched %q, which isn't a service label", m.Label)
+			}
+			if re, ok := patterns[m.Pattern]; !ok || !re.MatchString(m.Resource) {
+				t.Errorf("MatchIssueLabels() matched %q to %s with pattern %q, which doesn't match it", m.Resource, m.Label, m.Pattern)
+			}
+			if seen[m.Resource] {
+				t.Errorf("MatchIssueLabels() matched %q more than once", m.Resource)
+			}
+			seen[m.Resource] = true
+			if want, ok := scanRules(m.Resource, m.Section, rules); !ok || want.Regexp.String() != m.Pattern {
+				t.Errorf("MatchIssueLabels() matched %q with pattern %q; trying every rule in order matches %q", m.Resource, m.Pattern, want.Regexp)
+			}
+		}
+	})
+}
@@ -0,0 +1,267 @@
+package labeler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/smtp"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/google/go-github/v68/github"
+)
+
+// DefaultSendGridEndpoint is the SendGrid v3 mail send API.
+const DefaultSendGridEndpoint = "https://api.sendgrid.com/v3/mail/send"
+
+// excerptLength is the most characters of an issue's description a digest includes.
+const excerptLength = 280
+
+// excerptSections are the issue form sections a digest excerpt is taken from, in order of preference.
+var excerptSections = []string{"description", "actual behavior", "expected behavior"}
+
+var digestTemplate = template.Must(template.New("digest").Parse(`{{len .Issues}} new issues in {{.Repository}} are waiting in forward/review for {{.Label}}:
+{{range .Issues}}
+#{{.Number}}: {{.Title}} ({{.AgeDays}})
+{{.URL}}
+{{if .Excerpt}}> {{.Excerpt}}
+{{end}}{{end}}
+Once an issue has been picked up, replace forward/review with forward/linked.
+`))
+
+// DigestIssue is an issue listed in a team's digest.
+type DigestIssue struct {
+	Number  int
+	Title   string
+	URL     string
+	Age     time.Duration
+	Excerpt string
+}
+
+// AgeDays describes the issue's age in whole days.
+func (i DigestIssue) AgeDays() string {
+	days := int(i.Age.Hours() / 24)
+	switch days {
+	case 0:
+		return "opened today"
+	case 1:
+		return "1 day old"
+	}
+	return fmt.Sprintf("%d days old", days)
+}
+
+// Digest is the email sent to one service label's team.
+type Digest struct {
+	Repository string
+	Label      string
+	To         []string
+	Issues     []DigestIssue
+}
+
+// Subject is the digest email's subject line.
+func (d Digest) Subject() string {
+	return fmt.Sprintf("[%s] %d new %s issues to review", d.Repository, len(d.Issues), d.Label)
+}
+
+// Body renders the digest email as plain text.
+func (d Digest) Body() (string, error) {
+	var b bytes.Buffer
+	if err := digestTemplate.Execute(&b, d); err != nil {
+		return "", fmt.Errorf("rendering digest for %s: %w", d.Label, err)
+	}
+	return b.String(), nil
+}
+
+// BuildLabelDigests returns a map of service label to the addresses receiving its digest.
+func BuildLabelDigests(teamsYaml []byte) (map[string][]string, error) {
+	enrolledTeams, err := ParseEnrolledTeams(teamsYaml)
+	if err != nil {
+		return nil, err
+	}
+	labelDigests := make(map[string][]string)
+	for label, data := range enrolledTeams {
+		if len(data.Digest) > 0 {
+			labelDigests[label] = data.Digest
+		}
+	}
+	return labelDigests, nil
+}
+
+// ComputeDigests groups the open issues in forward/review that were opened after cutoff by
+// service label, for each label with digest addresses. Issues are listed oldest first.
+func ComputeDigests(repository string, issues []*github.Issue, labelDigests map[string][]string, cutoff, now time.Time) []Digest {
+	byLabel := make(map[string][]DigestIssue)
+	for _, issue := range issues {
+		if !needsForwarding(issue) || issue.GetCreatedAt().Time.Before(cutoff) {
+			continue
+		}
+		for _, l := range issue.Labels {
+			if _, ok := labelDigests[l.GetName()]; !ok {
+				continue
+			}
+			byLabel[l.GetName()] = append(byLabel[l.GetName()], DigestIssue{
+				Number:  issue.GetNumber(),
+				Title:   issue.GetTitle(),
+				URL:     fmt.Sprintf("https://github.com/%s/issues/%d", repository, issue.GetNumber()),
+				Age:     now.Sub(issue.GetCreatedAt().Time),
+				Excerpt: issueExcerpt(issue.GetBody()),
+			})
+		}
+	}
+
+	var digests []Digest
+	for label, issues := range byLabel {
+		sort.Slice(issues, func(i, j int) bool {
+			if issues[i].Age != issues[j].Age {
+				return issues[i].Age > issues[j].Age
+			}
+			return issues[i].Number < issues[j].Number
+		})
+		digests = append(digests, Digest{
+			Repository: repository,
+			Label:      label,
+			To:         labelDigests[label],
+			Issues:     issues,
+		})
+	}
+	sort.Slice(digests, func(i, j int) bool { return digests[i].Label < digests[j].Label })
+	return digests
+}
+
+// issueExcerpt returns the start of an issue's description on a single line.
+func issueExcerpt(body string) string {
+	form := ParseIssueForm(body)
+	text := ""
+	for _, section := range excerptSections {
+		if form[section] != "" {
+			text = form[section]
+			break
+		}
+	}
+	if text == "" && len(form) == 0 {
+		text = commentRegexp.ReplaceAllString(body, "")
+	}
+	text = strings.Join(strings.Fields(text), " ")
+	if r := []rune(text); len(r) > excerptLength {
+		text = strings.TrimSpace(string(r[:excerptLength])) + "…"
+	}
+	return text
+}
+
+// Email is a plain text message.
+type Email struct {
+	From    string
+	To      []string
+	Subject string
+	Body    string
+}
+
+// Mailer sends email.
+type Mailer interface {
+	Send(ctx context.Context, email Email) error
+}
+
+// SMTPMailer sends email through an SMTP server, authenticating with PLAIN auth if Username is set.
+type SMTPMailer struct {
+	Addr     string
+	Username string
+	Password string
+}
+
+func (m SMTPMailer) Send(ctx context.Context, email Email) error {
+	var auth smtp.Auth
+	if m.Username != "" {
+		host, _, _ := strings.Cut(m.Addr, ":")
+		auth = smtp.PlainAuth("", m.Username, m.Password, host)
+	}
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\nTo: %s\r\nSubject: %s\r\n", email.From, strings.Join(email.To, ", "), email.Subject)
+	fmt.Fprintf(&msg, "MIME-Version: 1.0\r\nContent-Type: text/plain; charset=UTF-8\r\n\r\n")
+	msg.WriteString(strings.ReplaceAll(email.Body, "\n", "\r\n"))
+	if err := smtp.SendMail(m.Addr, auth, email.From, email.To, msg.Bytes()); err != nil {
+		return fmt.Errorf("sending mail via %s: %w", m.Addr, err)
+	}
+	return nil
+}
+
+// SendGridMailer sends email through the SendGrid API.
+type SendGridMailer struct {
+	APIKey   string
+	Endpoint string
+	HTTP     *http.Client
+}
+
+func (m SendGridMailer) Send(ctx context.Context, email Email) error {
+	type address struct {
+		Email string `json:"email"`
+	}
+	type content struct {
+		Type  string `json:"type"`
+		Value string `json:"value"`
+	}
+	var to []address
+	for _, addr := range email.To {
+		to = append(to, address{addr})
+	}
+	body, err := json.Marshal(map[string]any{
+		"personalizations": []map[string]any{{"to": to}},
+		"from":             address{email.From},
+		"subject":          email.Subject,
+		"content":          []content{{"text/plain", email.Body}},
+	})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", m.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+m.APIKey)
+	req.Header.Set("Content-Type", "application/json")
+	client := m.HTTP
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("sending mail via SendGrid: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("sending mail via SendGrid: %s: %s", resp.Status, b)
+	}
+	return nil
+}
+
+// SendDigests emails each digest to its team, or only logs them in dry-run mode.
+func SendDigests(ctx context.Context, mailer Mailer, from string, digests []Digest, dryRun bool) error {
+	failed := 0
+	for _, d := range digests {
+		logger := slog.With("repo", d.Repository, "label", d.Label, "to", d.To, "issues", len(d.Issues))
+		body, err := d.Body()
+		if err != nil {
+			return err
+		}
+		if dryRun {
+			logger.Info("would send digest", "subject", d.Subject(), "body", body)
+			continue
+		}
+		if err := mailer.Send(ctx, Email{From: from, To: d.To, Subject: d.Subject(), Body: body}); err != nil {
+			logger.Error("sending digest failed", "error", err)
+			failed++
+			continue
+		}
+		logger.Info("sent digest")
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to send %d / %d digests", failed, len(digests))
+	}
+	return nil
+}
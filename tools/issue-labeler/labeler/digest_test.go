@@ -0,0 +1,159 @@
+package labeler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v68/github"
+)
+
+func TestComputeDigests(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	cutoff := now.Add(-24 * time.Hour)
+	labels := func(names ...string) []*github.Label {
+		var ls []*github.Label
+		for _, n := range names {
+			ls = append(ls, &github.Label{Name: github.Ptr(n)})
+		}
+		return ls
+	}
+	issue := func(number int, created time.Time, ls []*github.Label) *github.Issue {
+		return &github.Issue{
+			Number:    github.Ptr(number),
+			Title:     github.Ptr("Issue title"),
+			State:     github.Ptr("open"),
+			CreatedAt: &github.Timestamp{Time: created},
+			Body:      github.Ptr("### Description\n\nSomething is broken."),
+			Labels:    ls,
+		}
+	}
+	labelDigests := map[string][]string{
+		"service/service1": {"team1@example.com"},
+		"service/service2": {"team2@example.com"},
+	}
+
+	cases := map[string]struct {
+		issues []*github.Issue
+		want   []Digest
+	}{
+		"grouped by label, oldest first": {
+			issues: []*github.Issue{
+				issue(1, now.Add(-time.Hour), labels("forward/review", "service/service1")),
+				issue(2, now.Add(-20*time.Hour), labels("forward/review", "service/service1", "service/service2")),
+			},
+			want: []Digest{
+				{
+					Repository: "owner/repo",
+					Label:      "service/service1",
+					To:         []string{"team1@example.com"},
+					Issues: []DigestIssue{
+						{Number: 2, Title: "Issue title", URL: "https://github.com/owner/repo/issues/2", Age: 20 * time.Hour, Excerpt: "Something is broken."},
+						{Number: 1, Title: "Issue title", URL: "https://github.com/owner/repo/issues/1", Age: time.Hour, Excerpt: "Something is broken."},
+					},
+				},
+				{
+					Repository: "owner/repo",
+					Label:      "service/service2",
+					To:         []string{"team2@example.com"},
+					Issues: []DigestIssue{
+						{Number: 2, Title: "Issue title", URL: "https://github.com/owner/repo/issues/2", Age: 20 * time.Hour, Excerpt: "Something is broken."},
+					},
+				},
+			},
+		},
+		"skips old, linked and unmapped issues": {
+			issues: []*github.Issue{
+				issue(1, now.Add(-48*time.Hour), labels("forward/review", "service/service1")),
+				issue(2, now.Add(-time.Hour), labels("forward/linked", "service/service1")),
+				issue(3, now.Add(-time.Hour), labels("forward/review", "service/service3")),
+				issue(4, now.Add(-time.Hour), labels("service/service1")),
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		tc := tc
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+			got := ComputeDigests("owner/repo", tc.issues, labelDigests, cutoff, now)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("want %+v, got %+v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestIssueExcerpt(t *testing.T) {
+	cases := map[string]struct {
+		body string
+		want string
+	}{
+		"description section": {
+			body: "### Community Note\n\n* Please vote\n\n### Description\n\n<!-- hint -->\nAdd support for\nthe new field.\n\n### References\n\n_No response_",
+			want: "Add support for the new field.",
+		},
+		"actual behavior": {
+			body: "### Expected Behavior\n\nIt works.\n\n### Actual Behavior\n\nIt panics.",
+			want: "It panics.",
+		},
+		"no issue form": {
+			body: "Plain   body\ntext",
+			want: "Plain body text",
+		},
+		"truncated": {
+			body: "### Description\n\n" + strings.Repeat("word ", 100),
+			want: strings.Repeat("word ", 56)[:279] + "…",
+		},
+	}
+
+	for tn, tc := range cases {
+		tc := tc
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+			if got := issueExcerpt(tc.body); got != tc.want {
+				t.Errorf("want %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestSendGridMailer(t *testing.T) {
+	var got map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decoding request: %v", err)
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	m := SendGridMailer{APIKey: "key", Endpoint: srv.URL}
+	err := m.Send(context.Background(), Email{From: "labeler@example.com", To: []string{"team@example.com"}, Subject: "subject", Body: "body"})
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	want := map[string]any{
+		"personalizations": []any{map[string]any{"to": []any{map[string]any{"email": "team@example.com"}}}},
+		"from":             map[string]any{"email": "labeler@example.com"},
+		"subject":          "subject",
+		"content":          []any{map[string]any{"type": "text/plain", "value": "body"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+
+	m.APIKey = "wrong"
+	if err := m.Send(context.Background(), Email{From: "labeler@example.com", To: []string{"team@example.com"}}); err == nil {
+		t.Errorf("Send() with a bad key succeeded")
+	}
+}
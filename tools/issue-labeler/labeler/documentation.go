@@ -0,0 +1,50 @@
+package labeler
+
+import (
+	"regexp"
+)
+
+const (
+	// LabelDocumentation marks issues about the provider's documentation rather than its
+	// behavior, so service teams can fast-track them. It's added alongside service labels.
+	LabelDocumentation = "documentation"
+
+	// SectionDocumentation is the issue form section linking the documentation an issue is about.
+	SectionDocumentation = "documentation"
+
+	// sectionReferences is where feature requests link related docs and pull requests.
+	sectionReferences = "references"
+)
+
+// docsURLRegexp matches links to the provider's documentation on the Terraform registry, and
+// the older terraform.io links that redirect there.
+var docsURLRegexp = regexp.MustCompile(`(?i)registry\.terraform\.io/providers/hashicorp/google(-beta)?/[^/\s]+/docs|terraform\.io/docs/providers/google`)
+
+// typoRegexp matches reports of typos and spelling mistakes.
+var typoRegexp = regexp.MustCompile(`(?i)\b(typos?|misspell(ed|ing)?|spelling (mistake|error)s?)\b`)
+
+// IsDocumentationIssue reports whether an issue is about documentation: it links the
+// provider's docs, fills in the documentation section of the issue form, or reports a typo.
+// Config, debug output and references are ignored, since links and text pasted there are
+// rarely what the issue is about. Bodies that aren't issue forms are checked whole.
+func IsDocumentationIssue(title, body string) bool {
+	if typoRegexp.MatchString(title) {
+		return true
+	}
+	form := ParseIssueForm(body)
+	if len(form) == 0 {
+		return docsURLRegexp.MatchString(body) || typoRegexp.MatchString(body)
+	}
+	if form[SectionDocumentation] != "" {
+		return true
+	}
+	for name, content := range form {
+		if name == SectionConfig || name == SectionDebugOutput || name == sectionReferences {
+			continue
+		}
+		if docsURLRegexp.MatchString(content) || typoRegexp.MatchString(content) {
+			return true
+		}
+	}
+	return false
+}
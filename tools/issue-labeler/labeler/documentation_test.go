@@ -0,0 +1,112 @@
+package labeler
+
+import (
+	"context"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/google/go-github/v68/github"
+)
+
+func TestIsDocumentationIssue(t *testing.T) {
+	cases := map[string]struct {
+		title string
+		body  string
+		want  bool
+	}{
+		"registry docs link": {
+			body: "### Affected Resource(s)\n\ngoogle_compute_instance\n\n### Description\n\nhttps://registry.terraform.io/providers/hashicorp/google/latest/docs/resources/compute_instance says the field is optional.\n",
+			want: true,
+		},
+		"beta registry docs link": {
+			body: "### Description\n\nSee https://registry.terraform.io/providers/hashicorp/google-beta/6.0.0/docs/guides/provider_reference\n",
+			want: true,
+		},
+		"documentation section": {
+			body: "### Documentation\n\nThe import section\n\n### Affected Resource(s)\n\ngoogle_compute_instance\n",
+			want: true,
+		},
+		"empty documentation section": {
+			body: "### Documentation\n\n_No response_\n\n### Affected Resource(s)\n\ngoogle_compute_instance\n",
+		},
+		"typo in title": {
+			title: "Typo in google_compute_instance docs",
+			body:  "### Affected Resource(s)\n\ngoogle_compute_instance\n",
+			want:  true,
+		},
+		"misspelling in description": {
+			body: "### Description\n\nThe attribute is misspelled on the page.\n",
+			want: true,
+		},
+		"not an issue form": {
+			body: "The example at https://registry.terraform.io/providers/hashicorp/google/latest/docs/resources/storage_bucket doesn't work.",
+			want: true,
+		},
+		"docs link in references": {
+			body: "### Description\n\nSupport the new API field.\n\n### References\n\nhttps://registry.terraform.io/providers/hashicorp/google/latest/docs/resources/storage_bucket\n",
+		},
+		"docs link in config": {
+			body: "### Terraform Configuration\n\n```hcl\n# https://registry.terraform.io/providers/hashicorp/google/latest/docs\nresource \"google_storage_bucket\" \"b\" {}\n```\n",
+		},
+		"code bug": {
+			title: "Permadiff on google_storage_bucket",
+			body:  "### Affected Resource(s)\n\ngoogle_storage_bucket\n\n### Expected Behavior\n\nNo diff.\n",
+		},
+	}
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			if got := IsDocumentationIssue(tc.title, tc.body); got != tc.want {
+				t.Errorf("IsDocumentationIssue() = %v; want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestComputeIssueUpdatesDocumentation(t *testing.T) {
+	rules := []RegexpLabel{
+		{Regexp: regexp.MustCompile("^google_service1_.*$"), Label: "service/service1"},
+	}
+	issues := []*github.Issue{
+		{
+			Number: github.Ptr(1),
+			Title:  github.Ptr("Typo in google_service1_resource1 docs"),
+			Body:   github.Ptr("### Affected Resource(s)\n\ngoogle_service1_resource1\n"),
+		},
+		{
+			Number: github.Ptr(2),
+			Title:  github.Ptr("Typo in google_service1_resource1 docs"),
+			Body:   github.Ptr("### Affected Resource(s)\n\ngoogle_service1_resource1\n"),
+			Labels: []*github.Label{{Name: github.Ptr("documentation")}, {Name: github.Ptr("forward/review")}, {Name: github.Ptr("service/service1")}},
+		},
+	}
+
+	updates := ComputeIssueUpdates(context.Background(), issues, rules)
+	if len(updates) != 1 {
+		t.Fatalf("ComputeIssueUpdates() = %v; want 1 update", updates)
+	}
+	if want := []string{"documentation", "forward/review", "service/service1"}; !reflect.DeepEqual(updates[0].Labels, want) {
+		t.Errorf("Labels = %v; want %v", updates[0].Labels, want)
+	}
+}
+
+func TestComputeIssueUpdatesDocumentationUnmatched(t *testing.T) {
+	rules := []RegexpLabel{
+		{Regexp: regexp.MustCompile("^google_service1_.*$"), Label: "service/service1"},
+	}
+	issues := []*github.Issue{
+		{
+			Number: github.Ptr(1),
+			Title:  github.Ptr("Typo in google_service2_resource1 docs"),
+			Body:   github.Ptr("### Affected Resource(s)\n\ngoogle_service2_resource1\n"),
+		},
+	}
+
+	updates := ComputeIssueUpdates(context.Background(), issues, rules)
+	if len(updates) != 1 {
+		t.Fatalf("ComputeIssueUpdates() = %v; want 1 update", updates)
+	}
+	if want := []string{"documentation"}; !reflect.DeepEqual(updates[0].Labels, want) {
+		t.Errorf("Labels = %v; want %v", updates[0].Labels, want)
+	}
+}
@@ -0,0 +1,222 @@
+package labeler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v68/github"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	// driftHistoryRuns is how many runs per repository the drift history keeps.
+	driftHistoryRuns = 30
+	// minDriftHistory is how many earlier runs are needed before drift is detected.
+	minDriftHistory = 3
+	// minDriftIncrease is the least a label's share must grow by to count as a spike, so rare
+	// labels doubling from one issue to two don't alert.
+	minDriftIncrease = 0.1
+)
+
+var (
+	labelShare = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "issue_labeler_label_share",
+		Help: "Share of the last run's issues with each service label.",
+	}, []string{"label"})
+	unmatchedShare = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "issue_labeler_unmatched_share",
+		Help: "Share of the last run's issues that matched no service.",
+	})
+	driftAlerts = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "issue_labeler_drift_alerts_total",
+		Help: "Label distribution drift alerts, by kind: spike or unmatched.",
+	}, []string{"kind"})
+)
+
+// LabelDistribution counts the service labels the issues in one run ended up with.
+type LabelDistribution struct {
+	Time      time.Time      `json:"time"`
+	Issues    int            `json:"issues"`
+	Unmatched int            `json:"unmatched"`
+	ByLabel   map[string]int `json:"by_label"`
+}
+
+// ComputeLabelDistribution counts the service labels each issue has after its update, if any.
+// Pull requests are skipped and issues without a service label count as unmatched.
+func ComputeLabelDistribution(issues []*github.Issue, updates []IssueUpdate, now time.Time) LabelDistribution {
+	byNumber := make(map[int]IssueUpdate)
+	for _, u := range updates {
+		byNumber[u.Number] = u
+	}
+	d := LabelDistribution{Time: now, ByLabel: make(map[string]int)}
+	for _, issue := range issues {
+		if issue.IsPullRequest() {
+			continue
+		}
+		d.Issues++
+		var labels []string
+		if u, ok := byNumber[issue.GetNumber()]; ok {
+			labels = u.Labels
+		} else {
+			for _, l := range issue.Labels {
+				labels = append(labels, l.GetName())
+			}
+		}
+		matched := false
+		for _, l := range labels {
+			if strings.HasPrefix(l, "service/") {
+				d.ByLabel[l]++
+				matched = true
+			}
+		}
+		if !matched {
+			d.Unmatched++
+		}
+	}
+	return d
+}
+
+// DriftConfig sets when a run's label distribution is reported as drifting.
+type DriftConfig struct {
+	// SpikeFactor alerts when a label's share of a run's issues is more than this many times
+	// its share over the history.
+	SpikeFactor float64
+	// MaxUnmatched alerts when more than this share of a run's issues match no service.
+	MaxUnmatched float64
+	// MinIssues skips runs with fewer issues, whose shares are too noisy to compare.
+	MinIssues int
+}
+
+// DriftAlert is a label whose share spiked, or the unmatched share if Label is empty.
+type DriftAlert struct {
+	Label    string
+	Share    float64
+	Baseline float64
+}
+
+func (a DriftAlert) String() string {
+	if a.Label == "" {
+		return fmt.Sprintf("%.0f%% of issues matched no service (usually %.0f%%)", a.Share*100, a.Baseline*100)
+	}
+	return fmt.Sprintf("%s was applied to %.0f%% of issues (usually %.0f%%)", a.Label, a.Share*100, a.Baseline*100)
+}
+
+// DetectDrift compares a run's distribution with the earlier runs in history. A spike in a
+// label's share often means a rule started matching too broadly, and a jump in unmatched
+// issues that a rule or the issue template broke.
+func DetectDrift(history []LabelDistribution, current LabelDistribution, cfg DriftConfig) []DriftAlert {
+	if len(history) < minDriftHistory || current.Issues == 0 || current.Issues < cfg.MinIssues {
+		return nil
+	}
+	total, unmatched := 0, 0
+	byLabel := make(map[string]int)
+	for _, d := range history {
+		total += d.Issues
+		unmatched += d.Unmatched
+		for l, n := range d.ByLabel {
+			byLabel[l] += n
+		}
+	}
+	if total == 0 {
+		return nil
+	}
+
+	var alerts []DriftAlert
+	labels := make([]string, 0, len(current.ByLabel))
+	for l := range current.ByLabel {
+		labels = append(labels, l)
+	}
+	sort.Strings(labels)
+	for _, l := range labels {
+		share := float64(current.ByLabel[l]) / float64(current.Issues)
+		baseline := float64(byLabel[l]) / float64(total)
+		if share > baseline*cfg.SpikeFactor && share-baseline >= minDriftIncrease {
+			alerts = append(alerts, DriftAlert{Label: l, Share: share, Baseline: baseline})
+		}
+	}
+	if share := float64(current.Unmatched) / float64(current.Issues); cfg.MaxUnmatched > 0 && share > cfg.MaxUnmatched {
+		alerts = append(alerts, DriftAlert{Share: share, Baseline: float64(unmatched) / float64(total)})
+	}
+	return alerts
+}
+
+// ObserveDistribution exports a run's label shares and counts its drift alerts.
+func ObserveDistribution(d LabelDistribution, alerts []DriftAlert) {
+	if d.Issues == 0 {
+		return
+	}
+	labelShare.Reset()
+	for l, n := range d.ByLabel {
+		labelShare.WithLabelValues(l).Set(float64(n) / float64(d.Issues))
+	}
+	unmatchedShare.Set(float64(d.Unmatched) / float64(d.Issues))
+	for _, a := range alerts {
+		kind := "spike"
+		if a.Label == "" {
+			kind = "unmatched"
+		}
+		driftAlerts.WithLabelValues(kind).Inc()
+	}
+}
+
+// DriftHistory keeps the label distributions of recent runs for each repository.
+type DriftHistory struct {
+	Repositories map[string][]LabelDistribution `json:"repositories"`
+}
+
+// LoadDriftHistory reads the history at path. A missing file yields an empty history.
+func LoadDriftHistory(path string) (*DriftHistory, error) {
+	h := &DriftHistory{Repositories: make(map[string][]LabelDistribution)}
+	b, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return h, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading drift history: %w", err)
+	}
+	if err := json.Unmarshal(b, h); err != nil {
+		return nil, fmt.Errorf("parsing drift history: %w", err)
+	}
+	if h.Repositories == nil {
+		h.Repositories = make(map[string][]LabelDistribution)
+	}
+	return h, nil
+}
+
+// Add appends a run's distribution, dropping the oldest beyond the runs kept.
+func (h *DriftHistory) Add(repository string, d LabelDistribution) {
+	runs := append(h.Repositories[repository], d)
+	if len(runs) > driftHistoryRuns {
+		runs = runs[len(runs)-driftHistoryRuns:]
+	}
+	h.Repositories[repository] = runs
+}
+
+// Save atomically replaces the history file at path.
+func (h *DriftHistory) Save(path string) error {
+	if err := writeJSONAtomic(path, h); err != nil {
+		return fmt.Errorf("writing drift history: %w", err)
+	}
+	return nil
+}
+
+// NotifyDrift posts a run's drift alerts to the default notification channel, if set.
+func NotifyDrift(ctx context.Context, cfg NotifyConfig, repository string, alerts []DriftAlert) error {
+	if cfg.Default == "" || len(alerts) == 0 {
+		return nil
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "Issue labeler label distribution drifted on %s:", repository)
+	for _, a := range alerts {
+		fmt.Fprintf(&b, "\n%s", a)
+	}
+	return postChatMessage(ctx, cfg.Default, b.String())
+}
@@ -0,0 +1,114 @@
+package labeler
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v68/github"
+)
+
+func TestComputeLabelDistribution(t *testing.T) {
+	issue := func(number int, labels ...string) *github.Issue {
+		i := &github.Issue{Number: github.Ptr(number)}
+		for _, l := range labels {
+			i.Labels = append(i.Labels, &github.Label{Name: github.Ptr(l)})
+		}
+		return i
+	}
+	pr := issue(4)
+	pr.PullRequestLinks = &github.PullRequestLinks{URL: github.Ptr("https://api.github.com/repos/owner/repo/pulls/4")}
+	issues := []*github.Issue{
+		issue(1, "bug", "service/service1"),
+		issue(2, "bug"),
+		issue(3, "enhancement"),
+		pr,
+	}
+	updates := []IssueUpdate{
+		{Number: 2, Labels: []string{"bug", "forward/review", "service/service1", "service/service2"}, OldLabels: []string{"bug"}},
+	}
+
+	got := ComputeLabelDistribution(issues, updates, time.Time{})
+	want := LabelDistribution{
+		Issues:    3,
+		Unmatched: 1,
+		ByLabel:   map[string]int{"service/service1": 2, "service/service2": 1},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ComputeLabelDistribution() = %+v; want %+v", got, want)
+	}
+}
+
+func TestDetectDrift(t *testing.T) {
+	cfg := DriftConfig{SpikeFactor: 3, MaxUnmatched: 0.5, MinIssues: 10}
+	run := func(issues, unmatched int, byLabel map[string]int) LabelDistribution {
+		return LabelDistribution{Issues: issues, Unmatched: unmatched, ByLabel: byLabel}
+	}
+	steady := []LabelDistribution{
+		run(20, 4, map[string]int{"service/a": 10, "service/b": 2}),
+		run(20, 4, map[string]int{"service/a": 10, "service/b": 2}),
+		run(20, 4, map[string]int{"service/a": 10, "service/b": 2}),
+	}
+
+	cases := map[string]struct {
+		history []LabelDistribution
+		current LabelDistribution
+		want    []DriftAlert
+	}{
+		"steady": {
+			history: steady,
+			current: run(20, 4, map[string]int{"service/a": 11, "service/b": 2}),
+		},
+		"label spike": {
+			history: steady,
+			current: run(20, 4, map[string]int{"service/a": 10, "service/b": 12}),
+			want:    []DriftAlert{{Label: "service/b", Share: 0.6, Baseline: 0.1}},
+		},
+		"unmatched": {
+			history: steady,
+			current: run(20, 15, map[string]int{"service/a": 5}),
+			want:    []DriftAlert{{Share: 0.75, Baseline: 0.2}},
+		},
+		"too few issues": {
+			history: steady,
+			current: run(5, 5, map[string]int{}),
+		},
+		"not enough history": {
+			history: steady[:2],
+			current: run(20, 15, map[string]int{"service/b": 5}),
+		},
+	}
+
+	for tn, tc := range cases {
+		tc := tc
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+			if got := DetectDrift(tc.history, tc.current, cfg); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("DetectDrift() = %+v; want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDriftHistory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "drift.json")
+	h, err := LoadDriftHistory(path)
+	if err != nil {
+		t.Fatalf("LoadDriftHistory() on missing file: %v", err)
+	}
+	for i := 0; i < driftHistoryRuns+5; i++ {
+		h.Add("owner/repo", LabelDistribution{Issues: i, ByLabel: map[string]int{}})
+	}
+	if err := h.Save(path); err != nil {
+		t.Fatalf("Save(): %v", err)
+	}
+	got, err := LoadDriftHistory(path)
+	if err != nil {
+		t.Fatalf("LoadDriftHistory(): %v", err)
+	}
+	runs := got.Repositories["owner/repo"]
+	if len(runs) != driftHistoryRuns || runs[0].Issues != 5 {
+		t.Errorf("kept %d runs starting at %d; want %d starting at 5", len(runs), runs[0].Issues, driftHistoryRuns)
+	}
+}
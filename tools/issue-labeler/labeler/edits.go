@@ -0,0 +1,153 @@
+package labeler
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sort"
+
+	"github.com/google/go-github/v68/github"
+)
+
+// LabelDelta is the change to an issue's labels after its body was edited.
+type LabelDelta struct {
+	Number int
+	NodeID string
+	// Labels are the issue's labels when the edit was delivered.
+	Labels []string
+	Add    []string
+	Remove []string
+}
+
+// Update returns the delta as the issue update it amounts to, for the audit log.
+func (d LabelDelta) Update() IssueUpdate {
+	labels := make(map[string]struct{})
+	for _, l := range d.Labels {
+		labels[l] = struct{}{}
+	}
+	for _, l := range d.Add {
+		labels[l] = struct{}{}
+	}
+	for _, l := range d.Remove {
+		delete(labels, l)
+	}
+	return IssueUpdate{Number: d.Number, NodeID: d.NodeID, Labels: sortedKeys(labels), OldLabels: d.Labels}
+}
+
+// deriveLabels returns the labels the rules give an issue body, after policy. Bodies matching
+// no service derive the policy's UnroutableLabel, if it has one.
+func deriveLabels(body string, regexpLabels []RegexpLabel, policy ConflictPolicy) map[string]struct{} {
+	derived := make(map[string]struct{})
+	kept, triage := policy.Resolve(MatchIssueLabels(body, regexpLabels))
+	if triage {
+		derived[policy.TriageLabel] = struct{}{}
+	}
+	for _, label := range kept {
+		derived[label] = struct{}{}
+	}
+	if len(derived) == 0 && policy.UnroutableLabel != "" {
+		derived[policy.UnroutableLabel] = struct{}{}
+	}
+	return derived
+}
+
+// ComputeEditDelta compares the labels derived from an issue's body before and after an edit.
+// Only that difference is applied, so labels people changed by hand are kept: labels newly
+// derived are added unless already present, and labels no longer derived are removed only if
+// the old body derived them. A label someone removed by hand isn't added back just because
+// the old and new bodies both derive it. Issues gaining a label are forwarded for review, and
+// automation-authored issues are given automation's label instead, as in ComputeIssueUpdates.
+// Edits leaving an issue matching no service add the policy's UnroutableLabel, if it has one.
+// Edits that make an issue about documentation add LabelDocumentation, which is never removed.
+func ComputeEditDelta(issue *github.Issue, oldBody string, regexpLabels []RegexpLabel, policy ConflictPolicy, automation AutomationPolicy) (LabelDelta, bool) {
+	current := make(map[string]struct{})
+	for _, l := range issue.Labels {
+		current[l.GetName()] = struct{}{}
+	}
+	if exemptFromLabeling(current) {
+		return LabelDelta{}, false
+	}
+
+	before := deriveLabels(oldBody, regexpLabels, policy)
+	after := deriveLabels(issue.GetBody(), regexpLabels, policy)
+	delta := LabelDelta{Number: issue.GetNumber(), NodeID: issue.GetNodeID(), Labels: sortedKeys(current)}
+	for label := range after {
+		_, derived := before[label]
+		_, present := current[label]
+		if !derived && !present {
+			delta.Add = append(delta.Add, label)
+		}
+	}
+	for label := range before {
+		_, derived := after[label]
+		_, present := current[label]
+		if !derived && present {
+			delta.Remove = append(delta.Remove, label)
+		}
+	}
+	_, documentation := current[LabelDocumentation]
+	if !documentation && IsDocumentationIssue(issue.GetTitle(), issue.GetBody()) {
+		delta.Add = append(delta.Add, LabelDocumentation)
+	}
+	_, testfailure := current[LabelTestFailure]
+	_, forwarded := current[labelForwardReview]
+	_, labeledAutomated := current[automation.Label]
+	switch {
+	case !slices.ContainsFunc(delta.Add, func(l string) bool { return l != policy.UnroutableLabel }):
+		// Nothing added, or just the unroutable label, which there's no one to forward to.
+	case automation.Authored(issue):
+		if !labeledAutomated {
+			delta.Add = append(delta.Add, automation.Label)
+		}
+	case !testfailure && !forwarded:
+		delta.Add = append(delta.Add, labelForwardReview)
+	}
+	sort.Strings(delta.Add)
+	sort.Strings(delta.Remove)
+	return delta, len(delta.Add) > 0 || len(delta.Remove) > 0
+}
+
+// ApplyLabelDelta adds and removes the delta's labels one by one rather than replacing the
+// issue's labels, so changes made since the edit was delivered aren't overwritten.
+func ApplyLabelDelta(ctx context.Context, repository string, delta LabelDelta, dryRun bool) error {
+	logger := slog.With(
+		"repo", repository,
+		"number", delta.Number,
+		"url", fmt.Sprintf("https://github.com/%s/issues/%d", repository, delta.Number),
+		"labels_added", delta.Add,
+		"labels_removed", delta.Remove,
+	)
+	if dryRun {
+		logger.Info("would update edited issue")
+		return nil
+	}
+
+	client := newGitHubClient()
+	owner, repo, err := splitRepository(repository)
+	if err != nil {
+		return fmt.Errorf("invalid repository format: %w", err)
+	}
+
+	var resp *github.Response
+	if len(delta.Add) > 0 {
+		_, resp, err = client.Issues.AddLabelsToIssue(ctx, owner, repo, delta.Number, delta.Add)
+		observeResponse(resp)
+	}
+	for _, label := range delta.Remove {
+		if err != nil {
+			break
+		}
+		resp, err = client.Issues.RemoveLabelForIssue(ctx, owner, repo, delta.Number, label)
+		observeResponse(resp)
+	}
+	if aerr := auditLogFrom(ctx).Record(auditEntry(repository, delta.Update(), resp, err)); aerr != nil {
+		logger.Error("recording audit entry failed", "error", aerr)
+	}
+	if err != nil {
+		return fmt.Errorf("updating edited issue %d: %w", delta.Number, apiError("edit_delta", resp, err))
+	}
+	updatesApplied.Inc()
+	logger.Info("updated edited issue")
+	return nil
+}
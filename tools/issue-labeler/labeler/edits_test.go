@@ -0,0 +1,111 @@
+package labeler
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/google/go-github/v68/github"
+)
+
+func TestComputeEditDelta(t *testing.T) {
+	rules := []RegexpLabel{
+		{Regexp: regexp.MustCompile("^google_service1_.*$"), Label: "service/service1"},
+		{Regexp: regexp.MustCompile("^google_service2_.*$"), Label: "service/service2"},
+	}
+	body := func(resources string) string {
+		return "### Affected Resource(s)\n\n" + resources + "\n"
+	}
+	issue := func(newBody string, labels ...string) *github.Issue {
+		i := &github.Issue{Number: github.Ptr(1), Body: github.Ptr(newBody)}
+		for _, l := range labels {
+			i.Labels = append(i.Labels, &github.Label{Name: github.Ptr(l)})
+		}
+		return i
+	}
+
+	cases := map[string]struct {
+		issue   *github.Issue
+		oldBody string
+		want    LabelDelta
+		wantOk  bool
+	}{
+		"config pasted in": {
+			issue:   issue(body("google_service1_resource1"), "bug"),
+			oldBody: body("_No response_"),
+			want:    LabelDelta{Number: 1, Labels: []string{"bug"}, Add: []string{"forward/review", "service/service1"}},
+			wantOk:  true,
+		},
+		"resource replaced": {
+			issue:   issue(body("google_service2_resource1"), "bug", "forward/review", "service/service1"),
+			oldBody: body("google_service1_resource1"),
+			want: LabelDelta{
+				Number: 1,
+				Labels: []string{"bug", "forward/review", "service/service1"},
+				Add:    []string{"service/service2"},
+				Remove: []string{"service/service1"},
+			},
+			wantOk: true,
+		},
+		"label added by hand kept": {
+			issue:   issue(body("google_service2_resource1"), "bug", "service/service1"),
+			oldBody: body("google_service2_resource1"),
+		},
+		"label removed by hand not restored": {
+			issue:   issue(body("google_service1_resource1\ngoogle_service1_resource2"), "bug"),
+			oldBody: body("google_service1_resource1"),
+		},
+		"exempt": {
+			issue:   issue(body("google_service1_resource1"), "forward/exempt"),
+			oldBody: body(""),
+		},
+	}
+
+	for tn, tc := range cases {
+		tc := tc
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+			got, ok := ComputeEditDelta(tc.issue, tc.oldBody, rules, ConflictPolicy{}, AutomationPolicy{})
+			if ok != tc.wantOk {
+				t.Fatalf("ComputeEditDelta() ok = %v; want %v", ok, tc.wantOk)
+			}
+			if ok && !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("ComputeEditDelta() = %+v; want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestComputeEditDeltaUnroutable(t *testing.T) {
+	rules := []RegexpLabel{{Regexp: regexp.MustCompile("^google_service1_.*$"), Label: "service/service1"}}
+	policy := ConflictPolicy{UnroutableLabel: "needs-triage"}
+	issue := &github.Issue{Number: github.Ptr(1), Body: github.Ptr("### Affected Resource(s)\n\ngoogle_service1_resource1\n")}
+	issue.Labels = []*github.Label{{Name: github.Ptr("needs-triage")}}
+	got, ok := ComputeEditDelta(issue, "_No response_", rules, policy, AutomationPolicy{})
+	want := LabelDelta{Number: 1, Labels: []string{"needs-triage"}, Add: []string{"forward/review", "service/service1"}, Remove: []string{"needs-triage"}}
+	if !ok || !reflect.DeepEqual(got, want) {
+		t.Errorf("ComputeEditDelta() = %+v, %v; want %+v, true", got, ok, want)
+	}
+
+	// Edits removing the resources make the issue unroutable, without forwarding it.
+	issue = &github.Issue{Number: github.Ptr(1), Body: github.Ptr("_No response_")}
+	issue.Labels = []*github.Label{{Name: github.Ptr("service/service1")}}
+	got, ok = ComputeEditDelta(issue, "### Affected Resource(s)\n\ngoogle_service1_resource1\n", rules, policy, AutomationPolicy{})
+	want = LabelDelta{Number: 1, Labels: []string{"service/service1"}, Add: []string{"needs-triage"}, Remove: []string{"service/service1"}}
+	if !ok || !reflect.DeepEqual(got, want) {
+		t.Errorf("ComputeEditDelta() = %+v, %v; want %+v, true", got, ok, want)
+	}
+}
+
+func TestLabelDeltaUpdate(t *testing.T) {
+	delta := LabelDelta{
+		Number: 1,
+		Labels: []string{"bug", "service/service1"},
+		Add:    []string{"service/service2"},
+		Remove: []string{"service/service1"},
+	}
+	want := IssueUpdate{Number: 1, Labels: []string{"bug", "service/service2"}, OldLabels: []string{"bug", "service/service1"}}
+	if got := delta.Update(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Update() = %+v; want %+v", got, want)
+	}
+}
@@ -0,0 +1,223 @@
+package labeler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v68/github"
+)
+
+// ErrorClass says whether retrying a failed GitHub API call can help.
+type ErrorClass string
+
+const (
+	// ErrorRetryable failures are transient: rate limits, server errors and network failures.
+	ErrorRetryable ErrorClass = "retryable"
+	// ErrorPermanent failures fail the same way however often they're retried: missing issues,
+	// bad credentials and rejected requests. They need someone to look at them.
+	ErrorPermanent ErrorClass = "permanent"
+)
+
+// Reasons GitHub API calls fail, as given in APIError.Reason.
+const (
+	ReasonRateLimit = "rate_limit"
+	ReasonServer    = "server"
+	ReasonNetwork   = "network"
+	ReasonNotFound  = "not_found"
+	ReasonAuth      = "auth"
+	ReasonInvalid   = "invalid"
+	ReasonCanceled  = "canceled"
+	ReasonOther     = "other"
+)
+
+// APIError is a failed GitHub API call, classified by why it failed and whether retrying it
+// can help. It reads like the error it wraps.
+type APIError struct {
+	// Op is the operation that failed, like edit_issue.
+	Op     string
+	Reason string
+	Class  ErrorClass
+	// RetryAfter is how long GitHub asked to wait before retrying, if it said.
+	RetryAfter time.Duration
+	Err        error
+}
+
+func (e *APIError) Error() string { return e.Err.Error() }
+
+func (e *APIError) Unwrap() error { return e.Err }
+
+// IsRetryable reports whether err is a GitHub API failure that retrying can fix.
+func IsRetryable(err error) bool {
+	var ae *APIError
+	return errors.As(err, &ae) && ae.Class == ErrorRetryable
+}
+
+// IsPermanent reports whether err is a GitHub API failure that retrying can't fix.
+func IsPermanent(err error) bool {
+	var ae *APIError
+	return errors.As(err, &ae) && ae.Class == ErrorPermanent
+}
+
+// apiError counts a failed GitHub API call in the API error metric and classifies its error.
+// It returns nil if err is nil, and errors that are already classified unchanged.
+func apiError(op string, resp *github.Response, err error) error {
+	if err == nil {
+		return nil
+	}
+	var ae *APIError
+	if errors.As(err, &ae) {
+		return err
+	}
+	apiErrors.WithLabelValues(op).Inc()
+	return classifyError(op, resp, err)
+}
+
+func classifyError(op string, resp *github.Response, err error) *APIError {
+	ae := &APIError{Op: op, Reason: ReasonOther, Class: ErrorPermanent, Err: err}
+	var rateLimit *github.RateLimitError
+	var abuse *github.AbuseRateLimitError
+	var errResp *github.ErrorResponse
+	var netErr net.Error
+	switch {
+	case errors.As(err, &rateLimit):
+		ae.Reason, ae.Class = ReasonRateLimit, ErrorRetryable
+		ae.RetryAfter = time.Until(rateLimit.Rate.Reset.Time)
+	case errors.As(err, &abuse):
+		ae.Reason, ae.Class = ReasonRateLimit, ErrorRetryable
+		ae.RetryAfter = abuse.GetRetryAfter()
+	case errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded):
+		ae.Reason = ReasonCanceled
+	case errors.As(err, &errResp) && errResp.Response != nil:
+		ae.Reason, ae.Class = classifyStatus(errResp.Response.StatusCode)
+	case resp != nil && resp.Response != nil && resp.StatusCode >= 400:
+		ae.Reason, ae.Class = classifyStatus(resp.StatusCode)
+	case errors.As(err, &netErr):
+		ae.Reason, ae.Class = ReasonNetwork, ErrorRetryable
+	}
+	if ae.RetryAfter < 0 {
+		ae.RetryAfter = 0
+	}
+	return ae
+}
+
+func classifyStatus(status int) (string, ErrorClass) {
+	switch {
+	case status == http.StatusTooManyRequests:
+		return ReasonRateLimit, ErrorRetryable
+	case status >= 500:
+		return ReasonServer, ErrorRetryable
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		return ReasonAuth, ErrorPermanent
+	case status == http.StatusNotFound || status == http.StatusGone:
+		return ReasonNotFound, ErrorPermanent
+	case status == http.StatusUnprocessableEntity || status == http.StatusBadRequest:
+		return ReasonInvalid, ErrorPermanent
+	}
+	return ReasonOther, ErrorPermanent
+}
+
+// graphQLError classifies the errors field of a GraphQL response, which GitHub returns with a
+// 200 status, by its type.
+func graphQLError(errType, message string) error {
+	ae := &APIError{Op: "graphql", Reason: ReasonOther, Class: ErrorPermanent, Err: fmt.Errorf("graphql: %s", message)}
+	switch strings.ToUpper(errType) {
+	case "RATE_LIMITED":
+		ae.Reason, ae.Class = ReasonRateLimit, ErrorRetryable
+	case "NOT_FOUND":
+		ae.Reason = ReasonNotFound
+	case "FORBIDDEN":
+		ae.Reason = ReasonAuth
+	}
+	apiErrors.WithLabelValues("graphql").Inc()
+	return ae
+}
+
+// DefaultRetryPolicy is how updates are retried unless configured otherwise.
+var DefaultRetryPolicy = RetryPolicy{Retries: 2, BaseDelay: 2 * time.Second, MaxDelay: time.Minute}
+
+// RetryPolicy is how often and how patiently failed updates are retried. Only retryable
+// failures are. The zero value doesn't retry.
+type RetryPolicy struct {
+	// Retries is how many times a failed call is retried after the first attempt.
+	Retries int
+	// BaseDelay is the wait before the first retry, doubling for each one after it.
+	BaseDelay time.Duration
+	// MaxDelay caps the wait between attempts. A failure GitHub asks to wait longer for, like
+	// an exhausted rate limit, isn't retried.
+	MaxDelay time.Duration
+}
+
+// Validate checks that the policy's numbers are usable.
+func (p RetryPolicy) Validate() error {
+	if p.Retries < 0 {
+		return fmt.Errorf("retries must not be negative")
+	}
+	if p.BaseDelay < 0 || p.MaxDelay < 0 {
+		return fmt.Errorf("retry delays must not be negative")
+	}
+	return nil
+}
+
+// delay returns how long to wait before retry n, starting at 1, after err, and whether to
+// retry at all.
+func (p RetryPolicy) delay(n int, err error) (time.Duration, bool) {
+	var ae *APIError
+	if n > p.Retries || !errors.As(err, &ae) || ae.Class != ErrorRetryable {
+		return 0, false
+	}
+	d := p.BaseDelay
+	for i := 1; i < n && d < p.MaxDelay; i++ {
+		d *= 2
+	}
+	if d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+	if ae.RetryAfter > d {
+		if ae.RetryAfter > p.MaxDelay {
+			return 0, false
+		}
+		d = ae.RetryAfter
+	}
+	return d, true
+}
+
+// do calls fn, retrying retryable failures as the policy allows, and returns the last error.
+func (p RetryPolicy) do(ctx context.Context, logger *slog.Logger, fn func() error) error {
+	for n := 1; ; n++ {
+		err := fn()
+		d, ok := p.delay(n, err)
+		if err == nil || !ok {
+			return err
+		}
+		var ae *APIError
+		errors.As(err, &ae)
+		logger.Warn("retrying after retryable failure", "error", err, "reason", ae.Reason, "retry", n, "delay", d)
+		select {
+		case <-time.After(d):
+		case <-ctx.Done():
+			return err
+		case <-shutdownRequested(ctx):
+			return err
+		}
+	}
+}
+
+type retryPolicyKey struct{}
+
+// WithRetryPolicy returns a context whose issue updates are retried with p.
+func WithRetryPolicy(ctx context.Context, p RetryPolicy) context.Context {
+	return context.WithValue(ctx, retryPolicyKey{}, p)
+}
+
+func retryPolicyFrom(ctx context.Context) RetryPolicy {
+	if p, ok := ctx.Value(retryPolicyKey{}).(RetryPolicy); ok {
+		return p
+	}
+	return DefaultRetryPolicy
+}
@@ -0,0 +1,210 @@
+package labeler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v68/github"
+)
+
+// httpResponse returns a response to a PATCH of an issue with the given status.
+func httpResponse(status int) *http.Response {
+	req, _ := http.NewRequest(http.MethodPatch, "https://api.github.com/repos/owner/repo/issues/1", nil)
+	return &http.Response{StatusCode: status, Request: req}
+}
+
+func errorResponse(status int) *github.ErrorResponse {
+	return &github.ErrorResponse{Response: httpResponse(status), Message: http.StatusText(status)}
+}
+
+func TestClassifyError(t *testing.T) {
+	cases := map[string]struct {
+		resp       *github.Response
+		err        error
+		reason     string
+		class      ErrorClass
+		retryAfter time.Duration
+	}{
+		"rate limit": {
+			err:    &github.RateLimitError{Response: httpResponse(http.StatusForbidden), Rate: github.Rate{Reset: github.Timestamp{Time: time.Now().Add(-time.Minute)}}},
+			reason: ReasonRateLimit,
+			class:  ErrorRetryable,
+		},
+		"secondary rate limit": {
+			err:        &github.AbuseRateLimitError{Response: httpResponse(http.StatusForbidden), RetryAfter: github.Ptr(30 * time.Second)},
+			reason:     ReasonRateLimit,
+			class:      ErrorRetryable,
+			retryAfter: 30 * time.Second,
+		},
+		"too many requests": {
+			err:    errorResponse(http.StatusTooManyRequests),
+			reason: ReasonRateLimit,
+			class:  ErrorRetryable,
+		},
+		"server error": {
+			err:    errorResponse(http.StatusBadGateway),
+			reason: ReasonServer,
+			class:  ErrorRetryable,
+		},
+		"not found": {
+			err:    errorResponse(http.StatusNotFound),
+			reason: ReasonNotFound,
+			class:  ErrorPermanent,
+		},
+		"gone": {
+			err:    errorResponse(http.StatusGone),
+			reason: ReasonNotFound,
+			class:  ErrorPermanent,
+		},
+		"bad credentials": {
+			err:    errorResponse(http.StatusUnauthorized),
+			reason: ReasonAuth,
+			class:  ErrorPermanent,
+		},
+		"forbidden": {
+			err:    errorResponse(http.StatusForbidden),
+			reason: ReasonAuth,
+			class:  ErrorPermanent,
+		},
+		"validation failed": {
+			err:    errorResponse(http.StatusUnprocessableEntity),
+			reason: ReasonInvalid,
+			class:  ErrorPermanent,
+		},
+		"status from response": {
+			resp:   &github.Response{Response: &http.Response{StatusCode: http.StatusServiceUnavailable}},
+			err:    errors.New("unexpected end of JSON input"),
+			reason: ReasonServer,
+			class:  ErrorRetryable,
+		},
+		"network": {
+			err:    fmt.Errorf("dialing: %w", &net.OpError{Op: "dial", Err: errors.New("connection refused")}),
+			reason: ReasonNetwork,
+			class:  ErrorRetryable,
+		},
+		"canceled": {
+			err:    context.Canceled,
+			reason: ReasonCanceled,
+			class:  ErrorPermanent,
+		},
+		"other": {
+			err:    errors.New("something else"),
+			reason: ReasonOther,
+			class:  ErrorPermanent,
+		},
+	}
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			err := apiError("edit_issue", tc.resp, tc.err)
+			var ae *APIError
+			if !errors.As(err, &ae) {
+				t.Fatalf("apiError() = %v; want an *APIError", err)
+			}
+			if ae.Op != "edit_issue" || ae.Reason != tc.reason || ae.Class != tc.class || ae.RetryAfter != tc.retryAfter {
+				t.Errorf("apiError() = %+v; want reason %s, class %s, retry after %s", ae, tc.reason, tc.class, tc.retryAfter)
+			}
+			if err.Error() != tc.err.Error() || !errors.Is(err, tc.err) {
+				t.Errorf("apiError() = %v; want it to read like and wrap %v", err, tc.err)
+			}
+			if IsRetryable(err) != (tc.class == ErrorRetryable) || IsPermanent(err) != (tc.class == ErrorPermanent) {
+				t.Errorf("IsRetryable() = %v, IsPermanent() = %v for a %s error", IsRetryable(err), IsPermanent(err), tc.class)
+			}
+		})
+	}
+}
+
+func TestAPIErrorWrapped(t *testing.T) {
+	if err := apiError("edit_issue", nil, nil); err != nil {
+		t.Errorf("apiError() of nil = %v; want nil", err)
+	}
+	err := fmt.Errorf("updating issue 1: %w", apiError("edit_issue", nil, errorResponse(http.StatusNotFound)))
+	if !IsPermanent(err) || IsRetryable(err) {
+		t.Errorf("IsPermanent() = %v, IsRetryable() = %v for a wrapped 404; want true, false", IsPermanent(err), IsRetryable(err))
+	}
+	// Classifying again, further up, keeps the original classification.
+	if again := apiError("graphql", nil, err); again != err {
+		t.Errorf("apiError() of a classified error = %v; want it unchanged", again)
+	}
+	if err := graphQLError("RATE_LIMITED", "API rate limit exceeded"); !IsRetryable(err) {
+		t.Errorf("graphQLError(RATE_LIMITED) isn't retryable")
+	}
+	if err := graphQLError("NOT_FOUND", "Could not resolve to an Issue"); !IsPermanent(err) {
+		t.Errorf("graphQLError(NOT_FOUND) isn't permanent")
+	}
+}
+
+func TestRetryPolicyDelay(t *testing.T) {
+	p := RetryPolicy{Retries: 4, BaseDelay: time.Second, MaxDelay: 5 * time.Second}
+	retryable := &APIError{Class: ErrorRetryable, Err: errors.New("bad gateway")}
+	cases := map[string]struct {
+		n      int
+		err    error
+		want   time.Duration
+		wantOK bool
+	}{
+		"first retry":       {n: 1, err: retryable, want: time.Second, wantOK: true},
+		"backs off":         {n: 3, err: retryable, want: 4 * time.Second, wantOK: true},
+		"capped":            {n: 4, err: retryable, want: 5 * time.Second, wantOK: true},
+		"out of retries":    {n: 5, err: retryable},
+		"permanent":         {n: 1, err: &APIError{Class: ErrorPermanent, Err: errors.New("not found")}},
+		"unclassified":      {n: 1, err: errors.New("unknown")},
+		"retry after":       {n: 1, err: &APIError{Class: ErrorRetryable, RetryAfter: 3 * time.Second, Err: errors.New("slow down")}, want: 3 * time.Second, wantOK: true},
+		"retry after limit": {n: 1, err: &APIError{Class: ErrorRetryable, RetryAfter: time.Hour, Err: errors.New("rate limited")}},
+	}
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			got, ok := p.delay(tc.n, tc.err)
+			if got != tc.want || ok != tc.wantOK {
+				t.Errorf("delay(%d) = %s, %v; want %s, %v", tc.n, got, ok, tc.want, tc.wantOK)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyDo(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	p := RetryPolicy{Retries: 2, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}
+	cases := map[string]struct {
+		errs      []error
+		wantCalls int
+		wantErr   bool
+	}{
+		"succeeds": {
+			errs:      []error{nil},
+			wantCalls: 1,
+		},
+		"succeeds after retries": {
+			errs:      []error{apiError("edit_issue", nil, errorResponse(http.StatusBadGateway)), apiError("edit_issue", nil, errorResponse(http.StatusBadGateway)), nil},
+			wantCalls: 3,
+		},
+		"gives up": {
+			errs:      []error{apiError("edit_issue", nil, errorResponse(http.StatusBadGateway)), apiError("edit_issue", nil, errorResponse(http.StatusBadGateway)), apiError("edit_issue", nil, errorResponse(http.StatusBadGateway))},
+			wantCalls: 3,
+			wantErr:   true,
+		},
+		"permanent isn't retried": {
+			errs:      []error{apiError("edit_issue", nil, errorResponse(http.StatusNotFound)), nil},
+			wantCalls: 1,
+			wantErr:   true,
+		},
+	}
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			calls := 0
+			err := p.do(context.Background(), logger, func() error {
+				calls++
+				return tc.errs[calls-1]
+			})
+			if calls != tc.wantCalls || (err != nil) != tc.wantErr {
+				t.Errorf("do() made %d calls and returned %v; want %d calls, error %v", calls, err, tc.wantCalls, tc.wantErr)
+			}
+		})
+	}
+}
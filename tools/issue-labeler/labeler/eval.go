@@ -0,0 +1,201 @@
+package labeler
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/google/go-github/v68/github"
+)
+
+// CorpusIssue is a triaged issue with the service labels a human settled on.
+type CorpusIssue struct {
+	Number int      `json:"number"`
+	Body   string   `json:"body"`
+	Labels []string `json:"labels"`
+}
+
+// ReadCorpus reads a corpus of triaged issues, one JSON object per line.
+func ReadCorpus(r io.Reader) ([]CorpusIssue, error) {
+	var corpus []CorpusIssue
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 10*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		var issue CorpusIssue
+		if err := json.Unmarshal(scanner.Bytes(), &issue); err != nil {
+			return nil, fmt.Errorf("corpus line %d: %w", line, err)
+		}
+		corpus = append(corpus, issue)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading corpus: %w", err)
+	}
+	return corpus, nil
+}
+
+// WriteCorpus writes the issues with at least one service label as a corpus, keeping only their
+// service labels. Pull requests are skipped.
+func WriteCorpus(w io.Writer, issues []*github.Issue) error {
+	enc := json.NewEncoder(w)
+	for _, issue := range issues {
+		if issue.IsPullRequest() {
+			continue
+		}
+		entry := CorpusIssue{Number: issue.GetNumber(), Body: issue.GetBody()}
+		for _, l := range issue.Labels {
+			if strings.HasPrefix(l.GetName(), "service/") {
+				entry.Labels = append(entry.Labels, l.GetName())
+			}
+		}
+		if len(entry.Labels) == 0 {
+			continue
+		}
+		sort.Strings(entry.Labels)
+		if err := enc.Encode(entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RuleStats measures how well the rules for one label agree with the corpus.
+type RuleStats struct {
+	Label          string
+	TruePositives  int
+	FalsePositives int
+	FalseNegatives int
+	// Regressions are the issues the baseline rules got right for this label and the
+	// evaluated rules get wrong.
+	Regressions []int
+}
+
+// Precision is the fraction of issues given the label that should have it.
+func (s RuleStats) Precision() float64 {
+	return ratio(s.TruePositives, s.TruePositives+s.FalsePositives)
+}
+
+// Recall is the fraction of issues that should have the label that were given it.
+func (s RuleStats) Recall() float64 {
+	return ratio(s.TruePositives, s.TruePositives+s.FalseNegatives)
+}
+
+func ratio(n, d int) float64 {
+	if d == 0 {
+		return 1
+	}
+	return float64(n) / float64(d)
+}
+
+// Evaluation is the result of replaying rules against a corpus.
+type Evaluation struct {
+	Issues int
+	Rules  []RuleStats
+}
+
+// Regressions returns the total number of per-label regressions.
+func (e Evaluation) Regressions() int {
+	n := 0
+	for _, s := range e.Rules {
+		n += len(s.Regressions)
+	}
+	return n
+}
+
+// Evaluate replays rules against the corpus and compares the service labels they compute with
+// the corpus labels. If baseline is non-nil, labels the baseline got right but rules get wrong
+// are reported as regressions.
+func Evaluate(corpus []CorpusIssue, rules, baseline []RegexpLabel) Evaluation {
+	stats := make(map[string]*RuleStats)
+	get := func(label string) *RuleStats {
+		if s, ok := stats[label]; ok {
+			return s
+		}
+		s := &RuleStats{Label: label}
+		stats[label] = s
+		return s
+	}
+
+	matcher := NewMatcher(rules)
+	baseMatcher := NewMatcher(baseline)
+	for _, issue := range corpus {
+		want := serviceLabelSet(issue.Labels)
+		got := serviceLabelSet(matcher.IssueLabels(issue.Body))
+		var base map[string]struct{}
+		if baseline != nil {
+			base = serviceLabelSet(baseMatcher.IssueLabels(issue.Body))
+		}
+
+		all := make(map[string]struct{})
+		for _, set := range []map[string]struct{}{want, got, base} {
+			for l := range set {
+				all[l] = struct{}{}
+			}
+		}
+		for label := range all {
+			_, w := want[label]
+			_, g := got[label]
+			s := get(label)
+			switch {
+			case w && g:
+				s.TruePositives++
+			case g:
+				s.FalsePositives++
+			case w:
+				s.FalseNegatives++
+			}
+			if baseline != nil {
+				_, b := base[label]
+				if b == w && g != w {
+					s.Regressions = append(s.Regressions, issue.Number)
+				}
+			}
+		}
+	}
+
+	e := Evaluation{Issues: len(corpus)}
+	for _, s := range stats {
+		e.Rules = append(e.Rules, *s)
+	}
+	sort.Slice(e.Rules, func(i, j int) bool { return e.Rules[i].Label < e.Rules[j].Label })
+	return e
+}
+
+func serviceLabelSet(labels []string) map[string]struct{} {
+	set := make(map[string]struct{})
+	for _, l := range labels {
+		if strings.HasPrefix(l, "service/") {
+			set[l] = struct{}{}
+		}
+	}
+	return set
+}
+
+// Write prints per-label precision, recall and regressions as a plain text table.
+func (e Evaluation) Write(w io.Writer) error {
+	if _, err := fmt.Fprintf(w, "Issues: %d\nRegressions: %d\n\n%-9s %-9s %4s %4s %4s  %s\n",
+		e.Issues, e.Regressions(), "precision", "recall", "tp", "fp", "fn", "label"); err != nil {
+		return err
+	}
+	for _, s := range e.Rules {
+		if _, err := fmt.Fprintf(w, "%9.3f %9.3f %4d %4d %4d  %s\n",
+			s.Precision(), s.Recall(), s.TruePositives, s.FalsePositives, s.FalseNegatives, s.Label); err != nil {
+			return err
+		}
+		if len(s.Regressions) > 0 {
+			var numbers []string
+			for _, n := range s.Regressions {
+				numbers = append(numbers, fmt.Sprintf("#%d", n))
+			}
+			if _, err := fmt.Fprintf(w, "%40s  regressed: %s\n", "", strings.Join(numbers, " ")); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package labeler
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestReadCorpus(t *testing.T) {
+	corpus, err := ReadCorpus(strings.NewReader(`{"number": 1, "body": "b", "labels": ["service/service1"]}
+
+{"number": 2, "body": "c", "labels": []}
+`))
+	if err != nil {
+		t.Fatalf("ReadCorpus() error = %v", err)
+	}
+	want := []CorpusIssue{
+		{Number: 1, Body: "b", Labels: []string{"service/service1"}},
+		{Number: 2, Body: "c", Labels: []string{}},
+	}
+	if !reflect.DeepEqual(corpus, want) {
+		t.Errorf("want %+v; got %+v", want, corpus)
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	corpus := []CorpusIssue{
+		{Number: 1, Body: *testIssueBodyWithResources([]string{"google_service1_resource1"}), Labels: []string{"service/service1"}},
+		{Number: 2, Body: *testIssueBodyWithResources([]string{"google_service2_resource1"}), Labels: []string{"service/service2"}},
+		{Number: 3, Body: *testIssueBodyWithResources([]string{"google_service2_resource2"}), Labels: []string{"service/service1", "service/service2"}},
+	}
+	baseline := []RegexpLabel{
+		{Regexp: regexp.MustCompile("^google_service1_.*$"), Label: "service/service1"},
+		{Regexp: regexp.MustCompile("^google_service2_.*$"), Label: "service/service2"},
+	}
+	// Narrowing the service2 rule loses issue 3.
+	rules := []RegexpLabel{
+		{Regexp: regexp.MustCompile("^google_service1_.*$"), Label: "service/service1"},
+		{Regexp: regexp.MustCompile("^google_service2_resource1$"), Label: "service/service2"},
+	}
+
+	got := Evaluate(corpus, rules, baseline)
+	want := Evaluation{
+		Issues: 3,
+		Rules: []RuleStats{
+			{Label: "service/service1", TruePositives: 1, FalseNegatives: 1},
+			{Label: "service/service2", TruePositives: 1, FalseNegatives: 1, Regressions: []int{3}},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want %+v; got %+v", want, got)
+	}
+	if got.Regressions() != 1 {
+		t.Errorf("Regressions() = %d; want 1", got.Regressions())
+	}
+	if p, r := got.Rules[1].Precision(), got.Rules[1].Recall(); p != 1 || r != 0.5 {
+		t.Errorf("service/service2 precision, recall = %v, %v; want 1, 0.5", p, r)
+	}
+}
@@ -0,0 +1,119 @@
+package labeler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sync"
+)
+
+// FailedUpdate is an issue update that could not be applied, with enough detail to apply it
+// again later.
+type FailedUpdate struct {
+	Repository string       `json:"repository"`
+	Number     int          `json:"number"`
+	NodeID     string       `json:"node_id,omitempty"`
+	Labels     []string     `json:"labels"`
+	OldLabels  []string     `json:"old_labels"`
+	Matches    []LabelMatch `json:"matches,omitempty"`
+	Error      string       `json:"error"`
+	// Permanent failures, like a missing issue, will fail again unless something changes.
+	Permanent bool `json:"permanent,omitempty"`
+}
+
+// Update returns the issue update that failed.
+func (f FailedUpdate) Update() IssueUpdate {
+	return IssueUpdate{
+		Number:    f.Number,
+		NodeID:    f.NodeID,
+		Labels:    f.Labels,
+		OldLabels: f.OldLabels,
+		Matches:   f.Matches,
+	}
+}
+
+// AppliedTo reports whether an issue with the given labels already has the update's labels,
+// for example because someone applied them by hand since the failure.
+func (f FailedUpdate) AppliedTo(labels []string) bool {
+	return AppliedTo(f.Update(), labels)
+}
+
+// FailureReport collects the updates a run failed to apply, so they can be retried. A nil
+// *FailureReport discards everything.
+type FailureReport struct {
+	Failures []FailedUpdate `json:"failures"`
+
+	path string
+	mu   sync.Mutex
+}
+
+// NewFailureReport starts an empty report, saved to path.
+func NewFailureReport(path string) *FailureReport {
+	return &FailureReport{Failures: []FailedUpdate{}, path: path}
+}
+
+func (r *FailureReport) record(repository string, update IssueUpdate, err error) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.Failures = append(r.Failures, FailedUpdate{
+		Repository: repository,
+		Number:     update.Number,
+		NodeID:     update.NodeID,
+		Labels:     update.Labels,
+		OldLabels:  update.OldLabels,
+		Matches:    update.Matches,
+		Error:      err.Error(),
+		Permanent:  IsPermanent(err),
+	})
+}
+
+// Save writes the report if any updates failed, and otherwise removes a report left by an
+// earlier run so the file always describes the latest one.
+func (r *FailureReport) Save() error {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.Failures) == 0 {
+		if err := os.Remove(r.path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("removing failure report: %w", err)
+		}
+		return nil
+	}
+	if err := writeJSONAtomic(r.path, r); err != nil {
+		return fmt.Errorf("writing failure report: %w", err)
+	}
+	return nil
+}
+
+// LoadFailures reads the failed updates from a report written by Save.
+func LoadFailures(path string) ([]FailedUpdate, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading failure report: %w", err)
+	}
+	var r FailureReport
+	if err := json.Unmarshal(b, &r); err != nil {
+		return nil, fmt.Errorf("parsing failure report: %w", err)
+	}
+	return r.Failures, nil
+}
+
+type failureReportKey struct{}
+
+// WithFailureReport returns a context whose failed issue updates are recorded in r.
+func WithFailureReport(ctx context.Context, r *FailureReport) context.Context {
+	return context.WithValue(ctx, failureReportKey{}, r)
+}
+
+func failureReportFrom(ctx context.Context) *FailureReport {
+	r, _ := ctx.Value(failureReportKey{}).(*FailureReport)
+	return r
+}
@@ -0,0 +1,58 @@
+package labeler
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestFailureReport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "failures.json")
+	update := IssueUpdate{
+		Number:    1,
+		Labels:    []string{"bug", "service/service1"},
+		OldLabels: []string{"bug"},
+		Matches: []LabelMatch{
+			{Label: "service/service1", Resource: "google_service1_resource1", Section: SectionAffectedResources, Pattern: "google_service1_.*"},
+		},
+	}
+
+	report := NewFailureReport(path)
+	report.record("owner/repo", update, errors.New("502 Bad Gateway"))
+	if err := report.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	failures, err := LoadFailures(path)
+	if err != nil {
+		t.Fatalf("LoadFailures() error = %v", err)
+	}
+	if len(failures) != 1 {
+		t.Fatalf("LoadFailures() = %v; want 1 failure", failures)
+	}
+	if failures[0].Repository != "owner/repo" || failures[0].Error != "502 Bad Gateway" {
+		t.Errorf("LoadFailures() = %+v", failures[0])
+	}
+	if got := failures[0].Update(); !reflect.DeepEqual(got, update) {
+		t.Errorf("Update() = %+v; want %+v", got, update)
+	}
+	if failures[0].AppliedTo(update.OldLabels) || !failures[0].AppliedTo([]string{"service/service1", "bug"}) {
+		t.Errorf("AppliedTo() didn't compare labels as a set")
+	}
+
+	// A later run without failures removes the stale report.
+	if err := NewFailureReport(path).Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("report still exists after a run without failures: %v", err)
+	}
+
+	var nilReport *FailureReport
+	nilReport.record("owner/repo", update, errors.New("ignored"))
+	if err := nilReport.Save(); err != nil {
+		t.Errorf("Save() on nil report error = %v", err)
+	}
+}
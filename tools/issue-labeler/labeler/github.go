@@ -2,6 +2,7 @@ package labeler
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"strings"
@@ -16,6 +17,7 @@ func newGitHubClient() *github.Client {
 		&oauth2.Token{AccessToken: os.Getenv("GITHUB_TOKEN")},
 	)
 	tc := oauth2.NewClient(ctx, ts)
+	tc.Transport = usageTransport{base: tc.Transport}
 	return github.NewClient(tc)
 }
 
@@ -49,7 +51,7 @@ func listLabels(repository string) ([]*github.Label, error) {
 	for {
 		labels, resp, err := client.Issues.ListLabels(ctx, owner, repo, opts)
 		if err != nil {
-			return nil, fmt.Errorf("failed to list labels: %w", err)
+			return nil, fmt.Errorf("failed to list labels: %w", apiError("list_labels", resp, err))
 		}
 		allLabels = append(allLabels, labels...)
 		if resp.NextPage == 0 {
@@ -60,3 +62,36 @@ func listLabels(repository string) ([]*github.Label, error) {
 
 	return allLabels, nil
 }
+
+type graphQLRequest struct {
+	Query     string         `json:"query"`
+	Variables map[string]any `json:"variables,omitempty"`
+}
+
+type graphQLResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Type    string `json:"type"`
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// doGraphQL runs a query against the GitHub GraphQL API and decodes the data field into out.
+func doGraphQL(ctx context.Context, client *github.Client, query string, variables map[string]any, out any) error {
+	req, err := client.NewRequest("POST", "graphql", graphQLRequest{Query: query, Variables: variables})
+	if err != nil {
+		return err
+	}
+
+	var resp graphQLResponse
+	if r, err := client.Do(ctx, req, &resp); err != nil {
+		return apiError("graphql", r, err)
+	}
+	if len(resp.Errors) > 0 {
+		return graphQLError(resp.Errors[0].Type, resp.Errors[0].Message)
+	}
+	if out == nil {
+		return nil
+	}
+	return json.Unmarshal(resp.Data, out)
+}
@@ -0,0 +1,141 @@
+package labeler
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Canonical names for the issue form sections rules can target.
+const (
+	SectionAffectedResources = "affected resources"
+	SectionConfig            = "config"
+	SectionDebugOutput       = "debug output"
+	SectionVersions          = "versions"
+)
+
+var headingRegexp = regexp.MustCompile(`^#{1,6}\s+(.+?)\s*$`)
+
+// IssueForm is a rendered GitHub issue form split into sections keyed by canonical name.
+type IssueForm map[string]string
+
+// ParseIssueForm splits a rendered issue form body into its named sections. Text before the
+// first heading is dropped, as is the "_No response_" placeholder GitHub renders for empty
+// optional fields, and the body is sanitized first with SanitizeIssueBody.
+func ParseIssueForm(body string) IssueForm {
+	form := make(IssueForm)
+	body = SanitizeIssueBody(body)
+
+	var name string
+	var content []string
+	flush := func() {
+		if name == "" {
+			return
+		}
+		text := strings.TrimSpace(strings.Join(content, "\n"))
+		if text == "_No response_" {
+			text = ""
+		}
+		if existing, ok := form[name]; ok && existing != "" {
+			text = strings.TrimSpace(existing + "\n" + text)
+		}
+		form[name] = text
+	}
+
+	inFence := false
+	for _, line := range strings.Split(body, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+		}
+		if !inFence {
+			if m := headingRegexp.FindStringSubmatch(line); m != nil {
+				flush()
+				name = canonicalSectionName(m[1])
+				content = nil
+				continue
+			}
+		}
+		content = append(content, line)
+	}
+	flush()
+
+	return form
+}
+
+// Resources returns the google_* resource names mentioned in the given section.
+func (f IssueForm) Resources(section string) []string {
+	return resourceRegexp.FindAllString(f[section], -1)
+}
+
+func canonicalSectionName(heading string) string {
+	name := strings.ToLower(strings.TrimSpace(heading))
+	name = strings.TrimRight(name, ": ")
+	name = strings.ReplaceAll(name, "(s)", "")
+	switch {
+	case strings.Contains(name, "resource"):
+		return SectionAffectedResources
+	case strings.Contains(name, "documentation"):
+		return SectionDocumentation
+	case strings.Contains(name, "debug"):
+		return SectionDebugOutput
+	case strings.Contains(name, "configuration"):
+		return SectionConfig
+	case strings.Contains(name, "version"):
+		return SectionVersions
+	}
+	return name
+}
+
+// LabelMatch records which resource, found in which issue form section, caused a rule to
+// apply a label.
+type LabelMatch struct {
+	Label    string `json:"label"`
+	Resource string `json:"resource"`
+	Section  string `json:"section"`
+	Pattern  string `json:"pattern"`
+}
+
+// ComputeIssueLabels computes the labels for an issue body. Rules without sections only match
+// resources from the affected resources section; rules with sections match resources found in
+// any of the listed sections of the parsed issue form. Use a Matcher to label many bodies.
+func ComputeIssueLabels(body string, regexpLabels []RegexpLabel) []string {
+	return NewMatcher(regexpLabels).IssueLabels(body)
+}
+
+// ComputeResourceLabels computes the labels for resources given directly rather than parsed from
+// an issue, such as those a PR changes. The resources are matched as an issue's affected resources,
+// so they get the same labels an issue listing them would.
+func ComputeResourceLabels(resources []string, regexpLabels []RegexpLabel) []string {
+	var candidates []sectionResource
+	for _, resource := range resources {
+		candidates = append(candidates, sectionResource{SectionAffectedResources, resource})
+	}
+	labelSet := make(map[string]struct{})
+	for _, m := range NewMatcher(regexpLabels).matchCandidates(candidates) {
+		labelSet[m.Label] = struct{}{}
+	}
+	return sortedKeys(labelSet)
+}
+
+type sectionResource struct {
+	section  string
+	resource string
+}
+
+// MatchIssueLabels returns every rule match in an issue body, in the order resources appear.
+// The first rule matching a resource wins, as in ComputeIssueLabels. Use a Matcher to match
+// many bodies.
+func MatchIssueLabels(body string, regexpLabels []RegexpLabel) []LabelMatch {
+	return NewMatcher(regexpLabels).MatchIssue(body)
+}
+
+func (rl RegexpLabel) appliesTo(section string) bool {
+	if len(rl.Sections) == 0 {
+		return section == SectionAffectedResources
+	}
+	for _, s := range rl.Sections {
+		if s == section {
+			return true
+		}
+	}
+	return false
+}
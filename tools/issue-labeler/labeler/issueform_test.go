@@ -0,0 +1,124 @@
+package labeler
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+
+	"golang.org/x/exp/slices"
+)
+
+const testIssueFormBody = "### Community Note\n\n* Please vote on this issue\n\n" +
+	"### Terraform Version & Provider Version(s)\n\nTerraform v1.5.0\non linux_amd64\n+ provider registry.terraform.io/hashicorp/google v5.0.0\n\n" +
+	"### Affected Resource(s)\n\n<!-- Please list the resources. Use google_* if all are affected. -->\ngoogle_compute_instance\n\n" +
+	"### Terraform Configuration\n\n```tf\n# google_storage_bucket is referenced below\nresource \"google_storage_bucket\" \"b\" {}\n```\n\n" +
+	"### Debug Output\n\n_No response_\n\n" +
+	"### Expected Behavior\n\nIt works."
+
+func TestParseIssueForm(t *testing.T) {
+	form := ParseIssueForm(testIssueFormBody)
+	want := IssueForm{
+		"community note":         "* Please vote on this issue",
+		SectionVersions:          "Terraform v1.5.0\non linux_amd64\n+ provider registry.terraform.io/hashicorp/google v5.0.0",
+		SectionAffectedResources: "google_compute_instance",
+		SectionConfig:            "```tf\n# google_storage_bucket is referenced below\nresource \"google_storage_bucket\" \"b\" {}\n```",
+		SectionDebugOutput:       "",
+		"expected behavior":      "It works.",
+	}
+	if !reflect.DeepEqual(form, want) {
+		t.Errorf("want %#v; got %#v", want, form)
+	}
+}
+
+func TestIssueFormResources(t *testing.T) {
+	form := ParseIssueForm(testIssueFormBody)
+	cases := map[string][]string{
+		SectionAffectedResources: {"google_compute_instance"},
+		SectionConfig:            {"google_storage_bucket", "google_storage_bucket"},
+		SectionDebugOutput:       nil,
+	}
+	for section, want := range cases {
+		if got := form.Resources(section); !slices.Equal(got, want) {
+			t.Errorf("Resources(%q) want %v; got %v", section, want, got)
+		}
+	}
+}
+
+func TestComputeIssueLabels(t *testing.T) {
+	cases := map[string]struct {
+		regexpLabels   []RegexpLabel
+		expectedLabels []string
+	}{
+		"default section only": {
+			regexpLabels: []RegexpLabel{
+				{Regexp: regexp.MustCompile("^google_compute_.*$"), Label: "service/compute"},
+				{Regexp: regexp.MustCompile("^google_storage_.*$"), Label: "service/storage"},
+			},
+			expectedLabels: []string{"service/compute"},
+		},
+		"rule targeting config": {
+			regexpLabels: []RegexpLabel{
+				{Regexp: regexp.MustCompile("^google_compute_.*$"), Label: "service/compute"},
+				{Regexp: regexp.MustCompile("^google_storage_.*$"), Label: "service/storage", Sections: []string{SectionAffectedResources, SectionConfig}},
+			},
+			expectedLabels: []string{"service/compute", "service/storage"},
+		},
+		"rule excluding affected resources": {
+			regexpLabels: []RegexpLabel{
+				{Regexp: regexp.MustCompile("^google_compute_.*$"), Label: "service/compute", Sections: []string{SectionConfig}},
+			},
+			expectedLabels: []string{},
+		},
+	}
+
+	for tn, tc := range cases {
+		tc := tc
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+			labels := ComputeIssueLabels(testIssueFormBody, tc.regexpLabels)
+			if !slices.Equal(labels, tc.expectedLabels) {
+				t.Errorf("want %v; got %v", tc.expectedLabels, labels)
+			}
+		})
+	}
+}
+
+func TestComputeResourceLabels(t *testing.T) {
+	regexpLabels := []RegexpLabel{
+		{Regexp: regexp.MustCompile("^google_vertex_ai_featurestore.*$"), Label: "service/aiplatform-featurestore"},
+		{Regexp: regexp.MustCompile("^google_vertex_ai_.*$"), Label: "service/aiplatform"},
+		{Regexp: regexp.MustCompile("^google_storage_.*$"), Label: "service/storage", Sections: []string{SectionConfig}},
+		{Regexp: regexp.MustCompile("^google_compute_.*$"), Label: "service/compute", Sections: []string{SectionAffectedResources, SectionConfig}},
+	}
+	cases := map[string]struct {
+		resources      []string
+		expectedLabels []string
+	}{
+		"no resources": {
+			expectedLabels: []string{},
+		},
+		"first matching rule wins": {
+			resources:      []string{"google_vertex_ai_featurestore_entitytype"},
+			expectedLabels: []string{"service/aiplatform-featurestore"},
+		},
+		"multiple services": {
+			resources:      []string{"google_vertex_ai_endpoint", "google_compute_instance", "google_vertex_ai_featurestore"},
+			expectedLabels: []string{"service/aiplatform", "service/aiplatform-featurestore", "service/compute"},
+		},
+		"rules that don't apply to affected resources are skipped": {
+			resources:      []string{"google_storage_bucket"},
+			expectedLabels: []string{},
+		},
+	}
+
+	for tn, tc := range cases {
+		tc := tc
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+			labels := ComputeResourceLabels(tc.resources, regexpLabels)
+			if !slices.Equal(labels, tc.expectedLabels) {
+				t.Errorf("want %v; got %v", tc.expectedLabels, labels)
+			}
+		})
+	}
+}
@@ -3,13 +3,13 @@ package labeler
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"regexp"
 	"sort"
 	"strings"
 
 	_ "embed"
 
-	"github.com/golang/glog"
 	"github.com/google/go-github/v68/github"
 	"gopkg.in/yaml.v2"
 )
@@ -24,35 +24,70 @@ var (
 )
 
 type LabelData struct {
-	Team      string   `yaml:"team,omitempty"`
-	Resources []string `yaml:"resources"`
+	Team    string `yaml:"team,omitempty"`
+	Project string `yaml:"project,omitempty"`
+	// Component is the internal issue tracker component issues are exported to.
+	Component int64 `yaml:"component,omitempty"`
+	// Digest lists the addresses that get the team's daily digest of newly forwarded issues.
+	Digest []string `yaml:"digest,omitempty"`
+	// GitHubTeam is the org/team-slug @-mentioned when issues are routed to the team.
+	GitHubTeam string `yaml:"github_team,omitempty"`
+	// TriageComment is a text/template for the comment posted when issues are forwarded to
+	// the team, rendered with .Number, .Label and .Team.
+	TriageComment string `yaml:"triage_comment,omitempty"`
+	// Color and Description are what sync-labels gives the label in each repository. Color is
+	// a hex color without the leading #, and defaults to yellow.
+	Color       string   `yaml:"color,omitempty"`
+	Description string   `yaml:"description,omitempty"`
+	Resources   []string `yaml:"resources"`
+	// Sections limits matching to the named issue form sections; defaults to affected resources.
+	Sections []string `yaml:"sections,omitempty"`
 }
 
 type RegexpLabel struct {
-	Regexp *regexp.Regexp
-	Label  string
+	Regexp   *regexp.Regexp
+	Label    string
+	Sections []string
 }
 
 type LabelChange struct {
 	Name        string
 	Color       string
+	Description string
 	IsNew       bool
 	NeedsUpdate bool
 }
 
+// EnrolledTeams is a parsed enrolled teams config, keyed by service label.
+type EnrolledTeams map[string]LabelData
+
+// ParseEnrolledTeams parses an enrolled teams config. Unknown keys are ignored, so a config
+// written for a newer labeler still routes issues with an older one.
+func ParseEnrolledTeams(teamsYaml []byte) (EnrolledTeams, error) {
+	enrolledTeams := make(EnrolledTeams)
+	if err := yaml.Unmarshal(teamsYaml, &enrolledTeams); err != nil {
+		return nil, fmt.Errorf("unmarshalling enrolled teams yaml: %w", err)
+	}
+	return enrolledTeams, nil
+}
+
 func BuildRegexLabels(teamsYaml []byte) ([]RegexpLabel, error) {
-	enrolledTeams := make(map[string]LabelData)
 	regexpLabels := []RegexpLabel{}
-	if err := yaml.Unmarshal(teamsYaml, &enrolledTeams); err != nil {
-		return regexpLabels, fmt.Errorf("unmarshalling enrolled teams yaml: %w", err)
+	enrolledTeams, err := ParseEnrolledTeams(teamsYaml)
+	if err != nil {
+		return regexpLabels, err
 	}
 
 	for label, data := range enrolledTeams {
 		for _, resource := range data.Resources {
-			exactResource := fmt.Sprintf("^%s$", resource)
+			exactResource, err := regexp.Compile(fmt.Sprintf("^%s$", resource))
+			if err != nil {
+				return []RegexpLabel{}, fmt.Errorf("compiling resource %q for %s: %w", resource, label, err)
+			}
 			regexpLabels = append(regexpLabels, RegexpLabel{
-				Regexp: regexp.MustCompile(exactResource),
-				Label:  label,
+				Regexp:   exactResource,
+				Label:    label,
+				Sections: data.Sections,
 			})
 		}
 	}
@@ -65,7 +100,7 @@ func BuildRegexLabels(teamsYaml []byte) ([]RegexpLabel, error) {
 }
 
 func ExtractAffectedResources(body string) []string {
-	section := sectionRegexp.FindString(body)
+	section := sectionRegexp.FindString(SanitizeIssueBody(body))
 	section = commentRegexp.ReplaceAllString(section, "")
 	if section != "" {
 		return resourceRegexp.FindAllString(section, -1)
@@ -79,20 +114,23 @@ func ComputeLabels(resources []string, regexpLabels []RegexpLabel) []string {
 	for _, resource := range resources {
 		for _, rl := range regexpLabels {
 			if rl.Regexp.MatchString(resource) {
-				glog.Infof("found resource %q, applying label %q", resource, rl.Label)
+				slog.Debug("found resource", "resource", resource, "label", rl.Label)
 				labelSet[rl.Label] = struct{}{}
 				break
 			}
 		}
 	}
 
-	labels := []string{}
-	for label := range labelSet {
-		labels = append(labels, label)
-	}
-	sort.Strings(labels)
+	return sortedKeys(labelSet)
+}
 
-	return labels
+func sortedKeys(set map[string]struct{}) []string {
+	keys := []string{}
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
 }
 
 // EnsureLabelsWithColor applies the computed changes using the GitHub API
@@ -78,6 +78,60 @@ func TestEnrolledTeamsData(t *testing.T) {
 	}
 }
 
+func TestParseEnrolledTeams(t *testing.T) {
+	cases := map[string]struct {
+		teamsYaml     []byte
+		expectedTeams EnrolledTeams
+		expectError   bool
+	}{
+		"valid config": {
+			teamsYaml: []byte(`
+service/compute:
+  resources:
+  - google_compute_instance
+`),
+			expectedTeams: EnrolledTeams{
+				"service/compute": {Resources: []string{"google_compute_instance"}},
+			},
+		},
+		"unknown keys are ignored": {
+			teamsYaml: []byte(`
+service/compute:
+  owner: someone
+  resources:
+  - google_compute_instance
+`),
+			expectedTeams: EnrolledTeams{
+				"service/compute": {Resources: []string{"google_compute_instance"}},
+			},
+		},
+		"invalid yaml": {
+			teamsYaml:   []byte(`service/compute: [`),
+			expectError: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		tc := tc
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+			enrolledTeams, err := ParseEnrolledTeams(tc.teamsYaml)
+			if tc.expectError {
+				if err == nil {
+					t.Fatalf("ParseEnrolledTeams() succeeded, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseEnrolledTeams() error = %v", err)
+			}
+			if !reflect.DeepEqual(enrolledTeams, tc.expectedTeams) {
+				t.Errorf("ParseEnrolledTeams() = %v, want %v", enrolledTeams, tc.expectedTeams)
+			}
+		})
+	}
+}
+
 func TestBuildRegexLabels(t *testing.T) {
 	cases := map[string]struct {
 		yaml                 []byte
@@ -0,0 +1,155 @@
+package labeler
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/google/go-github/v68/github"
+
+	"github.com/GoogleCloudPlatform/magic-modules/tools/issue-labeler/constants"
+)
+
+// defaultLabelColor is the color of labels created without a configured one.
+const defaultLabelColor = "ededed"
+
+var labelColorRegexp = regexp.MustCompile(`^[0-9a-fA-F]{6}$`)
+
+// LabelDefinition is how a label the labeler applies should look in a repository.
+type LabelDefinition struct {
+	Name string
+	// Color is a hex color without the leading #. Empty leaves existing labels' colors alone.
+	Color string
+	// Description empty leaves existing labels' descriptions alone.
+	Description string
+}
+
+// EmittedLabels returns the definitions of every label the enrolled teams config and policies
+// can apply, sorted by name. Service labels are yellow unless the config gives them a color.
+// The workflow labels, like forward/review, keep whatever color they already have.
+func EmittedLabels(teamsYaml []byte, policy ConflictPolicy, automation AutomationPolicy) ([]LabelDefinition, error) {
+	enrolledTeams, err := ParseEnrolledTeams(teamsYaml)
+	if err != nil {
+		return nil, err
+	}
+
+	defs := make(map[string]LabelDefinition)
+	for label, data := range enrolledTeams {
+		color := data.Color
+		if color == "" {
+			color = constants.GITHUB_YELLOW
+		}
+		defs[label] = LabelDefinition{Name: label, Color: color, Description: data.Description}
+	}
+	defs[labelForwardReview] = LabelDefinition{Name: labelForwardReview, Description: "Waiting for the service team to confirm and track the issue"}
+	defs[LabelTestFailure] = LabelDefinition{Name: LabelTestFailure, Description: "Failing nightly acceptance tests"}
+	defs[LabelDocumentation] = LabelDefinition{Name: LabelDocumentation, Description: "About the provider's documentation"}
+	if policy.MaxLabels > 0 || policy.TriageAbove > 0 {
+		defs[policy.TriageLabel] = LabelDefinition{Name: policy.TriageLabel, Description: "Matches too many services to route automatically"}
+	}
+	if _, ok := defs[policy.UnroutableLabel]; !ok && policy.UnroutableLabel != "" {
+		defs[policy.UnroutableLabel] = LabelDefinition{Name: policy.UnroutableLabel, Description: "Doesn't match any service, needs routing by hand"}
+	}
+	if len(automation.Authors) > 0 {
+		defs[automation.Label] = LabelDefinition{Name: automation.Label, Description: "Opened by automation"}
+	}
+
+	labels := make([]LabelDefinition, 0, len(defs))
+	for _, def := range defs {
+		labels = append(labels, def)
+	}
+	sort.Slice(labels, func(i, j int) bool {
+		return labels[i].Name < labels[j].Name
+	})
+	return labels, nil
+}
+
+// ComputeLabelSync returns the changes that make a repository's existing labels match the
+// desired definitions: missing labels are created and labels whose color or description
+// differ are updated. Label names are compared case-insensitively, like GitHub does.
+func ComputeLabelSync(existingLabels []*github.Label, desired []LabelDefinition) []LabelChange {
+	existing := make(map[string]*github.Label)
+	for _, label := range existingLabels {
+		existing[strings.ToLower(label.GetName())] = label
+	}
+
+	var changes []LabelChange
+	for _, def := range desired {
+		label, ok := existing[strings.ToLower(def.Name)]
+		if !ok {
+			color := def.Color
+			if color == "" {
+				color = defaultLabelColor
+			}
+			changes = append(changes, LabelChange{Name: def.Name, Color: strings.ToUpper(color), Description: def.Description, IsNew: true})
+			continue
+		}
+		change := LabelChange{Name: label.GetName()}
+		if def.Color != "" && !strings.EqualFold(label.GetColor(), def.Color) {
+			change.Color = strings.ToUpper(def.Color)
+			change.NeedsUpdate = true
+		}
+		if def.Description != "" && label.GetDescription() != def.Description {
+			change.Description = def.Description
+			change.NeedsUpdate = true
+		}
+		if change.NeedsUpdate {
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
+
+// SyncLabels creates and updates labels in repository to match the desired definitions,
+// returning the changes made, or that would be made in a dry run.
+func SyncLabels(ctx context.Context, repository string, desired []LabelDefinition, dryRun bool) ([]LabelChange, error) {
+	owner, repo, err := splitRepository(repository)
+	if err != nil {
+		return nil, fmt.Errorf("invalid repository format: %w", err)
+	}
+	existingLabels, err := listLabels(repository)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list existing labels: %w", err)
+	}
+
+	changes := ComputeLabelSync(existingLabels, desired)
+	client := newGitHubClient()
+	for _, change := range changes {
+		logger := slog.With("repo", repository, "label", change.Name, "color", change.Color, "description", change.Description)
+		if dryRun {
+			if change.IsNew {
+				logger.Info("would create label")
+			} else {
+				logger.Info("would update label")
+			}
+			continue
+		}
+		label := &github.Label{}
+		if change.Color != "" {
+			label.Color = github.Ptr(change.Color)
+		}
+		if change.Description != "" {
+			label.Description = github.Ptr(change.Description)
+		}
+		if change.IsNew {
+			label.Name = github.Ptr(change.Name)
+			_, resp, err := client.Issues.CreateLabel(ctx, owner, repo, label)
+			observeResponse(resp)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create label %s: %w", change.Name, apiError("create_label", resp, err))
+			}
+			logger.Info("created label")
+			continue
+		}
+		_, resp, err := client.Issues.EditLabel(ctx, owner, repo, change.Name, label)
+		observeResponse(resp)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update label %s: %w", change.Name, apiError("edit_label", resp, err))
+		}
+		logger.Info("updated label")
+	}
+	return changes, nil
+}
@@ -0,0 +1,92 @@
+package labeler
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/go-github/v68/github"
+)
+
+func TestEmittedLabels(t *testing.T) {
+	teamsYaml := []byte(`
+service/service1:
+  color: 0E8A16
+  description: Service 1 issues
+  resources:
+  - google_service1_.*
+service/service2:
+  resources:
+  - google_service2_.*`)
+
+	cases := map[string]struct {
+		policy     ConflictPolicy
+		automation AutomationPolicy
+		want       []string
+	}{
+		"default policies": {
+			want: []string{"documentation", "forward/review", "service/service1", "service/service2", "test-failure"},
+		},
+		"triage and automation labels": {
+			policy:     ConflictPolicy{TriageAbove: 3, TriageLabel: "needs-triage"},
+			automation: AutomationPolicy{Authors: []string{"modular-magician"}, Label: "automated"},
+			want:       []string{"automated", "documentation", "forward/review", "needs-triage", "service/service1", "service/service2", "test-failure"},
+		},
+		"unroutable label": {
+			policy: ConflictPolicy{UnroutableLabel: "service/unknown"},
+			want:   []string{"documentation", "forward/review", "service/service1", "service/service2", "service/unknown", "test-failure"},
+		},
+	}
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			labels, err := EmittedLabels(teamsYaml, tc.policy, tc.automation)
+			if err != nil {
+				t.Fatalf("EmittedLabels() error = %v", err)
+			}
+			var got []string
+			for _, l := range labels {
+				got = append(got, l.Name)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("EmittedLabels() = %v; want %v", got, tc.want)
+			}
+		})
+	}
+
+	labels, err := EmittedLabels(teamsYaml, ConflictPolicy{}, AutomationPolicy{})
+	if err != nil {
+		t.Fatalf("EmittedLabels() error = %v", err)
+	}
+	want := []LabelDefinition{
+		{Name: "service/service1", Color: "0E8A16", Description: "Service 1 issues"},
+		{Name: "service/service2", Color: "fbca04"},
+	}
+	if got := labels[2:4]; !reflect.DeepEqual(got, want) {
+		t.Errorf("EmittedLabels() service labels = %+v; want %+v", got, want)
+	}
+}
+
+func TestComputeLabelSync(t *testing.T) {
+	existing := []*github.Label{
+		{Name: github.Ptr("service/service1"), Color: github.Ptr("fbca04"), Description: github.Ptr("Service 1 issues")},
+		{Name: github.Ptr("service/service2"), Color: github.Ptr("00ff00")},
+		{Name: github.Ptr("Forward/Review"), Color: github.Ptr("c2e0c6")},
+		{Name: github.Ptr("unrelated"), Color: github.Ptr("000000")},
+	}
+	desired := []LabelDefinition{
+		{Name: "forward/review"},
+		{Name: "service/service1", Color: "FBCA04", Description: "Service 1 issues"},
+		{Name: "service/service2", Color: "fbca04", Description: "Service 2 issues"},
+		{Name: "service/service3", Color: "fbca04"},
+		{Name: "test-failure", Description: "Failing nightly acceptance tests"},
+	}
+
+	got := ComputeLabelSync(existing, desired)
+	want := []LabelChange{
+		{Name: "service/service2", Color: "FBCA04", Description: "Service 2 issues", NeedsUpdate: true},
+		{Name: "service/service3", Color: "FBCA04", IsNew: true},
+		{Name: "test-failure", Color: "EDEDED", Description: "Failing nightly acceptance tests", IsNew: true},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ComputeLabelSync() = %+v; want %+v", got, want)
+	}
+}
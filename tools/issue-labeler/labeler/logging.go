@@ -0,0 +1,48 @@
+package labeler
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"sort"
+)
+
+// NewLogger returns a logger writing to w in the given format ("text" or "json") at or above
+// the given level ("debug", "info", "warn" or "error").
+func NewLogger(w io.Writer, format, level string) (*slog.Logger, error) {
+	var l slog.Level
+	if err := l.UnmarshalText([]byte(level)); err != nil {
+		return nil, fmt.Errorf("invalid log level %q", level)
+	}
+	opts := &slog.HandlerOptions{Level: l}
+	switch format {
+	case "text":
+		return slog.New(slog.NewTextHandler(w, opts)), nil
+	case "json":
+		return slog.New(slog.NewJSONHandler(w, opts)), nil
+	}
+	return nil, fmt.Errorf("invalid log format %q, must be text or json", format)
+}
+
+// diffLabels returns the labels present only in newLabels and only in oldLabels, sorted.
+func diffLabels(oldLabels, newLabels []string) (added, removed []string) {
+	oldSet := make(map[string]struct{}, len(oldLabels))
+	for _, l := range oldLabels {
+		oldSet[l] = struct{}{}
+	}
+	newSet := make(map[string]struct{}, len(newLabels))
+	for _, l := range newLabels {
+		newSet[l] = struct{}{}
+		if _, ok := oldSet[l]; !ok {
+			added = append(added, l)
+		}
+	}
+	for _, l := range oldLabels {
+		if _, ok := newSet[l]; !ok {
+			removed = append(removed, l)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+	return added, removed
+}
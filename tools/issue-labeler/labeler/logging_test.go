@@ -0,0 +1,58 @@
+package labeler
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"golang.org/x/exp/slices"
+)
+
+func TestNewLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := NewLogger(&buf, "json", "info")
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger.Debug("hidden")
+	logger.Info("updated issue", "repo", "owner/repo", "number", 1)
+
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("expected one json line, got %q: %v", buf.String(), err)
+	}
+	if entry["msg"] != "updated issue" || entry["repo"] != "owner/repo" || entry["number"] != float64(1) {
+		t.Errorf("unexpected entry %v", entry)
+	}
+
+	buf.Reset()
+	logger, err = NewLogger(&buf, "text", "debug")
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger.Debug("shown")
+	if !strings.Contains(buf.String(), "msg=shown") {
+		t.Errorf("expected text debug output, got %q", buf.String())
+	}
+
+	if _, err := NewLogger(&buf, "xml", "info"); err == nil {
+		t.Error("expected error for unknown format")
+	}
+	if _, err := NewLogger(&buf, "text", "loud"); err == nil {
+		t.Error("expected error for unknown level")
+	}
+}
+
+func TestDiffLabels(t *testing.T) {
+	added, removed := diffLabels(
+		[]string{"forward/review", "service/a", "service/b"},
+		[]string{"service/c", "forward/review", "service/a"},
+	)
+	if !slices.Equal(added, []string{"service/c"}) {
+		t.Errorf("added = %v", added)
+	}
+	if !slices.Equal(removed, []string{"service/b"}) {
+		t.Errorf("removed = %v", removed)
+	}
+}
@@ -0,0 +1,213 @@
+package labeler
+
+import (
+	"log/slog"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// Matcher matches issue bodies and resources against rules built by BuildRegexLabels. Build
+// one per run: the issue form sections the rules target are worked out once, and the rule each
+// resource matched is remembered, since the same resources are listed again and again across a
+// backfill. A Matcher is safe for concurrent use.
+//
+// Rules naming a single resource, like ^google_compute_instance$, are looked up in a trie
+// rather than run as regexes; only patterned rules, like ^google_compute_.*$, are.
+type Matcher struct {
+	rules []RegexpLabel
+	// sections are the issue form sections, other than affected resources, that rules target.
+	sections []string
+	// literals holds the rules matching a single resource, and patterned the indexes of the
+	// others, in order.
+	literals  *literalTrie
+	patterned []int
+
+	mu sync.RWMutex
+	// cache maps resources to the index of the first rule matching them, or -1 if none does.
+	cache map[sectionResource]int
+}
+
+// NewMatcher returns a Matcher for rules, which are tried in order.
+func NewMatcher(rules []RegexpLabel) *Matcher {
+	m := &Matcher{rules: rules, literals: &literalTrie{}, cache: make(map[sectionResource]int)}
+	seen := make(map[string]struct{})
+	for i, rl := range rules {
+		if literal, ok := literalResource(rl.Regexp); ok {
+			m.literals.insert(literal, i)
+		} else {
+			m.patterned = append(m.patterned, i)
+		}
+		for _, section := range rl.Sections {
+			if _, ok := seen[section]; ok || section == SectionAffectedResources {
+				continue
+			}
+			seen[section] = struct{}{}
+			m.sections = append(m.sections, section)
+		}
+	}
+	return m
+}
+
+// MatchIssue returns every rule match in an issue body, in the order resources appear. The
+// first rule matching a resource wins.
+func (m *Matcher) MatchIssue(body string) []LabelMatch {
+	var candidates []sectionResource
+	for _, resource := range ExtractAffectedResources(body) {
+		candidates = append(candidates, sectionResource{SectionAffectedResources, resource})
+	}
+	if len(m.sections) > 0 {
+		form := ParseIssueForm(body)
+		for _, section := range m.sections {
+			for _, resource := range form.Resources(section) {
+				candidates = append(candidates, sectionResource{section, resource})
+			}
+		}
+	}
+	return m.matchCandidates(candidates)
+}
+
+// IssueLabels returns the sorted labels of the rules matching an issue body.
+func (m *Matcher) IssueLabels(body string) []string {
+	labelSet := make(map[string]struct{})
+	for _, match := range m.MatchIssue(body) {
+		labelSet[match.Label] = struct{}{}
+	}
+	return sortedKeys(labelSet)
+}
+
+// matchCandidates returns the first rule matching each candidate resource, skipping duplicates.
+func (m *Matcher) matchCandidates(candidates []sectionResource) []LabelMatch {
+	var matches []LabelMatch
+	seen := make(map[LabelMatch]struct{})
+	for _, c := range candidates {
+		rl, ok := m.match(c)
+		if !ok {
+			continue
+		}
+		match := LabelMatch{Label: rl.Label, Resource: c.resource, Section: c.section, Pattern: rl.Regexp.String()}
+		if _, ok := seen[match]; !ok {
+			slog.Debug("found resource", "resource", c.resource, "section", c.section, "label", rl.Label)
+			seen[match] = struct{}{}
+			matches = append(matches, match)
+		}
+	}
+	return matches
+}
+
+// match returns the first rule matching a resource found in a section.
+func (m *Matcher) match(c sectionResource) (RegexpLabel, bool) {
+	m.mu.RLock()
+	i, ok := m.cache[c]
+	m.mu.RUnlock()
+	if !ok {
+		i = m.literals.lookup(c.resource, func(j int) bool {
+			return m.rules[j].appliesTo(c.section)
+		})
+		// A patterned rule only wins if it comes before the literal rule that matched.
+		for _, j := range m.patterned {
+			if i >= 0 && j > i {
+				break
+			}
+			if rl := m.rules[j]; rl.appliesTo(c.section) && rl.Regexp.MatchString(c.resource) {
+				i = j
+				break
+			}
+		}
+		m.mu.Lock()
+		m.cache[c] = i
+		m.mu.Unlock()
+	}
+	if i < 0 {
+		return RegexpLabel{}, false
+	}
+	return m.rules[i], true
+}
+
+// literalResource returns the resource an exact rule like ^google_compute_instance$ matches,
+// if the rule has no regex metacharacters between its anchors.
+func literalResource(re *regexp.Regexp) (string, bool) {
+	expr := re.String()
+	if !strings.HasPrefix(expr, "^") || !strings.HasSuffix(expr, "$") {
+		return "", false
+	}
+	literal := strings.TrimSuffix(strings.TrimPrefix(expr, "^"), "$")
+	if literal == "" || regexp.QuoteMeta(literal) != literal {
+		return "", false
+	}
+	return literal, true
+}
+
+// literalTrie maps resource names to the indexes of the rules matching exactly them. It's
+// path-compressed: resource names share long prefixes like google_compute_, which are stored
+// once on an edge, so a lookup compares a handful of edges however many rules there are.
+type literalTrie struct {
+	edges []literalEdge
+	// rules are the indexes of rules ending at this node, in order.
+	rules []int
+}
+
+// literalEdge leads to the node for the names continuing with label. A node's edges all start
+// with different bytes.
+type literalEdge struct {
+	label string
+	node  *literalTrie
+}
+
+func (t *literalTrie) insert(literal string, rule int) {
+	node := t
+	for literal != "" {
+		i := node.edge(literal[0])
+		if i < 0 {
+			node.edges = append(node.edges, literalEdge{label: literal, node: &literalTrie{rules: []int{rule}}})
+			return
+		}
+		e := node.edges[i]
+		n := commonPrefixLen(e.label, literal)
+		if n < len(e.label) {
+			// Split the edge where the names diverge.
+			split := &literalTrie{edges: []literalEdge{{label: e.label[n:], node: e.node}}}
+			node.edges[i] = literalEdge{label: e.label[:n], node: split}
+		}
+		node = node.edges[i].node
+		literal = literal[n:]
+	}
+	node.rules = append(node.rules, rule)
+}
+
+// lookup returns the first rule matching resource exactly that applies, or -1 if none does.
+func (t *literalTrie) lookup(resource string, applies func(rule int) bool) int {
+	node := t
+	for resource != "" {
+		i := node.edge(resource[0])
+		if i < 0 || !strings.HasPrefix(resource, node.edges[i].label) {
+			return -1
+		}
+		resource = resource[len(node.edges[i].label):]
+		node = node.edges[i].node
+	}
+	for _, rule := range node.rules {
+		if applies(rule) {
+			return rule
+		}
+	}
+	return -1
+}
+
+// edge returns the index of the edge starting with b, or -1 if there isn't one.
+func (t *literalTrie) edge(b byte) int {
+	for i, e := range t.edges {
+		if e.label[0] == b {
+			return i
+		}
+	}
+	return -1
+}
+
+func commonPrefixLen(a, b string) int {
+	n := 0
+	for n < len(a) && n < len(b) && a[n] == b[n] {
+		n++
+	}
+	return n
+}
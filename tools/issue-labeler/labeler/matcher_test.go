@@ -0,0 +1,260 @@
+package labeler
+
+import (
+	"context"
+	"reflect"
+	"regexp"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/google/go-github/v68/github"
+)
+
+func TestMatcherMatchIssue(t *testing.T) {
+	rules := []RegexpLabel{
+		{Regexp: regexp.MustCompile("^google_compute_instance$"), Label: "service/compute-instances"},
+		{Regexp: regexp.MustCompile("^google_compute_.*$"), Label: "service/compute"},
+		{Regexp: regexp.MustCompile("^google_container_.*$"), Label: "service/container", Sections: []string{SectionConfig}},
+	}
+	body := "### Affected Resource(s)\n\ngoogle_compute_instance\ngoogle_compute_disk\ngoogle_container_cluster\n\n### Terraform Configuration\n\n```hcl\nresource \"google_container_cluster\" \"primary\" {}\n```\n"
+	m := NewMatcher(rules)
+	want := []string{"service/compute", "service/compute-instances", "service/container"}
+	// The second pass is answered from the cache.
+	for i := 0; i < 2; i++ {
+		if got := m.IssueLabels(body); !reflect.DeepEqual(got, want) {
+			t.Errorf("IssueLabels() pass %d = %v; want %v", i, got, want)
+		}
+	}
+	if got, want := len(m.cache), 4; got != want {
+		t.Errorf("len(cache) = %d; want %d", got, want)
+	}
+	if got := m.IssueLabels("### Affected Resource(s)\n\ngoogle_storage_bucket\n"); len(got) != 0 {
+		t.Errorf("IssueLabels() = %v; want no labels", got)
+	}
+}
+
+// scanRules is how rules were matched before Matcher: every rule's regex is tried in order.
+func scanRules(resource, section string, rules []RegexpLabel) (RegexpLabel, bool) {
+	for _, rl := range rules {
+		if rl.appliesTo(section) && rl.Regexp.MatchString(resource) {
+			return rl, true
+		}
+	}
+	return RegexpLabel{}, false
+}
+
+func TestMatcherRuleOrder(t *testing.T) {
+	cases := map[string]struct {
+		rules    []RegexpLabel
+		resource string
+		section  string
+		want     string
+	}{
+		"literal": {
+			rules: []RegexpLabel{
+				{Regexp: regexp.MustCompile("^google_compute_instance$"), Label: "service/compute"},
+			},
+			resource: "google_compute_instance",
+			section:  SectionAffectedResources,
+			want:     "service/compute",
+		},
+		"literal prefix doesn't match": {
+			rules: []RegexpLabel{
+				{Regexp: regexp.MustCompile("^google_compute_instance$"), Label: "service/compute"},
+			},
+			resource: "google_compute_instance_group",
+			section:  SectionAffectedResources,
+		},
+		"earlier pattern wins over literal": {
+			rules: []RegexpLabel{
+				{Regexp: regexp.MustCompile("^google_compute_.*$"), Label: "service/compute"},
+				{Regexp: regexp.MustCompile("^google_compute_instance$"), Label: "service/compute-instances"},
+			},
+			resource: "google_compute_instance",
+			section:  SectionAffectedResources,
+			want:     "service/compute",
+		},
+		"earlier literal wins over pattern": {
+			rules: []RegexpLabel{
+				{Regexp: regexp.MustCompile("^google_compute_instance$"), Label: "service/compute-instances"},
+				{Regexp: regexp.MustCompile("^google_compute_.*$"), Label: "service/compute"},
+			},
+			resource: "google_compute_instance",
+			section:  SectionAffectedResources,
+			want:     "service/compute-instances",
+		},
+		"literal limited to other sections": {
+			rules: []RegexpLabel{
+				{Regexp: regexp.MustCompile("^google_compute_instance$"), Label: "service/compute-instances", Sections: []string{SectionConfig}},
+				{Regexp: regexp.MustCompile("^google_compute_instance$"), Label: "service/compute"},
+			},
+			resource: "google_compute_instance",
+			section:  SectionAffectedResources,
+			want:     "service/compute",
+		},
+		"unanchored rule is a pattern": {
+			rules: []RegexpLabel{
+				{Regexp: regexp.MustCompile("google_compute"), Label: "service/compute"},
+			},
+			resource: "google_compute_instance",
+			section:  SectionAffectedResources,
+			want:     "service/compute",
+		},
+	}
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			rl, ok := NewMatcher(tc.rules).match(sectionResource{tc.section, tc.resource})
+			if got := rl.Label; got != tc.want || ok != (tc.want != "") {
+				t.Errorf("match(%q) = %q, %v; want %q", tc.resource, got, ok, tc.want)
+			}
+		})
+	}
+}
+
+func TestLiteralResource(t *testing.T) {
+	cases := map[string]struct {
+		pattern string
+		want    string
+		ok      bool
+	}{
+		"exact resource":   {pattern: "^google_compute_instance$", want: "google_compute_instance", ok: true},
+		"wildcard":         {pattern: "^google_compute_.*$"},
+		"alternation":      {pattern: "^google_(compute|container)_instance$"},
+		"unanchored":       {pattern: "google_compute_instance"},
+		"escaped anchor":   {pattern: `^google_compute_instance\$`},
+		"empty":            {pattern: "^$"},
+		"character class":  {pattern: "^google_compute_[a-z]+$"},
+		"escaped metachar": {pattern: `^google_compute\.instance$`},
+	}
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			got, ok := literalResource(regexp.MustCompile(tc.pattern))
+			if got != tc.want || ok != tc.ok {
+				t.Errorf("literalResource(%q) = %q, %v; want %q, %v", tc.pattern, got, ok, tc.want, tc.ok)
+			}
+		})
+	}
+}
+
+// TestMatcherMatchesScan checks the matcher picks the same rule as trying every rule's regex
+// in order, for every resource the enrolled teams config names and those in the corpus.
+func TestMatcherMatchesScan(t *testing.T) {
+	rules, err := BuildRegexLabels(EnrolledTeamsYaml)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resources := []string{"google_compute_instance_templatex", "google_", "google_unknown_resource"}
+	for _, rl := range rules {
+		if literal, ok := literalResource(rl.Regexp); ok {
+			resources = append(resources, literal, literal+"s", literal[:len(literal)-1])
+		}
+	}
+	for _, body := range readCorpus(t) {
+		resources = append(resources, ExtractAffectedResources(body)...)
+	}
+
+	m := NewMatcher(rules)
+	for _, section := range []string{SectionAffectedResources, SectionConfig} {
+		for _, resource := range resources {
+			want, wantOK := scanRules(resource, section, rules)
+			got, ok := m.match(sectionResource{section, resource})
+			if ok != wantOK || got.Label != want.Label || (ok && got.Regexp != want.Regexp) {
+				t.Errorf("match(%q in %s) = %s %v; want %s %v", resource, section, got.Label, got.Regexp, want.Label, want.Regexp)
+			}
+		}
+	}
+}
+
+func TestMatcherConcurrent(t *testing.T) {
+	rules, err := BuildRegexLabels(EnrolledTeamsYaml)
+	if err != nil {
+		t.Fatal(err)
+	}
+	corpus := readCorpus(t)
+	want := make(map[string][]string)
+	for name, body := range corpus {
+		want[name] = ComputeIssueLabels(body, rules)
+	}
+
+	m := NewMatcher(rules)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		for name, body := range corpus {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if got := m.IssueLabels(body); !reflect.DeepEqual(got, want[name]) {
+					t.Errorf("%s: IssueLabels() = %v; want %v", name, got, want[name])
+				}
+			}()
+		}
+	}
+	wg.Wait()
+}
+
+// benchmarkIssues returns n issues cycling through the corpus bodies, like a large backfill.
+func benchmarkIssues(b *testing.B, n int) []*github.Issue {
+	corpus := readCorpus(b)
+	names := make([]string, 0, len(corpus))
+	for name := range corpus {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	issues := make([]*github.Issue, n)
+	for i := range issues {
+		issues[i] = &github.Issue{Number: github.Ptr(i + 1), Body: github.Ptr(corpus[names[i%len(names)]])}
+	}
+	return issues
+}
+
+func benchmarkRules(b *testing.B) []RegexpLabel {
+	rules, err := BuildRegexLabels(EnrolledTeamsYaml)
+	if err != nil {
+		b.Fatal(err)
+	}
+	return rules
+}
+
+func BenchmarkBuildRegexLabels(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		if _, err := BuildRegexLabels(EnrolledTeamsYaml); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkComputeIssueUpdates measures a backfill's hot loop over a thousand issues.
+func BenchmarkComputeIssueUpdates(b *testing.B) {
+	rules := benchmarkRules(b)
+	issues := benchmarkIssues(b, 1000)
+	ctx := context.Background()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ComputeIssueUpdates(ctx, issues, rules)
+	}
+}
+
+// BenchmarkMatchIssueLabels matches each body with a new matcher, so nothing is cached.
+func BenchmarkMatchIssueLabels(b *testing.B) {
+	rules := benchmarkRules(b)
+	issues := benchmarkIssues(b, 100)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, issue := range issues {
+			MatchIssueLabels(issue.GetBody(), rules)
+		}
+	}
+}
+
+// BenchmarkMatcherMatchIssue matches each body with a matcher shared across the run.
+func BenchmarkMatcherMatchIssue(b *testing.B) {
+	m := NewMatcher(benchmarkRules(b))
+	issues := benchmarkIssues(b, 100)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, issue := range issues {
+			m.MatchIssue(issue.GetBody())
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package labeler
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+
+	"github.com/google/go-github/v68/github"
+)
+
+// mentionMarkerPrefix starts the hidden marker recording each team a comment mentioned, so
+// reruns can tell which teams were already notified.
+const mentionMarkerPrefix = "<!-- issue-labeler:mention "
+
+// TeamMention is a pending @-mention of the teams owning the service labels newly added to an
+// issue.
+type TeamMention struct {
+	Number int
+	// Teams maps each GitHub team, as org/team-slug, to the service label it owns.
+	Teams map[string]string
+}
+
+// BuildLabelTeams returns a map of service label to the GitHub team owning it.
+func BuildLabelTeams(teamsYaml []byte) (map[string]string, error) {
+	enrolledTeams, err := ParseEnrolledTeams(teamsYaml)
+	if err != nil {
+		return nil, err
+	}
+	labelTeams := make(map[string]string)
+	for label, data := range enrolledTeams {
+		if data.GitHubTeam != "" {
+			labelTeams[label] = data.GitHubTeam
+		}
+	}
+	return labelTeams, nil
+}
+
+// ComputeTeamMentions returns the teams to mention for each update that adds a service label
+// with a GitHub team.
+func ComputeTeamMentions(issueUpdates []IssueUpdate, labelTeams map[string]string) []TeamMention {
+	var mentions []TeamMention
+	for _, update := range issueUpdates {
+		added, _ := diffLabels(update.OldLabels, update.Labels)
+		teams := make(map[string]string)
+		for _, l := range added {
+			if team, ok := labelTeams[l]; ok {
+				teams[team] = l
+			}
+		}
+		if len(teams) > 0 {
+			mentions = append(mentions, TeamMention{Number: update.Number, Teams: teams})
+		}
+	}
+	return mentions
+}
+
+// mentionedTeams returns the teams earlier mention comments recorded.
+func mentionedTeams(comments []*github.IssueComment) map[string]struct{} {
+	teams := make(map[string]struct{})
+	for _, c := range comments {
+		for _, line := range strings.Split(c.GetBody(), "\n") {
+			if team, ok := strings.CutPrefix(strings.TrimSpace(line), mentionMarkerPrefix); ok {
+				teams[strings.TrimSpace(strings.TrimSuffix(team, "-->"))] = struct{}{}
+			}
+		}
+	}
+	return teams
+}
+
+// mentionComment renders the comment for the teams not yet mentioned, or "" if there are none.
+func mentionComment(mention TeamMention, already map[string]struct{}) string {
+	var teams []string
+	for team := range mention.Teams {
+		if _, ok := already[team]; !ok {
+			teams = append(teams, team)
+		}
+	}
+	if len(teams) == 0 {
+		return ""
+	}
+	sort.Strings(teams)
+	var b strings.Builder
+	for _, team := range teams {
+		fmt.Fprintf(&b, "%s%s -->\n", mentionMarkerPrefix, team)
+	}
+	for _, team := range teams {
+		fmt.Fprintf(&b, "@%s: this issue has been routed to `%s`.\n", team, mention.Teams[team])
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// PostTeamMentions comments an @-mention of each team on its issues, skipping teams an earlier
+// run already mentioned, or only logs the comments in dry-run mode.
+func PostTeamMentions(ctx context.Context, repository string, mentions []TeamMention, dryRun bool) error {
+	client := newGitHubClient()
+	owner, repo, err := splitRepository(repository)
+	if err != nil {
+		return fmt.Errorf("invalid repository format: %w", err)
+	}
+
+	failed := 0
+	for _, mention := range mentions {
+		logger := slog.With(
+			"repo", repository,
+			"number", mention.Number,
+			"url", fmt.Sprintf("https://github.com/%s/issues/%d", repository, mention.Number),
+		)
+
+		var comments []*github.IssueComment
+		opt := &github.IssueListCommentsOptions{ListOptions: github.ListOptions{PerPage: 100}}
+		for {
+			page, resp, err := client.Issues.ListComments(ctx, owner, repo, mention.Number, opt)
+			observeResponse(resp)
+			if err != nil {
+				return fmt.Errorf("listing comments on issue %d: %w", mention.Number, apiError("list_comments", resp, err))
+			}
+			comments = append(comments, page...)
+			if resp.NextPage == 0 {
+				break
+			}
+			opt.Page = resp.NextPage
+		}
+
+		body := mentionComment(mention, mentionedTeams(comments))
+		if body == "" {
+			logger.Info("teams already mentioned")
+			continue
+		}
+		if dryRun {
+			logger.Info("would mention teams", "body", body)
+			continue
+		}
+		_, resp, err := client.Issues.CreateComment(ctx, owner, repo, mention.Number, &github.IssueComment{Body: &body})
+		observeResponse(resp)
+		if err = apiError("create_comment", resp, err); err != nil {
+			logger.Error("mentioning teams failed", "error", err)
+			failed++
+			continue
+		}
+		logger.Info("mentioned teams")
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to mention teams on %d / %d issues", failed, len(mentions))
+	}
+	return nil
+}
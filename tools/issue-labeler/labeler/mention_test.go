@@ -0,0 +1,64 @@
+package labeler
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/go-github/v68/github"
+)
+
+func TestComputeTeamMentions(t *testing.T) {
+	labelTeams := map[string]string{
+		"service/service1": "org/team1",
+		"service/service2": "org/team2",
+	}
+	updates := []IssueUpdate{
+		{Number: 1, OldLabels: []string{}, Labels: []string{"forward/review", "service/service1", "service/service2"}},
+		{Number: 2, OldLabels: []string{"service/service1"}, Labels: []string{"service/service1", "service/service3"}},
+		{Number: 3, OldLabels: []string{"service/service1"}, Labels: []string{"service/service1", "service/service2"}},
+	}
+	want := []TeamMention{
+		{Number: 1, Teams: map[string]string{"org/team1": "service/service1", "org/team2": "service/service2"}},
+		{Number: 3, Teams: map[string]string{"org/team2": "service/service2"}},
+	}
+	if got := ComputeTeamMentions(updates, labelTeams); !reflect.DeepEqual(got, want) {
+		t.Errorf("want %+v, got %+v", want, got)
+	}
+}
+
+func TestMentionComment(t *testing.T) {
+	mention := TeamMention{Number: 1, Teams: map[string]string{"org/team1": "service/service1", "org/team2": "service/service2"}}
+
+	cases := map[string]struct {
+		comments []*github.IssueComment
+		want     string
+	}{
+		"no earlier comments": {
+			want: "<!-- issue-labeler:mention org/team1 -->\n<!-- issue-labeler:mention org/team2 -->\n" +
+				"@org/team1: this issue has been routed to `service/service1`.\n" +
+				"@org/team2: this issue has been routed to `service/service2`.",
+		},
+		"one team already mentioned": {
+			comments: []*github.IssueComment{
+				{Body: github.Ptr("Thanks for the report!")},
+				{Body: github.Ptr("<!-- issue-labeler:mention org/team1 -->\n@org/team1: this issue has been routed to `service/service1`.")},
+			},
+			want: "<!-- issue-labeler:mention org/team2 -->\n@org/team2: this issue has been routed to `service/service2`.",
+		},
+		"all teams already mentioned": {
+			comments: []*github.IssueComment{
+				{Body: github.Ptr("<!-- issue-labeler:mention org/team1 -->\r\n<!-- issue-labeler:mention org/team2 -->\r\n@org/team1 @org/team2")},
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		tc := tc
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+			if got := mentionComment(mention, mentionedTeams(tc.comments)); got != tc.want {
+				t.Errorf("want %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
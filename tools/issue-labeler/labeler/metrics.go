@@ -0,0 +1,63 @@
+package labeler
+
+import (
+	"time"
+
+	"github.com/google/go-github/v68/github"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Metrics exported on /metrics in serve and daemon mode.
+var (
+	issuesFetched = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "issue_labeler_issues_fetched_total",
+		Help: "Issues and pull requests fetched from GitHub.",
+	})
+	updatesApplied = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "issue_labeler_updates_applied_total",
+		Help: "Issue label updates successfully applied.",
+	})
+	apiErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "issue_labeler_api_errors_total",
+		Help: "GitHub API calls that returned an error, by operation.",
+	}, []string{"operation"})
+	apiRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "issue_labeler_api_requests_total",
+		Help: "GitHub API requests made, by rate limit resource: core for REST, graphql, search.",
+	}, []string{"resource"})
+	runAPIRequests = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "issue_labeler_run_api_requests",
+		Help: "GitHub API requests made by the last run, by run and rate limit resource.",
+	}, []string{"run", "resource"})
+	rateLimitProjectedRuns = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "issue_labeler_rate_limit_projected_runs",
+		Help: "Runs like the last one that fit in the rate limit budget left before it resets, by run and rate limit resource.",
+	}, []string{"run", "resource"})
+	rateLimitRemaining = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "issue_labeler_rate_limit_remaining",
+		Help: "GitHub API requests remaining in the current rate limit window, as of the last response.",
+	})
+	runDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "issue_labeler_run_duration_seconds",
+		Help:    "Duration of labeling runs, by mode.",
+		Buckets: prometheus.ExponentialBuckets(0.5, 2, 12),
+	}, []string{"mode"})
+	matchingDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "issue_labeler_matching_duration_seconds",
+		Help:    "Time spent matching rules against a run's issues and computing their updates.",
+		Buckets: prometheus.ExponentialBuckets(0.001, 4, 10),
+	})
+)
+
+// ObserveRun records how long a labeling run took.
+func ObserveRun(mode string, start time.Time) {
+	runDuration.WithLabelValues(mode).Observe(time.Since(start).Seconds())
+}
+
+// observeResponse records rate limit information from a GitHub API response.
+func observeResponse(resp *github.Response) {
+	if resp != nil && resp.Rate.Limit > 0 {
+		rateLimitRemaining.Set(float64(resp.Rate.Remaining))
+	}
+}
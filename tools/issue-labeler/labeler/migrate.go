@@ -0,0 +1,128 @@
+package labeler
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/google/go-github/v68/github"
+)
+
+// ListIssuesWithLabel lists all issues, and pull requests, in any state carrying label.
+func ListIssuesWithLabel(ctx context.Context, repository, label string) ([]*github.Issue, error) {
+	client := newGitHubClient()
+	owner, repo, err := splitRepository(repository)
+	if err != nil {
+		return nil, fmt.Errorf("invalid repository format: %w", err)
+	}
+
+	opt := &github.IssueListByRepoOptions{
+		State:       "all",
+		Labels:      []string{label},
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+	var allIssues []*github.Issue
+	for page := 1; ; page++ {
+		issues, resp, err := fetchIssuesPage(ctx, page, func(ctx context.Context) ([]*github.Issue, *github.Response, error) {
+			return client.Issues.ListByRepo(ctx, owner, repo, opt)
+		})
+		if err != nil {
+			return allIssues, fmt.Errorf("listing issues labeled %s: %w", label, err)
+		}
+		allIssues = append(allIssues, issues...)
+		if resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+	return allIssues, nil
+}
+
+// ComputeMigrationUpdates replaces the from label with the to labels on every issue carrying it.
+// Pull requests are only included if includePRs is set.
+func ComputeMigrationUpdates(issues []*github.Issue, from string, to []string, includePRs bool) []IssueUpdate {
+	var updates []IssueUpdate
+	for _, issue := range issues {
+		if issue.IsPullRequest() && !includePRs {
+			continue
+		}
+		labels := make(map[string]struct{})
+		var oldLabels []string
+		found := false
+		for _, l := range issue.Labels {
+			oldLabels = append(oldLabels, l.GetName())
+			if l.GetName() == from {
+				found = true
+				continue
+			}
+			labels[l.GetName()] = struct{}{}
+		}
+		if !found {
+			continue
+		}
+		for _, l := range to {
+			labels[l] = struct{}{}
+		}
+		sort.Strings(oldLabels)
+		updates = append(updates, IssueUpdate{
+			Number:    issue.GetNumber(),
+			NodeID:    issue.GetNodeID(),
+			Labels:    sortedKeys(labels),
+			OldLabels: oldLabels,
+		})
+	}
+	return updates
+}
+
+// MigrateLabels applies updates in batches, pausing between batches until the rate limit resets
+// whenever fewer than minRemaining requests are left.
+func MigrateLabels(ctx context.Context, repository string, updates []IssueUpdate, batchSize, minRemaining int, dryRun bool) error {
+	if batchSize <= 0 {
+		return fmt.Errorf("batch size must be positive")
+	}
+	client := newGitHubClient()
+	failed := 0
+	for start := 0; start < len(updates); start += batchSize {
+		end := min(start+batchSize, len(updates))
+		slog.Info("migrating batch", "repo", repository, "from", start+1, "to", end, "total", len(updates))
+		if err := UpdateIssues(ctx, repository, updates[start:end], dryRun); err != nil {
+			slog.Error("migrating batch failed", "error", err)
+			failed++
+		}
+		if dryRun || end == len(updates) {
+			continue
+		}
+		if err := waitForRateLimit(ctx, client, minRemaining); err != nil {
+			return err
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d / %d batches had failures", failed, (len(updates)+batchSize-1)/batchSize)
+	}
+	return nil
+}
+
+// waitForRateLimit sleeps until the core rate limit resets if fewer than minRemaining requests
+// are left in the current window.
+func waitForRateLimit(ctx context.Context, client *github.Client, minRemaining int) error {
+	limits, resp, err := client.RateLimit.Get(ctx)
+	observeResponse(resp)
+	if err != nil {
+		return fmt.Errorf("checking rate limit: %w", apiError("rate_limit", resp, err))
+	}
+	core := limits.GetCore()
+	rateLimitRemaining.Set(float64(core.Remaining))
+	if core.Remaining >= minRemaining {
+		return nil
+	}
+	wait := time.Until(core.Reset.Time)
+	slog.Info("rate limit low, waiting for reset", "remaining", core.Remaining, "reset", core.Reset.Time, "wait", wait.Round(time.Second))
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(wait):
+		return nil
+	}
+}
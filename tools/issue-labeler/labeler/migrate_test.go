@@ -0,0 +1,58 @@
+package labeler
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/go-github/v68/github"
+)
+
+func TestComputeMigrationUpdates(t *testing.T) {
+	labels := func(names ...string) []*github.Label {
+		var ls []*github.Label
+		for _, n := range names {
+			ls = append(ls, &github.Label{Name: github.Ptr(n)})
+		}
+		return ls
+	}
+	issues := []*github.Issue{
+		{Number: github.Ptr(1), Labels: labels("service/old", "bug")},
+		{Number: github.Ptr(2), Labels: labels("service/new", "service/old")},
+		{Number: github.Ptr(3), Labels: labels("service/other")},
+		{Number: github.Ptr(4), Labels: labels("service/old"), PullRequestLinks: &github.PullRequestLinks{URL: github.Ptr("https://example.com")}},
+	}
+
+	cases := map[string]struct {
+		to         []string
+		includePRs bool
+		want       []IssueUpdate
+	}{
+		"rename": {
+			to: []string{"service/new"},
+			want: []IssueUpdate{
+				{Number: 1, Labels: []string{"bug", "service/new"}, OldLabels: []string{"bug", "service/old"}},
+				{Number: 2, Labels: []string{"service/new"}, OldLabels: []string{"service/new", "service/old"}},
+			},
+		},
+		"split including pull requests": {
+			to:         []string{"service/new", "service/new-subteam"},
+			includePRs: true,
+			want: []IssueUpdate{
+				{Number: 1, Labels: []string{"bug", "service/new", "service/new-subteam"}, OldLabels: []string{"bug", "service/old"}},
+				{Number: 2, Labels: []string{"service/new", "service/new-subteam"}, OldLabels: []string{"service/new", "service/old"}},
+				{Number: 4, Labels: []string{"service/new", "service/new-subteam"}, OldLabels: []string{"service/old"}},
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		tc := tc
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+			got := ComputeMigrationUpdates(issues, "service/old", tc.to, tc.includePRs)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("want %+v; got %+v", tc.want, got)
+			}
+		})
+	}
+}
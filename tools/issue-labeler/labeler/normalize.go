@@ -0,0 +1,52 @@
+package labeler
+
+import (
+	"regexp"
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// punctuationFolder maps typographic quotes and dashes, which editors and chat clients
+// substitute as people type, back to ASCII, and drops invisible zero-width characters.
+var punctuationFolder = strings.NewReplacer(
+	"\u2018", "'", "\u2019", "'", "\u201a", "'", "\u201b", "'",
+	"\u201c", `"`, "\u201d", `"`, "\u201e", `"`, "\u201f", `"`,
+	"\u2010", "-", "\u2011", "-", "\u2012", "-", "\u2013", "-", "\u2014", "-", "\u2015", "-", "\u2212", "-",
+	"\u200b", "", "\u200c", "", "\u200d", "", "\u2060", "", "\ufeff", "",
+)
+
+var (
+	// splitResourceHeadRegexp matches a line ending part way through a resource name, at an
+	// underscore, as when a long line is wrapped before pasting.
+	splitResourceHeadRegexp = regexp.MustCompile(`google_\w*_$`)
+	splitResourceTailRegexp = regexp.MustCompile(`^\w`)
+)
+
+// NormalizeIssueBody rewrites an issue body so resource names written unusually still match:
+// it applies NFKC, which turns full-width letters and underscores into ASCII, folds smart
+// quotes and dashes, and inside code fences rejoins resource names wrapped onto the next line
+// after an underscore.
+func NormalizeIssueBody(body string) string {
+	body = norm.NFKC.String(body)
+	body = punctuationFolder.Replace(body)
+
+	lines := strings.Split(body, "\n")
+	var out []string
+	inFence := false
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+			out = append(out, line)
+			continue
+		}
+		for inFence && i+1 < len(lines) && splitResourceHeadRegexp.MatchString(strings.TrimRight(line, " \t")) &&
+			splitResourceTailRegexp.MatchString(strings.TrimSpace(lines[i+1])) {
+			line = strings.TrimRight(line, " \t") + strings.TrimSpace(lines[i+1])
+			i++
+		}
+		out = append(out, line)
+	}
+	return strings.Join(out, "\n")
+}
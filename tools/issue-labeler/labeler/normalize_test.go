@@ -0,0 +1,52 @@
+package labeler
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNormalizeIssueBody(t *testing.T) {
+	cases := map[string]struct {
+		body string
+		want string
+	}{
+		"full-width": {
+			body: "ｇｏｏｇｌｅ＿ｃｏｍｐｕｔｅ＿ｉｎｓｔａｎｃｅ",
+			want: "google_compute_instance",
+		},
+		"smart quotes and dashes": {
+			body: "resource “google_storage_bucket” — it’s broken",
+			want: `resource "google_storage_bucket" - it's broken`,
+		},
+		"zero-width space": {
+			body: "google_compute_\u200binstance",
+			want: "google_compute_instance",
+		},
+		"wrapped resource in code fence": {
+			body: "```\nError creating google_compute_\n  instance_group_manager: 400\n```",
+			want: "```\nError creating google_compute_instance_group_manager: 400\n```",
+		},
+		"wrapped resource outside code fence kept": {
+			body: "google_compute_\ninstance",
+			want: "google_compute_\ninstance",
+		},
+	}
+
+	for tn, tc := range cases {
+		tc := tc
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+			if got := NormalizeIssueBody(tc.body); got != tc.want {
+				t.Errorf("NormalizeIssueBody() = %q; want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestExtractAffectedResourcesNormalized(t *testing.T) {
+	body := "### Affected Resource(s)\n\n* ｇｏｏｇｌｅ＿ｓｔｏｒａｇｅ＿ｂｕｃｋｅｔ\n* “google_compute_instance”\n"
+	want := []string{"google_storage_bucket", "google_compute_instance"}
+	if got := ExtractAffectedResources(body); !reflect.DeepEqual(got, want) {
+		t.Errorf("ExtractAffectedResources() = %v; want %v", got, want)
+	}
+}
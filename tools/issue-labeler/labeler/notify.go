@@ -0,0 +1,300 @@
+package labeler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/google/go-github/v68/github"
+	"gopkg.in/yaml.v2"
+)
+
+// RunStats collects which updates a run applied and which failed.
+type RunStats struct {
+	mu      sync.Mutex
+	applied []IssueUpdate
+	failed  []IssueUpdate
+	// permanent are the failed updates that retrying can't fix.
+	permanent []IssueUpdate
+	// transferred are issues skipped because they moved to another repository.
+	transferred []TransferredIssue
+}
+
+func (s *RunStats) record(update IssueUpdate, err error) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err != nil {
+		s.failed = append(s.failed, update)
+		if IsPermanent(err) {
+			s.permanent = append(s.permanent, update)
+		}
+	} else {
+		s.applied = append(s.applied, update)
+	}
+}
+
+func (s *RunStats) recordTransfer(issue TransferredIssue) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.transferred = append(s.transferred, issue)
+}
+
+// WithoutTransferred returns the updates for issues that weren't found to be transferred, which
+// are the only ones that can still be acted on in the repository.
+func (s *RunStats) WithoutTransferred(updates []IssueUpdate) []IssueUpdate {
+	if s == nil {
+		return updates
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.transferred) == 0 {
+		return updates
+	}
+	transferred := make(map[int]struct{})
+	for _, t := range s.transferred {
+		transferred[t.Number] = struct{}{}
+	}
+	var kept []IssueUpdate
+	for _, u := range updates {
+		if _, ok := transferred[u.Number]; !ok {
+			kept = append(kept, u)
+		}
+	}
+	return kept
+}
+
+type runStatsKey struct{}
+
+// WithRunStats returns a context whose label updates are recorded to s.
+func WithRunStats(ctx context.Context, s *RunStats) context.Context {
+	return context.WithValue(ctx, runStatsKey{}, s)
+}
+
+func runStatsFrom(ctx context.Context) *RunStats {
+	s, _ := ctx.Value(runStatsKey{}).(*RunStats)
+	return s
+}
+
+// RunSummary describes the outcome of a labeling run for notifications and job summaries.
+type RunSummary struct {
+	Repository string
+	// Updates are all the updates the run computed, whether or not they were applied.
+	Updates []IssueUpdate
+	// Routed are the applied updates, Failed the ones GitHub rejected, even after retrying.
+	Routed []IssueUpdate
+	Failed []IssueUpdate
+	// Permanent are the failed updates that failed in a way retrying can't fix, like a missing
+	// issue or bad credentials, so they need someone to look at them.
+	Permanent []IssueUpdate
+	// NeedsReview are open issues the rules couldn't route to any service, including those given
+	// the unroutable label by this run.
+	NeedsReview []int
+	// Transferred are issues that were skipped because they moved to another repository.
+	Transferred []TransferredIssue
+}
+
+// ComputeRunSummary summarizes a run over issues from the updates it computed and the
+// outcomes it recorded in stats. Issues count as routed once an applied update, or in a dry run
+// a proposed one, gives them a service label.
+func ComputeRunSummary(repository string, issues []*github.Issue, updates []IssueUpdate, stats *RunStats) RunSummary {
+	summary := RunSummary{Repository: repository, Updates: updates}
+	routed := make(map[int]struct{})
+	if stats != nil {
+		stats.mu.Lock()
+		summary.Routed = append(summary.Routed, stats.applied...)
+		summary.Failed = append(summary.Failed, stats.failed...)
+		summary.Permanent = append(summary.Permanent, stats.permanent...)
+		summary.Transferred = append(summary.Transferred, stats.transferred...)
+		stats.mu.Unlock()
+	}
+	transferred := make(map[int]struct{})
+	for _, t := range summary.Transferred {
+		transferred[t.Number] = struct{}{}
+	}
+	for _, us := range [][]IssueUpdate{summary.Routed, updates} {
+		for _, update := range us {
+			// The unroutable label may look like a service label, like service/unknown.
+			if update.Unroutable {
+				continue
+			}
+			for _, l := range update.Labels {
+				if strings.HasPrefix(l, "service/") {
+					routed[update.Number] = struct{}{}
+				}
+			}
+		}
+	}
+	for _, update := range summary.Failed {
+		delete(routed, update.Number)
+	}
+	for _, issue := range issues {
+		if issue.IsPullRequest() || issue.GetState() != "open" {
+			continue
+		}
+		if _, ok := routed[issue.GetNumber()]; ok {
+			continue
+		}
+		if _, ok := transferred[issue.GetNumber()]; ok {
+			continue
+		}
+		hasService := false
+		for _, l := range issue.Labels {
+			if strings.HasPrefix(l.GetName(), "service/") || l.GetName() == labelForwardExempt || l.GetName() == labelForwardLinked {
+				hasService = true
+			}
+		}
+		if !hasService {
+			summary.NeedsReview = append(summary.NeedsReview, issue.GetNumber())
+		}
+	}
+	sort.Ints(summary.NeedsReview)
+	return summary
+}
+
+// Merge adds the summary of another part of the same run, like a page of a streamed backfill.
+func (s *RunSummary) Merge(other RunSummary) {
+	s.Updates = append(s.Updates, other.Updates...)
+	s.Routed = append(s.Routed, other.Routed...)
+	s.Failed = append(s.Failed, other.Failed...)
+	s.Permanent = append(s.Permanent, other.Permanent...)
+	s.NeedsReview = append(s.NeedsReview, other.NeedsReview...)
+	sort.Ints(s.NeedsReview)
+	s.Transferred = append(s.Transferred, other.Transferred...)
+}
+
+// NotifyConfig maps where run summaries are posted. Webhook URLs may reference environment
+// variables, e.g. $COMPUTE_CHAT_WEBHOOK, to keep them out of the file.
+type NotifyConfig struct {
+	// Default receives the overall summary of every run.
+	Default string `yaml:"default"`
+	// Channels receive the issues routed to each service label.
+	Channels map[string]string `yaml:"channels"`
+}
+
+// LoadNotifyConfig reads a notification config, expanding environment variables in URLs.
+func LoadNotifyConfig(path string) (NotifyConfig, error) {
+	var cfg NotifyConfig
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return cfg, fmt.Errorf("reading notify config: %w", err)
+	}
+	if err := yaml.UnmarshalStrict(b, &cfg); err != nil {
+		return cfg, fmt.Errorf("parsing notify config: %w", err)
+	}
+	cfg.Default = os.ExpandEnv(cfg.Default)
+	for label, url := range cfg.Channels {
+		cfg.Channels[label] = os.ExpandEnv(url)
+	}
+	return cfg, nil
+}
+
+// Messages renders the summary for each configured webhook. Runs that routed nothing and had
+// no failures only notify the default channel, and only if issues need review.
+func (c NotifyConfig) Messages(s RunSummary) map[string]string {
+	issueURL := func(n int) string { return fmt.Sprintf("https://github.com/%s/issues/%d", s.Repository, n) }
+	messages := make(map[string]string)
+
+	if c.Default != "" && (len(s.Routed) > 0 || len(s.Failed) > 0 || len(s.NeedsReview) > 0) {
+		var b strings.Builder
+		fmt.Fprintf(&b, "Issue labeler run on %s: %d issues routed, %d failures.", s.Repository, len(s.Routed), len(s.Failed))
+		permanent := make(map[int]struct{})
+		for _, u := range s.Permanent {
+			permanent[u.Number] = struct{}{}
+		}
+		for _, u := range s.Failed {
+			if _, ok := permanent[u.Number]; ok {
+				fmt.Fprintf(&b, "\nFailed permanently: %s", issueURL(u.Number))
+			} else {
+				fmt.Fprintf(&b, "\nFailed: %s", issueURL(u.Number))
+			}
+		}
+		if len(s.NeedsReview) > 0 {
+			fmt.Fprintf(&b, "\n%d issues need manual review:", len(s.NeedsReview))
+			for _, n := range s.NeedsReview {
+				fmt.Fprintf(&b, "\n%s", issueURL(n))
+			}
+		}
+		messages[c.Default] = b.String()
+	}
+
+	byLabel := make(map[string][]int)
+	for _, u := range s.Routed {
+		added, _ := diffLabels(u.OldLabels, u.Labels)
+		for _, l := range added {
+			if _, ok := c.Channels[l]; ok {
+				byLabel[l] = append(byLabel[l], u.Number)
+			}
+		}
+	}
+	labels := make([]string, 0, len(byLabel))
+	for l := range byLabel {
+		labels = append(labels, l)
+	}
+	sort.Strings(labels)
+	for _, l := range labels {
+		var b strings.Builder
+		fmt.Fprintf(&b, "%d new issues routed to %s in %s:", len(byLabel[l]), l, s.Repository)
+		for _, n := range byLabel[l] {
+			fmt.Fprintf(&b, "\n%s", issueURL(n))
+		}
+		url := c.Channels[l]
+		if existing, ok := messages[url]; ok {
+			messages[url] = existing + "\n\n" + b.String()
+		} else {
+			messages[url] = b.String()
+		}
+	}
+	return messages
+}
+
+// Notify posts the summary to every configured webhook.
+func Notify(ctx context.Context, cfg NotifyConfig, s RunSummary) error {
+	var errs []string
+	for url, text := range cfg.Messages(s) {
+		if err := postChatMessage(ctx, url, text); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("posting notifications: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// postChatMessage posts a plain text message to a Slack or Google Chat incoming webhook, which
+// both accept a {"text": ...} body.
+func postChatMessage(ctx context.Context, url, text string) error {
+	body, err := json.Marshal(map[string]string{"text": text})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		// The URL is the webhook's credential, so keep it out of errors and logs.
+		return fmt.Errorf("posting to webhook %s: request failed", req.URL.Host)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("posting to webhook %s: %s: %s", req.URL.Host, resp.Status, b)
+	}
+	return nil
+}
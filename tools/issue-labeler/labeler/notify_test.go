@@ -0,0 +1,243 @@
+package labeler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/google/go-github/v68/github"
+)
+
+func TestComputeRunSummary(t *testing.T) {
+	stats := &RunStats{}
+	stats.record(IssueUpdate{Number: 1, OldLabels: []string{}, Labels: []string{"forward/review", "service/service1"}}, nil)
+	stats.record(IssueUpdate{Number: 2, OldLabels: []string{}, Labels: []string{"forward/review", "service/service2"}}, errors.New("forbidden"))
+	issues := []*github.Issue{
+		{Number: github.Ptr(1), State: github.Ptr("open")},
+		{Number: github.Ptr(2), State: github.Ptr("open")},
+		{Number: github.Ptr(3), State: github.Ptr("open"), Labels: []*github.Label{{Name: github.Ptr("service/service3")}}},
+		{Number: github.Ptr(4), State: github.Ptr("open"), Labels: []*github.Label{{Name: github.Ptr("forward/exempt")}}},
+		{Number: github.Ptr(5), State: github.Ptr("closed")},
+		{Number: github.Ptr(6), State: github.Ptr("open"), PullRequestLinks: &github.PullRequestLinks{}},
+	}
+
+	got := ComputeRunSummary("owner/repo", issues, nil, stats)
+	want := RunSummary{
+		Repository:  "owner/repo",
+		Routed:      []IssueUpdate{{Number: 1, OldLabels: []string{}, Labels: []string{"forward/review", "service/service1"}}},
+		Failed:      []IssueUpdate{{Number: 2, OldLabels: []string{}, Labels: []string{"forward/review", "service/service2"}}},
+		NeedsReview: []int{2},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want %+v, got %+v", want, got)
+	}
+}
+
+func TestComputeRunSummaryUnroutable(t *testing.T) {
+	updates := []IssueUpdate{
+		{Number: 1, OldLabels: []string{}, Labels: []string{"forward/review", "service/service1"}},
+		{Number: 2, OldLabels: []string{}, Labels: []string{"service/unknown"}, Unroutable: true},
+	}
+	issues := []*github.Issue{
+		{Number: github.Ptr(1), State: github.Ptr("open")},
+		{Number: github.Ptr(2), State: github.Ptr("open")},
+	}
+
+	got := ComputeRunSummary("owner/repo", issues, updates, nil)
+	if want := []int{2}; !reflect.DeepEqual(got.NeedsReview, want) {
+		t.Errorf("NeedsReview = %v; want %v", got.NeedsReview, want)
+	}
+}
+
+func TestComputeRunSummaryTransferred(t *testing.T) {
+	stats := &RunStats{}
+	stats.recordTransfer(TransferredIssue{Number: 1, Destination: "owner/other", URL: "https://github.com/owner/other/issues/10"})
+	issues := []*github.Issue{
+		{Number: github.Ptr(1), State: github.Ptr("open")},
+		{Number: github.Ptr(2), State: github.Ptr("open")},
+	}
+
+	got := ComputeRunSummary("owner/repo", issues, nil, stats)
+	want := RunSummary{
+		Repository:  "owner/repo",
+		NeedsReview: []int{2},
+		Transferred: []TransferredIssue{{Number: 1, Destination: "owner/other", URL: "https://github.com/owner/other/issues/10"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want %+v, got %+v", want, got)
+	}
+
+	updates := []IssueUpdate{{Number: 1}, {Number: 2}}
+	if got, want := stats.WithoutTransferred(updates), updates[1:]; !reflect.DeepEqual(got, want) {
+		t.Errorf("WithoutTransferred() = %+v; want %+v", got, want)
+	}
+}
+
+func TestComputeRunSummaryDryRun(t *testing.T) {
+	updates := []IssueUpdate{
+		{Number: 1, OldLabels: []string{}, Labels: []string{"forward/review", "service/service1"}},
+		{Number: 2, OldLabels: []string{}, Labels: []string{"forward/review"}},
+	}
+	issues := []*github.Issue{
+		{Number: github.Ptr(1), State: github.Ptr("open")},
+		{Number: github.Ptr(2), State: github.Ptr("open")},
+		{Number: github.Ptr(3), State: github.Ptr("open")},
+	}
+
+	got := ComputeRunSummary("owner/repo", issues, updates, nil)
+	want := RunSummary{
+		Repository:  "owner/repo",
+		Updates:     updates,
+		NeedsReview: []int{2, 3},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want %+v, got %+v", want, got)
+	}
+}
+
+func TestRunSummaryMerge(t *testing.T) {
+	first := []IssueUpdate{{Number: 30, Labels: []string{"service/service1"}}}
+	second := []IssueUpdate{{Number: 10, Labels: []string{"service/service2"}}}
+	summary := RunSummary{Repository: "owner/repo"}
+	summary.Merge(RunSummary{Repository: "owner/repo", Updates: first, Routed: first, NeedsReview: []int{31}})
+	summary.Merge(RunSummary{
+		Repository:  "owner/repo",
+		Updates:     second,
+		Failed:      second,
+		NeedsReview: []int{11},
+		Transferred: []TransferredIssue{{Number: 12}},
+	})
+	want := RunSummary{
+		Repository:  "owner/repo",
+		Updates:     append(first, second...),
+		Routed:      first,
+		Failed:      second,
+		NeedsReview: []int{11, 31},
+		Transferred: []TransferredIssue{{Number: 12}},
+	}
+	if !reflect.DeepEqual(summary, want) {
+		t.Errorf("want %+v, got %+v", want, summary)
+	}
+}
+
+func TestNotifyConfigMessages(t *testing.T) {
+	summary := RunSummary{
+		Repository: "owner/repo",
+		Routed: []IssueUpdate{
+			{Number: 1, OldLabels: []string{}, Labels: []string{"service/service1"}},
+			{Number: 2, OldLabels: []string{}, Labels: []string{"service/service1", "service/service2"}},
+			{Number: 3, OldLabels: []string{"service/service2"}, Labels: []string{"service/service2", "service/service3"}},
+		},
+		NeedsReview: []int{4},
+	}
+
+	cases := map[string]struct {
+		cfg     NotifyConfig
+		summary RunSummary
+		want    map[string]string
+	}{
+		"default only": {
+			cfg:     NotifyConfig{Default: "https://chat/default"},
+			summary: summary,
+			want: map[string]string{
+				"https://chat/default": "Issue labeler run on owner/repo: 3 issues routed, 0 failures.\n" +
+					"1 issues need manual review:\nhttps://github.com/owner/repo/issues/4",
+			},
+		},
+		"per service channels": {
+			cfg: NotifyConfig{Channels: map[string]string{
+				"service/service1": "https://chat/one",
+				"service/service2": "https://chat/two",
+			}},
+			summary: summary,
+			want: map[string]string{
+				"https://chat/one": "2 new issues routed to service/service1 in owner/repo:\n" +
+					"https://github.com/owner/repo/issues/1\nhttps://github.com/owner/repo/issues/2",
+				"https://chat/two": "1 new issues routed to service/service2 in owner/repo:\n" +
+					"https://github.com/owner/repo/issues/2",
+			},
+		},
+		"shared channel": {
+			cfg: NotifyConfig{Channels: map[string]string{
+				"service/service1": "https://chat/shared",
+				"service/service3": "https://chat/shared",
+			}},
+			summary: summary,
+			want: map[string]string{
+				"https://chat/shared": "2 new issues routed to service/service1 in owner/repo:\n" +
+					"https://github.com/owner/repo/issues/1\nhttps://github.com/owner/repo/issues/2\n\n" +
+					"1 new issues routed to service/service3 in owner/repo:\n" +
+					"https://github.com/owner/repo/issues/3",
+			},
+		},
+		"failures": {
+			cfg: NotifyConfig{Default: "https://chat/default"},
+			summary: RunSummary{
+				Repository: "owner/repo",
+				Failed:     []IssueUpdate{{Number: 5}, {Number: 6}},
+				Permanent:  []IssueUpdate{{Number: 6}},
+			},
+			want: map[string]string{
+				"https://chat/default": "Issue labeler run on owner/repo: 0 issues routed, 2 failures.\n" +
+					"Failed: https://github.com/owner/repo/issues/5\n" +
+					"Failed permanently: https://github.com/owner/repo/issues/6",
+			},
+		},
+		"nothing to report": {
+			cfg:     NotifyConfig{Default: "https://chat/default"},
+			summary: RunSummary{Repository: "owner/repo"},
+			want:    map[string]string{},
+		},
+	}
+
+	for tn, tc := range cases {
+		tc := tc
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+			got := tc.cfg.Messages(tc.summary)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("want %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestNotify(t *testing.T) {
+	var mu sync.Mutex
+	var got []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct{ Text string }
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decoding request: %v", err)
+		}
+		mu.Lock()
+		got = append(got, body.Text)
+		mu.Unlock()
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusForbidden)
+		}
+	}))
+	defer srv.Close()
+
+	summary := RunSummary{
+		Repository: "owner/repo",
+		Routed:     []IssueUpdate{{Number: 1, Labels: []string{"service/service1"}}},
+	}
+	cfg := NotifyConfig{Default: srv.URL + "/ok"}
+	if err := Notify(context.Background(), cfg, summary); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+	if want := []string{"Issue labeler run on owner/repo: 1 issues routed, 0 failures."}; !reflect.DeepEqual(got, want) {
+		t.Errorf("want %q, got %q", want, got)
+	}
+
+	cfg = NotifyConfig{Default: srv.URL + "/fail"}
+	if err := Notify(context.Background(), cfg, summary); err == nil {
+		t.Errorf("Notify() to a failing webhook succeeded")
+	}
+}
@@ -0,0 +1,95 @@
+package labeler
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// OutputFormats are the formats WriteIssueUpdates accepts.
+var OutputFormats = []string{"json", "csv"}
+
+type issueUpdateRecord struct {
+	Repository    string       `json:"repository"`
+	Number        int          `json:"number"`
+	URL           string       `json:"url"`
+	OldLabels     []string     `json:"old_labels"`
+	NewLabels     []string     `json:"new_labels"`
+	LabelsAdded   []string     `json:"labels_added"`
+	LabelsRemoved []string     `json:"labels_removed"`
+	Matches       []LabelMatch `json:"matches"`
+}
+
+func newIssueUpdateRecord(repository string, update IssueUpdate) issueUpdateRecord {
+	added, removed := diffLabels(update.OldLabels, update.Labels)
+	r := issueUpdateRecord{
+		Repository:    repository,
+		Number:        update.Number,
+		URL:           fmt.Sprintf("https://github.com/%s/issues/%d", repository, update.Number),
+		OldLabels:     update.OldLabels,
+		NewLabels:     update.Labels,
+		LabelsAdded:   added,
+		LabelsRemoved: removed,
+		Matches:       update.Matches,
+	}
+	// Emit empty lists rather than null so consumers don't need to special-case them.
+	for _, l := range []*[]string{&r.OldLabels, &r.NewLabels, &r.LabelsAdded, &r.LabelsRemoved} {
+		if *l == nil {
+			*l = []string{}
+		}
+	}
+	if r.Matches == nil {
+		r.Matches = []LabelMatch{}
+	}
+	return r
+}
+
+// RepositoryUpdates are the proposed updates for one repository.
+type RepositoryUpdates struct {
+	Repository string
+	Updates    []IssueUpdate
+}
+
+// WriteIssueUpdates writes the proposed updates to w as a JSON array or as CSV with one row
+// per issue. List columns in CSV are joined with ";", and each match is written as
+// "label=resource (section: pattern)".
+func WriteIssueUpdates(w io.Writer, format string, repos []RepositoryUpdates) error {
+	records := []issueUpdateRecord{}
+	for _, repo := range repos {
+		for _, update := range repo.Updates {
+			records = append(records, newIssueUpdateRecord(repo.Repository, update))
+		}
+	}
+
+	switch format {
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(records)
+	case "csv":
+		cw := csv.NewWriter(w)
+		cw.Write([]string{"repository", "number", "url", "old_labels", "new_labels", "labels_added", "labels_removed", "matches"})
+		for _, r := range records {
+			var matches []string
+			for _, m := range r.Matches {
+				matches = append(matches, fmt.Sprintf("%s=%s (%s: %s)", m.Label, m.Resource, m.Section, m.Pattern))
+			}
+			cw.Write([]string{
+				r.Repository,
+				strconv.Itoa(r.Number),
+				r.URL,
+				strings.Join(r.OldLabels, ";"),
+				strings.Join(r.NewLabels, ";"),
+				strings.Join(r.LabelsAdded, ";"),
+				strings.Join(r.LabelsRemoved, ";"),
+				strings.Join(matches, ";"),
+			})
+		}
+		cw.Flush()
+		return cw.Error()
+	}
+	return fmt.Errorf("invalid output format %q, must be one of %s", format, strings.Join(OutputFormats, ", "))
+}
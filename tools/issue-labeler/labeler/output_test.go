@@ -0,0 +1,82 @@
+package labeler
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteIssueUpdates(t *testing.T) {
+	updates := []IssueUpdate{
+		{
+			Number:    1,
+			Labels:    []string{"bug", "forward/review", "service/service1"},
+			OldLabels: []string{"bug"},
+			Matches: []LabelMatch{
+				{Label: "service/service1", Resource: "google_service1_resource1", Section: SectionAffectedResources, Pattern: "google_service1_.*"},
+			},
+		},
+	}
+
+	cases := map[string]struct {
+		format  string
+		want    string
+		wantErr bool
+	}{
+		"json": {
+			format: "json",
+			want: `[
+  {
+    "repository": "owner/repo",
+    "number": 1,
+    "url": "https://github.com/owner/repo/issues/1",
+    "old_labels": [
+      "bug"
+    ],
+    "new_labels": [
+      "bug",
+      "forward/review",
+      "service/service1"
+    ],
+    "labels_added": [
+      "forward/review",
+      "service/service1"
+    ],
+    "labels_removed": [],
+    "matches": [
+      {
+        "label": "service/service1",
+        "resource": "google_service1_resource1",
+        "section": "affected resources",
+        "pattern": "google_service1_.*"
+      }
+    ]
+  }
+]
+`,
+		},
+		"csv": {
+			format: "csv",
+			want: "repository,number,url,old_labels,new_labels,labels_added,labels_removed,matches\n" +
+				"owner/repo,1,https://github.com/owner/repo/issues/1,bug,bug;forward/review;service/service1,forward/review;service/service1,,service/service1=google_service1_resource1 (affected resources: google_service1_.*)\n",
+		},
+		"unknown format": {
+			format:  "yaml",
+			wantErr: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		tc := tc
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+			var buf bytes.Buffer
+			err := WriteIssueUpdates(&buf, tc.format, []RepositoryUpdates{{Repository: "owner/repo", Updates: updates}})
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("WriteIssueUpdates() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if got := buf.String(); !tc.wantErr && got != tc.want {
+				t.Errorf("want\n%s\ngot\n%s", tc.want, got)
+			}
+		})
+	}
+}
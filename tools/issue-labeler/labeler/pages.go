@@ -0,0 +1,150 @@
+package labeler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+
+	"github.com/google/go-github/v68/github"
+)
+
+// DefaultPageConcurrency is how many pages of issues are fetched at once when the listing
+// allows it.
+const DefaultPageConcurrency = 4
+
+type pageConcurrencyKey struct{}
+
+// WithPageConcurrency returns a context whose issue listings fetch up to n pages at once. n of
+// 1 fetches pages one after the other.
+func WithPageConcurrency(ctx context.Context, n int) context.Context {
+	return context.WithValue(ctx, pageConcurrencyKey{}, n)
+}
+
+func pageConcurrencyFrom(ctx context.Context) int {
+	if n, ok := ctx.Value(pageConcurrencyKey{}).(int); ok && n > 0 {
+		return n
+	}
+	return DefaultPageConcurrency
+}
+
+// pageLinks returns the URLs of the second through last pages of a listing, built from the
+// first page's next link, when its Link header gives the last page number. It returns nil when
+// there's no last link or the links aren't page-numbered, like listings paginated with cursors,
+// whose pages can only be followed in order through their next links.
+func pageLinks(resp *http.Response) []string {
+	next, last := parseNextLink(resp), parseLink(resp, "last")
+	if next == "" || last == "" {
+		return nil
+	}
+	nextURL, err := url.Parse(next)
+	if err != nil || nextURL.Query().Get("page") != "2" {
+		return nil
+	}
+	lastURL, err := url.Parse(last)
+	if err != nil {
+		return nil
+	}
+	lastPage, err := strconv.Atoi(lastURL.Query().Get("page"))
+	if err != nil {
+		return nil
+	}
+
+	var links []string
+	for page := 2; page <= lastPage; page++ {
+		q := nextURL.Query()
+		q.Set("page", strconv.Itoa(page))
+		u := *nextURL
+		u.RawQuery = q.Encode()
+		links = append(links, u.String())
+	}
+	return links
+}
+
+// getIssuesPage fetches a page of issues from a URL given by a Link header.
+func getIssuesPage(ctx context.Context, client *github.Client, link string) ([]*github.Issue, *github.Response, error) {
+	req, err := client.NewRequest("GET", link, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github.raw+json")
+	var issues []*github.Issue
+	resp, err := client.Do(ctx, req, &issues)
+	return issues, resp, err
+}
+
+// pageNumbersRejected reports whether err is GitHub refusing a page-numbered link, which it does
+// deep into large listings.
+func pageNumbersRejected(err error) bool {
+	var ae *APIError
+	return errors.As(err, &ae) && ae.Reason == ReasonInvalid
+}
+
+// fetchIssuePages fetches the pages at links, which start at page 2, with at most concurrency
+// requests in flight, and returns them in order along with the next link of the last one. On
+// failure the remaining requests are cancelled and the pages before the first failed one are
+// returned with the error. Once the context's shutdown is requested no more pages are started,
+// but those in flight finish, and ErrInterrupted is returned.
+func fetchIssuePages(ctx context.Context, client *github.Client, links []string, concurrency int) ([][]*github.Issue, string, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	pages := make([][]*github.Issue, len(links))
+	nexts := make([]string, len(links))
+	errs := make([]error, len(links))
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+	sem := make(chan struct{}, concurrency)
+	for i, link := range links {
+		// Pages start in order, so a failure or shutdown leaves as many earlier pages as possible.
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if errs[i] = ctx.Err(); errs[i] != nil {
+				return
+			}
+			if shuttingDown(ctx) {
+				errs[i] = ErrInterrupted
+				return
+			}
+			var resp *github.Response
+			pages[i], resp, errs[i] = fetchIssuesPage(ctx, i+2, func(ctx context.Context) ([]*github.Issue, *github.Response, error) {
+				return getIssuesPage(ctx, client, link)
+			})
+			if errs[i] == nil {
+				nexts[i] = parseNextLink(resp.Response)
+			}
+			// Requests cancelled because of another page's failure don't hide its error, and a
+			// shutdown lets the requests in flight finish.
+			if errs[i] != nil && !errors.Is(errs[i], context.Canceled) && !errors.Is(errs[i], ErrInterrupted) {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = errs[i]
+				}
+				mu.Unlock()
+				cancel()
+			}
+		}()
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			if i == 0 {
+				return nil, "", firstErr
+			}
+			return pages[:i], nexts[i-1], firstErr
+		}
+	}
+	return pages, nexts[len(nexts)-1], nil
+}
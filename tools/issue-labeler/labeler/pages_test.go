@@ -0,0 +1,168 @@
+package labeler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v68/github"
+)
+
+func TestPageLinks(t *testing.T) {
+	cases := map[string]struct {
+		link string
+		want []string
+	}{
+		"numbered pages": {
+			link: `<https://api.github.com/repositories/1/issues?page=2&per_page=100>; rel="next", <https://api.github.com/repositories/1/issues?page=4&per_page=100>; rel="last"`,
+			want: []string{
+				"https://api.github.com/repositories/1/issues?page=2&per_page=100",
+				"https://api.github.com/repositories/1/issues?page=3&per_page=100",
+				"https://api.github.com/repositories/1/issues?page=4&per_page=100",
+			},
+		},
+		"no last link": {
+			link: `<https://api.github.com/repositories/1/issues?after=abc&per_page=100>; rel="next"`,
+		},
+		"single page": {
+			link: "",
+		},
+		"last link without page number": {
+			link: `<https://api.github.com/repositories/1/issues?page=2>; rel="next", <https://api.github.com/repositories/1/issues?after=xyz>; rel="last"`,
+		},
+		"cursor next link": {
+			link: `<https://api.github.com/repositories/1/issues?after=abc>; rel="next", <https://api.github.com/repositories/1/issues?page=4>; rel="last"`,
+		},
+	}
+
+	for tn, tc := range cases {
+		tc := tc
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+			resp := &http.Response{Header: http.Header{}}
+			if tc.link != "" {
+				resp.Header.Set("Link", tc.link)
+			}
+			if got := pageLinks(resp); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("pageLinks() = %v; want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFetchIssuePages(t *testing.T) {
+	var inFlight, maxInFlight atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			m := maxInFlight.Load()
+			if n <= m || maxInFlight.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		switch fail := r.URL.Query().Get("fail"); {
+		case page == 5 && fail == "server":
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		case page == 5 && fail == "page":
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			fmt.Fprint(w, `{"message": "Pagination with the page parameter is not supported for large datasets, please use cursor based pagination (after/before)"}`)
+			return
+		}
+		w.Header().Set("Link", fmt.Sprintf(`<%s/issues?after=%d>; rel="next"`, "http://"+r.Host, page))
+		// Later pages answer first, so results arrive out of order.
+		time.Sleep(time.Duration(10-page) * time.Millisecond)
+		fmt.Fprintf(w, `[{"number": %d}]`, page*100)
+	}))
+	defer srv.Close()
+
+	links := func(query string) []string {
+		var links []string
+		for page := 2; page <= 7; page++ {
+			links = append(links, fmt.Sprintf("%s/issues?page=%d%s", srv.URL, page, query))
+		}
+		return links
+	}
+	client := github.NewClient(srv.Client())
+
+	pages, next, err := fetchIssuePages(context.Background(), client, links(""), 2)
+	if err != nil {
+		t.Fatalf("fetchIssuePages() error = %v", err)
+	}
+	if want := srv.URL + "/issues?after=7"; next != want {
+		t.Errorf("fetchIssuePages() next = %q; want %q", next, want)
+	}
+	var got []int
+	for _, issues := range pages {
+		for _, issue := range issues {
+			got = append(got, issue.GetNumber())
+		}
+	}
+	if want := []int{200, 300, 400, 500, 600, 700}; !reflect.DeepEqual(got, want) {
+		t.Errorf("fetchIssuePages() = %v; want %v", got, want)
+	}
+	if m := maxInFlight.Load(); m > 2 {
+		t.Errorf("%d requests in flight; want at most 2", m)
+	}
+
+	pages, _, err = fetchIssuePages(context.Background(), client, links("&fail=server"), 2)
+	if err == nil {
+		t.Fatalf("fetchIssuePages() with a failing page succeeded")
+	}
+	if len(pages) > 3 {
+		t.Errorf("fetchIssuePages() returned %d pages; want at most the 3 before the failed one", len(pages))
+	}
+	if pageNumbersRejected(err) {
+		t.Errorf("pageNumbersRejected(%v) = true; want false", err)
+	}
+
+	// A rejected page number leaves the pages before it to be followed through next links.
+	pages, next, err = fetchIssuePages(context.Background(), client, links("&fail=page"), 1)
+	if !pageNumbersRejected(err) {
+		t.Fatalf("fetchIssuePages() error = %v; want page numbers rejected", err)
+	}
+	if len(pages) != 3 {
+		t.Errorf("fetchIssuePages() returned %d pages; want the 3 before the rejected one", len(pages))
+	}
+	if want := srv.URL + "/issues?after=4"; next != want {
+		t.Errorf("fetchIssuePages() next = %q; want %q", next, want)
+	}
+}
+
+func TestFetchIssuePagesShutdown(t *testing.T) {
+	done := make(chan struct{})
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The shutdown is requested while the first page is in flight.
+		if requests.Add(1) == 1 {
+			close(done)
+		}
+		fmt.Fprint(w, `[{"number": 1}]`)
+	}))
+	defer srv.Close()
+
+	var links []string
+	for page := 2; page <= 5; page++ {
+		links = append(links, fmt.Sprintf("%s/issues?page=%d", srv.URL, page))
+	}
+	ctx := WithShutdown(context.Background(), done)
+	pages, _, err := fetchIssuePages(ctx, github.NewClient(srv.Client()), links, 1)
+	if !errors.Is(err, ErrInterrupted) {
+		t.Fatalf("fetchIssuePages() error = %v; want %v", err, ErrInterrupted)
+	}
+	if len(pages) != 1 {
+		t.Errorf("fetchIssuePages() returned %d pages; want the 1 in flight when shutdown was requested", len(pages))
+	}
+	if n := requests.Load(); n != 1 {
+		t.Errorf("%d pages requested; want 1", n)
+	}
+}
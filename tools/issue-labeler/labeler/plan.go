@@ -0,0 +1,125 @@
+package labeler
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// PlanVersion is the plan file format written by Plan.Save. LoadPlan rejects other versions
+// rather than guess at what they mean.
+const PlanVersion = 1
+
+// Plan is a set of proposed label updates saved for review, so that exactly the updates that
+// were reviewed are applied later.
+type Plan struct {
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	// Updates are in the same format as --output=json.
+	Updates []issueUpdateRecord `json:"updates"`
+}
+
+// NewPlan returns a plan of the proposed updates for each repository.
+func NewPlan(createdAt time.Time, repos []RepositoryUpdates) *Plan {
+	p := &Plan{Version: PlanVersion, CreatedAt: createdAt, Updates: []issueUpdateRecord{}}
+	for _, repo := range repos {
+		for _, update := range repo.Updates {
+			p.Updates = append(p.Updates, newIssueUpdateRecord(repo.Repository, update))
+		}
+	}
+	return p
+}
+
+// LoadPlan reads a plan written by Save.
+func LoadPlan(path string) (*Plan, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading plan: %w", err)
+	}
+	var p Plan
+	if err := json.Unmarshal(b, &p); err != nil {
+		return nil, fmt.Errorf("parsing plan: %w", err)
+	}
+	if p.Version != PlanVersion {
+		return nil, fmt.Errorf("unsupported plan version %d, must be %d", p.Version, PlanVersion)
+	}
+	for i, r := range p.Updates {
+		if _, _, err := splitRepository(r.Repository); err != nil {
+			return nil, fmt.Errorf("plan update %d: invalid repository: %w", i, err)
+		}
+		if r.Number <= 0 {
+			return nil, fmt.Errorf("plan update %d: invalid issue number %d", i, r.Number)
+		}
+		// Only the old and new labels are applied, so recompute the rest from them in case the
+		// file was edited during review.
+		p.Updates[i] = newIssueUpdateRecord(r.Repository, IssueUpdate{Number: r.Number, Labels: r.NewLabels, OldLabels: r.OldLabels, Matches: r.Matches})
+	}
+	return &p, nil
+}
+
+// Save writes the plan to path.
+func (p *Plan) Save(path string) error {
+	if err := writeJSONAtomic(path, p); err != nil {
+		return fmt.Errorf("writing plan: %w", err)
+	}
+	return nil
+}
+
+// Repositories returns the planned updates grouped by repository, in the order the
+// repositories first appear in the plan.
+func (p *Plan) Repositories() []RepositoryUpdates {
+	var repos []RepositoryUpdates
+	index := make(map[string]int)
+	for _, r := range p.Updates {
+		i, ok := index[r.Repository]
+		if !ok {
+			i = len(repos)
+			index[r.Repository] = i
+			repos = append(repos, RepositoryUpdates{Repository: r.Repository})
+		}
+		repos[i].Updates = append(repos[i].Updates, IssueUpdate{
+			Number:    r.Number,
+			Labels:    r.NewLabels,
+			OldLabels: r.OldLabels,
+			Matches:   r.Matches,
+		})
+	}
+	return repos
+}
+
+// WriteSummary writes the label changes in the plan for review, one issue per line.
+func (p *Plan) WriteSummary(w io.Writer) error {
+	repos := make(map[string]struct{})
+	for _, r := range p.Updates {
+		repos[r.Repository] = struct{}{}
+		var changes []string
+		for _, l := range r.LabelsAdded {
+			changes = append(changes, "+"+l)
+		}
+		for _, l := range r.LabelsRemoved {
+			changes = append(changes, "-"+l)
+		}
+		if _, err := fmt.Fprintf(w, "%s#%d: %s\n", r.Repository, r.Number, strings.Join(changes, " ")); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(w, "Plan: %d issues to update in %d repositories.\n", len(p.Updates), len(repos))
+	return err
+}
+
+// PlannedAgainst reports whether an issue with the given labels still has the labels the update
+// was planned against. Applying an update planned against other labels would undo whatever
+// changed them since.
+func PlannedAgainst(update IssueUpdate, labels []string) bool {
+	added, removed := diffLabels(labels, update.OldLabels)
+	return len(added) == 0 && len(removed) == 0
+}
+
+// AppliedTo reports whether an issue with the given labels already has the update's labels.
+func AppliedTo(update IssueUpdate, labels []string) bool {
+	added, removed := diffLabels(labels, update.Labels)
+	return len(added) == 0 && len(removed) == 0
+}
@@ -0,0 +1,91 @@
+package labeler
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestPlanRoundTrip(t *testing.T) {
+	repos := []RepositoryUpdates{
+		{Repository: "owner/repo", Updates: []IssueUpdate{
+			{Number: 1, OldLabels: []string{"bug"}, Labels: []string{"bug", "service/service1"}, Matches: []LabelMatch{}},
+			{Number: 2, OldLabels: []string{"forward/review"}, Labels: []string{"service/service2"}, Matches: []LabelMatch{}},
+		}},
+		{Repository: "owner/other", Updates: []IssueUpdate{
+			{Number: 3, OldLabels: []string{}, Labels: []string{"service/service1"}, Matches: []LabelMatch{
+				{Label: "service/service1", Resource: "google_service1_resource1", Section: SectionAffectedResources, Pattern: "google_service1_.*"},
+			}},
+		}},
+		{Repository: "owner/empty"},
+	}
+	path := filepath.Join(t.TempDir(), "plan.json")
+	if err := NewPlan(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC), repos).Save(path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	plan, err := LoadPlan(path)
+	if err != nil {
+		t.Fatalf("LoadPlan() error = %v", err)
+	}
+	if got, want := plan.Repositories(), repos[:2]; !reflect.DeepEqual(got, want) {
+		t.Errorf("Repositories() = %+v; want %+v", got, want)
+	}
+
+	var b bytes.Buffer
+	if err := plan.WriteSummary(&b); err != nil {
+		t.Fatalf("WriteSummary() error = %v", err)
+	}
+	want := "owner/repo#1: +service/service1\n" +
+		"owner/repo#2: +service/service2 -forward/review\n" +
+		"owner/other#3: +service/service1\n" +
+		"Plan: 3 issues to update in 2 repositories.\n"
+	if got := b.String(); got != want {
+		t.Errorf("WriteSummary() = %q; want %q", got, want)
+	}
+}
+
+func TestLoadPlanInvalid(t *testing.T) {
+	cases := map[string]string{
+		"version":    `{"version": 2, "updates": []}`,
+		"repository": `{"version": 1, "updates": [{"repository": "repo", "number": 1}]}`,
+		"number":     `{"version": 1, "updates": [{"repository": "owner/repo"}]}`,
+		"json":       `{"version": 1,`,
+	}
+	for tn, content := range cases {
+		t.Run(tn, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "plan.json")
+			if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := LoadPlan(path); err == nil {
+				t.Errorf("LoadPlan() succeeded; want an error")
+			}
+		})
+	}
+}
+
+func TestPlannedAgainst(t *testing.T) {
+	update := IssueUpdate{Number: 1, OldLabels: []string{"bug", "forward/review"}, Labels: []string{"bug", "service/service1"}}
+	cases := map[string]struct {
+		labels []string
+		want   bool
+	}{
+		"unchanged":       {labels: []string{"forward/review", "bug"}, want: true},
+		"label added":     {labels: []string{"bug", "forward/review", "priority/p1"}, want: false},
+		"label removed":   {labels: []string{"bug"}, want: false},
+		"already applied": {labels: []string{"bug", "service/service1"}, want: false},
+	}
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			if got := PlannedAgainst(update, tc.labels); got != tc.want {
+				t.Errorf("PlannedAgainst(%v) = %v; want %v", tc.labels, got, tc.want)
+			}
+			if got, want := AppliedTo(update, tc.labels), tn == "already applied"; got != want {
+				t.Errorf("AppliedTo(%v) = %v; want %v", tc.labels, got, want)
+			}
+		})
+	}
+}
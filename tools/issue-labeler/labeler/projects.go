@@ -0,0 +1,236 @@
+package labeler
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/google/go-github/v68/github"
+)
+
+const (
+	projectStatusField  = "Status"
+	projectStatusTriage = "Triage"
+)
+
+const projectQuery = `query($org: String!, $number: Int!) {
+  organization(login: $org) {
+    projectV2(number: $number) {
+      id
+      field(name: "` + projectStatusField + `") {
+        ... on ProjectV2SingleSelectField {
+          id
+          options { id name }
+        }
+      }
+    }
+  }
+}`
+
+const addProjectItemMutation = `mutation($project: ID!, $content: ID!) {
+  addProjectV2ItemById(input: {projectId: $project, contentId: $content}) {
+    item { id }
+  }
+}`
+
+const setProjectStatusMutation = `mutation($project: ID!, $item: ID!, $field: ID!, $option: String!) {
+  updateProjectV2ItemFieldValue(input: {projectId: $project, itemId: $item, fieldId: $field, value: {singleSelectOptionId: $option}}) {
+    projectV2Item { id }
+  }
+}`
+
+// ProjectItem is an issue that should be added to a team's Projects v2 board.
+type ProjectItem struct {
+	Project string // "org/number"
+	Number  int
+	NodeID  string
+}
+
+type projectInfo struct {
+	ID            string
+	StatusFieldID string
+	TriageID      string
+}
+
+// BuildLabelProjects returns a map of service label to the Projects v2 board ("org/number") owning it.
+func BuildLabelProjects(teamsYaml []byte) (map[string]string, error) {
+	enrolledTeams, err := ParseEnrolledTeams(teamsYaml)
+	if err != nil {
+		return nil, err
+	}
+
+	labelProjects := make(map[string]string)
+	for label, data := range enrolledTeams {
+		if data.Project == "" {
+			continue
+		}
+		if _, _, err := splitProject(data.Project); err != nil {
+			return nil, fmt.Errorf("label %s: %w", label, err)
+		}
+		labelProjects[label] = data.Project
+	}
+	return labelProjects, nil
+}
+
+// ComputeProjectItems returns the board items needed for labels newly added by the given updates.
+func ComputeProjectItems(issueUpdates []IssueUpdate, labelProjects map[string]string) []ProjectItem {
+	var items []ProjectItem
+	for _, update := range issueUpdates {
+		oldLabels := make(map[string]struct{})
+		for _, label := range update.OldLabels {
+			oldLabels[label] = struct{}{}
+		}
+
+		projects := make(map[string]struct{})
+		for _, label := range update.Labels {
+			if _, ok := oldLabels[label]; ok {
+				continue
+			}
+			if project, ok := labelProjects[label]; ok {
+				projects[project] = struct{}{}
+			}
+		}
+
+		var sorted []string
+		for project := range projects {
+			sorted = append(sorted, project)
+		}
+		sort.Strings(sorted)
+		for _, project := range sorted {
+			items = append(items, ProjectItem{
+				Project: project,
+				Number:  update.Number,
+				NodeID:  update.NodeID,
+			})
+		}
+	}
+	return items
+}
+
+// AddProjectItems adds each item to its project board and sets its status to Triage.
+func AddProjectItems(ctx context.Context, repository string, items []ProjectItem, dryRun bool) error {
+	client := newGitHubClient()
+	projects := make(map[string]projectInfo)
+	failed := 0
+
+	for _, item := range items {
+		logger := slog.With("repo", repository, "number", item.Number, "project", item.Project)
+		if dryRun {
+			logger.Info("would add issue to project")
+			continue
+		}
+
+		info, ok := projects[item.Project]
+		if !ok {
+			var err error
+			info, err = getProjectInfo(ctx, client, item.Project)
+			if err != nil {
+				return fmt.Errorf("looking up project %s: %w", item.Project, err)
+			}
+			projects[item.Project] = info
+		}
+
+		if err := addProjectItem(ctx, client, info, item.NodeID); err != nil {
+			logger.Error("adding issue to project failed", "error", err)
+			failed++
+			continue
+		}
+		logger.Info("added issue to project")
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to add %d / %d issues to projects", failed, len(items))
+	}
+	return nil
+}
+
+func getProjectInfo(ctx context.Context, client *github.Client, project string) (projectInfo, error) {
+	org, number, err := splitProject(project)
+	if err != nil {
+		return projectInfo{}, err
+	}
+
+	var data struct {
+		Organization struct {
+			ProjectV2 *struct {
+				ID    string `json:"id"`
+				Field *struct {
+					ID      string `json:"id"`
+					Options []struct {
+						ID   string `json:"id"`
+						Name string `json:"name"`
+					} `json:"options"`
+				} `json:"field"`
+			} `json:"projectV2"`
+		} `json:"organization"`
+	}
+	if err := doGraphQL(ctx, client, projectQuery, map[string]any{"org": org, "number": number}, &data); err != nil {
+		return projectInfo{}, err
+	}
+
+	p := data.Organization.ProjectV2
+	if p == nil {
+		return projectInfo{}, fmt.Errorf("project not found")
+	}
+	if p.Field == nil {
+		return projectInfo{}, fmt.Errorf("project has no single select %q field", projectStatusField)
+	}
+	info := projectInfo{ID: p.ID, StatusFieldID: p.Field.ID}
+	for _, option := range p.Field.Options {
+		if option.Name == projectStatusTriage {
+			info.TriageID = option.ID
+		}
+	}
+	if info.TriageID == "" {
+		return projectInfo{}, fmt.Errorf("%q field has no %q option", projectStatusField, projectStatusTriage)
+	}
+	return info, nil
+}
+
+func addProjectItem(ctx context.Context, client *github.Client, info projectInfo, contentID string) error {
+	if contentID == "" {
+		return fmt.Errorf("missing issue node id")
+	}
+
+	var added struct {
+		AddProjectV2ItemById struct {
+			Item struct {
+				ID string `json:"id"`
+			} `json:"item"`
+		} `json:"addProjectV2ItemById"`
+	}
+	err := doGraphQL(ctx, client, addProjectItemMutation, map[string]any{
+		"project": info.ID,
+		"content": contentID,
+	}, &added)
+	if err != nil {
+		return fmt.Errorf("adding item: %w", err)
+	}
+
+	err = doGraphQL(ctx, client, setProjectStatusMutation, map[string]any{
+		"project": info.ID,
+		"item":    added.AddProjectV2ItemById.Item.ID,
+		"field":   info.StatusFieldID,
+		"option":  info.TriageID,
+	}, nil)
+	if err != nil {
+		return fmt.Errorf("setting status: %w", err)
+	}
+	return nil
+}
+
+// splitProject parses a project reference of the form "org/number".
+func splitProject(project string) (string, int, error) {
+	org, num, ok := strings.Cut(project, "/")
+	if !ok || org == "" {
+		return "", 0, fmt.Errorf("unexpected project format %s", project)
+	}
+	number, err := strconv.Atoi(num)
+	if err != nil {
+		return "", 0, fmt.Errorf("unexpected project format %s: %w", project, err)
+	}
+	return org, number, nil
+}
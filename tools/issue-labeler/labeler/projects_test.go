@@ -0,0 +1,105 @@
+package labeler
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBuildLabelProjects(t *testing.T) {
+	cases := map[string]struct {
+		yaml    []byte
+		want    map[string]string
+		wantErr bool
+	}{
+		"no projects": {
+			yaml: []byte(`
+service/service1:
+  resources:
+  - google_service1_.*`),
+			want: map[string]string{},
+		},
+		"project configured": {
+			yaml: []byte(`
+service/service1:
+  project: GoogleCloudPlatform/12
+  resources:
+  - google_service1_.*
+service/service2:
+  resources:
+  - google_service2_.*`),
+			want: map[string]string{"service/service1": "GoogleCloudPlatform/12"},
+		},
+		"invalid project": {
+			yaml: []byte(`
+service/service1:
+  project: GoogleCloudPlatform
+  resources:
+  - google_service1_.*`),
+			wantErr: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		tc := tc
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+			got, err := BuildLabelProjects(tc.yaml)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("BuildLabelProjects() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if !tc.wantErr && !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("want %v; got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestComputeProjectItems(t *testing.T) {
+	labelProjects := map[string]string{
+		"service/service1": "org/1",
+		"service/service2": "org/2",
+		"service/service3": "org/1",
+	}
+	cases := map[string]struct {
+		issueUpdates []IssueUpdate
+		want         []ProjectItem
+	}{
+		"no updates": {
+			issueUpdates: []IssueUpdate{},
+		},
+		"new label with project": {
+			issueUpdates: []IssueUpdate{
+				{Number: 1, NodeID: "I_1", Labels: []string{"forward/review", "service/service1"}},
+			},
+			want: []ProjectItem{{Project: "org/1", Number: 1, NodeID: "I_1"}},
+		},
+		"existing label is skipped": {
+			issueUpdates: []IssueUpdate{
+				{Number: 1, NodeID: "I_1", Labels: []string{"forward/review", "service/service1", "service/service2"}, OldLabels: []string{"service/service1"}},
+			},
+			want: []ProjectItem{{Project: "org/2", Number: 1, NodeID: "I_1"}},
+		},
+		"shared project is deduplicated": {
+			issueUpdates: []IssueUpdate{
+				{Number: 1, NodeID: "I_1", Labels: []string{"service/service1", "service/service3"}},
+			},
+			want: []ProjectItem{{Project: "org/1", Number: 1, NodeID: "I_1"}},
+		},
+		"label without project": {
+			issueUpdates: []IssueUpdate{
+				{Number: 1, NodeID: "I_1", Labels: []string{"service/other"}},
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		tc := tc
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+			got := ComputeProjectItems(tc.issueUpdates, labelProjects)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("want %v; got %v", tc.want, got)
+			}
+		})
+	}
+}
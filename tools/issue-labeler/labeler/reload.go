@@ -0,0 +1,106 @@
+package labeler
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ReadConfig reads an enrolled teams config from a local path or a gs://bucket/object.
+func ReadConfig(ctx context.Context, src string) ([]byte, error) {
+	if rest, ok := strings.CutPrefix(src, "gs://"); ok {
+		bucket, object, _ := strings.Cut(rest, "/")
+		body, err := downloadGCSObject(ctx, bucket, object)
+		if err != nil {
+			return nil, fmt.Errorf("reading config: %w", err)
+		}
+		defer body.Close()
+		b, err := io.ReadAll(body)
+		if err != nil {
+			return nil, fmt.Errorf("reading config: %w", err)
+		}
+		return b, nil
+	}
+	b, err := os.ReadFile(src)
+	if err != nil {
+		return nil, fmt.Errorf("reading config: %w", err)
+	}
+	return b, nil
+}
+
+// RuleDiff lists the rules added and removed by a config change, each as "label: pattern".
+type RuleDiff struct {
+	Added   []string
+	Removed []string
+}
+
+// Empty reports whether the rules are unchanged.
+func (d RuleDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0
+}
+
+// DiffRules compares two rule sets.
+func DiffRules(oldRules, newRules []RegexpLabel) RuleDiff {
+	key := func(rl RegexpLabel) string {
+		k := rl.Label + ": " + strings.TrimSuffix(strings.TrimPrefix(rl.Regexp.String(), "^"), "$")
+		if len(rl.Sections) > 0 {
+			k += " (" + strings.Join(rl.Sections, ", ") + ")"
+		}
+		return k
+	}
+	oldSet := make(map[string]struct{}, len(oldRules))
+	for _, rl := range oldRules {
+		oldSet[key(rl)] = struct{}{}
+	}
+	newSet := make(map[string]struct{}, len(newRules))
+	for _, rl := range newRules {
+		newSet[key(rl)] = struct{}{}
+	}
+	var diff RuleDiff
+	for k := range newSet {
+		if _, ok := oldSet[k]; !ok {
+			diff.Added = append(diff.Added, k)
+		}
+	}
+	for k := range oldSet {
+		if _, ok := newSet[k]; !ok {
+			diff.Removed = append(diff.Removed, k)
+		}
+	}
+	sort.Strings(diff.Added)
+	sort.Strings(diff.Removed)
+	return diff
+}
+
+// WatchConfig polls the config at src every interval until ctx is done, calling reload with
+// the new contents whenever they change. Read and reload errors are logged and the watch goes
+// on, so a bad push leaves the current rules in place until a fixed config replaces it.
+func WatchConfig(ctx context.Context, src string, interval time.Duration, current []byte, reload func([]byte) error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		b, err := ReadConfig(ctx, src)
+		if err != nil {
+			slog.Error("polling config failed", "config", src, "error", err)
+			continue
+		}
+		if bytes.Equal(b, current) {
+			continue
+		}
+		current = b
+		if err := reload(b); err != nil {
+			slog.Error("reloading config failed, keeping current rules", "config", src, "error", err)
+		}
+	}
+}
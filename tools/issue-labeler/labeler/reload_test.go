@@ -0,0 +1,92 @@
+package labeler
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+func TestDiffRules(t *testing.T) {
+	rule := func(label, pattern string, sections ...string) RegexpLabel {
+		return RegexpLabel{Regexp: regexp.MustCompile("^" + pattern + "$"), Label: label, Sections: sections}
+	}
+	cases := map[string]struct {
+		oldRules []RegexpLabel
+		newRules []RegexpLabel
+		want     RuleDiff
+	}{
+		"unchanged": {
+			oldRules: []RegexpLabel{rule("service/service1", "google_service1_.*")},
+			newRules: []RegexpLabel{rule("service/service1", "google_service1_.*")},
+			want:     RuleDiff{},
+		},
+		"added and removed": {
+			oldRules: []RegexpLabel{rule("service/service1", "google_service1_.*"), rule("service/service2", "google_service2_resource1")},
+			newRules: []RegexpLabel{rule("service/service1", "google_service1_.*"), rule("service/service3", "google_service3_.*", "title")},
+			want: RuleDiff{
+				Added:   []string{"service/service3: google_service3_.* (title)"},
+				Removed: []string{"service/service2: google_service2_resource1"},
+			},
+		},
+		"pattern moved to another label": {
+			oldRules: []RegexpLabel{rule("service/service1", "google_shared_.*")},
+			newRules: []RegexpLabel{rule("service/service2", "google_shared_.*")},
+			want: RuleDiff{
+				Added:   []string{"service/service2: google_shared_.*"},
+				Removed: []string{"service/service1: google_shared_.*"},
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		tc := tc
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+			got := DiffRules(tc.oldRules, tc.newRules)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("DiffRules() = %+v, want %+v", got, tc.want)
+			}
+			if got.Empty() != (len(tc.want.Added) == 0 && len(tc.want.Removed) == 0) {
+				t.Errorf("Empty() = %v", got.Empty())
+			}
+		})
+	}
+}
+
+func TestWebhookHandlerReload(t *testing.T) {
+	handler, err := NewWebhookHandler([]byte(`
+service/service1:
+  resources:
+  - google_service1_.*`), []byte("secret"), true)
+	if err != nil {
+		t.Fatalf("NewWebhookHandler() error = %v", err)
+	}
+
+	if _, err := handler.Reload([]byte(`service/service1: [`)); err == nil {
+		t.Errorf("Reload() with invalid config succeeded")
+	}
+	if _, err := handler.Reload([]byte(``)); err == nil {
+		t.Errorf("Reload() with empty config succeeded")
+	}
+	if len(handler.RegexpLabels) != 1 {
+		t.Fatalf("rules changed after failed reloads: %v", handler.RegexpLabels)
+	}
+
+	diff, err := handler.Reload([]byte(`
+service/service2:
+  resources:
+  - google_service2_.*`))
+	if err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+	want := RuleDiff{
+		Added:   []string{"service/service2: google_service2_.*"},
+		Removed: []string{"service/service1: google_service1_.*"},
+	}
+	if !reflect.DeepEqual(diff, want) {
+		t.Errorf("Reload() = %+v, want %+v", diff, want)
+	}
+	if len(handler.RegexpLabels) != 1 || handler.RegexpLabels[0].Label != "service/service2" {
+		t.Errorf("rules not replaced: %v", handler.RegexpLabels)
+	}
+}
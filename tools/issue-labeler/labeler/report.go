@@ -0,0 +1,194 @@
+package labeler
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v68/github"
+)
+
+// topResourcesLimit is how many of the most mentioned resources a report lists.
+const topResourcesLimit = 10
+
+// ReportFormats are the formats a Report can be written in.
+var ReportFormats = []string{"text", "markdown", "csv"}
+
+// Report summarizes how issues are distributed across service labels.
+type Report struct {
+	Total     int
+	Unlabeled int
+	ByLabel   map[string]int
+	// MedianTimeToLabel is the median time from an issue being opened to getting its first
+	// service label, over the issues that have one. It is zero if none do.
+	MedianTimeToLabel time.Duration
+	// TopResources are the resources most often listed as affected, most mentioned first.
+	TopResources []ResourceCount
+}
+
+// ResourceCount is the number of issues mentioning a resource.
+type ResourceCount struct {
+	Resource string
+	Count    int
+}
+
+// ComputeReport counts issues per service label. Pull requests are skipped and issues without
+// any service label are counted as unlabeled. labeledAt gives when each issue first got a
+// service label, and may be nil.
+func ComputeReport(issues []*github.Issue, labeledAt map[int]time.Time) Report {
+	report := Report{ByLabel: make(map[string]int)}
+	resources := make(map[string]int)
+	var timesToLabel []time.Duration
+	for _, issue := range issues {
+		if issue.IsPullRequest() {
+			continue
+		}
+		report.Total++
+		labeled := false
+		for _, label := range issue.Labels {
+			if strings.HasPrefix(label.GetName(), "service/") {
+				report.ByLabel[label.GetName()]++
+				labeled = true
+			}
+		}
+		if !labeled {
+			report.Unlabeled++
+		}
+		if at, ok := labeledAt[issue.GetNumber()]; ok {
+			timesToLabel = append(timesToLabel, at.Sub(issue.GetCreatedAt().Time))
+		}
+		seen := make(map[string]struct{})
+		for _, resource := range ExtractAffectedResources(issue.GetBody()) {
+			if _, ok := seen[resource]; !ok {
+				seen[resource] = struct{}{}
+				resources[resource]++
+			}
+		}
+	}
+
+	report.MedianTimeToLabel = median(timesToLabel)
+	for resource, count := range resources {
+		report.TopResources = append(report.TopResources, ResourceCount{resource, count})
+	}
+	sort.Slice(report.TopResources, func(i, j int) bool {
+		if report.TopResources[i].Count != report.TopResources[j].Count {
+			return report.TopResources[i].Count > report.TopResources[j].Count
+		}
+		return report.TopResources[i].Resource < report.TopResources[j].Resource
+	})
+	if len(report.TopResources) > topResourcesLimit {
+		report.TopResources = report.TopResources[:topResourcesLimit]
+	}
+	return report
+}
+
+func median(ds []time.Duration) time.Duration {
+	if len(ds) == 0 {
+		return 0
+	}
+	sorted := append([]time.Duration(nil), ds...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
+
+// sortedLabels returns the labels busiest first.
+func (r Report) sortedLabels() []string {
+	labels := make([]string, 0, len(r.ByLabel))
+	for label := range r.ByLabel {
+		labels = append(labels, label)
+	}
+	sort.Slice(labels, func(i, j int) bool {
+		if r.ByLabel[labels[i]] != r.ByLabel[labels[j]] {
+			return r.ByLabel[labels[i]] > r.ByLabel[labels[j]]
+		}
+		return labels[i] < labels[j]
+	})
+	return labels
+}
+
+// WriteFormat writes the report as text, markdown or csv.
+func (r Report) WriteFormat(w io.Writer, format string) error {
+	switch format {
+	case "text":
+		return r.Write(w)
+	case "markdown":
+		return r.WriteMarkdown(w)
+	case "csv":
+		return r.WriteCSV(w)
+	}
+	return fmt.Errorf("invalid report format %q, must be one of %s", format, strings.Join(ReportFormats, ", "))
+}
+
+// Write prints the report as plain text, busiest labels first.
+func (r Report) Write(w io.Writer) error {
+	if _, err := fmt.Fprintf(w, "Issues: %d\nUnlabeled: %d\n", r.Total, r.Unlabeled); err != nil {
+		return err
+	}
+	if r.MedianTimeToLabel > 0 {
+		if _, err := fmt.Fprintf(w, "Median time to label: %s\n", r.MedianTimeToLabel.Round(time.Minute)); err != nil {
+			return err
+		}
+	}
+	for _, label := range r.sortedLabels() {
+		if _, err := fmt.Fprintf(w, "%6d  %s\n", r.ByLabel[label], label); err != nil {
+			return err
+		}
+	}
+	if len(r.TopResources) > 0 {
+		if _, err := fmt.Fprintf(w, "Top resources:\n"); err != nil {
+			return err
+		}
+		for _, rc := range r.TopResources {
+			if _, err := fmt.Fprintf(w, "%6d  %s\n", rc.Count, rc.Resource); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// WriteMarkdown prints the report as Markdown tables, for pasting into docs or issues.
+func (r Report) WriteMarkdown(w io.Writer) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "| | |\n|---|---:|\n| New issues | %d |\n| Unlabeled | %d |\n", r.Total, r.Unlabeled)
+	if r.MedianTimeToLabel > 0 {
+		fmt.Fprintf(&b, "| Median time to label | %s |\n", r.MedianTimeToLabel.Round(time.Minute))
+	}
+	b.WriteString("\n### Issues by service label\n\n| Label | Issues |\n|---|---:|\n")
+	for _, label := range r.sortedLabels() {
+		fmt.Fprintf(&b, "| `%s` | %d |\n", label, r.ByLabel[label])
+	}
+	if len(r.TopResources) > 0 {
+		b.WriteString("\n### Top resources\n\n| Resource | Issues |\n|---|---:|\n")
+		for _, rc := range r.TopResources {
+			fmt.Fprintf(&b, "| `%s` | %d |\n", rc.Resource, rc.Count)
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteCSV prints the report as metric,name,value rows, for spreadsheets.
+func (r Report) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"metric", "name", "value"})
+	cw.Write([]string{"issues", "", strconv.Itoa(r.Total)})
+	cw.Write([]string{"unlabeled", "", strconv.Itoa(r.Unlabeled)})
+	cw.Write([]string{"median_hours_to_label", "", strconv.FormatFloat(r.MedianTimeToLabel.Hours(), 'f', 1, 64)})
+	for _, label := range r.sortedLabels() {
+		cw.Write([]string{"label", label, strconv.Itoa(r.ByLabel[label])})
+	}
+	for _, rc := range r.TopResources {
+		cw.Write([]string{"resource", rc.Resource, strconv.Itoa(rc.Count)})
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,86 @@
+package labeler
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v68/github"
+)
+
+func TestComputeReport(t *testing.T) {
+	issues := []*github.Issue{
+		{Number: github.Ptr(1), Labels: []*github.Label{{Name: github.Ptr("service/service1")}, {Name: github.Ptr("forward/review")}}},
+		{Number: github.Ptr(2), Labels: []*github.Label{{Name: github.Ptr("service/service1")}, {Name: github.Ptr("service/service2")}}},
+		{Number: github.Ptr(3), Labels: []*github.Label{{Name: github.Ptr("bug")}}},
+		{Number: github.Ptr(4)},
+		{Number: github.Ptr(5), PullRequestLinks: &github.PullRequestLinks{URL: github.Ptr("https://example.com")}},
+	}
+	want := Report{
+		Total:     4,
+		Unlabeled: 2,
+		ByLabel:   map[string]int{"service/service1": 2, "service/service2": 1},
+	}
+
+	got := ComputeReport(issues, nil)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want %v; got %v", want, got)
+	}
+
+	var buf bytes.Buffer
+	if err := got.Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+	wantText := "Issues: 4\nUnlabeled: 2\n     2  service/service1\n     1  service/service2\n"
+	if buf.String() != wantText {
+		t.Errorf("want %q; got %q", wantText, buf.String())
+	}
+}
+
+func TestReportFormats(t *testing.T) {
+	opened := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	issues := []*github.Issue{
+		{
+			Number:    github.Ptr(1),
+			CreatedAt: &github.Timestamp{Time: opened},
+			Body:      testIssueBodyWithResources([]string{"google_service1_resource1", "google_service1_resource2"}),
+			Labels:    []*github.Label{{Name: github.Ptr("service/service1")}},
+		},
+		{
+			Number:    github.Ptr(2),
+			CreatedAt: &github.Timestamp{Time: opened},
+			Body:      testIssueBodyWithResources([]string{"google_service1_resource1"}),
+			Labels:    []*github.Label{{Name: github.Ptr("service/service1")}},
+		},
+		{
+			Number:    github.Ptr(3),
+			CreatedAt: &github.Timestamp{Time: opened},
+		},
+	}
+	labeledAt := map[int]time.Time{
+		1: opened.Add(time.Hour),
+		2: opened.Add(3 * time.Hour),
+	}
+	report := ComputeReport(issues, labeledAt)
+
+	cases := map[string]string{
+		"text": "Issues: 3\nUnlabeled: 1\nMedian time to label: 2h0m0s\n" +
+			"     2  service/service1\n" +
+			"Top resources:\n     2  google_service1_resource1\n     1  google_service1_resource2\n",
+		"markdown": "| | |\n|---|---:|\n| New issues | 3 |\n| Unlabeled | 1 |\n| Median time to label | 2h0m0s |\n" +
+			"\n### Issues by service label\n\n| Label | Issues |\n|---|---:|\n| `service/service1` | 2 |\n" +
+			"\n### Top resources\n\n| Resource | Issues |\n|---|---:|\n| `google_service1_resource1` | 2 |\n| `google_service1_resource2` | 1 |\n",
+		"csv": "metric,name,value\nissues,,3\nunlabeled,,1\nmedian_hours_to_label,,2.0\n" +
+			"label,service/service1,2\nresource,google_service1_resource1,2\nresource,google_service1_resource2,1\n",
+	}
+	for format, want := range cases {
+		var buf bytes.Buffer
+		if err := report.WriteFormat(&buf, format); err != nil {
+			t.Fatalf("WriteFormat(%q) error = %v", format, err)
+		}
+		if buf.String() != want {
+			t.Errorf("WriteFormat(%q) want\n%s\ngot\n%s", format, want, buf.String())
+		}
+	}
+}
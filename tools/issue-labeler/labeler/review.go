@@ -0,0 +1,88 @@
+package labeler
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/google/go-github/v68/github"
+)
+
+// ReviewIssueUpdates steps through the proposed updates, showing each issue's excerpt and the
+// rule matches behind its labels, and asks whether to apply, skip or edit it. It returns the
+// updates to apply. Answering "all" accepts the remaining updates unseen; "quit", or the end
+// of the input, skips them.
+func ReviewIssueUpdates(in io.Reader, out io.Writer, repository string, issues []*github.Issue, updates []IssueUpdate) ([]IssueUpdate, error) {
+	byNumber := make(map[int]*github.Issue, len(issues))
+	for _, issue := range issues {
+		byNumber[issue.GetNumber()] = issue
+	}
+	scanner := bufio.NewScanner(in)
+	prompt := func(format string, args ...any) (string, bool) {
+		fmt.Fprintf(out, format, args...)
+		if !scanner.Scan() {
+			fmt.Fprintln(out)
+			return "", false
+		}
+		return strings.TrimSpace(scanner.Text()), true
+	}
+
+	var accepted []IssueUpdate
+	for i := 0; i < len(updates); i++ {
+		update := updates[i]
+		issue := byNumber[update.Number]
+		fmt.Fprintf(out, "\n[%d/%d] #%d %s\nhttps://github.com/%s/issues/%d\n", i+1, len(updates), update.Number, issue.GetTitle(), repository, update.Number)
+		if excerpt := issueExcerpt(issue.GetBody()); excerpt != "" {
+			fmt.Fprintf(out, "> %s\n", excerpt)
+		}
+		for _, m := range update.Matches {
+			fmt.Fprintf(out, "  %s <- %s (%s: %s)\n", m.Label, m.Resource, m.Section, m.Pattern)
+		}
+
+		for {
+			added, removed := diffLabels(update.OldLabels, update.Labels)
+			fmt.Fprintf(out, "  add: %s\n  remove: %s\n", labelList(added), labelList(removed))
+			answer, ok := prompt("Apply? [y]es, [n]o, [e]dit, [a]ll remaining, [q]uit: ")
+			if !ok {
+				return accepted, scanner.Err()
+			}
+			switch strings.ToLower(answer) {
+			case "y", "yes":
+				// Edits may have put the labels back as they were.
+				if len(added) > 0 || len(removed) > 0 {
+					accepted = append(accepted, update)
+				}
+			case "n", "no":
+			case "a", "all":
+				accepted = append(accepted, update)
+				return append(accepted, updates[i+1:]...), nil
+			case "q", "quit":
+				return accepted, scanner.Err()
+			case "e", "edit":
+				labels, ok := prompt("New labels, space separated [%s]: ", strings.Join(update.Labels, " "))
+				if !ok {
+					return accepted, scanner.Err()
+				}
+				if labels != "" {
+					update.Labels = strings.Fields(labels)
+					sort.Strings(update.Labels)
+				}
+				continue
+			default:
+				fmt.Fprintf(out, "Unknown answer %q\n", answer)
+				continue
+			}
+			break
+		}
+	}
+	return accepted, scanner.Err()
+}
+
+func labelList(labels []string) string {
+	if len(labels) == 0 {
+		return "(none)"
+	}
+	return strings.Join(labels, ", ")
+}
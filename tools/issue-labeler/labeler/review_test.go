@@ -0,0 +1,72 @@
+package labeler
+
+import (
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v68/github"
+)
+
+func TestReviewIssueUpdates(t *testing.T) {
+	issues := []*github.Issue{
+		{Number: github.Ptr(1), Title: github.Ptr("one")},
+		{Number: github.Ptr(2), Title: github.Ptr("two")},
+		{Number: github.Ptr(3), Title: github.Ptr("three")},
+	}
+	updates := []IssueUpdate{
+		{Number: 1, OldLabels: []string{"bug"}, Labels: []string{"bug", "forward/review", "service/service1"}},
+		{Number: 2, OldLabels: []string{}, Labels: []string{"forward/review", "service/service2"}},
+		{Number: 3, OldLabels: []string{}, Labels: []string{"forward/review", "service/service3"}},
+	}
+
+	cases := map[string]struct {
+		input string
+		want  []IssueUpdate
+	}{
+		"accept and skip": {
+			input: "y\nn\nyes\n",
+			want:  []IssueUpdate{updates[0], updates[2]},
+		},
+		"edit labels": {
+			input: "e\nservice/service9 forward/review\ny\nn\nn\n",
+			want: []IssueUpdate{
+				{Number: 1, OldLabels: []string{"bug"}, Labels: []string{"forward/review", "service/service9"}},
+			},
+		},
+		"edit back to unchanged": {
+			input: "e\nbug\ny\nn\nn\n",
+		},
+		"empty edit keeps labels": {
+			input: "e\n\ny\nq\n",
+			want:  []IssueUpdate{updates[0]},
+		},
+		"accept all remaining": {
+			input: "n\na\n",
+			want:  []IssueUpdate{updates[1], updates[2]},
+		},
+		"unknown answer asks again": {
+			input: "maybe\ny\nq\n",
+			want:  []IssueUpdate{updates[0]},
+		},
+		"end of input skips the rest": {
+			input: "y\n",
+			want:  []IssueUpdate{updates[0]},
+		},
+	}
+
+	for tn, tc := range cases {
+		tc := tc
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+			got, err := ReviewIssueUpdates(strings.NewReader(tc.input), io.Discard, "owner/repo", issues, updates)
+			if err != nil {
+				t.Fatalf("ReviewIssueUpdates() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("want %+v, got %+v", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package labeler
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"golang.org/x/oauth2/google"
+)
+
+// ReadAuditLog returns the successfully applied entries for a run, in the order they were
+// recorded. src is a local audit file or the gs://bucket/prefix the run was uploaded under.
+func ReadAuditLog(ctx context.Context, src, runID string) ([]AuditEntry, error) {
+	var r io.Reader
+	if rest, ok := strings.CutPrefix(src, "gs://"); ok {
+		bucket, prefix, _ := strings.Cut(rest, "/")
+		body, err := downloadGCSObject(ctx, bucket, path.Join(prefix, runID+".jsonl"))
+		if err != nil {
+			return nil, err
+		}
+		defer body.Close()
+		r = body
+	} else {
+		f, err := os.Open(src)
+		if err != nil {
+			return nil, fmt.Errorf("opening audit log: %w", err)
+		}
+		defer f.Close()
+		r = f
+	}
+
+	var entries []AuditEntry
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		var entry AuditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("audit log line %d: %w", line, err)
+		}
+		if entry.RunID == runID && entry.Error == "" {
+			entries = append(entries, entry)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading audit log: %w", err)
+	}
+	return entries, nil
+}
+
+func downloadGCSObject(ctx context.Context, bucket, object string) (io.ReadCloser, error) {
+	client, err := google.DefaultClient(ctx, gcsScope)
+	if err != nil {
+		return nil, fmt.Errorf("getting GCS credentials: %w", err)
+	}
+	u := fmt.Sprintf("https://storage.googleapis.com/storage/v1/b/%s/o/%s?alt=media",
+		url.PathEscape(bucket), url.PathEscape(object))
+	req, err := http.NewRequestWithContext(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("downloading gs://%s/%s: %w", bucket, object, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("downloading gs://%s/%s: %s", bucket, object, resp.Status)
+	}
+	return resp.Body, nil
+}
+
+// RollbackChange is the net label change a run made to one issue.
+type RollbackChange struct {
+	Repository string
+	Number     int
+	Added      []string
+	Removed    []string
+}
+
+// ComputeRollbackChanges collapses a run's audit entries into one change per issue, from the
+// labels before the issue's first update to the labels after its last.
+func ComputeRollbackChanges(entries []AuditEntry) []RollbackChange {
+	type key struct {
+		repository string
+		number     int
+	}
+	first := make(map[key]AuditEntry)
+	last := make(map[key]AuditEntry)
+	var keys []key
+	for _, entry := range entries {
+		k := key{entry.Repository, entry.Number}
+		if _, ok := first[k]; !ok {
+			first[k] = entry
+			keys = append(keys, k)
+		}
+		last[k] = entry
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].repository != keys[j].repository {
+			return keys[i].repository < keys[j].repository
+		}
+		return keys[i].number < keys[j].number
+	})
+
+	var changes []RollbackChange
+	for _, k := range keys {
+		added, removed := diffLabels(first[k].LabelsBefore, last[k].LabelsAfter)
+		if len(added) == 0 && len(removed) == 0 {
+			continue
+		}
+		changes = append(changes, RollbackChange{
+			Repository: k.repository,
+			Number:     k.number,
+			Added:      added,
+			Removed:    removed,
+		})
+	}
+	return changes
+}
+
+// Revert returns the update undoing the change on an issue that currently has the given labels.
+// Labels changed by other people since the run are left alone.
+func (c RollbackChange) Revert(current []string) (IssueUpdate, bool) {
+	labels := make(map[string]struct{})
+	for _, l := range current {
+		labels[l] = struct{}{}
+	}
+	for _, l := range c.Added {
+		delete(labels, l)
+	}
+	for _, l := range c.Removed {
+		labels[l] = struct{}{}
+	}
+
+	update := IssueUpdate{
+		Number:    c.Number,
+		Labels:    sortedKeys(labels),
+		OldLabels: append([]string(nil), current...),
+	}
+	sort.Strings(update.OldLabels)
+	added, removed := diffLabels(update.OldLabels, update.Labels)
+	return update, len(added) > 0 || len(removed) > 0
+}
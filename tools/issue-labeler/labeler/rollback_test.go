@@ -0,0 +1,86 @@
+package labeler
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestReadAuditLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	log := `{"run_id":"run-1","repository":"owner/repo","number":1,"labels_before":[],"labels_after":["service/service1"],"status":200}
+{"run_id":"run-2","repository":"owner/repo","number":2,"labels_before":[],"labels_after":["service/service2"],"status":200}
+{"run_id":"run-1","repository":"owner/repo","number":3,"labels_before":[],"labels_after":["service/service1"],"error":"boom"}
+`
+	if err := os.WriteFile(path, []byte(log), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := ReadAuditLog(context.Background(), path, "run-1")
+	if err != nil {
+		t.Fatalf("ReadAuditLog() error = %v", err)
+	}
+	want := []AuditEntry{{
+		RunID:        "run-1",
+		Repository:   "owner/repo",
+		Number:       1,
+		LabelsBefore: []string{},
+		LabelsAfter:  []string{"service/service1"},
+		Status:       200,
+	}}
+	if !reflect.DeepEqual(entries, want) {
+		t.Errorf("want %+v; got %+v", want, entries)
+	}
+}
+
+func TestComputeRollbackChanges(t *testing.T) {
+	entries := []AuditEntry{
+		{Repository: "owner/repo", Number: 2, LabelsBefore: []string{"bug"}, LabelsAfter: []string{"bug", "service/service1"}},
+		{Repository: "owner/repo", Number: 1, LabelsBefore: []string{"service/old"}, LabelsAfter: []string{"service/new"}},
+		{Repository: "owner/repo", Number: 2, LabelsBefore: []string{"bug", "service/service1"}, LabelsAfter: []string{"bug", "forward/review", "service/service1"}},
+		{Repository: "owner/repo", Number: 3, LabelsBefore: []string{"bug"}, LabelsAfter: []string{"bug"}},
+	}
+	want := []RollbackChange{
+		{Repository: "owner/repo", Number: 1, Added: []string{"service/new"}, Removed: []string{"service/old"}},
+		{Repository: "owner/repo", Number: 2, Added: []string{"forward/review", "service/service1"}},
+	}
+	if got := ComputeRollbackChanges(entries); !reflect.DeepEqual(got, want) {
+		t.Errorf("want %+v; got %+v", want, got)
+	}
+}
+
+func TestRollbackChangeRevert(t *testing.T) {
+	change := RollbackChange{Number: 1, Added: []string{"forward/review", "service/new"}, Removed: []string{"service/old"}}
+	cases := map[string]struct {
+		current    []string
+		wantLabels []string
+		wantOk     bool
+	}{
+		"unchanged since run": {
+			current:    []string{"forward/review", "service/new"},
+			wantLabels: []string{"service/old"},
+			wantOk:     true,
+		},
+		"human edits kept": {
+			current:    []string{"service/new", "size/xl"},
+			wantLabels: []string{"service/old", "size/xl"},
+			wantOk:     true,
+		},
+		"already reverted": {
+			current:    []string{"service/old"},
+			wantLabels: []string{"service/old"},
+		},
+	}
+	for tn, tc := range cases {
+		tc := tc
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+			update, ok := change.Revert(tc.current)
+			if ok != tc.wantOk || !reflect.DeepEqual(update.Labels, tc.wantLabels) {
+				t.Errorf("Revert(%v) = %v, %v; want %v, %v", tc.current, update.Labels, ok, tc.wantLabels, tc.wantOk)
+			}
+		})
+	}
+}
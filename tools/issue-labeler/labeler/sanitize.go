@@ -0,0 +1,60 @@
+package labeler
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// multilineCommentRegexp also matches the multi-line comments issue templates leave behind.
+	multilineCommentRegexp = regexp.MustCompile(`(?s)<!--.*?-->`)
+	replyHeaderRegexp      = regexp.MustCompile(`^On .+ wrote:\s*$`)
+	blockStartRegexp       = regexp.MustCompile(`^\s*(resource|data|provider)\s+"([^"]+)"`)
+)
+
+// SanitizeIssueBody removes the parts of an issue body that mention resources without being
+// about them, so they can't cause false matches: HTML comments left by the issue template,
+// quoted replies, including the "On ... wrote:" line email replies start with, and resource,
+// data and provider blocks for providers other than google and google-beta in pasted configs.
+// The body is normalized with NormalizeIssueBody first.
+func SanitizeIssueBody(body string) string {
+	body = NormalizeIssueBody(strings.ReplaceAll(body, "\r\n", "\n"))
+	body = multilineCommentRegexp.ReplaceAllString(body, "")
+
+	var kept []string
+	inFence := false
+	// depth is the brace depth inside a foreign provider's block, or 0 outside one.
+	depth := 0
+	for _, line := range strings.Split(body, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if depth > 0 {
+			depth += strings.Count(line, "{") - strings.Count(line, "}")
+			if depth < 0 {
+				depth = 0
+			}
+			continue
+		}
+		if strings.HasPrefix(trimmed, "```") {
+			inFence = !inFence
+		}
+		if !inFence && (strings.HasPrefix(trimmed, ">") || replyHeaderRegexp.MatchString(trimmed)) {
+			continue
+		}
+		if m := blockStartRegexp.FindStringSubmatch(line); m != nil && isForeignProvider(m[1], m[2]) {
+			depth = strings.Count(line, "{") - strings.Count(line, "}")
+			continue
+		}
+		kept = append(kept, line)
+	}
+	return strings.Join(kept, "\n")
+}
+
+// isForeignProvider reports whether a block of the given kind and type, e.g. resource
+// "aws_instance" or provider "azurerm", belongs to a provider other than google or google-beta.
+func isForeignProvider(kind, typ string) bool {
+	provider := typ
+	if kind != "provider" {
+		provider, _, _ = strings.Cut(typ, "_")
+	}
+	return provider != "google" && provider != "google-beta"
+}
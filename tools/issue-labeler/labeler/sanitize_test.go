@@ -0,0 +1,72 @@
+package labeler
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestSanitizeIssueBody(t *testing.T) {
+	cases := map[string]struct {
+		body string
+		want string
+	}{
+		"multi-line template comment": {
+			body: "### Affected Resource(s)\n<!--- Please list the affected resources, e.g.\n* google_compute_instance\n--->\n* google_storage_bucket",
+			want: "### Affected Resource(s)\n\n* google_storage_bucket",
+		},
+		"quoted reply": {
+			body: "Still happening.\n\nOn Mon, Jan 1, 2024 at 10:00 AM Someone <someone@example.com> wrote:\n> I also see this with google_compute_instance\n> on 5.0.0",
+			want: "Still happening.\n",
+		},
+		"quote inside code fence kept": {
+			body: "```\n> terraform apply\ngoogle_compute_instance.default: Creating...\n```",
+			want: "```\n> terraform apply\ngoogle_compute_instance.default: Creating...\n```",
+		},
+		"foreign provider blocks": {
+			body: "```hcl\nprovider \"aws\" {\n  region = \"us-east-1\"\n}\n\nresource \"aws_instance\" \"web\" {\n  tags = {\n    Name = \"google_compute_instance\"\n  }\n}\n\nresource \"google_storage_bucket\" \"bucket\" {\n  name = \"bucket\"\n}\n```",
+			want: "```hcl\n\n\nresource \"google_storage_bucket\" \"bucket\" {\n  name = \"bucket\"\n}\n```",
+		},
+		"google-beta provider kept": {
+			body: "provider \"google-beta\" {\n  project = \"p\"\n}\ndata \"azurerm_client_config\" \"current\" {}\ndata \"google_client_config\" \"current\" {}",
+			want: "provider \"google-beta\" {\n  project = \"p\"\n}\ndata \"google_client_config\" \"current\" {}",
+		},
+	}
+
+	for tn, tc := range cases {
+		tc := tc
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+			if got := SanitizeIssueBody(tc.body); got != tc.want {
+				t.Errorf("SanitizeIssueBody() =\n%q\nwant\n%q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestMatchIssueLabelsSanitized(t *testing.T) {
+	rules := []RegexpLabel{
+		{Regexp: regexp.MustCompile("^google_compute_instance$"), Label: "service/compute-instances"},
+		{Regexp: regexp.MustCompile("^google_storage_bucket$"), Label: "service/storage", Sections: []string{SectionConfig}},
+	}
+	body := `### Affected Resource(s)
+
+<!--- e.g.
+* google_compute_instance
+--->
+google_storage_bucket
+
+### Terraform Configuration
+
+` + "```hcl" + `
+resource "aws_s3_bucket" "mirror" {
+  bucket = "google_storage_bucket"
+}
+` + "```" + `
+
+On Tue, Feb 2, 2024, Someone wrote:
+> google_compute_instance is affected too
+`
+	if got := ComputeIssueLabels(body, rules); len(got) != 0 {
+		t.Errorf("ComputeIssueLabels() = %v; want no labels", got)
+	}
+}
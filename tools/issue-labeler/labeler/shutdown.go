@@ -0,0 +1,41 @@
+package labeler
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrInterrupted is returned by runs that stopped early because a shutdown was requested. The
+// work they finished is saved, so the run can be resumed.
+var ErrInterrupted = errors.New("interrupted by shutdown request")
+
+type shutdownKey struct{}
+
+// WithShutdown returns a context whose runs stop starting new work once done is closed, like
+// the next issue update or page of issues. Unlike cancelling the context, work already in
+// flight is left to finish, so updates aren't cut off half way and checkpoints, audit logs and
+// failure reports are written as usual.
+func WithShutdown(ctx context.Context, done <-chan struct{}) context.Context {
+	return context.WithValue(ctx, shutdownKey{}, done)
+}
+
+// shuttingDown reports whether the context's shutdown was requested.
+func shuttingDown(ctx context.Context) bool {
+	done := shutdownRequested(ctx)
+	if done == nil {
+		return false
+	}
+	select {
+	case <-done:
+		return true
+	default:
+		return false
+	}
+}
+
+// shutdownRequested returns a channel closed when the context's shutdown is requested, or nil,
+// which never is, if it has no shutdown.
+func shutdownRequested(ctx context.Context) <-chan struct{} {
+	done, _ := ctx.Value(shutdownKey{}).(<-chan struct{})
+	return done
+}
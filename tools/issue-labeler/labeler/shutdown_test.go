@@ -0,0 +1,70 @@
+package labeler
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"regexp"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestShuttingDown(t *testing.T) {
+	if shuttingDown(context.Background()) {
+		t.Errorf("shuttingDown() = true for a context without a shutdown")
+	}
+	done := make(chan struct{})
+	ctx := WithShutdown(context.Background(), done)
+	if shuttingDown(ctx) {
+		t.Errorf("shuttingDown() = true before the shutdown was requested")
+	}
+	close(done)
+	if !shuttingDown(ctx) {
+		t.Errorf("shuttingDown() = false after the shutdown was requested")
+	}
+}
+
+func TestStreamIssueUpdatesShutdown(t *testing.T) {
+	rules := []RegexpLabel{{Regexp: regexp.MustCompile("^google_compute_instance$"), Label: "service/compute"}}
+	opt := IssueFilter{}.listOptions(time.Time{})
+	var fetched atomic.Int32
+	client := pagedIssuesServer(t, 10, nil, &fetched)
+
+	done := make(chan struct{})
+	ctx := WithShutdown(context.Background(), done)
+	var pages []int
+	err := streamIssueUpdates(ctx, client, "o", "r", opt, IssueFilter{}, rules, 1, func(ctx context.Context, batch IssueBatch) error {
+		pages = append(pages, batch.Page)
+		if batch.Page == 2 {
+			close(done)
+		}
+		return nil
+	})
+	if !errors.Is(err, ErrInterrupted) {
+		t.Fatalf("streamIssueUpdates() error = %v; want ErrInterrupted", err)
+	}
+	// Pages fetched before the shutdown are still applied, but no more are fetched.
+	if len(pages) < 2 || pages[0] != 1 || pages[1] != 2 {
+		t.Errorf("applied pages %v; want 1 and 2 first", pages)
+	}
+	if n := int(fetched.Load()); n != len(pages) || n >= 10 {
+		t.Errorf("fetched %d pages and applied %v; want every fetched page applied and fewer than 10", n, pages)
+	}
+}
+
+func TestRetryPolicyShutdown(t *testing.T) {
+	done := make(chan struct{})
+	close(done)
+	ctx := WithShutdown(context.Background(), done)
+	policy := RetryPolicy{Retries: 3, BaseDelay: time.Hour, MaxDelay: time.Hour}
+	calls := 0
+	retryable := &APIError{Op: "edit", Reason: ReasonServer, Class: ErrorRetryable, Err: errors.New("502")}
+	err := policy.do(ctx, slog.Default(), func() error {
+		calls++
+		return retryable
+	})
+	if calls != 1 || !errors.Is(err, retryable) {
+		t.Errorf("do() called %d times and returned %v; want one call returning the retryable error", calls, err)
+	}
+}
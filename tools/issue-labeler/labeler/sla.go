@@ -0,0 +1,183 @@
+package labeler
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v68/github"
+)
+
+const (
+	labelForwardReview = "forward/review"
+	labelForwardLinked = "forward/linked"
+	labelForwardExempt = "forward/exempt"
+
+	// noServiceLabel groups issues without a service label in reports.
+	noServiceLabel = "(no service label)"
+)
+
+// SLAIssue is an open issue that has waited in forward/review past the SLA.
+type SLAIssue struct {
+	Number int
+	Title  string
+	// Since is when forward/review was applied.
+	Since time.Time
+	Age   time.Duration
+}
+
+// SLAReport lists the issues breaching the forward/review SLA, grouped by service label and
+// oldest first. Issues with several service labels appear under each.
+type SLAReport struct {
+	Threshold time.Duration
+	ByService map[string][]SLAIssue
+}
+
+// ComputeSLAReport finds open issues that have carried forward/review for at least threshold
+// without being linked or exempted. labeledAt gives when forward/review was applied to each
+// issue; issues missing from it are aged from their creation time.
+func ComputeSLAReport(issues []*github.Issue, labeledAt map[int]time.Time, threshold time.Duration, now time.Time) SLAReport {
+	report := SLAReport{Threshold: threshold, ByService: make(map[string][]SLAIssue)}
+	for _, issue := range issues {
+		if !needsForwarding(issue) {
+			continue
+		}
+		since, ok := labeledAt[issue.GetNumber()]
+		if !ok {
+			since = issue.GetCreatedAt().Time
+		}
+		age := now.Sub(since)
+		if age < threshold {
+			continue
+		}
+		entry := SLAIssue{Number: issue.GetNumber(), Title: issue.GetTitle(), Since: since, Age: age}
+		services := 0
+		for _, label := range issue.Labels {
+			if strings.HasPrefix(label.GetName(), "service/") {
+				report.ByService[label.GetName()] = append(report.ByService[label.GetName()], entry)
+				services++
+			}
+		}
+		if services == 0 {
+			report.ByService[noServiceLabel] = append(report.ByService[noServiceLabel], entry)
+		}
+	}
+	for _, entries := range report.ByService {
+		sort.Slice(entries, func(i, j int) bool {
+			if entries[i].Age != entries[j].Age {
+				return entries[i].Age > entries[j].Age
+			}
+			return entries[i].Number < entries[j].Number
+		})
+	}
+	return report
+}
+
+// needsForwarding reports whether an issue is open and waiting in forward/review.
+func needsForwarding(issue *github.Issue) bool {
+	if issue.IsPullRequest() || issue.GetState() != "open" {
+		return false
+	}
+	review := false
+	for _, label := range issue.Labels {
+		switch label.GetName() {
+		case labelForwardLinked, labelForwardExempt:
+			return false
+		case labelForwardReview:
+			review = true
+		}
+	}
+	return review
+}
+
+// ForwardReviewTimes looks up when forward/review was last applied to each open issue waiting
+// in review.
+func ForwardReviewTimes(ctx context.Context, repository string, issues []*github.Issue) (map[int]time.Time, error) {
+	var waiting []*github.Issue
+	for _, issue := range issues {
+		if needsForwarding(issue) {
+			waiting = append(waiting, issue)
+		}
+	}
+	return labelTimes(ctx, repository, waiting, func(label string) bool { return label == labelForwardReview }, false)
+}
+
+// ServiceLabelTimes looks up when each issue first got a service label. Issues that never had
+// one are left out.
+func ServiceLabelTimes(ctx context.Context, repository string, issues []*github.Issue) (map[int]time.Time, error) {
+	var labeled []*github.Issue
+	for _, issue := range issues {
+		if !issue.IsPullRequest() {
+			labeled = append(labeled, issue)
+		}
+	}
+	return labelTimes(ctx, repository, labeled, func(label string) bool { return strings.HasPrefix(label, "service/") }, true)
+}
+
+// labelTimes returns when a label matching match was first (or last) applied to each issue,
+// from the issue's events.
+func labelTimes(ctx context.Context, repository string, issues []*github.Issue, match func(string) bool, first bool) (map[int]time.Time, error) {
+	client := newGitHubClient()
+	owner, repo, err := splitRepository(repository)
+	if err != nil {
+		return nil, fmt.Errorf("invalid repository format: %w", err)
+	}
+
+	labeledAt := make(map[int]time.Time)
+	for _, issue := range issues {
+		opts := &github.ListOptions{PerPage: 100}
+		for {
+			events, resp, err := client.Issues.ListIssueEvents(ctx, owner, repo, issue.GetNumber(), opts)
+			observeResponse(resp)
+			if err != nil {
+				return nil, fmt.Errorf("listing events for issue %d: %w", issue.GetNumber(), apiError("list_issue_events", resp, err))
+			}
+			for _, event := range events {
+				if event.GetEvent() != "labeled" || !match(event.GetLabel().GetName()) {
+					continue
+				}
+				if _, seen := labeledAt[issue.GetNumber()]; seen && first {
+					continue
+				}
+				labeledAt[issue.GetNumber()] = event.GetCreatedAt().Time
+			}
+			if resp.NextPage == 0 {
+				break
+			}
+			opts.Page = resp.NextPage
+		}
+	}
+	return labeledAt, nil
+}
+
+// Write prints the report as plain text, services with the most breaching issues first.
+func (r SLAReport) Write(w io.Writer) error {
+	services := make([]string, 0, len(r.ByService))
+	for service := range r.ByService {
+		services = append(services, service)
+	}
+	sort.Slice(services, func(i, j int) bool {
+		if len(r.ByService[services[i]]) != len(r.ByService[services[j]]) {
+			return len(r.ByService[services[i]]) > len(r.ByService[services[j]])
+		}
+		return services[i] < services[j]
+	})
+
+	if _, err := fmt.Fprintf(w, "Issues in %s for %d+ days\n", labelForwardReview, int(r.Threshold.Hours()/24)); err != nil {
+		return err
+	}
+	for _, service := range services {
+		if _, err := fmt.Fprintf(w, "\n%s (%d)\n", service, len(r.ByService[service])); err != nil {
+			return err
+		}
+		for _, issue := range r.ByService[service] {
+			if _, err := fmt.Fprintf(w, "%6dd  #%-6d %s\n", int(issue.Age.Hours()/24), issue.Number, issue.Title); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package labeler
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v68/github"
+)
+
+func TestComputeSLAReport(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	daysAgo := func(days int) time.Time { return now.AddDate(0, 0, -days) }
+	issue := func(number int, title string, created time.Time, labels ...string) *github.Issue {
+		i := &github.Issue{
+			Number:    github.Ptr(number),
+			Title:     github.Ptr(title),
+			State:     github.Ptr("open"),
+			CreatedAt: &github.Timestamp{Time: created},
+		}
+		for _, l := range labels {
+			i.Labels = append(i.Labels, &github.Label{Name: github.Ptr(l)})
+		}
+		return i
+	}
+
+	issues := []*github.Issue{
+		issue(1, "old", daysAgo(40), "forward/review", "service/service1"),
+		issue(2, "older", daysAgo(60), "forward/review", "service/service1", "service/service2"),
+		issue(3, "recent", daysAgo(3), "forward/review", "service/service1"),
+		issue(4, "linked", daysAgo(60), "forward/review", "forward/linked", "service/service1"),
+		issue(5, "no review", daysAgo(60), "service/service1"),
+		issue(6, "relabeled", daysAgo(60), "forward/review", "service/service2"),
+		issue(7, "unrouted", daysAgo(20), "forward/review"),
+	}
+	labeledAt := map[int]time.Time{6: daysAgo(2)}
+
+	got := ComputeSLAReport(issues, labeledAt, 14*24*time.Hour, now)
+	want := SLAReport{
+		Threshold: 14 * 24 * time.Hour,
+		ByService: map[string][]SLAIssue{
+			"service/service1": {
+				{Number: 2, Title: "older", Since: daysAgo(60), Age: 60 * 24 * time.Hour},
+				{Number: 1, Title: "old", Since: daysAgo(40), Age: 40 * 24 * time.Hour},
+			},
+			"service/service2": {
+				{Number: 2, Title: "older", Since: daysAgo(60), Age: 60 * 24 * time.Hour},
+			},
+			noServiceLabel: {
+				{Number: 7, Title: "unrouted", Since: daysAgo(20), Age: 20 * 24 * time.Hour},
+			},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want %+v; got %+v", want, got)
+	}
+
+	var buf bytes.Buffer
+	if err := got.Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+	wantText := "Issues in forward/review for 14+ days\n" +
+		"\nservice/service1 (2)\n    60d  #2      older\n    40d  #1      old\n" +
+		"\n(no service label) (1)\n    20d  #7      unrouted\n" +
+		"\nservice/service2 (1)\n    60d  #2      older\n"
+	if buf.String() != wantText {
+		t.Errorf("want %q; got %q", wantText, buf.String())
+	}
+}
@@ -0,0 +1,208 @@
+package labeler
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/google/go-github/v68/github"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// staleMarker identifies the warning comments posted by the stale command, so activity after
+// the warning can be told apart from the warning itself.
+const staleMarker = "<!-- issue-labeler:stale -->"
+
+// DefaultStaleComment is the warning posted when an issue is marked stale.
+const DefaultStaleComment = `This issue has had no activity for {{.DaysUntilStale}} days, so it has been marked ` + "`{{.Label}}`" + `.
+It will be closed in {{.DaysUntilClose}} days unless there is new activity. If it is still relevant, please leave a comment.`
+
+// StaleConfig controls which issues are marked stale and when they are closed.
+type StaleConfig struct {
+	StaleAfter   time.Duration
+	CloseAfter   time.Duration
+	Label        string
+	ExemptLabels []string
+	Comment      *template.Template
+}
+
+// StaleCommentData is passed to the stale comment template.
+type StaleCommentData struct {
+	Number         int
+	Author         string
+	Label          string
+	DaysUntilStale int
+	DaysUntilClose int
+}
+
+// StaleActionKind is what the stale command does to an issue.
+type StaleActionKind string
+
+const (
+	StaleActionMark   StaleActionKind = "mark"
+	StaleActionUnmark StaleActionKind = "unmark"
+	StaleActionClose  StaleActionKind = "close"
+)
+
+// StaleAction is a pending change to a single issue.
+type StaleAction struct {
+	Number  int
+	Kind    StaleActionKind
+	Comment string
+}
+
+// ComputeStaleAction decides what to do with an open issue. lastComment is the most recent
+// comment on issues already labeled stale, and is ignored otherwise.
+func ComputeStaleAction(issue *github.Issue, lastComment *github.IssueComment, cfg StaleConfig, now time.Time) (StaleAction, bool, error) {
+	if issue.IsPullRequest() || issue.GetState() != "open" {
+		return StaleAction{}, false, nil
+	}
+	stale := false
+	for _, l := range issue.Labels {
+		for _, exempt := range cfg.ExemptLabels {
+			if l.GetName() == exempt {
+				return StaleAction{}, false, nil
+			}
+		}
+		if l.GetName() == cfg.Label {
+			stale = true
+		}
+	}
+
+	action := StaleAction{Number: issue.GetNumber()}
+	if stale {
+		// Someone else commented after the warning, so the issue is active again.
+		if lastComment == nil || !strings.Contains(lastComment.GetBody(), staleMarker) {
+			action.Kind = StaleActionUnmark
+			return action, true, nil
+		}
+		if now.Sub(lastComment.GetCreatedAt().Time) < cfg.CloseAfter {
+			return StaleAction{}, false, nil
+		}
+		action.Kind = StaleActionClose
+		return action, true, nil
+	}
+
+	if now.Sub(issue.GetUpdatedAt().Time) < cfg.StaleAfter {
+		return StaleAction{}, false, nil
+	}
+	var body bytes.Buffer
+	err := cfg.Comment.Execute(&body, StaleCommentData{
+		Number:         issue.GetNumber(),
+		Author:         issue.GetUser().GetLogin(),
+		Label:          cfg.Label,
+		DaysUntilStale: int(cfg.StaleAfter.Hours() / 24),
+		DaysUntilClose: int(cfg.CloseAfter.Hours() / 24),
+	})
+	if err != nil {
+		return StaleAction{}, false, fmt.Errorf("rendering stale comment for issue %d: %w", issue.GetNumber(), err)
+	}
+	action.Kind = StaleActionMark
+	action.Comment = staleMarker + "\n" + body.String()
+	return action, true, nil
+}
+
+// ComputeStaleActions lists the comments needed to decide on stale-labeled issues and returns
+// the actions for all of the given issues.
+func ComputeStaleActions(ctx context.Context, repository string, issues []*github.Issue, cfg StaleConfig, now time.Time) ([]StaleAction, error) {
+	client := newGitHubClient()
+	owner, repo, err := splitRepository(repository)
+	if err != nil {
+		return nil, fmt.Errorf("invalid repository format: %w", err)
+	}
+
+	var actions []StaleAction
+	for _, issue := range issues {
+		var lastComment *github.IssueComment
+		for _, l := range issue.Labels {
+			if l.GetName() != cfg.Label {
+				continue
+			}
+			comments, resp, err := client.Issues.ListComments(ctx, owner, repo, issue.GetNumber(), &github.IssueListCommentsOptions{
+				Sort:        github.Ptr("created"),
+				Direction:   github.Ptr("desc"),
+				ListOptions: github.ListOptions{PerPage: 1},
+			})
+			observeResponse(resp)
+			if err != nil {
+				return nil, fmt.Errorf("listing comments on issue %d: %w", issue.GetNumber(), apiError("list_comments", resp, err))
+			}
+			if len(comments) > 0 {
+				lastComment = comments[0]
+			}
+		}
+		action, ok, err := ComputeStaleAction(issue, lastComment, cfg, now)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			actions = append(actions, action)
+		}
+	}
+	return actions, nil
+}
+
+// ApplyStaleActions labels and comments on newly stale issues, unlabels active ones and closes
+// those whose grace period has passed.
+func ApplyStaleActions(ctx context.Context, repository string, actions []StaleAction, label string, dryRun bool) (err error) {
+	ctx, span := tracer.Start(ctx, "ApplyStaleActions", trace.WithAttributes(
+		attribute.String("repository", repository),
+		attribute.Int("actions", len(actions)),
+		attribute.Bool("dry_run", dryRun),
+	))
+	defer func() { endSpan(span, err) }()
+
+	client := newGitHubClient()
+	owner, repo, err := splitRepository(repository)
+	if err != nil {
+		return fmt.Errorf("invalid repository format: %w", err)
+	}
+
+	failed := 0
+	for _, action := range actions {
+		logger := slog.With(
+			"repo", repository,
+			"number", action.Number,
+			"url", fmt.Sprintf("https://github.com/%s/issues/%d", repository, action.Number),
+			"action", action.Kind,
+		)
+		if dryRun {
+			logger.Info("would update stale issue")
+			continue
+		}
+
+		var resp *github.Response
+		switch action.Kind {
+		case StaleActionMark:
+			_, resp, err = client.Issues.AddLabelsToIssue(ctx, owner, repo, action.Number, []string{label})
+			if err == nil {
+				observeResponse(resp)
+				_, resp, err = client.Issues.CreateComment(ctx, owner, repo, action.Number, &github.IssueComment{Body: &action.Comment})
+			}
+		case StaleActionUnmark:
+			resp, err = client.Issues.RemoveLabelForIssue(ctx, owner, repo, action.Number, label)
+		case StaleActionClose:
+			_, resp, err = client.Issues.Edit(ctx, owner, repo, action.Number, &github.IssueRequest{
+				State:       github.Ptr("closed"),
+				StateReason: github.Ptr("not_planned"),
+			})
+		}
+		observeResponse(resp)
+		if err = apiError("stale_"+string(action.Kind), resp, err); err != nil {
+			logger.Error("updating stale issue failed", "error", err)
+			failed++
+			continue
+		}
+		logger.Info("updated stale issue")
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to update %d / %d stale issues", failed, len(actions))
+	}
+	return nil
+}
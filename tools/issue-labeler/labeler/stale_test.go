@@ -0,0 +1,85 @@
+package labeler
+
+import (
+	"testing"
+	"text/template"
+	"time"
+
+	"github.com/google/go-github/v68/github"
+)
+
+func TestComputeStaleAction(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	daysAgo := func(days int) *github.Timestamp {
+		return &github.Timestamp{Time: now.AddDate(0, 0, -days)}
+	}
+	labels := func(names ...string) []*github.Label {
+		var ls []*github.Label
+		for _, n := range names {
+			ls = append(ls, &github.Label{Name: github.Ptr(n)})
+		}
+		return ls
+	}
+	cfg := StaleConfig{
+		StaleAfter:   90 * 24 * time.Hour,
+		CloseAfter:   30 * 24 * time.Hour,
+		Label:        "stale",
+		ExemptLabels: []string{"pinned"},
+		Comment:      template.Must(template.New("stale").Parse("Closing in {{.DaysUntilClose}} days, @{{.Author}}.")),
+	}
+	warning := func(days int) *github.IssueComment {
+		return &github.IssueComment{Body: github.Ptr(staleMarker + "\nClosing soon."), CreatedAt: daysAgo(days)}
+	}
+
+	cases := map[string]struct {
+		issue       *github.Issue
+		lastComment *github.IssueComment
+		want        StaleAction
+		wantOk      bool
+	}{
+		"active": {
+			issue: &github.Issue{Number: github.Ptr(1), State: github.Ptr("open"), UpdatedAt: daysAgo(10)},
+		},
+		"inactive": {
+			issue:  &github.Issue{Number: github.Ptr(1), State: github.Ptr("open"), UpdatedAt: daysAgo(100), User: &github.User{Login: github.Ptr("octocat")}},
+			want:   StaleAction{Number: 1, Kind: StaleActionMark, Comment: staleMarker + "\nClosing in 30 days, @octocat."},
+			wantOk: true,
+		},
+		"exempt": {
+			issue: &github.Issue{Number: github.Ptr(1), State: github.Ptr("open"), UpdatedAt: daysAgo(100), Labels: labels("pinned")},
+		},
+		"closed": {
+			issue: &github.Issue{Number: github.Ptr(1), State: github.Ptr("closed"), UpdatedAt: daysAgo(100)},
+		},
+		"stale within grace period": {
+			issue:       &github.Issue{Number: github.Ptr(1), State: github.Ptr("open"), UpdatedAt: daysAgo(10), Labels: labels("stale")},
+			lastComment: warning(10),
+		},
+		"stale past grace period": {
+			issue:       &github.Issue{Number: github.Ptr(1), State: github.Ptr("open"), UpdatedAt: daysAgo(31), Labels: labels("stale")},
+			lastComment: warning(31),
+			want:        StaleAction{Number: 1, Kind: StaleActionClose},
+			wantOk:      true,
+		},
+		"stale with reply": {
+			issue:       &github.Issue{Number: github.Ptr(1), State: github.Ptr("open"), UpdatedAt: daysAgo(31), Labels: labels("stale")},
+			lastComment: &github.IssueComment{Body: github.Ptr("Still happening on 5.0"), CreatedAt: daysAgo(31)},
+			want:        StaleAction{Number: 1, Kind: StaleActionUnmark},
+			wantOk:      true,
+		},
+	}
+
+	for tn, tc := range cases {
+		tc := tc
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+			got, ok, err := ComputeStaleAction(tc.issue, tc.lastComment, cfg, now)
+			if err != nil {
+				t.Fatalf("ComputeStaleAction() error = %v", err)
+			}
+			if ok != tc.wantOk || got != tc.want {
+				t.Errorf("ComputeStaleAction() = %+v, %v; want %+v, %v", got, ok, tc.want, tc.wantOk)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package labeler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// RunState is persisted between scheduled runs so each run only looks at recent issues.
+type RunState struct {
+	LastSuccess time.Time `json:"last_success"`
+}
+
+// LoadRunState reads the state file at path. A missing file yields the zero state.
+func LoadRunState(path string) (RunState, error) {
+	var state RunState
+	b, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return state, fmt.Errorf("reading run state: %w", err)
+	}
+	if err := json.Unmarshal(b, &state); err != nil {
+		return state, fmt.Errorf("parsing run state: %w", err)
+	}
+	return state, nil
+}
+
+// SaveRunState atomically replaces the state file at path.
+func SaveRunState(path string, state RunState) error {
+	if err := writeJSONAtomic(path, state); err != nil {
+		return fmt.Errorf("writing run state: %w", err)
+	}
+	return nil
+}
+
+// WindowStart returns when an incremental run starts listing issues: at lastSync, or earlier
+// if window reaches further back from now. Overlapping runs this way re-examines issues whose
+// events were missed; issues that already have their labels need no update, so it's safe.
+func WindowStart(lastSync, now time.Time, window time.Duration) time.Time {
+	if start := now.Add(-window); window > 0 && start.Before(lastSync) {
+		return start
+	}
+	return lastSync
+}
+
+// writeJSONAtomic writes v to a temp file next to path and renames it into place, so readers
+// never see a partially written file.
+func writeJSONAtomic(path string, v any) error {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
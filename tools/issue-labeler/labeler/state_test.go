@@ -0,0 +1,65 @@
+package labeler
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRunState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+
+	state, err := LoadRunState(path)
+	if err != nil {
+		t.Fatalf("LoadRunState() on missing file: %v", err)
+	}
+	if !state.LastSuccess.IsZero() {
+		t.Errorf("want zero state; got %v", state)
+	}
+
+	want := RunState{LastSuccess: time.Date(2025, 3, 4, 5, 6, 7, 0, time.UTC)}
+	if err := SaveRunState(path, want); err != nil {
+		t.Fatalf("SaveRunState(): %v", err)
+	}
+	got, err := LoadRunState(path)
+	if err != nil {
+		t.Fatalf("LoadRunState(): %v", err)
+	}
+	if !got.LastSuccess.Equal(want.LastSuccess) {
+		t.Errorf("want %v; got %v", want, got)
+	}
+}
+
+func TestWindowStart(t *testing.T) {
+	now := time.Date(2025, 3, 4, 12, 0, 0, 0, time.UTC)
+	cases := map[string]struct {
+		lastSync time.Time
+		window   time.Duration
+		want     time.Time
+	}{
+		"no window": {
+			lastSync: now.Add(-time.Hour),
+			want:     now.Add(-time.Hour),
+		},
+		"window reaches before last sync": {
+			lastSync: now.Add(-time.Hour),
+			window:   6 * time.Hour,
+			want:     now.Add(-6 * time.Hour),
+		},
+		"last sync already older than window": {
+			lastSync: now.Add(-24 * time.Hour),
+			window:   6 * time.Hour,
+			want:     now.Add(-24 * time.Hour),
+		},
+	}
+
+	for tn, tc := range cases {
+		tc := tc
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+			if got := WindowStart(tc.lastSync, now, tc.window); !got.Equal(tc.want) {
+				t.Errorf("WindowStart() = %v; want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package labeler
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// stepSummaryMaxRows caps the issue table per repository, since GitHub truncates job summaries
+// over 1 MiB.
+const stepSummaryMaxRows = 500
+
+// StepSummaryRepository is one repository's part of a job summary.
+type StepSummaryRepository struct {
+	Repository string
+	// Issues is how many issues the run looked at.
+	Issues  int
+	Summary RunSummary
+	// Err is why the repository failed, if it did.
+	Err error
+}
+
+// WriteStepSummary writes a Markdown summary of a labeling run for the GitHub Actions job page:
+// per repository counts, a table of the issues with their old and new labels and whether the
+// update was applied or failed, permanently or not, the open issues that couldn't be routed to
+// any service, and the issues skipped because they were transferred.
+func WriteStepSummary(w io.Writer, repos []StepSummaryRepository, dryRun bool) error {
+	var b strings.Builder
+	title := "Issue labeler"
+	if dryRun {
+		title += " (dry run)"
+	}
+	fmt.Fprintf(&b, "## %s\n\n| Repository | Issues | Updates | Applied | Failed | Unroutable |\n|---|---:|---:|---:|---:|---:|\n", title)
+	for _, r := range repos {
+		fmt.Fprintf(&b, "| %s | %d | %d | %d | %d | %d |\n", r.Repository, r.Issues, len(r.Summary.Updates), len(r.Summary.Routed), len(r.Summary.Failed), len(r.Summary.NeedsReview))
+	}
+
+	for _, r := range repos {
+		fmt.Fprintf(&b, "\n### %s\n\n", r.Repository)
+		if r.Err != nil {
+			fmt.Fprintf(&b, "> [!CAUTION]\n> %s\n\n", markdownEscape(r.Err.Error()))
+		}
+		writeStepSummaryUpdates(&b, r, dryRun)
+		if len(r.Summary.NeedsReview) > 0 {
+			fmt.Fprintf(&b, "\n#### Unroutable\n\n%d open issues couldn't be routed to any service and need manual review:\n\n", len(r.Summary.NeedsReview))
+			for i, n := range r.Summary.NeedsReview {
+				if i == stepSummaryMaxRows {
+					fmt.Fprintf(&b, "\n…and %d more.\n", len(r.Summary.NeedsReview)-stepSummaryMaxRows)
+					break
+				}
+				fmt.Fprintf(&b, "- [#%d](https://github.com/%s/issues/%d)\n", n, r.Repository, n)
+			}
+		}
+		if len(r.Summary.Transferred) > 0 {
+			fmt.Fprintf(&b, "\n%d issues were skipped because they were transferred to another repository:\n\n", len(r.Summary.Transferred))
+			for _, t := range r.Summary.Transferred {
+				fmt.Fprintf(&b, "- #%d → [%s](%s)\n", t.Number, t.Destination, t.URL)
+			}
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeStepSummaryUpdates writes the table of a repository's updates and their status.
+func writeStepSummaryUpdates(b *strings.Builder, r StepSummaryRepository, dryRun bool) {
+	if len(r.Summary.Updates) == 0 {
+		b.WriteString("No label updates.\n")
+		return
+	}
+	status := make(map[int]string)
+	for _, u := range r.Summary.Routed {
+		status[u.Number] = "applied"
+	}
+	for _, u := range r.Summary.Failed {
+		status[u.Number] = "**failed**"
+	}
+	for _, u := range r.Summary.Permanent {
+		status[u.Number] = "**failed permanently**"
+	}
+	for _, t := range r.Summary.Transferred {
+		status[t.Number] = "transferred"
+	}
+	b.WriteString("| Issue | Old labels | New labels | Status |\n|---|---|---|---|\n")
+	for i, u := range r.Summary.Updates {
+		if i == stepSummaryMaxRows {
+			fmt.Fprintf(b, "\n…and %d more.\n", len(r.Summary.Updates)-stepSummaryMaxRows)
+			break
+		}
+		s, ok := status[u.Number]
+		switch {
+		case ok:
+		case dryRun:
+			s = "proposed"
+		default:
+			s = "skipped"
+		}
+		fmt.Fprintf(b, "| [#%d](https://github.com/%s/issues/%d) | %s | %s | %s |\n",
+			u.Number, r.Repository, u.Number, markdownLabels(u.OldLabels), markdownLabels(u.Labels), s)
+	}
+}
+
+func markdownLabels(labels []string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	return "`" + strings.Join(labels, "` `") + "`"
+}
+
+func markdownEscape(s string) string {
+	return strings.NewReplacer("|", `\|`, "\n", " ", "<", "&lt;", ">", "&gt;").Replace(s)
+}
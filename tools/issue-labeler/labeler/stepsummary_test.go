@@ -0,0 +1,129 @@
+package labeler
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestWriteStepSummary(t *testing.T) {
+	updates := []IssueUpdate{
+		{Number: 1, OldLabels: []string{"bug"}, Labels: []string{"bug", "forward/review", "service/service1"}},
+		{Number: 2, OldLabels: []string{}, Labels: []string{"forward/review", "service/service2"}},
+		{Number: 3, OldLabels: []string{}, Labels: []string{"forward/review", "service/service3"}},
+	}
+
+	cases := map[string]struct {
+		repos  []StepSummaryRepository
+		dryRun bool
+		want   string
+	}{
+		"applied": {
+			repos: []StepSummaryRepository{{
+				Repository: "owner/repo",
+				Issues:     5,
+				Summary: RunSummary{
+					Updates:     updates,
+					Routed:      updates[:1],
+					Failed:      updates[1:2],
+					NeedsReview: []int{2, 4},
+				},
+				Err: errors.New("failed to update 1 / 3 issues"),
+			}},
+			want: `## Issue labeler
+
+| Repository | Issues | Updates | Applied | Failed | Unroutable |
+|---|---:|---:|---:|---:|---:|
+| owner/repo | 5 | 3 | 1 | 1 | 2 |
+
+### owner/repo
+
+> [!CAUTION]
+> failed to update 1 / 3 issues
+
+| Issue | Old labels | New labels | Status |
+|---|---|---|---|
+| [#1](https://github.com/owner/repo/issues/1) | ` + "`bug`" + ` | ` + "`bug` `forward/review` `service/service1`" + ` | applied |
+| [#2](https://github.com/owner/repo/issues/2) |  | ` + "`forward/review` `service/service2`" + ` | **failed** |
+| [#3](https://github.com/owner/repo/issues/3) |  | ` + "`forward/review` `service/service3`" + ` | skipped |
+
+#### Unroutable
+
+2 open issues couldn't be routed to any service and need manual review:
+
+- [#2](https://github.com/owner/repo/issues/2)
+- [#4](https://github.com/owner/repo/issues/4)
+`,
+		},
+		"transferred": {
+			repos: []StepSummaryRepository{{
+				Repository: "owner/repo",
+				Issues:     1,
+				Summary: RunSummary{
+					Updates:     updates[2:],
+					Transferred: []TransferredIssue{{Number: 3, Destination: "owner/other", URL: "https://github.com/owner/other/issues/10"}},
+				},
+			}},
+			want: `## Issue labeler
+
+| Repository | Issues | Updates | Applied | Failed | Unroutable |
+|---|---:|---:|---:|---:|---:|
+| owner/repo | 1 | 1 | 0 | 0 | 0 |
+
+### owner/repo
+
+| Issue | Old labels | New labels | Status |
+|---|---|---|---|
+| [#3](https://github.com/owner/repo/issues/3) |  | ` + "`forward/review` `service/service3`" + ` | transferred |
+
+1 issues were skipped because they were transferred to another repository:
+
+- #3 → [owner/other](https://github.com/owner/other/issues/10)
+`,
+		},
+		"dry run": {
+			repos: []StepSummaryRepository{
+				{Repository: "owner/repo", Issues: 2, Summary: RunSummary{Updates: updates[2:]}},
+				{Repository: "owner/other", Issues: 1, Summary: RunSummary{NeedsReview: []int{7}}},
+			},
+			dryRun: true,
+			want: `## Issue labeler (dry run)
+
+| Repository | Issues | Updates | Applied | Failed | Unroutable |
+|---|---:|---:|---:|---:|---:|
+| owner/repo | 2 | 1 | 0 | 0 | 0 |
+| owner/other | 1 | 0 | 0 | 0 | 1 |
+
+### owner/repo
+
+| Issue | Old labels | New labels | Status |
+|---|---|---|---|
+| [#3](https://github.com/owner/repo/issues/3) |  | ` + "`forward/review` `service/service3`" + ` | proposed |
+
+### owner/other
+
+No label updates.
+
+#### Unroutable
+
+1 open issues couldn't be routed to any service and need manual review:
+
+- [#7](https://github.com/owner/other/issues/7)
+`,
+		},
+	}
+
+	for tn, tc := range cases {
+		tc := tc
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+			var b strings.Builder
+			if err := WriteStepSummary(&b, tc.repos, tc.dryRun); err != nil {
+				t.Fatalf("WriteStepSummary() error = %v", err)
+			}
+			if got := b.String(); got != tc.want {
+				t.Errorf("want\n%s\ngot\n%s", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,209 @@
+package labeler
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/go-github/v68/github"
+	bolt "go.etcd.io/bbolt"
+)
+
+// Store buckets. Each repository has a record in syncBucket, and a nested bucket of processed
+// issues keyed by big-endian issue number in issuesBucket.
+var (
+	syncBucket   = []byte("sync")
+	issuesBucket = []byte("issues")
+)
+
+// Store is an embedded database recording what previous runs processed, so runs only look at
+// issues updated since the last sync and reruns after a crash don't redo finished work. Records
+// are tied to a hash of the rules; changing the rules invalidates them.
+type Store struct {
+	db *bolt.DB
+}
+
+// OpenStore opens or creates the store at path. Only one process can have it open at a time.
+func OpenStore(path string) (*Store, error) {
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: 10 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("opening store %s: %w", path, err)
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{syncBucket, issuesBucket} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("initializing store %s: %w", path, err)
+	}
+	return &Store{db: db}, nil
+}
+
+// Close closes the store.
+func (s *Store) Close() error {
+	if s == nil {
+		return nil
+	}
+	return s.db.Close()
+}
+
+// RulesHash identifies a rule set, so records made under other rules can be told apart.
+func RulesHash(teamsYaml []byte) string {
+	sum := sha256.Sum256(teamsYaml)
+	return hex.EncodeToString(sum[:])
+}
+
+type syncRecord struct {
+	LastSync  time.Time `json:"last_sync"`
+	RulesHash string    `json:"rules_hash"`
+}
+
+type issueRecord struct {
+	ProcessedAt time.Time `json:"processed_at"`
+	RulesHash   string    `json:"rules_hash"`
+}
+
+// RepoStore is the part of a Store for one repository under one rule set.
+type RepoStore struct {
+	store      *Store
+	repository string
+	rulesHash  string
+	// LastSync is when the last completed run under the same rules started, or zero if there
+	// was none.
+	LastSync time.Time
+}
+
+// Repository returns the store for a repository, loading its last sync. It returns nil for a
+// nil Store, so callers without a store can use the result as is.
+func (s *Store) Repository(repository, rulesHash string) (*RepoStore, error) {
+	if s == nil {
+		return nil, nil
+	}
+	rs := &RepoStore{store: s, repository: repository, rulesHash: rulesHash}
+	err := s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(syncBucket).Get([]byte(repository))
+		if b == nil {
+			return nil
+		}
+		var rec syncRecord
+		if err := json.Unmarshal(b, &rec); err != nil {
+			return err
+		}
+		if rec.RulesHash == rulesHash {
+			rs.LastSync = rec.LastSync
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("reading sync state for %s: %w", repository, err)
+	}
+	return rs, nil
+}
+
+func issueKey(number int) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, uint64(number))
+	return key
+}
+
+// IsProcessed reports whether the issue was processed under the current rules and hasn't been
+// updated since.
+func (rs *RepoStore) IsProcessed(issue *github.Issue) bool {
+	if rs == nil {
+		return false
+	}
+	var rec issueRecord
+	found := false
+	rs.store.db.View(func(tx *bolt.Tx) error {
+		issues := tx.Bucket(issuesBucket).Bucket([]byte(rs.repository))
+		if issues == nil {
+			return nil
+		}
+		if b := issues.Get(issueKey(issue.GetNumber())); b != nil {
+			found = json.Unmarshal(b, &rec) == nil
+		}
+		return nil
+	})
+	return found && rec.RulesHash == rs.rulesHash && !issue.GetUpdatedAt().Time.After(rec.ProcessedAt)
+}
+
+// MarkProcessed records that the issues were processed at the given time.
+func (rs *RepoStore) MarkProcessed(at time.Time, numbers ...int) error {
+	if rs == nil || len(numbers) == 0 {
+		return nil
+	}
+	b, err := json.Marshal(issueRecord{ProcessedAt: at, RulesHash: rs.rulesHash})
+	if err != nil {
+		return err
+	}
+	err = rs.store.db.Update(func(tx *bolt.Tx) error {
+		issues, err := tx.Bucket(issuesBucket).CreateBucketIfNotExists([]byte(rs.repository))
+		if err != nil {
+			return err
+		}
+		for _, n := range numbers {
+			if err := issues.Put(issueKey(n), b); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("recording processed issues for %s: %w", rs.repository, err)
+	}
+	return nil
+}
+
+// FinishSync records a completed run that started at start, making it the next run's LastSync.
+func (rs *RepoStore) FinishSync(start time.Time) error {
+	if rs == nil {
+		return nil
+	}
+	b, err := json.Marshal(syncRecord{LastSync: start, RulesHash: rs.rulesHash})
+	if err != nil {
+		return err
+	}
+	err = rs.store.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(syncBucket).Put([]byte(rs.repository), b)
+	})
+	if err != nil {
+		return fmt.Errorf("recording sync for %s: %w", rs.repository, err)
+	}
+	rs.LastSync = start
+	return nil
+}
+
+// Unprocessed filters out the issues already processed under the current rules.
+func (rs *RepoStore) Unprocessed(issues []*github.Issue) []*github.Issue {
+	if rs == nil {
+		return issues
+	}
+	var unprocessed []*github.Issue
+	for _, issue := range issues {
+		if !rs.IsProcessed(issue) {
+			unprocessed = append(unprocessed, issue)
+		}
+	}
+	return unprocessed
+}
+
+type repoStoreKey struct{}
+
+// WithRepoStore returns a context whose applied updates are recorded in rs.
+func WithRepoStore(ctx context.Context, rs *RepoStore) context.Context {
+	return context.WithValue(ctx, repoStoreKey{}, rs)
+}
+
+func repoStoreFrom(ctx context.Context) *RepoStore {
+	rs, _ := ctx.Value(repoStoreKey{}).(*RepoStore)
+	return rs
+}
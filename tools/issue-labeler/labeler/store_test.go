@@ -0,0 +1,114 @@
+package labeler
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v68/github"
+)
+
+func TestStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "labeler.db")
+	store, err := OpenStore(path)
+	if err != nil {
+		t.Fatalf("OpenStore() error = %v", err)
+	}
+	rules := RulesHash([]byte("rules"))
+	rs, err := store.Repository("owner/repo", rules)
+	if err != nil {
+		t.Fatalf("Repository() error = %v", err)
+	}
+	if !rs.LastSync.IsZero() {
+		t.Errorf("LastSync = %v for a new store, want zero", rs.LastSync)
+	}
+
+	processedAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	start := processedAt.Add(-time.Hour)
+	if err := rs.MarkProcessed(processedAt, 1, 2); err != nil {
+		t.Fatalf("MarkProcessed() error = %v", err)
+	}
+	if err := rs.FinishSync(start); err != nil {
+		t.Fatalf("FinishSync() error = %v", err)
+	}
+	if err := store.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	issue := func(number int, updated time.Time) *github.Issue {
+		return &github.Issue{Number: github.Ptr(number), UpdatedAt: &github.Timestamp{Time: updated}}
+	}
+	issues := []*github.Issue{
+		issue(1, processedAt.Add(-time.Minute)),
+		issue(2, processedAt.Add(time.Minute)),
+		issue(3, processedAt.Add(-time.Minute)),
+	}
+
+	cases := map[string]struct {
+		repository string
+		rules      string
+		wantSync   time.Time
+		want       []int
+	}{
+		"same rules": {
+			repository: "owner/repo",
+			rules:      rules,
+			wantSync:   start,
+			want:       []int{2, 3},
+		},
+		"changed rules": {
+			repository: "owner/repo",
+			rules:      RulesHash([]byte("new rules")),
+			want:       []int{1, 2, 3},
+		},
+		"other repository": {
+			repository: "owner/other",
+			rules:      rules,
+			want:       []int{1, 2, 3},
+		},
+	}
+
+	// The store can only be opened once at a time, so the cases share it and run in sequence.
+	store, err = OpenStore(path)
+	if err != nil {
+		t.Fatalf("OpenStore() error = %v", err)
+	}
+	defer store.Close()
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			rs, err := store.Repository(tc.repository, tc.rules)
+			if err != nil {
+				t.Fatalf("Repository() error = %v", err)
+			}
+			if !rs.LastSync.Equal(tc.wantSync) {
+				t.Errorf("LastSync = %v, want %v", rs.LastSync, tc.wantSync)
+			}
+			var got []int
+			for _, issue := range rs.Unprocessed(issues) {
+				got = append(got, issue.GetNumber())
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("Unprocessed() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestNilStore(t *testing.T) {
+	var store *Store
+	rs, err := store.Repository("owner/repo", "hash")
+	if err != nil || rs != nil {
+		t.Fatalf("Repository() on a nil store = %v, %v", rs, err)
+	}
+	issues := []*github.Issue{{Number: github.Ptr(1)}}
+	if got := rs.Unprocessed(issues); len(got) != 1 {
+		t.Errorf("Unprocessed() on a nil store dropped issues")
+	}
+	if err := rs.MarkProcessed(time.Now(), 1); err != nil {
+		t.Errorf("MarkProcessed() on a nil store error = %v", err)
+	}
+	if err := rs.FinishSync(time.Now()); err != nil {
+		t.Errorf("FinishSync() on a nil store error = %v", err)
+	}
+}
@@ -0,0 +1,141 @@
+package labeler
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/go-github/v68/github"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// DefaultStreamBuffer is how many pages each stage of a streamed backfill can get ahead of the
+// next one.
+const DefaultStreamBuffer = 2
+
+// IssueBatch is a page of issues streamed through a backfill, with the updates computed for it.
+type IssueBatch struct {
+	// Page is the listing page the issues came from, starting at 1.
+	Page    int
+	Issues  []*github.Issue
+	Updates []IssueUpdate
+}
+
+// StreamIssueUpdates lists the issues updated at or after sinceTime that match filter a page at
+// a time, computes their label updates and passes each page to apply, in listing order.
+// Fetching, computing and applying run concurrently, connected by channels holding at most
+// buffer pages, so memory use doesn't grow with the number of issues listed. Issues the
+// context's repo store has already processed are left out, and the context's checkpoint
+// records each page once it's applied.
+//
+// The first error stops the pipeline: pages already fetched when a later one fails are still
+// applied, but nothing is applied after apply fails. If the context's shutdown is requested,
+// no more pages are fetched and ErrInterrupted is returned.
+func StreamIssueUpdates(ctx context.Context, repository string, sinceTime time.Time, filter IssueFilter, regexpLabels []RegexpLabel, buffer int, apply func(context.Context, IssueBatch) error) (err error) {
+	ctx, span := tracer.Start(ctx, "StreamIssueUpdates", trace.WithAttributes(
+		attribute.String("repository", repository),
+		attribute.String("since", sinceTime.Format(time.RFC3339)),
+	))
+	defer func() { endSpan(span, err) }()
+
+	owner, repo, err := splitRepository(repository)
+	if err != nil {
+		return fmt.Errorf("invalid repository format: %w", err)
+	}
+	return streamIssueUpdates(ctx, newGitHubClient(), owner, repo, filter.listOptions(sinceTime), filter, regexpLabels, buffer, apply)
+}
+
+func streamIssueUpdates(ctx context.Context, client *github.Client, owner, repo string, opt *github.IssueListByRepoOptions, filter IssueFilter, regexpLabels []RegexpLabel, buffer int, apply func(context.Context, IssueBatch) error) error {
+	if buffer < 1 {
+		buffer = 1
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	pages := make(chan IssueBatch, buffer)
+	batches := make(chan IssueBatch, buffer)
+	errs := make(chan error, 2)
+	go func() {
+		defer close(pages)
+		errs <- streamIssuePages(ctx, client, owner, repo, opt, filter, pages)
+	}()
+	go func() {
+		defer close(batches)
+		errs <- streamComputeUpdates(ctx, regexpLabels, pages, batches)
+	}()
+
+	var err error
+	checkpoint := checkpointFrom(ctx)
+	for batch := range batches {
+		// Keep draining after a failure so the other stages can exit.
+		if err != nil {
+			continue
+		}
+		if err = apply(ctx, batch); err != nil {
+			cancel()
+			continue
+		}
+		if cerr := checkpoint.MarkPage(batch.Page); cerr != nil {
+			slog.Warn("saving checkpoint failed", "error", cerr)
+		}
+	}
+	// The stages only fail with the cancellation once apply has, so its error comes first.
+	for i := 0; i < 2; i++ {
+		if serr := <-errs; err == nil {
+			err = serr
+		}
+	}
+	return err
+}
+
+// streamIssuePages sends the issues matching filter to pages a page at a time, following the
+// listing's next links.
+func streamIssuePages(ctx context.Context, client *github.Client, owner, repo string, opt *github.IssueListByRepoOptions, filter IssueFilter, pages chan<- IssueBatch) error {
+	issues, resp, err := fetchIssuesPage(ctx, 1, func(ctx context.Context) ([]*github.Issue, *github.Response, error) {
+		return client.Issues.ListByRepo(ctx, owner, repo, opt)
+	})
+	for page := 1; ; page++ {
+		if err != nil {
+			return fmt.Errorf("listing issues page %d: %w", page, err)
+		}
+		select {
+		case pages <- IssueBatch{Page: page, Issues: filter.filter(issues)}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		next := parseNextLink(resp.Response)
+		if next == "" {
+			return nil
+		}
+		if shuttingDown(ctx) {
+			return ErrInterrupted
+		}
+		issues, resp, err = fetchIssuesPage(ctx, page+1, func(ctx context.Context) ([]*github.Issue, *github.Response, error) {
+			return getIssuesPage(ctx, client, next)
+		})
+	}
+}
+
+// streamComputeUpdates computes the updates for each page, matching every page with the same
+// Matcher, and records the total matching time once all pages are done.
+func streamComputeUpdates(ctx context.Context, regexpLabels []RegexpLabel, pages <-chan IssueBatch, batches chan<- IssueBatch) error {
+	matcher := NewMatcher(regexpLabels)
+	store := repoStoreFrom(ctx)
+	var elapsed time.Duration
+	defer func() { matchingDuration.Observe(elapsed.Seconds()) }()
+	for batch := range pages {
+		start := time.Now()
+		batch.Issues = store.Unprocessed(batch.Issues)
+		batch.Updates = computeIssueUpdates(ctx, batch.Issues, matcher)
+		elapsed += time.Since(start)
+		select {
+		case batches <- batch:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package labeler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v68/github"
+)
+
+// pagedIssuesServer serves pages issues pages, linked by next links like GitHub's cursor
+// pagination. Each page has two issues, the first listing google_compute_instance. Pages in
+// failPages fail.
+func pagedIssuesServer(t *testing.T, pages int, failPages map[int]bool, fetched *atomic.Int32) *github.Client {
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetched.Add(1)
+		page := 1
+		if p := r.URL.Query().Get("page"); p != "" {
+			page, _ = strconv.Atoi(p)
+		}
+		if failPages[page] {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		if page < pages {
+			w.Header().Set("Link", fmt.Sprintf(`<%s/repos/o/r/issues?page=%d>; rel="next"`, srv.URL, page+1))
+		}
+		body := `### Affected Resource(s)\n\ngoogle_compute_instance`
+		fmt.Fprintf(w, `[{"number": %d, "body": "%s"}, {"number": %d}]`, page*10, body, page*10+1)
+	}))
+	t.Cleanup(srv.Close)
+	client := github.NewClient(srv.Client())
+	client.BaseURL, _ = url.Parse(srv.URL + "/")
+	return client
+}
+
+func TestStreamIssueUpdates(t *testing.T) {
+	rules := []RegexpLabel{{Regexp: regexp.MustCompile("^google_compute_instance$"), Label: "service/compute"}}
+	opt := IssueFilter{}.listOptions(time.Time{})
+	var fetched atomic.Int32
+	client := pagedIssuesServer(t, 3, nil, &fetched)
+
+	var pages []int
+	var updated []int
+	err := streamIssueUpdates(context.Background(), client, "o", "r", opt, IssueFilter{}, rules, 1, func(ctx context.Context, batch IssueBatch) error {
+		pages = append(pages, batch.Page)
+		if len(batch.Issues) != 2 {
+			t.Errorf("page %d has %d issues; want 2", batch.Page, len(batch.Issues))
+		}
+		for _, u := range batch.Updates {
+			updated = append(updated, u.Number)
+			if want := []string{"forward/review", "service/compute"}; !reflect.DeepEqual(u.Labels, want) {
+				t.Errorf("issue %d labels = %v; want %v", u.Number, u.Labels, want)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("streamIssueUpdates() error = %v", err)
+	}
+	if want := []int{1, 2, 3}; !reflect.DeepEqual(pages, want) {
+		t.Errorf("applied pages %v; want %v", pages, want)
+	}
+	if want := []int{10, 20, 30}; !reflect.DeepEqual(updated, want) {
+		t.Errorf("updated issues %v; want %v", updated, want)
+	}
+}
+
+func TestStreamIssueUpdatesBounded(t *testing.T) {
+	var fetched atomic.Int32
+	client := pagedIssuesServer(t, 20, nil, &fetched)
+	opt := IssueFilter{}.listOptions(time.Time{})
+
+	const buffer = 1
+	err := streamIssueUpdates(context.Background(), client, "o", "r", opt, IssueFilter{}, nil, buffer, func(ctx context.Context, batch IssueBatch) error {
+		if batch.Page == 1 {
+			// Give the other stages time to fill their channels.
+			time.Sleep(50 * time.Millisecond)
+			// The page being applied, one in each channel, one being computed and one
+			// waiting to be sent.
+			if n, max := fetched.Load(), int32(2*buffer+3); n > max {
+				t.Errorf("fetched %d pages while applying the first; want at most %d", n, max)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("streamIssueUpdates() error = %v", err)
+	}
+	if n := fetched.Load(); n != 20 {
+		t.Errorf("fetched %d pages; want 20", n)
+	}
+}
+
+func TestStreamIssueUpdatesErrors(t *testing.T) {
+	opt := IssueFilter{}.listOptions(time.Time{})
+	errApply := errors.New("apply failed")
+	cases := map[string]struct {
+		failPages map[int]bool
+		failApply int
+		wantPages []int
+		wantErr   error
+	}{
+		"fetch fails": {
+			failPages: map[int]bool{3: true},
+			wantPages: []int{1, 2},
+		},
+		"apply fails": {
+			failApply: 2,
+			wantPages: []int{1, 2},
+			wantErr:   errApply,
+		},
+	}
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			var fetched atomic.Int32
+			client := pagedIssuesServer(t, 5, tc.failPages, &fetched)
+			var pages []int
+			err := streamIssueUpdates(context.Background(), client, "o", "r", opt, IssueFilter{}, nil, 1, func(ctx context.Context, batch IssueBatch) error {
+				pages = append(pages, batch.Page)
+				if batch.Page == tc.failApply {
+					return errApply
+				}
+				return nil
+			})
+			if err == nil {
+				t.Fatalf("streamIssueUpdates() succeeded; want an error")
+			}
+			if tc.wantErr != nil && !errors.Is(err, tc.wantErr) {
+				t.Errorf("streamIssueUpdates() error = %v; want %v", err, tc.wantErr)
+			}
+			if !reflect.DeepEqual(pages, tc.wantPages) {
+				t.Errorf("applied pages %v; want %v", pages, tc.wantPages)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package labeler
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/google/go-github/v68/github"
+)
+
+const (
+	// LabelTestFailure marks issues filed for failing nightly acceptance tests. They're routed to
+	// their service directly, without being forwarded for review.
+	LabelTestFailure = "test-failure"
+
+	// SectionImpactedTests is the test failure issue section listing the failing tests.
+	SectionImpactedTests = "impacted tests"
+)
+
+var testNameRegexp = regexp.MustCompile(`\bTest[A-Z]\w*`)
+
+// TestFailureIssue is an issue for failing nightly acceptance tests. Its body is laid out like
+// the test failure issue form, so the labeler routes it the same way as an issue filed by hand.
+type TestFailureIssue struct {
+	Tests     []string
+	Resources []string
+	// Details is markdown added after the sections the labeler reads, like failure rates and
+	// links to debug logs.
+	Details string
+}
+
+// Title lists the failing tests.
+func (i TestFailureIssue) Title() string {
+	return fmt.Sprintf("Failing test(s): %s", strings.Join(i.Tests, ", "))
+}
+
+// Body renders the impacted tests and affected resources sections, followed by Details.
+func (i TestFailureIssue) Body() string {
+	var sb strings.Builder
+	sb.WriteString("### Impacted tests\n\n")
+	for _, test := range i.Tests {
+		fmt.Fprintf(&sb, "- %s\n", test)
+	}
+	sb.WriteString("\n### Affected Resource(s)\n\n")
+	for _, resource := range i.Resources {
+		fmt.Fprintf(&sb, "* %s\n", resource)
+	}
+	if details := strings.TrimSpace(i.Details); details != "" {
+		sb.WriteString("\n")
+		sb.WriteString(details)
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// Labels returns LabelTestFailure and the service labels the labeler derives from the issue's
+// body, resolved with policy.
+func (i TestFailureIssue) Labels(regexpLabels []RegexpLabel, policy ConflictPolicy) []string {
+	labels := deriveLabels(i.Body(), regexpLabels, policy)
+	labels[LabelTestFailure] = struct{}{}
+	return sortedKeys(labels)
+}
+
+// ImpactedTests returns the tests listed in the impacted tests section of a test failure
+// issue's body, in the order they're listed.
+func ImpactedTests(body string) []string {
+	var tests []string
+	seen := make(map[string]struct{})
+	for _, test := range testNameRegexp.FindAllString(ParseIssueForm(body)[SectionImpactedTests], -1) {
+		if _, ok := seen[test]; !ok {
+			seen[test] = struct{}{}
+			tests = append(tests, test)
+		}
+	}
+	return tests
+}
+
+// FindTestFailureIssues returns the issues, out of those given, that list test as impacted, so
+// a failure already being tracked isn't filed again. Pull requests are skipped.
+func FindTestFailureIssues(issues []*github.Issue, test string) []*github.Issue {
+	var found []*github.Issue
+	for _, issue := range issues {
+		if issue.IsPullRequest() {
+			continue
+		}
+		if slices.Contains(ImpactedTests(issue.GetBody()), test) {
+			found = append(found, issue)
+		}
+	}
+	return found
+}
@@ -0,0 +1,84 @@
+package labeler
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/google/go-github/v68/github"
+	"golang.org/x/exp/slices"
+)
+
+func TestTestFailureIssue(t *testing.T) {
+	issue := TestFailureIssue{
+		Tests:     []string{"TestAccComputeInstance_basic"},
+		Resources: []string{"google_compute_instance"},
+		Details:   "### Failure rates\n\n- ga: 100%\n",
+	}
+	wantBody := "### Impacted tests\n\n- TestAccComputeInstance_basic\n\n" +
+		"### Affected Resource(s)\n\n* google_compute_instance\n\n" +
+		"### Failure rates\n\n- ga: 100%\n"
+	if got := issue.Body(); got != wantBody {
+		t.Errorf("Body() want %q; got %q", wantBody, got)
+	}
+	if got, want := issue.Title(), "Failing test(s): TestAccComputeInstance_basic"; got != want {
+		t.Errorf("Title() want %q; got %q", want, got)
+	}
+
+	// The body is routed like any other issue's
+	if got := ExtractAffectedResources(issue.Body()); !slices.Equal(got, issue.Resources) {
+		t.Errorf("ExtractAffectedResources(Body()) want %v; got %v", issue.Resources, got)
+	}
+	regexpLabels := []RegexpLabel{
+		{Regexp: regexp.MustCompile("^google_compute_instance$"), Label: "service/compute-instances"},
+		{Regexp: regexp.MustCompile("^google_compute_.*$"), Label: "service/compute-nat"},
+	}
+	wantLabels := []string{"service/compute-instances", LabelTestFailure}
+	if got := issue.Labels(regexpLabels, ConflictPolicy{}); !slices.Equal(got, wantLabels) {
+		t.Errorf("Labels() want %v; got %v", wantLabels, got)
+	}
+	if got := ImpactedTests(issue.Body()); !slices.Equal(got, issue.Tests) {
+		t.Errorf("ImpactedTests(Body()) want %v; got %v", issue.Tests, got)
+	}
+}
+
+func TestImpactedTests(t *testing.T) {
+	cases := map[string]struct {
+		body string
+		want []string
+	}{
+		"issue form": {
+			body: "### Impacted tests\r\n\r\n<!-- List all impacted tests for searchability. -->\r\n- TestAccFoo_basic\r\n- TestAccFoo_update (beta only)\r\n\r\n### Affected Resource(s)\r\n\r\ngoogle_foo\r\n",
+			want: []string{"TestAccFoo_basic", "TestAccFoo_update"},
+		},
+		"listed twice": {
+			body: "### Impacted tests:\n\nTestAccFoo_basic\n* TestAccFoo_basic\n",
+			want: []string{"TestAccFoo_basic"},
+		},
+		"other sections": {
+			body: "### Affected Resource(s)\n\ngoogle_foo\n\n### Debug Output\n\nTestAccFoo_basic failed\n",
+		},
+	}
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			if got := ImpactedTests(tc.body); !slices.Equal(got, tc.want) {
+				t.Errorf("want %v; got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestFindTestFailureIssues(t *testing.T) {
+	issues := []*github.Issue{
+		{Number: github.Int(1), Body: github.String("### Impacted tests\n\n- TestAccFoo_basic\n")},
+		{Number: github.Int(2), Body: github.String("### Impacted tests\n\n- TestAccFoo_basicWithLabels\n")},
+		{Number: github.Int(3), Body: github.String("### Impacted tests\n\n- TestAccBar_basic\n- TestAccFoo_basic\n")},
+		{Number: github.Int(4), Body: github.String("### Impacted tests\n\n- TestAccFoo_basic\n"), PullRequestLinks: &github.PullRequestLinks{}},
+	}
+	var got []int
+	for _, issue := range FindTestFailureIssues(issues, "TestAccFoo_basic") {
+		got = append(got, issue.GetNumber())
+	}
+	if want := []int{1, 3}; !slices.Equal(got, want) {
+		t.Errorf("want %v; got %v", want, got)
+	}
+}
@@ -0,0 +1,209 @@
+package labeler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/google/go-github/v68/github"
+	"golang.org/x/oauth2/google"
+)
+
+const (
+	// DefaultTrackerEndpoint is the base URL of the issue tracker API.
+	DefaultTrackerEndpoint = "https://issuetracker.googleapis.com"
+	trackerScope           = "https://www.googleapis.com/auth/buganizer"
+)
+
+// TrackerExport is a GitHub issue to mirror into the internal issue tracker.
+type TrackerExport struct {
+	Number      int
+	Title       string
+	URL         string
+	Label       string
+	ComponentID int64
+	// Labels are the issue's current labels, used to swap forward/review for forward/linked.
+	Labels []string
+}
+
+// BuildLabelComponents returns a map of service label to the tracker component owning it.
+func BuildLabelComponents(teamsYaml []byte) (map[string]int64, error) {
+	enrolledTeams, err := ParseEnrolledTeams(teamsYaml)
+	if err != nil {
+		return nil, err
+	}
+	labelComponents := make(map[string]int64)
+	for label, data := range enrolledTeams {
+		if data.Component > 0 {
+			labelComponents[label] = data.Component
+		}
+	}
+	return labelComponents, nil
+}
+
+// ComputeTrackerExports returns the open issues waiting in forward/review whose service label
+// maps to a tracker component. Issues with several such labels are exported to the component
+// of the first label alphabetically.
+func ComputeTrackerExports(repository string, issues []*github.Issue, labelComponents map[string]int64) []TrackerExport {
+	var exports []TrackerExport
+	for _, issue := range issues {
+		if !needsForwarding(issue) {
+			continue
+		}
+		export := TrackerExport{
+			Number: issue.GetNumber(),
+			Title:  issue.GetTitle(),
+			URL:    fmt.Sprintf("https://github.com/%s/issues/%d", repository, issue.GetNumber()),
+		}
+		for _, l := range issue.Labels {
+			export.Labels = append(export.Labels, l.GetName())
+		}
+		sort.Strings(export.Labels)
+		for _, l := range export.Labels {
+			if component, ok := labelComponents[l]; ok {
+				export.Label = l
+				export.ComponentID = component
+				break
+			}
+		}
+		if export.ComponentID != 0 {
+			exports = append(exports, export)
+		}
+	}
+	return exports
+}
+
+// TrackerClient creates issues through the issue tracker API.
+type TrackerClient struct {
+	Endpoint string
+	HTTP     *http.Client
+}
+
+// NewTrackerClient returns a client authenticated with application default credentials.
+func NewTrackerClient(ctx context.Context, endpoint string) (*TrackerClient, error) {
+	client, err := google.DefaultClient(ctx, trackerScope)
+	if err != nil {
+		return nil, fmt.Errorf("getting issue tracker credentials: %w", err)
+	}
+	return &TrackerClient{Endpoint: strings.TrimSuffix(endpoint, "/"), HTTP: client}, nil
+}
+
+type trackerIssue struct {
+	IssueID    int64             `json:"issueId,omitempty,string"`
+	IssueState trackerIssueState `json:"issueState"`
+	Comment    *trackerComment   `json:"issueComment,omitempty"`
+}
+
+type trackerIssueState struct {
+	ComponentID int64  `json:"componentId,string"`
+	Title       string `json:"title"`
+	Type        string `json:"type"`
+	Priority    string `json:"priority"`
+	Severity    string `json:"severity"`
+}
+
+type trackerComment struct {
+	Comment string `json:"comment"`
+}
+
+// CreateIssue files a bug for the export and returns its ID.
+func (c *TrackerClient) CreateIssue(ctx context.Context, repository string, export TrackerExport) (int64, error) {
+	body, err := json.Marshal(trackerIssue{
+		IssueState: trackerIssueState{
+			ComponentID: export.ComponentID,
+			Title:       fmt.Sprintf("[%s#%d] %s", repository, export.Number, export.Title),
+			Type:        "BUG",
+			Priority:    "P2",
+			Severity:    "S2",
+		},
+		Comment: &trackerComment{Comment: fmt.Sprintf("Mirrored from %s (%s).", export.URL, export.Label)},
+	})
+	if err != nil {
+		return 0, err
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", c.Endpoint+"/v1/issues", bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("creating tracker issue: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return 0, fmt.Errorf("creating tracker issue: %s: %s", resp.Status, b)
+	}
+	var created trackerIssue
+	if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+		return 0, fmt.Errorf("parsing created tracker issue: %w", err)
+	}
+	return created.IssueID, nil
+}
+
+// ExportToTracker files a tracker bug for each export, comments the bug link on the GitHub
+// issue and replaces forward/review with forward/linked.
+func ExportToTracker(ctx context.Context, repository string, tracker *TrackerClient, exports []TrackerExport, dryRun bool) error {
+	client := newGitHubClient()
+	owner, repo, err := splitRepository(repository)
+	if err != nil {
+		return fmt.Errorf("invalid repository format: %w", err)
+	}
+
+	failed := 0
+	for _, export := range exports {
+		logger := slog.With("repo", repository, "number", export.Number, "url", export.URL, "component", export.ComponentID)
+		if dryRun {
+			logger.Info("would export issue to tracker")
+			continue
+		}
+
+		id, err := tracker.CreateIssue(ctx, repository, export)
+		if err = apiError("tracker_create", nil, err); err != nil {
+			logger.Error("exporting issue failed", "error", err)
+			failed++
+			continue
+		}
+		logger = logger.With("bug", id)
+
+		comment := fmt.Sprintf("This issue has been forwarded to the service team and is tracked internally as b/%d.", id)
+		_, resp, err := client.Issues.CreateComment(ctx, owner, repo, export.Number, &github.IssueComment{Body: &comment})
+		observeResponse(resp)
+		if err = apiError("create_comment", resp, err); err != nil {
+			logger.Error("commenting bug link failed", "error", err)
+			failed++
+			continue
+		}
+
+		labels := []string{labelForwardLinked}
+		for _, l := range export.Labels {
+			if l != labelForwardReview {
+				labels = append(labels, l)
+			}
+		}
+		sort.Strings(labels)
+		_, resp, err = client.Issues.Edit(ctx, owner, repo, export.Number, &github.IssueRequest{Labels: &labels})
+		observeResponse(resp)
+		if aerr := auditLogFrom(ctx).Record(auditEntry(repository, IssueUpdate{Number: export.Number, Labels: labels, OldLabels: export.Labels}, resp, err)); aerr != nil {
+			logger.Error("recording audit entry failed", "error", aerr)
+		}
+		if err = apiError("edit_issue", resp, err); err != nil {
+			logger.Error("marking issue linked failed", "error", err)
+			failed++
+			continue
+		}
+		logger.Info("exported issue to tracker")
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to export %d / %d issues", failed, len(exports))
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package labeler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-github/v68/github"
+)
+
+func TestComputeTrackerExports(t *testing.T) {
+	labels := func(names ...string) []*github.Label {
+		var ls []*github.Label
+		for _, n := range names {
+			ls = append(ls, &github.Label{Name: github.Ptr(n)})
+		}
+		return ls
+	}
+	issues := []*github.Issue{
+		{Number: github.Ptr(1), Title: github.Ptr("crash"), State: github.Ptr("open"), Labels: labels("service/service1", "forward/review")},
+		{Number: github.Ptr(2), State: github.Ptr("open"), Labels: labels("service/service2", "forward/review")},
+		{Number: github.Ptr(3), State: github.Ptr("open"), Labels: labels("service/service1", "forward/linked")},
+		{Number: github.Ptr(4), State: github.Ptr("closed"), Labels: labels("service/service1", "forward/review")},
+	}
+	got := ComputeTrackerExports("owner/repo", issues, map[string]int64{"service/service1": 1234})
+	want := []TrackerExport{{
+		Number:      1,
+		Title:       "crash",
+		URL:         "https://github.com/owner/repo/issues/1",
+		Label:       "service/service1",
+		ComponentID: 1234,
+		Labels:      []string{"forward/review", "service/service1"},
+	}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want %+v; got %+v", want, got)
+	}
+}
+
+func TestTrackerClientCreateIssue(t *testing.T) {
+	var got map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/v1/issues" {
+			http.NotFound(w, r)
+			return
+		}
+		json.NewDecoder(r.Body).Decode(&got)
+		w.Write([]byte(`{"issueId": "98765"}`))
+	}))
+	defer srv.Close()
+
+	client := &TrackerClient{Endpoint: srv.URL, HTTP: srv.Client()}
+	id, err := client.CreateIssue(context.Background(), "owner/repo", TrackerExport{
+		Number:      1,
+		Title:       "crash",
+		URL:         "https://github.com/owner/repo/issues/1",
+		Label:       "service/service1",
+		ComponentID: 1234,
+	})
+	if err != nil {
+		t.Fatalf("CreateIssue() error = %v", err)
+	}
+	if id != 98765 {
+		t.Errorf("CreateIssue() = %d; want 98765", id)
+	}
+	state := got["issueState"].(map[string]any)
+	if state["componentId"] != "1234" || state["title"] != "[owner/repo#1] crash" {
+		t.Errorf("unexpected issue state %v", state)
+	}
+}
@@ -0,0 +1,66 @@
+package labeler
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-github/v68/github"
+)
+
+// TransferredIssue is an issue that was listed for a run but has since been transferred to
+// another repository, so it can't be updated in the one it was listed from.
+type TransferredIssue struct {
+	Number int
+	// Destination is the repository the issue is in now, like owner/repo.
+	Destination string
+	URL         string
+}
+
+// The GraphQL API follows transfers: looking an issue up by its old repository and number
+// returns it from the repository it's in now.
+const transferredIssueQuery = `query($owner: String!, $name: String!, $number: Int!) {
+  repository(owner: $owner, name: $name) {
+    issue(number: $number) {
+      url
+      repository { nameWithOwner }
+    }
+  }
+}`
+
+// mayBeTransferred reports whether a failed request for an issue could have failed because
+// the issue was transferred, which the REST API reports as moved, gone or not found.
+func mayBeTransferred(resp *github.Response) bool {
+	if resp == nil {
+		return false
+	}
+	switch resp.StatusCode {
+	case http.StatusMovedPermanently, http.StatusNotFound, http.StatusGone:
+		return true
+	}
+	return false
+}
+
+// findTransfer looks up whether issue number in owner/repo was transferred to another
+// repository. Issues that were deleted, or are still in owner/repo, aren't transferred.
+func findTransfer(ctx context.Context, client *github.Client, owner, repo string, number int) (TransferredIssue, bool, error) {
+	var data struct {
+		Repository struct {
+			Issue *struct {
+				URL        string `json:"url"`
+				Repository struct {
+					NameWithOwner string `json:"nameWithOwner"`
+				} `json:"repository"`
+			} `json:"issue"`
+		} `json:"repository"`
+	}
+	err := doGraphQL(ctx, client, transferredIssueQuery, map[string]any{"owner": owner, "name": repo, "number": number}, &data)
+	if err != nil {
+		return TransferredIssue{}, false, err
+	}
+	issue := data.Repository.Issue
+	if issue == nil || issue.Repository.NameWithOwner == "" || strings.EqualFold(issue.Repository.NameWithOwner, owner+"/"+repo) {
+		return TransferredIssue{}, false, nil
+	}
+	return TransferredIssue{Number: number, Destination: issue.Repository.NameWithOwner, URL: issue.URL}, true, nil
+}
@@ -0,0 +1,82 @@
+package labeler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-github/v68/github"
+)
+
+func TestMayBeTransferred(t *testing.T) {
+	cases := map[string]struct {
+		resp *github.Response
+		want bool
+	}{
+		"no response": {},
+		"not found":   {resp: &github.Response{Response: &http.Response{StatusCode: http.StatusNotFound}}, want: true},
+		"gone":        {resp: &github.Response{Response: &http.Response{StatusCode: http.StatusGone}}, want: true},
+		"moved":       {resp: &github.Response{Response: &http.Response{StatusCode: http.StatusMovedPermanently}}, want: true},
+		"forbidden":   {resp: &github.Response{Response: &http.Response{StatusCode: http.StatusForbidden}}},
+	}
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			if got := mayBeTransferred(tc.resp); got != tc.want {
+				t.Errorf("mayBeTransferred() = %v; want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFindTransfer(t *testing.T) {
+	// Issues are answered from the repository they're in now.
+	issues := map[float64]string{
+		1: "owner/other",
+		2: "Owner/Repo",
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req graphQLRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decoding request: %v", err)
+		}
+		repo, ok := issues[req.Variables["number"].(float64)]
+		if !ok {
+			fmt.Fprint(w, `{"data": {"repository": {"issue": null}}}`)
+			return
+		}
+		fmt.Fprintf(w, `{"data": {"repository": {"issue": {"url": "https://github.com/%s/issues/10", "repository": {"nameWithOwner": %q}}}}}`, repo, repo)
+	}))
+	defer srv.Close()
+	client := github.NewClient(srv.Client())
+	client.BaseURL, _ = url.Parse(srv.URL + "/")
+
+	cases := map[string]struct {
+		number      int
+		want        TransferredIssue
+		transferred bool
+	}{
+		"transferred": {
+			number:      1,
+			want:        TransferredIssue{Number: 1, Destination: "owner/other", URL: "https://github.com/owner/other/issues/10"},
+			transferred: true,
+		},
+		"same repository": {number: 2},
+		"deleted":         {number: 3},
+	}
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			got, ok, err := findTransfer(context.Background(), client, "owner", "repo", tc.number)
+			if err != nil {
+				t.Fatalf("findTransfer() error = %v", err)
+			}
+			if ok != tc.transferred || !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("findTransfer() = %+v, %v; want %+v, %v", got, ok, tc.want, tc.transferred)
+			}
+		})
+	}
+}
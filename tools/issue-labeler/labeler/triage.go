@@ -0,0 +1,139 @@
+package labeler
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/google/go-github/v68/github"
+)
+
+// DefaultTriageComment is the triage comment for services without their own template.
+const DefaultTriageComment = `This issue has been routed to ` + "`{{.Label}}`" + `{{with .Team}} ({{.}}){{end}} and is waiting in ` + "`forward/review`" + ` for the service team.
+
+Next steps for the service team: confirm the issue is yours (if not, swap the service label and leave a comment), then track it internally and replace ` + "`forward/review`" + ` with ` + "`forward/linked`" + `. Use ` + "`forward/exempt`" + ` for issues that don't need to be tracked.`
+
+// TriageCommentData is passed to triage comment templates.
+type TriageCommentData struct {
+	Number int
+	Label  string
+	Team   string
+}
+
+// TriageTemplate renders the triage comment for one service label.
+type TriageTemplate struct {
+	Team     string
+	Template *template.Template
+}
+
+// TriageComment is a comment to post on a newly forwarded issue.
+type TriageComment struct {
+	Number int
+	Body   string
+}
+
+// BuildTriageTemplates parses each service's triage comment template from the enrolled teams
+// config, using defaultTemplate for services without one.
+func BuildTriageTemplates(teamsYaml []byte, defaultTemplate string) (map[string]TriageTemplate, error) {
+	enrolledTeams, err := ParseEnrolledTeams(teamsYaml)
+	if err != nil {
+		return nil, err
+	}
+	defaultTpl, err := parseTriageTemplate("default", defaultTemplate)
+	if err != nil {
+		return nil, err
+	}
+	templates := make(map[string]TriageTemplate)
+	for label, data := range enrolledTeams {
+		tpl := defaultTpl
+		if data.TriageComment != "" {
+			if tpl, err = parseTriageTemplate(label, data.TriageComment); err != nil {
+				return nil, err
+			}
+		}
+		templates[label] = TriageTemplate{Team: data.Team, Template: tpl}
+	}
+	return templates, nil
+}
+
+func parseTriageTemplate(name, text string) (*template.Template, error) {
+	tpl, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("parsing triage comment template for %s: %w", name, err)
+	}
+	return tpl, nil
+}
+
+// ComputeTriageComments returns a comment for each update that newly adds forward/review along
+// with a service label, with one section per service label it adds.
+func ComputeTriageComments(issueUpdates []IssueUpdate, templates map[string]TriageTemplate) ([]TriageComment, error) {
+	var comments []TriageComment
+	for _, update := range issueUpdates {
+		added, _ := diffLabels(update.OldLabels, update.Labels)
+		forwarded := false
+		for _, l := range added {
+			if l == labelForwardReview {
+				forwarded = true
+			}
+		}
+		if !forwarded {
+			continue
+		}
+		var sections []string
+		for _, l := range added {
+			tpl, ok := templates[l]
+			if !ok {
+				continue
+			}
+			var b bytes.Buffer
+			if err := tpl.Template.Execute(&b, TriageCommentData{Number: update.Number, Label: l, Team: tpl.Team}); err != nil {
+				return nil, fmt.Errorf("rendering triage comment for issue %d: %w", update.Number, err)
+			}
+			sections = append(sections, strings.TrimSpace(b.String()))
+		}
+		if len(sections) > 0 {
+			comments = append(comments, TriageComment{Number: update.Number, Body: strings.Join(sections, "\n\n")})
+		}
+	}
+	sort.Slice(comments, func(i, j int) bool { return comments[i].Number < comments[j].Number })
+	return comments, nil
+}
+
+// PostTriageComments posts each triage comment, or only logs them in dry-run mode.
+func PostTriageComments(ctx context.Context, repository string, comments []TriageComment, dryRun bool) error {
+	client := newGitHubClient()
+	owner, repo, err := splitRepository(repository)
+	if err != nil {
+		return fmt.Errorf("invalid repository format: %w", err)
+	}
+
+	failed := 0
+	for _, c := range comments {
+		logger := slog.With(
+			"repo", repository,
+			"number", c.Number,
+			"url", fmt.Sprintf("https://github.com/%s/issues/%d", repository, c.Number),
+		)
+		if dryRun {
+			logger.Info("would post triage comment", "body", c.Body)
+			continue
+		}
+		body := c.Body
+		_, resp, err := client.Issues.CreateComment(ctx, owner, repo, c.Number, &github.IssueComment{Body: &body})
+		observeResponse(resp)
+		if err = apiError("create_comment", resp, err); err != nil {
+			logger.Error("posting triage comment failed", "error", err)
+			failed++
+			continue
+		}
+		logger.Info("posted triage comment")
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to post %d / %d triage comments", failed, len(comments))
+	}
+	return nil
+}
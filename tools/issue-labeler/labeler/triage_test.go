@@ -0,0 +1,73 @@
+package labeler
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestComputeTriageComments(t *testing.T) {
+	teamsYaml := []byte(`
+service/service1:
+  team: service1-team
+  triage_comment: "Routed #{{.Number}} to {{.Team}}."
+  resources:
+  - google_service1_.*
+service/service2:
+  resources:
+  - google_service2_.*
+`)
+	templates, err := BuildTriageTemplates(teamsYaml, "Default for {{.Label}}.")
+	if err != nil {
+		t.Fatalf("BuildTriageTemplates() error = %v", err)
+	}
+
+	cases := map[string]struct {
+		updates []IssueUpdate
+		want    []TriageComment
+	}{
+		"service template": {
+			updates: []IssueUpdate{{Number: 1, OldLabels: []string{}, Labels: []string{"forward/review", "service/service1"}}},
+			want:    []TriageComment{{Number: 1, Body: "Routed #1 to service1-team."}},
+		},
+		"default template": {
+			updates: []IssueUpdate{{Number: 2, OldLabels: []string{"bug"}, Labels: []string{"bug", "forward/review", "service/service2"}}},
+			want:    []TriageComment{{Number: 2, Body: "Default for service/service2."}},
+		},
+		"several services": {
+			updates: []IssueUpdate{{Number: 3, OldLabels: []string{}, Labels: []string{"forward/review", "service/service1", "service/service2"}}},
+			want:    []TriageComment{{Number: 3, Body: "Routed #3 to service1-team.\n\nDefault for service/service2."}},
+		},
+		"already forwarded": {
+			updates: []IssueUpdate{{Number: 4, OldLabels: []string{"forward/review", "service/service1"}, Labels: []string{"forward/review", "service/service1", "service/service2"}}},
+		},
+		"unknown service": {
+			updates: []IssueUpdate{{Number: 5, OldLabels: []string{}, Labels: []string{"forward/review", "service/service3"}}},
+		},
+	}
+
+	for tn, tc := range cases {
+		tc := tc
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+			got, err := ComputeTriageComments(tc.updates, templates)
+			if err != nil {
+				t.Fatalf("ComputeTriageComments() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("want %+v, got %+v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestBuildTriageTemplatesInvalid(t *testing.T) {
+	teamsYaml := []byte(`
+service/service1:
+  triage_comment: "{{.Number"
+  resources:
+  - google_service1_.*
+`)
+	if _, err := BuildTriageTemplates(teamsYaml, DefaultTriageComment); err == nil {
+		t.Errorf("BuildTriageTemplates() with an invalid template succeeded")
+	}
+}
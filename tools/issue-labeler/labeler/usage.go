@@ -0,0 +1,170 @@
+package labeler
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Rate limit resources, as GitHub names them in the X-RateLimit-Resource header. core is the
+// REST API's limit.
+const (
+	resourceCore    = "core"
+	resourceGraphQL = "graphql"
+)
+
+// ResourceUsage is a run's use of one of GitHub's rate limits.
+type ResourceUsage struct {
+	Requests int
+	// Spent is how much of the budget went while the run was making requests: the drop in
+	// Remaining, which also counts GraphQL queries costing more than one point and other clients
+	// sharing the token, or Requests if that's higher, e.g. because the window reset.
+	Spent int
+	// Limit, Remaining and Reset are from the last response with rate limit headers. Limit is 0
+	// if there was none.
+	Limit     int
+	Remaining int
+	Reset     time.Time
+
+	firstRemaining int
+	firstReset     time.Time
+}
+
+// ProjectedRuns is how many more runs spending as much as this one fit in the remaining budget
+// before it resets, and in a full window after that. Both are -1 if the run spent nothing or
+// got no rate limit headers.
+func (u ResourceUsage) ProjectedRuns() (beforeReset, perWindow int) {
+	if u.Spent == 0 || u.Limit == 0 {
+		return -1, -1
+	}
+	return u.Remaining / u.Spent, u.Limit / u.Spent
+}
+
+// APIUsage counts the GitHub API requests a run makes against each rate limit.
+type APIUsage struct {
+	mu        sync.Mutex
+	resources map[string]*ResourceUsage
+}
+
+func (u *APIUsage) record(req *http.Request, resp *http.Response) {
+	resource := resourceCore
+	if strings.HasSuffix(req.URL.Path, "/graphql") {
+		resource = resourceGraphQL
+	}
+	var limit, remaining int
+	var reset time.Time
+	if resp != nil {
+		if r := resp.Header.Get("X-RateLimit-Resource"); r != "" {
+			resource = r
+		}
+		limit, _ = strconv.Atoi(resp.Header.Get("X-RateLimit-Limit"))
+		remaining, _ = strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining"))
+		if s, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+			reset = time.Unix(s, 0)
+		}
+	}
+	apiRequests.WithLabelValues(resource).Inc()
+	if u == nil {
+		return
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if u.resources == nil {
+		u.resources = make(map[string]*ResourceUsage)
+	}
+	r, ok := u.resources[resource]
+	if !ok {
+		r = &ResourceUsage{}
+		u.resources[resource] = r
+	}
+	r.Requests++
+	if limit > 0 {
+		if r.Limit == 0 {
+			// The first request's own cost isn't in the drop, so count it as one.
+			r.firstRemaining, r.firstReset = remaining+1, reset
+		}
+		r.Limit, r.Remaining, r.Reset = limit, remaining, reset
+	}
+	r.Spent = r.Requests
+	if r.Limit > 0 && r.Reset.Equal(r.firstReset) {
+		r.Spent = max(r.Requests, r.firstRemaining-r.Remaining)
+	}
+}
+
+// Resources returns the usage of each rate limit the run made requests against.
+func (u *APIUsage) Resources() map[string]ResourceUsage {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	resources := make(map[string]ResourceUsage, len(u.resources))
+	for name, r := range u.resources {
+		resources[name] = *r
+	}
+	return resources
+}
+
+// report logs the run's usage of each rate limit and exports it as metrics and attributes of
+// the run's span, to plan how many repositories a token can handle.
+func (u *APIUsage) report(run string, span trace.Span) {
+	resources := u.Resources()
+	names := make([]string, 0, len(resources))
+	for name := range resources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		r := resources[name]
+		beforeReset, perWindow := r.ProjectedRuns()
+		runAPIRequests.WithLabelValues(run, name).Set(float64(r.Requests))
+		if beforeReset >= 0 {
+			rateLimitProjectedRuns.WithLabelValues(run, name).Set(float64(beforeReset))
+		}
+		span.SetAttributes(
+			attribute.Int("github."+name+".requests", r.Requests),
+			attribute.Int("github."+name+".spent", r.Spent),
+			attribute.Int("github."+name+".remaining", r.Remaining),
+		)
+		slog.Info("github api usage",
+			"run", run,
+			"resource", name,
+			"requests", r.Requests,
+			"spent", r.Spent,
+			"remaining", r.Remaining,
+			"limit", r.Limit,
+			"reset", r.Reset,
+			"projected_runs_before_reset", beforeReset,
+			"projected_runs_per_window", perWindow,
+		)
+	}
+}
+
+type apiUsageKey struct{}
+
+func withAPIUsage(ctx context.Context, u *APIUsage) context.Context {
+	return context.WithValue(ctx, apiUsageKey{}, u)
+}
+
+func apiUsageFrom(ctx context.Context) *APIUsage {
+	u, _ := ctx.Value(apiUsageKey{}).(*APIUsage)
+	return u
+}
+
+// usageTransport counts every request made through it, in the APIUsage of the request's
+// context if it has one.
+type usageTransport struct {
+	base http.RoundTripper
+}
+
+func (t usageTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	apiUsageFrom(req.Context()).record(req, resp)
+	return resp, err
+}
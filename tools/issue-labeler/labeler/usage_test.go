@@ -0,0 +1,81 @@
+package labeler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestAPIUsage(t *testing.T) {
+	reset := time.Unix(1767225600, 0)
+	var mu sync.Mutex
+	remaining := map[string]int{"core": 4000, "graphql": 4000}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resource, cost := "core", 1
+		switch r.URL.Path {
+		case "/graphql":
+			// Queries can cost more than one point.
+			resource, cost = "graphql", 5
+		case "/norate":
+			return
+		}
+		mu.Lock()
+		remaining[resource] -= cost
+		w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(remaining[resource]))
+		mu.Unlock()
+		w.Header().Set("X-RateLimit-Resource", resource)
+		w.Header().Set("X-RateLimit-Limit", "5000")
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+	}))
+	defer srv.Close()
+
+	usage := &APIUsage{}
+	ctx := withAPIUsage(context.Background(), usage)
+	client := &http.Client{Transport: usageTransport{base: http.DefaultTransport}}
+	for _, path := range []string{"/repos/o/r/issues", "/repos/o/r/issues/1", "/graphql", "/graphql", "/norate"} {
+		req, err := http.NewRequestWithContext(ctx, "GET", srv.URL+path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+	}
+
+	want := map[string]ResourceUsage{
+		"core":    {Requests: 3, Spent: 3, Limit: 5000, Remaining: 3998, Reset: reset, firstRemaining: 4000, firstReset: reset},
+		"graphql": {Requests: 2, Spent: 6, Limit: 5000, Remaining: 3990, Reset: reset, firstRemaining: 3996, firstReset: reset},
+	}
+	got := usage.Resources()
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Resources() = %+v; want %+v", got, want)
+	}
+	if beforeReset, perWindow := got["graphql"].ProjectedRuns(); beforeReset != 665 || perWindow != 833 {
+		t.Errorf("ProjectedRuns() = %d, %d; want 665, 833", beforeReset, perWindow)
+	}
+}
+
+func TestResourceUsageProjectedRuns(t *testing.T) {
+	cases := map[string]struct {
+		usage             ResourceUsage
+		before, perWindow int
+	}{
+		"no requests":     {usage: ResourceUsage{Limit: 5000, Remaining: 5000}, before: -1, perWindow: -1},
+		"no rate headers": {usage: ResourceUsage{Requests: 3, Spent: 3}, before: -1, perWindow: -1},
+		"spent":           {usage: ResourceUsage{Requests: 10, Spent: 100, Limit: 5000, Remaining: 250}, before: 2, perWindow: 50},
+	}
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			if before, perWindow := tc.usage.ProjectedRuns(); before != tc.before || perWindow != tc.perWindow {
+				t.Errorf("ProjectedRuns() = %d, %d; want %d, %d", before, perWindow, tc.before, tc.perWindow)
+			}
+		})
+	}
+}
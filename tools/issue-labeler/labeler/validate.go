@@ -0,0 +1,76 @@
+package labeler
+
+import (
+	"fmt"
+	"net/mail"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// ValidateRules checks an enrolled teams config for problems that would cause bad routing,
+// returning one error per problem found. Unlike ParseEnrolledTeams it rejects unknown keys, since
+// a misspelled key silently drops part of a team's rules everywhere else.
+func ValidateRules(teamsYaml []byte) []error {
+	enrolledTeams := make(EnrolledTeams)
+	if err := yaml.UnmarshalStrict(teamsYaml, &enrolledTeams); err != nil {
+		return []error{fmt.Errorf("unmarshalling enrolled teams yaml: %w", err)}
+	}
+
+	labels := make([]string, 0, len(enrolledTeams))
+	for label := range enrolledTeams {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+
+	var errs []error
+	seen := make(map[string]string)
+	for _, label := range labels {
+		data := enrolledTeams[label]
+		if !strings.HasPrefix(label, "service/") {
+			errs = append(errs, fmt.Errorf("%s: label must start with service/", label))
+		}
+		if len(data.Resources) == 0 {
+			errs = append(errs, fmt.Errorf("%s: no resources listed", label))
+		}
+		if data.Project != "" {
+			if _, _, err := splitProject(data.Project); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", label, err))
+			}
+		}
+		if data.Component < 0 {
+			errs = append(errs, fmt.Errorf("%s: invalid component %d", label, data.Component))
+		}
+		if data.GitHubTeam != "" {
+			if org, team, ok := strings.Cut(data.GitHubTeam, "/"); !ok || org == "" || team == "" || strings.Contains(team, "/") {
+				errs = append(errs, fmt.Errorf("%s: github_team %q must be in org/team-slug form", label, data.GitHubTeam))
+			}
+		}
+		if data.Color != "" && !labelColorRegexp.MatchString(data.Color) {
+			errs = append(errs, fmt.Errorf("%s: color %q must be a 6 digit hex color without #", label, data.Color))
+		}
+		if data.TriageComment != "" {
+			if _, err := parseTriageTemplate(label, data.TriageComment); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		for _, addr := range data.Digest {
+			if _, err := mail.ParseAddress(addr); err != nil {
+				errs = append(errs, fmt.Errorf("%s: invalid digest address %q: %w", label, addr, err))
+			}
+		}
+		for _, resource := range data.Resources {
+			if _, err := regexp.Compile(fmt.Sprintf("^%s$", resource)); err != nil {
+				errs = append(errs, fmt.Errorf("%s: invalid resource pattern %q: %w", label, resource, err))
+			}
+			if other, ok := seen[resource]; ok {
+				errs = append(errs, fmt.Errorf("%s: resource pattern %q is also listed under %s", label, resource, other))
+				continue
+			}
+			seen[resource] = label
+		}
+	}
+	return errs
+}
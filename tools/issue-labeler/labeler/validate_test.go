@@ -0,0 +1,104 @@
+package labeler
+
+import (
+	"testing"
+)
+
+func TestValidateRules(t *testing.T) {
+	cases := map[string]struct {
+		yaml     []byte
+		wantErrs int
+	}{
+		"valid": {
+			yaml: []byte(`
+service/service1:
+  team: service1-team
+  resources:
+  - google_service1_.*`),
+		},
+		"enrolled teams": {
+			yaml: EnrolledTeamsYaml,
+		},
+		"bad label prefix": {
+			yaml: []byte(`
+service1:
+  resources:
+  - google_service1_.*`),
+			wantErrs: 1,
+		},
+		"no resources": {
+			yaml: []byte(`
+service/service1:
+  team: service1-team`),
+			wantErrs: 1,
+		},
+		"invalid pattern": {
+			yaml: []byte(`
+service/service1:
+  resources:
+  - google_service1_(`),
+			wantErrs: 1,
+		},
+		"invalid digest address": {
+			yaml: []byte(`
+service/service1:
+  digest:
+  - service1-team
+  resources:
+  - google_service1_.*`),
+			wantErrs: 1,
+		},
+		"invalid github team": {
+			yaml: []byte(`
+service/service1:
+  github_team: "@service1-team"
+  resources:
+  - google_service1_.*`),
+			wantErrs: 1,
+		},
+		"invalid color": {
+			yaml: []byte(`
+service/service1:
+  color: "#fbca04"
+  resources:
+  - google_service1_.*`),
+			wantErrs: 1,
+		},
+		"invalid triage comment": {
+			yaml: []byte(`
+service/service1:
+  triage_comment: "{{.Team"
+  resources:
+  - google_service1_.*`),
+			wantErrs: 1,
+		},
+		"duplicate pattern": {
+			yaml: []byte(`
+service/service1:
+  resources:
+  - google_shared
+service/service2:
+  resources:
+  - google_shared`),
+			wantErrs: 1,
+		},
+		"unknown field": {
+			yaml: []byte(`
+service/service1:
+  resource:
+  - google_service1_.*`),
+			wantErrs: 1,
+		},
+	}
+
+	for tn, tc := range cases {
+		tc := tc
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+			errs := ValidateRules(tc.yaml)
+			if len(errs) != tc.wantErrs {
+				t.Errorf("want %d errors; got %v", tc.wantErrs, errs)
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package labeler
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v68/github"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// WebhookHandler labels issues as GitHub delivers issues.opened and issues.edited events.
+type WebhookHandler struct {
+	Secret        []byte
+	RegexpLabels  []RegexpLabel
+	LabelProjects map[string]string
+	DryRun        bool
+	// Audit, if set, records every label change made by the handler.
+	Audit *AuditLog
+	// Policy resolves issues matching more services than it allows.
+	Policy ConflictPolicy
+	// Automation classifies issues opened by automation, which aren't forwarded for review.
+	Automation AutomationPolicy
+
+	// mu guards the rules, which Reload swaps while deliveries are being handled.
+	mu sync.RWMutex
+}
+
+// NewWebhookHandler builds a handler from an enrolled teams config, failing fast on bad config.
+func NewWebhookHandler(teamsYaml, secret []byte, dryRun bool) (*WebhookHandler, error) {
+	if len(secret) == 0 {
+		return nil, fmt.Errorf("webhook secret must not be empty")
+	}
+	regexpLabels, labelProjects, err := buildWebhookRules(teamsYaml)
+	if err != nil {
+		return nil, err
+	}
+	return &WebhookHandler{
+		Secret:        secret,
+		RegexpLabels:  regexpLabels,
+		LabelProjects: labelProjects,
+		DryRun:        dryRun,
+	}, nil
+}
+
+func buildWebhookRules(teamsYaml []byte) ([]RegexpLabel, map[string]string, error) {
+	regexpLabels, err := BuildRegexLabels(teamsYaml)
+	if err != nil {
+		return nil, nil, fmt.Errorf("building regex labels: %w", err)
+	}
+	if len(regexpLabels) == 0 {
+		return nil, nil, fmt.Errorf("enrolled teams config has no rules")
+	}
+	labelProjects, err := BuildLabelProjects(teamsYaml)
+	if err != nil {
+		return nil, nil, fmt.Errorf("building label projects: %w", err)
+	}
+	return regexpLabels, labelProjects, nil
+}
+
+// Reload swaps in the rules from a new enrolled teams config and returns how they changed. On
+// error the current rules are kept.
+func (h *WebhookHandler) Reload(teamsYaml []byte) (RuleDiff, error) {
+	regexpLabels, labelProjects, err := buildWebhookRules(teamsYaml)
+	if err != nil {
+		return RuleDiff{}, err
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	diff := DiffRules(h.RegexpLabels, regexpLabels)
+	h.RegexpLabels = regexpLabels
+	h.LabelProjects = labelProjects
+	return diff, nil
+}
+
+// NewWebhookMux serves the handler on /webhook alongside /healthz and /metrics endpoints.
+func NewWebhookMux(h http.Handler) *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.Handle("POST /webhook", h)
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	})
+	mux.Handle("GET /metrics", promhttp.Handler())
+	return mux
+}
+
+func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	payload, err := github.ValidatePayload(r, h.Secret)
+	if err != nil {
+		slog.Warn("rejected webhook delivery", "delivery", github.DeliveryID(r), "error", err)
+		http.Error(w, "invalid payload signature", http.StatusUnauthorized)
+		return
+	}
+
+	event, err := github.ParseWebHook(github.WebHookType(r), payload)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("parsing webhook: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	issuesEvent, ok := event.(*github.IssuesEvent)
+	if !ok {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	switch issuesEvent.GetAction() {
+	case "opened", "edited":
+	default:
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	repository := issuesEvent.GetRepo().GetFullName()
+	if body := issuesEvent.GetChanges().GetBody(); body != nil {
+		err = h.relabelEditedIssue(r.Context(), repository, issuesEvent.GetIssue(), body.GetFrom())
+	} else {
+		err = h.labelIssue(r.Context(), repository, issuesEvent.GetIssue())
+	}
+	if err != nil {
+		slog.Error("labeling issue failed", "repo", repository, "number", issuesEvent.GetIssue().GetNumber(), "error", err)
+		http.Error(w, "labeling issue failed", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// relabelEditedIssue applies the change in derived labels between an issue's old and new body.
+func (h *WebhookHandler) relabelEditedIssue(ctx context.Context, repository string, issue *github.Issue, oldBody string) (err error) {
+	defer ObserveRun("webhook", time.Now())
+	ctx, endRun := StartRun(ctx, "webhook")
+	defer func() { endRun(err) }()
+	ctx = WithAuditLog(ctx, h.Audit)
+
+	h.mu.RLock()
+	regexpLabels, labelProjects := h.RegexpLabels, h.LabelProjects
+	h.mu.RUnlock()
+
+	delta, ok := ComputeEditDelta(issue, oldBody, regexpLabels, h.Policy, h.Automation)
+	if !ok {
+		return nil
+	}
+	if err := ApplyLabelDelta(ctx, repository, delta, h.DryRun); err != nil {
+		return err
+	}
+	projectItems := ComputeProjectItems([]IssueUpdate{delta.Update()}, labelProjects)
+	return AddProjectItems(ctx, repository, projectItems, h.DryRun)
+}
+
+func (h *WebhookHandler) labelIssue(ctx context.Context, repository string, issue *github.Issue) (err error) {
+	defer ObserveRun("webhook", time.Now())
+	ctx, endRun := StartRun(ctx, "webhook")
+	defer func() { endRun(err) }()
+	ctx = WithAuditLog(ctx, h.Audit)
+	ctx = WithConflictPolicy(ctx, h.Policy)
+	ctx = WithAutomationPolicy(ctx, h.Automation)
+
+	h.mu.RLock()
+	regexpLabels, labelProjects := h.RegexpLabels, h.LabelProjects
+	h.mu.RUnlock()
+
+	issueUpdates := ComputeIssueUpdates(ctx, []*github.Issue{issue}, regexpLabels)
+	if err := UpdateIssues(ctx, repository, issueUpdates, h.DryRun); err != nil {
+		return err
+	}
+	projectItems := ComputeProjectItems(issueUpdates, labelProjects)
+	return AddProjectItems(ctx, repository, projectItems, h.DryRun)
+}
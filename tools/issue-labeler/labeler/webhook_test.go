@@ -0,0 +1,77 @@
+package labeler
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func signedWebhookRequest(secret, event, payload string) *http.Request {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(payload))
+	req := httptest.NewRequest("POST", "/webhook", strings.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-GitHub-Event", event)
+	req.Header.Set("X-Hub-Signature-256", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	return req
+}
+
+func TestWebhookHandler(t *testing.T) {
+	handler := &WebhookHandler{
+		Secret: []byte("secret"),
+		RegexpLabels: []RegexpLabel{
+			{Regexp: regexp.MustCompile("^google_service1_.*$"), Label: "service/service1"},
+		},
+		DryRun: true,
+	}
+	issuePayload := func(action string) string {
+		return `{"action": "` + action + `", "repository": {"full_name": "owner/repo"}, "issue": {"number": 1, "body": "### Affected Resource(s)\n\ngoogle_service1_resource1\n"}}`
+	}
+
+	cases := map[string]struct {
+		req        *http.Request
+		wantStatus int
+	}{
+		"bad signature": {
+			req:        signedWebhookRequest("wrong", "issues", issuePayload("opened")),
+			wantStatus: http.StatusUnauthorized,
+		},
+		"ping": {
+			req:        signedWebhookRequest("secret", "ping", `{"zen": "Keep it logically awesome."}`),
+			wantStatus: http.StatusNoContent,
+		},
+		"ignored action": {
+			req:        signedWebhookRequest("secret", "issues", issuePayload("closed")),
+			wantStatus: http.StatusNoContent,
+		},
+		"opened": {
+			req:        signedWebhookRequest("secret", "issues", issuePayload("opened")),
+			wantStatus: http.StatusOK,
+		},
+		"edited": {
+			req:        signedWebhookRequest("secret", "issues", issuePayload("edited")),
+			wantStatus: http.StatusOK,
+		},
+		"body edited": {
+			req:        signedWebhookRequest("secret", "issues", `{"action": "edited", "changes": {"body": {"from": "### Affected Resource(s)\n\n_No response_\n"}}, "repository": {"full_name": "owner/repo"}, "issue": {"number": 1, "body": "### Affected Resource(s)\n\ngoogle_service1_resource1\n"}}`),
+			wantStatus: http.StatusOK,
+		},
+	}
+
+	for tn, tc := range cases {
+		tc := tc
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, tc.req)
+			if rec.Code != tc.wantStatus {
+				t.Errorf("want status %d; got %d (%s)", tc.wantStatus, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
@@ -2,4 +2,7 @@ module github.com/GoogleCloudPlatform/magic-modules/tools/teamcity-generator
 
 go 1.24
 
-require golang.org/x/text v0.11.0
+require (
+	golang.org/x/text v0.11.0
+	gopkg.in/yaml.v3 v3.0.1
+)
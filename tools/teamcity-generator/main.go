@@ -5,143 +5,273 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"path/filepath"
+	"sort"
 	"strings"
 
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
+	"gopkg.in/yaml.v3"
 )
 
 var GA_VERSION = "ga"
 var BETA_VERSION = "beta"
 
-var oPath = flag.String("output", "", "path to output generated files to")
-var ver = flag.String("version", "", "version name, value must be `ga` or `beta`")
+var mmv1Path = flag.String("mmv1", "", "path to the mmv1 directory containing the products to generate TeamCity configuration for")
+var oPath = flag.String("output", "", "path to output generated files to, defaults to the TeamCity configuration's inputs in mmv1")
 
-func main() {
-	var version string
-	var outputPath string
-
-	flag.Parse()
-	outputPath = *oPath
-	version = *ver
-	if outputPath == "" {
-		log.Fatalf("missing output flag: provide `--output <path>` to set the path to output generated files to")
-	}
-	if version == "" {
-		log.Fatalf("missing version flag: provide `--version <ga|beta>` to set the path to output generated files to")
+// product is the subset of an mmv1 product.yaml file that's needed to configure its
+// service package's builds
+type product struct {
+	Name        string
+	DisplayName string `yaml:"display_name"`
+	Versions    []struct {
+		Name string
 	}
-	if version != GA_VERSION && version != BETA_VERSION {
-		log.Fatalf("invalid version flag value: value must be `%s` or `%s`", GA_VERSION, BETA_VERSION)
+	TeamCity struct {
+		Parallelism int
+		Environment map[string]string
+	} `yaml:"teamcity"`
+}
+
+// service is a service package in the provider that's tested in TeamCity
+type service struct {
+	name        string
+	displayName string
+	versions    []string
+	parallelism int
+	environment map[string]string
+}
+
+func (s service) existsAt(version string) bool {
+	for _, v := range s.versions {
+		if v == version {
+			return true
+		}
 	}
+	return false
+}
 
-	var terraformResourceDirectory string
-	switch version {
-	case GA_VERSION:
-		terraformResourceDirectory = "google"
-	case BETA_VERSION:
-		terraformResourceDirectory = "google-beta"
-	default:
-		log.Fatalf("invalid version flag value: value must be `%s` or `%s`", GA_VERSION, BETA_VERSION)
+func main() {
+	flag.Parse()
+	if *mmv1Path == "" {
+		log.Fatalf("missing mmv1 flag: provide `--mmv1 <path>` to set the path to the mmv1 directory")
+	}
+	outputPath := *oPath
+	if outputPath == "" {
+		outputPath = filepath.Join(*mmv1Path, "third_party", "terraform", ".teamcity", "components", "inputs")
 	}
 
-	log.Printf("Generating TeamCity configuration service package map for `%s` provider", terraformResourceDirectory)
+	log.Printf("Generating TeamCity configuration for the products in %s", *mmv1Path)
 
-	// Get a list of the service packages found in a given directory
-	servicesDir := fmt.Sprintf("%s/%s/services", outputPath, terraformResourceDirectory)
-	serviceList, err := readAllServicePackages(servicesDir)
+	services, err := readAllServices(filepath.Join(*mmv1Path, "products"), filepath.Join(*mmv1Path, "third_party", "terraform", "services"))
 	if err != nil {
 		log.Fatalf("error determining service package list: %s", err)
 	}
 
-	// Create a string of the map that should be created in .teamcity/components/generated/services.kt
-	relativeServicesDir := fmt.Sprintf("./%s/services", terraformResourceDirectory)
-	serviceMap, err := createMap(serviceList, relativeServicesDir)
-	if err != nil {
-		log.Fatalf("error creating service package map: %s", err)
+	files := map[string]func([]service) (string, error){
+		"services_ga.kt": func(s []service) (string, error) {
+			return createServicesMap(s, "ServicesListGa", GA_VERSION, "./google/services")
+		},
+		"services_beta.kt": func(s []service) (string, error) {
+			return createServicesMap(s, "ServicesListBeta", BETA_VERSION, "./google-beta/services")
+		},
+		"per_service_parallelism.kt": createParallelismMap,
+		"per_service_environment.kt": createEnvironmentMap,
 	}
-
-	// Ensure .teamcity/components/generated/services.kt exists, create if not present
-	// "Create creates or truncates the named file. If the file already exists, it is truncated."
-	servicesKtFilePath := fmt.Sprintf("%s/.teamcity/components/generated/services.kt", outputPath)
-	log.Printf("Opening %s", servicesKtFilePath)
-	f, err := os.Create(servicesKtFilePath)
-	if err != nil {
-		log.Fatalf("error creating or truncating existing file `.teamcity/components/generated/services.kt` in output directory: %s", err)
-	}
-	defer f.Close()
-
-	// Save map to .teamcity/components/generated/services.kt
-	log.Printf("Saving service map to %s", servicesKtFilePath)
-	_, err = f.Write([]byte(serviceMap))
-	if err != nil {
-		log.Fatalf("error writing to file `.teamcity/components/generated/services.kt` in output directory: %s", err)
+	for name, create := range files {
+		contents, err := create(services)
+		if err != nil {
+			log.Fatalf("error creating %s: %s", name, err)
+		}
+		filePath := filepath.Join(outputPath, name)
+		log.Printf("Saving %s", filePath)
+		if err := os.WriteFile(filePath, []byte(contents), 0644); err != nil {
+			log.Fatalf("error writing to file %s: %s", filePath, err)
+		}
 	}
 
 	log.Println("Finished")
 }
 
-func readAllServicePackages(providerDir string) ([]string, error) {
-	packages, err := os.ReadDir(providerDir)
+// readAllServices returns the service packages for the products in productsDir, which are in the
+// provider at the same versions as their product. Service packages with handwritten files in
+// handwrittenDir are in the provider at every version, as mmv1 compiles handwritten files for
+// every version even if their product isn't available at it.
+func readAllServices(productsDir, handwrittenDir string) ([]service, error) {
+	productFiles, err := filepath.Glob(filepath.Join(productsDir, "*", "product.yaml"))
 	if err != nil {
 		return nil, err
 	}
-	var services = make([]string, 0)
+	byName := make(map[string]service)
+	for _, f := range productFiles {
+		b, err := os.ReadFile(f)
+		if err != nil {
+			return nil, err
+		}
+		var p product
+		if err := yaml.Unmarshal(b, &p); err != nil {
+			return nil, fmt.Errorf("error parsing %s: %w", f, err)
+		}
+		if p.Name == "" {
+			return nil, fmt.Errorf("missing `name` in %s", f)
+		}
+		// Service packages are named after the lower-cased product name, like mmv1 does
+		s := service{
+			name:        strings.ToLower(p.Name),
+			displayName: p.DisplayName,
+			parallelism: p.TeamCity.Parallelism,
+			environment: p.TeamCity.Environment,
+		}
+		for _, v := range p.Versions {
+			switch v.Name {
+			case GA_VERSION:
+				// The beta provider is a superset of the GA provider
+				s.versions = []string{GA_VERSION, BETA_VERSION}
+			case BETA_VERSION:
+				if !s.existsAt(BETA_VERSION) {
+					s.versions = []string{BETA_VERSION}
+				}
+			}
+		}
+		byName[s.name] = s
+	}
 
+	packages, err := os.ReadDir(handwrittenDir)
+	if err != nil {
+		return nil, err
+	}
 	for _, p := range packages {
-		if p.IsDir() {
-			services = append(services, p.Name())
+		if !p.IsDir() {
+			continue
+		}
+		s, ok := byName[p.Name()]
+		if !ok {
+			s = service{name: p.Name()}
 		}
+		s.versions = []string{GA_VERSION, BETA_VERSION}
+		byName[p.Name()] = s
 	}
-	if len(services) == 0 {
-		return nil, fmt.Errorf("found 0 service packages in %s", providerDir)
+
+	if len(byName) == 0 {
+		return nil, fmt.Errorf("found 0 service packages in %s or %s", productsDir, handwrittenDir)
+	}
+	var services []service
+	for _, s := range byName {
+		services = append(services, s)
 	}
+	sort.Slice(services, func(i, j int) bool {
+		return services[i].name < services[j].name
+	})
 	return services, nil
 }
 
-func createMap(packageNames []string, servicesDir string) (string, error) {
+func writeHeader(b *strings.Builder) {
+	// Add copyright header
+	b.WriteString("/*\n")
+	b.WriteString(" * Copyright (c) HashiCorp, Inc.\n")
+	b.WriteString(" * SPDX-License-Identifier: MPL-2.0\n")
+	b.WriteString(" */\n\n")
+
+	// Add autogen notice
+	b.WriteString("// this file is auto-generated by magic-modules/tools/teamcity-generator from the products in mmv1/products, any changes made here will be overwritten\n\n")
+
+	b.WriteString("package generated\n\n")
+}
 
+// createServicesMap returns a Kotlin file declaring a map named mapName of the service packages
+// in the provider at version, which are found in servicesDir
+func createServicesMap(services []service, mapName, version, servicesDir string) (string, error) {
 	entryTemplate := `    "%s" to mapOf(
         "name" to "%s",
         "displayName" to "%s",
         "path" to "%s"
-    ),
-`
-	lastEntryTemplate := `    "%s" to mapOf(
-        "name" to "%s",
-        "displayName" to "%s",
-        "path" to "%s"
-    )` // No trailing comma
+    )`
 	caser := cases.Title(language.English)
 
+	var entries []string
+	for _, s := range services {
+		if !s.existsAt(version) {
+			continue
+		}
+		displayName := s.displayName
+		if displayName == "" {
+			displayName = caser.String(s.name)
+		}
+		path := fmt.Sprintf("%s/%s", servicesDir, s.name)
+		entries = append(entries, fmt.Sprintf(entryTemplate, s.name, s.name, displayName, path))
+	}
+	if len(entries) == 0 {
+		return "", fmt.Errorf("found 0 service packages at version %s", version)
+	}
+
 	var b strings.Builder
-	// Add copyright header
-	b.WriteString("/*\n")
-	b.WriteString(" * Copyright (c) HashiCorp, Inc.\n")
-	b.WriteString(" * SPDX-License-Identifier: MPL-2.0\n")
-	b.WriteString(" */\n\n")
+	writeHeader(&b)
+	fmt.Fprintf(&b, "var %s = mapOf(\n", mapName)
+	// Final entry in map doesn't have comma
+	b.WriteString(strings.Join(entries, ",\n"))
+	b.WriteString("\n)\n")
 
-	// Add autogen notice
-	b.WriteString("// this file is auto-generated by magic-modules/tools/teamcity-generator, any changes made here will be overwritten\n\n")
+	return b.String(), nil
+}
 
-	// Add the map
-	b.WriteString("var services = mapOf(\n")
-	for i, p := range packageNames {
-		path := fmt.Sprintf("%s/%s", servicesDir, p)
-		var e string
-		if i < (len(packageNames) - 1) {
-			e = fmt.Sprintf(entryTemplate, p, p, caser.String(p), path)
-		} else {
-			// Final entry in map doesn't have comma
-			e = fmt.Sprintf(lastEntryTemplate, p, p, caser.String(p), path)
+// createParallelismMap returns a Kotlin file declaring the parallelism of the service packages
+// whose tests can't run with the default parallelism
+func createParallelismMap(services []service) (string, error) {
+	var entries []string
+	for _, s := range services {
+		if s.parallelism > 0 {
+			entries = append(entries, fmt.Sprintf("    \"%s\" to %d", s.name, s.parallelism))
 		}
+	}
 
-		_, err := fmt.Fprint(&b, e)
-		if err != nil {
-			return "", err
+	var b strings.Builder
+	writeHeader(&b)
+	b.WriteString("var ServiceParallelism = mapOf<String, Int>(\n")
+	if len(entries) > 0 {
+		b.WriteString(strings.Join(entries, ",\n"))
+		b.WriteString("\n")
+	}
+	b.WriteString(")\n")
+
+	return b.String(), nil
+}
+
+// createEnvironmentMap returns a Kotlin file declaring the environment variables that are set when
+// testing service packages, for the service packages that need them
+func createEnvironmentMap(services []service) (string, error) {
+	var entries []string
+	for _, s := range services {
+		if len(s.environment) == 0 {
+			continue
+		}
+		var names []string
+		for name := range s.environment {
+			names = append(names, name)
 		}
+		sort.Strings(names)
+		var vars []string
+		for _, name := range names {
+			vars = append(vars, fmt.Sprintf("        \"%s\" to %s", name, kotlinString(s.environment[name])))
+		}
+		entries = append(entries, fmt.Sprintf("    \"%s\" to mapOf(\n%s\n    )", s.name, strings.Join(vars, ",\n")))
+	}
 
+	var b strings.Builder
+	writeHeader(&b)
+	b.WriteString("var ServiceEnvironment = mapOf<String, Map<String, String>>(\n")
+	if len(entries) > 0 {
+		b.WriteString(strings.Join(entries, ",\n"))
+		b.WriteString("\n")
 	}
-	b.WriteString("\n)\n")
+	b.WriteString(")\n")
 
 	return b.String(), nil
 }
+
+// kotlinString returns s as a Kotlin string literal, escaping the characters that Kotlin would
+// otherwise interpret, like $ in string templates
+func kotlinString(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, `$`, `\$`, "\n", `\n`)
+	return `"` + r.Replace(s) + `"`
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_readAllServices(t *testing.T) {
+	services, err := readAllServices("./test-fixtures/mmv1/products", "./test-fixtures/mmv1/third_party/terraform/services")
+	if err != nil {
+		t.Fatalf("saw an unexpected error: %s", err)
+	}
+
+	expected := []service{
+		{
+			name:        "compute",
+			displayName: "Compute Engine",
+			versions:    []string{"ga", "beta"},
+		},
+		{
+			// Handwritten packages without a product are in every version
+			name:     "container",
+			versions: []string{"ga", "beta"},
+		},
+		{
+			// Beta products with handwritten files are in every version
+			name:     "firebase",
+			versions: []string{"ga", "beta"},
+		},
+		{
+			name:        "looker",
+			displayName: "Looker (Google Cloud core)",
+			versions:    []string{"beta"},
+			parallelism: 1,
+			environment: map[string]string{
+				"GOOGLE_LOOKER_REGION": "us-central1",
+				"LOOKER_PATH":          "$HOME/%env.LOOKER%",
+			},
+		},
+	}
+	if !reflect.DeepEqual(services, expected) {
+		t.Errorf("readAllServices() = %+v, want %+v", services, expected)
+	}
+}
+
+func Test_createServicesMap(t *testing.T) {
+	services, err := readAllServices("./test-fixtures/mmv1/products", "./test-fixtures/mmv1/third_party/terraform/services")
+	if err != nil {
+		t.Fatalf("saw an unexpected error: %s", err)
+	}
+
+	testCases := map[string]struct {
+		create   func([]service) (string, error)
+		expected string
+	}{
+		"ga services": {
+			create: func(s []service) (string, error) {
+				return createServicesMap(s, "ServicesListGa", GA_VERSION, "./google/services")
+			},
+			expected: `var ServicesListGa = mapOf(
+    "compute" to mapOf(
+        "name" to "compute",
+        "displayName" to "Compute Engine",
+        "path" to "./google/services/compute"
+    ),
+    "container" to mapOf(
+        "name" to "container",
+        "displayName" to "Container",
+        "path" to "./google/services/container"
+    ),
+    "firebase" to mapOf(
+        "name" to "firebase",
+        "displayName" to "Firebase",
+        "path" to "./google/services/firebase"
+    )
+)
+`,
+		},
+		"beta services": {
+			create: func(s []service) (string, error) {
+				return createServicesMap(s, "ServicesListBeta", BETA_VERSION, "./google-beta/services")
+			},
+			expected: `    "looker" to mapOf(
+        "name" to "looker",
+        "displayName" to "Looker (Google Cloud core)",
+        "path" to "./google-beta/services/looker"
+    )
+)
+`,
+		},
+		"parallelism": {
+			create: createParallelismMap,
+			expected: `var ServiceParallelism = mapOf<String, Int>(
+    "looker" to 1
+)
+`,
+		},
+		"environment": {
+			create: createEnvironmentMap,
+			expected: `var ServiceEnvironment = mapOf<String, Map<String, String>>(
+    "looker" to mapOf(
+        "GOOGLE_LOOKER_REGION" to "us-central1",
+        "LOOKER_PATH" to "\$HOME/%env.LOOKER%"
+    )
+)
+`,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			got, err := tc.create(services)
+			if err != nil {
+				t.Fatalf("saw an unexpected error: %s", err)
+			}
+			if !strings.HasPrefix(got, "/*\n * Copyright (c) HashiCorp, Inc.") {
+				t.Errorf("expected the file to start with a copyright header, got:\n%s", got)
+			}
+			if !strings.HasSuffix(got, tc.expected) {
+				t.Errorf("expected the file to end with:\n%s\ngot:\n%s", tc.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+const missingDocsDesc = `Check that fields added to handwritten resources and data sources are documented.
+
+Added fields are read from the report written by ` + "`diff-processor schema-diff --detailed`" + `, and
+looked up in the resource's or data source's markdown page in the docs directory. Resources
+without a page there have their docs generated by mmv1, which documents every field, so they're
+skipped.`
+
+// supportedSchemaDiffVersion is the version of diff-processor's schema diff report this reads.
+const supportedSchemaDiffVersion = 1
+
+// docFieldRegexp matches documented fields, like "* `name` - (Required) The name."
+var docFieldRegexp = regexp.MustCompile("(?m)^\\s*[*-]\\s+`([a-z0-9_./]+)`")
+
+type missingDocsOptions struct {
+	rootOptions    *rootOptions
+	stdout         io.Writer
+	stdin          io.Reader
+	schemaDiffPath string
+	docsDir        string
+}
+
+// schemaDiffReport is the part of diff-processor's schema diff report needed to find added fields.
+type schemaDiffReport struct {
+	Version     int              `json:"version"`
+	Resources   schemaDiffChange `json:"resources"`
+	DataSources schemaDiffChange `json:"data_sources"`
+}
+
+type schemaDiffChange struct {
+	Modified []struct {
+		Name        string `json:"name"`
+		AddedFields []struct {
+			Path string `json:"path"`
+		} `json:"added_fields"`
+	} `json:"modified"`
+}
+
+func newMissingDocsCmd(rootOptions *rootOptions) *cobra.Command {
+	o := &missingDocsOptions{
+		rootOptions: rootOptions,
+		stdout:      os.Stdout,
+		stdin:       os.Stdin,
+	}
+	command := &cobra.Command{
+		Use:   "missing-docs",
+		Short: "Check that fields added to handwritten resources are documented",
+		Long:  missingDocsDesc,
+		RunE: func(c *cobra.Command, args []string) error {
+			return o.run()
+		},
+	}
+	command.Flags().StringVar(&o.schemaDiffPath, "schema-diff", "", "path to the schema diff report, or - to read it from stdin")
+	command.Flags().StringVar(&o.docsDir, "docs-dir", "../../mmv1/third_party/terraform/website/docs", "path to the handwritten docs")
+	command.MarkFlagRequired("schema-diff")
+	return command
+}
+
+func (o *missingDocsOptions) run() error {
+	var b []byte
+	var err error
+	if o.schemaDiffPath == "-" {
+		b, err = io.ReadAll(o.stdin)
+	} else {
+		b, err = os.ReadFile(o.schemaDiffPath)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read schema diff report: %w", err)
+	}
+	var report schemaDiffReport
+	if err := json.Unmarshal(b, &report); err != nil {
+		return fmt.Errorf("failed to parse schema diff report: %w", err)
+	}
+	if report.Version != supportedSchemaDiffVersion {
+		return fmt.Errorf("unsupported schema diff report version %d, expected %d", report.Version, supportedSchemaDiffVersion)
+	}
+
+	found := false
+	for _, kind := range []struct {
+		dir     string
+		changes schemaDiffChange
+	}{
+		{"r", report.Resources},
+		{"d", report.DataSources},
+	} {
+		for _, modified := range kind.changes.Modified {
+			var added []string
+			for _, field := range modified.AddedFields {
+				added = append(added, field.Path)
+			}
+			if len(added) == 0 {
+				continue
+			}
+			page := findDocPage(filepath.Join(o.docsDir, kind.dir), modified.Name)
+			if page == "" {
+				continue
+			}
+			content, err := os.ReadFile(page)
+			if err != nil {
+				return err
+			}
+			missing := undocumentedFields(string(content), added)
+			if len(missing) > 0 {
+				found = true
+				fmt.Fprintf(os.Stderr, "%s (%s):\n", page, modified.Name)
+				for _, field := range missing {
+					fmt.Fprintf(os.Stderr, "  %s\n", field)
+				}
+			}
+		}
+	}
+	if found {
+		return fmt.Errorf("found fields missing from docs")
+	}
+	fmt.Fprintln(o.stdout, "All added fields are documented.")
+	return nil
+}
+
+// findDocPage returns the markdown page for a resource or data source in dir, or "" if it
+// doesn't have one. Pages are usually named after the resource without its google_ prefix.
+func findDocPage(dir, name string) string {
+	for _, base := range []string{strings.TrimPrefix(name, "google_"), name} {
+		for _, ext := range []string{".html.markdown", ".markdown"} {
+			page := filepath.Join(dir, base+ext)
+			if _, err := os.Stat(page); err == nil {
+				return page
+			}
+		}
+	}
+	return ""
+}
+
+// undocumentedFields returns the fields, with paths like parent.child, that aren't listed in a
+// markdown page. Handwritten pages don't document nested fields consistently, so a nested field
+// counts as documented if its own name is listed anywhere on the page.
+func undocumentedFields(content string, fields []string) []string {
+	documented := make(map[string]bool)
+	for _, m := range docFieldRegexp.FindAllStringSubmatch(content, -1) {
+		// Some pages list fields like member/members or a.0.b
+		for _, name := range strings.Split(m[1], "/") {
+			documented[strings.ReplaceAll(name, ".0.", ".")] = true
+			parts := strings.Split(name, ".")
+			documented[parts[len(parts)-1]] = true
+		}
+	}
+
+	var missing []string
+	for _, field := range fields {
+		parts := strings.Split(field, ".")
+		if !documented[field] && !documented[parts[len(parts)-1]] {
+			missing = append(missing, field)
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
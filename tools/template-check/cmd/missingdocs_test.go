@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestUndocumentedFields(t *testing.T) {
+	content, err := os.ReadFile("testdata/docs/r/foo_bar.html.markdown")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fields := []string{"name", "members", "settings.tier", "settings.effective_tier", "settings.zone", "labels"}
+	got := undocumentedFields(string(content), fields)
+	want := []string{"labels", "settings.zone"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("undocumentedFields() got unexpected diff(-want, got) = %s", diff)
+	}
+}
+
+func TestFindDocPage(t *testing.T) {
+	cases := map[string]string{
+		"google_foo_bar": "testdata/docs/r/foo_bar.html.markdown",
+		// Generated resources don't have a page
+		"google_foo_generated": "",
+	}
+	for name, want := range cases {
+		if got := findDocPage("testdata/docs/r", name); got != want {
+			t.Errorf("findDocPage(%q) want %q; got %q", name, want, got)
+		}
+	}
+}
+
+func TestMissingDocsRun(t *testing.T) {
+	cases := map[string]struct {
+		report  string
+		wantErr string
+	}{
+		"undocumented fields": {
+			report:  "testdata/schema_diff.json",
+			wantErr: "found fields missing from docs",
+		},
+		"documented fields": {
+			report: "-",
+		},
+	}
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			var stdout bytes.Buffer
+			o := &missingDocsOptions{
+				stdout:         &stdout,
+				stdin:          strings.NewReader(`{"version":1,"resources":{"modified":[{"name":"google_foo_bar","added_fields":[{"path":"settings.tier"}]}]}}`),
+				schemaDiffPath: tc.report,
+				docsDir:        "testdata/docs",
+			}
+			err := o.run()
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("run() got unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tc.wantErr {
+				t.Errorf("run() want error %q; got %v", tc.wantErr, err)
+			}
+		})
+	}
+
+	o := &missingDocsOptions{
+		stdin:          strings.NewReader(`{"version":2}`),
+		schemaDiffPath: "-",
+	}
+	if err := o.run(); err == nil {
+		t.Error("run() want error for an unsupported report version; got nil")
+	}
+}
@@ -26,6 +26,9 @@ type Step map[string]Resources // map of resource types to resources of that typ
 type Test struct {
 	Name  string
 	Steps []Step
+	// Beta is true if the test runs against the beta provider, either through the beta provider
+	// factories or by setting provider = google-beta on a resource.
+	Beta bool
 }
 
 func (t *Test) String() string {
@@ -196,16 +199,57 @@ func readVcrTestCall(vcrTestCall *ast.CallExpr, funcDecls map[string]*ast.FuncDe
 }
 
 func readTestCaseCompLit(testCaseCompLit *ast.CompositeLit, funcDecls map[string]*ast.FuncDecl, varDecls map[string]*ast.BasicLit) (*Test, error) {
+	var test *Test
+	var err error
+	betaFactories := false
 	for _, elt := range testCaseCompLit.Elts {
 		if keyValueExpr, ok := elt.(*ast.KeyValueExpr); ok {
-			if ident, ok := keyValueExpr.Key.(*ast.Ident); ok && ident.Name == "Steps" {
-				if stepsCompLit, ok := keyValueExpr.Value.(*ast.CompositeLit); ok {
-					return readStepsCompLit(stepsCompLit, funcDecls, varDecls)
+			if ident, ok := keyValueExpr.Key.(*ast.Ident); ok {
+				switch ident.Name {
+				case "Steps":
+					if stepsCompLit, ok := keyValueExpr.Value.(*ast.CompositeLit); ok {
+						test, err = readStepsCompLit(stepsCompLit, funcDecls, varDecls)
+					}
+				case "ProtoV5ProviderFactories":
+					betaFactories = isBetaProviderFactories(keyValueExpr.Value)
+				}
+			}
+		}
+	}
+	if test == nil {
+		return nil, fmt.Errorf("failed to find Steps in %v", testCaseCompLit.Elts)
+	}
+	test.Beta = betaFactories || usesBetaProvider(test)
+	return test, err
+}
+
+// Return true if the expression is a call to ProtoV5ProviderBetaFactories.
+func isBetaProviderFactories(expr ast.Expr) bool {
+	callExpr, ok := expr.(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	if ident, ok := callExpr.Fun.(*ast.Ident); ok {
+		return ident.Name == "ProtoV5ProviderBetaFactories"
+	}
+	if selExpr, ok := callExpr.Fun.(*ast.SelectorExpr); ok {
+		return selExpr.Sel.Name == "ProtoV5ProviderBetaFactories"
+	}
+	return false
+}
+
+// Return true if any resource in the test sets provider = google-beta.
+func usesBetaProvider(test *Test) bool {
+	for _, step := range test.Steps {
+		for _, resources := range step {
+			for _, resource := range resources {
+				if resource["provider"] == "google-beta" {
+					return true
 				}
 			}
 		}
 	}
-	return nil, fmt.Errorf("failed to find Steps in %v", testCaseCompLit.Elts)
+	return false
 }
 
 func readStepsCompLit(stepsCompLit *ast.CompositeLit, funcDecls map[string]*ast.FuncDecl, varDecls map[string]*ast.BasicLit) (*Test, error) {
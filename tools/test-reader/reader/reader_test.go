@@ -247,3 +247,21 @@ func TestFlattenResource(t *testing.T) {
 		}
 	}
 }
+
+func TestReadBetaResourceTestFile(t *testing.T) {
+	tests, err := ReadTestFiles([]string{"testdata/service/beta_resource_test.go"})
+	if err != nil {
+		t.Fatalf("error reading beta resource test file: %v", err)
+	}
+	beta := make(map[string]bool)
+	for _, test := range tests {
+		beta[test.Name] = test.Beta
+	}
+	if expectedBeta := map[string]bool{
+		"TestAccBetaResource_betaFactories": true,
+		"TestAccBetaResource_betaProvider":  true,
+		"TestAccBetaResource_ga":            false,
+	}; !reflect.DeepEqual(beta, expectedBeta) {
+		t.Errorf("found wrong beta tests: %v, expected %v", beta, expectedBeta)
+	}
+}
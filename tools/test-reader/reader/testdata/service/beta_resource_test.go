@@ -0,0 +1,67 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-provider-google-beta/google-beta/acctest"
+)
+
+func TestAccBetaResource_betaFactories(t *testing.T) {
+	acctest.VcrTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderBetaFactories(t),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBetaResource_betaField(),
+			},
+		},
+	})
+}
+
+func TestAccBetaResource_betaProvider(t *testing.T) {
+	acctest.VcrTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBetaResource_betaProvider(),
+			},
+		},
+	})
+}
+
+func TestAccBetaResource_ga(t *testing.T) {
+	acctest.VcrTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBetaResource_ga(),
+			},
+		},
+	})
+}
+
+func testAccBetaResource_betaField() string {
+	return `
+resource "beta_resource" "resource" {
+  field_one = "value-one"
+  field_two = "value-two"
+}
+`
+}
+
+func testAccBetaResource_betaProvider() string {
+	return `
+resource "beta_resource" "resource" {
+  provider  = google-beta
+  field_one = "value-one"
+}
+`
+}
+
+func testAccBetaResource_ga() string {
+	return `
+resource "beta_resource" "resource" {
+  field_one = "value-one"
+}
+`
+}